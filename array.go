@@ -4,6 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/yalp/jsonpath"
 )
 
 // Array provides methods to inspect attached []interface{} object
@@ -116,6 +120,67 @@ func (a *Array) Alias(name string) *Array {
 	return a
 }
 
+// Warn is similar to Value.Warn.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{1, 2, 3})
+//	array.Warn().Length().IsEqual(4) // warning, test continues
+func (a *Array) Warn() *Array {
+	opChain := a.chain.enter("Warn()")
+	defer opChain.leave()
+
+	ret := newArray(opChain, a.value)
+
+	ret.chain.setRoot()
+	ret.chain.setSeverity(SeverityWarning)
+
+	return ret
+}
+
+// Assert is similar to Value.Assert.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{1, 2, 3})
+//	array.Assert(func(a []interface{}) error {
+//		if len(a)%2 == 0 {
+//			return errors.New("expected odd number of elements")
+//		}
+//		return nil
+//	})
+func (a *Array) Assert(fn func(value []interface{}) error) *Array {
+	opChain := a.chain.enter("Assert()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return a
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return a
+	}
+
+	if err := fn(a.value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{a.value},
+			Errors: []error{
+				errors.New("expected: value satisfies custom assertion"),
+				err,
+			},
+		})
+	}
+
+	return a
+}
+
 // Path is similar to Value.Path.
 func (a *Array) Path(path string) *Value {
 	opChain := a.chain.enter("Path(%q)", path)
@@ -150,6 +215,248 @@ func (a *Array) Length() *Number {
 	return newNumber(opChain, float64(len(a.value)))
 }
 
+// Sum returns a new Number instance with sum of array elements.
+//
+// If path is given, it's a JSONPath expression applied to every element
+// (e.g. "$.amount" for an array of objects), and matched values are summed.
+// Otherwise, elements themselves are summed. Every summed value should be
+// a number, otherwise failure is reported.
+//
+// Sum of empty array is zero.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//		map[string]interface{}{"amount": 30},
+//		map[string]interface{}{"amount": 70},
+//	})
+//	array.Sum("$.amount").IsEqual(100)
+func (a *Array) Sum(path ...string) *Number {
+	opChain := a.chain.enter("Sum(%s)", formatPathArgs(path))
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	numbers, ok := a.aggregateNumbers(opChain, path)
+	if !ok {
+		return newNumber(opChain, 0)
+	}
+
+	sum := float64(0)
+	for _, n := range numbers {
+		sum += n
+	}
+
+	return newNumber(opChain, sum)
+}
+
+// Min returns a new Number instance with minimum of array elements.
+//
+// Path is handled the same way as in Sum. If array is empty, failure
+// is reported.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{3, 1, 2})
+//	array.Min().IsEqual(1)
+func (a *Array) Min(path ...string) *Number {
+	opChain := a.chain.enter("Min(%s)", formatPathArgs(path))
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	numbers, ok := a.aggregateNumbers(opChain, path)
+	if !ok || !a.checkAggregateNotEmpty(opChain) {
+		return newNumber(opChain, 0)
+	}
+
+	min := numbers[0]
+	for _, n := range numbers[1:] {
+		if n < min {
+			min = n
+		}
+	}
+
+	return newNumber(opChain, min)
+}
+
+// Max returns a new Number instance with maximum of array elements.
+//
+// Path is handled the same way as in Sum. If array is empty, failure
+// is reported.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{3, 1, 2})
+//	array.Max().IsEqual(3)
+func (a *Array) Max(path ...string) *Number {
+	opChain := a.chain.enter("Max(%s)", formatPathArgs(path))
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	numbers, ok := a.aggregateNumbers(opChain, path)
+	if !ok || !a.checkAggregateNotEmpty(opChain) {
+		return newNumber(opChain, 0)
+	}
+
+	max := numbers[0]
+	for _, n := range numbers[1:] {
+		if n > max {
+			max = n
+		}
+	}
+
+	return newNumber(opChain, max)
+}
+
+// Average returns a new Number instance with arithmetic mean of array
+// elements.
+//
+// Path is handled the same way as in Sum. If array is empty, failure
+// is reported.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//		map[string]interface{}{"score": 4},
+//		map[string]interface{}{"score": 5},
+//	})
+//	array.Average("$.score").IsEqual(4.5)
+func (a *Array) Average(path ...string) *Number {
+	opChain := a.chain.enter("Average(%s)", formatPathArgs(path))
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	numbers, ok := a.aggregateNumbers(opChain, path)
+	if !ok || !a.checkAggregateNotEmpty(opChain) {
+		return newNumber(opChain, 0)
+	}
+
+	sum := float64(0)
+	for _, n := range numbers {
+		sum += n
+	}
+
+	return newNumber(opChain, sum/float64(len(numbers)))
+}
+
+func formatPathArgs(path []string) string {
+	if len(path) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%q", path[0])
+}
+
+func (a *Array) aggregateNumbers(opChain *chain, path []string) ([]float64, bool) {
+	if len(path) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple path arguments"),
+			},
+		})
+		return nil, false
+	}
+
+	values := a.value
+	if len(path) == 1 {
+		var ok bool
+		if values, ok = a.projectPath(opChain, path[0]); !ok {
+			return nil, false
+		}
+	}
+
+	numbers := make([]float64, 0, len(values))
+
+	for index, value := range values {
+		number, ok := value.(float64)
+		if !ok {
+			opChain.fail(AssertionFailure{
+				Type:      AssertValid,
+				Actual:    &AssertionValue{value},
+				Reference: &AssertionValue{a.value},
+				Errors: []error{
+					errors.New("expected: aggregated values are numbers"),
+					fmt.Errorf("value of element %v is not a number", index),
+				},
+			})
+			return nil, false
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	return numbers, true
+}
+
+func (a *Array) checkAggregateNotEmpty(opChain *chain) bool {
+	if len(a.value) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{a.value},
+			Errors: []error{
+				errors.New("expected: non-empty array"),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// projectPath applies JSONPath expression to every element and returns
+// slice of matched values.
+func (a *Array) projectPath(opChain *chain, path string) ([]interface{}, bool) {
+	filterFn, err := jsonpath.Prepare(path)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{path},
+			Errors: []error{
+				errors.New("expected: valid json path"),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	values := make([]interface{}, 0, len(a.value))
+
+	for index, element := range a.value {
+		value, err := filterFn(element)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:      AssertMatchPath,
+				Actual:    &AssertionValue{element},
+				Expected:  &AssertionValue{path},
+				Reference: &AssertionValue{a.value},
+				Errors: []error{
+					errors.New("expected: every element of reference array" +
+						" matches given json path"),
+					fmt.Errorf("element %v does not match path", index),
+					err,
+				},
+			})
+			return nil, false
+		}
+
+		values = append(values, value)
+	}
+
+	return values, true
+}
+
 // Value returns a new Value instance with array element for given index.
 //
 // If index is out of array bounds, Value reports failure and returns empty
@@ -421,6 +728,77 @@ func (a *Array) Every(fn func(index int, value *Value)) *Array {
 	return a
 }
 
+// EveryWith runs the passed function on all pairs of array elements and
+// corresponding elements of expected list.
+//
+// Array length should be equal to the length of expected list; otherwise
+// failure is reported and function is not invoked. Expected elements are
+// passed to the function as is, so it can compare only some fields or use
+// any other custom logic.
+//
+// If assertion inside function fails, the original Array is marked failed.
+// Failure messages include index of element.
+//
+// EveryWith will execute the function for all elements irrespective of
+// assertion failures for some of them.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//		map[string]interface{}{"id": 1, "name": "foo", "created": "..."},
+//		map[string]interface{}{"id": 2, "name": "bar", "created": "..."},
+//	})
+//
+//	array.EveryWith([]interface{}{"foo", "bar"},
+//		func(index int, actual *httpexpect.Value, expected interface{}) {
+//			actual.Object().HasValue("id", index+1)
+//			actual.Object().HasValue("name", expected)
+//		})
+func (a *Array) EveryWith(
+	expected []interface{},
+	fn func(index int, actual *Value, expected interface{}),
+) *Array {
+	opChain := a.chain.enter("EveryWith()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return a
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return a
+	}
+
+	if len(a.value) != len(expected) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{len(a.value)},
+			Expected: &AssertionValue{len(expected)},
+			Errors: []error{
+				errors.New("expected: array length is equal to expected list length"),
+			},
+		})
+		return a
+	}
+
+	for index, element := range a.value {
+		func() {
+			valueChain := opChain.replace("EveryWith[%d]", index)
+			defer valueChain.leave()
+
+			fn(index, newValue(valueChain, element), expected[index])
+		}()
+	}
+
+	return a
+}
+
 // Filter accepts a function that returns a boolean. The function is ran
 // over the array elements. If the function returns true, the element passes
 // the filter and is added to the new array of filtered elements. If false,
@@ -505,13 +883,127 @@ func (a *Array) Transform(fn func(index int, value interface{}) interface{}) *Ar
 		return newArray(opChain, nil)
 	}
 
-	transformedArray := []interface{}{}
+	transformedArray := []interface{}{}
+
+	for index, element := range a.value {
+		transformedArray = append(transformedArray, fn(index, element))
+	}
+
+	return newArray(opChain, transformedArray)
+}
+
+// GroupBy returns a new Object with array elements grouped by the value
+// matched by given JSONPath expression.
+//
+// path is applied to every element of the array, e.g. "$.status" for an
+// array of objects. Every element should match path, and matched value
+// should be a string, number, or boolean; it is converted to string and
+// used as object key. Object values are arrays of elements with that key,
+// in original order.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//		map[string]interface{}{"id": 1, "status": "active"},
+//		map[string]interface{}{"id": 2, "status": "blocked"},
+//		map[string]interface{}{"id": 3, "status": "active"},
+//	})
+//
+//	groups := array.GroupBy("$.status")
+//	groups.Keys().ContainsOnly("active", "blocked")
+//	groups.Value("active").Array().Length().IsEqual(2)
+func (a *Array) GroupBy(path string) *Object {
+	opChain := a.chain.enter("GroupBy(%q)", path)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	keys, ok := a.projectPath(opChain, path)
+	if !ok {
+		return newObject(opChain, nil)
+	}
+
+	groups := make(map[string]interface{})
+
+	for index, key := range keys {
+		var groupKey string
+
+		switch k := key.(type) {
+		case string:
+			groupKey = k
+		case float64:
+			groupKey = strconv.FormatFloat(k, 'f', -1, 64)
+		case bool:
+			groupKey = strconv.FormatBool(k)
+		default:
+			opChain.fail(AssertionFailure{
+				Type:      AssertValid,
+				Actual:    &AssertionValue{key},
+				Reference: &AssertionValue{a.value},
+				Errors: []error{
+					errors.New(
+						"expected: grouping values are strings, numbers, or booleans"),
+					fmt.Errorf("value of element %v can't be used as group key", index),
+				},
+			})
+			return newObject(opChain, nil)
+		}
+
+		group, _ := groups[groupKey].([]interface{})
+		groups[groupKey] = append(group, a.value[index])
+	}
+
+	return newObject(opChain, groups)
+}
+
+// ProjectUnique returns a new Array with distinct values matched by given
+// JSONPath expression.
+//
+// path is applied to every element of the array, e.g. "$.category" for an
+// array of objects. Every element should match path. Values are compared
+// using reflect.DeepEqual and returned in order of their first occurrence.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//		map[string]interface{}{"id": 1, "category": "books"},
+//		map[string]interface{}{"id": 2, "category": "music"},
+//		map[string]interface{}{"id": 3, "category": "books"},
+//	})
+//
+//	array.ProjectUnique("$.category").IsEqual([]interface{}{"books", "music"})
+func (a *Array) ProjectUnique(path string) *Array {
+	opChain := a.chain.enter("ProjectUnique(%q)", path)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	values, ok := a.projectPath(opChain, path)
+	if !ok {
+		return newArray(opChain, nil)
+	}
+
+	unique := make([]interface{}, 0, len(values))
 
-	for index, element := range a.value {
-		transformedArray = append(transformedArray, fn(index, element))
+	for _, value := range values {
+		found := false
+		for _, u := range unique {
+			if reflect.DeepEqual(value, u) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			unique = append(unique, value)
+		}
 	}
 
-	return newArray(opChain, transformedArray)
+	return newArray(opChain, unique)
 }
 
 // Find accepts a function that returns a boolean, runs it over the array
@@ -783,15 +1275,18 @@ func (a *Array) IsEqual(value interface{}) *Array {
 		return a
 	}
 
-	expected, ok := canonArray(opChain, value)
+	canonExpected, ok := canonArray(opChain, value)
 	if !ok {
 		return a
 	}
 
-	if !reflect.DeepEqual(expected, a.value) {
+	actual := opChain.redact(a.value)
+	expected := opChain.redact(canonExpected)
+
+	if !reflect.DeepEqual(expected, actual) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
-			Actual:   &AssertionValue{a.value},
+			Actual:   &AssertionValue{actual},
 			Expected: &AssertionValue{expected},
 			Errors: []error{
 				errors.New("expected: arrays are equal"),
@@ -802,6 +1297,183 @@ func (a *Array) IsEqual(value interface{}) *Array {
 	return a
 }
 
+// IsEqualIgnoring succeeds if array is equal to given value, except
+// nodes matching any of given JSON paths. Useful to skip volatile fields,
+// like ids or timestamps.
+//
+// Paths are relative to the array and start with "$". Syntax is the same
+// as for Object.IsEqualIgnoring. Ignored object fields are removed from
+// both array and value, and ignored elements match any element.
+//
+// On failure, reports JSON path of the first mismatching node.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//		map[string]interface{}{"id": 1, "name": "john"},
+//		map[string]interface{}{"id": 2, "name": "bob"},
+//	})
+//	array.IsEqualIgnoring([]string{"$[*].id"}, []interface{}{
+//		map[string]interface{}{"name": "john"},
+//		map[string]interface{}{"name": "bob"},
+//	})
+func (a *Array) IsEqualIgnoring(paths []string, value interface{}) *Array {
+	opChain := a.chain.enter("IsEqualIgnoring()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return a
+	}
+
+	patterns, ok := parseIgnorePaths(opChain, paths)
+	if !ok {
+		return a
+	}
+
+	canonExpected, ok := canonArray(opChain, value)
+	if !ok {
+		return a
+	}
+
+	actual := opChain.redact(ignoreNodes(patterns, a.value))
+	expected := opChain.redact(ignoreNodes(patterns, canonExpected))
+
+	if !reflect.DeepEqual(expected, actual) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{expected},
+			Errors: []error{
+				errors.New("expected: arrays are equal, except ignored paths"),
+				fmt.Errorf("first mismatch at %s",
+					firstMismatchPath("$", expected, actual)),
+			},
+		})
+	}
+
+	return a
+}
+
+// IsEqualApprox succeeds if array is equal to given value, treating
+// numbers as equal if they differ by no more than epsilon. Tolerance is
+// applied recursively to nested objects and arrays.
+//
+// value should be a slice of any type.
+//
+// On failure, reports JSON path of the first mismatching node.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{0.30000000000000004, "foo"})
+//	array.IsEqualApprox([]interface{}{0.3, "foo"}, 1e-9)
+func (a *Array) IsEqualApprox(value interface{}, epsilon float64) *Array {
+	opChain := a.chain.enter("IsEqualApprox()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return a
+	}
+
+	if !checkApproxEpsilon(opChain, epsilon) {
+		return a
+	}
+
+	canonExpected, ok := canonArray(opChain, value)
+	if !ok {
+		return a
+	}
+
+	actual := opChain.redact(a.value)
+	expected := opChain.redact(canonExpected)
+
+	path := firstMismatchPathFunc("$", expected, actual, approxEqualFunc(epsilon))
+
+	if path != "" {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{expected},
+			Delta:    &AssertionValue{epsilon},
+			Errors: []error{
+				errors.New("expected: arrays are equal within epsilon"),
+				fmt.Errorf("first mismatch at %s", path),
+			},
+		})
+	}
+
+	return a
+}
+
+// IsEqualWith succeeds if array has one element per matcher, and every
+// element satisfies matcher with the same index.
+//
+// Matchers are functions that run assertions on given element. This allows
+// to check heterogeneous arrays element-by-element with custom logic.
+//
+// If array length is not equal to the number of matchers, failure is
+// reported and matchers are not invoked. Otherwise, all matchers are
+// invoked irrespective of assertion failures in some of them. Failure
+// messages include index of element.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{"foo", 123, map[string]interface{}{"id": 1}})
+//
+//	array.IsEqualWith(
+//		func(value *httpexpect.Value) {
+//			value.String().HasPrefix("f")
+//		},
+//		func(value *httpexpect.Value) {
+//			value.Number().Gt(100)
+//		},
+//		func(value *httpexpect.Value) {
+//			value.Object().ContainsKey("id")
+//		},
+//	)
+func (a *Array) IsEqualWith(matchers ...func(value *Value)) *Array {
+	opChain := a.chain.enter("IsEqualWith()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return a
+	}
+
+	for _, matcher := range matchers {
+		if matcher == nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected nil matcher argument"),
+				},
+			})
+			return a
+		}
+	}
+
+	if len(a.value) != len(matchers) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{len(a.value)},
+			Expected: &AssertionValue{len(matchers)},
+			Errors: []error{
+				errors.New("expected: array length is equal to number of matchers"),
+			},
+		})
+		return a
+	}
+
+	for index, element := range a.value {
+		func() {
+			valueChain := opChain.replace("IsEqualWith[%d]", index)
+			defer valueChain.leave()
+
+			matchers[index](newValue(valueChain, element))
+		}()
+	}
+
+	return a
+}
+
 // NotEqual succeeds if array is not equal to given value.
 // Before comparison, both array and value are converted to canonical form.
 //
@@ -819,15 +1491,18 @@ func (a *Array) NotEqual(value interface{}) *Array {
 		return a
 	}
 
-	expected, ok := canonArray(opChain, value)
+	canonExpected, ok := canonArray(opChain, value)
 	if !ok {
 		return a
 	}
 
-	if reflect.DeepEqual(expected, a.value) {
+	actual := opChain.redact(a.value)
+	expected := opChain.redact(canonExpected)
+
+	if reflect.DeepEqual(expected, actual) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
-			Actual:   &AssertionValue{a.value},
+			Actual:   &AssertionValue{actual},
 			Expected: &AssertionValue{expected},
 			Errors: []error{
 				errors.New("expected: arrays are non-equal"),
@@ -863,15 +1538,18 @@ func (a *Array) IsEqualUnordered(value interface{}) *Array {
 		return a
 	}
 
+	actual := redactArray(opChain, a.value)
+	expected = redactArray(opChain, expected)
+
 	for _, element := range expected {
 		expectedCount := countElement(expected, element)
-		actualCount := countElement(a.value, element)
+		actualCount := countElement(actual, element)
 
 		if actualCount != expectedCount {
 			if expectedCount == 1 && actualCount == 0 {
 				opChain.fail(AssertionFailure{
 					Type:      AssertContainsElement,
-					Actual:    &AssertionValue{a.value},
+					Actual:    &AssertionValue{actual},
 					Expected:  &AssertionValue{element},
 					Reference: &AssertionValue{value},
 					Errors: []error{
@@ -881,7 +1559,7 @@ func (a *Array) IsEqualUnordered(value interface{}) *Array {
 			} else {
 				opChain.fail(AssertionFailure{
 					Type:      AssertNotContainsElement,
-					Actual:    &AssertionValue{a.value},
+					Actual:    &AssertionValue{actual},
 					Expected:  &AssertionValue{element},
 					Reference: &AssertionValue{value},
 					Errors: []error{
@@ -897,15 +1575,15 @@ func (a *Array) IsEqualUnordered(value interface{}) *Array {
 		}
 	}
 
-	for _, element := range a.value {
+	for _, element := range actual {
 		expectedCount := countElement(expected, element)
-		actualCount := countElement(a.value, element)
+		actualCount := countElement(actual, element)
 
 		if actualCount != expectedCount {
 			if expectedCount == 0 && actualCount == 1 {
 				opChain.fail(AssertionFailure{
 					Type:      AssertNotContainsElement,
-					Actual:    &AssertionValue{a.value},
+					Actual:    &AssertionValue{actual},
 					Expected:  &AssertionValue{element},
 					Reference: &AssertionValue{value},
 					Errors: []error{
@@ -916,7 +1594,7 @@ func (a *Array) IsEqualUnordered(value interface{}) *Array {
 			} else {
 				opChain.fail(AssertionFailure{
 					Type:      AssertNotContainsElement,
-					Actual:    &AssertionValue{a.value},
+					Actual:    &AssertionValue{actual},
 					Expected:  &AssertionValue{element},
 					Reference: &AssertionValue{value},
 					Errors: []error{
@@ -955,11 +1633,14 @@ func (a *Array) NotEqualUnordered(value interface{}) *Array {
 		return a
 	}
 
+	actual := redactArray(opChain, a.value)
+	expected = redactArray(opChain, expected)
+
 	different := false
 
 	for _, element := range expected {
 		expectedCount := countElement(expected, element)
-		actualCount := countElement(a.value, element)
+		actualCount := countElement(actual, element)
 
 		if actualCount != expectedCount {
 			different = true
@@ -967,9 +1648,9 @@ func (a *Array) NotEqualUnordered(value interface{}) *Array {
 		}
 	}
 
-	for _, element := range a.value {
+	for _, element := range actual {
 		expectedCount := countElement(expected, element)
-		actualCount := countElement(a.value, element)
+		actualCount := countElement(actual, element)
 
 		if actualCount != expectedCount {
 			different = true
@@ -980,7 +1661,7 @@ func (a *Array) NotEqualUnordered(value interface{}) *Array {
 	if !different {
 		opChain.fail(AssertionFailure{
 			Type:      AssertNotEqual,
-			Actual:    &AssertionValue{a.value},
+			Actual:    &AssertionValue{actual},
 			Expected:  &AssertionValue{value},
 			Reference: &AssertionValue{value},
 			Errors: []error{
@@ -1637,6 +2318,124 @@ func (a *Array) IsOrdered(less ...func(x, y *Value) bool) *Array {
 	return a
 }
 
+// SortDirection defines sorting direction for Array.IsOrderedBy.
+type SortDirection int
+
+const (
+	// Every element is not less than the previous element.
+	Ascending SortDirection = iota
+
+	// Every element is not greater than the previous element.
+	Descending
+)
+
+// IsOrderedBy succeeds if array elements are ordered by the value matched
+// by given JSONPath expression, in given direction.
+//
+// path is applied to every element of the array (not to the array itself),
+// e.g. "$.created_at" for an array of objects. Every element should match
+// path. Matched values are compared using built-in comparator, the same as
+// used by IsOrdered, so they should have the same data type (boolean,
+// number, string, or null).
+//
+// Note that strings are compared lexicographically, which is correct for
+// timestamps in RFC 3339 format with the same time zone.
+//
+// Array with 0 or 1 element will always succeed.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//		map[string]interface{}{"id": 3, "created_at": "2024-03-01T00:00:00Z"},
+//		map[string]interface{}{"id": 1, "created_at": "2024-02-01T00:00:00Z"},
+//		map[string]interface{}{"id": 2, "created_at": "2024-01-01T00:00:00Z"},
+//	})
+//
+//	array.IsOrderedBy("$.created_at", httpexpect.Descending)
+func (a *Array) IsOrderedBy(path string, direction SortDirection) *Array {
+	opChain := a.chain.enter("IsOrderedBy(%q)", path)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return a
+	}
+
+	if direction != Ascending && direction != Descending {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected sort direction %d", direction),
+			},
+		})
+		return a
+	}
+
+	keys, ok := a.projectPath(opChain, path)
+	if !ok {
+		return a
+	}
+
+	lessFn := builtinComparator(opChain, keys)
+	if lessFn == nil {
+		return a
+	}
+
+	for i := 0; i < len(keys)-1; i++ {
+		var unordered bool
+
+		func() {
+			xChain := opChain.replace("IsOrderedBy[%d]", i)
+			defer xChain.leave()
+
+			yChain := opChain.replace("IsOrderedBy[%d]", i+1)
+			defer yChain.leave()
+
+			x := newValue(xChain, keys[i])
+			y := newValue(yChain, keys[i+1])
+
+			if direction == Ascending {
+				unordered = lessFn(y, x)
+			} else {
+				unordered = lessFn(x, y)
+			}
+		}()
+
+		if opChain.failed() {
+			return a
+		}
+
+		if unordered {
+			var errRelation error
+			if direction == Ascending {
+				errRelation = fmt.Errorf(
+					"element %v must not be less than element %v by %q", i+1, i, path)
+			} else {
+				errRelation = fmt.Errorf(
+					"element %v must not be greater than element %v by %q", i+1, i, path)
+			}
+
+			failureType := AssertLt
+			if direction == Descending {
+				failureType = AssertGt
+			}
+
+			opChain.fail(AssertionFailure{
+				Type:      failureType,
+				Actual:    &AssertionValue{keys[i]},
+				Expected:  &AssertionValue{keys[i+1]},
+				Reference: &AssertionValue{a.value},
+				Errors: []error{
+					errors.New("expected: reference array is ordered by given json path"),
+					errRelation,
+				},
+			})
+			return a
+		}
+	}
+
+	return a
+}
+
 // NotOrdered succeeds if at least one element is less than the previous element
 // as defined on the given `less` comparator function.
 // For default, it will use built-in comparator function for each data type.
@@ -1731,6 +2530,99 @@ func (a *Array) NotOrdered(less ...func(x, y *Value) bool) *Array {
 	return a
 }
 
+// Sorted returns a new Array instance with elements sorted in ascending
+// order. Original array is not modified.
+//
+// If no argument is provided, Sorted uses built-in comparator that has
+// the same limitations as in IsOrdered: all elements should be of the same
+// type (boolean, number, string, or null).
+//
+// If less function is provided, it is used to compare elements. Sorting
+// is stable.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"b": 1, "a": 2})
+//	object.Keys().Sorted().IsEqual([]interface{}{"a", "b"})
+//
+//	array := NewArray(t, []interface{}{3, 1, 2})
+//	array.Sorted().IsEqual([]interface{}{1, 2, 3})
+//
+//	array.Sorted(func(x, y *httpexpect.Value) bool {
+//		return x.Number().Raw() > y.Number().Raw()
+//	}).IsEqual([]interface{}{3, 2, 1})
+func (a *Array) Sorted(less ...func(x, y *Value) bool) *Array {
+	opChain := a.chain.enter("Sorted()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	if len(less) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple less arguments"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	var lessFn func(x, y *Value) bool
+	if len(less) == 1 {
+		lessFn = less[0]
+		if lessFn == nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected nil less argument"),
+				},
+			})
+			return newArray(opChain, nil)
+		}
+	} else {
+		// lessFn is nil if array has less than two elements,
+		// but then it's never called during sorting
+		lessFn = builtinComparator(opChain, a.value)
+		if opChain.failed() {
+			return newArray(opChain, nil)
+		}
+	}
+
+	indices := make([]int, len(a.value))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	sort.SliceStable(indices, func(i, j int) bool {
+		if opChain.failed() {
+			return false
+		}
+
+		xChain := opChain.replace("Sorted[%d]", indices[i])
+		defer xChain.leave()
+
+		yChain := opChain.replace("Sorted[%d]", indices[j])
+		defer yChain.leave()
+
+		return lessFn(
+			newValue(xChain, a.value[indices[i]]),
+			newValue(yChain, a.value[indices[j]]))
+	})
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	sorted := make([]interface{}, 0, len(a.value))
+	for _, index := range indices {
+		sorted = append(sorted, a.value[index])
+	}
+
+	return newArray(opChain, sorted)
+}
+
 func countElement(array []interface{}, element interface{}) int {
 	count := 0
 	for _, e := range array {
@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"errors"
 	"sort"
 	"testing"
 
@@ -14,11 +15,16 @@ func TestArray_FailedChain(t *testing.T) {
 		value.Path("$").chain.assert(t, failure)
 		value.Schema("")
 		value.Alias("foo")
+		value.Assert(nil)
 
 		var target interface{}
 		value.Decode(&target)
 
 		value.Length().chain.assert(t, failure)
+		value.Sum().chain.assert(t, failure)
+		value.Min().chain.assert(t, failure)
+		value.Max().chain.assert(t, failure)
+		value.Average().chain.assert(t, failure)
 		value.Value(0).chain.assert(t, failure)
 		value.First().chain.assert(t, failure)
 		value.Last().chain.assert(t, failure)
@@ -26,6 +32,8 @@ func TestArray_FailedChain(t *testing.T) {
 		value.IsEmpty()
 		value.NotEmpty()
 		value.IsEqual([]interface{}{})
+		value.IsEqualIgnoring(nil, []interface{}{})
+		value.IsEqualApprox([]interface{}{}, 0)
 		value.NotEqual([]interface{}{})
 		value.IsEqualUnordered([]interface{}{})
 		value.NotEqualUnordered([]interface{}{})
@@ -50,6 +58,10 @@ func TestArray_FailedChain(t *testing.T) {
 		value.Every(func(_ int, val *Value) {
 			val.String().NotEmpty()
 		})
+		value.EveryWith([]interface{}{}, func(_ int, val *Value, _ interface{}) {
+			val.String().NotEmpty()
+		})
+		value.IsEqualWith()
 		value.Filter(func(_ int, val *Value) bool {
 			val.String().NotEmpty()
 			return true
@@ -57,6 +69,8 @@ func TestArray_FailedChain(t *testing.T) {
 		value.Transform(func(index int, value interface{}) interface{} {
 			return nil
 		})
+		value.GroupBy("$.foo").chain.assert(t, failure)
+		value.ProjectUnique("$.foo").chain.assert(t, failure)
 		value.Find(func(index int, value *Value) bool {
 			value.String().NotEmpty()
 			return true
@@ -69,6 +83,8 @@ func TestArray_FailedChain(t *testing.T) {
 			value.String().NotEmpty()
 			return true
 		})
+		value.IsOrderedBy("$.foo", Ascending)
+		value.Sorted().chain.assert(t, failure)
 	}
 
 	t.Run("failed chain", func(t *testing.T) {
@@ -239,6 +255,43 @@ func TestArray_Alias(t *testing.T) {
 	assert.Equal(t, []string{"foo", "Filter()"}, childValue.chain.context.AliasedPath)
 }
 
+func TestArray_Warn(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	array := NewArray(reporter, []interface{}{1, 2, 3})
+
+	warn := array.Warn()
+	warn.Length().IsEqual(4)
+
+	assert.True(t, warn.chain.treeFailed())
+	assert.False(t, array.chain.treeFailed())
+
+	assert.False(t, reporter.reported)
+}
+
+func TestArray_Assert(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	var actual []interface{}
+	NewArray(reporter, []interface{}{"foo", 123}).
+		Assert(func(a []interface{}) error {
+			actual = a
+			return nil
+		}).
+		chain.assert(t, success)
+	assert.Equal(t, []interface{}{"foo", 123.0}, actual)
+
+	NewArray(reporter, []interface{}{"foo", 123}).
+		Assert(func(a []interface{}) error {
+			return errors.New("custom error")
+		}).
+		chain.assert(t, failure)
+
+	NewArray(reporter, []interface{}{"foo", 123}).
+		Assert(nil).
+		chain.assert(t, failure)
+}
+
 func TestArray_Path(t *testing.T) {
 	cases := []struct {
 		name  string
@@ -333,6 +386,107 @@ func TestArray_Getters(t *testing.T) {
 	})
 }
 
+func TestArray_Aggregate(t *testing.T) {
+	t.Run("elements", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{3, 1.5, -2, 5.5})
+
+		array.Sum().IsEqual(8).chain.assert(t, success)
+		array.Min().IsEqual(-2).chain.assert(t, success)
+		array.Max().IsEqual(5.5).chain.assert(t, success)
+		array.Average().IsEqual(2).chain.assert(t, success)
+	})
+
+	t.Run("path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{
+			map[string]interface{}{"amount": 30, "meta": map[string]interface{}{"n": 1}},
+			map[string]interface{}{"amount": 70, "meta": map[string]interface{}{"n": 2}},
+		})
+
+		array.Sum("$.amount").IsEqual(100).chain.assert(t, success)
+		array.Min("$.amount").IsEqual(30).chain.assert(t, success)
+		array.Max("$.amount").IsEqual(70).chain.assert(t, success)
+		array.Average("$.amount").IsEqual(50).chain.assert(t, success)
+
+		array.Sum("$.meta.n").IsEqual(3).chain.assert(t, success)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewArray(reporter, []interface{}{}).Sum().IsEqual(0).
+			chain.assert(t, success)
+		NewArray(reporter, []interface{}{}).Sum("$.amount").IsEqual(0).
+			chain.assert(t, success)
+
+		NewArray(reporter, []interface{}{}).Min().
+			chain.assert(t, failure)
+		NewArray(reporter, []interface{}{}).Max().
+			chain.assert(t, failure)
+		NewArray(reporter, []interface{}{}).Average("$.amount").
+			chain.assert(t, failure)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			values []interface{}
+			path   []string
+		}{
+			{
+				name:   "not a number",
+				values: []interface{}{1, "2"},
+			},
+			{
+				name:   "null",
+				values: []interface{}{1, nil},
+			},
+			{
+				name: "path value not a number",
+				values: []interface{}{
+					map[string]interface{}{"amount": 1},
+					map[string]interface{}{"amount": true},
+				},
+				path: []string{"$.amount"},
+			},
+			{
+				name: "path not matched",
+				values: []interface{}{
+					map[string]interface{}{"amount": 1},
+					map[string]interface{}{"total": 2},
+				},
+				path: []string{"$.amount"},
+			},
+			{
+				name:   "invalid path",
+				values: []interface{}{1, 2},
+				path:   []string{"!"},
+			},
+			{
+				name:   "multiple paths",
+				values: []interface{}{1, 2},
+				path:   []string{"$.a", "$.b"},
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				NewArray(reporter, tc.values).Sum(tc.path...).
+					chain.assert(t, failure)
+				NewArray(reporter, tc.values).Min(tc.path...).
+					chain.assert(t, failure)
+				NewArray(reporter, tc.values).Max(tc.path...).
+					chain.assert(t, failure)
+				NewArray(reporter, tc.values).Average(tc.path...).
+					chain.assert(t, failure)
+			})
+		}
+	})
+}
+
 func TestArray_IsEmpty(t *testing.T) {
 	cases := []struct {
 		name      string
@@ -490,6 +644,173 @@ func TestArray_IsEqual(t *testing.T) {
 	})
 }
 
+func TestArray_IsEqualIgnoring(t *testing.T) {
+	value := []interface{}{
+		map[string]interface{}{"id": 1, "name": "john"},
+		map[string]interface{}{"id": 2, "name": "bob"},
+	}
+
+	t.Run("basic", func(t *testing.T) {
+		cases := []struct {
+			name      string
+			paths     []string
+			testValue []interface{}
+			result    chainResult
+		}{
+			{
+				name:  "no paths",
+				paths: nil,
+				testValue: []interface{}{
+					map[string]interface{}{"name": "john"},
+					map[string]interface{}{"name": "bob"},
+				},
+				result: failure,
+			},
+			{
+				name:  "ignored fields",
+				paths: []string{"$[*].id"},
+				testValue: []interface{}{
+					map[string]interface{}{"name": "john"},
+					map[string]interface{}{"id": 20, "name": "bob"},
+				},
+				result: success,
+			},
+			{
+				name:  "ignored element",
+				paths: []string{"$[0]"},
+				testValue: []interface{}{
+					nil,
+					map[string]interface{}{"id": 2, "name": "bob"},
+				},
+				result: success,
+			},
+			{
+				name:  "non-ignored field differs",
+				paths: []string{"$[*].id"},
+				testValue: []interface{}{
+					map[string]interface{}{"name": "john"},
+					map[string]interface{}{"name": "alice"},
+				},
+				result: failure,
+			},
+			{
+				name:  "different length",
+				paths: []string{"$[*].id"},
+				testValue: []interface{}{
+					map[string]interface{}{"name": "john"},
+				},
+				result: failure,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				NewArray(reporter, value).IsEqualIgnoring(tc.paths, tc.testValue).
+					chain.assert(t, tc.result)
+			})
+		}
+	})
+
+	t.Run("mismatch path", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewArrayC(Config{
+			AssertionHandler: handler,
+		}, value).IsEqualIgnoring([]string{"$[*].id"}, []interface{}{
+			map[string]interface{}{"name": "john"},
+			map[string]interface{}{"name": "alice"},
+		})
+
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Equal(t, AssertEqual, handler.failure.Type)
+		assert.Equal(t, 2, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[1].Error(), "$[1].name")
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewArray(reporter, value).
+			IsEqualIgnoring([]string{"[*].id"}, value).
+			chain.assert(t, failure)
+	})
+}
+
+func TestArray_IsEqualApprox(t *testing.T) {
+	value := []interface{}{
+		0.30000000000000004,
+		"foo",
+		map[string]interface{}{"score": 9.9999},
+	}
+
+	t.Run("basic", func(t *testing.T) {
+		cases := []struct {
+			name      string
+			testValue []interface{}
+			epsilon   float64
+			result    chainResult
+		}{
+			{
+				name: "within epsilon",
+				testValue: []interface{}{
+					0.3, "foo", map[string]interface{}{"score": 10},
+				},
+				epsilon: 0.001,
+				result:  success,
+			},
+			{
+				name: "outside epsilon",
+				testValue: []interface{}{
+					0.3, "foo", map[string]interface{}{"score": 10},
+				},
+				epsilon: 1e-9,
+				result:  failure,
+			},
+			{
+				name: "different length",
+				testValue: []interface{}{
+					0.3, "foo",
+				},
+				epsilon: 1,
+				result:  failure,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				NewArray(reporter, value).IsEqualApprox(tc.testValue, tc.epsilon).
+					chain.assert(t, tc.result)
+			})
+		}
+	})
+
+	t.Run("mismatch path", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewArrayC(Config{
+			AssertionHandler: handler,
+		}, value).IsEqualApprox([]interface{}{
+			0.3, "foo", map[string]interface{}{"score": 11},
+		}, 0.001)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Equal(t, AssertEqual, handler.failure.Type)
+		assert.Equal(t, 2, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[1].Error(), "$[2].score")
+	})
+
+	t.Run("invalid epsilon", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewArray(reporter, value).IsEqualApprox(value, -1).
+			chain.assert(t, failure)
+	})
+}
+
 func TestArray_IsEqualUnordered(t *testing.T) {
 	t.Run("without duplicates", func(t *testing.T) {
 		cases := []struct {
@@ -1690,6 +2011,159 @@ func TestArray_Every(t *testing.T) {
 	})
 }
 
+func TestArray_EveryWith(t *testing.T) {
+	t.Run("check values", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{
+			map[string]interface{}{"id": 1, "name": "foo"},
+			map[string]interface{}{"id": 2, "name": "bar"},
+		})
+
+		var indexes []int
+		array.EveryWith([]interface{}{"foo", "bar"},
+			func(index int, actual *Value, expected interface{}) {
+				indexes = append(indexes, index)
+				actual.Object().HasValue("id", index+1)
+				actual.Object().HasValue("name", expected)
+			})
+
+		assert.Equal(t, []int{0, 1}, indexes)
+		array.chain.assert(t, success)
+	})
+
+	t.Run("empty array", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{})
+
+		invoked := 0
+		array.EveryWith(nil, func(_ int, _ *Value, _ interface{}) {
+			invoked++
+		})
+
+		assert.Equal(t, 0, invoked)
+		array.chain.assert(t, success)
+	})
+
+	t.Run("one assertion fails", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{"foo", "baz", "bar"})
+
+		invoked := 0
+		array.EveryWith([]interface{}{"foo", "bar", "bar"},
+			func(_ int, actual *Value, expected interface{}) {
+				invoked++
+				actual.IsEqual(expected)
+			})
+
+		assert.Equal(t, 3, invoked)
+		array.chain.assert(t, failure)
+	})
+
+	t.Run("length mismatch", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{"foo", "bar"})
+
+		invoked := 0
+		array.EveryWith([]interface{}{"foo"},
+			func(_ int, _ *Value, _ interface{}) {
+				invoked++
+			})
+
+		assert.Equal(t, 0, invoked)
+		array.chain.assert(t, failure)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{1, 2, 3})
+		array.EveryWith([]interface{}{1, 2, 3},
+			(func(index int, actual *Value, expected interface{}))(nil))
+		array.chain.assert(t, failure)
+	})
+}
+
+func TestArray_IsEqualWith(t *testing.T) {
+	t.Run("check values", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{
+			"foo", 123, map[string]interface{}{"id": 1},
+		})
+
+		invoked := 0
+		array.IsEqualWith(
+			func(value *Value) {
+				invoked++
+				value.String().HasPrefix("f")
+			},
+			func(value *Value) {
+				invoked++
+				value.Number().Gt(100)
+			},
+			func(value *Value) {
+				invoked++
+				value.Object().ContainsKey("id")
+			},
+		)
+
+		assert.Equal(t, 3, invoked)
+		array.chain.assert(t, success)
+	})
+
+	t.Run("empty array", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{})
+
+		array.IsEqualWith()
+		array.chain.assert(t, success)
+	})
+
+	t.Run("one assertion fails", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{"foo", 123})
+
+		invoked := 0
+		array.IsEqualWith(
+			func(value *Value) {
+				invoked++
+				value.Number()
+			},
+			func(value *Value) {
+				invoked++
+				value.Number().IsEqual(123)
+			},
+		)
+
+		assert.Equal(t, 2, invoked)
+		array.chain.assert(t, failure)
+	})
+
+	t.Run("length mismatch", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{"foo"})
+
+		invoked := 0
+		array.IsEqualWith(
+			func(value *Value) {
+				invoked++
+			},
+			func(value *Value) {
+				invoked++
+			},
+		)
+
+		assert.Equal(t, 0, invoked)
+		array.chain.assert(t, failure)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{"foo"})
+
+		array.IsEqualWith(nil)
+		array.chain.assert(t, failure)
+	})
+}
+
 func TestArray_Transform(t *testing.T) {
 	t.Run("check index", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -1850,6 +2324,155 @@ func TestArray_Filter(t *testing.T) {
 	})
 }
 
+func TestArray_GroupBy(t *testing.T) {
+	t.Run("group", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{
+			map[string]interface{}{"id": 1, "status": "active"},
+			map[string]interface{}{"id": 2, "status": "blocked"},
+			map[string]interface{}{"id": 3, "status": "active"},
+		})
+
+		groups := array.GroupBy("$.status")
+		groups.chain.assert(t, success)
+
+		assert.Equal(t, map[string]interface{}{
+			"active": []interface{}{
+				map[string]interface{}{"id": 1.0, "status": "active"},
+				map[string]interface{}{"id": 3.0, "status": "active"},
+			},
+			"blocked": []interface{}{
+				map[string]interface{}{"id": 2.0, "status": "blocked"},
+			},
+		}, groups.Raw())
+
+		array.chain.assert(t, success)
+	})
+
+	t.Run("key types", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{
+			map[string]interface{}{"key": 1},
+			map[string]interface{}{"key": 2.5},
+			map[string]interface{}{"key": true},
+			map[string]interface{}{"key": "1"},
+		})
+
+		groups := array.GroupBy("$.key")
+		groups.chain.assert(t, success)
+
+		groups.Keys().ContainsOnly("1", "2.5", "true")
+		groups.Value("1").Array().Length().IsEqual(2)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewArray(reporter, []interface{}{}).GroupBy("$.status").IsEmpty().
+			chain.assert(t, success)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			values []interface{}
+			path   string
+		}{
+			{
+				name:   "invalid path",
+				values: []interface{}{},
+				path:   "!",
+			},
+			{
+				name: "path not matched",
+				values: []interface{}{
+					map[string]interface{}{"status": "active"},
+					map[string]interface{}{"id": 2},
+				},
+				path: "$.status",
+			},
+			{
+				name: "null key",
+				values: []interface{}{
+					map[string]interface{}{"status": nil},
+				},
+				path: "$.status",
+			},
+			{
+				name: "object key",
+				values: []interface{}{
+					map[string]interface{}{"status": map[string]interface{}{}},
+				},
+				path: "$.status",
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				NewArray(reporter, tc.values).GroupBy(tc.path).
+					chain.assert(t, failure)
+			})
+		}
+	})
+}
+
+func TestArray_ProjectUnique(t *testing.T) {
+	t.Run("unique", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{
+			map[string]interface{}{"id": 1, "category": "books"},
+			map[string]interface{}{"id": 2, "category": "music"},
+			map[string]interface{}{"id": 3, "category": "books"},
+		})
+
+		array.ProjectUnique("$.category").
+			IsEqual([]interface{}{"books", "music"}).
+			chain.assert(t, success)
+
+		array.ProjectUnique("$.id").
+			IsEqual([]interface{}{1, 2, 3}).
+			chain.assert(t, success)
+
+		array.chain.assert(t, success)
+	})
+
+	t.Run("complex values", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{
+			map[string]interface{}{"tags": []interface{}{"a", "b"}},
+			map[string]interface{}{"tags": nil},
+			map[string]interface{}{"tags": []interface{}{"a", "b"}},
+			map[string]interface{}{"tags": nil},
+		})
+
+		array.ProjectUnique("$.tags").
+			IsEqual([]interface{}{[]interface{}{"a", "b"}, nil}).
+			chain.assert(t, success)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewArray(reporter, []interface{}{}).ProjectUnique("$.id").IsEmpty().
+			chain.assert(t, success)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewArray(reporter, []interface{}{}).ProjectUnique("!").
+			chain.assert(t, failure)
+
+		NewArray(reporter, []interface{}{
+			map[string]interface{}{"id": 1},
+			map[string]interface{}{"name": "john"},
+		}).ProjectUnique("$.id").
+			chain.assert(t, failure)
+	})
+}
+
 func TestArray_Find(t *testing.T) {
 	t.Run("elements of same type", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -2481,6 +3104,222 @@ func TestArray_IsOrdered(t *testing.T) {
 	}
 }
 
+func TestArray_IsOrderedBy(t *testing.T) {
+	users := []interface{}{
+		map[string]interface{}{"id": 1.0, "created_at": "2024-01-01T00:00:00Z"},
+		map[string]interface{}{"id": 2.0, "created_at": "2024-02-01T00:00:00Z"},
+		map[string]interface{}{"id": 3.0, "created_at": "2024-02-01T00:00:00Z"},
+		map[string]interface{}{"id": 4.0, "created_at": "2024-03-01T00:00:00Z"},
+	}
+
+	reversed := make([]interface{}, len(users))
+	for i := range users {
+		reversed[len(users)-1-i] = users[i]
+	}
+
+	cases := []struct {
+		name        string
+		values      []interface{}
+		path        string
+		direction   SortDirection
+		wantSuccess bool
+	}{
+		{
+			name:        "empty",
+			values:      []interface{}{},
+			path:        "$.id",
+			direction:   Ascending,
+			wantSuccess: true,
+		},
+		{
+			name:        "single element",
+			values:      users[:1],
+			path:        "$.id",
+			direction:   Descending,
+			wantSuccess: true,
+		},
+		{
+			name:        "ascending numbers",
+			values:      users,
+			path:        "$.id",
+			direction:   Ascending,
+			wantSuccess: true,
+		},
+		{
+			name:        "ascending numbers, descending direction",
+			values:      users,
+			path:        "$.id",
+			direction:   Descending,
+			wantSuccess: false,
+		},
+		{
+			name:        "descending numbers",
+			values:      reversed,
+			path:        "$.id",
+			direction:   Descending,
+			wantSuccess: true,
+		},
+		{
+			name:        "descending numbers, ascending direction",
+			values:      reversed,
+			path:        "$.id",
+			direction:   Ascending,
+			wantSuccess: false,
+		},
+		{
+			name:        "ascending strings with duplicates",
+			values:      users,
+			path:        "$.created_at",
+			direction:   Ascending,
+			wantSuccess: true,
+		},
+		{
+			name:        "descending strings with duplicates",
+			values:      reversed,
+			path:        "$.created_at",
+			direction:   Descending,
+			wantSuccess: true,
+		},
+		{
+			name: "nested path",
+			values: []interface{}{
+				map[string]interface{}{"meta": map[string]interface{}{"rank": 1.0}},
+				map[string]interface{}{"meta": map[string]interface{}{"rank": 2.0}},
+			},
+			path:        "$.meta.rank",
+			direction:   Ascending,
+			wantSuccess: true,
+		},
+		{
+			name:        "invalid path",
+			values:      users,
+			path:        "!",
+			direction:   Ascending,
+			wantSuccess: false,
+		},
+		{
+			name: "path not matched",
+			values: []interface{}{
+				map[string]interface{}{"id": 1.0},
+				map[string]interface{}{"name": "john"},
+			},
+			path:        "$.id",
+			direction:   Ascending,
+			wantSuccess: false,
+		},
+		{
+			name: "mixed types",
+			values: []interface{}{
+				map[string]interface{}{"id": 1.0},
+				map[string]interface{}{"id": "2"},
+			},
+			path:        "$.id",
+			direction:   Ascending,
+			wantSuccess: false,
+		},
+		{
+			name: "unsupported type",
+			values: []interface{}{
+				map[string]interface{}{"id": []interface{}{1.0}},
+				map[string]interface{}{"id": []interface{}{2.0}},
+			},
+			path:        "$.id",
+			direction:   Ascending,
+			wantSuccess: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			if tc.wantSuccess {
+				NewArray(reporter, tc.values).IsOrderedBy(tc.path, tc.direction).
+					chain.assert(t, success)
+			} else {
+				NewArray(reporter, tc.values).IsOrderedBy(tc.path, tc.direction).
+					chain.assert(t, failure)
+			}
+		})
+	}
+
+	t.Run("invalid direction", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewArray(reporter, users).IsOrderedBy("$.id", SortDirection(10)).
+			chain.assert(t, failure)
+	})
+}
+
+func TestArray_Sorted(t *testing.T) {
+	t.Run("builtin comparator", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{3, 1, 2})
+
+		array.Sorted().
+			IsEqual([]interface{}{1, 2, 3}).
+			chain.assert(t, success)
+
+		array.IsEqual([]interface{}{3, 1, 2})
+		array.chain.assert(t, success)
+
+		NewArray(reporter, []interface{}{"b", "c", "a"}).Sorted().
+			IsEqual([]interface{}{"a", "b", "c"}).
+			chain.assert(t, success)
+
+		NewArray(reporter, []interface{}{}).Sorted().
+			IsEmpty().
+			chain.assert(t, success)
+	})
+
+	t.Run("object keys", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		object := NewObject(reporter, map[string]interface{}{"b": 1, "a": 2, "c": 3})
+
+		object.Keys().Sorted().
+			IsEqual([]interface{}{"a", "b", "c"}).
+			chain.assert(t, success)
+	})
+
+	t.Run("custom less", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{
+			map[string]interface{}{"id": 1, "rank": 2},
+			map[string]interface{}{"id": 2, "rank": 1},
+			map[string]interface{}{"id": 3, "rank": 2},
+		})
+
+		array.Sorted(func(x, y *Value) bool {
+			return x.Object().Value("rank").Number().Raw() <
+				y.Object().Value("rank").Number().Raw()
+		}).
+			Path("$[*].id").
+			IsEqual([]interface{}{2, 1, 3}).
+			chain.assert(t, success)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewArray(reporter, []interface{}{1, "2"}).Sorted().
+			chain.assert(t, failure)
+
+		NewArray(reporter, []interface{}{1, 2}).Sorted(nil).
+			chain.assert(t, failure)
+
+		less := func(x, y *Value) bool {
+			return false
+		}
+		NewArray(reporter, []interface{}{1, 2}).Sorted(less, less).
+			chain.assert(t, failure)
+
+		NewArray(reporter, []interface{}{1, "2"}).Sorted(func(x, y *Value) bool {
+			return x.Number().Raw() < y.Number().Raw()
+		}).
+			chain.assert(t, failure)
+	})
+}
+
 func TestArray_ComparatorErrors(t *testing.T) {
 	t.Run("nil slice", func(t *testing.T) {
 		chain := newMockChain(t).enter("test")
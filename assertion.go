@@ -100,6 +100,11 @@ const (
 	// This severity is used for assertions issued inside predicate functions,
 	// e.g. in Array.Filter and Object.Filter.
 	SeverityLog
+	// This assertion failure is a warning, it should be reported, but should
+	// not cause test failure.
+	// Typically handler will call t.Logf().
+	// This severity is used for assertions issued via Warn(), e.g. Value.Warn.
+	SeverityWarning
 )
 
 // AssertionContext provides context where the assetion happened.
@@ -112,6 +117,10 @@ type AssertionContext struct {
 	// Comes from Request.WithName()
 	RequestName string
 
+	// ID of request being sent
+	// Comes from Config.RequestIDHeader
+	RequestID string
+
 	// Chain of nested assertion names
 	// Example value:
 	//   {`Request("GET")`, `Expect()`, `JSON()`, `NotNull()`}
@@ -227,9 +236,39 @@ type AssertionHandler interface {
 	// Handling depends on Failure.Severity field:
 	//  - for SeverityError, reports failure to testing suite, e.g. using t.Errorf()
 	//  - for SeverityLog, ignores failure, or logs it, e.g. using t.Logf()
+	//  - for SeverityWarning, reports warning without failing test, e.g. using t.Logf()
 	Failure(*AssertionContext, *AssertionFailure)
 }
 
+// AttachmentAssertionHandler is optional extension of AssertionHandler that
+// receives raw HTTP traffic along with failure.
+//
+// If AssertionHandler also implements AttachmentAssertionHandler, then
+// FailureWithAttachments is invoked instead of Failure.
+//
+// AllureAssertionHandler implements this interface.
+type AttachmentAssertionHandler interface {
+	AssertionHandler
+
+	// Invoked every time when an assertion failed.
+	// Attachments hold dumps of request and response being checked, if they
+	// are available, e.g. full request and response with bodies, and
+	// equivalent curl command.
+	FailureWithAttachments(*AssertionContext, *AssertionFailure, []AssertionAttachment)
+}
+
+// AssertionAttachment holds raw data attached to assertion failure.
+type AssertionAttachment struct {
+	// Human-readable name, e.g. "request" or "response body"
+	Name string
+
+	// MIME type of content, e.g. "text/plain" or "application/json"
+	ContentType string
+
+	// Attachment content
+	Content []byte
+}
+
 // DefaultAssertionHandler is default implementation for AssertionHandler.
 //
 //   - Formatter is used to format success and failure messages
@@ -256,7 +295,7 @@ func (h *DefaultAssertionHandler) Success(ctx *AssertionContext) {
 
 	msg := h.Formatter.FormatSuccess(ctx)
 
-	h.Logger.Logf("%s", msg)
+	logWithFields(h.Logger, assertionLogFields(ctx, nil), "%s", msg)
 }
 
 // Failure implements AssertionHandler.Failure.
@@ -284,6 +323,20 @@ func (h *DefaultAssertionHandler) Failure(
 
 		msg := h.Formatter.FormatFailure(ctx, failure)
 
-		h.Logger.Logf("%s", msg)
+		logWithFields(h.Logger, assertionLogFields(ctx, failure), "%s", msg)
+
+	case SeverityWarning:
+		logger := h.Logger
+		if logger == nil {
+			// e.g. testing.T, which implements both Reporter and Logger
+			logger, _ = h.Reporter.(Logger)
+		}
+		if logger == nil {
+			return
+		}
+
+		msg := h.Formatter.FormatFailure(ctx, failure)
+
+		logWithFields(logger, assertionLogFields(ctx, failure), "%s", msg)
 	}
 }
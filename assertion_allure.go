@@ -0,0 +1,242 @@
+package httpexpect
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AllureAssertionHandler is AttachmentAssertionHandler that writes failed
+// assertions as Allure test results.
+//
+// For every failure with SeverityError, it writes "<uuid>-result.json" file
+// to ResultsDir, along with attachments: full request and response dumps,
+// request and response bodies, and equivalent curl command. The directory
+// can be then passed to "allure generate" or "allure serve".
+//
+// Result name is request name (Request.WithName), if it's set, or assertion
+// path otherwise. Test name (AssertionContext.TestName) is used as suite.
+// Succeeded assertions are not written.
+//
+// AllureAssertionHandler doesn't fail tests by itself. To report failures to
+// the testing suite as well, set Handler to another AssertionHandler, usually
+// DefaultAssertionHandler; every assertion is forwarded to it.
+//
+// ResultsDir is required. Handler and Formatter are optional. Errors during
+// writing results are ignored.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		TestName: t.Name(),
+//		BaseURL:  "http://example.com",
+//		AssertionHandler: &httpexpect.AllureAssertionHandler{
+//			ResultsDir: "allure-results",
+//			Handler: &httpexpect.DefaultAssertionHandler{
+//				Formatter: &httpexpect.DefaultFormatter{},
+//				Reporter:  httpexpect.NewAssertReporter(t),
+//			},
+//		},
+//	})
+type AllureAssertionHandler struct {
+	// Directory where results are written.
+	// Created if it doesn't exist.
+	ResultsDir string
+
+	// Optional handler to which all assertions are forwarded.
+	Handler AssertionHandler
+
+	// Optional formatter for failure details.
+	// If nil, DefaultFormatter with disabled colors is used.
+	Formatter Formatter
+
+	mu sync.Mutex
+}
+
+type allureResult struct {
+	UUID          string             `json:"uuid"`
+	HistoryID     string             `json:"historyId"`
+	Name          string             `json:"name"`
+	FullName      string             `json:"fullName"`
+	Status        string             `json:"status"`
+	StatusDetails allureStatus       `json:"statusDetails"`
+	Stage         string             `json:"stage"`
+	Start         int64              `json:"start"`
+	Stop          int64              `json:"stop"`
+	Labels        []allureLabel      `json:"labels"`
+	Attachments   []allureAttachment `json:"attachments"`
+}
+
+type allureStatus struct {
+	Message string `json:"message"`
+	Trace   string `json:"trace"`
+}
+
+type allureLabel struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type allureAttachment struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+}
+
+// Success implements AssertionHandler.Success.
+func (h *AllureAssertionHandler) Success(ctx *AssertionContext) {
+	if h.Handler != nil {
+		h.Handler.Success(ctx)
+	}
+}
+
+// Failure implements AssertionHandler.Failure.
+func (h *AllureAssertionHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.FailureWithAttachments(ctx, failure, buildAttachments(ctx))
+}
+
+// FailureWithAttachments implements
+// AttachmentAssertionHandler.FailureWithAttachments.
+func (h *AllureAssertionHandler) FailureWithAttachments(
+	ctx *AssertionContext, failure *AssertionFailure,
+	attachments []AssertionAttachment,
+) {
+	if h.ResultsDir == "" {
+		panic("AllureAssertionHandler.ResultsDir is empty")
+	}
+
+	if failure.Severity == SeverityError {
+		h.writeResult(ctx, failure, attachments)
+	}
+
+	if h.Handler != nil {
+		h.Handler.Failure(ctx, failure)
+	}
+}
+
+func (h *AllureAssertionHandler) writeResult(
+	ctx *AssertionContext, failure *AssertionFailure,
+	attachments []AssertionAttachment,
+) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := os.MkdirAll(h.ResultsDir, 0o755); err != nil {
+		return
+	}
+
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+
+	name := ctx.RequestName
+	if name == "" {
+		name = strings.Join(ctx.AliasedPath, ".")
+	}
+
+	fullName := name
+	if ctx.TestName != "" {
+		fullName = ctx.TestName + ": " + name
+	}
+
+	historyID := sha256.Sum256([]byte(fullName))
+
+	result := allureResult{
+		UUID:      newAllureUUID(),
+		HistoryID: hex.EncodeToString(historyID[:16]),
+		Name:      name,
+		FullName:  fullName,
+		Status:    "failed",
+		StatusDetails: allureStatus{
+			Message: failure.Type.String(),
+			Trace:   h.getFormatter().FormatFailure(ctx, failure),
+		},
+		Stage:       "finished",
+		Start:       now,
+		Stop:        now,
+		Labels:      []allureLabel{{Name: "framework", Value: "httpexpect"}},
+		Attachments: []allureAttachment{},
+	}
+
+	for _, err := range failure.Errors {
+		if !refIsNil(err) {
+			result.StatusDetails.Message = err.Error()
+			break
+		}
+	}
+
+	if ctx.TestName != "" {
+		result.Labels = append(result.Labels,
+			allureLabel{Name: "suite", Value: ctx.TestName})
+	}
+
+	for _, a := range attachments {
+		source := fmt.Sprintf("%s-attachment%s",
+			newAllureUUID(), allureExtension(a.ContentType))
+
+		err := os.WriteFile(filepath.Join(h.ResultsDir, source), a.Content, 0o644)
+		if err != nil {
+			continue
+		}
+
+		result.Attachments = append(result.Attachments, allureAttachment{
+			Name:   a.Name,
+			Source: source,
+			Type:   a.ContentType,
+		})
+	}
+
+	b, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return
+	}
+
+	_ = os.WriteFile(
+		filepath.Join(h.ResultsDir, result.UUID+"-result.json"), b, 0o644)
+}
+
+func (h *AllureAssertionHandler) getFormatter() Formatter {
+	if h.Formatter != nil {
+		return h.Formatter
+	}
+
+	return &DefaultFormatter{
+		ColorMode: ColorModeNever,
+	}
+}
+
+// Generate random UUID (version 4).
+func newAllureUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func allureExtension(contentType string) string {
+	switch {
+	case contentType == "application/json" || strings.HasSuffix(contentType, "+json"):
+		return ".json"
+	case contentType == "application/xml" || contentType == "text/xml" ||
+		strings.HasSuffix(contentType, "+xml"):
+		return ".xml"
+	case contentType == "text/html":
+		return ".html"
+	case strings.HasPrefix(contentType, "text/"):
+		return ".txt"
+	default:
+		return ".bin"
+	}
+}
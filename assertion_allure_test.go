@@ -0,0 +1,150 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAllureResults(t *testing.T, dir string) []allureResult {
+	matches, err := filepath.Glob(filepath.Join(dir, "*-result.json"))
+	require.NoError(t, err)
+
+	var results []allureResult
+	for _, path := range matches {
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		var result allureResult
+		require.NoError(t, json.Unmarshal(b, &result))
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func TestAssertionAllure_Handler(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "allure-results")
+	next := &mockAssertionHandler{}
+
+	handler := &AllureAssertionHandler{
+		ResultsDir: dir,
+		Handler:    next,
+	}
+
+	config := Config{
+		TestName: "TestAPI",
+		BaseURL:  "http://example.com",
+		Client: &mockClient{
+			resp: http.Response{StatusCode: http.StatusOK},
+		},
+		AssertionHandler: handler,
+	}
+
+	resp := NewRequestC(config, "PUT", "/users/1").
+		WithName("update user").
+		WithJSON(map[string]interface{}{"name": "john"}).
+		Expect()
+
+	resp.Status(http.StatusOK)
+	resp.JSON().Object().Value("name").IsEqual("bob")
+
+	assert.NotEqual(t, 0, next.successCalled)
+	assert.Equal(t, 1, next.failureCalled)
+
+	results := readAllureResults(t, dir)
+	require.Equal(t, 1, len(results))
+
+	result := results[0]
+
+	assert.Equal(t, "update user", result.Name)
+	assert.Equal(t, "TestAPI: update user", result.FullName)
+	assert.Equal(t, "failed", result.Status)
+	assert.Equal(t, "finished", result.Stage)
+	assert.Equal(t, "expected: values are equal", result.StatusDetails.Message)
+	assert.Contains(t, result.StatusDetails.Trace, `"bob"`)
+	assert.NotEqual(t, "", result.HistoryID)
+	assert.Contains(t, result.Labels, allureLabel{Name: "suite", Value: "TestAPI"})
+
+	sources := map[string]string{}
+	for _, a := range result.Attachments {
+		sources[a.Name] = a.Source
+
+		b, err := os.ReadFile(filepath.Join(dir, a.Source))
+		require.NoError(t, err)
+		assert.NotEqual(t, 0, len(b))
+	}
+
+	assert.True(t, strings.HasSuffix(sources["request"], "-attachment.txt"))
+	assert.True(t, strings.HasSuffix(sources["request body"], "-attachment.json"))
+	assert.True(t, strings.HasSuffix(sources["curl"], "-attachment.txt"))
+	assert.True(t, strings.HasSuffix(sources["response"], "-attachment.txt"))
+	assert.True(t, strings.HasSuffix(sources["response body"], "-attachment.json"))
+}
+
+func TestAssertionAllure_Severity(t *testing.T) {
+	dir := t.TempDir()
+
+	handler := &AllureAssertionHandler{
+		ResultsDir: dir,
+		Formatter:  newMockFormatter(t),
+	}
+
+	ctx := &AssertionContext{
+		Path:        []string{"foo", "bar()"},
+		AliasedPath: []string{"foo", "bar()"},
+	}
+
+	handler.Success(ctx)
+	handler.Failure(ctx, &AssertionFailure{
+		Type:     AssertValid,
+		Severity: SeverityLog,
+	})
+
+	assert.Equal(t, 0, len(readAllureResults(t, dir)))
+
+	handler.Failure(ctx, &AssertionFailure{
+		Type:     AssertValid,
+		Severity: SeverityError,
+	})
+
+	results := readAllureResults(t, dir)
+	require.Equal(t, 1, len(results))
+
+	assert.Equal(t, "foo.bar()", results[0].Name)
+	assert.Equal(t, "foo.bar()", results[0].FullName)
+	assert.Equal(t, "AssertValid", results[0].StatusDetails.Message)
+	assert.Equal(t, []allureAttachment{}, results[0].Attachments)
+}
+
+func TestAssertionAllure_Panics(t *testing.T) {
+	handler := &AllureAssertionHandler{}
+
+	assert.Panics(t, func() {
+		handler.Failure(&AssertionContext{}, &AssertionFailure{})
+	})
+}
+
+func TestAssertionAllure_Extension(t *testing.T) {
+	cases := map[string]string{
+		"application/json":         ".json",
+		"application/problem+json": ".json",
+		"application/xml":          ".xml",
+		"text/xml":                 ".xml",
+		"text/html":                ".html",
+		"text/plain":               ".txt",
+		"text/csv":                 ".txt",
+		"application/octet-stream": ".bin",
+		"image/png":                ".bin",
+	}
+
+	for contentType, ext := range cases {
+		assert.Equal(t, ext, allureExtension(contentType), contentType)
+	}
+}
@@ -0,0 +1,122 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httputil"
+
+	"moul.io/http2curl/v2"
+)
+
+// Build attachments for AttachmentAssertionHandler from request and
+// response stored in assertion context.
+//
+// Request body is available only if request was sent, in which case it's
+// stored in bodyWrapper. Response body is available only if it was already
+// read by assertions; we never consume it here.
+func buildAttachments(ctx *AssertionContext) []AssertionAttachment {
+	attachments := []AssertionAttachment{}
+
+	if ctx.Request != nil && ctx.Request.httpReq != nil {
+		attachments = append(attachments, requestAttachments(ctx.Request.httpReq)...)
+	}
+
+	if ctx.Response != nil && ctx.Response.httpResp != nil {
+		attachments = append(attachments, responseAttachments(ctx.Response)...)
+	}
+
+	return attachments
+}
+
+func requestAttachments(httpReq *http.Request) []AssertionAttachment {
+	var body []byte
+	if bw, ok := httpReq.Body.(*bodyWrapper); ok {
+		if rd, err := bw.GetBody(); err == nil {
+			body, _ = io.ReadAll(rd)
+		}
+	}
+
+	// work on a copy, since dumping consumes the body
+	cloneReq := func() *http.Request {
+		req := httpReq.Clone(httpReq.Context())
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		} else {
+			req.Body = nil
+		}
+		return req
+	}
+
+	var attachments []AssertionAttachment
+
+	if dump, err := httputil.DumpRequest(cloneReq(), body != nil); err == nil {
+		attachments = append(attachments, AssertionAttachment{
+			Name:        "request",
+			ContentType: "text/plain",
+			Content:     dump,
+		})
+	}
+
+	if len(body) != 0 {
+		attachments = append(attachments, AssertionAttachment{
+			Name:        "request body",
+			ContentType: attachmentContentType(httpReq.Header),
+			Content:     body,
+		})
+	}
+
+	if cmd, err := http2curl.GetCurlCommand(cloneReq()); err == nil {
+		attachments = append(attachments, AssertionAttachment{
+			Name:        "curl",
+			ContentType: "text/plain",
+			Content:     []byte(cmd.String()),
+		})
+	}
+
+	return attachments
+}
+
+func responseAttachments(resp *Response) []AssertionAttachment {
+	var body []byte
+	if resp.contentState == contentRetreived {
+		body = resp.content
+	}
+
+	httpResp := *resp.httpResp
+	if body != nil {
+		httpResp.Body = io.NopCloser(bytes.NewReader(body))
+	} else {
+		httpResp.Body = nil
+	}
+
+	var attachments []AssertionAttachment
+
+	if dump, err := httputil.DumpResponse(&httpResp, body != nil); err == nil {
+		attachments = append(attachments, AssertionAttachment{
+			Name:        "response",
+			ContentType: "text/plain",
+			Content:     dump,
+		})
+	}
+
+	if len(body) != 0 {
+		attachments = append(attachments, AssertionAttachment{
+			Name:        "response body",
+			ContentType: attachmentContentType(resp.httpResp.Header),
+			Content:     body,
+		})
+	}
+
+	return attachments
+}
+
+func attachmentContentType(header http.Header) string {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || mediaType == "" {
+		return "application/octet-stream"
+	}
+
+	return mediaType
+}
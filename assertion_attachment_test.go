@@ -0,0 +1,130 @@
+package httpexpect
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAttachmentHandler struct {
+	mockAssertionHandler
+	attachments []AssertionAttachment
+}
+
+func (mh *mockAttachmentHandler) FailureWithAttachments(
+	ctx *AssertionContext, failure *AssertionFailure,
+	attachments []AssertionAttachment,
+) {
+	mh.attachments = attachments
+	mh.Failure(ctx, failure)
+}
+
+func findAttachment(
+	attachments []AssertionAttachment, name string,
+) *AssertionAttachment {
+	for n := range attachments {
+		if attachments[n].Name == name {
+			return &attachments[n]
+		}
+	}
+	return nil
+}
+
+func TestAssertionAttachment_Handler(t *testing.T) {
+	newConfig := func(handler AssertionHandler) Config {
+		return Config{
+			BaseURL: "http://example.com",
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+			AssertionHandler: handler,
+		}
+	}
+
+	t.Run("body read", func(t *testing.T) {
+		handler := &mockAttachmentHandler{}
+
+		resp := NewRequestC(newConfig(handler), "POST", "/path").
+			WithJSON(map[string]interface{}{"a": 1}).
+			Expect()
+
+		resp.JSON().Object().IsEqual(map[string]interface{}{"a": 2})
+
+		assert.Equal(t, 1, handler.failureCalled)
+
+		names := []string{}
+		for _, a := range handler.attachments {
+			names = append(names, a.Name)
+		}
+		assert.Equal(t, []string{
+			"request", "request body", "curl", "response", "response body",
+		}, names)
+
+		req := findAttachment(handler.attachments, "request")
+		require.NotNil(t, req)
+		assert.Equal(t, "text/plain", req.ContentType)
+		assert.True(t, strings.HasPrefix(string(req.Content), "POST /path HTTP/1.1"))
+		assert.Contains(t, string(req.Content), `{"a":1}`)
+
+		reqBody := findAttachment(handler.attachments, "request body")
+		require.NotNil(t, reqBody)
+		assert.Equal(t, "application/json", reqBody.ContentType)
+		assert.Equal(t, `{"a":1}`, string(reqBody.Content))
+
+		curl := findAttachment(handler.attachments, "curl")
+		require.NotNil(t, curl)
+		assert.Contains(t, string(curl.Content), "curl -X 'POST'")
+		assert.Contains(t, string(curl.Content), `-d '{"a":1}'`)
+
+		respDump := findAttachment(handler.attachments, "response")
+		require.NotNil(t, respDump)
+		assert.Contains(t, string(respDump.Content), "200 OK")
+		assert.Contains(t, string(respDump.Content), `{"a":1}`)
+
+		respBody := findAttachment(handler.attachments, "response body")
+		require.NotNil(t, respBody)
+		assert.Equal(t, "application/json", respBody.ContentType)
+		assert.Equal(t, `{"a":1}`, string(respBody.Content))
+	})
+
+	t.Run("body not read", func(t *testing.T) {
+		handler := &mockAttachmentHandler{}
+
+		resp := NewRequestC(newConfig(handler), "GET", "/path").
+			Expect()
+
+		resp.Status(http.StatusNotFound)
+
+		assert.Equal(t, 1, handler.failureCalled)
+
+		assert.Nil(t, findAttachment(handler.attachments, "request body"))
+		assert.Nil(t, findAttachment(handler.attachments, "response body"))
+
+		respDump := findAttachment(handler.attachments, "response")
+		require.NotNil(t, respDump)
+		assert.Contains(t, string(respDump.Content), "200 OK")
+
+		// response body is still available to assertions
+		resp.Body().IsEmpty()
+	})
+
+	t.Run("no request", func(t *testing.T) {
+		handler := &mockAttachmentHandler{}
+
+		NewValueC(newConfig(handler), 123).IsEqual(456)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Equal(t, []AssertionAttachment{}, handler.attachments)
+	})
+
+	t.Run("plain handler", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewValueC(newConfig(handler), 123).IsEqual(456)
+
+		assert.Equal(t, 1, handler.failureCalled)
+	})
+}
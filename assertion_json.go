@@ -0,0 +1,276 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// JSONAssertionHandler is AssertionHandler that writes every succeeded and
+// failed assertion as a structured JSON record to Writer.
+//
+// Records are written in JSON Lines format, i.e. one JSONAssertionRecord
+// per line. This allows CI systems to collect and aggregate test results
+// without parsing text output of Formatter.
+//
+// JSONAssertionHandler doesn't fail tests by itself. To report failures to
+// the testing suite as well, set Handler to another AssertionHandler, usually
+// DefaultAssertionHandler; every assertion is forwarded to it after the
+// record is written.
+//
+// Writer is required. Handler is optional. Errors returned by Writer are
+// ignored.
+//
+// Example:
+//
+//	file, _ := os.Create("assertions.jsonl")
+//	defer file.Close()
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL: "http://example.com",
+//		AssertionHandler: &httpexpect.JSONAssertionHandler{
+//			Writer: file,
+//			Handler: &httpexpect.DefaultAssertionHandler{
+//				Formatter: &httpexpect.DefaultFormatter{},
+//				Reporter:  httpexpect.NewAssertReporter(t),
+//			},
+//		},
+//	})
+type JSONAssertionHandler struct {
+	// Destination for JSON records.
+	Writer io.Writer
+
+	// Optional handler to which all assertions are forwarded.
+	Handler AssertionHandler
+
+	// Don't write records for succeeded assertions.
+	DisableSuccesses bool
+
+	// Exclude HTTP request from records.
+	DisableRequests bool
+
+	// Exclude HTTP response from records.
+	DisableResponses bool
+
+	// Exclude stacktrace from records.
+	DisableStacktrace bool
+
+	mu sync.Mutex
+}
+
+// JSONAssertionRecord defines a single record written by JSONAssertionHandler.
+//
+// Actual, Expected, Reference, and Delta hold JSON representation of
+// corresponding AssertionFailure fields. Values that can't be represented
+// in JSON are written as strings formatted using "%v".
+type JSONAssertionRecord struct {
+	// Either "success" or "failure"
+	Result string `json:"result"`
+
+	// Name of the running test, name and ID of request (AssertionContext)
+	TestName    string `json:"test_name,omitempty"`
+	RequestName string `json:"request_name,omitempty"`
+	RequestID   string `json:"request_id,omitempty"`
+
+	// Chains of nested assertion names (AssertionContext)
+	Path        []string `json:"path"`
+	AliasedPath []string `json:"aliased_path"`
+
+	// Assertion type and severity, e.g. "AssertEqual" and "SeverityError"
+	// Set only for failures
+	Type     string `json:"type,omitempty"`
+	Severity string `json:"severity,omitempty"`
+
+	// Failure details (AssertionFailure)
+	Errors    []string        `json:"errors,omitempty"`
+	Actual    json.RawMessage `json:"actual,omitempty"`
+	Expected  json.RawMessage `json:"expected,omitempty"`
+	Reference json.RawMessage `json:"reference,omitempty"`
+	Delta     json.RawMessage `json:"delta,omitempty"`
+
+	// Snapshots of request and response, if they're available
+	Request  *JSONRequestRecord  `json:"request,omitempty"`
+	Response *JSONResponseRecord `json:"response,omitempty"`
+
+	// Stacktrace of the failure, up to the test entry point
+	Stacktrace []JSONStacktraceRecord `json:"stacktrace,omitempty"`
+}
+
+// JSONRequestRecord defines snapshot of HTTP request in JSONAssertionRecord.
+type JSONRequestRecord struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Proto   string      `json:"proto,omitempty"`
+	Headers http.Header `json:"headers,omitempty"`
+}
+
+// JSONResponseRecord defines snapshot of HTTP response in JSONAssertionRecord.
+//
+// Body is set only if response body was already read by assertions.
+type JSONResponseRecord struct {
+	Status     string      `json:"status"`
+	StatusCode int         `json:"status_code"`
+	Proto      string      `json:"proto,omitempty"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       *string     `json:"body,omitempty"`
+	RTT        string      `json:"rtt,omitempty"`
+}
+
+// JSONStacktraceRecord defines stacktrace entry in JSONAssertionRecord.
+type JSONStacktraceRecord struct {
+	Func    string `json:"func"`
+	Package string `json:"package"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+}
+
+// Success implements AssertionHandler.Success.
+func (h *JSONAssertionHandler) Success(ctx *AssertionContext) {
+	if h.Writer == nil {
+		panic("JSONAssertionHandler.Writer is nil")
+	}
+
+	if !h.DisableSuccesses {
+		h.write(h.buildRecord(ctx, nil))
+	}
+
+	if h.Handler != nil {
+		h.Handler.Success(ctx)
+	}
+}
+
+// Failure implements AssertionHandler.Failure.
+func (h *JSONAssertionHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	if h.Writer == nil {
+		panic("JSONAssertionHandler.Writer is nil")
+	}
+
+	h.write(h.buildRecord(ctx, failure))
+
+	if h.Handler != nil {
+		h.Handler.Failure(ctx, failure)
+	}
+}
+
+func (h *JSONAssertionHandler) write(record *JSONAssertionRecord) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, _ = h.Writer.Write(append(b, '\n'))
+}
+
+func (h *JSONAssertionHandler) buildRecord(
+	ctx *AssertionContext, failure *AssertionFailure,
+) *JSONAssertionRecord {
+	record := JSONAssertionRecord{
+		Result:      "success",
+		TestName:    ctx.TestName,
+		RequestName: ctx.RequestName,
+		RequestID:   ctx.RequestID,
+		Path:        ctx.Path,
+		AliasedPath: ctx.AliasedPath,
+	}
+
+	if !h.DisableRequests && ctx.Request != nil && ctx.Request.httpReq != nil {
+		record.Request = buildRequestRecord(ctx.Request.httpReq)
+	}
+
+	if !h.DisableResponses && ctx.Response != nil && ctx.Response.httpResp != nil {
+		record.Response = buildResponseRecord(ctx.Response)
+	}
+
+	if failure == nil {
+		return &record
+	}
+
+	record.Result = "failure"
+	record.Type = failure.Type.String()
+	record.Severity = failure.Severity.String()
+
+	for _, err := range failure.Errors {
+		if refIsNil(err) {
+			continue
+		}
+		record.Errors = append(record.Errors, err.Error())
+	}
+
+	record.Actual = marshalAssertionValue(failure.Actual)
+	record.Expected = marshalAssertionValue(failure.Expected)
+	record.Reference = marshalAssertionValue(failure.Reference)
+	record.Delta = marshalAssertionValue(failure.Delta)
+
+	if !h.DisableStacktrace {
+		for _, entry := range failure.Stacktrace {
+			if entry.IsEntrypoint {
+				break
+			}
+			record.Stacktrace = append(record.Stacktrace, JSONStacktraceRecord{
+				Func:    entry.FuncName,
+				Package: entry.FuncPackage,
+				File:    entry.File,
+				Line:    entry.Line,
+			})
+		}
+	}
+
+	return &record
+}
+
+func buildRequestRecord(httpReq *http.Request) *JSONRequestRecord {
+	record := &JSONRequestRecord{
+		Method:  httpReq.Method,
+		Proto:   httpReq.Proto,
+		Headers: httpReq.Header.Clone(),
+	}
+
+	if httpReq.URL != nil {
+		record.URL = httpReq.URL.String()
+	}
+
+	return record
+}
+
+func buildResponseRecord(resp *Response) *JSONResponseRecord {
+	httpResp := resp.httpResp
+
+	record := &JSONResponseRecord{
+		Status:     httpResp.Status,
+		StatusCode: httpResp.StatusCode,
+		Proto:      httpResp.Proto,
+		Headers:    httpResp.Header.Clone(),
+	}
+
+	// don't consume body here, report it only if assertions already did
+	if resp.contentState == contentRetreived {
+		body := string(resp.content)
+		record.Body = &body
+	}
+
+	if resp.rtt != nil {
+		record.RTT = resp.rtt.String()
+	}
+
+	return record
+}
+
+func marshalAssertionValue(value *AssertionValue) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(value.Value)
+	if err != nil {
+		b, _ = json.Marshal(fmt.Sprintf("%v", value.Value))
+	}
+
+	return b
+}
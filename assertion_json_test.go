@@ -0,0 +1,251 @@
+package httpexpect
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeJSONRecords(t *testing.T, buf *bytes.Buffer) []JSONAssertionRecord {
+	var records []JSONAssertionRecord
+
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var record JSONAssertionRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestAssertionJSON_Handler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		next := &mockAssertionHandler{}
+
+		handler := &JSONAssertionHandler{
+			Writer:  buf,
+			Handler: next,
+		}
+
+		handler.Success(&AssertionContext{
+			TestName:    "TestFoo",
+			RequestName: "login",
+			RequestID:   "req-1",
+			Path:        []string{"Request()", "Expect()"},
+			AliasedPath: []string{"foo", "Expect()"},
+		})
+
+		assert.Equal(t, 1, next.successCalled)
+
+		records := decodeJSONRecords(t, buf)
+		require.Equal(t, 1, len(records))
+
+		assert.Equal(t, JSONAssertionRecord{
+			Result:      "success",
+			TestName:    "TestFoo",
+			RequestName: "login",
+			RequestID:   "req-1",
+			Path:        []string{"Request()", "Expect()"},
+			AliasedPath: []string{"foo", "Expect()"},
+		}, records[0])
+	})
+
+	t.Run("disabled success", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		next := &mockAssertionHandler{}
+
+		handler := &JSONAssertionHandler{
+			Writer:           buf,
+			Handler:          next,
+			DisableSuccesses: true,
+		}
+
+		handler.Success(&AssertionContext{})
+
+		assert.Equal(t, 1, next.successCalled)
+		assert.Equal(t, 0, buf.Len())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		next := &mockAssertionHandler{}
+
+		handler := &JSONAssertionHandler{
+			Writer:  buf,
+			Handler: next,
+		}
+
+		handler.Failure(
+			&AssertionContext{
+				TestName: "TestFoo",
+				Path:     []string{"Number()", "IsEqual()"},
+			},
+			&AssertionFailure{
+				Type:     AssertInRange,
+				Severity: SeverityLog,
+				Errors: []error{
+					errors.New("out of range"),
+					nil,
+				},
+				Actual:   &AssertionValue{123},
+				Expected: &AssertionValue{AssertionRange{1, 10}},
+				Delta:    &AssertionValue{math.NaN()},
+				Stacktrace: []StacktraceEntry{
+					{
+						FuncName:    "TestFoo",
+						FuncPackage: "example.com/foo",
+						File:        "/src/foo_test.go",
+						Line:        12,
+					},
+					{
+						FuncName:     "tRunner",
+						IsEntrypoint: true,
+					},
+				},
+			})
+
+		assert.Equal(t, 1, next.failureCalled)
+
+		records := decodeJSONRecords(t, buf)
+		require.Equal(t, 1, len(records))
+
+		record := records[0]
+
+		assert.Equal(t, "failure", record.Result)
+		assert.Equal(t, "AssertInRange", record.Type)
+		assert.Equal(t, "SeverityLog", record.Severity)
+		assert.Equal(t, []string{"out of range"}, record.Errors)
+		assert.JSONEq(t, `123`, string(record.Actual))
+		assert.JSONEq(t, `{"Min":1,"Max":10}`, string(record.Expected))
+		assert.Nil(t, record.Reference)
+		assert.JSONEq(t, `"NaN"`, string(record.Delta))
+		assert.Equal(t, []JSONStacktraceRecord{
+			{
+				Func:    "TestFoo",
+				Package: "example.com/foo",
+				File:    "/src/foo_test.go",
+				Line:    12,
+			},
+		}, record.Stacktrace)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		handler := &JSONAssertionHandler{
+			Writer: buf,
+		}
+
+		handler.Failure(&AssertionContext{}, &AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{nil},
+		})
+
+		assert.Contains(t, buf.String(), `"actual":null`)
+		assert.NotContains(t, buf.String(), `"expected"`)
+	})
+
+	t.Run("nil writer", func(t *testing.T) {
+		handler := &JSONAssertionHandler{}
+
+		assert.Panics(t, func() {
+			handler.Success(&AssertionContext{})
+		})
+
+		assert.Panics(t, func() {
+			handler.Failure(&AssertionContext{}, &AssertionFailure{})
+		})
+	})
+}
+
+func TestAssertionJSON_RequestResponse(t *testing.T) {
+	newHandler := func(buf *bytes.Buffer) *JSONAssertionHandler {
+		return &JSONAssertionHandler{
+			Writer:           buf,
+			DisableSuccesses: true,
+		}
+	}
+
+	send := func(handler AssertionHandler) *Response {
+		client := &mockClient{
+			resp: http.Response{
+				StatusCode: http.StatusTeapot,
+			},
+		}
+
+		config := Config{
+			BaseURL:          "http://example.com",
+			Client:           client,
+			AssertionHandler: handler,
+		}
+
+		return NewRequestC(config, "POST", "/path").
+			WithHeader("X-Foo", "bar").
+			WithText("hello").
+			Expect()
+	}
+
+	t.Run("body not read", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		resp := send(newHandler(buf))
+		resp.Status(http.StatusOK)
+
+		records := decodeJSONRecords(t, buf)
+		require.Equal(t, 1, len(records))
+
+		record := records[0]
+
+		require.NotNil(t, record.Request)
+		assert.Equal(t, "POST", record.Request.Method)
+		assert.Equal(t, "http://example.com/path", record.Request.URL)
+		assert.Equal(t, "bar", record.Request.Headers.Get("X-Foo"))
+
+		require.NotNil(t, record.Response)
+		assert.Equal(t, http.StatusTeapot, record.Response.StatusCode)
+		assert.Equal(t, "bar", record.Response.Headers.Get("X-Foo"))
+		assert.Nil(t, record.Response.Body)
+	})
+
+	t.Run("body read", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		resp := send(newHandler(buf))
+		resp.Body().IsEqual("bye")
+
+		records := decodeJSONRecords(t, buf)
+		require.Equal(t, 1, len(records))
+
+		record := records[0]
+
+		require.NotNil(t, record.Response)
+		require.NotNil(t, record.Response.Body)
+		assert.Equal(t, "hello", *record.Response.Body)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		handler := newHandler(buf)
+		handler.DisableRequests = true
+		handler.DisableResponses = true
+
+		resp := send(handler)
+		resp.Status(http.StatusOK)
+
+		records := decodeJSONRecords(t, buf)
+		require.Equal(t, 1, len(records))
+
+		assert.Nil(t, records[0].Request)
+		assert.Nil(t, records[0].Response)
+	})
+}
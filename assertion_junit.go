@@ -0,0 +1,381 @@
+package httpexpect
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JUnitAssertionHandler is AssertionHandler that aggregates assertion results
+// and writes them as JUnit-compatible XML report.
+//
+// Every Go test (AssertionContext.TestName) becomes a test suite, and every
+// request becomes a test case inside it. Test case is identified by request
+// name (Request.WithName), or alias (Alias method), or, if neither is set,
+// by request method and path. This way httpexpect-driven API suites show up
+// as individual cases in CI dashboards even when run under a single Go test.
+//
+// A test case fails if at least one of its assertions failed with
+// SeverityError. Failures with SeverityLog and SeverityWarning are ignored.
+//
+// The report is written to Writer when Flush or Close is called. Formatter
+// is used to format failure details; if it's nil, DefaultFormatter with
+// disabled colors is used.
+//
+// JUnitAssertionHandler doesn't fail tests by itself. To report failures to
+// the testing suite as well, set Handler to another AssertionHandler, usually
+// DefaultAssertionHandler; every assertion is forwarded to it.
+//
+// Example:
+//
+//	file, _ := os.Create("report.xml")
+//
+//	handler := &httpexpect.JUnitAssertionHandler{
+//		Writer: file,
+//		Handler: &httpexpect.DefaultAssertionHandler{
+//			Formatter: &httpexpect.DefaultFormatter{},
+//			Reporter:  httpexpect.NewAssertReporter(t),
+//		},
+//	}
+//	defer handler.Close()
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		TestName:         t.Name(),
+//		BaseURL:          "http://example.com",
+//		AssertionHandler: handler,
+//	})
+type JUnitAssertionHandler struct {
+	// Destination for XML report.
+	Writer io.Writer
+
+	// Optional handler to which all assertions are forwarded.
+	Handler AssertionHandler
+
+	// Optional formatter for failure details.
+	Formatter Formatter
+
+	// Name of test suite used when test name is unknown.
+	// Default is "httpexpect".
+	SuiteName string
+
+	mu     sync.Mutex
+	suites []*junitSuite
+}
+
+type junitSuite struct {
+	name       string
+	cases      []*junitCase
+	casesByReq map[*Request]*junitCase
+	casesByKey map[string]*junitCase
+}
+
+// Results of a single request, or of assertions with the same name that
+// are not bound to a request. Cases with the same name are merged when
+// building the report.
+type junitCase struct {
+	name       string
+	nameRank   int
+	assertions int
+	failures   []junitFailure
+	responses  map[*Response]struct{}
+	duration   time.Duration
+}
+
+type junitFailure struct {
+	typ     string
+	message string
+	details string
+}
+
+// Success implements AssertionHandler.Success.
+func (h *JUnitAssertionHandler) Success(ctx *AssertionContext) {
+	h.mu.Lock()
+	c := h.getCase(ctx)
+	c.assertions++
+	h.mu.Unlock()
+
+	if h.Handler != nil {
+		h.Handler.Success(ctx)
+	}
+}
+
+// Failure implements AssertionHandler.Failure.
+func (h *JUnitAssertionHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	if failure.Severity == SeverityError {
+		f := junitFailure{
+			typ:     failure.Type.String(),
+			message: failure.Type.String(),
+			details: h.getFormatter().FormatFailure(ctx, failure),
+		}
+
+		for _, err := range failure.Errors {
+			if !refIsNil(err) {
+				f.message = err.Error()
+				break
+			}
+		}
+
+		h.mu.Lock()
+		c := h.getCase(ctx)
+		c.assertions++
+		c.failures = append(c.failures, f)
+		h.mu.Unlock()
+	}
+
+	if h.Handler != nil {
+		h.Handler.Failure(ctx, failure)
+	}
+}
+
+// Flush writes XML report with all results collected so far to Writer.
+func (h *JUnitAssertionHandler) Flush() error {
+	if h.Writer == nil {
+		panic("JUnitAssertionHandler.Writer is nil")
+	}
+
+	h.mu.Lock()
+	report := h.buildReport()
+	h.mu.Unlock()
+
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(h.Writer, xml.Header); err != nil {
+		return err
+	}
+
+	if _, err := h.Writer.Write(append(b, '\n')); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Close writes XML report to Writer using Flush, and then closes Writer,
+// if it implements io.Closer.
+func (h *JUnitAssertionHandler) Close() error {
+	if err := h.Flush(); err != nil {
+		return err
+	}
+
+	if closer, ok := h.Writer.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+func (h *JUnitAssertionHandler) getFormatter() Formatter {
+	if h.Formatter != nil {
+		return h.Formatter
+	}
+
+	return &DefaultFormatter{
+		ColorMode: ColorModeNever,
+	}
+}
+
+func (h *JUnitAssertionHandler) getCase(ctx *AssertionContext) *junitCase {
+	suiteName := ctx.TestName
+	if suiteName == "" {
+		suiteName = h.SuiteName
+	}
+	if suiteName == "" {
+		suiteName = "httpexpect"
+	}
+
+	var suite *junitSuite
+	for _, s := range h.suites {
+		if s.name == suiteName {
+			suite = s
+			break
+		}
+	}
+	if suite == nil {
+		suite = &junitSuite{
+			name:       suiteName,
+			casesByReq: make(map[*Request]*junitCase),
+			casesByKey: make(map[string]*junitCase),
+		}
+		h.suites = append(h.suites, suite)
+	}
+
+	caseName, caseRank := junitCaseName(ctx)
+
+	var tc *junitCase
+	if ctx.Request != nil {
+		tc = suite.casesByReq[ctx.Request]
+	} else {
+		tc = suite.casesByKey[caseName]
+	}
+
+	if tc == nil {
+		tc = &junitCase{
+			responses: make(map[*Response]struct{}),
+		}
+		suite.cases = append(suite.cases, tc)
+
+		if ctx.Request != nil {
+			suite.casesByReq[ctx.Request] = tc
+		} else {
+			suite.casesByKey[caseName] = tc
+		}
+	}
+
+	// request name and alias may be set after first assertions
+	if tc.name == "" || caseRank > tc.nameRank {
+		tc.name = caseName
+		tc.nameRank = caseRank
+	}
+
+	// account round-trip time of every response only once
+	if resp := ctx.Response; resp != nil && resp.rtt != nil {
+		if _, ok := tc.responses[resp]; !ok {
+			tc.responses[resp] = struct{}{}
+			tc.duration += *resp.rtt
+		}
+	}
+
+	return tc
+}
+
+// Returns test case name and its rank; names with higher rank are
+// preferred over names with lower rank.
+func junitCaseName(ctx *AssertionContext) (string, int) {
+	if ctx.RequestName != "" {
+		return ctx.RequestName, 3
+	}
+
+	if len(ctx.AliasedPath) != 0 && len(ctx.Path) != 0 &&
+		ctx.AliasedPath[0] != ctx.Path[0] {
+		return ctx.AliasedPath[0], 2
+	}
+
+	if ctx.Request != nil && ctx.Request.httpReq != nil {
+		return ctx.Request.httpReq.Method + " " + ctx.Request.path, 1
+	}
+
+	if len(ctx.Path) != 0 {
+		return ctx.Path[0], 0
+	}
+
+	return "(unnamed)", 0
+}
+
+type junitXMLReport struct {
+	XMLName  xml.Name        `xml:"testsuites"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Suites   []junitXMLSuite `xml:"testsuite"`
+}
+
+type junitXMLSuite struct {
+	Name     string         `xml:"name,attr"`
+	Tests    int            `xml:"tests,attr"`
+	Failures int            `xml:"failures,attr"`
+	Time     string         `xml:"time,attr"`
+	Cases    []junitXMLCase `xml:"testcase"`
+}
+
+type junitXMLCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Assertions int              `xml:"assertions,attr"`
+	Time       string           `xml:"time,attr"`
+	Failure    *junitXMLFailure `xml:"failure,omitempty"`
+}
+
+type junitXMLFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (h *JUnitAssertionHandler) buildReport() *junitXMLReport {
+	report := &junitXMLReport{}
+
+	var reportTime time.Duration
+
+	for _, suite := range h.suites {
+		xmlSuite := junitXMLSuite{
+			Name: suite.name,
+		}
+
+		var suiteTime time.Duration
+
+		// merge cases with the same name, preserving order
+		var cases []*junitCase
+		casesByName := make(map[string]*junitCase)
+
+		for _, c := range suite.cases {
+			if m := casesByName[c.name]; m != nil {
+				m.assertions += c.assertions
+				m.failures = append(m.failures, c.failures...)
+				m.duration += c.duration
+				continue
+			}
+			m := &junitCase{
+				name:       c.name,
+				assertions: c.assertions,
+				failures:   append([]junitFailure(nil), c.failures...),
+				duration:   c.duration,
+			}
+			casesByName[c.name] = m
+			cases = append(cases, m)
+		}
+
+		for _, c := range cases {
+			xmlCase := junitXMLCase{
+				Name:       c.name,
+				ClassName:  suite.name,
+				Assertions: c.assertions,
+				Time:       formatJUnitTime(c.duration),
+			}
+
+			if len(c.failures) != 0 {
+				details := make([]string, 0, len(c.failures))
+				for _, f := range c.failures {
+					details = append(details, f.details)
+				}
+
+				// JUnit allows only one failure per test case, so we report
+				// first failure and include details of all failures
+				xmlCase.Failure = &junitXMLFailure{
+					Message: c.failures[0].message,
+					Type:    c.failures[0].typ,
+					Text:    strings.Join(details, "\n\n"),
+				}
+				xmlSuite.Failures++
+			}
+
+			xmlSuite.Tests++
+			xmlSuite.Cases = append(xmlSuite.Cases, xmlCase)
+
+			suiteTime += c.duration
+		}
+
+		xmlSuite.Time = formatJUnitTime(suiteTime)
+
+		report.Tests += xmlSuite.Tests
+		report.Failures += xmlSuite.Failures
+		report.Suites = append(report.Suites, xmlSuite)
+
+		reportTime += suiteTime
+	}
+
+	report.Time = formatJUnitTime(reportTime)
+
+	return report
+}
+
+func formatJUnitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,209 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type junitTestCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *junitTestCloser) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestAssertionJUnit_Report(t *testing.T) {
+	buf := &bytes.Buffer{}
+	next := &mockAssertionHandler{}
+
+	handler := &JUnitAssertionHandler{
+		Writer:  buf,
+		Handler: next,
+	}
+
+	config := Config{
+		TestName: "TestAPI",
+		BaseURL:  "http://example.com",
+		Client: &mockClient{
+			resp: http.Response{StatusCode: http.StatusOK},
+		},
+		AssertionHandler: handler,
+	}
+
+	NewRequestC(config, "GET", "/users/{id}", 1).
+		WithQuery("full", "1").
+		Expect().
+		Status(http.StatusOK)
+
+	NewRequestC(config, "POST", "/login").
+		WithName("login").
+		WithText("hello").
+		Expect().
+		Body().IsEqual("bye")
+
+	resp := NewRequestC(config, "GET", "/items").
+		Expect()
+	resp.Alias("items").Status(http.StatusNotFound)
+
+	config.TestName = ""
+	NewRequestC(config, "GET", "/health").
+		Expect()
+
+	assert.NotEqual(t, 0, next.successCalled)
+	assert.Equal(t, 2, next.failureCalled)
+
+	require.NoError(t, handler.Flush())
+
+	assert.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var report junitXMLReport
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+
+	assert.Equal(t, 4, report.Tests)
+	assert.Equal(t, 2, report.Failures)
+	require.Equal(t, 2, len(report.Suites))
+
+	suite := report.Suites[0]
+	assert.Equal(t, "TestAPI", suite.Name)
+	assert.Equal(t, 3, suite.Tests)
+	assert.Equal(t, 2, suite.Failures)
+
+	names := []string{}
+	for _, c := range suite.Cases {
+		names = append(names, c.Name)
+		assert.Equal(t, "TestAPI", c.ClassName)
+	}
+	assert.Equal(t, []string{
+		"GET /users/1",
+		"login",
+		"items",
+	}, names)
+
+	assert.Nil(t, suite.Cases[0].Failure)
+	assert.NotEqual(t, 0, suite.Cases[0].Assertions)
+
+	require.NotNil(t, suite.Cases[1].Failure)
+	assert.Equal(t, "AssertEqual", suite.Cases[1].Failure.Type)
+	assert.Contains(t, suite.Cases[1].Failure.Text, "bye")
+
+	require.NotNil(t, suite.Cases[2].Failure)
+	assert.Equal(t, "AssertEqual", suite.Cases[2].Failure.Type)
+	assert.Contains(t, suite.Cases[2].Failure.Text, "404 Not Found")
+
+	assert.Equal(t, "httpexpect", report.Suites[1].Name)
+}
+
+func TestAssertionJUnit_Merge(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	handler := &JUnitAssertionHandler{
+		Writer:    buf,
+		Formatter: newMockFormatter(t),
+	}
+
+	config := Config{
+		TestName:         "TestAPI",
+		BaseURL:          "http://example.com",
+		Client:           &mockClient{},
+		AssertionHandler: handler,
+	}
+
+	for _, text := range []string{"a", "b", "c"} {
+		NewRequestC(config, "PUT", "/item").
+			WithName("update").
+			WithText(text).
+			Expect().
+			Body().IsEqual("b")
+	}
+
+	require.NoError(t, handler.Flush())
+
+	var report junitXMLReport
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+
+	require.Equal(t, 1, len(report.Suites))
+	require.Equal(t, 1, len(report.Suites[0].Cases))
+
+	tc := report.Suites[0].Cases[0]
+	assert.Equal(t, "update", tc.Name)
+	require.NotNil(t, tc.Failure)
+	assert.Equal(t, 2, strings.Count(tc.Failure.Text, "\n\n")+1)
+}
+
+func TestAssertionJUnit_Severity(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	handler := &JUnitAssertionHandler{
+		Writer:    buf,
+		Formatter: newMockFormatter(t),
+		SuiteName: "suite",
+	}
+
+	ctx := &AssertionContext{
+		Path:        []string{"foo"},
+		AliasedPath: []string{"foo"},
+	}
+
+	handler.Success(ctx)
+	handler.Failure(ctx, &AssertionFailure{
+		Type:     AssertValid,
+		Severity: SeverityLog,
+		Errors:   []error{errors.New("ignored")},
+	})
+
+	require.NoError(t, handler.Flush())
+
+	var report junitXMLReport
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+
+	require.Equal(t, 1, len(report.Suites))
+	assert.Equal(t, "suite", report.Suites[0].Name)
+	require.Equal(t, 1, len(report.Suites[0].Cases))
+	assert.Equal(t, "foo", report.Suites[0].Cases[0].Name)
+	assert.Equal(t, 1, report.Suites[0].Cases[0].Assertions)
+	assert.Nil(t, report.Suites[0].Cases[0].Failure)
+}
+
+func TestAssertionJUnit_Close(t *testing.T) {
+	t.Run("closer", func(t *testing.T) {
+		writer := &junitTestCloser{}
+
+		handler := &JUnitAssertionHandler{
+			Writer: writer,
+		}
+
+		require.NoError(t, handler.Close())
+
+		assert.True(t, writer.closed)
+		assert.Contains(t, writer.String(), "<testsuites")
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		handler := &JUnitAssertionHandler{
+			Writer: &mockWriter{
+				Writer: &bytes.Buffer{},
+				err:    errors.New("write error"),
+			},
+		}
+
+		assert.Error(t, handler.Close())
+	})
+
+	t.Run("nil writer", func(t *testing.T) {
+		handler := &JUnitAssertionHandler{}
+
+		assert.Panics(t, func() {
+			_ = handler.Flush()
+		})
+	})
+}
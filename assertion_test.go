@@ -139,6 +139,70 @@ func TestAssertion_Handler(t *testing.T) {
 		assert.Nil(t, test.logger)
 		assert.True(t, test.reporter.reported)
 	})
+
+	t.Run("failure, severity warning", func(t *testing.T) {
+		test := createTest(t, true)
+
+		test.handler.Failure(
+			&AssertionContext{
+				TestName: t.Name(),
+			},
+			&AssertionFailure{
+				Type:     AssertValid,
+				Severity: SeverityWarning,
+			})
+
+		assert.Equal(t, 0, test.formatter.formattedSuccess)
+		assert.Equal(t, 1, test.formatter.formattedFailure)
+
+		assert.True(t, test.logger.logged)
+		assert.False(t, test.reporter.reported)
+	})
+
+	t.Run("failure, severity warning, no logger", func(t *testing.T) {
+		test := createTest(t, false)
+
+		test.handler.Failure(
+			&AssertionContext{
+				TestName: t.Name(),
+			},
+			&AssertionFailure{
+				Type:     AssertValid,
+				Severity: SeverityWarning,
+			})
+
+		assert.Equal(t, 0, test.formatter.formattedSuccess)
+		assert.Equal(t, 0, test.formatter.formattedFailure)
+
+		assert.Nil(t, test.logger)
+		assert.False(t, test.reporter.reported)
+	})
+
+	t.Run("failure, severity warning, reporter logger", func(t *testing.T) {
+		test := createTest(t, false)
+
+		logger := newMockLogger(t)
+
+		test.handler.Reporter = struct {
+			*mockReporter
+			*mockLogger
+		}{test.reporter, logger}
+
+		test.handler.Failure(
+			&AssertionContext{
+				TestName: t.Name(),
+			},
+			&AssertionFailure{
+				Type:     AssertValid,
+				Severity: SeverityWarning,
+			})
+
+		assert.Equal(t, 0, test.formatter.formattedSuccess)
+		assert.Equal(t, 1, test.formatter.formattedFailure)
+
+		assert.True(t, logger.logged)
+		assert.False(t, test.reporter.reported)
+	})
 }
 
 func TestAssertion_HandlerPanics(t *testing.T) {
@@ -0,0 +1,342 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWSSigV4_Sign(t *testing.T) {
+	// test vectors from AWS Signature Version 4 test suite
+	credentials := AWSCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+
+	now := func() time.Time {
+		return time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		name          string
+		method        string
+		url           string
+		header        http.Header
+		service       string
+		wantHeaders   string
+		wantSignature string
+	}{
+		{
+			name:          "get vanilla",
+			method:        "GET",
+			url:           "https://example.amazonaws.com/",
+			service:       "service",
+			wantHeaders:   "host;x-amz-date",
+			wantSignature: "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:          "get vanilla query order",
+			method:        "GET",
+			url:           "https://example.amazonaws.com/?Param2=value2&Param1=value1",
+			service:       "service",
+			wantHeaders:   "host;x-amz-date",
+			wantSignature: "b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500",
+		},
+		{
+			name:          "post vanilla",
+			method:        "POST",
+			url:           "https://example.amazonaws.com/",
+			service:       "service",
+			wantHeaders:   "host;x-amz-date",
+			wantSignature: "5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b",
+		},
+		{
+			name:   "iam list users",
+			method: "GET",
+			url:    "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08",
+			header: http.Header{
+				"Content-Type": {"application/x-www-form-urlencoded; charset=utf-8"},
+			},
+			service:       "iam",
+			wantHeaders:   "content-type;host;x-amz-date",
+			wantSignature: "5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.url, nil)
+			require.NoError(t, err)
+
+			for k, v := range tc.header {
+				req.Header[k] = v
+			}
+
+			signer := &awsSigner{
+				credentials: credentials,
+				region:      "us-east-1",
+				service:     tc.service,
+				now:         now,
+			}
+
+			require.NoError(t, signer.sign(req, nil))
+
+			assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+			assert.Equal(t,
+				"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/"+
+					tc.service+"/aws4_request, SignedHeaders="+tc.wantHeaders+
+					", Signature="+tc.wantSignature,
+				req.Header.Get("Authorization"))
+		})
+	}
+}
+
+func TestAWSSigV4_CanonicalQuery(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "empty",
+			query: "",
+			want:  "",
+		},
+		{
+			name:  "prefix keys",
+			query: "a1=2&a=1&b-c=1&b=2",
+			want:  "a=1&a1=2&b=2&b-c=1",
+		},
+		{
+			name:  "s3 list",
+			query: "list-type=2&list=x",
+			want:  "list=x&list-type=2",
+		},
+		{
+			name:  "repeated key",
+			query: "k=b&k=a&k1=c",
+			want:  "k=a&k=b&k1=c",
+		},
+		{
+			name:  "encoded",
+			query: "b=%20&a%2Bb=1",
+			want:  "a%2Bb=1&b=%20",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET",
+				"https://example.amazonaws.com/?"+tc.query, nil)
+			require.NoError(t, err)
+
+			signer := &awsSigner{service: "s3"}
+
+			assert.Equal(t, tc.want, signer.canonicalQuery(req.URL))
+		})
+	}
+}
+
+func TestAWSSigV4_Headers(t *testing.T) {
+	now := func() time.Time {
+		return time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC)
+	}
+
+	t.Run("session token", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "https://example.amazonaws.com/", nil)
+
+		signer := &awsSigner{
+			credentials: AWSCredentials{
+				AccessKeyID:     "AKIDEXAMPLE",
+				SecretAccessKey: "secret",
+				SessionToken:    "token",
+			},
+			region:  "us-east-1",
+			service: "service",
+			now:     now,
+		}
+
+		require.NoError(t, signer.sign(req, nil))
+
+		assert.Equal(t, "token", req.Header.Get("X-Amz-Security-Token"))
+		assert.Contains(t, req.Header.Get("Authorization"),
+			"SignedHeaders=host;x-amz-date;x-amz-security-token,")
+	})
+
+	t.Run("s3 payload hash", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", "https://bucket.s3.amazonaws.com/a b", nil)
+
+		body := newBodyWrapper(io.NopCloser(bytes.NewReader([]byte("hello"))), nil)
+
+		signer := &awsSigner{
+			credentials: AWSCredentials{
+				AccessKeyID:     "AKIDEXAMPLE",
+				SecretAccessKey: "secret",
+			},
+			region:  "us-east-1",
+			service: "s3",
+			now:     now,
+		}
+
+		require.NoError(t, signer.sign(req, body))
+
+		assert.Equal(t,
+			"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			req.Header.Get("X-Amz-Content-Sha256"))
+		assert.Contains(t, req.Header.Get("Authorization"),
+			"SignedHeaders=host;x-amz-content-sha256;x-amz-date,")
+
+		b, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(b))
+	})
+
+	t.Run("uri encoding", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "https://example.com/a b/c", nil)
+
+		s3 := &awsSigner{service: "s3"}
+		assert.Equal(t, "/a%20b/c", s3.canonicalURI(req.URL))
+
+		other := &awsSigner{service: "service"}
+		assert.Equal(t, "/a%2520b/c", other.canonicalURI(req.URL))
+
+		req, _ = http.NewRequest("GET", "https://example.com", nil)
+		assert.Equal(t, "/", other.canonicalURI(req.URL))
+	})
+}
+
+func TestAWSSigV4_Request(t *testing.T) {
+	credentials := AWSCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+
+	t.Run("sign", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = client
+
+		req := NewRequestC(config, "POST", "/path").
+			WithAWSSigV4(credentials, "us-east-1", "s3").
+			WithQuery("a", "b").
+			WithText("hello")
+		req.chain.assert(t, success)
+
+		req.Expect().chain.assert(t, success)
+
+		require.NotNil(t, client.req)
+		assert.True(t, strings.HasPrefix(client.req.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"))
+		assert.Equal(t,
+			"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			client.req.Header.Get("X-Amz-Content-Sha256"))
+
+		b, err := io.ReadAll(client.req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(b))
+	})
+
+	t.Run("clock", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = client
+		config.Clock = NewFakeClock(
+			time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC))
+
+		sign := func() string {
+			NewRequestC(config, "GET", "/path").
+				WithAWSSigV4(credentials, "us-east-1", "service").
+				Expect().
+				chain.assert(t, success)
+
+			require.NotNil(t, client.req)
+			assert.Equal(t, "20150830T123600Z", client.req.Header.Get("X-Amz-Date"))
+
+			return client.req.Header.Get("Authorization")
+		}
+
+		assert.Equal(t, sign(), sign())
+	})
+
+	t.Run("resign on retry", func(t *testing.T) {
+		var auths []string
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &mockClient{
+			err: errors.New("test error"),
+			cb: func(req *http.Request) {
+				auths = append(auths, req.Header.Get("Authorization"))
+			},
+		}
+
+		req := NewRequestC(config, "GET", "/path").
+			WithAWSSigV4(credentials, "us-east-1", "service").
+			WithRetryPolicy(RetryAllErrors).
+			WithMaxRetries(2).
+			WithRetryDelay(0, 0)
+
+		signs := 0
+		req.signers[0].(*awsSigner).now = func() time.Time {
+			signs++
+			return time.Date(2015, time.August, 30, 12, 36, signs, 0, time.UTC)
+		}
+
+		req.Expect().chain.assert(t, failure)
+
+		assert.Equal(t, 3, signs)
+		require.Equal(t, 3, len(auths))
+		assert.NotEqual(t, auths[0], auths[1])
+		assert.NotEqual(t, auths[1], auths[2])
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		cases := []struct {
+			name        string
+			credentials AWSCredentials
+			region      string
+			service     string
+		}{
+			{
+				name:        "empty access key id",
+				credentials: AWSCredentials{SecretAccessKey: "secret"},
+				region:      "us-east-1",
+				service:     "s3",
+			},
+			{
+				name:        "empty secret access key",
+				credentials: AWSCredentials{AccessKeyID: "id"},
+				region:      "us-east-1",
+				service:     "s3",
+			},
+			{
+				name:        "empty region",
+				credentials: credentials,
+				service:     "s3",
+			},
+			{
+				name:        "empty service",
+				credentials: credentials,
+				region:      "us-east-1",
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				config := newMockConfig(newMockReporter(t))
+
+				req := NewRequestC(config, "GET", "/").
+					WithAWSSigV4(tc.credentials, tc.region, tc.service)
+				req.chain.assert(t, failure)
+			})
+		}
+	})
+}
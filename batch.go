@@ -0,0 +1,321 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Batch is a group of prepared requests that are sent concurrently.
+//
+// Requests are added to the batch with names, which are used later
+// to retrieve corresponding responses. Batch sends requests using a
+// bounded pool of workers and waits until all responses are received.
+//
+// If any request in batch fails, batch is marked as failed too.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	resps := e.Batch().
+//		WithConcurrency(2).
+//		Add("first", e.GET("/first")).
+//		Add("second", e.GET("/second")).
+//		Expect()
+//
+//	resps["first"].Status(http.StatusOK)
+//	resps["second"].Status(http.StatusOK)
+type Batch struct {
+	noCopy noCopy
+	chain  *chain
+
+	mu sync.Mutex
+
+	names       []string
+	requests    map[string]*Request
+	concurrency int
+
+	expectCalled bool
+}
+
+// NewBatch returns a new Batch instance.
+//
+// If reporter is nil, the function panics.
+//
+// Example:
+//
+//	batch := NewBatch(t)
+//	batch.Add("first", req1)
+//	batch.Add("second", req2)
+//	resps := batch.Expect()
+func NewBatch(reporter Reporter) *Batch {
+	return newBatch(newChainWithDefaults("Batch()", reporter))
+}
+
+// NewBatchC returns a new Batch instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+//
+// Example:
+//
+//	batch := NewBatchC(config)
+func NewBatchC(config Config) *Batch {
+	return newBatch(newChainWithConfig("Batch()", config.withDefaults()))
+}
+
+func newBatch(parent *chain) *Batch {
+	return &Batch{
+		chain:    parent.clone(),
+		requests: make(map[string]*Request),
+	}
+}
+
+// Batch returns a new Batch instance.
+// Requests created by this Expect instance can be added to the batch
+// and sent concurrently.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	resps := e.Batch().
+//		Add("a", e.PUT("/item").WithJSON(item)).
+//		Add("b", e.PUT("/item").WithJSON(item)).
+//		Expect()
+func (e *Expect) Batch() *Batch {
+	opChain := e.chain.enter("Batch()")
+	defer opChain.leave()
+
+	return newBatch(opChain)
+}
+
+// Alias is similar to Value.Alias.
+func (b *Batch) Alias(name string) *Batch {
+	opChain := b.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.chain.setAlias(name)
+	return b
+}
+
+// WithConcurrency sets maximum number of requests sent simultaneously.
+//
+// If concurrency is zero (default), all requests are sent simultaneously.
+//
+// Example:
+//
+//	batch := NewBatch(t)
+//	batch.WithConcurrency(4)
+func (b *Batch) WithConcurrency(concurrency int) *Batch {
+	opChain := b.chain.enter("WithConcurrency()")
+	defer opChain.leave()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if !b.checkOrder(opChain, "WithConcurrency()") {
+		return b
+	}
+
+	if concurrency < 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{concurrency},
+			Errors: []error{
+				errors.New("invalid negative argument"),
+			},
+		})
+		return b
+	}
+
+	b.concurrency = concurrency
+
+	return b
+}
+
+// Add adds request to the batch under given name.
+//
+// Name is used as a key in map returned by Expect. If request with
+// the same name was already added, failure is reported.
+//
+// Example:
+//
+//	batch := NewBatch(t)
+//	batch.Add("login", e.POST("/login").WithForm(creds))
+func (b *Batch) Add(name string, req *Request) *Batch {
+	opChain := b.chain.enter("Add(%q)", name)
+	defer opChain.leave()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if !b.checkOrder(opChain, "Add()") {
+		return b
+	}
+
+	if req == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return b
+	}
+
+	if _, ok := b.requests[name]; ok {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("duplicate request name %q", name),
+			},
+		})
+		return b
+	}
+
+	b.names = append(b.names, name)
+	b.requests[name] = req
+
+	return b
+}
+
+// Expect sends all requests added to the batch and waits until all
+// responses are received.
+//
+// Returned map contains response for every added request, keyed by
+// request name. If some request fails, corresponding response is
+// marked as failed, and batch reports failure listing names of all
+// failed requests.
+//
+// After calling Expect, there should not be any more calls of Expect or
+// other methods on the same Batch instance.
+//
+// Example:
+//
+//	resps := batch.Expect()
+//	resps["login"].Status(http.StatusOK)
+func (b *Batch) Expect() map[string]*Response {
+	opChain := b.chain.enter("Expect()")
+	defer opChain.leave()
+
+	if !b.prepare(opChain) {
+		return b.emptyResponses(opChain)
+	}
+
+	workers := b.concurrency
+	if workers == 0 || workers > len(b.names) {
+		workers = len(b.names)
+	}
+
+	var (
+		resps   = make(map[string]*Response, len(b.names))
+		respsMu sync.Mutex
+		queue   = make(chan string, len(b.names))
+		wg      sync.WaitGroup
+	)
+
+	for _, name := range b.names {
+		queue <- name
+	}
+	close(queue)
+
+	for n := 0; n < workers; n++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for name := range queue {
+				resp := b.requests[name].Expect()
+
+				respsMu.Lock()
+				resps[name] = resp
+				respsMu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	var failedNames []string
+	for _, name := range b.names {
+		if b.requests[name].chain.treeFailed() {
+			failedNames = append(failedNames, name)
+		}
+	}
+
+	if len(failedNames) != 0 {
+		errs := []error{
+			fmt.Errorf("%d of %d batch requests failed",
+				len(failedNames), len(b.names)),
+		}
+		for _, name := range failedNames {
+			errs = append(errs, fmt.Errorf("request %q failed", name))
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:   AssertOperation,
+			Errors: errs,
+		})
+	}
+
+	return resps
+}
+
+func (b *Batch) prepare(opChain *chain) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if opChain.failed() {
+		return false
+	}
+
+	if !b.checkOrder(opChain, "Expect()") {
+		return false
+	}
+
+	b.expectCalled = true
+
+	return true
+}
+
+func (b *Batch) emptyResponses(opChain *chain) map[string]*Response {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	resps := make(map[string]*Response, len(b.names))
+
+	for _, name := range b.names {
+		resps[name] = newResponse(responseOpts{
+			config: b.requests[name].config,
+			chain:  opChain,
+		})
+	}
+
+	return resps
+}
+
+func (b *Batch) checkOrder(opChain *chain, funcCall string) bool {
+	if b.expectCalled {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected call to %s: Expect() has already been called", funcCall),
+			},
+		})
+		return false
+	}
+	return true
+}
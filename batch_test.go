@@ -0,0 +1,124 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch_Basic(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: ClientFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			paths = append(paths, req.URL.Path)
+			mu.Unlock()
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       http.NoBody,
+			}, nil
+		}),
+	})
+
+	batch := e.Batch().
+		WithConcurrency(2).
+		Add("a", e.GET("/a")).
+		Add("b", e.GET("/b")).
+		Add("c", e.GET("/c"))
+
+	resps := batch.Expect()
+
+	assert.Equal(t, 3, len(resps))
+	assert.ElementsMatch(t, []string{"/a", "/b", "/c"}, paths)
+
+	for _, name := range []string{"a", "b", "c"} {
+		resps[name].Status(http.StatusOK)
+		resps[name].chain.assert(t, success)
+	}
+
+	batch.chain.assert(t, success)
+	assert.False(t, reporter.reported)
+}
+
+func TestBatch_Failure(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		Reporter: reporter,
+		Client: ClientFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/bad" {
+				return nil, errors.New("test error")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       http.NoBody,
+			}, nil
+		}),
+	})
+
+	batch := e.Batch().
+		Add("good", e.GET("/good")).
+		Add("bad", e.GET("/bad"))
+
+	resps := batch.Expect()
+
+	assert.Equal(t, 2, len(resps))
+
+	resps["good"].chain.assert(t, success)
+	resps["bad"].chain.assert(t, failure)
+
+	batch.chain.assert(t, failure)
+	assert.True(t, reporter.reported)
+}
+
+func TestBatch_Usage(t *testing.T) {
+	t.Run("nil request", func(t *testing.T) {
+		batch := NewBatch(newMockReporter(t))
+
+		batch.Add("a", nil)
+		batch.chain.assert(t, failure)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		batch := NewBatchC(config)
+
+		batch.Add("a", NewRequestC(config, "GET", "/"))
+		batch.chain.assert(t, success)
+
+		batch.Add("a", NewRequestC(config, "GET", "/"))
+		batch.chain.assert(t, failure)
+	})
+
+	t.Run("negative concurrency", func(t *testing.T) {
+		batch := NewBatch(newMockReporter(t))
+
+		batch.WithConcurrency(-1)
+		batch.chain.assert(t, failure)
+	})
+
+	t.Run("add after expect", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		batch := NewBatchC(config)
+
+		batch.Expect()
+		batch.chain.assert(t, success)
+
+		batch.Add("a", NewRequestC(config, "GET", "/"))
+		batch.chain.assert(t, failure)
+	})
+}
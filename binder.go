@@ -3,6 +3,7 @@ package httpexpect
 import (
 	"bytes"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -90,6 +91,15 @@ func (binder Binder) RoundTrip(origReq *http.Request) (*http.Response, error) {
 // FastBinder emulates network communication by invoking given fasthttp.RequestHandler
 // directly. It converts http.Request to fasthttp.Request, invokes handler, and then
 // converts fasthttp.Response to http.Response.
+//
+// If handler sets body stream (e.g. using SetBodyStream or SetBodyStreamWriter),
+// response body is not buffered: it's read from the stream while the client
+// reads http.Response body.
+//
+// FastBinder doesn't support connection hijacking. If handler hijacks
+// connection (e.g. to upgrade it to websocket), RoundTrip returns an error,
+// which is reported as AssertUsage failure. To test websockets, use
+// Request.WithWebsocketUpgrade, which dials handler using NewFastWebsocketDialer.
 type FastBinder struct {
 	// FastHTTP handler invoked for every request.
 	Handler fasthttp.RequestHandler
@@ -99,6 +109,11 @@ type FastBinder struct {
 	Logger Logger
 }
 
+// Returned by FastBinder when handler hijacks connection.
+var errFastBinderHijack = errors.New(
+	"FastBinder doesn't support connection hijacking (e.g. websocket upgrade);" +
+		" use WithWebsocketUpgrade() for websocket requests")
+
 // NewFastBinder returns a new FastBinder given a fasthttp.RequestHandler.
 //
 // Example:
@@ -154,8 +169,25 @@ func (binder FastBinder) RoundTrip(stdreq *http.Request) (*http.Response, error)
 		}
 	}
 
+	// trailers are available only after body is read
+	if stdreq.ContentLength < 0 {
+		for k, a := range stdreq.Trailer {
+			if err := ctx.Request.Header.AddTrailer(k); err != nil {
+				continue
+			}
+			for _, v := range a {
+				ctx.Request.Header.Add(k, v)
+			}
+		}
+	}
+
 	binder.Handler(&ctx)
 
+	if ctx.Hijacked() {
+		ctx.Response.ResetBody()
+		return nil, errFastBinderHijack
+	}
+
 	return fast2std(stdreq, &ctx.Response), nil
 }
 
@@ -194,31 +226,99 @@ func std2fast(stdreq *http.Request) *fasthttp.Request {
 
 func fast2std(stdreq *http.Request, fastresp *fasthttp.Response) *http.Response {
 	status := fastresp.Header.StatusCode()
-	body := fastresp.Body()
+
+	statusText := string(fastresp.Header.StatusMessage())
+	if statusText == "" {
+		statusText = http.StatusText(status)
+	}
+
+	proto := string(fastresp.Header.Protocol())
+	protoMajor, protoMinor, ok := http.ParseHTTPVersion(proto)
+	if !ok {
+		proto, protoMajor, protoMinor = "HTTP/1.1", 1, 1
+	}
+
+	// body stream, if any, is not read here, see below
+	isStream := fastresp.IsBodyStream()
+
+	var body []byte
+	if !isStream {
+		body = fastresp.Body()
+	}
 
 	stdresp := &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, statusText),
 		StatusCode: status,
-		Status:     http.StatusText(status),
+		Proto:      proto,
+		ProtoMajor: protoMajor,
+		ProtoMinor: protoMinor,
+		Header:     make(http.Header),
+		Close:      fastresp.ConnectionClose(),
 		Request:    stdreq,
 	}
 
+	trailers := make(map[string]bool)
+
+	fastresp.Header.VisitAllTrailer(func(k []byte) {
+		trailers[http.CanonicalHeaderKey(string(k))] = true
+	})
+
+	// like net/http, keep transfer encoding and trailers out of header
 	fastresp.Header.VisitAll(func(k, v []byte) {
-		sk := string(k)
+		sk := http.CanonicalHeaderKey(string(k))
 		sv := string(v)
-		if stdresp.Header == nil {
-			stdresp.Header = make(http.Header)
+
+		switch {
+		case sk == "Transfer-Encoding" || sk == "Trailer":
+			return
+
+		case trailers[sk]:
+			if stdresp.Trailer == nil {
+				stdresp.Trailer = make(http.Header)
+			}
+			stdresp.Trailer.Add(sk, sv)
+
+		default:
+			stdresp.Header.Add(sk, sv)
 		}
-		stdresp.Header.Add(sk, sv)
 	})
 
-	if fastresp.Header.ContentLength() >= 0 {
-		stdresp.ContentLength = int64(fastresp.Header.ContentLength())
-	} else {
+	switch contentLength := fastresp.Header.ContentLength(); {
+	case contentLength == -1:
 		stdresp.ContentLength = -1
 		stdresp.TransferEncoding = []string{"chunked"}
+
+	case contentLength < 0:
+		// identity body, terminated by connection close
+		stdresp.ContentLength = -1
+
+	case isStream:
+		stdresp.ContentLength = int64(contentLength)
+
+	default:
+		// header is updated by fasthttp only when response is written
+		stdresp.ContentLength = int64(len(body))
+	}
+
+	// like real server, don't send body in response to HEAD
+	if stdreq != nil && stdreq.Method == http.MethodHead {
+		if isStream {
+			fastresp.ResetBody()
+		}
+		body, isStream = nil, false
 	}
 
-	if body != nil {
+	if isStream {
+		// stream is read on demand, while client reads response body;
+		// if client closes body, stream is closed too
+		reader, writer := io.Pipe()
+
+		go func() {
+			_ = writer.CloseWithError(fastresp.BodyWriteTo(writer))
+		}()
+
+		stdresp.Body = reader
+	} else if body != nil {
 		stdresp.Body = io.NopCloser(bytes.NewReader(body))
 	} else {
 		stdresp.Body = io.NopCloser(bytes.NewReader(nil))
@@ -3,12 +3,16 @@ package httpexpect
 import (
 	"bufio"
 	"crypto/tls"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 )
 
@@ -457,6 +461,363 @@ func TestFastBinder_Chunked(t *testing.T) {
 	assert.Equal(t, []string{"chunked"}, resp.TransferEncoding)
 }
 
+func TestFastBinder_Stream(t *testing.T) {
+	cases := []struct {
+		name                   string
+		handler                fasthttp.RequestHandler
+		expectContentLength    int64
+		expectTransferEncoding []string
+	}{
+		{
+			name: "fixed body",
+			handler: func(ctx *fasthttp.RequestCtx) {
+				ctx.SetBodyString("hello world")
+			},
+			expectContentLength:    11,
+			expectTransferEncoding: nil,
+		},
+		{
+			name: "stream with size",
+			handler: func(ctx *fasthttp.RequestCtx) {
+				ctx.SetBodyStream(strings.NewReader("hello world"), 11)
+			},
+			expectContentLength:    11,
+			expectTransferEncoding: nil,
+		},
+		{
+			name: "stream without size",
+			handler: func(ctx *fasthttp.RequestCtx) {
+				ctx.SetBodyStream(strings.NewReader("hello world"), -1)
+			},
+			expectContentLength:    -1,
+			expectTransferEncoding: []string{"chunked"},
+		},
+		{
+			name: "stream writer",
+			handler: func(ctx *fasthttp.RequestCtx) {
+				ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+					_, _ = w.WriteString("hello ")
+					_ = w.Flush()
+					_, _ = w.WriteString("world")
+				})
+			},
+			expectContentLength:    -1,
+			expectTransferEncoding: []string{"chunked"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &http.Client{
+				Transport: NewFastBinder(tc.handler),
+			}
+
+			req, err := http.NewRequest("GET", "http://example.com/path", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, "hello world", string(b))
+
+			assert.Equal(t, tc.expectContentLength, resp.ContentLength)
+			assert.Equal(t, tc.expectTransferEncoding, resp.TransferEncoding)
+			assert.Empty(t, resp.Header.Get("Transfer-Encoding"))
+		})
+	}
+}
+
+func TestFastBinder_StreamNotBuffered(t *testing.T) {
+	t.Run("read", func(t *testing.T) {
+		release := make(chan struct{})
+
+		handler := func(ctx *fasthttp.RequestCtx) {
+			ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+				_, _ = w.WriteString("hello ")
+				_ = w.Flush()
+				<-release
+				_, _ = w.WriteString("world")
+			})
+		}
+
+		client := &http.Client{
+			Transport: NewFastBinder(handler),
+		}
+
+		req, err := http.NewRequest("GET", "http://example.com/path", nil)
+		require.NoError(t, err)
+
+		// returns before stream is finished
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+
+		b := make([]byte, 6)
+		_, err = io.ReadFull(resp.Body, b)
+		require.NoError(t, err)
+		assert.Equal(t, "hello ", string(b))
+
+		close(release)
+
+		b, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "world", string(b))
+
+		assert.NoError(t, resp.Body.Close())
+	})
+
+	t.Run("close", func(t *testing.T) {
+		done := make(chan struct{})
+
+		handler := func(ctx *fasthttp.RequestCtx) {
+			ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+				defer close(done)
+				for {
+					if _, err := w.WriteString("hello"); err != nil {
+						return
+					}
+					if err := w.Flush(); err != nil {
+						return
+					}
+				}
+			})
+		}
+
+		client := &http.Client{
+			Transport: NewFastBinder(handler),
+		}
+
+		req, err := http.NewRequest("GET", "http://example.com/path", nil)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+
+		b := make([]byte, 5)
+		_, err = io.ReadFull(resp.Body, b)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(b))
+
+		// closing body stops endless stream
+		assert.NoError(t, resp.Body.Close())
+
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatal("stream writer not stopped after body was closed")
+		}
+	})
+}
+
+func TestFastBinder_Hijack(t *testing.T) {
+	handler := func(ctx *fasthttp.RequestCtx) {
+		ctx.SetStatusCode(http.StatusSwitchingProtocols)
+		ctx.Hijack(func(net.Conn) {})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "http://example.com/path", nil)
+		require.NoError(t, err)
+
+		resp, err := NewFastBinder(handler).RoundTrip(req)
+		assert.Nil(t, resp)
+		assert.True(t, errors.Is(err, errFastBinderHijack))
+	})
+
+	t.Run("request", func(t *testing.T) {
+		assertionHandler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          "http://example.com",
+			AssertionHandler: assertionHandler,
+			Client: &http.Client{
+				Transport: NewFastBinder(handler),
+			},
+		})
+
+		e.GET("/path").
+			Expect().
+			chain.assert(t, failure)
+
+		require.NotNil(t, assertionHandler.failure)
+		assert.Equal(t, AssertUsage, assertionHandler.failure.Type)
+	})
+}
+
+func TestFastBinder_Status(t *testing.T) {
+	cases := []struct {
+		name         string
+		handler      fasthttp.RequestHandler
+		expectStatus string
+	}{
+		{
+			name: "default message",
+			handler: func(ctx *fasthttp.RequestCtx) {
+				ctx.SetStatusCode(http.StatusCreated)
+			},
+			expectStatus: "201 Created",
+		},
+		{
+			name: "custom message",
+			handler: func(ctx *fasthttp.RequestCtx) {
+				ctx.SetStatusCode(http.StatusOK)
+				ctx.Response.Header.SetStatusMessage([]byte("Fine"))
+			},
+			expectStatus: "200 Fine",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &http.Client{
+				Transport: NewFastBinder(tc.handler),
+			}
+
+			req, err := http.NewRequest("GET", "http://example.com/path", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.expectStatus, resp.Status)
+			assert.Equal(t, "HTTP/1.1", resp.Proto)
+			assert.Equal(t, 1, resp.ProtoMajor)
+			assert.Equal(t, 1, resp.ProtoMinor)
+		})
+	}
+}
+
+func TestFastBinder_Cookies(t *testing.T) {
+	handler := func(ctx *fasthttp.RequestCtx) {
+		assert.Equal(t, "1", string(ctx.Request.Header.Cookie("foo")))
+		assert.Equal(t, "2", string(ctx.Request.Header.Cookie("bar")))
+
+		cookie := fasthttp.AcquireCookie()
+		defer fasthttp.ReleaseCookie(cookie)
+
+		cookie.SetKey("b")
+		cookie.SetValue("1")
+		cookie.SetPath("/")
+		ctx.Response.Header.SetCookie(cookie)
+
+		cookie.SetKey("a")
+		cookie.SetValue("2")
+		cookie.SetPath("/")
+		ctx.Response.Header.SetCookie(cookie)
+
+		ctx.Response.Header.Add("Set-Cookie", "c=3")
+	}
+
+	client := &http.Client{
+		Transport: NewFastBinder(handler),
+	}
+
+	req, err := http.NewRequest("GET", "http://example.com/path", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.AddCookie(&http.Cookie{Name: "foo", Value: "1"})
+	req.AddCookie(&http.Cookie{Name: "bar", Value: "2"})
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t,
+		[]string{"b=1; path=/", "a=2; path=/", "c=3"},
+		resp.Header.Values("Set-Cookie"))
+
+	cookies := resp.Cookies()
+	if assert.Equal(t, 3, len(cookies)) {
+		assert.Equal(t, "b", cookies[0].Name)
+		assert.Equal(t, "a", cookies[1].Name)
+		assert.Equal(t, "c", cookies[2].Name)
+	}
+}
+
+func TestFastBinder_Trailers(t *testing.T) {
+	handler := func(ctx *fasthttp.RequestCtx) {
+		assert.Equal(t, "foo=bar", string(ctx.Request.Body()))
+		assert.Equal(t, "123", string(ctx.Request.Header.Peek("X-Request-Sum")))
+
+		_ = ctx.Response.Header.AddTrailer("X-Response-Sum")
+		ctx.Response.Header.Set("X-Response-Sum", "456")
+		ctx.Response.Header.Set("X-Other", "foo")
+
+		ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+			_, _ = w.WriteString("hello")
+		})
+	}
+
+	client := &http.Client{
+		Transport: NewFastBinder(handler),
+	}
+
+	req, err := http.NewRequest(
+		"POST", "http://example.com/path", strings.NewReader("foo=bar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.ContentLength = -1
+	req.Trailer = http.Header{
+		"X-Request-Sum": {"123"},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, http.Header{"X-Response-Sum": {"456"}}, resp.Trailer)
+
+	assert.Equal(t, "foo", resp.Header.Get("X-Other"))
+	assert.Empty(t, resp.Header.Get("X-Response-Sum"))
+	assert.Empty(t, resp.Header.Get("Trailer"))
+}
+
+func TestFastBinder_Head(t *testing.T) {
+	handler := func(ctx *fasthttp.RequestCtx) {
+		ctx.SetBodyString("hello")
+	}
+
+	client := &http.Client{
+		Transport: NewFastBinder(handler),
+	}
+
+	req, err := http.NewRequest("HEAD", "http://example.com/path", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "", string(b))
+	assert.Equal(t, int64(5), resp.ContentLength)
+}
+
 func TestFastBinder_EmptyResponse(t *testing.T) {
 	handler := func(*fasthttp.RequestCtx) {}
 
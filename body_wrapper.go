@@ -29,6 +29,11 @@ import (
 // functionality is disabled, memory cache is cleared, and bodyWrapper switches to
 // reading original body (if it's not fully read yet).
 //
+// If maximum size is set using SetMaxSize, and body exceeds it, bodyWrapper stops
+// storing body in memory and behaves like if DisableRewinds was called. Current
+// read continues from original body, but subsequent Rewind and GetBody calls will
+// make reading fail with errBodyTooLarge.
+//
 // bodyWrapper automatically creates finalizer that will close original body if the
 // user never reads it fully or calls Closes.
 type bodyWrapper struct {
@@ -62,8 +67,17 @@ type bodyWrapper struct {
 
 	// True means that a read operation of any type was called at least once.
 	isReadBefore bool
+
+	// Maximum number of bytes stored in memory; zero means no limit.
+	maxSize int64
+
+	// True means that body exceeded maxSize and memory cache was dropped.
+	isTooLarge bool
 }
 
+// Returned when body can't be re-read because it exceeded maximum size.
+var errBodyTooLarge = errors.New("body exceeds maximum buffered size")
+
 func newBodyWrapper(reader io.ReadCloser, cancelFunc context.CancelFunc) *bodyWrapper {
 	bw := &bodyWrapper{
 		httpReader:     reader,
@@ -105,10 +119,12 @@ func (bw *bodyWrapper) Close() error {
 
 	// Rewind or GetBody may be called later, so be sure to
 	// read body into memory before closing.
+	// If body is too large, it is just discarded; Rewind and GetBody
+	// will report error later.
 	if !bw.isRewindDisabled && !bw.isFullyRead {
 		bw.isReadBefore = true
 
-		if readErr := bw.httpReadFull(); readErr != nil {
+		if readErr := bw.httpReadFull(); readErr != nil && readErr != errBodyTooLarge {
 			err = readErr
 		}
 	}
@@ -135,13 +151,19 @@ func (bw *bodyWrapper) Rewind() {
 	bw.mu.Lock()
 	defer bw.mu.Unlock()
 
-	// Rewind is no-op if disabled.
-	if bw.isRewindDisabled {
+	// Rewind is no-op until first read operation.
+	if !bw.isReadBefore {
 		return
 	}
 
-	// Rewind is no-op until first read operation.
-	if !bw.isReadBefore {
+	// Body was dropped from memory, so subsequent reads should fail.
+	if bw.isTooLarge {
+		bw.failTooLarge()
+		return
+	}
+
+	// Rewind is no-op if disabled.
+	if bw.isRewindDisabled {
 		return
 	}
 
@@ -169,6 +191,11 @@ func (bw *bodyWrapper) GetBody() (io.ReadCloser, error) {
 		return nil, bw.readErr
 	}
 
+	// Body was dropped from memory.
+	if bw.isTooLarge {
+		return nil, errBodyTooLarge
+	}
+
 	// GetBody() requires rewinds to be enabled.
 	if bw.isRewindDisabled {
 		return nil, errors.New("rewinds are disabled, cannot get body")
@@ -202,6 +229,26 @@ func (bw *bodyWrapper) DisableRewinds() {
 	bw.isRewindDisabled = true
 }
 
+// Sets maximum number of bytes stored in memory; zero means no limit.
+// If body already exceeded the limit, memory cache is cleared.
+func (bw *bodyWrapper) SetMaxSize(maxSize int64) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	bw.maxSize = maxSize
+
+	if bw.isTooLarge || !bw.isExceeded(0) {
+		return
+	}
+
+	bw.dropMemory()
+
+	// If body was fully read into memory, nothing left to read from.
+	if bw.isFullyRead {
+		bw.failTooLarge()
+	}
+}
+
 func (bw *bodyWrapper) memReadNext(p []byte) (int, error) {
 	n, err := bw.memReader.Read(p)
 
@@ -224,7 +271,16 @@ func (bw *bodyWrapper) httpReadNext(p []byte) (int, error) {
 	n, err := bw.httpReader.Read(p)
 
 	if n > 0 {
-		bw.memBytes = append(bw.memBytes, p[:n]...)
+		if bw.isTooLarge || bw.isExceeded(n) {
+			// Continue reading from original HTTP response without
+			// storing it into memory. Once memory was dropped, never
+			// append to it again, even if next chunk is small.
+			if !bw.isTooLarge {
+				bw.dropMemory()
+			}
+		} else {
+			bw.memBytes = append(bw.memBytes, p[:n]...)
+		}
 	}
 
 	if err != nil {
@@ -244,7 +300,19 @@ func (bw *bodyWrapper) httpReadNext(p []byte) (int, error) {
 }
 
 func (bw *bodyWrapper) httpReadFull() error {
-	b, err := io.ReadAll(bw.httpReader)
+	var reader io.Reader = bw.httpReader
+	if bw.maxSize > 0 {
+		// Read at most one byte more than allowed to detect overflow.
+		reader = io.LimitReader(reader, bw.maxSize-int64(len(bw.memBytes))+1)
+	}
+
+	b, err := io.ReadAll(reader)
+
+	if err == nil && bw.isExceeded(len(b)) {
+		bw.dropMemory()
+		bw.failTooLarge()
+		return errBodyTooLarge
+	}
 
 	// Switch to reading from memory.
 	bw.isFullyRead = true
@@ -262,6 +330,26 @@ func (bw *bodyWrapper) httpReadFull() error {
 	return err
 }
 
+func (bw *bodyWrapper) isExceeded(n int) bool {
+	return bw.maxSize > 0 && int64(len(bw.memBytes)+n) > bw.maxSize
+}
+
+func (bw *bodyWrapper) dropMemory() {
+	bw.memReader = bytes.NewReader(nil)
+	bw.memBytes = nil
+	bw.isRewindDisabled = true
+	bw.isTooLarge = true
+}
+
+// Close original body and make subsequent reads return errBodyTooLarge.
+func (bw *bodyWrapper) failTooLarge() {
+	_ = bw.closeAndCancel()
+
+	bw.readErr = errBodyTooLarge
+	bw.isFullyRead = true
+	bw.memReader = bytes.NewReader(nil)
+}
+
 func (bw *bodyWrapper) closeAndCancel() error {
 	if bw.httpReader == nil && bw.httpCancelFunc == nil {
 		return bw.closeErr
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBodyWrapper_Close(t *testing.T) {
@@ -1456,3 +1457,170 @@ func TestBodyWrapper_DisableRewindsErrors(t *testing.T) {
 		assert.Equal(t, 2, body.readCount)
 	})
 }
+
+func TestBodyWrapper_MaxSize(t *testing.T) {
+	bodyText := "test_body"
+
+	t.Run("below limit", func(t *testing.T) {
+		body := newMockBody(bodyText)
+		wrp := newBodyWrapper(body, nil)
+		wrp.SetMaxSize(int64(len(bodyText)))
+
+		b, err := io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, bodyText, string(b))
+		assert.False(t, wrp.isTooLarge)
+
+		wrp.Rewind()
+
+		b, err = io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, bodyText, string(b))
+
+		rd, err := wrp.GetBody()
+		assert.NoError(t, err)
+
+		b, err = io.ReadAll(rd)
+		assert.NoError(t, err)
+		assert.Equal(t, bodyText, string(b))
+	})
+
+	t.Run("above limit, read", func(t *testing.T) {
+		body := newMockBody(bodyText)
+		wrp := newBodyWrapper(body, nil)
+		wrp.SetMaxSize(4)
+
+		// first read returns whole body
+		b, err := io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, bodyText, string(b))
+
+		assert.True(t, wrp.isTooLarge)
+		assert.True(t, wrp.isRewindDisabled)
+		assert.Nil(t, wrp.memBytes)
+
+		// subsequent reads fail
+		wrp.Rewind()
+
+		_, err = io.ReadAll(wrp)
+		assert.Equal(t, errBodyTooLarge, err)
+
+		_, err = wrp.GetBody()
+		assert.Equal(t, errBodyTooLarge, err)
+
+		assert.NoError(t, wrp.Close())
+		assert.Equal(t, 1, body.closeCount)
+	})
+
+	t.Run("above limit, close", func(t *testing.T) {
+		body := newMockBody(bodyText)
+		wrp := newBodyWrapper(body, nil)
+		wrp.SetMaxSize(4)
+
+		// body is discarded without error
+		err := wrp.Close()
+		assert.NoError(t, err)
+		assert.True(t, wrp.isTooLarge)
+		assert.Nil(t, wrp.memBytes)
+		assert.Equal(t, 1, body.closeCount)
+
+		_, err = wrp.GetBody()
+		assert.Equal(t, errBodyTooLarge, err)
+
+		wrp.Rewind()
+
+		_, err = io.ReadAll(wrp)
+		assert.Equal(t, errBodyTooLarge, err)
+	})
+
+	t.Run("above limit, get body", func(t *testing.T) {
+		body := newMockBody(bodyText)
+		wrp := newBodyWrapper(body, nil)
+		wrp.SetMaxSize(4)
+
+		_, err := wrp.GetBody()
+		assert.Equal(t, errBodyTooLarge, err)
+		assert.Equal(t, 1, body.closeCount)
+	})
+
+	t.Run("above limit, small reads", func(t *testing.T) {
+		body := newMockBody(bodyText)
+		wrp := newBodyWrapper(body, nil)
+		wrp.SetMaxSize(4)
+
+		var b []byte
+		p := make([]byte, 3)
+
+		for {
+			n, err := wrp.Read(p)
+			b = append(b, p[:n]...)
+
+			if len(b) > 4 {
+				assert.True(t, wrp.isTooLarge)
+				assert.Nil(t, wrp.memBytes)
+			} else {
+				assert.False(t, wrp.isTooLarge)
+				assert.Equal(t, b, wrp.memBytes)
+			}
+
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, bodyText, string(b))
+
+		wrp.Rewind()
+
+		_, err := io.ReadAll(wrp)
+		assert.Equal(t, errBodyTooLarge, err)
+	})
+
+	t.Run("above limit, small reads from http", func(t *testing.T) {
+		body := newMockBody(bodyText)
+		wrp := newBodyWrapper(body, nil)
+		wrp.SetMaxSize(4)
+
+		var b []byte
+		p := make([]byte, 3)
+
+		// memory is never used again once dropped, even if
+		// next chunks fit into the limit
+		for {
+			n, err := wrp.httpReadNext(p)
+			b = append(b, p[:n]...)
+
+			if len(b) > 4 {
+				assert.True(t, wrp.isTooLarge)
+				assert.Nil(t, wrp.memBytes)
+			}
+
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, bodyText, string(b))
+		assert.Nil(t, wrp.memBytes)
+	})
+
+	t.Run("limit set after read", func(t *testing.T) {
+		body := newMockBody(bodyText)
+		wrp := newBodyWrapper(body, nil)
+
+		b, err := io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, bodyText, string(b))
+
+		wrp.SetMaxSize(4)
+		assert.True(t, wrp.isTooLarge)
+		assert.Nil(t, wrp.memBytes)
+
+		wrp.Rewind()
+
+		_, err = io.ReadAll(wrp)
+		assert.Equal(t, errBodyTooLarge, err)
+	})
+}
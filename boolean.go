@@ -86,6 +86,67 @@ func (b *Boolean) Alias(name string) *Boolean {
 	return b
 }
 
+// Warn is similar to Value.Warn.
+//
+// Example:
+//
+//	boolean := NewBoolean(t, true)
+//	boolean.Warn().IsFalse() // warning, test continues
+func (b *Boolean) Warn() *Boolean {
+	opChain := b.chain.enter("Warn()")
+	defer opChain.leave()
+
+	ret := newBoolean(opChain, b.value)
+
+	ret.chain.setRoot()
+	ret.chain.setSeverity(SeverityWarning)
+
+	return ret
+}
+
+// Assert is similar to Value.Assert.
+//
+// Example:
+//
+//	boolean := NewBoolean(t, true)
+//	boolean.Assert(func(b bool) error {
+//		if !b {
+//			return errors.New("feature flag is disabled")
+//		}
+//		return nil
+//	})
+func (b *Boolean) Assert(fn func(value bool) error) *Boolean {
+	opChain := b.chain.enter("Assert()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return b
+	}
+
+	if err := fn(b.value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{b.value},
+			Errors: []error{
+				errors.New("expected: value satisfies custom assertion"),
+				err,
+			},
+		})
+	}
+
+	return b
+}
+
 // Path is similar to Value.Path.
 func (b *Boolean) Path(path string) *Value {
 	opChain := b.chain.enter("Path(%q)", path)
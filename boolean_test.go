@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -16,6 +17,7 @@ func TestBoolean_FailedChain(t *testing.T) {
 	value.Path("$").chain.assert(t, failure)
 	value.Schema("")
 	value.Alias("foo")
+	value.Assert(nil)
 
 	var target interface{}
 	value.Decode(&target)
@@ -124,6 +126,43 @@ func TestBoolean_Alias(t *testing.T) {
 	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
 }
 
+func TestBoolean_Warn(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	boolean := NewBoolean(reporter, true)
+
+	warn := boolean.Warn()
+	warn.IsFalse()
+
+	warn.chain.assert(t, failure)
+	boolean.chain.assert(t, success)
+
+	assert.False(t, reporter.reported)
+}
+
+func TestBoolean_Assert(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	var actual bool
+	NewBoolean(reporter, true).
+		Assert(func(b bool) error {
+			actual = b
+			return nil
+		}).
+		chain.assert(t, success)
+	assert.Equal(t, true, actual)
+
+	NewBoolean(reporter, true).
+		Assert(func(b bool) error {
+			return errors.New("custom error")
+		}).
+		chain.assert(t, failure)
+
+	NewBoolean(reporter, true).
+		Assert(nil).
+		chain.assert(t, failure)
+}
+
 func TestBoolean_Path(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -0,0 +1,388 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// Bytes provides methods to inspect attached []byte value.
+//
+// Unlike String, it's intended for binary content, like images or archives,
+// and reports failures using hex dump instead of text.
+type Bytes struct {
+	noCopy noCopy
+	chain  *chain
+	value  []byte
+}
+
+// NewBytes returns a new Bytes instance.
+//
+// If reporter is nil, the function panics.
+//
+// Example:
+//
+//	b := NewBytes(t, []byte{0x89, 'P', 'N', 'G'})
+//	b.HasPrefix([]byte{0x89, 'P', 'N', 'G'})
+func NewBytes(reporter Reporter, value []byte) *Bytes {
+	return newBytes(newChainWithDefaults("Bytes()", reporter), value)
+}
+
+// NewBytesC returns a new Bytes instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+//
+// Example:
+//
+//	b := NewBytesC(config, []byte{0x89, 'P', 'N', 'G'})
+//	b.HasPrefix([]byte{0x89, 'P', 'N', 'G'})
+func NewBytesC(config Config, value []byte) *Bytes {
+	return newBytes(newChainWithConfig("Bytes()", config.withDefaults()), value)
+}
+
+func newBytes(parent *chain, val []byte) *Bytes {
+	b := &Bytes{chain: parent.clone()}
+
+	b.value = make([]byte, len(val))
+	copy(b.value, val)
+
+	return b
+}
+
+// Raw returns underlying value attached to Bytes.
+// This is the value originally passed to NewBytes.
+//
+// Example:
+//
+//	b := NewBytes(t, data)
+//	assert.Equal(t, data, b.Raw())
+func (b *Bytes) Raw() []byte {
+	return b.value
+}
+
+// Alias is similar to Value.Alias.
+func (b *Bytes) Alias(name string) *Bytes {
+	opChain := b.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	b.chain.setAlias(name)
+	return b
+}
+
+// Length returns a new Number instance with number of bytes.
+//
+// Example:
+//
+//	b := NewBytes(t, []byte("hello"))
+//	b.Length().IsEqual(5)
+func (b *Bytes) Length() *Number {
+	opChain := b.chain.enter("Length()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(len(b.value)))
+}
+
+// IsEmpty succeeds if byte slice is empty.
+//
+// Example:
+//
+//	b := NewBytes(t, nil)
+//	b.IsEmpty()
+func (b *Bytes) IsEmpty() *Bytes {
+	opChain := b.chain.enter("IsEmpty()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if !(len(b.value) == 0) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertEmpty,
+			Actual: &AssertionValue{bytesDump(b.value)},
+			Errors: []error{
+				errors.New("expected: bytes are empty"),
+			},
+		})
+	}
+
+	return b
+}
+
+// NotEmpty succeeds if byte slice is non-empty.
+//
+// Example:
+//
+//	b := NewBytes(t, []byte{0})
+//	b.NotEmpty()
+func (b *Bytes) NotEmpty() *Bytes {
+	opChain := b.chain.enter("NotEmpty()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if !(len(b.value) != 0) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{bytesDump(b.value)},
+			Errors: []error{
+				errors.New("expected: bytes are non-empty"),
+			},
+		})
+	}
+
+	return b
+}
+
+// IsEqual succeeds if bytes are equal to given value.
+//
+// Example:
+//
+//	b := NewBytes(t, []byte{1, 2, 3})
+//	b.IsEqual([]byte{1, 2, 3})
+func (b *Bytes) IsEqual(value []byte) *Bytes {
+	opChain := b.chain.enter("IsEqual()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if !bytes.Equal(b.value, value) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{bytesDump(b.value)},
+			Expected: &AssertionValue{bytesDump(value)},
+			Errors: []error{
+				errors.New("expected: bytes are equal"),
+				bytesMismatch(b.value, value),
+			},
+		})
+	}
+
+	return b
+}
+
+// NotEqual succeeds if bytes are not equal to given value.
+//
+// Example:
+//
+//	b := NewBytes(t, []byte{1, 2, 3})
+//	b.NotEqual([]byte{3, 2, 1})
+func (b *Bytes) NotEqual(value []byte) *Bytes {
+	opChain := b.chain.enter("NotEqual()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if bytes.Equal(b.value, value) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotEqual,
+			Actual:   &AssertionValue{bytesDump(b.value)},
+			Expected: &AssertionValue{bytesDump(value)},
+			Errors: []error{
+				errors.New("expected: bytes are non-equal"),
+			},
+		})
+	}
+
+	return b
+}
+
+// IsEqualBase64 succeeds if bytes are equal to given value encoded
+// using standard base64 encoding.
+//
+// Useful to keep expected binary content in test source code.
+//
+// Example:
+//
+//	b := NewBytes(t, []byte("hello"))
+//	b.IsEqualBase64("aGVsbG8=")
+func (b *Bytes) IsEqualBase64(value string) *Bytes {
+	opChain := b.chain.enter("IsEqualBase64()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	expected, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid base64 value argument"),
+				err,
+			},
+		})
+		return b
+	}
+
+	if !bytes.Equal(b.value, expected) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{bytesDump(b.value)},
+			Expected: &AssertionValue{bytesDump(expected)},
+			Errors: []error{
+				errors.New("expected: bytes are equal"),
+				bytesMismatch(b.value, expected),
+			},
+		})
+	}
+
+	return b
+}
+
+// HasPrefix succeeds if bytes start with given prefix.
+//
+// Useful to check magic numbers of file formats.
+//
+// Example:
+//
+//	b := NewBytes(t, []byte("GIF89a..."))
+//	b.HasPrefix([]byte("GIF89a"))
+func (b *Bytes) HasPrefix(prefix []byte) *Bytes {
+	opChain := b.chain.enter("HasPrefix()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if !bytes.HasPrefix(b.value, prefix) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsSubset,
+			Actual:   &AssertionValue{bytesDump(b.value)},
+			Expected: &AssertionValue{bytesDump(prefix)},
+			Errors: []error{
+				errors.New("expected: bytes have given prefix"),
+			},
+		})
+	}
+
+	return b
+}
+
+// HasSuffix succeeds if bytes end with given suffix.
+//
+// Example:
+//
+//	b := NewBytes(t, []byte("...%%EOF"))
+//	b.HasSuffix([]byte("%%EOF"))
+func (b *Bytes) HasSuffix(suffix []byte) *Bytes {
+	opChain := b.chain.enter("HasSuffix()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if !bytes.HasSuffix(b.value, suffix) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsSubset,
+			Actual:   &AssertionValue{bytesDump(b.value)},
+			Expected: &AssertionValue{bytesDump(suffix)},
+			Errors: []error{
+				errors.New("expected: bytes have given suffix"),
+			},
+		})
+	}
+
+	return b
+}
+
+var (
+	pngSignature  = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+	jpegSignature = []byte{0xff, 0xd8, 0xff}
+	pdfSignature  = []byte("%PDF-")
+)
+
+// IsPNG succeeds if bytes start with PNG file signature.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Body().AsBytes().IsPNG()
+func (b *Bytes) IsPNG() *Bytes {
+	return b.checkSignature("IsPNG()", "png", pngSignature)
+}
+
+// IsJPEG succeeds if bytes start with JPEG file signature.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Body().AsBytes().IsJPEG()
+func (b *Bytes) IsJPEG() *Bytes {
+	return b.checkSignature("IsJPEG()", "jpeg", jpegSignature)
+}
+
+// IsPDF succeeds if bytes start with PDF file signature.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Body().AsBytes().IsPDF()
+func (b *Bytes) IsPDF() *Bytes {
+	return b.checkSignature("IsPDF()", "pdf", pdfSignature)
+}
+
+func (b *Bytes) checkSignature(method, format string, signature []byte) *Bytes {
+	opChain := b.chain.enter(method)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if !bytes.HasPrefix(b.value, signature) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{bytesDump(b.value)},
+			Errors: []error{
+				fmt.Errorf("expected: bytes have %s signature", format),
+				fmt.Errorf("%s signature is % x", format, signature),
+			},
+		})
+	}
+
+	return b
+}
+
+// Max number of bytes included into failure message.
+const bytesDumpLimit = 64
+
+// Format bytes as hex for failure message, truncating long values.
+func bytesDump(b []byte) string {
+	if len(b) == 0 {
+		return "(0 bytes)"
+	}
+
+	if len(b) > bytesDumpLimit {
+		return fmt.Sprintf("% x ... (%d bytes)", b[:bytesDumpLimit], len(b))
+	}
+
+	return fmt.Sprintf("% x (%d bytes)", b, len(b))
+}
+
+// Describe first difference between two byte slices.
+func bytesMismatch(actual, expected []byte) error {
+	for i := 0; i < len(actual) && i < len(expected); i++ {
+		if actual[i] != expected[i] {
+			return fmt.Errorf("first mismatch at offset %d: got 0x%02x, expected 0x%02x",
+				i, actual[i], expected[i])
+		}
+	}
+
+	return fmt.Errorf("length mismatch: got %d bytes, expected %d bytes",
+		len(actual), len(expected))
+}
@@ -0,0 +1,206 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytes_FailedChain(t *testing.T) {
+	chain := newMockChain(t, flagFailed)
+
+	value := newBytes(chain, []byte("foo"))
+	value.chain.assert(t, failure)
+
+	value.Alias("foo")
+	value.Length().chain.assert(t, failure)
+	value.IsEmpty()
+	value.NotEmpty()
+	value.IsEqual(nil)
+	value.NotEqual(nil)
+	value.IsEqualBase64("")
+	value.HasPrefix(nil)
+	value.HasSuffix(nil)
+	value.IsPNG()
+	value.IsJPEG()
+	value.IsPDF()
+}
+
+func TestBytes_Constructors(t *testing.T) {
+	data := []byte{1, 2, 3}
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewBytes(reporter, data)
+		assert.Equal(t, data, value.Raw())
+		value.chain.assert(t, success)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewBytesC(Config{
+			Reporter: reporter,
+		}, data)
+		assert.Equal(t, data, value.Raw())
+		value.chain.assert(t, success)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newBytes(chain, data)
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestBytes_Raw(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	data := []byte{1, 2, 3}
+
+	value := NewBytes(reporter, data)
+	assert.Equal(t, data, value.Raw())
+
+	data[0] = 0
+	assert.Equal(t, []byte{1, 2, 3}, value.Raw())
+}
+
+func TestBytes_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewBytes(reporter, nil)
+	assert.Equal(t, []string{"Bytes()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"Bytes()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"Bytes()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+}
+
+func TestBytes_Length(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	NewBytes(reporter, nil).Length().IsEqual(0)
+	NewBytes(reporter, []byte{0, 0, 0}).Length().IsEqual(3)
+
+	NewBytes(reporter, nil).IsEmpty().chain.assert(t, success)
+	NewBytes(reporter, nil).NotEmpty().chain.assert(t, failure)
+	NewBytes(reporter, []byte{0}).IsEmpty().chain.assert(t, failure)
+	NewBytes(reporter, []byte{0}).NotEmpty().chain.assert(t, success)
+}
+
+func TestBytes_IsEqual(t *testing.T) {
+	cases := []struct {
+		name      string
+		value     []byte
+		expected  []byte
+		wantEqual chainResult
+	}{
+		{"equal", []byte{0, 0xff, 1}, []byte{0, 0xff, 1}, success},
+		{"different byte", []byte{0, 0xff, 1}, []byte{0, 0xfe, 1}, failure},
+		{"shorter", []byte{0, 0xff}, []byte{0, 0xff, 1}, failure},
+		{"longer", []byte{0, 0xff, 1, 2}, []byte{0, 0xff, 1}, failure},
+		{"nil and empty", nil, []byte{}, success},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewBytes(reporter, tc.value).IsEqual(tc.expected).
+				chain.assert(t, tc.wantEqual)
+
+			NewBytes(reporter, tc.value).NotEqual(tc.expected).
+				chain.assert(t, !tc.wantEqual)
+		})
+	}
+
+	t.Run("mismatch offset", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewBytesC(Config{
+			AssertionHandler: handler,
+		}, []byte{0, 0xff, 1}).IsEqual([]byte{0, 0xfe, 1})
+
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Equal(t, 2, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[1].Error(), "offset 1")
+	})
+}
+
+func TestBytes_IsEqualBase64(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	NewBytes(reporter, []byte("hello")).IsEqualBase64("aGVsbG8=").
+		chain.assert(t, success)
+
+	NewBytes(reporter, []byte("hellO")).IsEqualBase64("aGVsbG8=").
+		chain.assert(t, failure)
+
+	NewBytes(reporter, []byte("hello")).IsEqualBase64("not base64").
+		chain.assert(t, failure)
+}
+
+func TestBytes_Affixes(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	data := []byte{1, 2, 3, 4}
+
+	NewBytes(reporter, data).HasPrefix([]byte{1, 2}).chain.assert(t, success)
+	NewBytes(reporter, data).HasPrefix([]byte{}).chain.assert(t, success)
+	NewBytes(reporter, data).HasPrefix([]byte{2}).chain.assert(t, failure)
+	NewBytes(reporter, data).HasPrefix([]byte{1, 2, 3, 4, 5}).chain.assert(t, failure)
+
+	NewBytes(reporter, data).HasSuffix([]byte{3, 4}).chain.assert(t, success)
+	NewBytes(reporter, data).HasSuffix([]byte{3}).chain.assert(t, failure)
+}
+
+func TestBytes_Signatures(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	jpeg := []byte("\xff\xd8\xff\xe0\x00\x10JFIF")
+	pdf := []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3")
+
+	cases := []struct {
+		name   string
+		data   []byte
+		isPNG  chainResult
+		isJPEG chainResult
+		isPDF  chainResult
+	}{
+		{"png", png, success, failure, failure},
+		{"jpeg", jpeg, failure, success, failure},
+		{"pdf", pdf, failure, failure, success},
+		{"text", []byte("hello"), failure, failure, failure},
+		{"empty", nil, failure, failure, failure},
+		{"truncated png", png[:4], failure, failure, failure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewBytes(reporter, tc.data).IsPNG().chain.assert(t, tc.isPNG)
+			NewBytes(reporter, tc.data).IsJPEG().chain.assert(t, tc.isJPEG)
+			NewBytes(reporter, tc.data).IsPDF().chain.assert(t, tc.isPDF)
+		})
+	}
+}
+
+func TestBytes_Dump(t *testing.T) {
+	assert.Equal(t, "(0 bytes)", bytesDump(nil))
+	assert.Equal(t, "00 ff 10 (3 bytes)", bytesDump([]byte{0, 0xff, 0x10}))
+
+	long := make([]byte, bytesDumpLimit+1)
+	assert.Contains(t, bytesDump(long), "... (65 bytes)")
+}
+
+func TestBytes_FromString(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	data := []byte{0x89, 0xff, 0x00, 0xc3, 0x28}
+
+	value := NewString(reporter, string(data)).AsBytes()
+	value.chain.assert(t, success)
+
+	assert.Equal(t, data, value.Raw())
+}
@@ -0,0 +1,251 @@
+package httpexpect
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Certificate provides methods to inspect attached x509.Certificate value.
+type Certificate struct {
+	noCopy noCopy
+	chain  *chain
+	value  *x509.Certificate
+}
+
+// NewCertificate returns a new Certificate instance.
+//
+// If reporter is nil, the function panics.
+// If value is nil, failure is reported.
+//
+// Example:
+//
+//	cert := NewCertificate(t, &x509.Certificate{...})
+//
+//	cert.Subject().IsEqual("example.com")
+//	cert.DNSNames().ContainsOnly("example.com", "www.example.com")
+//	cert.IsValidFor(30 * 24 * time.Hour)
+func NewCertificate(reporter Reporter, value *x509.Certificate) *Certificate {
+	return newCertificate(newChainWithDefaults("Certificate()", reporter), value)
+}
+
+// NewCertificateC returns a new Certificate instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is nil, failure is reported.
+//
+// See NewCertificate for usage example.
+func NewCertificateC(config Config, value *x509.Certificate) *Certificate {
+	return newCertificate(
+		newChainWithConfig("Certificate()", config.withDefaults()), value)
+}
+
+func newCertificate(parent *chain, val *x509.Certificate) *Certificate {
+	c := &Certificate{chain: parent.clone(), value: nil}
+
+	opChain := c.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil certificate"),
+			},
+		})
+	} else {
+		c.value = val
+	}
+
+	return c
+}
+
+// Raw returns underlying x509.Certificate value attached to Certificate.
+// This is the value originally passed to NewCertificate.
+//
+// Example:
+//
+//	cert := NewCertificate(t, c)
+//	assert.Equal(t, c, cert.Raw())
+func (c *Certificate) Raw() *x509.Certificate {
+	return c.value
+}
+
+// Alias is similar to Value.Alias.
+func (c *Certificate) Alias(name string) *Certificate {
+	opChain := c.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	c.chain.setAlias(name)
+	return c
+}
+
+// Subject returns a new String instance with common name (CN) of
+// certificate subject.
+//
+// Example:
+//
+//	cert := NewCertificate(t, &x509.Certificate{...})
+//	cert.Subject().IsEqual("example.com")
+func (c *Certificate) Subject() *String {
+	opChain := c.chain.enter("Subject()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, c.value.Subject.CommonName)
+}
+
+// Issuer returns a new String instance with common name (CN) of
+// certificate issuer.
+//
+// Example:
+//
+//	cert := NewCertificate(t, &x509.Certificate{...})
+//	cert.Issuer().IsEqual("Example CA")
+func (c *Certificate) Issuer() *String {
+	opChain := c.chain.enter("Issuer()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, c.value.Issuer.CommonName)
+}
+
+// DNSNames returns a new Array instance with DNS names from certificate
+// Subject Alternative Names (SANs).
+//
+// Returned Array contains a String value for every DNS name.
+//
+// Example:
+//
+//	cert := NewCertificate(t, &x509.Certificate{...})
+//	cert.DNSNames().ContainsAll("example.com", "www.example.com")
+func (c *Certificate) DNSNames() *Array {
+	opChain := c.chain.enter("DNSNames()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	names := []interface{}{}
+	for _, name := range c.value.DNSNames {
+		names = append(names, name)
+	}
+
+	return newArray(opChain, names)
+}
+
+// IPAddresses returns a new Array instance with IP addresses from
+// certificate Subject Alternative Names (SANs).
+//
+// Returned Array contains a String value for every IP address.
+//
+// Example:
+//
+//	cert := NewCertificate(t, &x509.Certificate{...})
+//	cert.IPAddresses().ContainsOnly("127.0.0.1", "::1")
+func (c *Certificate) IPAddresses() *Array {
+	opChain := c.chain.enter("IPAddresses()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	addrs := []interface{}{}
+	for _, addr := range c.value.IPAddresses {
+		addrs = append(addrs, addr.String())
+	}
+
+	return newArray(opChain, addrs)
+}
+
+// NotBefore returns a new DateTime instance with time when certificate
+// becomes valid.
+//
+// Example:
+//
+//	cert := NewCertificate(t, &x509.Certificate{...})
+//	cert.NotBefore().Lt(time.Now())
+func (c *Certificate) NotBefore() *DateTime {
+	opChain := c.chain.enter("NotBefore()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	return newDateTime(opChain, c.value.NotBefore)
+}
+
+// NotAfter returns a new DateTime instance with time when certificate
+// expires.
+//
+// Example:
+//
+//	cert := NewCertificate(t, &x509.Certificate{...})
+//	cert.NotAfter().Gt(time.Now().Add(30 * 24 * time.Hour))
+func (c *Certificate) NotAfter() *DateTime {
+	opChain := c.chain.enter("NotAfter()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	return newDateTime(opChain, c.value.NotAfter)
+}
+
+// IsValidFor succeeds if certificate is valid now and remains valid for
+// at least given duration.
+//
+// It's useful to catch certificates that are going to expire soon.
+// IsValidFor(0) just checks that certificate is valid now.
+//
+// Example:
+//
+//	cert := NewCertificate(t, &x509.Certificate{...})
+//	cert.IsValidFor(30 * 24 * time.Hour)
+func (c *Certificate) IsValidFor(duration time.Duration) *Certificate {
+	opChain := c.chain.enter("IsValidFor()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if duration < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected negative duration argument: %s", duration),
+			},
+		})
+		return c
+	}
+
+	now := opChain.now()
+
+	if now.Before(c.value.NotBefore) || now.Add(duration).After(c.value.NotAfter) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{now},
+			Expected: &AssertionValue{
+				AssertionRange{c.value.NotBefore, c.value.NotAfter.Add(-duration)},
+			},
+			Errors: []error{
+				fmt.Errorf("expected: certificate is valid for at least %s", duration),
+			},
+		})
+	}
+
+	return c
+}
@@ -0,0 +1,228 @@
+package httpexpect
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificate_FailedChain(t *testing.T) {
+	check := func(value *Certificate, isNil bool) {
+		value.chain.assert(t, failure)
+
+		if isNil {
+			assert.Nil(t, value.Raw())
+		} else {
+			assert.NotNil(t, value.Raw())
+		}
+
+		value.Alias("foo")
+
+		value.Subject().chain.assert(t, failure)
+		value.Issuer().chain.assert(t, failure)
+		value.DNSNames().chain.assert(t, failure)
+		value.IPAddresses().chain.assert(t, failure)
+		value.NotBefore().chain.assert(t, failure)
+		value.NotAfter().chain.assert(t, failure)
+
+		value.IsValidFor(0)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newCertificate(chain, &x509.Certificate{})
+
+		check(value, false)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newCertificate(chain, nil)
+
+		check(value, true)
+	})
+
+	t.Run("failed chain, nil value", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newCertificate(chain, nil)
+
+		check(value, true)
+	})
+}
+
+func TestCertificate_Constructors(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject: pkix.Name{CommonName: "example.com"},
+	}
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewCertificate(reporter, cert)
+		value.Subject().IsEqual("example.com")
+		value.chain.assert(t, success)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewCertificateC(Config{
+			Reporter: reporter,
+		}, cert)
+		value.Subject().IsEqual("example.com")
+		value.chain.assert(t, success)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newCertificate(chain, cert)
+		assert.NotSame(t, value.chain, &chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestCertificate_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewCertificate(reporter, &x509.Certificate{})
+	assert.Equal(t, []string{"Certificate()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"Certificate()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"Certificate()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+
+	childValue := value.Subject()
+	assert.Equal(t, []string{"Certificate()", "Subject()"},
+		childValue.chain.context.Path)
+	assert.Equal(t, []string{"foo", "Subject()"},
+		childValue.chain.context.AliasedPath)
+}
+
+func TestCertificate_Getters(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	data := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "example.com"},
+		Issuer:      pkix.Name{CommonName: "Example CA"},
+		DNSNames:    []string{"example.com", "www.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
+		NotBefore:   time.Unix(1000, 0),
+		NotAfter:    time.Unix(2000, 0),
+	}
+
+	value := NewCertificate(reporter, data)
+
+	assert.Same(t, data, value.Raw())
+
+	value.Subject().IsEqual("example.com")
+	value.Issuer().IsEqual("Example CA")
+	value.DNSNames().IsEqual([]string{"example.com", "www.example.com"})
+	value.IPAddresses().IsEqual([]string{"127.0.0.1", "::1"})
+	value.NotBefore().IsEqual(time.Unix(1000, 0))
+	value.NotAfter().IsEqual(time.Unix(2000, 0))
+
+	value.chain.assert(t, success)
+
+	empty := NewCertificate(reporter, &x509.Certificate{})
+
+	empty.DNSNames().IsEmpty()
+	empty.IPAddresses().IsEmpty()
+
+	empty.chain.assert(t, success)
+}
+
+func TestCertificate_IsValidFor(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		duration  time.Duration
+		result    chainResult
+	}{
+		{
+			name:      "valid now",
+			notBefore: now.Add(-time.Hour),
+			notAfter:  now.Add(time.Hour),
+			duration:  0,
+			result:    success,
+		},
+		{
+			name:      "valid for duration",
+			notBefore: now.Add(-time.Hour),
+			notAfter:  now.Add(48 * time.Hour),
+			duration:  24 * time.Hour,
+			result:    success,
+		},
+		{
+			name:      "expires before duration",
+			notBefore: now.Add(-time.Hour),
+			notAfter:  now.Add(time.Hour),
+			duration:  24 * time.Hour,
+			result:    failure,
+		},
+		{
+			name:      "expired",
+			notBefore: now.Add(-2 * time.Hour),
+			notAfter:  now.Add(-time.Hour),
+			duration:  0,
+			result:    failure,
+		},
+		{
+			name:      "not yet valid",
+			notBefore: now.Add(time.Hour),
+			notAfter:  now.Add(2 * time.Hour),
+			duration:  0,
+			result:    failure,
+		},
+		{
+			name:      "negative duration",
+			notBefore: now.Add(-time.Hour),
+			notAfter:  now.Add(time.Hour),
+			duration:  -time.Hour,
+			result:    failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewCertificate(reporter, &x509.Certificate{
+				NotBefore: tc.notBefore,
+				NotAfter:  tc.notAfter,
+			}).IsValidFor(tc.duration).
+				chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestCertificate_IsValidForClock(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cert := &x509.Certificate{
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(time.Hour),
+	}
+
+	clock := NewFakeClock(now)
+
+	config := Config{
+		Reporter: newMockReporter(t),
+		Clock:    clock,
+	}
+
+	NewCertificateC(config, cert).
+		IsValidFor(30*time.Minute).
+		chain.assert(t, success)
+
+	clock.Advance(45 * time.Minute)
+
+	NewCertificateC(config, cert).
+		IsValidFor(30*time.Minute).
+		chain.assert(t, failure)
+}
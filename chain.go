@@ -1,9 +1,12 @@
 package httpexpect
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -79,6 +82,17 @@ type chain struct {
 	handler  AssertionHandler
 	severity AssertionSeverity
 	failure  *AssertionFailure
+
+	redactors []func(path string, value interface{}) (interface{}, bool)
+
+	// extra attachments reported with failures, added by matchers
+	attachments []AssertionAttachment
+
+	// clock from config, used by time-dependent assertions
+	clock Clock
+
+	// hook from config, invoked for every reported failure
+	onFailure func(FailureContext)
 }
 
 // If enabled, chain will panic if used incorrectly or gets illformed AssertionFailure.
@@ -112,9 +126,12 @@ func newChainWithConfig(name string, config Config) *chain {
 	config.validate()
 
 	c := &chain{
-		context:  AssertionContext{},
-		handler:  config.AssertionHandler,
-		severity: SeverityError,
+		context:   AssertionContext{},
+		handler:   config.AssertionHandler,
+		severity:  SeverityError,
+		redactors: config.Redactors,
+		clock:     config.Clock,
+		onFailure: config.OnFailure,
 	}
 
 	c.context.TestName = config.TestName
@@ -238,6 +255,19 @@ func (c *chain) setRequestName(name string) {
 	c.context.RequestName = name
 }
 
+// Store request ID in AssertionContext.
+// Child chains inherit context from parent.
+func (c *chain) setRequestID(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.context.RequestID = id
+}
+
 // Store request pointer in AssertionContext.
 // Child chains inherit context from parent.
 func (c *chain) setRequest(req *Request) {
@@ -272,6 +302,33 @@ func (c *chain) setResponse(resp *Response) {
 	c.context.Response = resp
 }
 
+// Remove response pointer from AssertionContext.
+// Used when a new response is derived from existing one.
+func (c *chain) clearResponse() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.context.Response = nil
+}
+
+// Remove request and response pointers from AssertionContext.
+// Used when a new request is derived from existing response.
+func (c *chain) clearRequest() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.context.Request = nil
+	c.context.Response = nil
+}
+
 // Set assertion handler
 // Chain always overrides assertion handler with given one.
 func (c *chain) setHandler(handler AssertionHandler) {
@@ -286,6 +343,42 @@ func (c *chain) setHandler(handler AssertionHandler) {
 	c.context.TestingTB = isTestingTB(handler)
 }
 
+// Set Config.OnFailure hook; nil disables hook.
+// Child chains inherit hook from parent.
+func (c *chain) setOnFailure(onFailure func(FailureContext)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.onFailure = onFailure
+}
+
+// Add attachment to be reported with failures of this chain and of its
+// children created after this call.
+func (c *chain) addAttachment(attachment AssertionAttachment) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.attachments = append(c.attachments, attachment)
+}
+
+// Apply redactors from Config.Redactors to value.
+// Returns value unchanged if there are no redactors.
+func (c *chain) redact(value interface{}) interface{} {
+	c.mu.Lock()
+	redactors := c.redactors
+	c.mu.Unlock()
+
+	return redactNodes(redactors, value)
+}
+
 // Create chain clone.
 // Typically is called between enter() and leave().
 func (c *chain) clone() *chain {
@@ -311,10 +404,19 @@ func (c *chain) clone() *chain {
 		severity: c.severity,
 		// failure is not inherited because it should be reported only once
 		// by the chain where it happened
-		failure: nil,
+		failure:     nil,
+		redactors:   c.redactors,
+		attachments: append(([]AssertionAttachment)(nil), c.attachments...),
+		clock:       c.clock,
+		onFailure:   c.onFailure,
 	}
 }
 
+// Get current time from Config.Clock.
+func (c *chain) now() time.Time {
+	return clockOrDefault(c.clock).Now()
+}
+
 // Create temporary chain clone to be used in assertion.
 // If name is not empty, it is appended to the path.
 // You must call leave() at the end of assertion.
@@ -374,11 +476,13 @@ func (c *chain) replace(name string, args ...interface{}) *chain {
 // Chain can't be used after this call.
 func (c *chain) leave() {
 	var (
-		parent  *chain
-		flags   chainFlags
-		context AssertionContext
-		handler AssertionHandler
-		failure *AssertionFailure
+		parent      *chain
+		flags       chainFlags
+		context     AssertionContext
+		handler     AssertionHandler
+		failure     *AssertionFailure
+		attachments []AssertionAttachment
+		onFailure   func(FailureContext)
 	)
 	func() {
 		c.mu.Lock()
@@ -395,7 +499,8 @@ func (c *chain) leave() {
 		context = c.context
 		handler = c.handler
 		failure = c.failure
-
+		attachments = c.attachments
+		onFailure = c.onFailure
 	}()
 
 	if flags&(flagFailed|flagFailedChildren) == 0 {
@@ -403,13 +508,35 @@ func (c *chain) leave() {
 	}
 
 	if flags&(flagFailed) != 0 && failure != nil {
-		handler.Failure(&context, failure)
+		if context.Request != nil && failure.Severity == SeverityError {
+			context.Request.flushLazyPrinters()
+		}
+
+		if h, ok := handler.(AttachmentAssertionHandler); ok {
+			h.FailureWithAttachments(&context, failure,
+				append(buildAttachments(&context), attachments...))
+		} else {
+			handler.Failure(&context, failure)
+		}
 
 		if chainValidation {
 			if err := validateAssertion(failure); err != nil {
 				panic(err)
 			}
 		}
+
+		if onFailure != nil && failure.Severity == SeverityError {
+			failureCtx := buildFailureContext(&context, failure)
+
+			if soft, ok := handler.(*softAssertionHandler); ok {
+				// collected failure, hook is invoked by Verify
+				soft.deferHook(func() {
+					onFailure(failureCtx)
+				})
+			} else {
+				onFailure(failureCtx)
+			}
+		}
 	}
 
 	if flags&(flagFailed|flagFailedChildren) != 0 && parent != nil {
@@ -463,6 +590,34 @@ func (c *chain) failed() bool {
 	return c.flags&flagFailed != 0
 }
 
+// Build error from failure reported by chain.
+// Returns nil if chain is not failed.
+func (c *chain) failureError() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.flags&flagFailed == 0 {
+		return nil
+	}
+
+	if c.failure == nil {
+		return errors.New("previous assertion failed")
+	}
+
+	var msgs []string
+	for _, err := range c.failure.Errors {
+		if !refIsNil(err) {
+			msgs = append(msgs, err.Error())
+		}
+	}
+
+	if len(msgs) == 0 {
+		return fmt.Errorf("assertion failed: %s", c.failure.Type)
+	}
+
+	return errors.New(strings.Join(msgs, ": "))
+}
+
 // Check if chain or any of its children failed.
 func (c *chain) treeFailed() bool {
 	c.mu.Lock()
@@ -509,6 +664,10 @@ func (c *chain) clear() {
 
 // Whether handler outputs to testing.TB
 func isTestingTB(in AssertionHandler) bool {
+	if soft, ok := in.(*softAssertionHandler); ok {
+		in = soft.handler
+	}
+
 	h, ok := in.(*DefaultAssertionHandler)
 	if !ok {
 		return false
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func testFailure() AssertionFailure {
@@ -717,6 +718,42 @@ func TestChain_Severity(t *testing.T) {
 	})
 }
 
+func TestChain_FailureError(t *testing.T) {
+	t.Run("not failed", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		opChain := chain.enter("test")
+		assert.Nil(t, opChain.failureError())
+		opChain.leave()
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		opChain := chain.enter("test")
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("first"),
+				errors.New("second"),
+			},
+		})
+		err := opChain.failureError()
+		opChain.leave()
+
+		require.Error(t, err)
+		assert.Equal(t, "first: second", err.Error())
+	})
+
+	t.Run("failed parent", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+
+		opChain := chain.enter("test")
+		assert.Error(t, opChain.failureError())
+		opChain.leave()
+	})
+}
+
 func TestChain_Stacktrace(t *testing.T) {
 	handler := &mockAssertionHandler{}
 
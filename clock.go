@@ -0,0 +1,123 @@
+package httpexpect
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is used to get current time and to wait between retries.
+//
+// Default implementation is SystemClock. FakeClock can be used in tests
+// to make retries and time-dependent assertions instant and deterministic.
+type Clock interface {
+	// Now returns current time.
+	Now() time.Time
+
+	// After waits for given duration and then sends current time on
+	// returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock implements Clock using time package.
+type SystemClock struct{}
+
+// Now implements Clock.Now.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After implements Clock.After.
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// FakeClock implements Clock with manually controlled time.
+//
+// FakeClock never blocks: After advances fake time by given duration and
+// returns channel that is already fired. This makes retry delays instant,
+// while total waited time can be inspected using Now or Slept.
+//
+// FakeClock is safe for concurrent use.
+type FakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	slept time.Duration
+}
+
+// NewFakeClock returns a new FakeClock with given current time.
+//
+// Example:
+//
+//	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//
+//	e := WithConfig(Config{
+//		Reporter: NewAssertReporter(t),
+//		Clock:    clock,
+//	})
+//
+//	e.GET("/path").
+//		WithMaxRetries(3).
+//		WithRetryDelay(time.Second, time.Minute).
+//		Expect()
+//
+//	assert.Equal(t, 7*time.Second, clock.Slept())
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now implements Clock.Now.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After implements Clock.After.
+// It advances time by given duration and returns fired channel.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if d > 0 {
+		c.now = c.now.Add(d)
+		c.slept += d
+	}
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+
+	return ch
+}
+
+// Advance moves time forward (or backward, if d is negative).
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// Set sets current time.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
+
+// Slept returns total duration passed to After.
+func (c *FakeClock) Slept() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.slept
+}
+
+// Return given clock, or SystemClock if it's nil.
+func clockOrDefault(clock Clock) Clock {
+	if clock == nil {
+		return SystemClock{}
+	}
+	return clock
+}
@@ -0,0 +1,160 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock_System(t *testing.T) {
+	clock := SystemClock{}
+
+	before := time.Now()
+	now := clock.Now()
+	assert.False(t, now.Before(before))
+
+	select {
+	case <-clock.After(time.Millisecond):
+	case <-time.After(time.Minute):
+		t.Fatal("timeout")
+	}
+}
+
+func TestClock_Fake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	clock := NewFakeClock(start)
+	assert.Equal(t, start, clock.Now())
+	assert.Equal(t, time.Duration(0), clock.Slept())
+
+	fired := <-clock.After(time.Second)
+	assert.Equal(t, start.Add(time.Second), fired)
+	assert.Equal(t, start.Add(time.Second), clock.Now())
+	assert.Equal(t, time.Second, clock.Slept())
+
+	<-clock.After(0)
+	assert.Equal(t, start.Add(time.Second), clock.Now())
+	assert.Equal(t, time.Second, clock.Slept())
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour+time.Second), clock.Now())
+	assert.Equal(t, time.Second, clock.Slept())
+
+	clock.Set(start)
+	assert.Equal(t, start, clock.Now())
+}
+
+func TestClock_Default(t *testing.T) {
+	assert.Equal(t, SystemClock{}, clockOrDefault(nil))
+
+	clock := NewFakeClock(time.Now())
+	assert.Same(t, clock, clockOrDefault(clock))
+
+	config := Config{Reporter: newMockReporter(t)}.withDefaults()
+	assert.Equal(t, SystemClock{}, config.Clock)
+}
+
+func TestClock_Retries(t *testing.T) {
+	t.Run("backoff", func(t *testing.T) {
+		callCount := 0
+
+		client := &mockClient{
+			resp: http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+			},
+			cb: func(req *http.Request) {
+				callCount++
+			},
+		}
+
+		clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Clock:    clock,
+		}
+
+		resp := NewRequestC(config, http.MethodGet, "/url").
+			WithMaxRetries(3).
+			WithRetryDelay(time.Second, time.Minute).
+			Expect()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, 4, callCount)
+		assert.Equal(t, (1+2+4)*time.Second, clock.Slept())
+	})
+
+	t.Run("retry after date", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		clock := NewFakeClock(now)
+
+		config := Config{
+			Client: &mockClient{
+				resp: http.Response{
+					StatusCode: http.StatusTooManyRequests,
+				},
+			},
+			Reporter: newMockReporter(t),
+			Clock:    clock,
+		}
+
+		resp := NewRequestC(config, http.MethodGet, "/url").
+			WithMaxRetries(1).
+			WithRetryRespectRetryAfter().
+			// mockClient copies request headers to response
+			WithHeader("Retry-After",
+				now.Add(90*time.Second).Format(http.TimeFormat)).
+			Expect()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, 90*time.Second, clock.Slept())
+	})
+
+	t.Run("budget", func(t *testing.T) {
+		callCount := 0
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			callCount++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		// every attempt takes 10 seconds according to fake clock
+		transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			clock.Advance(10 * time.Second)
+			return NewBinder(handler).RoundTrip(req)
+		})
+
+		config := Config{
+			Client: &http.Client{
+				Transport: transport,
+			},
+			Reporter: newMockReporter(t),
+			Clock:    clock,
+		}
+
+		resp := NewRequestC(config, http.MethodGet, "http://example.com").
+			WithMaxRetries(10).
+			WithRetryDelay(time.Second, time.Second).
+			WithRetryBudget(30 * time.Second).
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.RoundTripTime().IsEqual(10 * time.Second)
+
+		// 10s + 1s + 10s + 1s + 10s, then next 1s delay exceeds budget
+		assert.Equal(t, 3, callCount)
+		assert.Equal(t, 2*time.Second, clock.Slept())
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
@@ -0,0 +1,436 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// GenerateOptions defines options for generated test code.
+// See GenerateTestFromHAR and RecordingTransport.GenerateTest.
+type GenerateOptions struct {
+	// Package name of generated file.
+	// If empty, "tests" is used.
+	Package string
+
+	// Name of generated test function.
+	// If empty, "TestRecorded" is used.
+	TestName string
+
+	// Base URL passed to httpexpect.Default. Requests to this URL use
+	// relative paths, and requests to other URLs use Request.WithURL.
+	// If empty, scheme and host of the first request are used.
+	BaseURL string
+}
+
+// Request and response pair used to generate test code.
+type generatedEntry struct {
+	method string
+	url    string
+	header http.Header
+	body   []byte
+
+	hasResponse bool
+	status      int
+	respHeader  http.Header
+	respBody    []byte
+}
+
+// Headers that are set automatically by client and should not be
+// included into generated code.
+var generatedSkipHeaders = map[string]bool{
+	"Accept-Encoding":   true,
+	"Connection":        true,
+	"Content-Length":    true,
+	"Host":              true,
+	"Transfer-Encoding": true,
+	"User-Agent":        true,
+}
+
+// GenerateTestFromHAR generates Go test code from HTTP Archive (HAR) file,
+// e.g. exported from browser developer tools or from a proxy.
+//
+// Generated test contains a request for every HAR entry, in the same
+// order, with the same method, URL, headers, and body. For every request,
+// basic assertions are generated: response status, and, if response body
+// is a JSON object or array, its top-level keys or length.
+//
+// Generated code is formatted with gofmt and is intended to be a starting
+// point for a test suite; review and edit it before use, e.g. to remove
+// secrets from headers and to strengthen assertions.
+//
+// Example:
+//
+//	har, _ := os.Open("legacy.har")
+//	defer har.Close()
+//
+//	code, err := httpexpect.GenerateTestFromHAR(har, httpexpect.GenerateOptions{
+//		Package:  "legacy_test",
+//		TestName: "TestLegacyAPI",
+//	})
+//
+//	_ = os.WriteFile("legacy_test.go", code, 0644)
+func GenerateTestFromHAR(har io.Reader, opts GenerateOptions) ([]byte, error) {
+	entries, err := parseHAR(har)
+	if err != nil {
+		return nil, err
+	}
+
+	return generateTest(entries, opts)
+}
+
+// GenerateTest generates Go test code from requests recorded by transport.
+//
+// Response status and body are recorded while response is being read by
+// client, so generated assertions include only parts of response body that
+// were actually read. See GenerateTestFromHAR for details about generated
+// code.
+//
+// Example:
+//
+//	transport := NewRecordingTransport(http.DefaultTransport)
+//
+//	e := WithConfig(Config{
+//		BaseURL:  "http://legacy.example.com",
+//		Reporter: NewAssertReporter(t),
+//		Client: &http.Client{
+//			Transport: transport,
+//		},
+//	})
+//
+//	e.GET("/users").Expect().JSON()
+//
+//	code, err := transport.GenerateTest(GenerateOptions{})
+func (t *RecordingTransport) GenerateTest(opts GenerateOptions) ([]byte, error) {
+	return generateTest(t.recorder.entries(), opts)
+}
+
+func (rec *requestRecorder) entries() []generatedEntry {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	entries := make([]generatedEntry, 0, len(rec.requests))
+
+	for _, r := range rec.requests {
+		entry := generatedEntry{
+			method: r.method,
+			url:    r.url,
+			header: r.header,
+			body:   r.body,
+		}
+
+		if r.resp != nil && r.resp.received {
+			entry.hasResponse = true
+			entry.status = r.resp.status
+			entry.respHeader = r.resp.header
+			entry.respBody = append([]byte(nil), r.resp.body...)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+type harFile struct {
+	Log struct {
+		Entries []struct {
+			Request struct {
+				Method   string      `json:"method"`
+				URL      string      `json:"url"`
+				Headers  []harHeader `json:"headers"`
+				PostData *struct {
+					MimeType string `json:"mimeType"`
+					Text     string `json:"text"`
+				} `json:"postData"`
+			} `json:"request"`
+			Response *struct {
+				Status  int         `json:"status"`
+				Headers []harHeader `json:"headers"`
+				Content struct {
+					MimeType string `json:"mimeType"`
+					Text     string `json:"text"`
+					Encoding string `json:"encoding"`
+				} `json:"content"`
+			} `json:"response"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+type harHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func parseHAR(r io.Reader) ([]generatedEntry, error) {
+	var har harFile
+	if err := json.NewDecoder(r).Decode(&har); err != nil {
+		return nil, fmt.Errorf("failed to parse HAR: %w", err)
+	}
+
+	entries := make([]generatedEntry, 0, len(har.Log.Entries))
+
+	for i, e := range har.Log.Entries {
+		if e.Request.Method == "" || e.Request.URL == "" {
+			return nil, fmt.Errorf("HAR entry %d: missing request method or url", i)
+		}
+
+		entry := generatedEntry{
+			method: e.Request.Method,
+			url:    e.Request.URL,
+			header: harHeaders(e.Request.Headers),
+		}
+
+		if e.Request.PostData != nil {
+			entry.body = []byte(e.Request.PostData.Text)
+
+			if e.Request.PostData.MimeType != "" &&
+				entry.header.Get("Content-Type") == "" {
+				entry.header.Set("Content-Type", e.Request.PostData.MimeType)
+			}
+		}
+
+		if e.Response != nil && e.Response.Status != 0 {
+			entry.hasResponse = true
+			entry.status = e.Response.Status
+			entry.respHeader = harHeaders(e.Response.Headers)
+
+			content := e.Response.Content
+			if content.Encoding == "base64" {
+				body, err := base64.StdEncoding.DecodeString(content.Text)
+				if err != nil {
+					return nil, fmt.Errorf("HAR entry %d: invalid response content: %w",
+						i, err)
+				}
+				entry.respBody = body
+			} else {
+				entry.respBody = []byte(content.Text)
+			}
+
+			if content.MimeType != "" && entry.respHeader.Get("Content-Type") == "" {
+				entry.respHeader.Set("Content-Type", content.MimeType)
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func harHeaders(headers []harHeader) http.Header {
+	h := http.Header{}
+
+	for _, header := range headers {
+		// skip HTTP/2 pseudo-headers, like ":authority"
+		if strings.HasPrefix(header.Name, ":") {
+			continue
+		}
+		h.Add(header.Name, header.Value)
+	}
+
+	return h
+}
+
+func generateTest(entries []generatedEntry, opts GenerateOptions) ([]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "tests"
+	}
+
+	if opts.TestName == "" {
+		opts.TestName = "TestRecorded"
+	}
+
+	if opts.BaseURL == "" && len(entries) != 0 {
+		u, err := url.Parse(entries[0].url)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request url %q: %w", entries[0].url, err)
+		}
+		opts.BaseURL = originOf(u)
+	}
+
+	baseURL, err := url.Parse(opts.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url %q: %w", opts.BaseURL, err)
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by httpexpect. Review and edit before use.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", opts.Package)
+	fmt.Fprintf(&buf, "import (\n\t\"testing\"\n\n")
+	fmt.Fprintf(&buf, "\t\"github.com/gavv/httpexpect/v2\"\n)\n\n")
+	fmt.Fprintf(&buf, "func %s(t *testing.T) {\n", opts.TestName)
+	fmt.Fprintf(&buf, "\te := httpexpect.Default(t, %s)\n", strconv.Quote(opts.BaseURL))
+
+	for _, entry := range entries {
+		buf.WriteString("\n")
+
+		if err := generateEntry(&buf, entry, baseURL); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteString("}\n")
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	return code, nil
+}
+
+func generateEntry(buf *bytes.Buffer, entry generatedEntry, baseURL *url.URL) error {
+	u, err := url.Parse(entry.url)
+	if err != nil {
+		return fmt.Errorf("invalid request url %q: %w", entry.url, err)
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	basePath := strings.TrimSuffix(baseURL.EscapedPath(), "/")
+
+	if originOf(u) == originOf(baseURL) && strings.HasPrefix(path, basePath) {
+		fmt.Fprintf(buf, "\te.%s", generateMethod(entry.method,
+			strings.TrimPrefix(path, basePath)))
+	} else {
+		fmt.Fprintf(buf, "\te.%s.\n\t\tWithURL(%s)", generateMethod(entry.method, path),
+			strconv.Quote(originOf(u)))
+	}
+
+	query := u.Query()
+	for _, key := range sortedQueryKeys(query) {
+		for _, value := range query[key] {
+			fmt.Fprintf(buf, ".\n\t\tWithQuery(%s, %s)",
+				strconv.Quote(key), strconv.Quote(value))
+		}
+	}
+
+	headerKeys := make([]string, 0, len(entry.header))
+	for key := range entry.header {
+		if !generatedSkipHeaders[http.CanonicalHeaderKey(key)] {
+			headerKeys = append(headerKeys, key)
+		}
+	}
+	sort.Strings(headerKeys)
+
+	for _, key := range headerKeys {
+		for _, value := range entry.header[key] {
+			fmt.Fprintf(buf, ".\n\t\tWithHeader(%s, %s)",
+				strconv.Quote(key), strconv.Quote(value))
+		}
+	}
+
+	if len(entry.body) != 0 {
+		fmt.Fprintf(buf, ".\n\t\tWithBytes([]byte(%s))", goStringLiteral(entry.body))
+	}
+
+	buf.WriteString(".\n\t\tExpect()")
+
+	if entry.hasResponse {
+		fmt.Fprintf(buf, ".\n\t\tStatus(%d)", entry.status)
+		generateJSONAssertions(buf, entry)
+	}
+
+	buf.WriteString("\n")
+
+	return nil
+}
+
+// Format Expect method call, using shorthand methods when possible.
+func generateMethod(method, path string) string {
+	switch method {
+	case http.MethodOptions, http.MethodHead, http.MethodGet, http.MethodPost,
+		http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return fmt.Sprintf("%s(%s)", method, strconv.Quote(path))
+	default:
+		return fmt.Sprintf("Request(%s, %s)", strconv.Quote(method), strconv.Quote(path))
+	}
+}
+
+func generateJSONAssertions(buf *bytes.Buffer, entry generatedEntry) {
+	mediaType, _, _ := mime.ParseMediaType(entry.respHeader.Get("Content-Type"))
+	if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+		return
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(entry.respBody, &body); err != nil {
+		return
+	}
+
+	switch v := body.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		if len(keys) == 0 {
+			buf.WriteString(".\n\t\tJSON().Object().IsEmpty()")
+			return
+		}
+
+		quoted := make([]string, 0, len(keys))
+		for _, key := range keys {
+			quoted = append(quoted, strconv.Quote(key))
+		}
+
+		fmt.Fprintf(buf, ".\n\t\tJSON().Object().Keys().ContainsAll(%s)",
+			strings.Join(quoted, ", "))
+
+	case []interface{}:
+		fmt.Fprintf(buf, ".\n\t\tJSON().Array().Length().IsEqual(%d)", len(v))
+	}
+}
+
+func originOf(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
+
+func sortedQueryKeys(query url.Values) []string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Format bytes as Go string literal, preferring raw string literal
+// for readability.
+func goStringLiteral(b []byte) string {
+	s := string(b)
+
+	if utf8.ValidString(s) && !strings.ContainsAny(s, "`\r") && !hasControlChars(s) {
+		return "`" + s + "`"
+	}
+
+	return strconv.Quote(s)
+}
+
+func hasControlChars(s string) bool {
+	for _, r := range s {
+		if r < 0x20 && r != '\n' && r != '\t' {
+			return true
+		}
+	}
+	return false
+}
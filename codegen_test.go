@@ -0,0 +1,279 @@
+package httpexpect
+
+import (
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodegen_HAR(t *testing.T) {
+	t.Run("entries", func(t *testing.T) {
+		har := `{"log": {"entries": [
+  {
+    "request": {
+      "method": "GET",
+      "url": "http://example.com/users?limit=10&a=b",
+      "headers": [
+        {"name": ":authority", "value": "example.com"},
+        {"name": "Accept", "value": "application/json"},
+        {"name": "User-Agent", "value": "curl"}
+      ]
+    },
+    "response": {
+      "status": 200,
+      "headers": [],
+      "content": {
+        "mimeType": "application/json",
+        "text": "{\"name\": \"john\", \"id\": 1}"
+      }
+    }
+  },
+  {
+    "request": {
+      "method": "POST",
+      "url": "https://other.example.com/items",
+      "headers": [],
+      "postData": {"mimeType": "application/json", "text": "{\"x\": 1}"}
+    },
+    "response": {
+      "status": 201,
+      "headers": [{"name": "Content-Type", "value": "application/json"}],
+      "content": {"text": "WzEsMiwzXQ==", "encoding": "base64"}
+    }
+  },
+  {
+    "request": {
+      "method": "PURGE",
+      "url": "http://example.com/cache",
+      "headers": []
+    },
+    "response": {
+      "status": 204,
+      "headers": [],
+      "content": {"mimeType": "text/plain", "text": ""}
+    }
+  },
+  {
+    "request": {
+      "method": "DELETE",
+      "url": "http://example.com/items/1",
+      "headers": []
+    }
+  }
+]}}`
+
+		code, err := GenerateTestFromHAR(strings.NewReader(har), GenerateOptions{
+			Package:  "legacy_test",
+			TestName: "TestLegacy",
+		})
+		require.NoError(t, err)
+
+		expected := "// Code generated by httpexpect. Review and edit before use.\n" +
+			`
+package legacy_test
+
+import (
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+)
+
+func TestLegacy(t *testing.T) {
+	e := httpexpect.Default(t, "http://example.com")
+
+	e.GET("/users").
+		WithQuery("a", "b").
+		WithQuery("limit", "10").
+		WithHeader("Accept", "application/json").
+		Expect().
+		Status(200).
+		JSON().Object().Keys().ContainsAll("id", "name")
+
+	e.POST("/items").
+		WithURL("https://other.example.com").
+		WithHeader("Content-Type", "application/json").
+		WithBytes([]byte(` + "`" + `{"x": 1}` + "`" + `)).
+		Expect().
+		Status(201).
+		JSON().Array().Length().IsEqual(3)
+
+	e.Request("PURGE", "/cache").
+		Expect().
+		Status(204)
+
+	e.DELETE("/items/1").
+		Expect()
+}
+`
+
+		assert.Equal(t, expected, string(code))
+	})
+
+	t.Run("base url", func(t *testing.T) {
+		har := `{"log": {"entries": [
+  {
+    "request": {"method": "GET", "url": "http://example.com/api/v1/users"},
+    "response": {
+      "status": 200,
+      "content": {"mimeType": "application/json", "text": "{}"}
+    }
+  },
+  {
+    "request": {"method": "GET", "url": "http://example.com/health"},
+    "response": {
+      "status": 200,
+      "content": {"mimeType": "text/plain", "text": "{}"}
+    }
+  }
+]}}`
+
+		code, err := GenerateTestFromHAR(strings.NewReader(har), GenerateOptions{
+			BaseURL: "http://example.com/api/v1/",
+		})
+		require.NoError(t, err)
+
+		assert.Contains(t, string(code), "package tests\n")
+		assert.Contains(t, string(code), "func TestRecorded(t *testing.T) {\n")
+		assert.Contains(t, string(code),
+			`e := httpexpect.Default(t, "http://example.com/api/v1/")`)
+
+		assert.Contains(t, string(code), `e.GET("/users").
+		Expect().
+		Status(200).
+		JSON().Object().IsEmpty()`)
+
+		assert.Contains(t, string(code), `e.GET("/health").
+		WithURL("http://example.com").
+		Expect().
+		Status(200)
+`)
+	})
+
+	t.Run("body literals", func(t *testing.T) {
+		har := `{"log": {"entries": [
+  {
+    "request": {
+      "method": "POST",
+      "url": "http://example.com/a",
+      "postData": {"mimeType": "text/plain", "text": "line1\nline2"}
+    }
+  },
+  {
+    "request": {
+      "method": "POST",
+      "url": "http://example.com/b",
+      "postData": {"mimeType": "text/plain", "text": "a` + "`" + `b\r\n"}
+    }
+  }
+]}}`
+
+		code, err := GenerateTestFromHAR(strings.NewReader(har), GenerateOptions{})
+		require.NoError(t, err)
+
+		assert.Contains(t, string(code), "WithBytes([]byte(`line1\nline2`))")
+		assert.Contains(t, string(code), "WithBytes([]byte(\"a`b\\r\\n\"))")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := []struct {
+			name string
+			har  string
+		}{
+			{
+				name: "syntax",
+				har:  `{"log": `,
+			},
+			{
+				name: "missing method",
+				har:  `{"log": {"entries": [{"request": {"url": "http://example.com"}}]}}`,
+			},
+			{
+				name: "invalid url",
+				har: `{"log": {"entries": [` +
+					`{"request": {"method": "GET", "url": "http://[::1"}}]}}`,
+			},
+			{
+				name: "invalid base64",
+				har: `{"log": {"entries": [` +
+					`{"request": {"method": "GET", "url": "http://example.com"},` +
+					` "response": {"status": 200,` +
+					` "content": {"text": "???", "encoding": "base64"}}}]}}`,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := GenerateTestFromHAR(strings.NewReader(tc.har), GenerateOptions{})
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestCodegen_RecordingTransport(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"name": "john"}, {"name": "bob"}]`))
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	transport := NewRecordingTransport(NewBinder(handler))
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: transport,
+		},
+	})
+
+	e.GET("/users").
+		WithHeader("Authorization", "Bearer token").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(2)
+
+	e.PUT("/missing").
+		WithText("hello").
+		Expect().
+		Status(http.StatusNotFound)
+
+	code, err := transport.GenerateTest(GenerateOptions{})
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "generated_test.go", code, 0)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(code), `e := httpexpect.Default(t, "http://example.com")`)
+
+	assert.Contains(t, string(code), `e.GET("/users").
+		WithHeader("Authorization", "Bearer token").
+		Expect().
+		Status(200).
+		JSON().Array().Length().IsEqual(2)
+`)
+
+	assert.Contains(t, string(code), `e.PUT("/missing").
+		WithHeader("Content-Type", "text/plain; charset=utf-8").
+		WithBytes([]byte(`+"`hello`"+`)).
+		Expect().
+		Status(404)
+`)
+
+	transport.Reset()
+
+	code, err = transport.GenerateTest(GenerateOptions{})
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(code), "e.GET")
+}
@@ -0,0 +1,431 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WithIfNoneMatch sets "If-None-Match" header to given entity tag.
+//
+// etag should be specified in the same form as it's returned by server
+// in "ETag" header, including quotes and optional weakness prefix, e.g.
+// `"xyzzy"` or `W/"xyzzy"`. Special value "*" is also allowed.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/users/1")
+//	req.WithIfNoneMatch(`"33a64df5"`)
+//	req.Expect().Status(http.StatusNotModified)
+func (r *Request) WithIfNoneMatch(etag string) *Request {
+	opChain := r.chain.enter("WithIfNoneMatch()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithIfNoneMatch()") {
+		return r
+	}
+
+	if etag == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty etag"),
+			},
+		})
+		return r
+	}
+
+	r.httpReq.Header.Set("If-None-Match", etag)
+
+	return r
+}
+
+// WithIfMatch sets "If-Match" header to given entity tag.
+//
+// It's typically used with PUT, PATCH, and DELETE requests to implement
+// optimistic concurrency: server should respond with "412 Precondition
+// Failed" if resource was modified and its current ETag doesn't match.
+//
+// etag should be specified in the same form as it's returned by server
+// in "ETag" header, including quotes. Special value "*" is also allowed.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "/users/1")
+//	req.WithIfMatch(`"33a64df5"`)
+//	req.Expect().Status(http.StatusPreconditionFailed)
+func (r *Request) WithIfMatch(etag string) *Request {
+	opChain := r.chain.enter("WithIfMatch()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithIfMatch()") {
+		return r
+	}
+
+	if etag == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty etag"),
+			},
+		})
+		return r
+	}
+
+	r.httpReq.Header.Set("If-Match", etag)
+
+	return r
+}
+
+// WithIfModifiedSince sets "If-Modified-Since" header to given time.
+//
+// Time is formatted according to HTTP spec (see http.TimeFormat) and is
+// truncated to seconds.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/users/1")
+//	req.WithIfModifiedSince(time.Now().Add(-time.Hour))
+//	req.Expect().Status(http.StatusNotModified)
+func (r *Request) WithIfModifiedSince(t time.Time) *Request {
+	opChain := r.chain.enter("WithIfModifiedSince()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithIfModifiedSince()") {
+		return r
+	}
+
+	r.httpReq.Header.Set("If-Modified-Since", t.UTC().Format(http.TimeFormat))
+
+	return r
+}
+
+// ExpectNotModifiedWhenRevalidated re-sends the request that produced
+// this response, with conditional headers built from validators of this
+// response, and succeeds if server responds with "304 Not Modified".
+//
+// If response has "ETag" header, it's sent in "If-None-Match" header.
+// If response has "Last-Modified" header, it's sent in "If-Modified-Since"
+// header. If response has neither, failure is reported.
+//
+// Only GET and HEAD requests can be revalidated. Request is re-sent with
+// the same URL and headers, using the same client, in the same way as by
+// Request.Expect: rate limit, request and response hooks, printers, and
+// metrics collector are applied. Redirect, retry, timeout, and
+// signing settings of the original request are preserved. Request
+// matchers are not invoked for the revalidation response.
+//
+// Returns a new Response instance for the revalidation response, which
+// can be used for further checks.
+//
+// Example:
+//
+//	resp := NewRequestC(config, "GET", "/users/1").Expect()
+//	resp.Status(http.StatusOK)
+//	resp.ExpectNotModifiedWhenRevalidated().
+//		Header("ETag").IsEqual(resp.Header("ETag").Raw())
+func (r *Response) ExpectNotModifiedWhenRevalidated() *Response {
+	opChain := r.chain.enter("ExpectNotModifiedWhenRevalidated()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	req := r.newRevalidationRequest(opChain)
+	if req == nil {
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	var resp *Response
+	if req.prepareSend(opChain, "Expect()") {
+		resp = req.execute(opChain)
+	}
+
+	if resp == nil {
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	opChain.setResponse(resp)
+
+	if resp.httpResp.StatusCode != http.StatusNotModified {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{statusCodeText(resp.httpResp.StatusCode)},
+			Expected: &AssertionValue{statusCodeText(http.StatusNotModified)},
+			Errors: []error{
+				errors.New("expected: revalidation request is answered with" +
+					" \"304 Not Modified\""),
+			},
+		})
+	}
+
+	return resp
+}
+
+// Create request which re-sends request that produced this response,
+// with conditional headers built from response validators.
+func (r *Response) newRevalidationRequest(opChain *chain) *Request {
+	etag := r.httpResp.Header.Get("ETag")
+	lastModified := r.httpResp.Header.Get("Last-Modified")
+
+	if etag == "" && lastModified == "" {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{r.httpResp.Header},
+			Expected: &AssertionValue{[]string{"ETag", "Last-Modified"}},
+			Errors: []error{
+				errors.New(
+					"expected: response has \"ETag\" or \"Last-Modified\" header"),
+			},
+		})
+		return nil
+	}
+
+	origReq := r.httpResp.Request
+	if origReq == nil || origReq.URL == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("can't revalidate response without request"),
+			},
+		})
+		return nil
+	}
+
+	if origReq.Method != http.MethodGet && origReq.Method != http.MethodHead {
+		opChain.fail(AssertionFailure{
+			Type:     AssertBelongs,
+			Actual:   &AssertionValue{origReq.Method},
+			Expected: &AssertionValue{AssertionList{http.MethodGet, http.MethodHead}},
+			Errors: []error{
+				errors.New("expected: revalidated request method is GET or HEAD"),
+			},
+		})
+		return nil
+	}
+
+	orig := opChain.context.Request
+
+	// new request replaces this one in context of reported failures
+	opChain.clearRequest()
+
+	var req *Request
+
+	if r.owner != nil {
+		req = r.owner.newRequest(opChain, origReq.Method, "")
+	} else {
+		req = newRequest(opChain, r.config, origReq.Method, "")
+	}
+
+	opChain.setRequest(req)
+
+	// original request is sent using the same client
+	req.config.Client = r.config.Client
+
+	// original URL already includes base path and query
+	urlCopy := *origReq.URL
+	req.httpReq.URL = &urlCopy
+	req.httpReq.Host = origReq.Host
+	req.httpReq.Header = origReq.Header.Clone()
+	req.basePath = ""
+	req.query = nil
+
+	if orig != nil {
+		req.redirectPolicy = orig.redirectPolicy
+		req.maxRedirects = orig.maxRedirects
+		req.retryPolicy = orig.retryPolicy
+		req.maxRetries = orig.maxRetries
+		req.minRetryDelay = orig.minRetryDelay
+		req.maxRetryDelay = orig.maxRetryDelay
+		req.retryJitter = orig.retryJitter
+		req.retryBudget = orig.retryBudget
+		req.retryAfter = orig.retryAfter
+		req.timeout = orig.timeout
+		req.unavailableSkipper = orig.unavailableSkipper
+		req.signers = orig.signers
+	}
+
+	// cookie jar will add cookies by itself
+	if httpClient, ok := req.config.Client.(*http.Client); ok && httpClient.Jar != nil {
+		req.httpReq.Header.Del("Cookie")
+	}
+
+	if etag != "" {
+		req.httpReq.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.httpReq.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	return req
+}
+
+// ConcurrencyScenario defines optimistic concurrency scenario,
+// see Expect.RunConcurrencyScenario.
+type ConcurrencyScenario struct {
+	// Resource path and path arguments, see Expect.Request.
+	// Used for both GET and update requests.
+	Path     string
+	PathArgs []interface{}
+
+	// Update request method.
+	// Default is PUT.
+	Method string
+
+	// Optional function to configure update requests, e.g. to set body.
+	// Invoked for every update request.
+	Update func(req *Request)
+
+	// Expected status of successful update.
+	// If zero, any 2xx status is accepted.
+	Status int
+}
+
+// RunConcurrencyScenario checks that resource supports optimistic
+// concurrency using "ETag" and "If-Match" headers.
+//
+// Scenario consists of three steps:
+//   - "get": GET request; succeeds if response has 2xx status and
+//     non-empty "ETag" header
+//   - "update": update request with "If-Match" set to captured ETag;
+//     succeeds if response has expected status
+//   - "stale update": the same update request with the same, now stale,
+//     ETag; succeeds if response has "412 Precondition Failed" status
+//
+// For the last step to make sense, update should modify the resource and
+// hence its ETag. If "update" response has "ETag" header equal to the
+// captured one, failure is reported.
+//
+// If a step fails, remaining steps are skipped. Returns map of responses
+// of executed steps, with step names as keys, which can be used for
+// further checks.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	resps := e.RunConcurrencyScenario(httpexpect.ConcurrencyScenario{
+//		Path:     "/users/{id}",
+//		PathArgs: []interface{}{1},
+//		Update: func(req *httpexpect.Request) {
+//			req.WithJSON(map[string]interface{}{"name": "john"})
+//		},
+//	})
+//
+//	resps["update"].JSON().Object().HasValue("name", "john")
+func (e *Expect) RunConcurrencyScenario(
+	scenario ConcurrencyScenario,
+) map[string]*Response {
+	opChain := e.chain.enter("RunConcurrencyScenario()")
+	defer opChain.leave()
+
+	resps := make(map[string]*Response, 3)
+
+	if opChain.failed() {
+		return resps
+	}
+
+	method := scenario.Method
+	if method == "" {
+		method = http.MethodPut
+	}
+
+	if method == http.MethodGet || method == http.MethodHead {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected update method %q", method),
+			},
+		})
+		return resps
+	}
+
+	getResp := e.newRequest(opChain, http.MethodGet, scenario.Path,
+		scenario.PathArgs...).
+		Alias("get").
+		Expect()
+
+	resps["get"] = getResp
+
+	getResp.StatusIsSuccess()
+	etag := getResp.Header("ETag").NotEmpty().Raw()
+
+	if getResp.chain.treeFailed() {
+		return resps
+	}
+
+	update := func(name string) *Response {
+		req := e.newRequest(opChain, method, scenario.Path, scenario.PathArgs...).
+			Alias(name).
+			WithIfMatch(etag)
+
+		if scenario.Update != nil {
+			scenario.Update(req)
+		}
+
+		resp := req.Expect()
+		resps[name] = resp
+
+		return resp
+	}
+
+	updateResp := update("update")
+
+	if scenario.Status != 0 {
+		updateResp.Status(scenario.Status)
+	} else {
+		updateResp.StatusIsSuccess()
+	}
+
+	if updateResp.chain.treeFailed() {
+		return resps
+	}
+
+	if updateResp.Raw().Header.Get("ETag") == etag {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotEqual,
+			Actual:   &AssertionValue{updateResp.Raw().Header.Get("ETag")},
+			Expected: &AssertionValue{etag},
+			Errors: []error{
+				errors.New("expected: update changes resource ETag"),
+			},
+		})
+		return resps
+	}
+
+	update("stale update").Status(http.StatusPreconditionFailed)
+
+	return resps
+}
@@ -0,0 +1,447 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_ConditionalHeaders(t *testing.T) {
+	t.Run("if-none-match", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		req := NewRequestC(config, "GET", "/").
+			WithIfNoneMatch(`W/"abc"`)
+		req.chain.assert(t, success)
+
+		assert.Equal(t, `W/"abc"`, req.httpReq.Header.Get("If-None-Match"))
+	})
+
+	t.Run("if-none-match, empty", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		req := NewRequestC(config, "GET", "/").
+			WithIfNoneMatch("")
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("if-match", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		req := NewRequestC(config, "PUT", "/").
+			WithIfMatch(`"abc"`)
+		req.chain.assert(t, success)
+
+		assert.Equal(t, `"abc"`, req.httpReq.Header.Get("If-Match"))
+	})
+
+	t.Run("if-match, empty", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		req := NewRequestC(config, "PUT", "/").
+			WithIfMatch("")
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("if-modified-since", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		tm := time.Date(2015, time.October, 21, 7, 28, 0, 0,
+			time.FixedZone("UTC+3", 3*60*60))
+
+		req := NewRequestC(config, "GET", "/").
+			WithIfModifiedSince(tm)
+		req.chain.assert(t, success)
+
+		assert.Equal(t, "Wed, 21 Oct 2015 04:28:00 GMT",
+			req.httpReq.Header.Get("If-Modified-Since"))
+	})
+}
+
+func TestResponse_ExpectNotModifiedWhenRevalidated(t *testing.T) {
+	const (
+		etag         = `"v1"`
+		lastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+	)
+
+	newHandler := func(
+		header http.Header, conditional bool, received *http.Header,
+	) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			isConditional := r.Header.Get("If-None-Match") != "" ||
+				r.Header.Get("If-Modified-Since") != ""
+			if isConditional {
+				*received = r.Header.Clone()
+				if conditional {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+			}
+			_, _ = w.Write([]byte("hello"))
+		})
+	}
+
+	cases := []struct {
+		name        string
+		method      string
+		header      http.Header
+		conditional bool
+		result      chainResult
+		wantETag    string
+		wantLastMod string
+	}{
+		{
+			name:        "etag",
+			method:      "GET",
+			header:      http.Header{"Etag": {etag}},
+			conditional: true,
+			result:      success,
+			wantETag:    etag,
+		},
+		{
+			name:        "last-modified",
+			method:      "GET",
+			header:      http.Header{"Last-Modified": {lastModified}},
+			conditional: true,
+			result:      success,
+			wantLastMod: lastModified,
+		},
+		{
+			name:   "etag and last-modified",
+			method: "HEAD",
+			header: http.Header{
+				"Etag":          {etag},
+				"Last-Modified": {lastModified},
+			},
+			conditional: true,
+			result:      success,
+			wantETag:    etag,
+			wantLastMod: lastModified,
+		},
+		{
+			name:        "not modified is not supported",
+			method:      "GET",
+			header:      http.Header{"Etag": {etag}},
+			conditional: false,
+			result:      failure,
+			wantETag:    etag,
+		},
+		{
+			name:        "no validators",
+			method:      "GET",
+			header:      http.Header{},
+			conditional: true,
+			result:      failure,
+		},
+		{
+			name:        "unsupported method",
+			method:      "POST",
+			header:      http.Header{"Etag": {etag}},
+			conditional: true,
+			result:      failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var received http.Header
+
+			config := newMockConfig(newMockReporter(t))
+			config.BaseURL = "http://example.com"
+			config.Client = &http.Client{
+				Transport: NewBinder(newHandler(tc.header, tc.conditional, &received)),
+			}
+
+			resp := NewRequestC(config, tc.method, "/path").
+				WithHeader("X-Custom", "foo").
+				Expect()
+			resp.chain.assert(t, success)
+
+			resp.Status(http.StatusOK)
+			resp.chain.assert(t, success)
+
+			revalidated := resp.ExpectNotModifiedWhenRevalidated()
+			resp.chain.assert(t, tc.result)
+
+			if tc.result == success {
+				revalidated.Status(http.StatusNotModified)
+				revalidated.chain.assert(t, success)
+			}
+
+			if tc.wantETag != "" || tc.wantLastMod != "" {
+				assert.Equal(t, tc.wantETag, received.Get("If-None-Match"))
+				assert.Equal(t, tc.wantLastMod, received.Get("If-Modified-Since"))
+				assert.Equal(t, "foo", received.Get("X-Custom"))
+			} else {
+				assert.Nil(t, received)
+			}
+		})
+	}
+
+	t.Run("pipeline", func(t *testing.T) {
+		var received http.Header
+
+		printer := &revalidationPrinter{}
+
+		var hooked []string
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = "http://example.com"
+		config.Client = &http.Client{
+			Transport: NewBinder(
+				newHandler(http.Header{"Etag": {etag}}, true, &received)),
+		}
+		config.Printers = []Printer{printer}
+		config.RequestHooks = []func(*http.Request) error{
+			func(req *http.Request) error {
+				hooked = append(hooked, req.Header.Get("If-None-Match"))
+				return nil
+			},
+		}
+
+		resp := NewRequestC(config, "GET", "/path").Expect()
+		resp.chain.assert(t, success)
+
+		resp.ExpectNotModifiedWhenRevalidated()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, []string{"", etag}, hooked)
+		assert.Equal(t, []string{"", etag}, printer.requests)
+		assert.Equal(t,
+			[]int{http.StatusOK, http.StatusNotModified}, printer.responses)
+	})
+
+	t.Run("request error", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		httpReq, _ := http.NewRequest("GET", "http://example.com", nil)
+
+		config.Client = ClientFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("test error")
+		})
+
+		resp := NewResponseC(config, &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Etag": {etag}},
+			Request:    httpReq,
+		})
+
+		resp.ExpectNotModifiedWhenRevalidated().chain.assert(t, failure)
+	})
+
+	t.Run("no request", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		resp := NewResponseC(config, &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Etag": {etag}},
+		})
+
+		resp.ExpectNotModifiedWhenRevalidated().chain.assert(t, failure)
+	})
+}
+
+type revalidationPrinter struct {
+	requests  []string
+	responses []int
+}
+
+func (p *revalidationPrinter) Request(req *http.Request) {
+	p.requests = append(p.requests, req.Header.Get("If-None-Match"))
+}
+
+func (p *revalidationPrinter) Response(resp *http.Response, _ time.Duration) {
+	p.responses = append(p.responses, resp.StatusCode)
+}
+
+func TestExpect_RunConcurrencyScenario(t *testing.T) {
+	type resource struct {
+		version   int
+		name      string
+		keepETag  bool
+		skipCheck bool
+	}
+
+	newHandler := func(res *resource, calls *int) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*calls++
+
+			if r.URL.Path != "/users/1" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			etag := fmt.Sprintf(`"v%d"`, res.version)
+
+			switch r.Method {
+			case http.MethodGet:
+				w.Header().Set("ETag", etag)
+				_, _ = w.Write([]byte(res.name))
+
+			case http.MethodPut, http.MethodPatch:
+				if !res.skipCheck && r.Header.Get("If-Match") != etag {
+					w.WriteHeader(http.StatusPreconditionFailed)
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				res.name = string(body)
+				if !res.keepETag {
+					res.version++
+				}
+				w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, res.version))
+				_, _ = w.Write(body)
+
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		})
+	}
+
+	newExpect := func(
+		t *testing.T, res *resource, calls *int,
+	) (*Expect, *mockReporter) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(newHandler(res, calls)),
+			},
+		})
+
+		return e, reporter
+	}
+
+	update := func(req *Request) {
+		req.WithText("john")
+	}
+
+	t.Run("success", func(t *testing.T) {
+		res := &resource{}
+		calls := 0
+		e, reporter := newExpect(t, res, &calls)
+
+		resps := e.RunConcurrencyScenario(ConcurrencyScenario{
+			Path:     "/users/{id}",
+			PathArgs: []interface{}{1},
+			Update:   update,
+		})
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, 3, calls)
+		require.Equal(t, 3, len(resps))
+
+		resps["get"].Header("ETag").IsEqual(`"v0"`)
+		resps["update"].Header("ETag").IsEqual(`"v1"`)
+		resps["update"].Body().IsEqual("john")
+		resps["stale update"].Status(http.StatusPreconditionFailed)
+
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("method and status", func(t *testing.T) {
+		res := &resource{}
+		calls := 0
+		e, reporter := newExpect(t, res, &calls)
+
+		resps := e.RunConcurrencyScenario(ConcurrencyScenario{
+			Path:   "/users/1",
+			Method: http.MethodPatch,
+			Update: update,
+			Status: http.StatusOK,
+		})
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, 3, len(resps))
+	})
+
+	t.Run("unexpected status", func(t *testing.T) {
+		res := &resource{}
+		calls := 0
+		e, reporter := newExpect(t, res, &calls)
+
+		resps := e.RunConcurrencyScenario(ConcurrencyScenario{
+			Path:   "/users/1",
+			Update: update,
+			Status: http.StatusCreated,
+		})
+
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, 2, len(resps))
+		assert.Nil(t, resps["stale update"])
+	})
+
+	t.Run("missing resource", func(t *testing.T) {
+		res := &resource{}
+		calls := 0
+		e, reporter := newExpect(t, res, &calls)
+
+		resps := e.RunConcurrencyScenario(ConcurrencyScenario{
+			Path:   "/users/2",
+			Update: update,
+		})
+
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, 1, len(resps))
+	})
+
+	t.Run("etag not changed", func(t *testing.T) {
+		res := &resource{keepETag: true}
+		calls := 0
+		e, reporter := newExpect(t, res, &calls)
+
+		resps := e.RunConcurrencyScenario(ConcurrencyScenario{
+			Path:   "/users/1",
+			Update: update,
+		})
+
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, 2, len(resps))
+	})
+
+	t.Run("stale etag accepted", func(t *testing.T) {
+		res := &resource{skipCheck: true}
+		calls := 0
+		e, reporter := newExpect(t, res, &calls)
+
+		resps := e.RunConcurrencyScenario(ConcurrencyScenario{
+			Path:   "/users/1",
+			Update: update,
+		})
+
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, 3, len(resps))
+		assert.True(t, resps["stale update"].chain.failed())
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		res := &resource{}
+		calls := 0
+		e, reporter := newExpect(t, res, &calls)
+
+		resps := e.RunConcurrencyScenario(ConcurrencyScenario{
+			Path:   "/users/1",
+			Method: http.MethodGet,
+		})
+
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 0, calls)
+		assert.Equal(t, 0, len(resps))
+	})
+}
@@ -0,0 +1,72 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+)
+
+// ConnectionStats contains number of connections used by requests
+// created by Expect instance, see Expect.ConnectionStats.
+type ConnectionStats struct {
+	// Number of requests sent over a newly established connection.
+	New int
+
+	// Number of requests sent over a connection reused from pool
+	// of idle keep-alive connections.
+	Reused int
+}
+
+// Shared between Expect instance and its copies.
+type connectionCounter struct {
+	mu    sync.Mutex
+	stats ConnectionStats
+}
+
+func (c *connectionCounter) add(reused bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if reused {
+		c.stats.Reused++
+	} else {
+		c.stats.New++
+	}
+}
+
+func (c *connectionCounter) get() ConnectionStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
+
+// Returns request with attached httptrace hook, which reports whether
+// connection was reused to owner Expect instance and to onConn, if it
+// is non-nil.
+//
+// If request is redirected, hook is invoked for every request in
+// redirect chain. If client doesn't use httptrace hooks, e.g. Binder,
+// hook is not invoked at all.
+func (r *Request) traceConnection(
+	httpReq *http.Request, onConn func(reused bool),
+) *http.Request {
+	var counter *connectionCounter
+	if r.owner != nil {
+		counter = r.owner.connCounter
+	}
+
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if counter != nil {
+				counter.add(info.Reused)
+			}
+			if onConn != nil {
+				onConn(info.Reused)
+			}
+		},
+	}
+
+	return httpReq.WithContext(
+		httptrace.WithClientTrace(httpReq.Context(), trace))
+}
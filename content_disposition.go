@@ -0,0 +1,183 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// ContentDisposition provides methods to inspect attached Content-Disposition
+// header value, as defined by RFC 6266.
+type ContentDisposition struct {
+	noCopy   noCopy
+	chain    *chain
+	value    string
+	dispType string
+	params   map[string]string
+}
+
+// NewContentDisposition returns a new ContentDisposition instance given
+// Content-Disposition header value.
+//
+// If reporter is nil, the function panics.
+// If value can't be parsed, failure is reported.
+//
+// Example:
+//
+//	cd := NewContentDisposition(t, `attachment; filename="report.pdf"`)
+//
+//	cd.Type().IsEqual("attachment")
+//	cd.Filename().IsEqual("report.pdf")
+func NewContentDisposition(reporter Reporter, value string) *ContentDisposition {
+	return newContentDisposition(
+		newChainWithDefaults("ContentDisposition()", reporter), value)
+}
+
+// NewContentDispositionC returns a new ContentDisposition instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value can't be parsed, failure is reported.
+//
+// See NewContentDisposition for usage example.
+func NewContentDispositionC(config Config, value string) *ContentDisposition {
+	return newContentDisposition(
+		newChainWithConfig("ContentDisposition()", config.withDefaults()), value)
+}
+
+func newContentDisposition(parent *chain, val string) *ContentDisposition {
+	cd := &ContentDisposition{chain: parent.clone(), value: val}
+
+	opChain := cd.chain.enter("")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return cd
+	}
+
+	dispType, params, err := mime.ParseMediaType(val)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: valid Content-Disposition header"),
+				err,
+			},
+		})
+		return cd
+	}
+
+	cd.dispType = dispType
+	cd.params = params
+
+	return cd
+}
+
+// Raw returns underlying Content-Disposition header value.
+// This is the value originally passed to NewContentDisposition.
+//
+// Example:
+//
+//	cd := NewContentDisposition(t, `attachment; filename="report.pdf"`)
+//	assert.Equal(t, `attachment; filename="report.pdf"`, cd.Raw())
+func (cd *ContentDisposition) Raw() string {
+	return cd.value
+}
+
+// Alias is similar to Value.Alias.
+func (cd *ContentDisposition) Alias(name string) *ContentDisposition {
+	opChain := cd.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	cd.chain.setAlias(name)
+	return cd
+}
+
+// Type returns a new String instance with lower-cased disposition type,
+// e.g. "attachment" or "inline".
+//
+// Example:
+//
+//	cd := NewContentDisposition(t, `attachment; filename="report.pdf"`)
+//	cd.Type().IsEqual("attachment")
+func (cd *ContentDisposition) Type() *String {
+	opChain := cd.chain.enter("Type()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, cd.dispType)
+}
+
+// Filename returns a new String instance with file name from "filename"
+// parameter.
+//
+// If "filename*" parameter with RFC 5987 encoding is present, its decoded
+// value takes precedence, as recommended by RFC 6266.
+// If there is no file name, failure is reported.
+//
+// Example:
+//
+//	cd := NewContentDisposition(t,
+//		`attachment; filename="report.pdf"; filename*=UTF-8''%D0%BE%D1%82.pdf`)
+//	cd.Filename().IsEqual("от.pdf")
+func (cd *ContentDisposition) Filename() *String {
+	opChain := cd.chain.enter("Filename()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	// mime.ParseMediaType decodes "filename*" and stores it as "filename"
+	filename, ok := cd.params["filename"]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{cd.value},
+			Expected: &AssertionValue{"filename"},
+			Errors: []error{
+				errors.New("expected: Content-Disposition has file name"),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, filename)
+}
+
+// Param returns a new String instance with value of given parameter.
+// Parameter name is case-insensitive.
+//
+// If there is no such parameter, failure is reported.
+//
+// Example:
+//
+//	cd := NewContentDisposition(t, `form-data; name="file"; filename="a.txt"`)
+//	cd.Param("name").IsEqual("file")
+func (cd *ContentDisposition) Param(name string) *String {
+	opChain := cd.chain.enter("Param(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	value, ok := cd.params[strings.ToLower(name)]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{cd.value},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: Content-Disposition has parameter %q", name),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, value)
+}
@@ -0,0 +1,141 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentDisposition_FailedChain(t *testing.T) {
+	check := func(value *ContentDisposition) {
+		value.chain.assert(t, failure)
+
+		value.Alias("foo")
+
+		value.Type().chain.assert(t, failure)
+		value.Filename().chain.assert(t, failure)
+		value.Param("foo").chain.assert(t, failure)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newContentDisposition(chain, "attachment; filename=a.txt")
+
+		check(value)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newContentDisposition(chain, "attachment; filename")
+
+		check(value)
+	})
+}
+
+func TestContentDisposition_Constructors(t *testing.T) {
+	const header = `attachment; filename="report.pdf"`
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewContentDisposition(reporter, header)
+		value.chain.assert(t, success)
+		assert.Equal(t, header, value.Raw())
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewContentDispositionC(Config{
+			Reporter: reporter,
+		}, header)
+		value.chain.assert(t, success)
+		assert.Equal(t, header, value.Raw())
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newContentDisposition(chain, header)
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestContentDisposition_Getters(t *testing.T) {
+	t.Run("quoted", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewContentDisposition(reporter,
+			`Attachment; filename="annual report.pdf"; Size=1024`)
+
+		value.Type().IsEqual("attachment").
+			chain.assert(t, success)
+		value.Filename().IsEqual("annual report.pdf").
+			chain.assert(t, success)
+		value.Param("size").IsEqual("1024").
+			chain.assert(t, success)
+		value.Param("SIZE").IsEqual("1024").
+			chain.assert(t, success)
+	})
+
+	t.Run("extended", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewContentDisposition(reporter,
+			`attachment; filename="report.pdf"; `+
+				`filename*=UTF-8''%D0%BE%D1%82%D1%87%D0%B5%D1%82.pdf`)
+
+		value.Filename().IsEqual("отчет.pdf").
+			chain.assert(t, success)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewContentDisposition(reporter, "inline")
+
+		value.Type().IsEqual("inline").
+			chain.assert(t, success)
+		value.Filename().
+			chain.assert(t, failure)
+		value.Param("name").
+			chain.assert(t, failure)
+	})
+}
+
+func TestContentDisposition_Response(t *testing.T) {
+	newResponse := func(header string) *Response {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewBufferString("%PDF-1.4")),
+		}
+		if header != "" {
+			httpResp.Header.Set("Content-Disposition", header)
+		}
+		return NewResponse(newMockReporter(t), httpResp)
+	}
+
+	t.Run("present", func(t *testing.T) {
+		resp := newResponse(`attachment; filename="report.pdf"`)
+
+		resp.ContentDisposition().Filename().IsEqual("report.pdf")
+		resp.Body().HasLength(8).LengthInRange(1, 100)
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		resp := newResponse("")
+
+		resp.ContentDisposition().chain.assert(t, failure)
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp := newResponse("attachment; filename")
+
+		resp.ContentDisposition().chain.assert(t, failure)
+		resp.chain.assert(t, failure)
+	})
+}
@@ -0,0 +1,490 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORS provides methods to inspect CORS headers of response, as defined
+// by Fetch standard (Access-Control-Allow-Origin, Access-Control-Allow-Methods,
+// Access-Control-Allow-Headers, Access-Control-Allow-Credentials,
+// Access-Control-Expose-Headers, and Access-Control-Max-Age).
+//
+// Assertions check headers coherently, the same way as browsers do. For
+// example, wildcard "*" values don't allow anything if credentials are
+// allowed, and Authorization header is never covered by wildcard.
+type CORS struct {
+	noCopy noCopy
+	chain  *chain
+	header http.Header
+}
+
+// NewCORS returns a new CORS instance given response headers.
+//
+// If reporter is nil, the function panics.
+// If header is nil, failure is reported.
+//
+// Example:
+//
+//	cors := NewCORS(t, response.Header)
+//
+//	cors.AllowsOrigin("https://app.example.com")
+//	cors.AllowsMethod("PUT")
+func NewCORS(reporter Reporter, header http.Header) *CORS {
+	return newCORS(newChainWithDefaults("CORS()", reporter), header)
+}
+
+// NewCORSC returns a new CORS instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If header is nil, failure is reported.
+//
+// See NewCORS for usage example.
+func NewCORSC(config Config, header http.Header) *CORS {
+	return newCORS(newChainWithConfig("CORS()", config.withDefaults()), header)
+}
+
+func newCORS(parent *chain, header http.Header) *CORS {
+	c := &CORS{chain: parent.clone(), header: nil}
+
+	opChain := c.chain.enter("")
+	defer opChain.leave()
+
+	if header == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: non-nil header"),
+			},
+		})
+	} else {
+		c.header = header
+	}
+
+	return c
+}
+
+// Raw returns underlying http.Header value attached to CORS.
+// This is the value originally passed to NewCORS.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	assert.Equal(t, header, cors.Raw())
+func (c *CORS) Raw() http.Header {
+	return c.header
+}
+
+// Alias is similar to Value.Alias.
+func (c *CORS) Alias(name string) *CORS {
+	opChain := c.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	c.chain.setAlias(name)
+	return c
+}
+
+// AllowsOrigin succeeds if response allows requests from given origin.
+//
+// Access-Control-Allow-Origin should be equal to origin, or should be "*"
+// if credentials are not allowed.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.AllowsOrigin("https://app.example.com")
+func (c *CORS) AllowsOrigin(origin string) *CORS {
+	opChain := c.chain.enter("AllowsOrigin()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.allowsOrigin(origin) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{c.header.Values("Access-Control-Allow-Origin")},
+			Expected: &AssertionValue{origin},
+			Errors: []error{
+				fmt.Errorf("expected: response allows origin %q", origin),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotAllowsOrigin succeeds if response doesn't allow requests from given
+// origin.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.NotAllowsOrigin("https://evil.example.com")
+func (c *CORS) NotAllowsOrigin(origin string) *CORS {
+	opChain := c.chain.enter("NotAllowsOrigin()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.allowsOrigin(origin) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotEqual,
+			Actual:   &AssertionValue{c.header.Values("Access-Control-Allow-Origin")},
+			Expected: &AssertionValue{origin},
+			Errors: []error{
+				fmt.Errorf("expected: response does not allow origin %q", origin),
+			},
+		})
+	}
+
+	return c
+}
+
+// AllowsMethod succeeds if response allows given request method.
+//
+// Access-Control-Allow-Methods should contain method, or should contain "*"
+// if credentials are not allowed. Methods are case-sensitive.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.AllowsMethod("PUT")
+func (c *CORS) AllowsMethod(method string) *CORS {
+	opChain := c.chain.enter("AllowsMethod()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	methods := c.list("Access-Control-Allow-Methods")
+
+	if !c.listAllows(methods, method, false) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{methods},
+			Expected: &AssertionValue{method},
+			Errors: []error{
+				fmt.Errorf("expected: response allows method %q", method),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotAllowsMethod succeeds if response doesn't allow given request method.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.NotAllowsMethod("DELETE")
+func (c *CORS) NotAllowsMethod(method string) *CORS {
+	opChain := c.chain.enter("NotAllowsMethod()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	methods := c.list("Access-Control-Allow-Methods")
+
+	if c.listAllows(methods, method, false) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsElement,
+			Actual:   &AssertionValue{methods},
+			Expected: &AssertionValue{method},
+			Errors: []error{
+				fmt.Errorf("expected: response does not allow method %q", method),
+			},
+		})
+	}
+
+	return c
+}
+
+// AllowsHeader succeeds if response allows given request header.
+//
+// Access-Control-Allow-Headers should contain header, or should contain "*"
+// if credentials are not allowed. Header names are case-insensitive.
+// Authorization header is never covered by "*".
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.AllowsHeader("Content-Type")
+func (c *CORS) AllowsHeader(name string) *CORS {
+	opChain := c.chain.enter("AllowsHeader()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	headers := c.list("Access-Control-Allow-Headers")
+
+	if !c.listAllows(headers, name, true, "Authorization") {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{headers},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: response allows header %q", name),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotAllowsHeader succeeds if response doesn't allow given request header.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.NotAllowsHeader("X-Debug")
+func (c *CORS) NotAllowsHeader(name string) *CORS {
+	opChain := c.chain.enter("NotAllowsHeader()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	headers := c.list("Access-Control-Allow-Headers")
+
+	if c.listAllows(headers, name, true, "Authorization") {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsElement,
+			Actual:   &AssertionValue{headers},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: response does not allow header %q", name),
+			},
+		})
+	}
+
+	return c
+}
+
+// ExposesHeader succeeds if response exposes given response header to
+// scripts.
+//
+// Access-Control-Expose-Headers should contain header, or should contain "*"
+// if credentials are not allowed. Header names are case-insensitive.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.ExposesHeader("X-Request-Id")
+func (c *CORS) ExposesHeader(name string) *CORS {
+	opChain := c.chain.enter("ExposesHeader()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	headers := c.list("Access-Control-Expose-Headers")
+
+	if !c.listAllows(headers, name, true) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{headers},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: response exposes header %q", name),
+			},
+		})
+	}
+
+	return c
+}
+
+// AllowsCredentials succeeds if response allows credentials, i.e.
+// Access-Control-Allow-Credentials is "true".
+//
+// Since wildcard origin can't be used with credentials, AllowsCredentials
+// also fails if Access-Control-Allow-Origin is "*".
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.AllowsCredentials()
+func (c *CORS) AllowsCredentials() *CORS {
+	opChain := c.chain.enter("AllowsCredentials()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.allowsCredentials() {
+		opChain.fail(AssertionFailure{
+			Type: AssertEqual,
+			Actual: &AssertionValue{
+				c.header.Values("Access-Control-Allow-Credentials"),
+			},
+			Expected: &AssertionValue{"true"},
+			Errors: []error{
+				errors.New("expected: response allows credentials"),
+			},
+		})
+		return c
+	}
+
+	if c.header.Get("Access-Control-Allow-Origin") == "*" {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.header.Get("Access-Control-Allow-Origin")},
+			Errors: []error{
+				errors.New("expected: response allows credentials"),
+				errors.New("wildcard origin can't be used with credentials"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotAllowsCredentials succeeds if response doesn't allow credentials.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.NotAllowsCredentials()
+func (c *CORS) NotAllowsCredentials() *CORS {
+	opChain := c.chain.enter("NotAllowsCredentials()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.allowsCredentials() {
+		opChain.fail(AssertionFailure{
+			Type: AssertNotEqual,
+			Actual: &AssertionValue{
+				c.header.Values("Access-Control-Allow-Credentials"),
+			},
+			Expected: &AssertionValue{"true"},
+			Errors: []error{
+				errors.New("expected: response does not allow credentials"),
+			},
+		})
+	}
+
+	return c
+}
+
+// MaxAge returns a new Duration instance with value of Access-Control-Max-Age
+// header, which defines for how long preflight results can be cached.
+//
+// If header is missing, returned Duration is not set. If header is not
+// a non-negative integer, failure is reported.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.MaxAge().IsEqual(10 * time.Minute)
+func (c *CORS) MaxAge() *Duration {
+	opChain := c.chain.enter("MaxAge()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	value := c.header.Get("Access-Control-Max-Age")
+	if value == "" {
+		return newDuration(opChain, nil)
+	}
+
+	seconds, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`invalid "Access-Control-Max-Age" header`),
+				err,
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	maxAge := time.Duration(seconds) * time.Second
+
+	return newDuration(opChain, &maxAge)
+}
+
+func (c *CORS) allowsOrigin(origin string) bool {
+	values := c.header.Values("Access-Control-Allow-Origin")
+	if len(values) != 1 {
+		return false
+	}
+
+	if values[0] == "*" {
+		return !c.allowsCredentials()
+	}
+
+	return values[0] == origin
+}
+
+func (c *CORS) allowsCredentials() bool {
+	return c.header.Get("Access-Control-Allow-Credentials") == "true"
+}
+
+// Merge comma-separated values of all header lines.
+func (c *CORS) list(key string) []string {
+	list := []string{}
+
+	for _, value := range c.header.Values(key) {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+
+	return list
+}
+
+// Check if list contains item or wildcard. Wildcard is ignored if credentials
+// are allowed, and for items listed in noWildcard.
+func (c *CORS) listAllows(
+	list []string, item string, foldCase bool, noWildcard ...string,
+) bool {
+	for _, v := range list {
+		if v == item || (foldCase && strings.EqualFold(v, item)) {
+			return true
+		}
+	}
+
+	if c.allowsCredentials() {
+		return false
+	}
+
+	for _, v := range noWildcard {
+		if strings.EqualFold(v, item) {
+			return false
+		}
+	}
+
+	for _, v := range list {
+		if v == "*" {
+			return true
+		}
+	}
+
+	return false
+}
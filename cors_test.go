@@ -0,0 +1,502 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS_FailedChain(t *testing.T) {
+	check := func(value *CORS, isNil bool) {
+		value.chain.assert(t, failure)
+
+		if isNil {
+			assert.Nil(t, value.Raw())
+		} else {
+			assert.NotNil(t, value.Raw())
+		}
+
+		value.Alias("foo")
+
+		value.AllowsOrigin("https://example.com")
+		value.NotAllowsOrigin("https://example.com")
+		value.AllowsMethod("PUT")
+		value.NotAllowsMethod("PUT")
+		value.AllowsHeader("Content-Type")
+		value.NotAllowsHeader("Content-Type")
+		value.ExposesHeader("X-Request-Id")
+		value.AllowsCredentials()
+		value.NotAllowsCredentials()
+
+		value.MaxAge().chain.assert(t, failure)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newCORS(chain, http.Header{})
+
+		check(value, false)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newCORS(chain, nil)
+
+		check(value, true)
+	})
+
+	t.Run("failed chain, nil value", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newCORS(chain, nil)
+
+		check(value, true)
+	})
+}
+
+func TestCORS_Constructors(t *testing.T) {
+	header := http.Header{
+		"Access-Control-Allow-Origin": {"https://example.com"},
+	}
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewCORS(reporter, header)
+		value.AllowsOrigin("https://example.com")
+		value.chain.assert(t, success)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewCORSC(Config{
+			Reporter: reporter,
+		}, header)
+		value.AllowsOrigin("https://example.com")
+		value.chain.assert(t, success)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newCORS(chain, header)
+		assert.NotSame(t, value.chain, &chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestCORS_Raw(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	header := http.Header{}
+
+	value := NewCORS(reporter, header)
+
+	assert.Equal(t, header, value.Raw())
+	value.chain.assert(t, success)
+}
+
+func TestCORS_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewCORS(reporter, http.Header{})
+	assert.Equal(t, []string{"CORS()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"CORS()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"CORS()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+}
+
+func TestCORS_Origin(t *testing.T) {
+	cases := []struct {
+		name          string
+		header        http.Header
+		origin        string
+		expectAllowed bool
+	}{
+		{
+			name: "exact origin",
+			header: http.Header{
+				"Access-Control-Allow-Origin": {"https://example.com"},
+			},
+			origin:        "https://example.com",
+			expectAllowed: true,
+		},
+		{
+			name: "other origin",
+			header: http.Header{
+				"Access-Control-Allow-Origin": {"https://example.com"},
+			},
+			origin:        "https://evil.com",
+			expectAllowed: false,
+		},
+		{
+			name: "wildcard",
+			header: http.Header{
+				"Access-Control-Allow-Origin": {"*"},
+			},
+			origin:        "https://example.com",
+			expectAllowed: true,
+		},
+		{
+			name: "wildcard with credentials",
+			header: http.Header{
+				"Access-Control-Allow-Origin":      {"*"},
+				"Access-Control-Allow-Credentials": {"true"},
+			},
+			origin:        "https://example.com",
+			expectAllowed: false,
+		},
+		{
+			name: "multiple values",
+			header: http.Header{
+				"Access-Control-Allow-Origin": {"https://example.com", "https://foo.com"},
+			},
+			origin:        "https://example.com",
+			expectAllowed: false,
+		},
+		{
+			name:          "missing",
+			header:        http.Header{},
+			origin:        "https://example.com",
+			expectAllowed: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			if tc.expectAllowed {
+				NewCORS(reporter, tc.header).AllowsOrigin(tc.origin).
+					chain.assert(t, success)
+
+				NewCORS(reporter, tc.header).NotAllowsOrigin(tc.origin).
+					chain.assert(t, failure)
+			} else {
+				NewCORS(reporter, tc.header).AllowsOrigin(tc.origin).
+					chain.assert(t, failure)
+
+				NewCORS(reporter, tc.header).NotAllowsOrigin(tc.origin).
+					chain.assert(t, success)
+			}
+		})
+	}
+}
+
+func TestCORS_Method(t *testing.T) {
+	cases := []struct {
+		name          string
+		header        http.Header
+		method        string
+		expectAllowed bool
+	}{
+		{
+			name: "listed",
+			header: http.Header{
+				"Access-Control-Allow-Methods": {"GET, PUT"},
+			},
+			method:        "PUT",
+			expectAllowed: true,
+		},
+		{
+			name: "multiple header lines",
+			header: http.Header{
+				"Access-Control-Allow-Methods": {"GET", "PUT"},
+			},
+			method:        "PUT",
+			expectAllowed: true,
+		},
+		{
+			name: "case-sensitive",
+			header: http.Header{
+				"Access-Control-Allow-Methods": {"GET, PUT"},
+			},
+			method:        "put",
+			expectAllowed: false,
+		},
+		{
+			name: "not listed",
+			header: http.Header{
+				"Access-Control-Allow-Methods": {"GET, PUT"},
+			},
+			method:        "DELETE",
+			expectAllowed: false,
+		},
+		{
+			name: "wildcard",
+			header: http.Header{
+				"Access-Control-Allow-Methods": {"*"},
+			},
+			method:        "DELETE",
+			expectAllowed: true,
+		},
+		{
+			name: "wildcard with credentials",
+			header: http.Header{
+				"Access-Control-Allow-Methods":     {"*"},
+				"Access-Control-Allow-Credentials": {"true"},
+			},
+			method:        "DELETE",
+			expectAllowed: false,
+		},
+		{
+			name:          "missing",
+			header:        http.Header{},
+			method:        "PUT",
+			expectAllowed: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			if tc.expectAllowed {
+				NewCORS(reporter, tc.header).AllowsMethod(tc.method).
+					chain.assert(t, success)
+
+				NewCORS(reporter, tc.header).NotAllowsMethod(tc.method).
+					chain.assert(t, failure)
+			} else {
+				NewCORS(reporter, tc.header).AllowsMethod(tc.method).
+					chain.assert(t, failure)
+
+				NewCORS(reporter, tc.header).NotAllowsMethod(tc.method).
+					chain.assert(t, success)
+			}
+		})
+	}
+}
+
+func TestCORS_Header(t *testing.T) {
+	cases := []struct {
+		name          string
+		header        http.Header
+		requestHeader string
+		expectAllowed bool
+	}{
+		{
+			name: "listed",
+			header: http.Header{
+				"Access-Control-Allow-Headers": {"Content-Type, X-Request-Id"},
+			},
+			requestHeader: "X-Request-Id",
+			expectAllowed: true,
+		},
+		{
+			name: "case-insensitive",
+			header: http.Header{
+				"Access-Control-Allow-Headers": {"content-type"},
+			},
+			requestHeader: "Content-Type",
+			expectAllowed: true,
+		},
+		{
+			name: "not listed",
+			header: http.Header{
+				"Access-Control-Allow-Headers": {"Content-Type"},
+			},
+			requestHeader: "X-Debug",
+			expectAllowed: false,
+		},
+		{
+			name: "wildcard",
+			header: http.Header{
+				"Access-Control-Allow-Headers": {"*"},
+			},
+			requestHeader: "X-Debug",
+			expectAllowed: true,
+		},
+		{
+			name: "wildcard with credentials",
+			header: http.Header{
+				"Access-Control-Allow-Headers":     {"*"},
+				"Access-Control-Allow-Credentials": {"true"},
+			},
+			requestHeader: "X-Debug",
+			expectAllowed: false,
+		},
+		{
+			name: "wildcard and authorization",
+			header: http.Header{
+				"Access-Control-Allow-Headers": {"*"},
+			},
+			requestHeader: "Authorization",
+			expectAllowed: false,
+		},
+		{
+			name: "listed authorization",
+			header: http.Header{
+				"Access-Control-Allow-Headers": {"*, Authorization"},
+			},
+			requestHeader: "authorization",
+			expectAllowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			if tc.expectAllowed {
+				NewCORS(reporter, tc.header).AllowsHeader(tc.requestHeader).
+					chain.assert(t, success)
+
+				NewCORS(reporter, tc.header).NotAllowsHeader(tc.requestHeader).
+					chain.assert(t, failure)
+			} else {
+				NewCORS(reporter, tc.header).AllowsHeader(tc.requestHeader).
+					chain.assert(t, failure)
+
+				NewCORS(reporter, tc.header).NotAllowsHeader(tc.requestHeader).
+					chain.assert(t, success)
+			}
+		})
+	}
+}
+
+func TestCORS_ExposesHeader(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	header := http.Header{
+		"Access-Control-Expose-Headers": {"X-Request-Id, X-Total-Count"},
+	}
+
+	NewCORS(reporter, header).ExposesHeader("x-total-count").
+		chain.assert(t, success)
+
+	NewCORS(reporter, header).ExposesHeader("X-Debug").
+		chain.assert(t, failure)
+
+	NewCORS(reporter, http.Header{
+		"Access-Control-Expose-Headers": {"*"},
+	}).ExposesHeader("X-Debug").
+		chain.assert(t, success)
+
+	NewCORS(reporter, http.Header{
+		"Access-Control-Expose-Headers":    {"*"},
+		"Access-Control-Allow-Credentials": {"true"},
+	}).ExposesHeader("X-Debug").
+		chain.assert(t, failure)
+}
+
+func TestCORS_Credentials(t *testing.T) {
+	cases := []struct {
+		name          string
+		header        http.Header
+		expectAllowed chainResult
+		expectDenied  chainResult
+	}{
+		{
+			name: "allowed",
+			header: http.Header{
+				"Access-Control-Allow-Origin":      {"https://example.com"},
+				"Access-Control-Allow-Credentials": {"true"},
+			},
+			expectAllowed: success,
+			expectDenied:  failure,
+		},
+		{
+			name: "not true",
+			header: http.Header{
+				"Access-Control-Allow-Origin":      {"https://example.com"},
+				"Access-Control-Allow-Credentials": {"false"},
+			},
+			expectAllowed: failure,
+			expectDenied:  success,
+		},
+		{
+			name: "missing",
+			header: http.Header{
+				"Access-Control-Allow-Origin": {"https://example.com"},
+			},
+			expectAllowed: failure,
+			expectDenied:  success,
+		},
+		{
+			name: "wildcard origin",
+			header: http.Header{
+				"Access-Control-Allow-Origin":      {"*"},
+				"Access-Control-Allow-Credentials": {"true"},
+			},
+			expectAllowed: failure,
+			expectDenied:  failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewCORS(reporter, tc.header).AllowsCredentials().
+				chain.assert(t, tc.expectAllowed)
+
+			NewCORS(reporter, tc.header).NotAllowsCredentials().
+				chain.assert(t, tc.expectDenied)
+		})
+	}
+}
+
+func TestCORS_MaxAge(t *testing.T) {
+	t.Run("present", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewCORS(reporter, http.Header{
+			"Access-Control-Max-Age": {"600"},
+		})
+
+		value.MaxAge().IsEqual(10 * time.Minute)
+		value.chain.assert(t, success)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewCORS(reporter, http.Header{})
+
+		value.MaxAge().NotSet()
+		value.chain.assert(t, success)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewCORS(reporter, http.Header{
+			"Access-Control-Max-Age": {"-1"},
+		})
+
+		value.MaxAge().chain.assert(t, failure)
+		value.chain.assert(t, failure)
+	})
+}
+
+func TestCORS_Response(t *testing.T) {
+	httpResp := &http.Response{
+		StatusCode: http.StatusNoContent,
+		Header: http.Header{
+			"Access-Control-Allow-Origin":  {"https://app.example.com"},
+			"Access-Control-Allow-Methods": {"GET, PUT"},
+			"Access-Control-Allow-Headers": {"Content-Type"},
+			"Access-Control-Max-Age":       {"60"},
+		},
+		Body: io.NopCloser(bytes.NewBuffer(nil)),
+	}
+
+	resp := NewResponse(newMockReporter(t), httpResp)
+
+	cors := resp.CORS()
+	cors.AllowsOrigin("https://app.example.com").
+		AllowsMethod("PUT").
+		AllowsHeader("content-type").
+		NotAllowsCredentials()
+	cors.MaxAge().IsEqual(time.Minute)
+
+	cors.chain.assert(t, success)
+	resp.chain.assert(t, success)
+
+	resp.CORS().AllowsMethod("DELETE").chain.assert(t, failure)
+	assert.True(t, resp.chain.treeFailed())
+}
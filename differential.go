@@ -0,0 +1,477 @@
+package httpexpect
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// DifferentialOpts defines parameters for Request.DifferentialProtocols.
+type DifferentialOpts struct {
+	// Client used to send request over HTTP/1.1.
+	// If nil, client is derived from Config.Client with HTTP/2 disabled.
+	HTTP1Client Client
+
+	// Client used to send request over HTTP/2.
+	// If nil, client is derived from Config.Client with HTTP/2 transport.
+	// For "http" URLs, HTTP/2 with prior knowledge (h2c) is used.
+	//
+	// To derive clients, Config.Client should be *http.Client with
+	// *http.Transport (or nil transport), otherwise failure is reported.
+	// Derived transports are reused by requests of the same Expect instance,
+	// see Request.WithHTTP2.
+	HTTP2Client Client
+
+	// Names of headers which values should be equal in both responses.
+	// Header names are case-insensitive.
+	// By default, headers are not compared.
+	CompareHeaders []string
+
+	// If true, response bodies are not compared.
+	// By default, bodies should be equal. If both responses have JSON
+	// Content-Type, bodies are compared as decoded JSON values.
+	IgnoreBody bool
+}
+
+// DifferentialProtocols sends the request twice, over HTTP/1.1 and over
+// HTTP/2, and checks that both responses are equivalent.
+//
+// Responses are equivalent if they have equal status codes, equal values
+// of headers listed in CompareHeaders, and equal bodies (unless IgnoreBody
+// is set). Also, each response should be actually received using expected
+// protocol, i.e. silent fallback from HTTP/2 to HTTP/1.1 is reported as
+// failure.
+//
+// This allows to catch protocol-dependent bugs, e.g. in gateways and
+// proxies, which appear only on one path.
+//
+// Request matchers are invoked for both responses. Returned Response
+// corresponds to HTTP/1.1 request.
+//
+// DifferentialProtocols can't be used with WithWebsocketUpgrade,
+// WithRawRequestBytes, WithHTTP2, and WithHTTP3. After calling it, there should not be any more calls
+// of Expect or other methods on the same Request instance.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/users")
+//	req.DifferentialProtocols(DifferentialOpts{
+//		CompareHeaders: []string{"Content-Type", "Cache-Control"},
+//	}).
+//		Status(http.StatusOK)
+func (r *Request) DifferentialProtocols(options ...DifferentialOpts) *Response {
+	opChain := r.chain.enter("DifferentialProtocols()")
+	defer opChain.leave()
+
+	resp := r.differentialProtocols(opChain, options)
+
+	if resp == nil {
+		resp = newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	return resp
+}
+
+func (r *Request) differentialProtocols(
+	opChain *chain, options []DifferentialOpts,
+) *Response {
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return nil
+	}
+
+	var opts DifferentialOpts
+	if len(options) != 0 {
+		opts = options[0]
+	}
+
+	if !r.prepareSend(opChain, "DifferentialProtocols()") {
+		return nil
+	}
+
+	if r.wsUpgrade {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New(
+					"DifferentialProtocols() can't be used with WithWebsocketUpgrade()"),
+			},
+		})
+		return nil
+	}
+
+	if r.rawRequest != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New(
+					"DifferentialProtocols() can't be used with WithRawRequestBytes()"),
+			},
+		})
+		return nil
+	}
+
+	if r.protoMajor != 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					"DifferentialProtocols() can't be used with WithHTTP%d()", r.protoMajor),
+			},
+		})
+		return nil
+	}
+
+	if !r.prepareHTTPRequest(opChain) {
+		return nil
+	}
+
+	if !r.checkBodyReusable(opChain, "DifferentialProtocols()") {
+		return nil
+	}
+
+	var ok bool
+	if opts.HTTP1Client, ok = r.differentialClient(
+		opChain, opts.HTTP1Client, 1); !ok {
+		return nil
+	}
+	if opts.HTTP2Client, ok = r.differentialClient(
+		opChain, opts.HTTP2Client, 2); !ok {
+		return nil
+	}
+
+	resp1 := r.sendProtocolRequest(opChain, opts.HTTP1Client, 1)
+	if resp1 == nil {
+		return nil
+	}
+
+	resp2 := r.sendProtocolRequest(opChain, opts.HTTP2Client, 2)
+	if resp2 == nil {
+		return nil
+	}
+
+	r.runMatchers(resp1)
+	r.runMatchers(resp2)
+
+	compareProtocolResponses(opChain, resp1, resp2, opts)
+
+	return resp1
+}
+
+func (r *Request) sendProtocolRequest(
+	opChain *chain, client Client, protoMajor int,
+) *Response {
+	httpResp, elapsed, err := r.retryRequest(func() (*http.Response, error) {
+		return client.Do(r.httpReq)
+	})
+
+	if err != nil {
+		r.skipIfUnavailable(err)
+
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to send http request over %s",
+					protocolName(protoMajor)),
+				err,
+			},
+		})
+		return nil
+	}
+
+	if httpResp.ProtoMajor != protoMajor {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{httpResp.Proto},
+			Expected: &AssertionValue{protocolName(protoMajor)},
+			Errors: []error{
+				errors.New("unexpected protocol of received response"),
+			},
+		})
+		return nil
+	}
+
+	if !runResponseHooks(opChain, r.config, httpResp) {
+		return nil
+	}
+
+	return newResponse(responseOpts{
+		config:   r.config,
+		chain:    opChain,
+		httpResp: httpResp,
+		rtt:      []time.Duration{elapsed},
+		logger:   r.logger,
+		owner:    r.owner,
+	})
+}
+
+// Returns client for given protocol. If client is nil, it's derived from
+// Config.Client, to which WithResolveTo was already applied by
+// setupProtocol (see protocolClient). Otherwise, WithResolveTo is
+// applied to given client.
+func (r *Request) differentialClient(
+	opChain *chain, client Client, protoMajor int,
+) (Client, bool) {
+	if client == nil {
+		return r.protocolClient(opChain, r.config.Client, protoMajor)
+	}
+
+	if r.resolveTo != "" {
+		return r.resolveClient(opChain, client)
+	}
+
+	return client, true
+}
+
+// Derive client for given protocol from given client.
+// Redirect policy and cookie jar of the client are preserved.
+// For HTTP/3, transport passed to WithHTTP3 is used.
+//
+// For HTTP/1.1 and HTTP/2, transport is derived from transport of the
+// client, which should be *http.Transport (or nil), or *http2.Transport
+// for HTTP/2; otherwise failure is reported. Derived transport is shared
+// by requests of the same Expect instance with the same client transport,
+// protocol, and URL scheme, see transportCache.
+func (r *Request) protocolClient(
+	opChain *chain, client Client, protoMajor int,
+) (Client, bool) {
+	if protoMajor == 3 {
+		httpClient := &http.Client{}
+		if baseClient, ok := client.(*http.Client); ok {
+			clientCopy := *baseClient
+			httpClient = &clientCopy
+		}
+
+		httpClient.Transport = r.http3Transport
+
+		return httpClient, true
+	}
+
+	httpClient, ok := client.(*http.Client)
+	if !ok {
+		r.failProtocol(opChain, protoMajor, fmt.Errorf(
+			"unsupported client type %T, expected *http.Client", client))
+		return nil, false
+	}
+
+	key := transportCacheKey{
+		transport:  httpClient.Transport,
+		protoMajor: protoMajor,
+		scheme:     r.httpReq.URL.Scheme,
+	}
+
+	transport, err := r.config.lifecycle.transports().transport(key,
+		func() (http.RoundTripper, error) {
+			return protocolTransport(httpClient.Transport, protoMajor, key.scheme)
+		})
+	if err != nil {
+		r.failProtocol(opChain, protoMajor, err)
+		return nil, false
+	}
+
+	clientCopy := *httpClient
+	clientCopy.Transport = transport
+
+	return &clientCopy, true
+}
+
+func (r *Request) failProtocol(opChain *chain, protoMajor int, err error) {
+	opChain.fail(AssertionFailure{
+		Type: AssertUsage,
+		Errors: []error{
+			fmt.Errorf("can't send request over %s using this client",
+				protocolName(protoMajor)),
+			err,
+		},
+	})
+}
+
+// Derive transport for given protocol and URL scheme from given transport.
+// Dial functions and TLS config of original transport are preserved.
+func protocolTransport(
+	transport http.RoundTripper, protoMajor int, scheme string,
+) (http.RoundTripper, error) {
+	var baseTransport *http.Transport
+
+	switch t := transport.(type) {
+	case nil:
+		baseTransport = http.DefaultTransport.(*http.Transport)
+
+	case *http.Transport:
+		baseTransport = t
+
+	case *http2.Transport:
+		if protoMajor == 2 {
+			return t, nil
+		}
+		return nil, fmt.Errorf(
+			"unsupported transport type %T, expected *http.Transport", transport)
+
+	default:
+		return nil, fmt.Errorf(
+			"unsupported transport type %T, expected *http.Transport", transport)
+	}
+
+	if protoMajor == 2 {
+		return newHTTP2Transport(scheme, baseTransport), nil
+	}
+
+	derived := baseTransport.Clone()
+
+	// non-nil empty map disables HTTP/2
+	derived.ForceAttemptHTTP2 = false
+	derived.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+
+	// don't offer HTTP/2 during TLS handshake
+	if derived.TLSClientConfig != nil {
+		derived.TLSClientConfig = derived.TLSClientConfig.Clone()
+		derived.TLSClientConfig.NextProtos = []string{"http/1.1"}
+	}
+
+	return derived, nil
+}
+
+// Create HTTP/2 transport for given URL scheme, which dials connections
+// using dial functions of given transport.
+// For "http" scheme, HTTP/2 with prior knowledge (h2c) is used.
+func newHTTP2Transport(scheme string, transport *http.Transport) *http2.Transport {
+	dial := dialFuncOrDefault(transportDialFunc(transport))
+
+	if scheme == "http" {
+		return &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(
+				ctx context.Context, network, addr string, _ *tls.Config,
+			) (net.Conn, error) {
+				return dial(ctx, network, addr)
+			},
+		}
+	}
+
+	var tlsConfig *tls.Config
+	if transport.TLSClientConfig != nil {
+		tlsConfig = transport.TLSClientConfig.Clone()
+	}
+
+	dialTLS := transport.DialTLSContext
+
+	return &http2.Transport{
+		TLSClientConfig: tlsConfig,
+		DialTLSContext: func(
+			ctx context.Context, network, addr string, cfg *tls.Config,
+		) (net.Conn, error) {
+			if dialTLS != nil {
+				return dialTLS(ctx, network, addr)
+			}
+			return dialHTTP2TLS(ctx, dial, network, addr, cfg)
+		},
+	}
+}
+
+func protocolName(protoMajor int) string {
+	if protoMajor == 1 {
+		return "HTTP/1.1"
+	}
+	return fmt.Sprintf("HTTP/%d", protoMajor)
+}
+
+func compareProtocolResponses(
+	opChain *chain, resp1, resp2 *Response, opts DifferentialOpts,
+) {
+	if resp1.httpResp.StatusCode != resp2.httpResp.StatusCode {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{resp2.httpResp.StatusCode},
+			Expected: &AssertionValue{resp1.httpResp.StatusCode},
+			Errors: []error{
+				errors.New(
+					"expected: status codes over HTTP/1.1 and HTTP/2 are equal"),
+			},
+		})
+		return
+	}
+
+	for _, name := range opts.CompareHeaders {
+		key := http.CanonicalHeaderKey(name)
+
+		values1 := resp1.httpResp.Header.Values(key)
+		values2 := resp2.httpResp.Header.Values(key)
+
+		if !reflect.DeepEqual(values1, values2) {
+			opChain.fail(AssertionFailure{
+				Type:     AssertEqual,
+				Actual:   &AssertionValue{values2},
+				Expected: &AssertionValue{values1},
+				Errors: []error{
+					fmt.Errorf(
+						"expected: header %q over HTTP/1.1 and HTTP/2 is equal", key),
+				},
+			})
+			return
+		}
+	}
+
+	if opts.IgnoreBody {
+		return
+	}
+
+	content1, ok := resp1.getContent(opChain, "DifferentialProtocols()")
+	if !ok {
+		return
+	}
+
+	content2, ok := resp2.getContent(opChain, "DifferentialProtocols()")
+	if !ok {
+		return
+	}
+
+	if isJSONContent(resp1.httpResp.Header) && isJSONContent(resp2.httpResp.Header) {
+		var value1, value2 interface{}
+
+		err1 := json.Unmarshal(content1, &value1)
+		err2 := json.Unmarshal(content2, &value2)
+
+		if err1 == nil && err2 == nil {
+			if !reflect.DeepEqual(value1, value2) {
+				opChain.fail(AssertionFailure{
+					Type:     AssertEqual,
+					Actual:   &AssertionValue{value2},
+					Expected: &AssertionValue{value1},
+					Errors: []error{
+						errors.New(
+							"expected: json bodies over HTTP/1.1 and HTTP/2 are equal"),
+					},
+				})
+			}
+			return
+		}
+	}
+
+	if !bytes.Equal(content1, content2) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{string(content2)},
+			Expected: &AssertionValue{string(content1)},
+			Errors: []error{
+				errors.New("expected: bodies over HTTP/1.1 and HTTP/2 are equal"),
+			},
+		})
+	}
+}
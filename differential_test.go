@@ -0,0 +1,299 @@
+package httpexpect
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_DifferentialProtocols(t *testing.T) {
+	type response struct {
+		status int
+		header http.Header
+		body   string
+	}
+
+	newClient := func(protoMajor int, resp response) Client {
+		return ClientFunc(func(req *http.Request) (*http.Response, error) {
+			proto := "HTTP/1.1"
+			if protoMajor == 2 {
+				proto = "HTTP/2.0"
+			}
+			return &http.Response{
+				Proto:      proto,
+				ProtoMajor: protoMajor,
+				StatusCode: resp.status,
+				Header:     resp.header,
+				Body:       io.NopCloser(bytes.NewBufferString(resp.body)),
+			}, nil
+		})
+	}
+
+	jsonHeader := http.Header{"Content-Type": {"application/json"}}
+
+	cases := []struct {
+		name    string
+		opts    DifferentialOpts
+		resp1   response
+		resp2   response
+		proto1  int
+		proto2  int
+		success bool
+	}{
+		{
+			name:    "equal",
+			resp1:   response{status: http.StatusOK, body: "hello"},
+			resp2:   response{status: http.StatusOK, body: "hello"},
+			success: true,
+		},
+		{
+			name:    "different status",
+			resp1:   response{status: http.StatusOK, body: "hello"},
+			resp2:   response{status: http.StatusBadGateway, body: "hello"},
+			success: false,
+		},
+		{
+			name:    "different body",
+			resp1:   response{status: http.StatusOK, body: "hello"},
+			resp2:   response{status: http.StatusOK, body: "world"},
+			success: false,
+		},
+		{
+			name:    "different body, ignored",
+			opts:    DifferentialOpts{IgnoreBody: true},
+			resp1:   response{status: http.StatusOK, body: "hello"},
+			resp2:   response{status: http.StatusOK, body: "world"},
+			success: true,
+		},
+		{
+			name: "equal json, different formatting",
+			resp1: response{
+				status: http.StatusOK, header: jsonHeader, body: `{"a":1,"b":2}`,
+			},
+			resp2: response{
+				status: http.StatusOK, header: jsonHeader, body: `{ "b": 2, "a": 1 }`,
+			},
+			success: true,
+		},
+		{
+			name: "different json",
+			resp1: response{
+				status: http.StatusOK, header: jsonHeader, body: `{"a":1}`,
+			},
+			resp2: response{
+				status: http.StatusOK, header: jsonHeader, body: `{"a":2}`,
+			},
+			success: false,
+		},
+		{
+			name: "compared header equal",
+			opts: DifferentialOpts{CompareHeaders: []string{"cache-control"}},
+			resp1: response{
+				status: http.StatusOK,
+				header: http.Header{"Cache-Control": {"no-cache"}},
+			},
+			resp2: response{
+				status: http.StatusOK,
+				header: http.Header{"Cache-Control": {"no-cache"}},
+			},
+			success: true,
+		},
+		{
+			name: "compared header differs",
+			opts: DifferentialOpts{CompareHeaders: []string{"cache-control"}},
+			resp1: response{
+				status: http.StatusOK,
+				header: http.Header{"Cache-Control": {"no-cache"}},
+			},
+			resp2: response{
+				status: http.StatusOK,
+				header: http.Header{"Cache-Control": {"max-age=60"}},
+			},
+			success: false,
+		},
+		{
+			name: "not compared header differs",
+			resp1: response{
+				status: http.StatusOK,
+				header: http.Header{"Cache-Control": {"no-cache"}},
+			},
+			resp2: response{
+				status: http.StatusOK,
+				header: http.Header{"Cache-Control": {"max-age=60"}},
+			},
+			success: true,
+		},
+		{
+			name:    "fallback to http/1.1",
+			resp1:   response{status: http.StatusOK},
+			resp2:   response{status: http.StatusOK},
+			proto2:  1,
+			success: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			proto1, proto2 := 1, 2
+			if tc.proto1 != 0 {
+				proto1 = tc.proto1
+			}
+			if tc.proto2 != 0 {
+				proto2 = tc.proto2
+			}
+
+			reporter := newMockReporter(t)
+			config := newMockConfig(reporter)
+
+			opts := tc.opts
+			opts.HTTP1Client = newClient(proto1, tc.resp1)
+			opts.HTTP2Client = newClient(proto2, tc.resp2)
+
+			var matched []int
+
+			req := NewRequestC(config, "GET", "/path").
+				WithMatcher(func(resp *Response) {
+					matched = append(matched, resp.Raw().ProtoMajor)
+				})
+
+			resp := req.DifferentialProtocols(opts)
+
+			if tc.success {
+				req.chain.assert(t, success)
+				resp.chain.assert(t, success)
+
+				assert.Equal(t, 1, resp.Raw().ProtoMajor)
+				assert.Equal(t, []int{1, 2}, matched)
+			} else {
+				req.chain.assert(t, failure)
+			}
+		})
+	}
+
+	t.Run("request error", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		req := NewRequestC(config, "GET", "/path")
+
+		resp := req.DifferentialProtocols(DifferentialOpts{
+			HTTP1Client: newClient(1, response{status: http.StatusOK}),
+			HTTP2Client: ClientFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("test error")
+			}),
+		})
+
+		req.chain.assert(t, failure)
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("multiple options", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		req := NewRequestC(config, "GET", "/path")
+
+		req.DifferentialProtocols(DifferentialOpts{}, DifferentialOpts{})
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		req := NewRequestC(config, "GET", "/path").WithWebsocketUpgrade()
+
+		req.DifferentialProtocols()
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("http2 request", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		req := NewRequestC(config, "GET", "/path").WithHTTP2()
+
+		req.DifferentialProtocols(DifferentialOpts{
+			HTTP1Client: newClient(1, response{status: http.StatusOK}),
+			HTTP2Client: newClient(2, response{status: http.StatusOK}),
+		})
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("unsupported transport", func(t *testing.T) {
+		handlerCalled := false
+
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			BaseURL:          "http://example.invalid",
+			AssertionHandler: handler,
+			Client: &http.Client{
+				Transport: NewBinder(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						handlerCalled = true
+					})),
+			},
+		}
+
+		req := NewRequestC(config, "GET", "/path")
+
+		req.DifferentialProtocols()
+		req.chain.assert(t, failure)
+
+		// transport is not replaced with network transport
+		assert.False(t, handlerCalled)
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertUsage, handler.failure.Type)
+	})
+
+	t.Run("shared transports", func(t *testing.T) {
+		baseTransport := &http.Transport{
+			DialContext: func(
+				ctx context.Context, network, addr string,
+			) (net.Conn, error) {
+				return nil, errors.New("test error")
+			},
+		}
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   &http.Client{Transport: baseTransport},
+			Reporter: newMockReporter(t),
+		})
+
+		for i := 0; i < 2; i++ {
+			e.GET("/path").DifferentialProtocols().
+				chain.assert(t, failure)
+		}
+
+		// one transport per protocol
+		cache := e.config.lifecycle.transportCache
+		assert.Equal(t, 2, len(cache.transports))
+
+		e.Close()
+
+		assert.Equal(t, 0, len(cache.transports))
+	})
+
+	t.Run("after expect", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+		config.Client = newClient(1, response{status: http.StatusOK})
+
+		req := NewRequestC(config, "GET", "/path")
+
+		req.Expect()
+		req.chain.assert(t, success)
+
+		req.DifferentialProtocols()
+		req.chain.assert(t, failure)
+	})
+}
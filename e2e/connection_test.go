@@ -0,0 +1,112 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func createConnectionHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/keepalive", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	mux.HandleFunc("/close", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Connection", "close")
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	return mux
+}
+
+func TestE2EConnection_Reused(t *testing.T) {
+	server := httptest.NewServer(createConnectionHandler())
+	defer server.Close()
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  server.URL,
+		Client:   server.Client(),
+		Reporter: httpexpect.NewAssertReporter(t),
+	})
+
+	// connection is returned to pool after response body is read
+	resp := e.GET("/keepalive").Expect()
+	resp.ConnectionReused().IsFalse()
+	resp.Body().IsEqual("ok")
+
+	resp = e.GET("/keepalive").Expect()
+	resp.ConnectionReused().IsTrue()
+	resp.Body().IsEqual("ok")
+
+	assert.Equal(t, httpexpect.ConnectionStats{New: 1, Reused: 1},
+		e.ConnectionStats())
+}
+
+func TestE2EConnection_Closed(t *testing.T) {
+	server := httptest.NewServer(createConnectionHandler())
+	defer server.Close()
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  server.URL,
+		Client:   server.Client(),
+		Reporter: httpexpect.NewAssertReporter(t),
+	})
+
+	for i := 0; i < 3; i++ {
+		resp := e.GET("/close").Expect()
+		resp.ConnectionReused().IsFalse()
+		resp.Body().IsEqual("ok")
+	}
+
+	assert.Equal(t, httpexpect.ConnectionStats{New: 3, Reused: 0},
+		e.ConnectionStats())
+}
+
+func TestE2EConnection_Shared(t *testing.T) {
+	server := httptest.NewServer(createConnectionHandler())
+	defer server.Close()
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  server.URL,
+		Client:   server.Client(),
+		Reporter: httpexpect.NewAssertReporter(t),
+	})
+
+	b := e.Builder(func(req *httpexpect.Request) {
+		req.WithHeader("X-Test", "1")
+	})
+
+	e.GET("/keepalive").Expect().Body().IsEqual("ok")
+	b.GET("/keepalive").Expect().Body().IsEqual("ok")
+
+	b.GET("/keepalive").Repeat(3, 1).
+		Errors().IsEqual(0)
+
+	assert.Equal(t, httpexpect.ConnectionStats{New: 1, Reused: 4},
+		e.ConnectionStats())
+	assert.Equal(t, e.ConnectionStats(), b.ConnectionStats())
+}
+
+func TestE2EConnection_Binder(t *testing.T) {
+	reporter := &mockReporter{}
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		Client: &http.Client{
+			Transport: httpexpect.NewBinder(createConnectionHandler()),
+		},
+		Reporter: reporter,
+	})
+
+	e.GET("/keepalive").
+		Expect().
+		Status(http.StatusOK).
+		ConnectionReused()
+
+	assert.True(t, reporter.failed)
+	assert.Equal(t, httpexpect.ConnectionStats{}, e.ConnectionStats())
+}
@@ -0,0 +1,110 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func createDifferentialHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/same", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message": "hello"}`))
+	})
+
+	mux.HandleFunc("/proto", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Proto", r.Proto)
+		_, _ = w.Write([]byte(`{"message": "hello"}`))
+	})
+
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return mux
+}
+
+func testDifferentialHandler(t *testing.T, config httpexpect.Config) {
+	t.Run("same", func(t *testing.T) {
+		reporter := &mockReporter{}
+
+		config.Reporter = reporter
+
+		e := httpexpect.WithConfig(config)
+
+		e.GET("/same").
+			DifferentialProtocols().
+			Status(http.StatusOK).
+			JSON().Object().HasValue("message", "hello")
+
+		assert.False(t, reporter.failed)
+	})
+
+	t.Run("header differs", func(t *testing.T) {
+		reporter := &mockReporter{}
+
+		config.Reporter = reporter
+
+		e := httpexpect.WithConfig(config)
+
+		e.GET("/proto").
+			DifferentialProtocols()
+
+		assert.False(t, reporter.failed)
+
+		e.GET("/proto").
+			DifferentialProtocols(httpexpect.DifferentialOpts{
+				CompareHeaders: []string{"X-Proto"},
+			})
+
+		assert.True(t, reporter.failed)
+	})
+
+	t.Run("status differs", func(t *testing.T) {
+		reporter := &mockReporter{}
+
+		config.Reporter = reporter
+
+		e := httpexpect.WithConfig(config)
+
+		e.GET("/broken").
+			DifferentialProtocols()
+
+		assert.True(t, reporter.failed)
+	})
+}
+
+func TestE2EDifferential_H2C(t *testing.T) {
+	handler := h2c.NewHandler(createDifferentialHandler(), &http2.Server{})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	testDifferentialHandler(t, httpexpect.Config{
+		BaseURL: server.URL,
+	})
+}
+
+func TestE2EDifferential_TLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(createDifferentialHandler())
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	testDifferentialHandler(t, httpexpect.Config{
+		BaseURL: server.URL,
+		Client:  server.Client(),
+	})
+}
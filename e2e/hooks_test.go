@@ -0,0 +1,111 @@
+package e2e
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func hooksSign(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func createHooksHandler(key []byte) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/signed", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Header.Get("X-Signature") != hooksSign(key, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write(body)
+	})
+
+	mux.HandleFunc("/cached", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		_, _ = w.Write([]byte("cached"))
+	})
+
+	return mux
+}
+
+func TestE2EHooks(t *testing.T) {
+	key := []byte("secret")
+
+	server := httptest.NewServer(createHooksHandler(key))
+	defer server.Close()
+
+	var audit []string
+
+	newConfig := func(reporter httpexpect.Reporter) httpexpect.Config {
+		return httpexpect.Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+			RequestHooks: []func(*http.Request) error{
+				func(req *http.Request) error {
+					body := []byte{}
+					if req.Body != nil {
+						body, _ = io.ReadAll(req.Body)
+					}
+					req.Header.Set("X-Signature", hooksSign(key, body))
+					return nil
+				},
+			},
+			ResponseHooks: []func(*http.Response) error{
+				func(resp *http.Response) error {
+					audit = append(audit, resp.Request.URL.Path)
+					return nil
+				},
+				func(resp *http.Response) error {
+					if resp.Header.Get("Cache-Control") != "no-store" {
+						return errors.New("missing Cache-Control: no-store")
+					}
+					return nil
+				},
+			},
+		}
+	}
+
+	t.Run("signed request", func(t *testing.T) {
+		audit = nil
+
+		reporter := &mockReporter{}
+		e := httpexpect.WithConfig(newConfig(reporter))
+
+		e.POST("/signed").
+			WithText("hello").
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("hello")
+
+		assert.False(t, reporter.failed)
+		assert.Equal(t, []string{"/signed"}, audit)
+	})
+
+	t.Run("policy violation", func(t *testing.T) {
+		audit = nil
+
+		reporter := &mockReporter{}
+		e := httpexpect.WithConfig(newConfig(reporter))
+
+		e.GET("/cached").
+			Expect()
+
+		assert.True(t, reporter.failed)
+		assert.Equal(t, []string{"/cached"}, audit)
+	})
+}
@@ -0,0 +1,69 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestE2EMockServer(t *testing.T) {
+	mock := httpexpect.NewMockServer(t)
+	defer mock.Close()
+
+	mock.InOrder()
+
+	mock.Expect("POST", "/login").
+		WithJSON(map[string]interface{}{"user": "john"}).
+		RespondJSON(http.StatusOK, map[string]interface{}{"token": "secret"})
+
+	mock.Expect("GET", "/items/*").
+		WithHeader("Authorization", "Bearer secret").
+		Times(2).
+		Respond(http.StatusOK, "item")
+
+	e := httpexpect.Default(t, mock.URL())
+
+	token := e.POST("/login").
+		WithJSON(map[string]interface{}{"user": "john"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("token").String().Raw()
+
+	for _, id := range []string{"1", "2"} {
+		e.GET("/items/{id}", id).
+			WithHeader("Authorization", "Bearer "+token).
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("item")
+	}
+
+	mock.AssertExpectations()
+
+	mock.Requests().Length().IsEqual(3)
+}
+
+func TestE2EMockServer_Unmet(t *testing.T) {
+	reporter := &mockReporter{}
+
+	mock := httpexpect.NewMockServer(reporter)
+	defer mock.Close()
+
+	mock.Expect("GET", "/a")
+	mock.Expect("GET", "/b")
+
+	e := httpexpect.Default(t, mock.URL())
+
+	e.GET("/b").
+		Expect().
+		Status(http.StatusOK)
+
+	e.GET("/c").
+		Expect().
+		Status(http.StatusNotImplemented)
+
+	mock.AssertExpectations()
+
+	assert.True(t, reporter.failed)
+}
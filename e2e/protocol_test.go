@@ -0,0 +1,100 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func createProtocolHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/proto", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	})
+
+	return mux
+}
+
+func testProtocolHandler(t *testing.T, config httpexpect.Config) {
+	t.Run("default", func(t *testing.T) {
+		e := httpexpect.WithConfig(config)
+
+		e.GET("/proto").
+			Expect().
+			Status(http.StatusOK).
+			ProtoIs("HTTP/1.1").
+			Body().IsEqual("HTTP/1.1")
+	})
+
+	t.Run("http2", func(t *testing.T) {
+		e := httpexpect.WithConfig(config)
+
+		e.GET("/proto").
+			WithHTTP2().
+			Expect().
+			Status(http.StatusOK).
+			ProtoIs("HTTP/2").
+			Body().IsEqual("HTTP/2.0")
+	})
+
+	t.Run("http2 repeat", func(t *testing.T) {
+		e := httpexpect.WithConfig(config)
+
+		e.GET("/proto").
+			WithHTTP2().
+			Repeat(5, 2).
+			StatusCount(http.StatusOK).IsEqual(5)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		reporter := &mockReporter{}
+
+		config := config
+		config.Reporter = reporter
+
+		e := httpexpect.WithConfig(config)
+
+		e.GET("/proto").
+			Expect().
+			ProtoIs("HTTP/2.0")
+
+		assert.True(t, reporter.failed)
+	})
+}
+
+func TestE2EProtocol_H2C(t *testing.T) {
+	handler := h2c.NewHandler(createProtocolHandler(), &http2.Server{})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	testProtocolHandler(t, httpexpect.Config{
+		BaseURL:  server.URL,
+		Reporter: httpexpect.NewAssertReporter(t),
+	})
+}
+
+func TestE2EProtocol_TLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(createProtocolHandler())
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	client := server.Client()
+
+	// server.Client() has HTTP/2 enabled; use HTTP/1.1 by default
+	client.Transport.(*http.Transport).ForceAttemptHTTP2 = false
+	client.Transport.(*http.Transport).TLSClientConfig.NextProtos = []string{"http/1.1"}
+
+	testProtocolHandler(t, httpexpect.Config{
+		BaseURL:  server.URL,
+		Client:   client,
+		Reporter: httpexpect.NewAssertReporter(t),
+	})
+}
@@ -0,0 +1,103 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func createRawHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Method", r.Method)
+		_, _ = w.Write([]byte(r.Header.Get("X-Foo")))
+	})
+
+	return mux
+}
+
+func testRawHandler(t *testing.T, config httpexpect.Config) {
+	t.Run("valid", func(t *testing.T) {
+		reporter := &mockReporter{}
+
+		config := config
+		config.Reporter = reporter
+
+		e := httpexpect.WithConfig(config)
+
+		resp := e.POST("/echo").
+			WithRawRequestBytes([]byte(strings.Join([]string{
+				"POST /echo HTTP/1.1",
+				"host: example.com",
+				"x-FOO: bar",
+				"Content-Length: 5",
+				"",
+				"hello",
+			}, "\r\n"))).
+			Expect()
+
+		resp.Status(http.StatusOK)
+		resp.Header("X-Method").IsEqual("POST")
+		resp.Body().IsEqual("bar")
+
+		assert.False(t, reporter.failed)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		reporter := &mockReporter{}
+
+		config := config
+		config.Reporter = reporter
+
+		e := httpexpect.WithConfig(config)
+
+		e.POST("/echo").
+			WithRawRequestBytes([]byte(strings.Join([]string{
+				"POST /echo HTTP/1.1",
+				"Host: example.com",
+				"Content-Length: 5",
+				"Content-Length: 6",
+				"",
+				"hello!",
+			}, "\r\n"))).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		e.GET("/echo").
+			WithRawRequestBytes([]byte(strings.Join([]string{
+				"GET /echo HTTP/1.1",
+				"Host: example.com",
+				"X-Foo : bar",
+				"",
+				"",
+			}, "\r\n"))).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		assert.False(t, reporter.failed)
+	})
+}
+
+func TestE2ERaw_HTTP(t *testing.T) {
+	server := httptest.NewServer(createRawHandler())
+	defer server.Close()
+
+	testRawHandler(t, httpexpect.Config{
+		BaseURL: server.URL,
+	})
+}
+
+func TestE2ERaw_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(createRawHandler())
+	defer server.Close()
+
+	testRawHandler(t, httpexpect.Config{
+		BaseURL: server.URL,
+		Client:  server.Client(),
+	})
+}
@@ -0,0 +1,65 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestE2ERecording_Handler(t *testing.T) {
+	attempts := 0
+
+	handler := httpexpect.NewRecordingHandler(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/redirect":
+				http.Redirect(w, r, "/flaky?x=1", http.StatusFound)
+			case "/flaky":
+				attempts++
+				if attempts == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}
+		}))
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	reporter := &mockReporter{}
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  server.URL,
+		Reporter: reporter,
+	})
+
+	e.POST("/redirect").
+		WithRetryPolicy(httpexpect.RetryAllErrors).
+		WithMaxRetries(1).
+		WithRetryDelay(0, 0).
+		Expect().
+		Status(http.StatusOK)
+
+	requests := handler.Requests(reporter)
+
+	requests.Length().IsEqual(4)
+
+	paths := []interface{}{}
+	for _, req := range requests.Iter() {
+		paths = append(paths, req.Object().Value("url").Raw())
+	}
+
+	assert.Equal(t, []interface{}{
+		"/redirect", "/flaky?x=1",
+		"/redirect", "/flaky?x=1",
+	}, paths)
+
+	requests.Value(1).Object().Value("method").IsEqual("GET")
+	requests.Value(1).Object().Value("query").IsEqual("x=1")
+
+	assert.False(t, reporter.failed)
+}
@@ -215,6 +215,32 @@ func testRedirects(t *testing.T, createFn func(httpexpect.Reporter) *httpexpect.
 			assert.False(t, reporter.failed)
 		})
 	})
+
+	t.Run("redirect chain", func(t *testing.T) {
+		e := createFn(httpexpect.NewAssertReporter(t))
+
+		resp := e.GET("/double_redirect").
+			WithRedirectPolicy(httpexpect.FollowAllRedirects).
+			Expect().
+			Status(http.StatusOK)
+
+		chain := resp.RedirectChain()
+		chain.Length().IsEqual(2)
+
+		chain.Value(0).Object().HasValue("status", http.StatusTemporaryRedirect)
+		chain.Value(0).Object().Value("location").String().HasSuffix("/redirect308")
+		chain.Value(0).Object().Value("url").String().HasSuffix("/double_redirect")
+
+		chain.Value(1).Object().HasValue("status", http.StatusPermanentRedirect)
+		chain.Value(1).Object().Value("location").String().HasSuffix("/content")
+		chain.Value(1).Object().Value("url").String().HasSuffix("/redirect308")
+
+		e.GET("/double_redirect").
+			WithRedirectPolicy(httpexpect.DontFollowRedirects).
+			Expect().
+			Status(http.StatusTemporaryRedirect).
+			RedirectChain().IsEmpty()
+	})
 }
 
 func TestE2ERedirect_Live(t *testing.T) {
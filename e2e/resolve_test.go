@@ -0,0 +1,144 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func createResolveHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/host", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host))
+	})
+
+	return mux
+}
+
+func TestE2EResolve_HTTP(t *testing.T) {
+	server := httptest.NewServer(createResolveHandler())
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  "http://api.example.com",
+		Reporter: httpexpect.NewAssertReporter(t),
+	})
+
+	e.GET("/host").
+		WithResolveTo(serverURL.Host).
+		Expect().
+		Status(http.StatusOK).
+		Body().IsEqual("api.example.com")
+}
+
+func TestE2EResolve_H2C(t *testing.T) {
+	handler := h2c.NewHandler(createResolveHandler(), &http2.Server{})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  "http://api.example.com",
+		Reporter: httpexpect.NewAssertReporter(t),
+	})
+
+	e.GET("/host").
+		WithHTTP2().
+		WithResolveTo(serverURL.Host).
+		Expect().
+		Status(http.StatusOK).
+		ProtoIs("HTTP/2").
+		Body().IsEqual("api.example.com")
+}
+
+func TestE2EResolve_TLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(createResolveHandler())
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+
+	// certificate of test server is issued for example.com
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  "https://example.com",
+		Client:   server.Client(),
+		Reporter: httpexpect.NewAssertReporter(t),
+	})
+
+	t.Run("http1", func(t *testing.T) {
+		e.GET("/host").
+			WithResolveTo(serverURL.Host).
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("example.com")
+	})
+
+	t.Run("http2", func(t *testing.T) {
+		e.GET("/host").
+			WithHTTP2().
+			WithResolveTo(serverURL.Host).
+			Expect().
+			Status(http.StatusOK).
+			ProtoIs("HTTP/2").
+			Body().IsEqual("example.com")
+	})
+}
+
+func TestE2EResolve_DNSOverrides(t *testing.T) {
+	handler := h2c.NewHandler(createResolveHandler(), &http2.Server{})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL: "http://api.example.com:" + serverURL.Port(),
+		DNSOverrides: map[string]string{
+			"api.example.com": serverURL.Hostname(),
+		},
+		Reporter: httpexpect.NewAssertReporter(t),
+	})
+
+	t.Run("http1", func(t *testing.T) {
+		e.GET("/host").
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("api.example.com:" + serverURL.Port())
+	})
+
+	t.Run("http2", func(t *testing.T) {
+		e.GET("/host").
+			WithHTTP2().
+			Expect().
+			Status(http.StatusOK).
+			ProtoIs("HTTP/2").
+			Body().IsEqual("api.example.com:" + serverURL.Port())
+	})
+
+	t.Run("with port", func(t *testing.T) {
+		e := httpexpect.WithConfig(httpexpect.Config{
+			BaseURL: "http://api.example.com",
+			DNSOverrides: map[string]string{
+				"api.example.com": serverURL.Host,
+			},
+			Reporter: httpexpect.NewAssertReporter(t),
+		})
+
+		e.GET("/host").
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("api.example.com")
+	})
+}
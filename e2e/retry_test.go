@@ -375,3 +375,53 @@ func TestE2ERetry_BinderFast(t *testing.T) {
 		})
 	})
 }
+
+func TestE2ERetry_Metrics(t *testing.T) {
+	rc := &retryController{}
+
+	handler := createRetryHandler(rc)
+
+	tc := &transportController{RoundTripper: httpexpect.NewBinder(handler)}
+
+	metrics := httpexpect.NewMemoryMetrics()
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  "http://example.com",
+		Reporter: httpexpect.NewAssertReporter(t),
+		Client: &http.Client{
+			Transport: tc,
+		},
+		MetricsCollector: metrics,
+	})
+
+	rc.reset(2, http.StatusBadGateway)
+	tc.reset(1)
+
+	e.POST("/test").
+		WithName("flaky").
+		WithText(`test`).
+		WithMaxRetries(5).
+		WithRetryDelay(0, 0).
+		Expect().
+		Status(http.StatusOK).Body().IsEqual(`test`)
+
+	assert.Equal(t, 4, metrics.Total())
+	assert.Equal(t, 3, metrics.Retries())
+
+	counters := metrics.Counters()
+
+	assert.Equal(t, 1, counters[httpexpect.MetricsLabels{
+		Name: "flaky", Method: "POST", StatusClass: "error", Attempt: 0,
+	}])
+	assert.Equal(t, 1, counters[httpexpect.MetricsLabels{
+		Name: "flaky", Method: "POST", StatusClass: "5xx", Attempt: 1,
+	}])
+	assert.Equal(t, 1, counters[httpexpect.MetricsLabels{
+		Name: "flaky", Method: "POST", StatusClass: "5xx", Attempt: 2,
+	}])
+	assert.Equal(t, 1, counters[httpexpect.MetricsLabels{
+		Name: "flaky", Method: "POST", StatusClass: "2xx", Attempt: 3,
+	}])
+
+	assert.Equal(t, 4, metrics.Histogram(nil).Count)
+}
@@ -0,0 +1,87 @@
+package e2e
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func createSmugglingHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return mux
+}
+
+func testSmugglingHandler(t *testing.T, config httpexpect.Config) {
+	t.Run("rejected", func(t *testing.T) {
+		reporter := &mockReporter{}
+
+		config := config
+		config.Reporter = reporter
+
+		e := httpexpect.WithConfig(config)
+
+		e.SmugglingProbe("/upload").
+			ExpectRejected(httpexpect.SmugglingDuplicateCL, httpexpect.SmugglingTESpace)
+
+		assert.False(t, reporter.failed)
+	})
+
+	t.Run("send", func(t *testing.T) {
+		reporter := &mockReporter{}
+
+		config := config
+		config.Reporter = reporter
+
+		e := httpexpect.WithConfig(config)
+
+		e.SmugglingProbe("/upload").
+			Send(httpexpect.SmugglingTEObfuscated).
+			StatusList(http.StatusBadRequest, http.StatusNotImplemented)
+
+		assert.False(t, reporter.failed)
+	})
+
+	t.Run("all variants", func(t *testing.T) {
+		reporter := &mockReporter{}
+
+		config := config
+		config.Reporter = reporter
+
+		e := httpexpect.WithConfig(config)
+
+		// net/http doesn't reject all variants with 400
+		e.SmugglingProbe("/upload").
+			ExpectRejected()
+
+		assert.True(t, reporter.failed)
+	})
+}
+
+func TestE2ESmuggling_HTTP(t *testing.T) {
+	server := httptest.NewServer(createSmugglingHandler())
+	defer server.Close()
+
+	testSmugglingHandler(t, httpexpect.Config{
+		BaseURL: server.URL,
+	})
+}
+
+func TestE2ESmuggling_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(createSmugglingHandler())
+	defer server.Close()
+
+	testSmugglingHandler(t, httpexpect.Config{
+		BaseURL: server.URL,
+		Client:  server.Client(),
+	})
+}
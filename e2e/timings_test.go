@@ -0,0 +1,90 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTimingsHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	return mux
+}
+
+func TestE2ETimings_HTTP(t *testing.T) {
+	server := httptest.NewServer(createTimingsHandler())
+	defer server.Close()
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  server.URL,
+		Client:   server.Client(),
+		Reporter: httpexpect.NewAssertReporter(t),
+	})
+
+	t.Run("new connection", func(t *testing.T) {
+		resp := e.GET("/slow").Expect()
+
+		timings := resp.Timings()
+		timings.TTFB().Ge(20 * time.Millisecond)
+		timings.TLSHandshake().IsEqual(0)
+
+		raw := timings.Raw()
+		assert.True(t, raw.Connect > 0)
+		assert.True(t, raw.Total >= raw.TTFB+raw.Transfer)
+
+		resp.Body().IsEqual("ok")
+	})
+
+	t.Run("reused connection", func(t *testing.T) {
+		timings := e.GET("/slow").Expect().Timings()
+
+		timings.TTFB().Ge(20 * time.Millisecond)
+		timings.DNS().IsEqual(0)
+		timings.Connect().IsEqual(0)
+		timings.TLSHandshake().IsEqual(0)
+	})
+}
+
+func TestE2ETimings_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(createTimingsHandler())
+	defer server.Close()
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  server.URL,
+		Client:   server.Client(),
+		Reporter: httpexpect.NewAssertReporter(t),
+	})
+
+	timings := e.GET("/slow").Expect().Timings()
+
+	timings.TTFB().Ge(20 * time.Millisecond)
+
+	raw := timings.Raw()
+	assert.True(t, raw.Connect > 0)
+	assert.True(t, raw.TLSHandshake > 0)
+}
+
+func TestE2ETimings_Binder(t *testing.T) {
+	reporter := &mockReporter{}
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		Client: &http.Client{
+			Transport: httpexpect.NewBinder(createTimingsHandler()),
+		},
+		Reporter: reporter,
+	})
+
+	e.GET("/slow").Expect().Timings()
+
+	assert.True(t, reporter.failed)
+}
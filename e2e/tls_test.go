@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gavv/httpexpect/v2"
 	"github.com/stretchr/testify/assert"
@@ -139,3 +140,57 @@ func TestE2EAutoTLS_BinderFast(t *testing.T) {
 		})
 	}
 }
+
+func TestE2ETLS_ConnectionState(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  server.URL,
+		Reporter: httpexpect.NewAssertReporter(t),
+		Client:   server.Client(),
+	})
+
+	state := e.GET("/").
+		Expect().
+		Status(http.StatusOK).
+		TLS()
+
+	state.HasMinVersion(tls.VersionTLS12)
+	state.Version().NotEmpty()
+	state.CipherSuite().NotEmpty()
+	state.NegotiatedProtocol().IsEqual("h2")
+	state.CertificateCount().IsEqual(1)
+
+	cert := state.Certificate(0)
+	cert.DNSNames().ContainsAll("example.com")
+	cert.IPAddresses().ContainsAll("127.0.0.1")
+	cert.IsValidFor(time.Hour)
+}
+
+func TestE2ETLS_NoConnectionState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	defer server.Close()
+
+	reporter := &mockReporter{}
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  server.URL,
+		Reporter: reporter,
+	})
+
+	e.GET("/").
+		Expect().
+		Status(http.StatusOK).
+		TLS()
+
+	assert.True(t, reporter.failed)
+}
@@ -0,0 +1,69 @@
+package e2e
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestE2EUnavailable_Skip(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	var (
+		subtest  *testing.T
+		reporter = &mockReporter{}
+		reached  = false
+	)
+
+	t.Run("skipped", func(t *testing.T) {
+		subtest = t
+
+		e := httpexpect.WithConfig(httpexpect.Config{
+			BaseURL:  "http://" + addr,
+			Reporter: reporter,
+		})
+
+		e.GET("/path").
+			SkipIfUnavailable(t).
+			Expect().
+			Status(http.StatusOK)
+
+		reached = true
+	})
+
+	assert.True(t, subtest.Skipped())
+	assert.False(t, reporter.failed)
+	assert.False(t, reached)
+}
+
+func TestE2EUnavailable_Available(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	defer server.Close()
+
+	reporter := &mockReporter{}
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  server.URL,
+		Reporter: reporter,
+	})
+
+	e.GET("/path").
+		SkipIfUnavailable(t).
+		Expect().
+		Status(http.StatusOK)
+
+	assert.False(t, reporter.failed)
+	assert.False(t, t.Skipped())
+}
@@ -210,6 +210,37 @@ func TestE2EWebsocket_HandlerStandard(t *testing.T) {
 			req.WithWebsocketDialer(httpexpect.NewWebsocketDialer(handler))
 		}))
 	})
+
+	t.Run("binder", func(t *testing.T) {
+		handler := createWebsocketHandler(wsHandlerOpts{})
+
+		e := httpexpect.WithConfig(httpexpect.Config{
+			Reporter: httpexpect.NewAssertReporter(t),
+			Client: &http.Client{
+				Transport: httpexpect.NewBinder(handler),
+			},
+			Printers: []httpexpect.Printer{
+				httpexpect.NewDebugPrinter(t, true),
+			},
+		})
+
+		testWebsocket(e)
+	})
+
+	t.Run("handler method", func(t *testing.T) {
+		handler := createWebsocketHandler(wsHandlerOpts{})
+
+		e := httpexpect.WithConfig(httpexpect.Config{
+			Reporter: httpexpect.NewAssertReporter(t),
+			Printers: []httpexpect.Printer{
+				httpexpect.NewDebugPrinter(t, true),
+			},
+		})
+
+		testWebsocket(e.Builder(func(req *httpexpect.Request) {
+			req.WithHandler(handler)
+		}))
+	})
 }
 
 func TestE2EWebsocket_HandlerFast(t *testing.T) {
@@ -237,6 +268,20 @@ func TestE2EWebsocket_HandlerFast(t *testing.T) {
 			req.WithWebsocketDialer(httpexpect.NewFastWebsocketDialer(websocketFastHandler))
 		}))
 	})
+
+	t.Run("binder", func(t *testing.T) {
+		e := httpexpect.WithConfig(httpexpect.Config{
+			Reporter: httpexpect.NewAssertReporter(t),
+			Client: &http.Client{
+				Transport: httpexpect.NewFastBinder(websocketFastHandler),
+			},
+			Printers: []httpexpect.Printer{
+				httpexpect.NewDebugPrinter(t, true),
+			},
+		})
+
+		testWebsocket(e)
+	})
 }
 
 func testWebsocketTimeout(
@@ -501,3 +546,306 @@ func TestE2EWebsocket_Invalid(t *testing.T) {
 		assert.True(t, reporter.failed)
 	})
 }
+
+func TestE2EWebsocket_Pings(t *testing.T) {
+	const (
+		pingInterval = 50 * time.Millisecond
+		pingCount    = 3
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := &websocket.Upgrader{}
+
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			panic(err)
+		}
+		defer c.Close()
+
+		go func() {
+			for {
+				if _, _, err := c.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		for i := 0; i < pingCount; i++ {
+			time.Sleep(pingInterval)
+
+			err := c.WriteControl(websocket.PingMessage, []byte("ping"),
+				time.Now().Add(time.Second))
+			if err != nil {
+				return
+			}
+		}
+
+		_ = c.WriteMessage(websocket.TextMessage, []byte("done"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	t.Run("success", func(t *testing.T) {
+		e := httpexpect.WithConfig(httpexpect.Config{
+			BaseURL:  server.URL,
+			Reporter: httpexpect.NewAssertReporter(t),
+			Printers: []httpexpect.Printer{
+				httpexpect.NewDebugPrinter(t, true),
+			},
+		})
+
+		ws := e.GET("/").WithWebsocketUpgrade().
+			Expect().
+			Status(http.StatusSwitchingProtocols).
+			Websocket()
+		defer ws.Disconnect()
+
+		ws.ExpectPings(pingInterval, 40*time.Millisecond, pingCount)
+
+		ws.Expect().TextMessage().Body().IsEqual("done")
+	})
+
+	t.Run("not enough pings", func(t *testing.T) {
+		reporter := &mockReporter{}
+
+		e := httpexpect.WithConfig(httpexpect.Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+			Printers: []httpexpect.Printer{
+				httpexpect.NewDebugPrinter(t, true),
+			},
+		})
+
+		ws := e.GET("/").WithWebsocketUpgrade().
+			Expect().
+			Status(http.StatusSwitchingProtocols).
+			Websocket()
+		defer ws.Disconnect()
+
+		ws.ExpectPings(pingInterval, 40*time.Millisecond, pingCount+1)
+
+		assert.True(t, reporter.failed)
+	})
+}
+
+func TestE2EWebsocket_ControlFrames(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := &websocket.Upgrader{
+			Subprotocols: []string{"graphql-ws"},
+		}
+
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			panic(err)
+		}
+		defer c.Close()
+
+		for {
+			mt, message, err := c.ReadMessage()
+			if err != nil {
+				break
+			}
+			if string(message) == "ping me" {
+				err = c.WriteControl(websocket.PingMessage, []byte("server ping"),
+					time.Now().Add(time.Second))
+			} else {
+				err = c.WriteMessage(mt, message)
+			}
+			if err != nil {
+				break
+			}
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  server.URL,
+		Reporter: httpexpect.NewAssertReporter(t),
+		Printers: []httpexpect.Printer{
+			httpexpect.NewDebugPrinter(t, true),
+		},
+	})
+
+	ws := e.GET("/").
+		WithWebsocketUpgrade().
+		WithWebsocketSubprotocols("graphql-transport-ws", "graphql-ws").
+		Expect().
+		Status(http.StatusSwitchingProtocols).
+		Websocket().
+		WithReadTimeout(time.Second)
+	defer ws.Disconnect()
+
+	ws.Subprotocol().IsEqual("graphql-ws")
+
+	ws.WritePing([]byte("client ping")).
+		ExpectPong().
+		Body().IsEqual("client ping")
+
+	ws.WriteText("hello").
+		WriteText("ping me").
+		ExpectPing().
+		Body().IsEqual("server ping")
+
+	ws.Expect().TextMessage().Body().IsEqual("hello")
+}
+
+func TestE2EWebsocket_ExpectMessages(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := &websocket.Upgrader{}
+
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			panic(err)
+		}
+		defer c.Close()
+
+		for {
+			_, message, err := c.ReadMessage()
+			if err != nil {
+				break
+			}
+			if string(message) != "subscribe" {
+				continue
+			}
+			for i := 0; i < 3; i++ {
+				_ = c.WriteMessage(websocket.TextMessage, []byte("heartbeat"))
+			}
+			_ = c.WriteMessage(websocket.TextMessage, []byte(`{"event": "subscribed"}`))
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	notHeartbeat := func(msg *httpexpect.WebsocketMessage) bool {
+		_, content, _ := msg.Raw()
+		return string(content) != "heartbeat"
+	}
+
+	t.Run("match", func(t *testing.T) {
+		e := httpexpect.WithConfig(httpexpect.Config{
+			BaseURL:  server.URL,
+			Reporter: httpexpect.NewAssertReporter(t),
+		})
+
+		ws := e.GET("/").
+			WithWebsocketUpgrade().
+			Expect().
+			Websocket().
+			WithReadTimeout(time.Second)
+		defer ws.Disconnect()
+
+		msgs := ws.WriteText("subscribe").
+			ExpectMessages(notHeartbeat)
+
+		msgs.Length().IsEqual(4)
+		msgs.Last().String().IsEqual(`{"event": "subscribed"}`)
+	})
+
+	t.Run("count reached", func(t *testing.T) {
+		reporter := &mockReporter{}
+
+		e := httpexpect.WithConfig(httpexpect.Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+
+		ws := e.GET("/").
+			WithWebsocketUpgrade().
+			Expect().
+			Websocket().
+			WithReadTimeout(time.Second)
+		defer ws.Disconnect()
+
+		ws.WriteText("subscribe").
+			ExpectMessages(notHeartbeat, httpexpect.ExpectMessagesOpts{Count: 2})
+
+		assert.True(t, reporter.failed)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		reporter := &mockReporter{}
+
+		e := httpexpect.WithConfig(httpexpect.Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+
+		ws := e.GET("/").
+			WithWebsocketUpgrade().
+			Expect().
+			Websocket()
+		defer ws.Disconnect()
+
+		msgs := ws.ExpectMessages(nil, httpexpect.ExpectMessagesOpts{
+			Timeout: 50 * time.Millisecond,
+		})
+
+		assert.False(t, reporter.failed)
+		msgs.IsEmpty()
+
+		ws.WriteText("subscribe").
+			ExpectMessages(notHeartbeat).
+			Length().IsEqual(4)
+
+		assert.False(t, reporter.failed)
+	})
+}
+
+func TestE2EWebsocket_ExpectClose(t *testing.T) {
+	t.Run("disconnected", func(t *testing.T) {
+		handler := createWebsocketHandler(wsHandlerOpts{})
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		reporter := &mockReporter{}
+
+		e := httpexpect.WithConfig(httpexpect.Config{
+			BaseURL:     server.URL,
+			Reporter:    reporter,
+			StrictClose: true,
+		})
+
+		ws := e.GET("/test").WithWebsocketUpgrade().
+			Expect().
+			Status(http.StatusSwitchingProtocols).
+			Websocket()
+
+		ws.Disconnect()
+
+		e.Close()
+		assert.False(t, reporter.failed)
+	})
+
+	t.Run("leaked", func(t *testing.T) {
+		handler := createWebsocketHandler(wsHandlerOpts{})
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		reporter := &mockReporter{}
+
+		e := httpexpect.WithConfig(httpexpect.Config{
+			BaseURL:     server.URL,
+			Reporter:    reporter,
+			StrictClose: true,
+		})
+
+		ws := e.GET("/test").WithWebsocketUpgrade().
+			Expect().
+			Status(http.StatusSwitchingProtocols).
+			Websocket()
+
+		e.Close()
+		assert.True(t, reporter.failed)
+
+		// connection was closed by Close()
+		reporter.failed = false
+		ws.WriteText("test")
+		assert.True(t, reporter.failed)
+	})
+}
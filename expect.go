@@ -75,12 +75,25 @@
 //
 // Custom AssertionHandler can handle all assertions (e.g. dump them in JSON format)
 // and is free to use or not to use Formatter and Reporter in its sole discretion.
+//
+// JSONAssertionHandler, JUnitAssertionHandler, and AllureAssertionHandler are
+// ready to use implementations that write assertions as JSON records, JUnit XML
+// report, or Allure results, optionally forwarding them to another handler.
+//
+// Handlers that also implement AttachmentAssertionHandler receive dumps of
+// request and response along with every failure.
 package httpexpect
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 
 	"github.com/gorilla/websocket"
 )
@@ -91,8 +104,14 @@ type Expect struct {
 	noCopy   noCopy
 	config   Config
 	chain    *chain
-	builders []func(*Request)
-	matchers []func(*Response)
+	builders []*expectBuilder
+	matchers []func(*MatcherContext, *Response)
+
+	// protects builders modified by WithScopedBuilder
+	mu sync.Mutex
+
+	connCounter *connectionCounter
+	invariants  *InvariantMonitor
 }
 
 // Config contains various settings.
@@ -104,6 +123,29 @@ type Config struct {
 	// Normally you set this value to t.Name().
 	TestName string
 
+	// AutoAliasFromTest enables automatic request aliases derived from
+	// TestName.
+	//
+	// If true and TestName contains subtest name, e.g. "TestUsers/admin",
+	// every request created by Expect gets subtest name ("admin") as alias,
+	// see Request.Alias. This way failures in table tests identify failing
+	// case without calling Alias or WithName in every row. Nested subtests
+	// are kept, e.g. "TestUsers/create/admin" gives "create/admin".
+	//
+	// If failures are reported to testing.TB (e.g. *testing.T is used as
+	// Reporter), test name is taken from it instead of TestName. The same
+	// applies to Request.WithReporter and Request.WithAssertionHandler:
+	// when request is redirected to reporter of a subtest, alias is updated
+	// to name of that subtest. Alias set explicitly using Request.Alias
+	// is never overridden.
+	//
+	// Note that TestName is fixed when Expect is created. If Expect is shared
+	// between subtests and reports via reporter that doesn't provide test
+	// name (e.g. AssertReporter), all requests get alias from TestName.
+	//
+	// See also Expect.WithTestNameAliases.
+	AutoAliasFromTest bool
+
 	// BaseURL is a URL to prepended to all requests.
 	// May be empty.
 	//
@@ -111,6 +153,15 @@ type Config struct {
 	// automatically.
 	BaseURL string
 
+	// BasePath is a path prefix inserted between BaseURL and request path.
+	// May be empty.
+	//
+	// If non-empty, leading and trailing slashes are optional. BaseURL,
+	// BasePath, and request path are always joined using a single slash.
+	// BasePath is also applied when base URL is overwritten using
+	// Request.WithURL, and can be overwritten using Request.WithBasePath.
+	BasePath string
+
 	// RequestFactory is used to pass in a custom *http.Request generation func.
 	// May be nil.
 	//
@@ -139,10 +190,34 @@ type Config struct {
 	// If nil, set to a default dialer:
 	//  &websocket.Dialer{}
 	//
+	// If nil, and request is sent using http.Client with Binder or FastBinder
+	// transport (set in Client, or by Request.WithClient or WithHandler),
+	// websocket requests use a dialer that invokes the same handler directly,
+	// without real network connection (see NewWebsocketDialer and
+	// NewFastWebsocketDialer). Such dialer doesn't support TLS and can be used
+	// only with "ws://" URLs. Dialer set explicitly is never replaced.
+	//
 	// You can use websocket.DefaultDialer or websocket.Dialer, or provide
 	// custom implementation.
 	WebsocketDialer WebsocketDialer
 
+	// DNSOverrides maps host names to IP addresses used to connect to them,
+	// like entries in /etc/hosts.
+	// May be nil.
+	//
+	// Keys are host names (case-insensitive), and values are IP addresses,
+	// optionally with port, e.g. "10.0.0.5" or "10.0.0.5:8443". If port is
+	// omitted, port from request URL is used. Request URL, Host header, and
+	// TLS server name are not changed.
+	//
+	// If non-empty, Client should be *http.Client with *http.Transport or nil
+	// transport, and WebsocketDialer should be *websocket.Dialer. Transport
+	// and dialer are cloned once, and their dial functions are wrapped.
+	// Proxy settings are ignored, i.e. connections are established directly
+	// to overridden addresses. Clients set using Request.WithClient are
+	// used as is. Request.WithResolveTo takes precedence over overrides.
+	DNSOverrides map[string]string
+
 	// Context is passed to all requests. It is typically used for request cancellation,
 	// either explicit or after a time-out.
 	// May be nil.
@@ -151,6 +226,20 @@ type Config struct {
 	// for per-request timeout.
 	Context context.Context
 
+	// RequestIDHeader defines name of the header used to correlate requests
+	// with server logs, e.g. "X-Request-Id".
+	// May be empty.
+	//
+	// If non-empty, every request sent by Request.Expect, Request.Repeat,
+	// and Request.DifferentialProtocols, or built by Request.Build, gets
+	// this header with random UUID, unless the header was set explicitly,
+	// in which case its value is used as request ID. Request.Repeat
+	// generates new UUID for every sample. Request ID is included in failure
+	// reports (see AssertionContext.RequestID) and in printer output as part
+	// of request headers. Use Response.HasRequestID to check that server
+	// echoed it back.
+	RequestIDHeader string
+
 	// Reporter is used to report formatted failure messages.
 	// Should NOT be nil, unless custom AssertionHandler is used.
 	//
@@ -205,13 +294,130 @@ type Config struct {
 	// If printer implements WebsocketPrinter interface, it will be also used
 	// to print Websocket messages.
 	//
-	// You can use CompactPrinter, DebugPrinter, CurlPrinter, or provide
-	// custom implementation.
+	// You can use CompactPrinter, DebugPrinter, LazyPrinter, CurlPrinter, or
+	// provide custom implementation.
 	//
 	// You can also use builtin printers with alternative Logger if you're happy
 	// with their format, but want to send logs somewhere else than *testing.T.
 	Printers []Printer
 
+	// Verbosity selects printers for requests and responses in one setting.
+	// Default is VerbosityDefault, which uses Printers as is.
+	//
+	// Other levels select builtin printers writing to Logger, or to Reporter
+	// if it implements Logger (like *testing.T does), and can't be used
+	// together with non-empty Printers. They also affect AssertionHandler
+	// if it's not set: VerbositySilent disables printing of warnings, and
+	// VerbosityDebug enables printing of successful assertions. Can be
+	// overridden per request using Request.WithVerbosity.
+	Verbosity Verbosity
+
+	// RequestHooks are invoked for every request before it's sent.
+	// May be nil.
+	//
+	// Hooks are invoked in order, after request transformers, and may modify
+	// request, e.g. to add authentication headers or signature. Request body
+	// may be read by hook; it's rewound after every hook.
+	//
+	// If hook returns error, request is not sent and failure is reported.
+	//
+	// Unlike Request.WithTransformer, hooks are specified once for all requests
+	// and can abort request with failure.
+	RequestHooks []func(*http.Request) error
+
+	// ResponseHooks are invoked for every received response.
+	// May be nil.
+	//
+	// Hooks are invoked in order, before response matchers. Response body
+	// may be read by hook; it's rewound after every hook.
+	//
+	// If hook returns error, failure is reported.
+	//
+	// Useful for audit logging and header policy checks that should be
+	// applied to all responses.
+	ResponseHooks []func(*http.Response) error
+
+	// OnFailure is invoked for every failed assertion.
+	// May be nil.
+	//
+	// Hook is invoked after AssertionHandler, with failed assertion, dumps of
+	// request and response it relates to (if any), and response round-trip
+	// time. Only failures with SeverityError are passed to hook.
+	//
+	// For failures collected in soft mode (see Expect.Soft), hook is invoked
+	// when Expect.Verify reports them.
+	//
+	// Useful for integrations like posting failures to a chat, saving repro
+	// bundles, or triggering debugging captures, without implementing custom
+	// AssertionHandler.
+	OnFailure func(ctx FailureContext)
+
+	// MetricsCollector is invoked for every HTTP round trip, including retries.
+	// May be nil.
+	//
+	// Collector receives request labels (name or alias, method, status class,
+	// retry attempt) and round-trip time.
+	//
+	// You can use MemoryMetrics to assert traffic generated by tests, e.g.
+	// number of retries, or provide custom implementation, e.g. to export
+	// metrics to Prometheus.
+	MetricsCollector MetricsCollector
+
+	// ResponseCache enables client-side caching of GET and HEAD responses.
+	// May be nil.
+	//
+	// Responses with ETag or Last-Modified header are stored, and repeated
+	// requests are sent as conditional requests. If server responds with
+	// "304 Not Modified", stored response is returned instead. Responses
+	// with body larger than MaxBufferedBodySize are not stored.
+	//
+	// Useful to speed up read-heavy suites against slow environments, and
+	// to test caching headers by asserting ResponseCache.Hits.
+	ResponseCache *ResponseCache
+
+	// SnapshotDir defines directory where golden files used by
+	// Response.MatchSnapshot are stored.
+	// If empty, "testdata/snapshots" is used.
+	SnapshotDir string
+
+	// Redactors are used to mask secrets and normalize volatile fields,
+	// like UUIDs and timestamps.
+	// May be nil.
+	//
+	// Redactors are applied to expected and actual values before JSON
+	// equality assertions (IsEqual, NotEqual, and their variants), to JSON
+	// bodies in snapshots (Response.MatchSnapshot), and to JSON bodies of
+	// requests and responses passed to Printers.
+	//
+	// For every node of JSON value, redactors are invoked in order with JSON
+	// path of the node (e.g. "$.users[1].token") and node value. First
+	// redactor that returns true replaces the node with returned value;
+	// replaced node is not descended into. In equality assertions, root "$"
+	// is the value being compared, not the whole response body.
+	//
+	// Example:
+	//
+	//	Redactors: []func(string, interface{}) (interface{}, bool){
+	//		func(path string, value interface{}) (interface{}, bool) {
+	//			if strings.HasSuffix(path, ".token") {
+	//				return "<redacted>", true
+	//			}
+	//			return nil, false
+	//		},
+	//	}
+	Redactors []func(path string, value interface{}) (interface{}, bool)
+
+	// Logger is used to write debug messages from custom matchers and
+	// transformers, see Request.Logger and RequestLogger.
+	// May be nil.
+	//
+	// If nil, messages are written to Reporter if it implements Logger
+	// interface (e.g. *testing.T), and are discarded otherwise.
+	//
+	// Usually you want to use the same Logger as for Printers, so that
+	// debug messages are interleaved with printed requests and responses.
+	Logger Logger
+
 	// Environment provides a container for arbitrary data shared between tests.
 	// May be nil.
 	//
@@ -222,9 +428,90 @@ type Config struct {
 	// If Environment is nil, a new empty environment is automatically created
 	// when Expect instance is constructed.
 	Environment *Environment
+
+	// OnClose hooks are invoked by Expect.Close, in order.
+	// May be nil.
+	//
+	// If hook returns error, failure is reported. Useful to release
+	// resources associated with tests, e.g. to stop mock servers or flush
+	// custom reports.
+	OnClose []func() error
+
+	// StrictClose enables additional checks in Expect.Close.
+	//
+	// If true, Expect.Close reports failure if some websockets were not
+	// disconnected, or if some requests were created but never sent
+	// (e.g. Expect was not called).
+	StrictClose bool
+
+	// Clock is used to wait between retries, to measure round-trip time,
+	// and to get current time in time-dependent assertions, like
+	// Certificate.IsValidFor.
+	// May be nil.
+	//
+	// If nil, SystemClock is used. Use FakeClock to make tests of retries
+	// and backoff instant and deterministic.
+	//
+	// Note that Clock doesn't affect timeouts (see Request.WithTimeout)
+	// and websocket deadlines, which are handled by network stack.
+	Clock Clock
+
+	// RandSource is used to generate random values, like multipart
+	// boundaries, retry jitter, and request correlation IDs.
+	// May be nil.
+	//
+	// If nil, global random generators are used. Set it to a source with
+	// fixed seed (e.g. rand.NewSource(1)) to make generated requests
+	// byte-stable, e.g. for snapshot tests of outgoing traffic.
+	//
+	// Source may be shared by concurrent requests; it's protected by mutex.
+	RandSource rand.Source
+
+	// MaxBufferedBodySize defines maximum size of response body, in bytes,
+	// retained in memory to allow re-reading it by multiple assertions.
+	// May be zero.
+	//
+	// If zero, body size is not limited. If response body exceeds the limit,
+	// first assertion that reads it (e.g. Response.Body or Response.JSON)
+	// still works, but body is not retained, and subsequent assertions
+	// report usage failure. Useful for soak tests with huge responses.
+	//
+	// See also Response.DisableBodyRewinds.
+	MaxBufferedBodySize int64
+
+	// RateLimit limits rate of requests, to avoid tripping server-side
+	// rate limits of shared environments, like staging.
+	// May be zero.
+	//
+	// If RateLimit.RequestsPerSecond is zero, rate is not limited.
+	// Otherwise, all requests created by Expect instance and its copies
+	// (see Expect.Builder and similar methods) share the same token bucket.
+	// Every attempt to send request, including retries, Request.Repeat
+	// samples, raw requests, smuggling probes, and websocket handshakes,
+	// waits for a token. Waiting uses Clock, is interrupted
+	// when Context is canceled, and is not included into round-trip time.
+	//
+	// Requests created using NewRequestC get their own token bucket.
+	RateLimit RateLimit
+
+	// token bucket for RateLimit; set by withDefaults
+	rateLimiter *rateLimiter
+
+	// true if WebsocketDialer was not set explicitly; set by withDefaults
+	websocketDialerDefault bool
+
+	// true if Printers were selected by Verbosity; set by withDefaults
+	verbosityApplied bool
+
+	// resources tracked by Expect.Close; set by WithConfig
+	lifecycle *lifecycle
 }
 
 func (config Config) withDefaults() Config {
+	if err := config.check(false); err != nil {
+		panic(err.Error())
+	}
+
 	if config.RequestFactory == nil {
 		config.RequestFactory = DefaultRequestFactory{}
 	}
@@ -237,6 +524,17 @@ func (config Config) withDefaults() Config {
 
 	if config.WebsocketDialer == nil {
 		config.WebsocketDialer = &websocket.Dialer{}
+		config.websocketDialerDefault = true
+	}
+
+	if config.Clock == nil {
+		config.Clock = SystemClock{}
+	}
+
+	config.RandSource = newLockedSource(config.RandSource)
+
+	if config.rateLimiter == nil && config.RateLimit.isEnabled() {
+		config.rateLimiter = newRateLimiter(config.RateLimit)
 	}
 
 	if config.AssertionHandler == nil {
@@ -244,19 +542,219 @@ func (config Config) withDefaults() Config {
 			config.Formatter = &DefaultFormatter{}
 		}
 
-		if config.Reporter == nil {
-			panic("either Config.Reporter or Config.AssertionHandler should be non-nil")
-		}
-
 		config.AssertionHandler = &DefaultAssertionHandler{
 			Formatter: config.Formatter,
 			Reporter:  config.Reporter,
+			Logger:    verbosityHandlerLogger(config, config.Verbosity),
 		}
 	}
 
+	if config.Verbosity != VerbosityDefault && !config.verbosityApplied {
+		config.Printers = verbosityPrinters(config, config.Verbosity)
+		config.verbosityApplied = true
+	}
+
 	return config
 }
 
+func (config Config) withDNSOverrides() Config {
+	if len(config.DNSOverrides) == 0 {
+		return config
+	}
+
+	for _, target := range config.DNSOverrides {
+		if err := validateResolveOverride(target); err != nil {
+			panic(fmt.Sprintf("invalid Config.DNSOverrides: %s", err))
+		}
+	}
+
+	resolve := resolveOverrides(config.DNSOverrides)
+
+	client, err := resolveClient(config.Client, resolve)
+	if err != nil {
+		panic(fmt.Sprintf("Config.DNSOverrides can't be used with Config.Client: %s",
+			err))
+	}
+	config.Client = client
+
+	dialer, err := resolveWebsocketDialer(config.WebsocketDialer, resolve)
+	if err != nil {
+		panic(fmt.Sprintf(
+			"Config.DNSOverrides can't be used with Config.WebsocketDialer: %s", err))
+	}
+	config.WebsocketDialer = dialer
+
+	return config
+}
+
+// ConfigError is returned by Config.Check and describes all problems found
+// in Config.
+type ConfigError struct {
+	Errors []error
+}
+
+// Error implements error interface.
+func (e *ConfigError) Error() string {
+	if len(e.Errors) == 1 {
+		return "invalid httpexpect.Config: " + e.Errors[0].Error()
+	}
+
+	var b strings.Builder
+
+	b.WriteString("invalid httpexpect.Config:")
+	for _, err := range e.Errors {
+		b.WriteString("\n  - ")
+		b.WriteString(err.Error())
+	}
+
+	return b.String()
+}
+
+// Check reports misconfigurations in Config.
+//
+// If there are any problems, returns *ConfigError with all of them,
+// otherwise returns nil. Zero fields that have defaults (see Config
+// documentation) are not reported.
+//
+// WithConfig and other constructors accepting Config perform the same
+// checks and panic on failure, except that they don't check BaseURL:
+// for compatibility, constructors accept any BaseURL, including relative
+// ones. Check can be used to validate config beforehand, e.g. when it's
+// loaded from environment or file, and requires BaseURL, if set, to be
+// an absolute URL with http, https, ws, or wss scheme.
+//
+// Example:
+//
+//	config := httpexpect.Config{
+//		BaseURL:  os.Getenv("API_URL"),
+//		Reporter: httpexpect.NewAssertReporter(t),
+//	}
+//
+//	if err := config.Check(); err != nil {
+//		t.Fatal(err)
+//	}
+func (config Config) Check() error {
+	return config.check(true)
+}
+
+// If strict is false, skips checks that constructors don't perform.
+func (config Config) check(strict bool) error {
+	var errs []error
+
+	if config.Reporter == nil && config.AssertionHandler == nil {
+		errs = append(errs, fmt.Errorf(
+			"either Config.Reporter or Config.AssertionHandler should be non-nil"))
+	}
+
+	if handler, ok := config.AssertionHandler.(*DefaultAssertionHandler); ok {
+		if handler.Formatter == nil {
+			errs = append(errs, fmt.Errorf(
+				"Config.AssertionHandler is DefaultAssertionHandler with nil Formatter"))
+		}
+
+		if handler.Reporter == nil {
+			errs = append(errs, fmt.Errorf(
+				"Config.AssertionHandler is DefaultAssertionHandler with nil Reporter"))
+		}
+	}
+
+	if strict && config.BaseURL != "" {
+		if err := checkBaseURL(config.BaseURL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if config.MaxBufferedBodySize < 0 {
+		errs = append(errs, fmt.Errorf(
+			"Config.MaxBufferedBodySize should be non-negative, got %d",
+			config.MaxBufferedBodySize))
+	}
+
+	if !config.Verbosity.isValid() {
+		errs = append(errs, fmt.Errorf(
+			"Config.Verbosity has invalid value %s", config.Verbosity))
+	}
+
+	if config.Verbosity != VerbosityDefault && len(config.Printers) != 0 &&
+		!config.verbosityApplied {
+		errs = append(errs, fmt.Errorf(
+			"Config.Printers and Config.Verbosity %s can't be used together",
+			config.Verbosity))
+	}
+
+	if !config.RateLimit.isValid() {
+		errs = append(errs, fmt.Errorf(
+			"Config.RateLimit should have finite non-negative RequestsPerSecond"+
+				" and non-negative Burst, got %v and %d",
+			config.RateLimit.RequestsPerSecond, config.RateLimit.Burst))
+	}
+
+	errs = append(errs, config.checkDNSOverrides()...)
+
+	if len(errs) != 0 {
+		return &ConfigError{Errors: errs}
+	}
+
+	return nil
+}
+
+func checkBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("Config.BaseURL is invalid: %w", err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf(
+			"Config.BaseURL should be absolute URL with scheme and host,"+
+				" e.g. \"http://example.com\", got %q", baseURL)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "ws", "wss":
+	default:
+		return fmt.Errorf(
+			"Config.BaseURL should have http, https, ws, or wss scheme, got %q",
+			baseURL)
+	}
+
+	return nil
+}
+
+func (config Config) checkDNSOverrides() []error {
+	if len(config.DNSOverrides) == 0 {
+		return nil
+	}
+
+	var errs []error
+
+	for _, host := range sortedKeys(config.DNSOverrides) {
+		if err := validateResolveOverride(config.DNSOverrides[host]); err != nil {
+			errs = append(errs, fmt.Errorf(
+				"Config.DNSOverrides has invalid target for %q: %w", host, err))
+		}
+	}
+
+	resolve := resolveOverrides(config.DNSOverrides)
+
+	if config.Client != nil {
+		if _, err := resolveClient(config.Client, resolve); err != nil {
+			errs = append(errs, fmt.Errorf(
+				"Config.DNSOverrides can't be used with Config.Client: %w", err))
+		}
+	}
+
+	if config.WebsocketDialer != nil {
+		if _, err := resolveWebsocketDialer(config.WebsocketDialer, resolve); err != nil {
+			errs = append(errs, fmt.Errorf(
+				"Config.DNSOverrides can't be used with Config.WebsocketDialer: %w",
+				err))
+		}
+	}
+
+	return errs
+}
+
 func (config *Config) validate() {
 	if config.RequestFactory == nil {
 		panic("Config.RequestFactory is nil")
@@ -270,6 +768,10 @@ func (config *Config) validate() {
 		panic("Config.AssertionHandler is nil")
 	}
 
+	if config.MaxBufferedBodySize < 0 {
+		panic("Config.MaxBufferedBodySize is negative")
+	}
+
 	if handler, ok := config.AssertionHandler.(*DefaultAssertionHandler); ok {
 		if handler.Formatter == nil {
 			panic("DefaultAssertionHandler.Formatter is nil")
@@ -439,6 +941,13 @@ type TestingTB interface {
 	Name() string // Returns current test name.
 }
 
+// Skipper is used to skip test, see Request.SkipIfUnavailable.
+// *testing.T implements this interface.
+type Skipper interface {
+	// Skip marks test as skipped and stops its execution.
+	Skip(args ...interface{})
+}
+
 // Deprecated: use TestingTB instead.
 type LoggerReporter interface {
 	Logger
@@ -516,13 +1025,16 @@ func Default(t TestingTB, baseURL string) *Expect {
 //			Status(http.StatusOK)
 //	}
 func WithConfig(config Config) *Expect {
-	config = config.withDefaults()
+	config = config.withDefaults().withDNSOverrides()
 
 	config.validate()
 
+	config.lifecycle = newLifecycle()
+
 	return &Expect{
-		chain:  newChainWithConfig("", config),
-		config: config,
+		chain:       newChainWithConfig("", config),
+		config:      config,
+		connCounter: &connectionCounter{},
 	}
 }
 
@@ -539,12 +1051,42 @@ func (e *Expect) Env() *Environment {
 	return e.chain.env()
 }
 
+// ConnectionStats returns number of new and reused connections used by
+// requests created by Expect instance and its copies (see Builder and
+// Matcher).
+//
+// Every request, including redirects and retries, is counted once.
+// Connections are tracked using httptrace hooks, so requests sent via
+// clients that don't invoke them, e.g. Binder, are not counted.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	for i := 0; i < 10; i++ {
+//		e.GET("/path").Expect().Status(http.StatusOK)
+//	}
+//
+//	stats := e.ConnectionStats()
+//	assert.Equal(t, 1, stats.New)
+//	assert.Equal(t, 9, stats.Reused)
+func (e *Expect) ConnectionStats() ConnectionStats {
+	if e.connCounter == nil {
+		return ConnectionStats{}
+	}
+
+	return e.connCounter.get()
+}
+
 func (e *Expect) clone() *Expect {
 	return &Expect{
 		config:   e.config,
 		chain:    e.chain.clone(),
-		builders: append(([]func(*Request))(nil), e.builders...),
-		matchers: append(([]func(*Response))(nil), e.matchers...),
+		builders: e.getBuilders(),
+		matchers: append(([]func(*MatcherContext, *Response))(nil), e.matchers...),
+
+		connCounter: e.connCounter,
+		invariants:  e.invariants,
 	}
 }
 
@@ -570,36 +1112,394 @@ func (e *Expect) clone() *Expect {
 func (e *Expect) Builder(builder func(*Request)) *Expect {
 	ret := e.clone()
 
-	ret.builders = append(ret.builders, builder)
+	ret.builders = append(ret.builders, &expectBuilder{fn: builder})
 	return ret
 }
 
+// NamedBuilder is like Builder, but attaches builder with given name,
+// which can be later removed using RemoveBuilder.
+//
+// If builder with the same name is already attached, it is replaced.
+// If name is empty, failure is reported, and builder is not attached.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	auth := e.NamedBuilder("auth", func (req *httpexpect.Request) {
+//		req.WithHeader("Authorization", "Bearer "+token)
+//	})
+//
+//	auth.GET("/restricted").
+//		Expect().
+//		Status(http.StatusOK)
+//
+//	auth.RemoveBuilder("auth").GET("/restricted").
+//		Expect().
+//		Status(http.StatusUnauthorized)
+func (e *Expect) NamedBuilder(name string, builder func(*Request)) *Expect {
+	opChain := e.chain.enter("NamedBuilder()")
+	defer opChain.leave()
+
+	if name == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty builder name"),
+			},
+		})
+		return e.clone()
+	}
+
+	ret := e.RemoveBuilder(name)
+
+	ret.builders = append(ret.builders, &expectBuilder{name: name, fn: builder})
+	return ret
+}
+
+// RemoveBuilder returns a copy of Expect instance without builder attached
+// using NamedBuilder with given name.
+//
+// If there is no such builder, returned copy contains all builders of
+// original instance. See NamedBuilder for usage example.
+func (e *Expect) RemoveBuilder(name string) *Expect {
+	ret := e.clone()
+
+	builders := ret.builders[:0]
+	for _, b := range ret.builders {
+		if name == "" || b.name != name {
+			builders = append(builders, b)
+		}
+	}
+	ret.builders = builders
+
+	return ret
+}
+
+// WithScopedBuilder attaches builder to Expect instance (not to a copy),
+// invokes body, and detaches builder after body returns or panics.
+//
+// It allows to apply builder temporarily to a group of requests, e.g.
+// to test several auth variations with the same Expect instance.
+// Requests created by this instance from other goroutines while body
+// is running are affected as well.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	asAdmin := func(req *httpexpect.Request) {
+//		req.WithHeader("X-Role", "admin")
+//	}
+//
+//	e.WithScopedBuilder(asAdmin, func() {
+//		e.DELETE("/users/123").
+//			Expect().
+//			Status(http.StatusNoContent)
+//	})
+//
+//	e.DELETE("/users/123").
+//		Expect().
+//		Status(http.StatusForbidden)
+func (e *Expect) WithScopedBuilder(builder func(*Request), body func()) {
+	scoped := &expectBuilder{fn: builder}
+
+	e.mu.Lock()
+	e.builders = append(e.builders[:len(e.builders):len(e.builders)], scoped)
+	e.mu.Unlock()
+
+	defer func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		builders := make([]*expectBuilder, 0, len(e.builders))
+		for _, b := range e.builders {
+			if b != scoped {
+				builders = append(builders, b)
+			}
+		}
+		e.builders = builders
+	}()
+
+	body()
+}
+
+// Builder attached to Expect instance.
+type expectBuilder struct {
+	name string
+	fn   func(*Request)
+}
+
+func (e *Expect) getBuilders() []*expectBuilder {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append(([]*expectBuilder)(nil), e.builders...)
+}
+
 // Matcher returns a copy of Expect instance with given matcher attached to it.
 // Returned copy contains all previously attached matchers plus a new one.
 // Matchers are invoked from Request.Expect method, after retrieving a new response.
 //
+// Matchers attached to Expect are applied to every response from requests
+// created by the returned instance, before matchers attached to individual
+// requests via Request.WithMatcher.
+//
 // Example:
 //
-//	 e := httpexpect.Default(t, "http://example.com")
+//	e := httpexpect.Default(t, "http://example.com")
 //
-//	 m := e.Matcher(func (resp *httpexpect.Response) {
-//		 resp.Header("API-Version").NotEmpty()
-//	 })
+//	m := e.Matcher(func (resp *httpexpect.Response) {
+//		resp.Header("API-Version").NotEmpty()
+//		resp.Header("X-Correlation-Id").NotEmpty()
+//	})
 //
-//	 m.GET("/some-path").
-//			Expect().
-//			Status(http.StatusOK)
+//	m.GET("/some-path").
+//		Expect().
+//		Status(http.StatusOK)
 //
-//	 m.GET("/bad-path").
-//			Expect().
-//			Status(http.StatusNotFound)
+//	m.GET("/bad-path").
+//		Expect().
+//		Status(http.StatusNotFound)
 func (e *Expect) Matcher(matcher func(*Response)) *Expect {
 	ret := e.clone()
 
+	if matcher == nil {
+		// reported when matcher is attached to request
+		ret.matchers = append(ret.matchers, nil)
+		return ret
+	}
+
+	ret.matchers = append(ret.matchers, func(_ *MatcherContext, resp *Response) {
+		matcher(resp)
+	})
+	return ret
+}
+
+// ContextMatcher is like Matcher, but matcher also receives MatcherContext.
+// See Request.WithContextMatcher.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	m := e.ContextMatcher(
+//		func(ctx *httpexpect.MatcherContext, resp *httpexpect.Response) {
+//			ctx.Attach("request name", "text/plain", []byte(ctx.RequestName))
+//			resp.Header("API-Version").NotEmpty()
+//		})
+//
+//	m.GET("/some-path").
+//		WithName("some request").
+//		Expect().
+//		Status(http.StatusOK)
+func (e *Expect) ContextMatcher(matcher func(*MatcherContext, *Response)) *Expect {
+	ret := e.clone()
+
 	ret.matchers = append(ret.matchers, matcher)
 	return ret
 }
 
+// WithInvariants returns a copy of Expect instance with given monitor
+// attached to it. Monitor observes every response from requests created
+// by returned instance and its copies.
+//
+// Invariants declared in monitor are checked by VerifyInvariants.
+// See InvariantMonitor for details.
+//
+// If monitor is nil, failure is reported, and monitor is not attached.
+//
+// Example:
+//
+//	monitor := httpexpect.NewInvariantMonitor().
+//		WithMaxResponseTime(2 * time.Second).
+//		WithMaxErrorRate(0.01)
+//
+//	e := httpexpect.Default(t, "http://example.com").
+//		WithInvariants(monitor)
+//
+//	// run functional tests using e
+//
+//	e.VerifyInvariants(t)
+func (e *Expect) WithInvariants(monitor *InvariantMonitor) *Expect {
+	opChain := e.chain.enter("WithInvariants()")
+	defer opChain.leave()
+
+	if monitor == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil monitor"),
+			},
+		})
+		return e.clone()
+	}
+
+	ret := e.ContextMatcher(func(_ *MatcherContext, resp *Response) {
+		monitor.observe(resp)
+	})
+
+	ret.invariants = monitor
+	return ret
+}
+
+// WithSchemaDrift returns a copy of Expect instance which records shape
+// of JSON responses and detects its drift.
+//
+// For every successful (2xx) JSON response of a named request (see
+// Request.WithName), shape of response body (JSON paths of all fields
+// and their types) is compared with shape recorded for the same request
+// name by previous runs. New and removed fields and changed types are
+// reported with configured severity.
+//
+// Shapes are stored in SchemaDriftOpts.Dir (by default, "testdata/schemas")
+// in files named "<request name>.json". If file doesn't exist, or if
+// UPDATE_SNAPSHOTS environment variable is set to a non-empty value other
+// than "0" and "false", shape is recorded and check succeeds.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com").
+//		WithSchemaDrift(httpexpect.SchemaDriftOpts{
+//			Severity: httpexpect.SeverityWarning,
+//		})
+//
+//	e.GET("/users/123").
+//		WithName("get_user").
+//		Expect().
+//		Status(http.StatusOK)
+func (e *Expect) WithSchemaDrift(options ...SchemaDriftOpts) *Expect {
+	if len(options) > 1 {
+		panic("unexpected multiple options arguments")
+	}
+
+	var opts SchemaDriftOpts
+	if len(options) != 0 {
+		opts = options[0]
+	}
+
+	return e.ContextMatcher(func(ctx *MatcherContext, resp *Response) {
+		if ctx.RequestName == "" || resp.httpResp == nil {
+			return
+		}
+		if resp.httpResp.StatusCode < 200 || resp.httpResp.StatusCode >= 300 {
+			return
+		}
+		if !isJSONContent(resp.httpResp.Header) {
+			return
+		}
+
+		resp.checkSchemaDrift(ctx.RequestName, opts)
+	})
+}
+
+// VerifyInvariants checks invariants of monitor attached using
+// WithInvariants against all responses observed so far, and reports
+// a failure to given reporter for every violated invariant.
+//
+// Reporter is usually testing.T of the suite, since VerifyInvariants
+// is intended to be called at suite teardown.
+//
+// If reporter is nil, the function panics.
+// If no monitor is attached, failure is reported.
+//
+// See WithInvariants for usage example.
+func (e *Expect) VerifyInvariants(reporter Reporter) {
+	if e.invariants == nil {
+		opChain := newChainWithDefaults("VerifyInvariants()", reporter).enter("")
+		defer opChain.leave()
+
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected call: no invariant monitor attached," +
+					" see WithInvariants"),
+			},
+		})
+		return
+	}
+
+	e.invariants.verify(reporter)
+}
+
+// WithTestNameAliases returns a copy of Expect instance with
+// Config.AutoAliasFromTest enabled.
+//
+// Requests created by returned copy get subtest name from Config.TestName
+// (or from testing.TB used as reporter) as alias, so that failure paths
+// identify the failing case. See Config.AutoAliasFromTest for details.
+//
+// Example:
+//
+//	for _, tc := range cases {
+//		t.Run(tc.name, func(t *testing.T) {
+//			e := httpexpect.Default(t, "http://example.com").
+//				WithTestNameAliases()
+//
+//			// failure path starts with subtest name instead of Request()
+//			e.GET(tc.path).
+//				Expect().
+//				Status(tc.status)
+//		})
+//	}
+func (e *Expect) WithTestNameAliases() *Expect {
+	ret := e.clone()
+
+	ret.config.AutoAliasFromTest = true
+	return ret
+}
+
+// GroupOption defines option for Expect.Group.
+type GroupOption func(*Expect)
+
+// WithGroupHeader returns GroupOption that adds header to every request
+// created by the group.
+//
+// Example:
+//
+//	admin := e.Group("/admin", httpexpect.WithGroupHeader("X-Role", "admin"))
+func WithGroupHeader(key, value string) GroupOption {
+	return func(e *Expect) {
+		e.builders = append(e.builders, &expectBuilder{
+			fn: func(req *Request) {
+				req.WithHeader(key, value)
+			},
+		})
+	}
+}
+
+// Group returns a copy of Expect instance for a group of requests sharing
+// path prefix.
+//
+// Prefix is appended to Config.BasePath of the original instance, so groups
+// can be nested. Returned copy shares client, cookie jar, builders, and
+// matchers with the original instance. Options can add extra settings
+// applied to every request of the group, see WithGroupHeader.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	admin := e.Group("/admin", httpexpect.WithGroupHeader("X-Role", "admin"))
+//	users := admin.Group("/users")
+//
+//	// GET http://example.com/admin/users/123
+//	users.GET("/{id}", 123).
+//		Expect().
+//		Status(http.StatusOK)
+func (e *Expect) Group(prefix string, opts ...GroupOption) *Expect {
+	ret := e.clone()
+
+	ret.config.BasePath = normalizeBasePath(
+		normalizeBasePath(e.config.BasePath) + normalizeBasePath(prefix))
+
+	for _, opt := range opts {
+		opt(ret)
+	}
+
+	return ret
+}
+
 // Request returns a new Request instance.
 // Arguments are similar to NewRequest.
 // After creating request, all builders attached to Expect instance are invoked.
@@ -608,14 +1508,27 @@ func (e *Expect) Request(method, path string, pathargs ...interface{}) *Request
 	opChain := e.chain.enter("Request(%q)", method)
 	defer opChain.leave()
 
+	return e.newRequest(opChain, method, path, pathargs...)
+}
+
+func (e *Expect) newRequest(
+	opChain *chain, method, path string, pathargs ...interface{},
+) *Request {
 	req := newRequest(opChain, e.config, method, path, pathargs...)
+	req.owner = e
 
-	for _, builder := range e.builders {
-		builder(req)
+	if testName := handlerTestName(opChain.handler); testName != "" {
+		req.setAutoAlias(testName)
+	} else {
+		req.setAutoAlias(e.config.TestName)
+	}
+
+	for _, builder := range e.getBuilders() {
+		builder.fn(req)
 	}
 
 	for _, matcher := range e.matchers {
-		req.WithMatcher(matcher)
+		req.WithContextMatcher(matcher)
 	}
 
 	return req
@@ -703,3 +1616,32 @@ func (e *Expect) Boolean(value bool) *Boolean {
 
 	return newBoolean(opChain, value)
 }
+
+// Returns name of the test to which handler reports failures, if handler
+// uses testing.TB (or other type with Name method) as Reporter.
+// Otherwise returns empty string.
+func handlerTestName(handler AssertionHandler) string {
+	if soft, ok := handler.(*softAssertionHandler); ok {
+		handler = soft.handler
+	}
+
+	h, ok := handler.(*DefaultAssertionHandler)
+	if !ok {
+		return ""
+	}
+
+	if tb, ok := h.Reporter.(interface{ Name() string }); ok {
+		return tb.Name()
+	}
+
+	return ""
+}
+
+// Returns subtest part of test name, or empty string if there is no subtest.
+func subtestName(testName string) string {
+	if i := strings.IndexByte(testName, '/'); i >= 0 {
+		return testName[i+1:]
+	}
+
+	return ""
+}
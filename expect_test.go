@@ -4,10 +4,13 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptrace"
 	"testing"
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
 )
 
 func TestExpect_Constructors(t *testing.T) {
@@ -153,6 +156,185 @@ func TestExpect_Builders(t *testing.T) {
 	})
 }
 
+func TestExpect_NamedBuilders(t *testing.T) {
+	newExpect := func(client *mockClient) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+	}
+
+	withHeader := func(key, value string) func(*Request) {
+		return func(req *Request) {
+			req.WithHeader(key, value)
+		}
+	}
+
+	t.Run("remove", func(t *testing.T) {
+		client := &mockClient{}
+
+		e1 := newExpect(client).
+			Builder(withHeader("X-Common", "1")).
+			NamedBuilder("auth", withHeader("Authorization", "token"))
+
+		e2 := e1.RemoveBuilder("auth")
+
+		e1.GET("/").Expect().chain.assert(t, success)
+		assert.Equal(t, http.Header{
+			"X-Common":      {"1"},
+			"Authorization": {"token"},
+		}, client.req.Header)
+
+		e2.GET("/").Expect().chain.assert(t, success)
+		assert.Equal(t, http.Header{
+			"X-Common": {"1"},
+		}, client.req.Header)
+
+		e3 := e2.RemoveBuilder("auth").RemoveBuilder("")
+
+		e3.GET("/").Expect().chain.assert(t, success)
+		assert.Equal(t, http.Header{
+			"X-Common": {"1"},
+		}, client.req.Header)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		client := &mockClient{}
+
+		e1 := newExpect(client).
+			NamedBuilder("auth", withHeader("Authorization", "token1"))
+
+		e2 := e1.NamedBuilder("auth", withHeader("Authorization", "token2"))
+
+		e1.GET("/").Expect().chain.assert(t, success)
+		assert.Equal(t, http.Header{
+			"Authorization": {"token1"},
+		}, client.req.Header)
+
+		e2.GET("/").Expect().chain.assert(t, success)
+		assert.Equal(t, http.Header{
+			"Authorization": {"token2"},
+		}, client.req.Header)
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          "http://example.com",
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		})
+
+		assert.NotPanics(t, func() {
+			e.NamedBuilder("", func(*Request) {})
+		})
+
+		assert.Equal(t, 1, handler.failureCalled)
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertUsage, handler.failure.Type)
+		assert.Equal(t, []string{"NamedBuilder()"}, handler.ctx.Path)
+		e.chain.assert(t, failure)
+	})
+}
+
+func TestExpect_ScopedBuilders(t *testing.T) {
+	newExpect := func(client *mockClient) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+	}
+
+	withHeader := func(key, value string) func(*Request) {
+		return func(req *Request) {
+			req.WithHeader(key, value)
+		}
+	}
+
+	t.Run("basic", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := newExpect(client).Builder(withHeader("X-Common", "1"))
+
+		e.WithScopedBuilder(withHeader("X-Role", "admin"), func() {
+			e.GET("/").Expect().chain.assert(t, success)
+			assert.Equal(t, http.Header{
+				"X-Common": {"1"},
+				"X-Role":   {"admin"},
+			}, client.req.Header)
+		})
+
+		e.GET("/").Expect().chain.assert(t, success)
+		assert.Equal(t, http.Header{
+			"X-Common": {"1"},
+		}, client.req.Header)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := newExpect(client)
+
+		e.WithScopedBuilder(withHeader("X-Role", "admin"), func() {
+			e.WithScopedBuilder(withHeader("X-Tenant", "foo"), func() {
+				e.GET("/").Expect().chain.assert(t, success)
+				assert.Equal(t, http.Header{
+					"X-Role":   {"admin"},
+					"X-Tenant": {"foo"},
+				}, client.req.Header)
+			})
+
+			e.GET("/").Expect().chain.assert(t, success)
+			assert.Equal(t, http.Header{
+				"X-Role": {"admin"},
+			}, client.req.Header)
+		})
+
+		e.GET("/").Expect().chain.assert(t, success)
+		assert.Equal(t, 0, len(client.req.Header))
+	})
+
+	t.Run("copies", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := newExpect(client)
+
+		var inner *Expect
+
+		e.WithScopedBuilder(withHeader("X-Role", "admin"), func() {
+			inner = e.Builder(withHeader("X-Tenant", "foo"))
+		})
+
+		// copy made inside scope keeps scoped builder
+		inner.GET("/").Expect().chain.assert(t, success)
+		assert.Equal(t, http.Header{
+			"X-Role":   {"admin"},
+			"X-Tenant": {"foo"},
+		}, client.req.Header)
+
+		e.GET("/").Expect().chain.assert(t, success)
+		assert.Equal(t, 0, len(client.req.Header))
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := newExpect(client)
+
+		assert.Panics(t, func() {
+			e.WithScopedBuilder(withHeader("X-Role", "admin"), func() {
+				panic("test")
+			})
+		})
+
+		e.GET("/").Expect().chain.assert(t, success)
+		assert.Equal(t, 0, len(client.req.Header))
+	})
+}
+
 func TestExpect_Matchers(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
 		client := &mockClient{}
@@ -465,6 +647,284 @@ func TestExpect_Propagation(t *testing.T) {
 	})
 }
 
+func TestExpect_TestNameAliases(t *testing.T) {
+	cases := []struct {
+		name          string
+		testName      string
+		autoAlias     bool
+		withAliases   bool
+		expectedAlias string
+	}{
+		{
+			name:          "disabled",
+			testName:      "TestFoo/case_1",
+			expectedAlias: `Request("GET")`,
+		},
+		{
+			name:          "config",
+			testName:      "TestFoo/case_1",
+			autoAlias:     true,
+			expectedAlias: "case_1",
+		},
+		{
+			name:          "method",
+			testName:      "TestFoo/case_1",
+			withAliases:   true,
+			expectedAlias: "case_1",
+		},
+		{
+			name:          "nested subtest",
+			testName:      "TestFoo/group/case_1",
+			autoAlias:     true,
+			expectedAlias: "group/case_1",
+		},
+		{
+			name:          "no subtest",
+			testName:      "TestFoo",
+			autoAlias:     true,
+			expectedAlias: `Request("GET")`,
+		},
+		{
+			name:          "no test name",
+			testName:      "",
+			autoAlias:     true,
+			expectedAlias: `Request("GET")`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := WithConfig(Config{
+				TestName:          tc.testName,
+				Reporter:          newMockReporter(t),
+				AutoAliasFromTest: tc.autoAlias,
+			})
+
+			if tc.withAliases {
+				e = e.WithTestNameAliases()
+			}
+
+			req := e.GET("/")
+			req.chain.assert(t, success)
+
+			assert.Equal(t, []string{`Request("GET")`}, req.chain.context.Path)
+			assert.Equal(t, []string{tc.expectedAlias}, req.chain.context.AliasedPath)
+		})
+	}
+
+	t.Run("original instance not affected", func(t *testing.T) {
+		e := WithConfig(Config{
+			TestName: "TestFoo/case_1",
+			Reporter: newMockReporter(t),
+		})
+
+		e.WithTestNameAliases()
+
+		req := e.GET("/")
+		assert.Equal(t, []string{`Request("GET")`}, req.chain.context.AliasedPath)
+	})
+
+	t.Run("reporter test name", func(t *testing.T) {
+		e := WithConfig(Config{
+			TestName:          "TestFoo",
+			Reporter:          t,
+			AutoAliasFromTest: true,
+		})
+
+		req := e.GET("/")
+		assert.Equal(t, []string{"reporter_test_name"}, req.chain.context.AliasedPath)
+	})
+
+	t.Run("shared between subtests", func(t *testing.T) {
+		e := WithConfig(Config{
+			TestName:          t.Name(),
+			Reporter:          newMockReporter(t),
+			AutoAliasFromTest: true,
+		})
+
+		for _, name := range []string{"case_1", "case_2"} {
+			t.Run(name, func(t *testing.T) {
+				req := e.GET("/").WithReporter(t)
+				assert.Equal(t, []string{"shared_between_subtests/" + name},
+					req.chain.context.AliasedPath)
+
+				req = e.GET("/").WithAssertionHandler(&DefaultAssertionHandler{
+					Reporter:  t,
+					Formatter: &DefaultFormatter{},
+				})
+				assert.Equal(t, []string{"shared_between_subtests/" + name},
+					req.chain.context.AliasedPath)
+			})
+		}
+	})
+
+	t.Run("explicit alias not overridden", func(t *testing.T) {
+		e := WithConfig(Config{
+			TestName:          "TestFoo/case_1",
+			Reporter:          newMockReporter(t),
+			AutoAliasFromTest: true,
+		})
+
+		req := e.GET("/").Alias("custom").WithReporter(t)
+		assert.Equal(t, []string{"custom"}, req.chain.context.AliasedPath)
+	})
+
+	t.Run("reporter without test name", func(t *testing.T) {
+		e := WithConfig(Config{
+			TestName:          "TestFoo/case_1",
+			Reporter:          newMockReporter(t),
+			AutoAliasFromTest: true,
+		})
+
+		// test name is fixed when Expect is created
+		req := e.GET("/").WithReporter(newMockReporter(t))
+		assert.Equal(t, []string{"case_1"}, req.chain.context.AliasedPath)
+	})
+
+	t.Run("builder overrides alias", func(t *testing.T) {
+		e := WithConfig(Config{
+			TestName: "TestFoo/case_1",
+			Reporter: newMockReporter(t),
+		}).
+			WithTestNameAliases().
+			Builder(func(req *Request) {
+				req.Alias("custom")
+			})
+
+		req := e.GET("/")
+		assert.Equal(t, []string{"custom"}, req.chain.context.AliasedPath)
+	})
+}
+
+func TestExpect_ConnectionStats(t *testing.T) {
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+	})
+
+	b := e.Builder(func(req *Request) {})
+
+	assert.Equal(t, ConnectionStats{}, e.ConnectionStats())
+
+	gotConn := func(e *Expect, reused bool) *bool {
+		var result *bool
+
+		req := e.GET("/path")
+		httpReq := req.traceConnection(req.httpReq, func(reused bool) {
+			result = &reused
+		})
+
+		trace := httptrace.ContextClientTrace(httpReq.Context())
+		require.NotNil(t, trace)
+
+		trace.GotConn(httptrace.GotConnInfo{Reused: reused})
+		return result
+	}
+
+	reused := gotConn(e, false)
+	require.NotNil(t, reused)
+	assert.False(t, *reused)
+
+	reused = gotConn(b, true)
+	require.NotNil(t, reused)
+	assert.True(t, *reused)
+
+	gotConn(b, true)
+
+	assert.Equal(t, ConnectionStats{New: 1, Reused: 2}, e.ConnectionStats())
+	assert.Equal(t, ConnectionStats{New: 1, Reused: 2}, b.ConnectionStats())
+
+	other := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+	})
+
+	assert.Equal(t, ConnectionStats{}, other.ConnectionStats())
+}
+
+func TestExpect_Group(t *testing.T) {
+	t.Run("prefix", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		admin := e.Group("/admin")
+
+		admin.GET("/users/{id}", 123).Expect().chain.assert(t, success)
+		assert.Equal(t, "http://example.com/admin/users/123", client.req.URL.String())
+
+		e.GET("/users").Expect().chain.assert(t, success)
+		assert.Equal(t, "http://example.com/users", client.req.URL.String())
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			BasePath: "/api/",
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		users := e.Group("admin/").Group("/users")
+
+		users.GET("/123").Expect().chain.assert(t, success)
+		assert.Equal(t, "http://example.com/api/admin/users/123",
+			client.req.URL.String())
+
+		users.GET("").Expect().chain.assert(t, success)
+		assert.Equal(t, "http://example.com/api/admin/users",
+			client.req.URL.String())
+	})
+
+	t.Run("headers", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}).Builder(func(req *Request) {
+			req.WithHeader("X-Common", "1")
+		})
+
+		admin := e.Group("/admin",
+			WithGroupHeader("X-Role", "admin"),
+			WithGroupHeader("X-Tenant", "foo"))
+
+		admin.GET("/").Expect().chain.assert(t, success)
+		assert.Equal(t, http.Header{
+			"X-Common": {"1"},
+			"X-Role":   {"admin"},
+			"X-Tenant": {"foo"},
+		}, client.req.Header)
+
+		e.GET("/").Expect().chain.assert(t, success)
+		assert.Equal(t, http.Header{
+			"X-Common": {"1"},
+		}, client.req.Header)
+	})
+
+	t.Run("shared state", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+		})
+
+		admin := e.Group("/admin")
+
+		assert.Same(t, e.config.Client, admin.config.Client)
+		assert.Same(t, e.connCounter, admin.connCounter)
+		assert.Equal(t, "", e.config.BasePath)
+		assert.Equal(t, "/admin", admin.config.BasePath)
+	})
+}
+
 func TestExpect_Inheritance(t *testing.T) {
 	t.Run("reporter", func(t *testing.T) {
 		rootReporter := newMockReporter(t)
@@ -700,6 +1160,12 @@ func TestExpect_Config(t *testing.T) {
 			badConfig.AssertionHandler = nil
 			badConfig.validate()
 		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.MaxBufferedBodySize = -1
+			badConfig.validate()
+		})
 	})
 
 	t.Run("validate handler", func(t *testing.T) {
@@ -738,6 +1204,299 @@ func TestExpect_Config(t *testing.T) {
 	})
 }
 
+func TestExpect_ConfigCheck(t *testing.T) {
+	cases := []struct {
+		name         string
+		config       Config
+		expectErrors int
+		expectPanic  bool
+	}{
+		{
+			name: "valid",
+			config: Config{
+				Reporter: newMockReporter(t),
+				BaseURL:  "http://example.com/api",
+			},
+			expectErrors: 0,
+		},
+		{
+			name: "valid, assertion handler",
+			config: Config{
+				AssertionHandler: &mockAssertionHandler{},
+				BaseURL:          "wss://example.com",
+			},
+			expectErrors: 0,
+		},
+		{
+			name:         "nil reporter and assertion handler",
+			config:       Config{},
+			expectErrors: 1,
+			expectPanic:  true,
+		},
+		{
+			name: "default assertion handler with nil fields",
+			config: Config{
+				AssertionHandler: &DefaultAssertionHandler{},
+			},
+			expectErrors: 2,
+			expectPanic:  true,
+		},
+		{
+			name: "relative base url",
+			config: Config{
+				Reporter: newMockReporter(t),
+				BaseURL:  "/api",
+			},
+			expectErrors: 1,
+		},
+		{
+			name: "base url without scheme",
+			config: Config{
+				Reporter: newMockReporter(t),
+				BaseURL:  "example.com",
+			},
+			expectErrors: 1,
+		},
+		{
+			name: "base url with unsupported scheme",
+			config: Config{
+				Reporter: newMockReporter(t),
+				BaseURL:  "ftp://example.com",
+			},
+			expectErrors: 1,
+		},
+		{
+			name: "invalid base url",
+			config: Config{
+				Reporter: newMockReporter(t),
+				BaseURL:  "http://example.com/%zz",
+			},
+			expectErrors: 1,
+		},
+		{
+			name: "negative max buffered body size",
+			config: Config{
+				Reporter:            newMockReporter(t),
+				MaxBufferedBodySize: -1,
+			},
+			expectErrors: 1,
+			expectPanic:  true,
+		},
+		{
+			name: "invalid verbosity",
+			config: Config{
+				Reporter:  newMockReporter(t),
+				Verbosity: Verbosity(100),
+			},
+			expectErrors: 1,
+			expectPanic:  true,
+		},
+		{
+			name: "verbosity with printers",
+			config: Config{
+				Reporter:  newMockReporter(t),
+				Verbosity: VerbosityCompact,
+				Printers:  []Printer{NewCurlPrinter(newMockLogger(t))},
+			},
+			expectErrors: 1,
+			expectPanic:  true,
+		},
+		{
+			name: "invalid dns overrides",
+			config: Config{
+				Reporter: newMockReporter(t),
+				Client:   &mockClient{},
+				DNSOverrides: map[string]string{
+					"a.example.com": "example.org",
+					"b.example.com": "127.0.0.1",
+				},
+			},
+			expectErrors: 2,
+			expectPanic:  true,
+		},
+		{
+			name: "multiple errors",
+			config: Config{
+				BaseURL:             "example.com",
+				MaxBufferedBodySize: -1,
+			},
+			expectErrors: 3,
+			expectPanic:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Check()
+
+			if !tc.expectPanic {
+				assert.NotPanics(t, func() {
+					WithConfig(tc.config)
+				})
+			}
+
+			if tc.expectErrors == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+
+			var configErr *ConfigError
+			require.True(t, errors.As(err, &configErr))
+			assert.Equal(t, tc.expectErrors, len(configErr.Errors))
+
+			for _, e := range configErr.Errors {
+				assert.Contains(t, err.Error(), e.Error())
+			}
+
+			if tc.expectPanic {
+				assert.Panics(t, func() {
+					WithConfig(tc.config)
+				})
+			}
+		})
+	}
+}
+
+func TestExpect_WebsocketDialer(t *testing.T) {
+	handler := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+
+	cases := []struct {
+		name         string
+		client       Client
+		expectBinder bool
+	}{
+		{
+			name:         "default client",
+			client:       nil,
+			expectBinder: false,
+		},
+		{
+			name:         "binder",
+			client:       &http.Client{Transport: NewBinder(handler)},
+			expectBinder: true,
+		},
+		{
+			name:         "binder pointer",
+			client:       &http.Client{Transport: &Binder{Handler: handler}},
+			expectBinder: true,
+		},
+		{
+			name: "fast binder",
+			client: &http.Client{
+				Transport: NewFastBinder(func(*fasthttp.RequestCtx) {}),
+			},
+			expectBinder: true,
+		},
+		{
+			name:         "other transport",
+			client:       &http.Client{Transport: &mockTransport{}},
+			expectBinder: false,
+		},
+		{
+			name:         "other client",
+			client:       &mockClient{},
+			expectBinder: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := Config{
+				Reporter: newMockReporter(t),
+				Client:   tc.client,
+			}.withDefaults()
+
+			// default dialer is not bound to handler
+			assert.Nil(t, config.WebsocketDialer.(*websocket.Dialer).NetDial)
+
+			req := NewRequestC(config, "GET", "/path")
+
+			dialer, ok := req.websocketDialer().(*websocket.Dialer)
+			require.True(t, ok)
+
+			if tc.expectBinder {
+				assert.NotNil(t, dialer.NetDial)
+			} else {
+				assert.Nil(t, dialer.NetDial)
+			}
+		})
+	}
+
+	t.Run("explicit dialer", func(t *testing.T) {
+		wsDialer := &websocket.Dialer{}
+
+		config := Config{
+			Reporter:        newMockReporter(t),
+			Client:          &http.Client{Transport: NewBinder(handler)},
+			WebsocketDialer: wsDialer,
+		}.withDefaults()
+
+		assert.Same(t, wsDialer, config.WebsocketDialer)
+
+		req := NewRequestC(config, "GET", "/path")
+		assert.Same(t, wsDialer, req.websocketDialer())
+
+		req = NewRequestC(config, "GET", "/path").WithHandler(handler)
+		assert.Same(t, wsDialer, req.websocketDialer())
+	})
+}
+
+func TestExpect_DNSOverrides(t *testing.T) {
+	t.Run("no overrides", func(t *testing.T) {
+		config := Config{
+			Reporter: newMockReporter(t),
+		}.withDefaults()
+
+		assert.Same(t, config.Client, config.withDNSOverrides().Client)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		config := Config{
+			Reporter:     newMockReporter(t),
+			DNSOverrides: map[string]string{"example.com": "127.0.0.1"},
+		}.withDefaults()
+
+		resolved := config.withDNSOverrides()
+
+		assert.NotSame(t, config.Client, resolved.Client)
+		assert.NotSame(t, config.WebsocketDialer, resolved.WebsocketDialer)
+
+		transport := resolved.Client.(*http.Client).Transport.(*http.Transport)
+		assert.NotNil(t, transport.DialContext)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		assert.Panics(t, func() {
+			WithConfig(Config{
+				Reporter:     newMockReporter(t),
+				DNSOverrides: map[string]string{"example.com": "example.org"},
+			})
+		})
+	})
+
+	t.Run("unsupported client", func(t *testing.T) {
+		assert.Panics(t, func() {
+			WithConfig(Config{
+				Reporter:     newMockReporter(t),
+				Client:       &mockClient{},
+				DNSOverrides: map[string]string{"example.com": "127.0.0.1"},
+			})
+		})
+	})
+
+	t.Run("unsupported websocket dialer", func(t *testing.T) {
+		assert.Panics(t, func() {
+			WithConfig(Config{
+				Reporter:        newMockReporter(t),
+				WebsocketDialer: WebsocketDialerFunc(nil),
+				DNSOverrides:    map[string]string{"example.com": "127.0.0.1"},
+			})
+		})
+	})
+}
+
 func TestExpect_Adapters(t *testing.T) {
 	t.Run("RequestFactoryFunc", func(t *testing.T) {
 		called := false
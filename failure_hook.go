@@ -0,0 +1,65 @@
+package httpexpect
+
+import (
+	"time"
+)
+
+// FailureContext provides information about failed assertion to
+// Config.OnFailure hook.
+type FailureContext struct {
+	// Context of failed assertion
+	// Provides test name, request name, assertion path, etc.
+	Context *AssertionContext
+
+	// Failed assertion
+	// Provides assertion type, errors, actual and expected values, etc.
+	Failure *AssertionFailure
+
+	// Dump of HTTP request related to failed assertion
+	// Nil if assertion is not related to request, or request was not sent
+	RequestDump []byte
+
+	// Dump of HTTP response related to failed assertion
+	// Nil if assertion is not related to response
+	// Includes response body only if it was already read by assertions
+	ResponseDump []byte
+
+	// Response round-trip time
+	// Zero if assertion is not related to response, or if time is unknown
+	RoundTripTime time.Duration
+}
+
+func buildFailureContext(
+	ctx *AssertionContext, failure *AssertionFailure,
+) FailureContext {
+	failureCtx := FailureContext{
+		Context: ctx,
+		Failure: failure,
+	}
+
+	if ctx.Request != nil && ctx.Request.httpReq != nil {
+		failureCtx.RequestDump = findAttachmentContent(
+			requestAttachments(ctx.Request.httpReq), "request")
+	}
+
+	if ctx.Response != nil && ctx.Response.httpResp != nil {
+		failureCtx.ResponseDump = findAttachmentContent(
+			responseAttachments(ctx.Response), "response")
+
+		if ctx.Response.rtt != nil {
+			failureCtx.RoundTripTime = *ctx.Response.rtt
+		}
+	}
+
+	return failureCtx
+}
+
+func findAttachmentContent(attachments []AssertionAttachment, name string) []byte {
+	for _, attachment := range attachments {
+		if attachment.Name == name {
+			return attachment.Content
+		}
+	}
+
+	return nil
+}
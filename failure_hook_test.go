@@ -0,0 +1,91 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureHook_Response(t *testing.T) {
+	var calls []FailureContext
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"error": "not found"}`))
+				})),
+		},
+		OnFailure: func(ctx FailureContext) {
+			calls = append(calls, ctx)
+		},
+	})
+
+	resp := e.GET("/path").
+		WithHeader("X-Test", "foo").
+		Expect()
+
+	resp.JSON().Object().ContainsKey("error")
+	assert.Equal(t, 0, len(calls))
+
+	resp.Status(http.StatusOK)
+	require.Equal(t, 1, len(calls))
+
+	ctx := calls[0]
+
+	require.NotNil(t, ctx.Context)
+	assert.Equal(t, []string{`Request("GET")`, "Expect()", "Status()"}, ctx.Context.Path)
+
+	require.NotNil(t, ctx.Failure)
+	assert.Equal(t, AssertEqual, ctx.Failure.Type)
+
+	assert.Contains(t, string(ctx.RequestDump), "GET /path")
+	assert.Contains(t, string(ctx.RequestDump), "X-Test: foo")
+
+	assert.Contains(t, string(ctx.ResponseDump), "404 Not Found")
+	assert.Contains(t, string(ctx.ResponseDump), `{"error": "not found"}`)
+}
+
+func TestFailureHook_Value(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		var calls []FailureContext
+
+		value := NewValueC(Config{
+			Reporter: newMockReporter(t),
+			OnFailure: func(ctx FailureContext) {
+				calls = append(calls, ctx)
+			},
+		}, 123)
+
+		value.IsEqual(123)
+		assert.Equal(t, 0, len(calls))
+
+		value.IsEqual(456)
+		require.Equal(t, 1, len(calls))
+
+		assert.Equal(t, []string{"Value()", "IsEqual()"}, calls[0].Context.Path)
+		assert.Nil(t, calls[0].RequestDump)
+		assert.Nil(t, calls[0].ResponseDump)
+		assert.Equal(t, 0, int(calls[0].RoundTripTime))
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		var calls []FailureContext
+
+		value := NewValueC(Config{
+			Reporter: newMockReporter(t),
+			OnFailure: func(ctx FailureContext) {
+				calls = append(calls, ctx)
+			},
+		}, 123)
+
+		value.Warn().IsEqual(456)
+		assert.Equal(t, 0, len(calls))
+	})
+}
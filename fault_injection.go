@@ -0,0 +1,290 @@
+package httpexpect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrInjectedFault is returned by FaultInjectionTransport for dropped
+// requests.
+//
+// It implements net.Error and reports itself as a timeout, like a request
+// which was lost on the network, so that it is retried by default retry
+// policy (see Request.WithRetryPolicy).
+var ErrInjectedFault net.Error = injectedFaultError{}
+
+type injectedFaultError struct{}
+
+func (injectedFaultError) Error() string {
+	return "injected fault: request dropped"
+}
+
+func (injectedFaultError) Timeout() bool {
+	return true
+}
+
+func (injectedFaultError) Temporary() bool {
+	return true
+}
+
+// FaultInjectionTransport implements http.RoundTripper that wraps another
+// transport and injects faults into requests and responses.
+//
+// It allows to test how client code (e.g. retries) behaves when the server
+// or network misbehaves, without modifying the server. Faults are applied
+// in the following order:
+//   - Latency is added before every request
+//   - FailFirst requests are answered with FailStatus, without invoking
+//     wrapped transport
+//   - DropRate fraction of requests fail with ErrInjectedFault
+//   - CorruptRate fraction of responses get truncated body, which reading
+//     fails with io.ErrUnexpectedEOF after first half
+//
+// Fields should be configured before transport is used.
+// FaultInjectionTransport is safe for concurrent use.
+type FaultInjectionTransport struct {
+	// Transport used to send requests.
+	// If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// Delay added before sending every request.
+	// If request context is canceled during delay, context error is returned.
+	Latency time.Duration
+
+	// Number of first requests answered with FailStatus.
+	FailFirst int
+
+	// Status code returned for first FailFirst requests.
+	// If zero, 503 Service Unavailable is used.
+	FailStatus int
+
+	// Fraction of requests, in range [0; 1], that are not sent and instead
+	// fail with ErrInjectedFault.
+	DropRate float64
+
+	// Fraction of responses, in range [0; 1], which body is truncated to
+	// half of its length, simulating interrupted transfer: reading body
+	// returns first half and then fails with io.ErrUnexpectedEOF.
+	// Headers and ContentLength are not changed.
+	CorruptRate float64
+
+	// Source of random numbers used for DropRate and CorruptRate.
+	// If nil, global generator from math/rand is used.
+	// Set to rand.NewSource(seed) to make injected faults repeatable.
+	RandSource rand.Source
+
+	mu     sync.Mutex
+	calls  int
+	randFn func() float64
+}
+
+// NewFaultInjectionTransport returns a new FaultInjectionTransport given
+// a http.RoundTripper.
+//
+// Example:
+//
+//	transport := NewFaultInjectionTransport(NewBinder(handler))
+//	transport.FailFirst = 2
+//
+//	e := WithConfig(Config{
+//		Client: &http.Client{
+//			Transport: transport,
+//		},
+//	})
+//
+//	e.GET("/path").
+//		WithMaxRetries(2).
+//		WithRetryDelay(0, 0).
+//		Expect().
+//		Status(http.StatusOK)
+//
+//	assert.Equal(t, 3, transport.Calls())
+func NewFaultInjectionTransport(transport http.RoundTripper) *FaultInjectionTransport {
+	return &FaultInjectionTransport{Transport: transport}
+}
+
+// NewFaultInjectionClient returns a new http.Client that uses
+// FaultInjectionTransport wrapping transport of given client.
+//
+// If client is nil, http.DefaultClient is used. Other client fields,
+// like Jar and CheckRedirect, are copied from given client.
+//
+// Example:
+//
+//	client, transport := NewFaultInjectionClient(&http.Client{})
+//	transport.DropRate = 0.5
+//
+//	e := WithConfig(Config{
+//		Client: client,
+//	})
+func NewFaultInjectionClient(
+	client *http.Client,
+) (*http.Client, *FaultInjectionTransport) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	transport := NewFaultInjectionTransport(client.Transport)
+
+	wrapped := *client
+	wrapped.Transport = transport
+
+	return &wrapped, transport
+}
+
+// RoundTrip implements http.RoundTripper.RoundTrip.
+func (t *FaultInjectionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	call := t.nextCall()
+
+	if t.Latency > 0 {
+		timer := time.NewTimer(t.Latency)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	if call <= t.FailFirst {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return t.failResponse(req), nil
+	}
+
+	if t.DropRate > 0 && t.random() < t.DropRate {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, ErrInjectedFault
+	}
+
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if t.CorruptRate > 0 && t.random() < t.CorruptRate {
+		if err := corruptBody(resp); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// Calls returns number of requests passed to the transport,
+// including failed and dropped ones.
+func (t *FaultInjectionTransport) Calls() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.calls
+}
+
+// Reset resets number of requests, so that FailFirst is applied again.
+func (t *FaultInjectionTransport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.calls = 0
+}
+
+func (t *FaultInjectionTransport) nextCall() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.calls++
+
+	return t.calls
+}
+
+func (t *FaultInjectionTransport) random() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.randFn == nil {
+		t.randFn = randFloat64(t.RandSource)
+	}
+
+	return t.randFn()
+}
+
+func (t *FaultInjectionTransport) failResponse(req *http.Request) *http.Response {
+	status := t.FailStatus
+	if status == 0 {
+		status = http.StatusServiceUnavailable
+	}
+
+	return &http.Response{
+		Request:    req,
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       http.NoBody,
+	}
+}
+
+// Replace response body with reader that returns first half of the body
+// and then io.ErrUnexpectedEOF, like a connection closed in the middle
+// of transfer.
+func corruptBody(resp *http.Response) error {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil
+	}
+
+	if resp.ContentLength > 0 {
+		resp.Body = &corruptedBody{
+			reader: io.LimitReader(resp.Body, resp.ContentLength/2),
+			closer: resp.Body,
+		}
+		return nil
+	}
+
+	// length is unknown (or zero, which some transports report for
+	// non-empty bodies), so body is read to find where the half is
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	resp.Body = &corruptedBody{
+		reader: bytes.NewReader(body[:len(body)/2]),
+		closer: io.NopCloser(nil),
+	}
+
+	return nil
+}
+
+type corruptedBody struct {
+	reader io.Reader
+	closer io.Closer
+}
+
+func (b *corruptedBody) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (b *corruptedBody) Close() error {
+	return b.closer.Close()
+}
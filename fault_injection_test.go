@@ -0,0 +1,340 @@
+package httpexpect
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFaultTestHandler(callCount *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*callCount++
+		_, _ = w.Write([]byte("0123456789"))
+	})
+}
+
+func TestFaultInjectionTransport_FailFirst(t *testing.T) {
+	t.Run("default status", func(t *testing.T) {
+		callCount := 0
+
+		transport := NewFaultInjectionTransport(
+			NewBinder(newFaultTestHandler(&callCount)))
+		transport.FailFirst = 2
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &http.Client{
+			Transport: transport,
+		}
+
+		var statuses []int
+
+		req := NewRequestC(config, "GET", "http://example.com").
+			WithMaxRetries(3).
+			WithRetryDelay(0, 0).
+			OnRetryAttempt(
+				func(_ int, resp *http.Response, _ error, _ time.Duration) {
+					statuses = append(statuses, resp.StatusCode)
+				})
+		req.sleepFn = mockSleep
+
+		resp := req.Expect()
+		resp.Status(http.StatusOK).
+			Body().IsEqual("0123456789")
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, []int{
+			http.StatusServiceUnavailable,
+			http.StatusServiceUnavailable,
+			http.StatusOK,
+		}, statuses)
+
+		assert.Equal(t, 3, transport.Calls())
+		assert.Equal(t, 1, callCount)
+	})
+
+	t.Run("custom status", func(t *testing.T) {
+		callCount := 0
+
+		transport := NewFaultInjectionTransport(
+			NewBinder(newFaultTestHandler(&callCount)))
+		transport.FailFirst = 1
+		transport.FailStatus = http.StatusTooManyRequests
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &http.Client{
+			Transport: transport,
+		}
+
+		NewRequestC(config, "GET", "http://example.com").
+			Expect().
+			Status(http.StatusTooManyRequests).
+			StatusText().IsEqual("Too Many Requests").
+			chain.assert(t, success)
+
+		NewRequestC(config, "GET", "http://example.com").
+			Expect().
+			Status(http.StatusOK).
+			chain.assert(t, success)
+
+		transport.Reset()
+		assert.Equal(t, 0, transport.Calls())
+
+		NewRequestC(config, "GET", "http://example.com").
+			Expect().
+			Status(http.StatusTooManyRequests).
+			chain.assert(t, success)
+
+		assert.Equal(t, 1, callCount)
+	})
+}
+
+func TestFaultInjectionTransport_Drop(t *testing.T) {
+	callCount := 0
+
+	transport := NewFaultInjectionTransport(
+		NewBinder(newFaultTestHandler(&callCount)))
+	transport.DropRate = 0.5
+
+	randValues := []float64{0.1, 0.7}
+	transport.randFn = func() float64 {
+		v := randValues[0]
+		randValues = randValues[1:]
+		return v
+	}
+
+	req, err := http.NewRequest("GET", "http://example.com", nil)
+	require.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, ErrInjectedFault))
+
+	var netErr net.Error
+	require.True(t, errors.As(err, &netErr))
+	assert.True(t, netErr.Timeout())
+
+	resp, err = transport.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Equal(t, 2, transport.Calls())
+	assert.Equal(t, 1, callCount)
+}
+
+func TestFaultInjectionTransport_DropRetried(t *testing.T) {
+	callCount := 0
+
+	transport := NewFaultInjectionTransport(
+		NewBinder(newFaultTestHandler(&callCount)))
+	transport.DropRate = 0.5
+
+	randValues := []float64{0.1, 0.2, 0.9}
+	transport.randFn = func() float64 {
+		v := randValues[0]
+		randValues = randValues[1:]
+		return v
+	}
+
+	config := newMockConfig(newMockReporter(t))
+	config.Client = &http.Client{
+		Transport: transport,
+	}
+
+	req := NewRequestC(config, "GET", "http://example.com").
+		WithMaxRetries(2).
+		WithRetryDelay(0, 0)
+	req.sleepFn = mockSleep
+
+	req.Expect().
+		Status(http.StatusOK).
+		chain.assert(t, success)
+
+	assert.Equal(t, 3, transport.Calls())
+	assert.Equal(t, 1, callCount)
+}
+
+func TestFaultInjectionTransport_Corrupt(t *testing.T) {
+	t.Run("unknown length", func(t *testing.T) {
+		callCount := 0
+
+		transport := NewFaultInjectionTransport(
+			NewBinder(newFaultTestHandler(&callCount)))
+		transport.CorruptRate = 1
+
+		req, err := http.NewRequest("GET", "http://example.com", nil)
+		require.NoError(t, err)
+
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+
+		contentLength := resp.ContentLength
+
+		b, err := io.ReadAll(resp.Body)
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+		assert.Equal(t, "01234", string(b))
+
+		assert.Equal(t, contentLength, resp.ContentLength)
+		assert.NoError(t, resp.Body.Close())
+	})
+
+	t.Run("known length", func(t *testing.T) {
+		body := &mockBody{reader: strings.NewReader("0123456789")}
+
+		transport := NewFaultInjectionTransport(&mockTransport{
+			resp: &http.Response{
+				StatusCode:    http.StatusOK,
+				Header:        http.Header{"Content-Length": {"10"}},
+				ContentLength: 10,
+				Body:          body,
+			},
+		})
+		transport.CorruptRate = 1
+
+		req, err := http.NewRequest("GET", "http://example.com", nil)
+		require.NoError(t, err)
+
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+
+		b, err := io.ReadAll(resp.Body)
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+		assert.Equal(t, "01234", string(b))
+
+		assert.Equal(t, int64(10), resp.ContentLength)
+		assert.Equal(t, "10", resp.Header.Get("Content-Length"))
+
+		assert.NoError(t, resp.Body.Close())
+		assert.Equal(t, 1, body.closeCount)
+	})
+
+	t.Run("response", func(t *testing.T) {
+		callCount := 0
+
+		transport := NewFaultInjectionTransport(
+			NewBinder(newFaultTestHandler(&callCount)))
+		transport.CorruptRate = 1
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &http.Client{
+			Transport: transport,
+		}
+
+		resp := NewRequestC(config, "GET", "http://example.com").
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.Body()
+		resp.chain.assert(t, failure)
+	})
+}
+
+func TestFaultInjectionTransport_RandSource(t *testing.T) {
+	run := func() []bool {
+		transport := NewFaultInjectionTransport(&mockTransport{
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       http.NoBody,
+			},
+		})
+		transport.DropRate = 0.5
+		transport.RandSource = rand.NewSource(1)
+
+		var dropped []bool
+		for n := 0; n < 20; n++ {
+			req, err := http.NewRequest("GET", "http://example.com", nil)
+			require.NoError(t, err)
+
+			_, err = transport.RoundTrip(req)
+			dropped = append(dropped, err != nil)
+		}
+
+		return dropped
+	}
+
+	first := run()
+
+	assert.Contains(t, first, true)
+	assert.Contains(t, first, false)
+
+	assert.Equal(t, first, run())
+}
+
+func TestFaultInjectionTransport_Latency(t *testing.T) {
+	t.Run("delay", func(t *testing.T) {
+		callCount := 0
+
+		transport := NewFaultInjectionTransport(
+			NewBinder(newFaultTestHandler(&callCount)))
+		transport.Latency = 10 * time.Millisecond
+
+		req, err := http.NewRequest("GET", "http://example.com", nil)
+		require.NoError(t, err)
+
+		start := time.Now()
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+
+		assert.True(t, time.Since(start) >= 10*time.Millisecond)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		callCount := 0
+
+		transport := NewFaultInjectionTransport(
+			NewBinder(newFaultTestHandler(&callCount)))
+		transport.Latency = time.Hour
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "http://example.com", nil)
+		require.NoError(t, err)
+
+		resp, err := transport.RoundTrip(req)
+		assert.Nil(t, resp)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, 0, callCount)
+	})
+}
+
+func TestFaultInjectionTransport_Client(t *testing.T) {
+	callCount := 0
+
+	jar := NewCookieJar()
+
+	client, transport := NewFaultInjectionClient(&http.Client{
+		Transport: NewBinder(newFaultTestHandler(&callCount)),
+		Jar:       jar,
+	})
+	transport.FailFirst = 1
+
+	assert.Same(t, transport, client.Transport)
+	assert.Equal(t, jar, client.Jar)
+
+	config := newMockConfig(newMockReporter(t))
+	config.Client = client
+
+	NewRequestC(config, "GET", "http://example.com").
+		Expect().
+		Status(http.StatusServiceUnavailable).
+		chain.assert(t, success)
+
+	NewRequestC(config, "GET", "http://example.com").
+		Expect().
+		Status(http.StatusOK).
+		chain.assert(t, success)
+
+	client, transport = NewFaultInjectionClient(nil)
+	assert.Nil(t, transport.Transport)
+	assert.Same(t, transport, client.Transport)
+}
@@ -43,7 +43,7 @@ type Formatter interface {
 // If desired, you can provide custom templates and function map. This may
 // be easier than creating your own formatter from scratch.
 type DefaultFormatter struct {
-	// Exclude test name and request name from failure report.
+	// Exclude test name, request name, and request ID from failure report.
 	DisableNames bool
 
 	// Exclude assertion path from failure report.
@@ -55,6 +55,15 @@ type DefaultFormatter struct {
 	// Exclude diff from failure report.
 	DisableDiffs bool
 
+	// Diff printing mode.
+	// Default is DiffModeDefault.
+	DiffMode DiffMode
+
+	// Number of context lines around changed lines, used by DiffModeUnified
+	// and DiffModeSideBySide.
+	// Use zero for default number (3), and negative value to disable context.
+	DiffContext int
+
 	// Exclude HTTP request from failure report.
 	DisableRequests bool
 
@@ -199,6 +208,7 @@ const (
 type FormatData struct {
 	TestName    string
 	RequestName string
+	RequestID   string
 
 	AssertPath     []string
 	AssertType     string
@@ -320,6 +330,7 @@ func (f *DefaultFormatter) fillGeneral(
 	if !f.DisableNames {
 		data.TestName = ctx.TestName
 		data.RequestName = ctx.RequestName
+		data.RequestID = ctx.RequestID
 	}
 
 	if !f.DisablePaths {
@@ -403,8 +414,21 @@ func (f *DefaultFormatter) fillExpected(
 		}
 
 		if !f.DisableDiffs && failure.Actual != nil && failure.Expected != nil {
-			data.Diff, data.HaveDiff = f.formatDiff(
-				failure.Expected.Value, failure.Actual.Value)
+			if f.DiffMode != DiffModeDefault {
+				data.Diff, data.HaveDiff = f.formatLineDiff(
+					failure.Expected.Value, failure.Actual.Value, data.EnableColors)
+
+				// line diff replaces full values
+				if data.HaveDiff {
+					data.HaveExpected = false
+					data.HaveActual = false
+				}
+			}
+
+			if !data.HaveDiff {
+				data.Diff, data.HaveDiff = f.formatDiff(
+					failure.Expected.Value, failure.Actual.Value)
+			}
 		}
 
 	case AssertLt, AssertLe, AssertGt, AssertGe:
@@ -1112,6 +1136,7 @@ var defaultTemplateFuncs = template.FuncMap{
 		}{
 			{"---", color.FgWhite},
 			{"+++", color.FgWhite},
+			{"@@", color.FgCyan},
 			{"-", color.FgRed},
 			{"+", color.FgGreen},
 		}
@@ -1142,11 +1167,18 @@ var defaultTemplateFuncs = template.FuncMap{
 var defaultSuccessTemplate = `[OK] {{ join .LineWidth .AssertPath }}`
 
 var defaultFailureTemplate = `
+{{- $color := "Red" -}}
+{{- if eq .AssertSeverity "SeverityWarning" -}}
+{{- $color = "Yellow" -}}
+{{- end -}}
 {{- range $n, $err := .Errors }}
 {{ if eq $n 0 -}}
-{{ $err | wrap $.LineWidth | color $.EnableColors "Red" }}
+{{ if eq $.AssertSeverity "SeverityWarning" -}}
+{{ "warning: " | color $.EnableColors $color }}
+{{- end -}}
+{{ $err | wrap $.LineWidth | color $.EnableColors $color }}
 {{- else -}}
-{{ $err | wrap $.LineWidth | indent | color $.EnableColors "Red" }}
+{{ $err | wrap $.LineWidth | indent | color $.EnableColors $color }}
 {{- end -}}
 {{- end -}}
 {{- if .TestName }}
@@ -1157,6 +1189,10 @@ test name: {{ .TestName | color $.EnableColors "Cyan" }}
 
 request name: {{ .RequestName | color $.EnableColors "Cyan" }}
 {{- end -}}
+{{- if .RequestID }}
+
+request id: {{ .RequestID | color $.EnableColors "Cyan" }}
+{{- end -}}
 {{- if .HaveRequest }}
 
 request: {{ .Request | colorhttp $.EnableColors false | indent | trim }}
@@ -0,0 +1,446 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// DiffMode defines how the diff between expected and actual values is
+// printed in failure report.
+type DiffMode int
+
+const (
+	// Print structural diff of JSON values, along with full expected and
+	// actual values.
+	DiffModeDefault DiffMode = iota
+
+	// Print unified line diff of pretty-printed JSON values, with context
+	// lines and JSON path annotations, instead of full expected and actual
+	// values.
+	DiffModeUnified
+
+	// Print side-by-side line diff of pretty-printed JSON values, with
+	// context lines and JSON path annotations, instead of full expected and
+	// actual values.
+	DiffModeSideBySide
+)
+
+const (
+	defaultDiffContext = 3
+
+	// Maximum product of line counts of expected and actual values for
+	// which line diff is computed. Larger values fall back to default mode.
+	maxLineDiffComplexity = 4000000
+)
+
+type lineDiffOp int
+
+const (
+	lineEqual lineDiffOp = iota
+	lineDelete
+	lineInsert
+)
+
+type lineDiffEntry struct {
+	op       lineDiffOp
+	expIndex int // index in expected lines, for equal and delete
+	actIndex int // index in actual lines, for equal and insert
+}
+
+type lineDiffHunk struct {
+	entries []lineDiffEntry
+}
+
+// Format line diff of expected and actual values, according to DiffMode.
+// Returns false if values are not objects or arrays, or are equal.
+func (f *DefaultFormatter) formatLineDiff(
+	expected, actual interface{}, enableColors bool,
+) (string, bool) {
+	if !isDiffable(expected) || !isDiffable(actual) {
+		return "", false
+	}
+
+	expLines, ok := diffLines(expected)
+	if !ok {
+		return "", false
+	}
+
+	actLines, ok := diffLines(actual)
+	if !ok {
+		return "", false
+	}
+
+	if len(expLines)*len(actLines) > maxLineDiffComplexity {
+		return "", false
+	}
+
+	entries := computeLineDiff(expLines, actLines)
+
+	contextLines := f.DiffContext
+	if contextLines == 0 {
+		contextLines = defaultDiffContext
+	} else if contextLines < 0 {
+		contextLines = 0
+	}
+
+	hunks := splitLineDiff(entries, contextLines)
+	if len(hunks) == 0 {
+		return "", false
+	}
+
+	expPaths := jsonLinePaths(expLines)
+	actPaths := jsonLinePaths(actLines)
+
+	if f.DiffMode == DiffModeSideBySide {
+		return renderSideBySideDiff(
+			hunks, expLines, actLines, expPaths, actPaths, enableColors), true
+	}
+
+	return renderUnifiedDiff(hunks, expLines, actLines, expPaths, actPaths), true
+}
+
+func isDiffable(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func diffLines(value interface{}) ([]string, bool) {
+	b, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, false
+	}
+
+	return strings.Split(string(b), "\n"), true
+}
+
+// Compute line diff using longest common subsequence.
+func computeLineDiff(exp, act []string) []lineDiffEntry {
+	n, m := len(exp), len(act)
+
+	// lcs[i][j] is length of LCS of exp[i:] and act[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if exp[i] == act[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	entries := make([]lineDiffEntry, 0, n+m)
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && exp[i] == act[j]:
+			entries = append(entries, lineDiffEntry{lineEqual, i, j})
+			i++
+			j++
+		case j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			entries = append(entries, lineDiffEntry{lineDelete, i, j})
+			i++
+		default:
+			entries = append(entries, lineDiffEntry{lineInsert, i, j})
+			j++
+		}
+	}
+
+	return entries
+}
+
+// Split diff into hunks of changed lines surrounded by context lines.
+// Hunks which context lines overlap are merged.
+func splitLineDiff(entries []lineDiffEntry, contextLines int) []lineDiffHunk {
+	var hunks []lineDiffHunk
+
+	start, end := -1, -1
+
+	for n, e := range entries {
+		if e.op == lineEqual {
+			continue
+		}
+
+		from := n - contextLines
+		if from < 0 {
+			from = 0
+		}
+		to := n + contextLines + 1
+		if to > len(entries) {
+			to = len(entries)
+		}
+
+		if start >= 0 && from <= end {
+			end = to
+			continue
+		}
+
+		if start >= 0 {
+			hunks = append(hunks, lineDiffHunk{entries[start:end]})
+		}
+
+		start, end = from, to
+	}
+
+	if start >= 0 {
+		hunks = append(hunks, lineDiffHunk{entries[start:end]})
+	}
+
+	return hunks
+}
+
+// Build hunk header in unified diff format, annotated with JSON path
+// of first changed line.
+func formatHunkHeader(hunk lineDiffHunk, expPaths, actPaths []string) string {
+	expStart, expCount, actStart, actCount := -1, 0, -1, 0
+	path := ""
+
+	for _, e := range hunk.entries {
+		if e.op != lineInsert {
+			if expStart < 0 {
+				expStart = e.expIndex
+			}
+			expCount++
+		}
+		if e.op != lineDelete {
+			if actStart < 0 {
+				actStart = e.actIndex
+			}
+			actCount++
+		}
+		if path == "" {
+			switch e.op {
+			case lineDelete:
+				path = expPaths[e.expIndex]
+			case lineInsert:
+				path = actPaths[e.actIndex]
+			}
+		}
+	}
+
+	if expStart < 0 {
+		expStart = hunk.entries[0].expIndex
+	}
+	if actStart < 0 {
+		actStart = hunk.entries[0].actIndex
+	}
+
+	// like in GNU diff, empty range refers to the line before it
+	if expCount != 0 {
+		expStart++
+	}
+	if actCount != 0 {
+		actStart++
+	}
+
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@ %s",
+		expStart, expCount, actStart, actCount, path)
+}
+
+func renderUnifiedDiff(
+	hunks []lineDiffHunk, expLines, actLines, expPaths, actPaths []string,
+) string {
+	var sb strings.Builder
+
+	sb.WriteString("--- expected\n+++ actual\n")
+
+	for _, hunk := range hunks {
+		sb.WriteString(formatHunkHeader(hunk, expPaths, actPaths))
+		sb.WriteString("\n")
+
+		for _, e := range hunk.entries {
+			switch e.op {
+			case lineEqual:
+				sb.WriteString(" " + expLines[e.expIndex])
+			case lineDelete:
+				sb.WriteString("-" + expLines[e.expIndex])
+			case lineInsert:
+				sb.WriteString("+" + actLines[e.actIndex])
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func renderSideBySideDiff(
+	hunks []lineDiffHunk, expLines, actLines, expPaths, actPaths []string,
+	enableColors bool,
+) string {
+	type row struct {
+		left, right string
+		marker      string
+	}
+
+	width := len("--- expected")
+
+	hunkRows := make([][]row, 0, len(hunks))
+
+	for _, hunk := range hunks {
+		var rows []row
+
+		entries := hunk.entries
+		for n := 0; n < len(entries); {
+			if entries[n].op == lineEqual {
+				line := expLines[entries[n].expIndex]
+				rows = append(rows, row{line, actLines[entries[n].actIndex], " "})
+				n++
+				continue
+			}
+
+			// pair consecutive deleted and inserted lines
+			var deleted, inserted []string
+			for ; n < len(entries) && entries[n].op == lineDelete; n++ {
+				deleted = append(deleted, expLines[entries[n].expIndex])
+			}
+			for ; n < len(entries) && entries[n].op == lineInsert; n++ {
+				inserted = append(inserted, actLines[entries[n].actIndex])
+			}
+
+			for k := 0; k < len(deleted) || k < len(inserted); k++ {
+				switch {
+				case k < len(deleted) && k < len(inserted):
+					rows = append(rows, row{deleted[k], inserted[k], "|"})
+				case k < len(deleted):
+					rows = append(rows, row{deleted[k], "", "<"})
+				default:
+					rows = append(rows, row{"", inserted[k], ">"})
+				}
+			}
+		}
+
+		for _, r := range rows {
+			if len(r.left) > width {
+				width = len(r.left)
+			}
+		}
+
+		hunkRows = append(hunkRows, rows)
+	}
+
+	paint := func(s string, attr color.Attribute) string {
+		if !enableColors || s == "" {
+			return s
+		}
+		return color.New(attr).Sprint(s)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%-*s   %s\n", width, "--- expected", "+++ actual"))
+
+	for h, rows := range hunkRows {
+		sb.WriteString(formatHunkHeader(hunks[h], expPaths, actPaths))
+		sb.WriteString("\n")
+
+		for _, r := range rows {
+			left := fmt.Sprintf("%-*s", width, r.left)
+			right := r.right
+
+			switch r.marker {
+			case "|":
+				left = paint(left, color.FgRed)
+				right = paint(right, color.FgGreen)
+			case "<":
+				left = paint(left, color.FgRed)
+			case ">":
+				right = paint(right, color.FgGreen)
+			}
+
+			sb.WriteString(strings.TrimRight(
+				left+" "+r.marker+" "+right, " "))
+			sb.WriteString("\n")
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+var jsonIdentRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Compute JSON path for every line of pretty-printed JSON document,
+// e.g. "$.users[1].name". Closing brackets get path of their container.
+func jsonLinePaths(lines []string) []string {
+	type frame struct {
+		path    string
+		isArray bool
+		next    int
+	}
+
+	paths := make([]string, len(lines))
+
+	stack := []frame{}
+
+	for n, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "}") || strings.HasPrefix(trimmed, "]") {
+			if len(stack) != 0 {
+				paths[n] = stack[len(stack)-1].path
+				stack = stack[:len(stack)-1]
+			} else {
+				paths[n] = "$"
+			}
+			continue
+		}
+
+		path := "$"
+
+		if len(stack) != 0 {
+			top := &stack[len(stack)-1]
+
+			if top.isArray {
+				path = top.path + "[" + strconv.Itoa(top.next) + "]"
+				top.next++
+			} else {
+				path = top.path + jsonKeyPath(trimmed)
+			}
+		}
+
+		paths[n] = path
+
+		switch {
+		case strings.HasSuffix(trimmed, "{"):
+			stack = append(stack, frame{path: path})
+		case strings.HasSuffix(trimmed, "["):
+			stack = append(stack, frame{path: path, isArray: true})
+		}
+	}
+
+	return paths
+}
+
+// Extract object key from line like `"key": value` and format it as
+// JSON path element.
+func jsonKeyPath(line string) string {
+	dec := json.NewDecoder(strings.NewReader(line))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return ""
+	}
+
+	key, ok := tok.(string)
+	if !ok {
+		return ""
+	}
+
+	return jsonPathKey(key)
+}
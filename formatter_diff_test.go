@@ -0,0 +1,170 @@
+package httpexpect
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatter_LineDiff(t *testing.T) {
+	expected := map[string]interface{}{
+		"id": 1.0,
+		"user": map[string]interface{}{
+			"name": "john",
+			"tags": []interface{}{"a", "b"},
+		},
+	}
+
+	actual := map[string]interface{}{
+		"id": 1.0,
+		"user": map[string]interface{}{
+			"name": "bob",
+			"tags": []interface{}{"a", "b"},
+		},
+	}
+
+	t.Run("unified", func(t *testing.T) {
+		formatter := &DefaultFormatter{
+			DiffMode:    DiffModeUnified,
+			DiffContext: 1,
+		}
+
+		diff, ok := formatter.formatLineDiff(expected, actual, false)
+		assert.True(t, ok)
+		assert.Equal(t, strings.Join([]string{
+			`--- expected`,
+			`+++ actual`,
+			`@@ -3,3 +3,3 @@ $.user.name`,
+			`   "user": {`,
+			`-    "name": "john",`,
+			`+    "name": "bob",`,
+			`     "tags": [`,
+		}, "\n"), diff)
+	})
+
+	t.Run("side by side", func(t *testing.T) {
+		formatter := &DefaultFormatter{
+			DiffMode:    DiffModeSideBySide,
+			DiffContext: -1,
+		}
+
+		diff, ok := formatter.formatLineDiff(expected, actual, false)
+		assert.True(t, ok)
+		assert.Equal(t, strings.Join([]string{
+			`--- expected          +++ actual`,
+			`@@ -4,1 +4,1 @@ $.user.name`,
+			`    "name": "john", |     "name": "bob",`,
+		}, "\n"), diff)
+	})
+
+	t.Run("insert and delete", func(t *testing.T) {
+		formatter := &DefaultFormatter{
+			DiffMode:    DiffModeSideBySide,
+			DiffContext: -1,
+		}
+
+		diff, ok := formatter.formatLineDiff(
+			[]interface{}{1.0, 2.0, 3.0, 9.0},
+			[]interface{}{1.0, 3.0, 4.0, 9.0},
+			false)
+		assert.True(t, ok)
+		assert.Equal(t, strings.Join([]string{
+			`--- expected   +++ actual`,
+			`@@ -3,1 +2,0 @@ $[1]`,
+			`  2,         <`,
+			`@@ -4,0 +4,1 @@ $[2]`,
+			`             >   4,`,
+		}, "\n"), diff)
+	})
+
+	t.Run("not diffable", func(t *testing.T) {
+		formatter := &DefaultFormatter{
+			DiffMode: DiffModeUnified,
+		}
+
+		check := func(a, b interface{}) {
+			diff, ok := formatter.formatLineDiff(a, b, false)
+			assert.False(t, ok)
+			assert.Equal(t, "", diff)
+		}
+
+		check("foo", "bar")
+		check(map[string]interface{}{}, "bar")
+		check(expected, expected)
+	})
+}
+
+func TestFormatter_LineDiffMode(t *testing.T) {
+	failure := &AssertionFailure{
+		Type: AssertEqual,
+		Actual: &AssertionValue{
+			map[string]interface{}{"a": 1.0},
+		},
+		Expected: &AssertionValue{
+			map[string]interface{}{"a": 2.0},
+		},
+	}
+
+	cases := []struct {
+		name         string
+		mode         DiffMode
+		haveExpected bool
+		wantDiff     string
+	}{
+		{
+			name:         "default",
+			mode:         DiffModeDefault,
+			haveExpected: true,
+			wantDiff:     `-  "a": 2`,
+		},
+		{
+			name:         "unified",
+			mode:         DiffModeUnified,
+			haveExpected: false,
+			wantDiff:     "@@ -1,3 +1,3 @@ $.a\n {\n-  \"a\": 2\n+  \"a\": 1\n }",
+		},
+		{
+			name:         "side by side",
+			mode:         DiffModeSideBySide,
+			haveExpected: false,
+			wantDiff:     `  "a": 2     |   "a": 1`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			formatter := &DefaultFormatter{
+				DiffMode: tc.mode,
+			}
+
+			data := formatter.buildFormatData(&AssertionContext{}, failure)
+
+			assert.True(t, data.HaveDiff)
+			assert.Equal(t, tc.haveExpected, data.HaveExpected)
+			assert.Equal(t, tc.haveExpected, data.HaveActual)
+			assert.Contains(t, data.Diff, tc.wantDiff)
+		})
+	}
+}
+
+func TestFormatter_JSONLinePaths(t *testing.T) {
+	lines, ok := diffLines(map[string]interface{}{
+		"a": []interface{}{
+			1.0,
+			map[string]interface{}{"b c": true},
+		},
+	})
+	assert.True(t, ok)
+
+	assert.Equal(t, []string{
+		"$",
+		"$.a",
+		"$.a[0]",
+		"$.a[1]",
+		`$.a[1]["b c"]`,
+		"$.a[1]",
+		"$.a",
+		"$",
+	}, jsonLinePaths(lines))
+}
@@ -533,10 +533,39 @@ func TestFormatter_FailureErrors(t *testing.T) {
 	}
 }
 
+func TestFormatter_FailureSeverity(t *testing.T) {
+	df := &DefaultFormatter{
+		ColorMode: ColorModeNever,
+	}
+	ctx := &AssertionContext{}
+
+	t.Run("error", func(t *testing.T) {
+		msg := df.FormatFailure(ctx, &AssertionFailure{
+			Type:     AssertValid,
+			Severity: SeverityError,
+			Errors:   []error{fmt.Errorf("error message")},
+		})
+
+		assert.True(t, strings.HasPrefix(msg, "\nerror message"))
+		assert.NotContains(t, msg, "warning")
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		msg := df.FormatFailure(ctx, &AssertionFailure{
+			Type:     AssertValid,
+			Severity: SeverityWarning,
+			Errors:   []error{fmt.Errorf("error message")},
+		})
+
+		assert.True(t, strings.HasPrefix(msg, "\nwarning: error message"))
+	})
+}
+
 func TestFormatter_FailureContext(t *testing.T) {
 	ctx := &AssertionContext{
 		TestName:    "MyTestName",
 		RequestName: "MyRequestName",
+		RequestID:   "MyRequestID",
 		Path:        []string{"MyPath"},
 		AliasedPath: []string{"MyAliasedPath"},
 	}
@@ -552,6 +581,7 @@ func TestFormatter_FailureContext(t *testing.T) {
 			check: func(t *testing.T, fd *FormatData) {
 				assert.Equal(t, "MyTestName", fd.TestName)
 				assert.Equal(t, "MyRequestName", fd.RequestName)
+				assert.Equal(t, "MyRequestID", fd.RequestID)
 				assert.Equal(t, []string{"MyAliasedPath"}, fd.AssertPath)
 			},
 		},
@@ -563,6 +593,7 @@ func TestFormatter_FailureContext(t *testing.T) {
 			check: func(t *testing.T, fd *FormatData) {
 				assert.Equal(t, "", fd.TestName)
 				assert.Equal(t, "", fd.RequestName)
+				assert.Equal(t, "", fd.RequestID)
 				assert.Equal(t, []string{"MyAliasedPath"}, fd.AssertPath)
 			},
 		},
@@ -599,6 +630,19 @@ func TestFormatter_FailureContext(t *testing.T) {
 			tc.check(t, fd)
 		})
 	}
+
+	t.Run("template", func(t *testing.T) {
+		f := DefaultFormatter{
+			ColorMode: ColorModeNever,
+		}
+
+		msg := f.FormatFailure(ctx, &AssertionFailure{
+			Type: AssertEqual,
+		})
+
+		assert.Contains(t, msg, "request name: MyRequestName")
+		assert.Contains(t, msg, "request id: MyRequestID")
+	})
 }
 
 func TestFormatter_FloatFormat(t *testing.T) {
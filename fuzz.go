@@ -0,0 +1,744 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FuzzSource is a source of randomness for FuzzGenerator.
+//
+// Fuzzer.Run uses pseudo-random source initialized with seed, and
+// Fuzzer.RunInput uses source that consumes bytes of fuzzing input,
+// so that Go fuzzing engine can mutate generated values.
+//
+// *rand.Rand implements this interface.
+type FuzzSource interface {
+	// Intn returns a number in range [0; n).
+	Intn(n int) int
+}
+
+// FuzzGenerator generates a value of request parameter, see Fuzzer.
+//
+// Values for path, query, and header parameters are formatted using
+// fmt.Sprint. Values for JSON fields are inserted into body as is.
+type FuzzGenerator func(src FuzzSource) interface{}
+
+// Fuzzer sends many requests with generated parameters and checks that
+// responses satisfy given properties.
+//
+// Fuzzer is created from Expect instance, so all configured builders,
+// matchers, and printers are applied to generated requests. Parameters
+// are generated by FuzzGenerator functions; Fuzz* helpers provide common
+// generators. Properties are regular functions that perform assertions
+// on response, like WithMatcher.
+//
+// Requests are named "fuzz #N (seed S)" or "fuzz input", so failure
+// message includes request name and dump of the failed request.
+// Fuzzer stops after the first failed request. Before reporting failure,
+// Run shrinks generated parameters to a minimal failing case, see
+// WithMaxShrinks.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.Fuzz("GET", "/users/{id}").
+//		WithPathParam("id", httpexpect.FuzzOneOf(0, -1, "abc", "1e10")).
+//		WithQueryParam("limit", httpexpect.FuzzInt(-10, 1000)).
+//		WithProperty(httpexpect.FuzzNoServerErrors).
+//		WithProperty(func(resp *httpexpect.Response) {
+//			if resp.Raw().StatusCode >= 400 {
+//				resp.HasContentType("application/problem+json")
+//			}
+//		}).
+//		Run(100)
+type Fuzzer struct {
+	noCopy noCopy
+	chain  *chain
+
+	mu sync.Mutex
+
+	owner  *Expect
+	method string
+	path   string
+
+	params     []fuzzParam
+	template   interface{}
+	hasJSON    bool
+	properties []func(*Response)
+
+	seed    int64
+	hasSeed bool
+
+	maxShrinks int
+}
+
+type fuzzParamKind int
+
+const (
+	fuzzPath fuzzParamKind = iota
+	fuzzQuery
+	fuzzHeader
+	fuzzJSON
+)
+
+type fuzzParam struct {
+	kind   fuzzParamKind
+	key    string
+	tokens []ignorePathToken
+	gen    FuzzGenerator
+}
+
+// Fuzz returns a new Fuzzer instance, which sends requests with given
+// method and path. Path may contain "{name}" placeholders filled with
+// WithPathParam.
+//
+// See Fuzzer for usage example.
+func (e *Expect) Fuzz(method, path string) *Fuzzer {
+	opChain := e.chain.enter("Fuzz(%q, %q)", method, path)
+	defer opChain.leave()
+
+	return &Fuzzer{
+		chain:  opChain.clone(),
+		owner:  e,
+		method: method,
+		path:   path,
+
+		maxShrinks: defaultFuzzMaxShrinks,
+	}
+}
+
+// Alias is similar to Value.Alias.
+func (f *Fuzzer) Alias(name string) *Fuzzer {
+	opChain := f.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	f.chain.setAlias(name)
+	return f
+}
+
+// WithSeed sets seed used by Run to generate parameters.
+// If not set, seed is chosen randomly and included into request names.
+//
+// Example:
+//
+//	fuzzer.WithSeed(42).Run(100)
+func (f *Fuzzer) WithSeed(seed int64) *Fuzzer {
+	opChain := f.chain.enter("WithSeed()")
+	defer opChain.leave()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seed = seed
+	f.hasSeed = true
+	return f
+}
+
+// WithMaxShrinks sets maximum number of additional requests that Run
+// may send to shrink failing case. Default is 100. Zero disables
+// shrinking, and negative value is not allowed.
+//
+// Example:
+//
+//	fuzzer.WithMaxShrinks(1000).Run(100)
+func (f *Fuzzer) WithMaxShrinks(maxShrinks int) *Fuzzer {
+	opChain := f.chain.enter("WithMaxShrinks()")
+	defer opChain.leave()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if opChain.failed() {
+		return f
+	}
+
+	if maxShrinks < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected negative maxShrinks argument: %d",
+					maxShrinks),
+			},
+		})
+		return f
+	}
+
+	f.maxShrinks = maxShrinks
+	return f
+}
+
+// WithPathParam adds generator for path parameter, see Request.WithPath.
+//
+// Example:
+//
+//	fuzzer := e.Fuzz("GET", "/users/{id}").
+//		WithPathParam("id", httpexpect.FuzzInt(-1, 100))
+func (f *Fuzzer) WithPathParam(key string, gen FuzzGenerator) *Fuzzer {
+	return f.addParam("WithPathParam()", fuzzParam{kind: fuzzPath, key: key, gen: gen})
+}
+
+// WithQueryParam adds generator for query parameter, see Request.WithQuery.
+//
+// Example:
+//
+//	fuzzer := e.Fuzz("GET", "/users").
+//		WithQueryParam("limit", httpexpect.FuzzInt(-10, 1000))
+func (f *Fuzzer) WithQueryParam(key string, gen FuzzGenerator) *Fuzzer {
+	return f.addParam("WithQueryParam()", fuzzParam{kind: fuzzQuery, key: key, gen: gen})
+}
+
+// WithHeaderParam adds generator for header, see Request.WithHeader.
+//
+// Example:
+//
+//	fuzzer := e.Fuzz("GET", "/users").
+//		WithHeaderParam("Accept-Language", httpexpect.FuzzString(10))
+func (f *Fuzzer) WithHeaderParam(key string, gen FuzzGenerator) *Fuzzer {
+	return f.addParam("WithHeaderParam()", fuzzParam{kind: fuzzHeader, key: key, gen: gen})
+}
+
+// WithJSON sets template of JSON body. Fields of template are replaced
+// with generated values by WithJSONParam.
+//
+// Example:
+//
+//	fuzzer := e.Fuzz("POST", "/users").
+//		WithJSON(map[string]interface{}{"name": "john", "age": 30}).
+//		WithJSONParam("$.age", httpexpect.FuzzInt(-100, 1000))
+func (f *Fuzzer) WithJSON(template interface{}) *Fuzzer {
+	opChain := f.chain.enter("WithJSON()")
+	defer opChain.leave()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if opChain.failed() {
+		return f
+	}
+
+	value, ok := canonValue(opChain, template)
+	if !ok {
+		return f
+	}
+
+	f.template = value
+	f.hasJSON = true
+	return f
+}
+
+// WithJSONParam adds generator for JSON body field with given path, like
+// "$.user.name" or "$.items[0]". Missing object fields are created.
+// If WithJSON was not called, body is built from generated fields only.
+//
+// Example:
+//
+//	fuzzer := e.Fuzz("POST", "/users").
+//		WithJSONParam("$.name", httpexpect.FuzzString(100))
+func (f *Fuzzer) WithJSONParam(path string, gen FuzzGenerator) *Fuzzer {
+	opChain := f.chain.enter("WithJSONParam(%q)", path)
+	defer opChain.leave()
+
+	tokens, err := parseIgnorePath(path)
+	if err == nil {
+		for _, token := range tokens {
+			if token.wildcard || token.recursive {
+				err = fmt.Errorf("wildcards are not allowed in json path: %q", path)
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid path argument"),
+				err,
+			},
+		})
+		return f
+	}
+
+	f.mu.Lock()
+	f.hasJSON = true
+	f.mu.Unlock()
+
+	return f.addParamChain(opChain, fuzzParam{kind: fuzzJSON, tokens: tokens, gen: gen})
+}
+
+// WithProperty adds property that should hold for every response.
+// Property is a function that performs assertions on response.
+//
+// Example:
+//
+//	fuzzer.WithProperty(func(resp *httpexpect.Response) {
+//		resp.Header("Content-Type").NotEmpty()
+//	})
+func (f *Fuzzer) WithProperty(property func(*Response)) *Fuzzer {
+	opChain := f.chain.enter("WithProperty()")
+	defer opChain.leave()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if opChain.failed() {
+		return f
+	}
+
+	if property == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return f
+	}
+
+	f.properties = append(f.properties, property)
+	return f
+}
+
+func (f *Fuzzer) addParam(method string, param fuzzParam) *Fuzzer {
+	opChain := f.chain.enter(method)
+	defer opChain.leave()
+
+	return f.addParamChain(opChain, param)
+}
+
+func (f *Fuzzer) addParamChain(opChain *chain, param fuzzParam) *Fuzzer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if opChain.failed() {
+		return f
+	}
+
+	if param.gen == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil generator"),
+			},
+		})
+		return f
+	}
+
+	f.params = append(f.params, param)
+	return f
+}
+
+// Run sends given number of requests with parameters generated using
+// pseudo-random source, and checks properties of every response.
+// Stops after the first failed request.
+//
+// When a request fails, Run does not report it immediately. Instead, it
+// sends more requests, making generated values simpler (smaller numbers,
+// shorter strings, earlier FuzzOneOf alternatives, and so on) while the
+// failure persists. Then only the minimal failing request is reported,
+// named "fuzz #N (seed S, shrunk)", together with its method, URL, body,
+// and failed properties. If the failure can't be reproduced, the original
+// failure is reported.
+//
+// Returns responses of sent requests; the last one is the reported
+// failing response, if any.
+//
+// Example:
+//
+//	resps := fuzzer.WithSeed(42).Run(100)
+func (f *Fuzzer) Run(iterations int) []*Response {
+	opChain := f.chain.enter("Run(%d)", iterations)
+	defer opChain.leave()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if opChain.failed() {
+		return nil
+	}
+
+	if iterations <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-positive iterations argument: %d",
+					iterations),
+			},
+		})
+		return nil
+	}
+
+	seed := f.seed
+	if !f.hasSeed {
+		seed = time.Now().UnixNano()
+	}
+
+	resps := make([]*Response, 0, iterations)
+
+	for n := 0; n < iterations; n++ {
+		src := &fuzzRecordingSource{
+			src: rand.New(rand.NewSource(seed + int64(n))), //nolint
+		}
+
+		// hold failures until we know whether the case can be shrunk
+		handler := &fuzzAssertionHandler{
+			handler:  opChain.handler,
+			deferred: true,
+		}
+
+		name := fmt.Sprintf("fuzz #%d (seed %d)", n, seed+int64(n))
+
+		resp := f.send(opChain, src, name, handler)
+
+		if !resp.chain.treeFailed() {
+			handler.flush()
+			resps = append(resps, resp)
+			continue
+		}
+
+		choices, shrunk := f.shrink(opChain, src.choices)
+
+		if shrunk {
+			name = fmt.Sprintf("fuzz #%d (seed %d, shrunk)", n, seed+int64(n))
+
+			shrunkHandler := &fuzzAssertionHandler{
+				handler:  opChain.handler,
+				deferred: true,
+			}
+
+			shrunkResp := f.send(opChain,
+				&fuzzReplaySource{choices: choices}, name, shrunkHandler)
+
+			if shrunkResp.chain.treeFailed() {
+				handler.discard()
+
+				f.reportShrunk(opChain, shrunkResp, shrunkHandler.discard())
+
+				resps = append(resps, shrunkResp)
+				break
+			}
+
+			shrunkHandler.flush()
+		}
+
+		handler.flush()
+		resps = append(resps, resp)
+		break
+	}
+
+	return resps
+}
+
+// RunInput sends a single request with parameters generated from given
+// fuzzing input, and checks properties of response.
+//
+// It allows to use Go fuzzing engine to mutate parameters and to store
+// failing inputs in corpus (testdata/fuzz) for reproducibility.
+// RunInput does not shrink failing cases; Go fuzzing engine minimizes
+// failing inputs by itself.
+//
+// Example:
+//
+//	func FuzzUsers(f *testing.F) {
+//		f.Add([]byte("seed"))
+//
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			e := httpexpect.Default(t, "http://example.com")
+//
+//			e.Fuzz("GET", "/users").
+//				WithQueryParam("limit", httpexpect.FuzzInt(-10, 1000)).
+//				WithProperty(httpexpect.FuzzNoServerErrors).
+//				RunInput(data)
+//		})
+//	}
+func (f *Fuzzer) RunInput(data []byte) *Response {
+	opChain := f.chain.enter("RunInput()")
+	defer opChain.leave()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if opChain.failed() {
+		return newResponse(responseOpts{
+			config: f.owner.config,
+			chain:  opChain,
+		})
+	}
+
+	return f.send(opChain, &fuzzByteSource{data: data}, "fuzz input", nil)
+}
+
+// Send request with generated parameters and check properties.
+// If handler is non-nil, it is used for request and response.
+func (f *Fuzzer) send(
+	opChain *chain, src FuzzSource, name string, handler AssertionHandler,
+) *Response {
+	req := f.owner.newRequest(opChain, f.method, f.path).
+		WithName(name)
+
+	if handler != nil {
+		req.WithAssertionHandler(handler)
+	}
+
+	body := copyJSONValue(f.template)
+
+	for _, param := range f.params {
+		value := param.gen(src)
+
+		switch param.kind {
+		case fuzzPath:
+			req.WithPath(param.key, fmt.Sprint(value))
+
+		case fuzzQuery:
+			req.WithQuery(param.key, fmt.Sprint(value))
+
+		case fuzzHeader:
+			req.WithHeader(param.key, fmt.Sprint(value))
+
+		case fuzzJSON:
+			var err error
+			if body, err = setJSONPath(body, param.tokens, value); err != nil {
+				reqChain := req.chain.enter("WithJSONParam()")
+				reqChain.fail(AssertionFailure{
+					Type: AssertUsage,
+					Errors: []error{
+						errors.New("can't set generated value in json body"),
+						err,
+					},
+				})
+				reqChain.leave()
+			}
+		}
+	}
+
+	if f.hasJSON {
+		req.WithJSON(body)
+	}
+
+	resp := req.Expect()
+
+	for _, property := range f.properties {
+		property(resp)
+	}
+
+	return resp
+}
+
+// Returns deep copy of canonical JSON value.
+func copyJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			ret[key] = copyJSONValue(elem)
+		}
+		return ret
+
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for n, elem := range v {
+			ret[n] = copyJSONValue(elem)
+		}
+		return ret
+
+	default:
+		return value
+	}
+}
+
+// Set value at given path, creating missing object fields.
+// Returns updated root.
+func setJSONPath(
+	root interface{}, tokens []ignorePathToken, value interface{},
+) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	token := tokens[0]
+
+	if token.isIndex {
+		arr, ok := root.([]interface{})
+		if !ok || token.index >= len(arr) {
+			return root, fmt.Errorf("array index %d is out of range", token.index)
+		}
+
+		elem, err := setJSONPath(arr[token.index], tokens[1:], value)
+		if err != nil {
+			return root, err
+		}
+		arr[token.index] = elem
+		return arr, nil
+	}
+
+	obj, ok := root.(map[string]interface{})
+	if !ok {
+		if root != nil {
+			return root, fmt.Errorf("field %q is set on non-object value", token.key)
+		}
+		obj = make(map[string]interface{})
+	}
+
+	elem, err := setJSONPath(obj[token.key], tokens[1:], value)
+	if err != nil {
+		return root, err
+	}
+	obj[token.key] = elem
+	return obj, nil
+}
+
+// Source that consumes bytes of fuzzing input.
+// When input is exhausted, it returns zeros.
+type fuzzByteSource struct {
+	data []byte
+}
+
+func (s *fuzzByteSource) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+
+	numBytes := (bits.Len(uint(n-1)) + 7) / 8
+
+	var v uint64
+	for i := 0; i < numBytes && len(s.data) != 0; i++ {
+		v = v<<8 | uint64(s.data[0])
+		s.data = s.data[1:]
+	}
+
+	return int(v % uint64(n))
+}
+
+// FuzzInt returns generator of integers in range [min; max].
+//
+// If max is less than min, the function panics.
+func FuzzInt(min, max int) FuzzGenerator {
+	if max < min {
+		panic("max is less than min")
+	}
+
+	return func(src FuzzSource) interface{} {
+		return min + src.Intn(max-min+1)
+	}
+}
+
+// Characters used by FuzzString: letters, digits, whitespace, characters
+// with special meaning in URLs, HTML, SQL, and JSON, and non-ASCII letters.
+var fuzzChars = []rune("aZ09 \t\n'\"`<>&%/\\?#=;:.,-_*{}[]()\x00éЖ漢😀")
+
+// FuzzString returns generator of strings with length in range [0; maxLen],
+// consisting of letters, digits, and special characters.
+//
+// If maxLen is negative, the function panics.
+func FuzzString(maxLen int) FuzzGenerator {
+	if maxLen < 0 {
+		panic("negative max length")
+	}
+
+	return func(src FuzzSource) interface{} {
+		var sb strings.Builder
+
+		n := src.Intn(maxLen + 1)
+		for i := 0; i < n; i++ {
+			sb.WriteRune(fuzzChars[src.Intn(len(fuzzChars))])
+		}
+
+		return sb.String()
+	}
+}
+
+// FuzzOneOf returns generator that chooses one of given values.
+//
+// If no values are given, the function panics.
+func FuzzOneOf(values ...interface{}) FuzzGenerator {
+	if len(values) == 0 {
+		panic("no values")
+	}
+
+	return func(src FuzzSource) interface{} {
+		return values[src.Intn(len(values))]
+	}
+}
+
+// FuzzJSON returns generator of arbitrary JSON values: nulls, booleans,
+// numbers, strings, and arrays and objects nested up to maxDepth levels.
+//
+// If maxDepth is negative, the function panics.
+func FuzzJSON(maxDepth int) FuzzGenerator {
+	if maxDepth < 0 {
+		panic("negative max depth")
+	}
+
+	numbers := []interface{}{0, -1, 1, 0.5, 1e308, -1e308, 9007199254740993}
+	str := FuzzString(10)
+
+	var gen func(src FuzzSource, depth int) interface{}
+
+	gen = func(src FuzzSource, depth int) interface{} {
+		kinds := 5
+		if depth < maxDepth {
+			kinds = 7
+		}
+
+		switch src.Intn(kinds) {
+		case 0:
+			return nil
+		case 1:
+			return src.Intn(2) == 1
+		case 2:
+			return numbers[src.Intn(len(numbers))]
+		case 3, 4:
+			return str(src)
+		case 5:
+			arr := make([]interface{}, src.Intn(4))
+			for n := range arr {
+				arr[n] = gen(src, depth+1)
+			}
+			return arr
+		default:
+			obj := make(map[string]interface{})
+			for n := src.Intn(4); n > 0; n-- {
+				obj[str(src).(string)] = gen(src, depth+1)
+			}
+			return obj
+		}
+	}
+
+	return func(src FuzzSource) interface{} {
+		return gen(src, 0)
+	}
+}
+
+// FuzzNoServerErrors is a property for Fuzzer.WithProperty that
+// succeeds if response status is not 5xx.
+//
+// Example:
+//
+//	fuzzer.WithProperty(httpexpect.FuzzNoServerErrors)
+func FuzzNoServerErrors(resp *Response) {
+	opChain := resp.chain.enter("FuzzNoServerErrors()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return
+	}
+
+	status := resp.httpResp.StatusCode
+
+	if status >= 500 && status < 600 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotInRange,
+			Actual:   &AssertionValue{status},
+			Expected: &AssertionValue{AssertionRange{Min: 500, Max: 599}},
+			Errors: []error{
+				errors.New("expected: response status is not 5xx"),
+			},
+		})
+	}
+}
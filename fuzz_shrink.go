@@ -0,0 +1,265 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+const defaultFuzzMaxShrinks = 100
+
+// Shrinking works on the sequence of choices made by generators, i.e. on
+// values returned by FuzzSource.Intn. Generators are written so that smaller
+// choices produce simpler values: smaller numbers, shorter strings, earlier
+// alternatives, and shallower JSON. So instead of shrinking generated values
+// of every kind, we shrink the sequence of choices and replay it.
+
+// Source that records choices made by underlying source.
+type fuzzRecordingSource struct {
+	src     FuzzSource
+	choices []int
+}
+
+func (s *fuzzRecordingSource) Intn(n int) int {
+	v := s.src.Intn(n)
+	s.choices = append(s.choices, v)
+
+	return v
+}
+
+// Source that replays recorded choices.
+// Choices out of range are clamped, and missing choices are zeros.
+type fuzzReplaySource struct {
+	choices []int
+	pos     int
+}
+
+func (s *fuzzReplaySource) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+
+	if s.pos >= len(s.choices) {
+		return 0
+	}
+
+	v := s.choices[s.pos]
+	s.pos++
+
+	if v >= n {
+		v = n - 1
+	}
+
+	return v
+}
+
+// Shrink failing sequence of choices.
+// Returns minimal sequence which still fails, and flag whether
+// it differs from original sequence.
+func (f *Fuzzer) shrink(opChain *chain, choices []int) ([]int, bool) {
+	attempts := 0
+
+	// probe requests don't report anything
+	fails := func(candidate []int) bool {
+		attempts++
+
+		resp := f.send(opChain, &fuzzReplaySource{choices: candidate},
+			"fuzz shrink", &fuzzAssertionHandler{})
+
+		return resp.chain.treeFailed()
+	}
+
+	best := append([]int(nil), choices...)
+	shrunk := false
+
+	for improved := true; improved && attempts < f.maxShrinks; {
+		improved = false
+
+		// try to drop tail of the sequence, which is same as zeroing it
+		for size := len(best) / 2; size > 0 && attempts < f.maxShrinks; size /= 2 {
+			if fails(best[:len(best)-size]) {
+				best = best[:len(best)-size]
+				improved, shrunk = true, true
+				break
+			}
+		}
+
+		// try to make every choice smaller
+		for i := 0; i < len(best) && attempts < f.maxShrinks; i++ {
+			for _, v := range fuzzShrinkCandidates(best[i]) {
+				if attempts >= f.maxShrinks {
+					break
+				}
+
+				candidate := append([]int(nil), best...)
+				candidate[i] = v
+
+				if fails(candidate) {
+					best = candidate
+					improved, shrunk = true, true
+					break
+				}
+			}
+		}
+	}
+
+	// trailing zeros are same as missing choices
+	for len(best) != 0 && best[len(best)-1] == 0 {
+		best = best[:len(best)-1]
+	}
+
+	return best, shrunk
+}
+
+func fuzzShrinkCandidates(v int) []int {
+	switch {
+	case v <= 0:
+		return nil
+	case v == 1:
+		return []int{0}
+	case v == 2:
+		return []int{0, 1}
+	default:
+		return []int{0, v / 2, v - 1}
+	}
+}
+
+// Assertion handler that holds failures while deferred, and then either
+// reports them to underlying handler, or discards them.
+// After flush or discard, it passes everything through.
+// If underlying handler is nil, everything is discarded.
+type fuzzAssertionHandler struct {
+	mu       sync.Mutex
+	handler  AssertionHandler
+	deferred bool
+	failures []fuzzFailure
+}
+
+type fuzzFailure struct {
+	context     AssertionContext
+	failure     AssertionFailure
+	attachments []AssertionAttachment
+}
+
+func (h *fuzzAssertionHandler) Success(ctx *AssertionContext) {
+	if h.handler != nil {
+		h.handler.Success(ctx)
+	}
+}
+
+func (h *fuzzAssertionHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.FailureWithAttachments(ctx, failure, nil)
+}
+
+func (h *fuzzAssertionHandler) FailureWithAttachments(
+	ctx *AssertionContext, failure *AssertionFailure, attachments []AssertionAttachment,
+) {
+	if h.handler == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.deferred && failure.Severity == SeverityError {
+		h.failures = append(h.failures, fuzzFailure{
+			context:     *ctx,
+			failure:     *failure,
+			attachments: attachments,
+		})
+		return
+	}
+
+	h.report(ctx, failure, attachments)
+}
+
+func (h *fuzzAssertionHandler) report(
+	ctx *AssertionContext, failure *AssertionFailure, attachments []AssertionAttachment,
+) {
+	if ah, ok := h.handler.(AttachmentAssertionHandler); ok && attachments != nil {
+		ah.FailureWithAttachments(ctx, failure, attachments)
+	} else {
+		h.handler.Failure(ctx, failure)
+	}
+}
+
+// Report held failures and stop deferring.
+func (h *fuzzAssertionHandler) flush() {
+	for _, f := range h.discard() {
+		f := f
+		h.report(&f.context, &f.failure, f.attachments)
+	}
+}
+
+// Stop deferring and return held failures without reporting them.
+func (h *fuzzAssertionHandler) discard() []fuzzFailure {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	failures := h.failures
+	h.failures = nil
+	h.deferred = false
+
+	return failures
+}
+
+// Report failures of shrunk case as a single failure, which includes
+// minimal failing request with body.
+func (f *Fuzzer) reportShrunk(
+	opChain *chain, resp *Response, failures []fuzzFailure,
+) {
+	failChain := opChain.enter("Shrink()")
+	defer failChain.leave()
+
+	failChain.setRequestName(resp.chain.context.RequestName)
+	failChain.setRequest(resp.chain.context.Request)
+	failChain.setResponse(resp)
+
+	formatter := f.owner.config.Formatter
+	if formatter == nil {
+		formatter = &DefaultFormatter{}
+	}
+
+	errs := []error{
+		errors.New("expected: fuzz properties hold for every request"),
+		fmt.Errorf("minimal failing request:\n%s",
+			fuzzDumpRequest(resp.chain.context.Request)),
+	}
+
+	for n := range failures {
+		errs = append(errs, fmt.Errorf("failure %d of %d:\n%s",
+			n+1, len(failures),
+			formatter.FormatFailure(&failures[n].context, &failures[n].failure)))
+	}
+
+	failChain.fail(AssertionFailure{
+		Type:   AssertOperation,
+		Errors: errs,
+	})
+}
+
+// Format method, URL, and body of sent request.
+func fuzzDumpRequest(req *Request) string {
+	if req == nil || req.httpReq == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s %s", req.httpReq.Method, req.httpReq.URL)
+
+	if bw, ok := req.httpReq.Body.(*bodyWrapper); ok {
+		if rd, err := bw.GetBody(); err == nil {
+			if body, err := io.ReadAll(rd); err == nil && len(body) != 0 {
+				sb.WriteString("\n\n")
+				sb.Write(body)
+			}
+		}
+	}
+
+	return sb.String()
+}
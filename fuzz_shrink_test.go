@@ -0,0 +1,126 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzShrink_Run(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Name string `json:"name"`
+		}
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+
+		n, _ := strconv.Atoi(r.URL.Query().Get("n"))
+
+		if n >= 50 && len(body.Name) >= 4 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	assertionHandler := &mockAssertionHandler{}
+
+	e := WithConfig(Config{
+		BaseURL:          "http://example.com",
+		AssertionHandler: assertionHandler,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	resps := e.Fuzz("POST", "/").
+		WithSeed(1).
+		WithMaxShrinks(1000).
+		WithQueryParam("n", FuzzInt(0, 100)).
+		WithJSONParam("$.name", FuzzString(50)).
+		WithProperty(FuzzNoServerErrors).
+		Run(100)
+
+	assert.Equal(t, 1, assertionHandler.failureCalled)
+
+	require.NotNil(t, assertionHandler.failure)
+	assert.Equal(t, AssertOperation, assertionHandler.failure.Type)
+	assert.Contains(t, assertionHandler.failure.Errors[1].Error(),
+		"POST http://example.com/?n=50\n\n{\"name\":\"aaaa\"}")
+
+	last := resps[len(resps)-1]
+	last.chain.assert(t, failure)
+	assert.Contains(t, last.chain.context.RequestName, "shrunk")
+
+	httpReq := last.chain.context.Request.httpReq
+	assert.Equal(t, "n=50", httpReq.URL.RawQuery)
+
+	body, err := httpReq.Body.(*bodyWrapper).GetBody()
+	require.NoError(t, err)
+	data, _ := io.ReadAll(body)
+	assert.JSONEq(t, `{"name":"aaaa"}`, string(data))
+
+	for _, resp := range resps[:len(resps)-1] {
+		resp.chain.assert(t, success)
+	}
+}
+
+func TestFuzzShrink_NotReproduced(t *testing.T) {
+	count := 0
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	resps := e.Fuzz("GET", "/").
+		WithSeed(1).
+		WithQueryParam("n", FuzzInt(0, 100)).
+		WithProperty(FuzzNoServerErrors).
+		Run(10)
+
+	assert.Equal(t, 1, reporter.reportCalled)
+	require.Equal(t, 1, len(resps))
+	resps[0].chain.assert(t, failure)
+	assert.NotContains(t, resps[0].chain.context.RequestName, "shrunk")
+}
+
+func TestFuzzShrink_Usage(t *testing.T) {
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client:   &mockClient{},
+	})
+
+	f := e.Fuzz("GET", "/").WithMaxShrinks(-1)
+	f.chain.assert(t, failure)
+}
+
+func TestFuzzShrink_ReplaySource(t *testing.T) {
+	src := &fuzzReplaySource{choices: []int{5, 1}}
+
+	assert.Equal(t, 2, src.Intn(3))
+	assert.Equal(t, 1, src.Intn(3))
+	assert.Equal(t, 0, src.Intn(3))
+
+	assert.Panics(t, func() { src.Intn(0) })
+}
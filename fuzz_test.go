@@ -0,0 +1,238 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzz_Run(t *testing.T) {
+	var bodies []map[string]interface{}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		bodies = append(bodies, body)
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("X-Mode") == "" || r.URL.Path != "/users/bob" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	var statuses []int
+
+	resps := e.Fuzz("POST", "/users/{name}").
+		WithSeed(42).
+		WithPathParam("name", FuzzOneOf("bob")).
+		WithQueryParam("limit", FuzzInt(-5, 5)).
+		WithHeaderParam("X-Mode", FuzzOneOf("a", "b")).
+		WithJSON(map[string]interface{}{"name": "bob", "tags": []string{"x"}}).
+		WithJSONParam("$.age", FuzzInt(0, 10)).
+		WithJSONParam("$.tags[0]", FuzzString(5)).
+		WithProperty(FuzzNoServerErrors).
+		WithProperty(func(resp *Response) {
+			statuses = append(statuses, resp.Raw().StatusCode)
+		}).
+		Run(20)
+
+	assert.False(t, reporter.reported)
+	assert.Equal(t, 20, len(resps))
+	assert.Equal(t, 20, len(statuses))
+	assert.Contains(t, statuses, http.StatusOK)
+	assert.Contains(t, statuses, http.StatusBadRequest)
+
+	require.Equal(t, 20, len(bodies))
+	for _, body := range bodies {
+		assert.Equal(t, "bob", body["name"])
+		assert.Contains(t, body, "age")
+		assert.Equal(t, 1, len(body["tags"].([]interface{})))
+	}
+
+	t.Run("reproducible", func(t *testing.T) {
+		var first, second []string
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		for _, out := range []*[]string{&first, &second} {
+			out := out
+			e := WithConfig(Config{
+				Reporter: newMockReporter(t),
+				Client: &http.Client{
+					Transport: NewBinder(handler),
+				},
+			})
+
+			e.Fuzz("GET", "/").
+				WithSeed(7).
+				WithQueryParam("q", FuzzString(20)).
+				WithProperty(func(resp *Response) {
+					*out = append(*out,
+						resp.chain.context.Request.httpReq.URL.RawQuery)
+				}).
+				Run(10)
+		}
+
+		assert.Equal(t, first, second)
+	})
+}
+
+func TestFuzz_Failure(t *testing.T) {
+	count := 0
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if r.URL.Query().Get("n") == "3" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	resps := e.Fuzz("GET", "/").
+		WithSeed(1).
+		WithMaxShrinks(0).
+		WithQueryParam("n", FuzzInt(0, 3)).
+		WithProperty(FuzzNoServerErrors).
+		Run(1000)
+
+	assert.Equal(t, 1, reporter.reportCalled)
+	assert.Equal(t, count, len(resps))
+	assert.Less(t, len(resps), 1000)
+
+	last := resps[len(resps)-1]
+	last.chain.assert(t, failure)
+	assert.Equal(t, http.StatusInternalServerError, last.Raw().StatusCode)
+	assert.Contains(t, last.chain.context.RequestName, "fuzz #")
+}
+
+func TestFuzz_RunInput(t *testing.T) {
+	var queries []string
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	newFuzzer := func() *Fuzzer {
+		return e.Fuzz("GET", "/").
+			WithQueryParam("a", FuzzInt(0, 9)).
+			WithQueryParam("b", FuzzOneOf("x", "y", "z"))
+	}
+
+	newFuzzer().RunInput([]byte{3, 1}).chain.assert(t, success)
+	newFuzzer().RunInput([]byte{3, 1}).chain.assert(t, success)
+	newFuzzer().RunInput(nil).chain.assert(t, success)
+
+	assert.Equal(t, []string{"a=3&b=y", "a=3&b=y", "a=0&b=x"}, queries)
+}
+
+func TestFuzz_Usage(t *testing.T) {
+	newExpect := func(t *testing.T) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client:   &mockClient{},
+		})
+	}
+
+	t.Run("nil generator", func(t *testing.T) {
+		f := newExpect(t).Fuzz("GET", "/").WithQueryParam("a", nil)
+		f.chain.assert(t, failure)
+	})
+
+	t.Run("nil property", func(t *testing.T) {
+		f := newExpect(t).Fuzz("GET", "/").WithProperty(nil)
+		f.chain.assert(t, failure)
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		f := newExpect(t).Fuzz("GET", "/").WithJSONParam("$.a[*]", FuzzInt(0, 1))
+		f.chain.assert(t, failure)
+	})
+
+	t.Run("invalid iterations", func(t *testing.T) {
+		f := newExpect(t).Fuzz("GET", "/")
+		assert.Nil(t, f.Run(0))
+		f.chain.assert(t, failure)
+	})
+
+	t.Run("out of range index", func(t *testing.T) {
+		f := newExpect(t).Fuzz("GET", "/").
+			WithJSON([]interface{}{}).
+			WithJSONParam("$[1]", FuzzInt(0, 1))
+		resp := f.RunInput(nil)
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("generator panics", func(t *testing.T) {
+		assert.Panics(t, func() { FuzzInt(1, 0) })
+		assert.Panics(t, func() { FuzzString(-1) })
+		assert.Panics(t, func() { FuzzOneOf() })
+		assert.Panics(t, func() { FuzzJSON(-1) })
+	})
+}
+
+func TestFuzz_Generators(t *testing.T) {
+	src := rand.New(rand.NewSource(1)) //nolint
+
+	for n := 0; n < 100; n++ {
+		i := FuzzInt(-3, 3)(src).(int)
+		assert.True(t, i >= -3 && i <= 3)
+
+		s := FuzzString(5)(src).(string)
+		assert.LessOrEqual(t, len([]rune(s)), 5)
+
+		v := FuzzJSON(2)(src)
+		_, err := json.Marshal(v)
+		assert.NoError(t, err)
+	}
+
+	bsrc := &fuzzByteSource{data: []byte{1, 2, 0xff}}
+	assert.Equal(t, 1, bsrc.Intn(10))
+	assert.Equal(t, 2*256+0xff, bsrc.Intn(1000))
+	assert.Equal(t, 0, bsrc.Intn(10))
+}
@@ -21,6 +21,7 @@ require (
 	github.com/yalp/jsonpath v0.0.0-20180802001716-5cc68e5049a0
 	github.com/yudai/gojsondiff v1.0.0
 	golang.org/x/net v0.23.0
+	gopkg.in/yaml.v2 v2.4.0
 	moul.io/http2curl/v2 v2.3.0
 )
 
@@ -41,5 +42,5 @@ require (
 	github.com/yudai/golcs v0.0.0-20170316035057-ecda9a501e82 // indirect
 	github.com/yudai/pp v2.0.1+incompatible // indirect
 	golang.org/x/sys v0.18.0 // indirect
-	gopkg.in/yaml.v2 v2.4.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
 )
@@ -0,0 +1,342 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// HAL provides methods to inspect HAL (Hypertext Application Language)
+// resource.
+//
+// See https://datatracker.ietf.org/doc/html/draft-kelly-json-hal for details.
+type HAL struct {
+	noCopy noCopy
+	chain  *chain
+	value  map[string]interface{}
+}
+
+// NewHAL returns a new HAL instance.
+//
+// If reporter is nil, the function panics.
+// If value is nil or is not a JSON object, failure is reported.
+//
+// value should be map[string]interface{} or struct.
+//
+// Example:
+//
+//	res := NewHAL(t, map[string]interface{}{
+//		"_links": map[string]interface{}{
+//			"self": map[string]interface{}{"href": "/orders/1"},
+//		},
+//		"total": 30,
+//	})
+//	res.Href("self").IsEqual("/orders/1")
+func NewHAL(reporter Reporter, value interface{}) *HAL {
+	return newHAL(newChainWithDefaults("HAL()", reporter), value)
+}
+
+// NewHALC returns a new HAL instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is nil or is not a JSON object, failure is reported.
+//
+// See NewHAL for usage example.
+func NewHALC(config Config, value interface{}) *HAL {
+	return newHAL(newChainWithConfig("HAL()", config.withDefaults()), value)
+}
+
+func newHAL(parent *chain, val interface{}) *HAL {
+	h := &HAL{chain: parent.clone(), value: nil}
+
+	opChain := h.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil resource"),
+			},
+		})
+		return h
+	}
+
+	h.value, _ = canonMap(opChain, val)
+
+	return h
+}
+
+// Raw returns underlying resource attached to HAL.
+// This is the value originally passed to NewHAL, converted to canonical form.
+//
+// Example:
+//
+//	res := NewHAL(t, resource)
+//	assert.Equal(t, resource, res.Raw())
+func (h *HAL) Raw() map[string]interface{} {
+	return h.value
+}
+
+// Alias is similar to Value.Alias.
+func (h *HAL) Alias(name string) *HAL {
+	opChain := h.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	h.chain.setAlias(name)
+	return h
+}
+
+// Properties returns a new Object instance with resource state, i.e. all
+// resource properties except reserved "_links" and "_embedded".
+//
+// Example:
+//
+//	res := resp.HAL()
+//	res.Properties().IsEqual(map[string]interface{}{
+//		"total":  30,
+//		"status": "shipped",
+//	})
+func (h *HAL) Properties() *Object {
+	opChain := h.chain.enter("Properties()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	props := make(map[string]interface{}, len(h.value))
+	for key, value := range h.value {
+		if key != "_links" && key != "_embedded" {
+			props[key] = value
+		}
+	}
+
+	return newObject(opChain, props)
+}
+
+// Links returns a new Object instance with "_links" of the resource.
+//
+// If resource has no links, failure is reported.
+//
+// Example:
+//
+//	res := resp.HAL()
+//	res.Links().ContainsKey("self")
+func (h *HAL) Links() *Object {
+	opChain := h.chain.enter("Links()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	links, ok := h.getReserved(opChain, "_links")
+	if !ok {
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, links)
+}
+
+// Link returns a new Object instance with link object for given relation.
+//
+// If there is no such relation, or relation has multiple links,
+// failure is reported. Use Links to inspect multiple links.
+//
+// Example:
+//
+//	res := resp.HAL()
+//	res.Link("next").HasValue("href", "/orders?page=2")
+func (h *HAL) Link(rel string) *Object {
+	opChain := h.chain.enter("Link(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	link, ok := h.getLink(opChain, rel)
+	if !ok {
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, link)
+}
+
+// Href returns a new String instance with "href" of link for given relation.
+//
+// If there is no such relation, relation has multiple links, or link has
+// no href, failure is reported.
+//
+// Example:
+//
+//	res := resp.HAL()
+//	res.Href("self").IsEqual("/orders/123")
+func (h *HAL) Href(rel string) *String {
+	opChain := h.chain.enter("Href(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	link, ok := h.getLink(opChain, rel)
+	if !ok {
+		return newString(opChain, "")
+	}
+
+	href, ok := link["href"].(string)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{link},
+			Errors: []error{
+				fmt.Errorf("expected: link %q has string href", rel),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, href)
+}
+
+// Embedded returns a new Object instance with "_embedded" of the resource.
+//
+// If resource has no embedded resources, failure is reported.
+//
+// Example:
+//
+//	res := resp.HAL()
+//	res.Embedded().Value("orders").Array().Length().IsEqual(2)
+func (h *HAL) Embedded() *Object {
+	opChain := h.chain.enter("Embedded()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	embedded, ok := h.getReserved(opChain, "_embedded")
+	if !ok {
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, embedded)
+}
+
+// EmbeddedResource returns a new HAL instance with embedded resource for
+// given relation, which allows to navigate nested resources.
+//
+// If there is no such relation, or relation has multiple resources,
+// failure is reported.
+//
+// Example:
+//
+//	res := resp.HAL()
+//	res.EmbeddedResource("customer").Href("self").IsEqual("/customers/7")
+func (h *HAL) EmbeddedResource(rel string) *HAL {
+	opChain := h.chain.enter("EmbeddedResource(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newHAL(opChain, nil)
+	}
+
+	embedded, ok := h.getReserved(opChain, "_embedded")
+	if !ok {
+		return newHAL(opChain, nil)
+	}
+
+	value, ok := embedded[rel]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{embedded},
+			Expected: &AssertionValue{rel},
+			Errors: []error{
+				fmt.Errorf("expected: resource embeds %q relation", rel),
+			},
+		})
+		return newHAL(opChain, nil)
+	}
+
+	resource, ok := value.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: %q relation embeds single resource", rel),
+			},
+		})
+		return newHAL(opChain, nil)
+	}
+
+	return newHAL(opChain, resource)
+}
+
+func (h *HAL) getReserved(
+	opChain *chain, key string,
+) (map[string]interface{}, bool) {
+	value, ok := h.value[key]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{h.value},
+			Expected: &AssertionValue{key},
+			Errors: []error{
+				fmt.Errorf("expected: resource contains %q", key),
+			},
+		})
+		return nil, false
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: %q is object", key),
+			},
+		})
+		return nil, false
+	}
+
+	return object, true
+}
+
+func (h *HAL) getLink(opChain *chain, rel string) (map[string]interface{}, bool) {
+	links, ok := h.getReserved(opChain, "_links")
+	if !ok {
+		return nil, false
+	}
+
+	value, ok := links[rel]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{links},
+			Expected: &AssertionValue{rel},
+			Errors: []error{
+				fmt.Errorf("expected: resource has %q link", rel),
+			},
+		})
+		return nil, false
+	}
+
+	link, ok := value.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: %q relation has single link object", rel),
+			},
+		})
+		return nil, false
+	}
+
+	return link, true
+}
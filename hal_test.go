@@ -0,0 +1,144 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHAL_FailedChain(t *testing.T) {
+	chain := newMockChain(t, flagFailed)
+
+	value := newHAL(chain, map[string]interface{}{})
+	value.chain.assert(t, failure)
+
+	value.Alias("foo")
+	value.Properties().chain.assert(t, failure)
+	value.Links().chain.assert(t, failure)
+	value.Link("self").chain.assert(t, failure)
+	value.Href("self").chain.assert(t, failure)
+	value.Embedded().chain.assert(t, failure)
+	value.EmbeddedResource("foo").chain.assert(t, failure)
+}
+
+func TestHAL_Constructors(t *testing.T) {
+	res := map[string]interface{}{
+		"total": 1.0,
+	}
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewHAL(reporter, res)
+		assert.Equal(t, res, value.Raw())
+		value.chain.assert(t, success)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewHALC(Config{
+			Reporter: reporter,
+		}, res)
+		assert.Equal(t, res, value.Raw())
+		value.chain.assert(t, success)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newHAL(chain, res)
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		NewHAL(reporter, nil).chain.assert(t, failure)
+		NewHAL(reporter, []interface{}{}).chain.assert(t, failure)
+	})
+}
+
+func TestHAL_Navigation(t *testing.T) {
+	res := map[string]interface{}{
+		"_links": map[string]interface{}{
+			"self": map[string]interface{}{"href": "/orders/1"},
+			"items": []interface{}{
+				map[string]interface{}{"href": "/items/1"},
+				map[string]interface{}{"href": "/items/2"},
+			},
+			"broken": map[string]interface{}{"title": "no href"},
+		},
+		"_embedded": map[string]interface{}{
+			"customer": map[string]interface{}{
+				"_links": map[string]interface{}{
+					"self": map[string]interface{}{"href": "/customers/7"},
+				},
+				"name": "john",
+			},
+			"items": []interface{}{
+				map[string]interface{}{"sku": "a"},
+				map[string]interface{}{"sku": "b"},
+			},
+		},
+		"total":  30,
+		"status": "shipped",
+	}
+
+	t.Run("properties", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewHAL(reporter, res)
+
+		value.Properties().IsEqual(map[string]interface{}{
+			"total":  30,
+			"status": "shipped",
+		})
+		value.chain.assert(t, success)
+	})
+
+	t.Run("links", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewHAL(reporter, res)
+
+		value.Links().ContainsKey("items")
+		value.Link("self").HasValue("href", "/orders/1")
+		value.Href("self").IsEqual("/orders/1")
+		value.chain.assert(t, success)
+
+		value.Link("missing").chain.assert(t, failure)
+		value.Link("items").chain.assert(t, failure)
+		value.Href("items").chain.assert(t, failure)
+		value.Href("broken").chain.assert(t, failure)
+	})
+
+	t.Run("embedded", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewHAL(reporter, res)
+
+		value.Embedded().Value("items").Array().Length().IsEqual(2)
+
+		customer := value.EmbeddedResource("customer")
+		customer.Href("self").IsEqual("/customers/7")
+		customer.Properties().HasValue("name", "john")
+		customer.Embedded().chain.assert(t, failure)
+
+		value.chain.assert(t, success)
+
+		value.EmbeddedResource("missing").chain.assert(t, failure)
+		value.EmbeddedResource("items").chain.assert(t, failure)
+	})
+
+	t.Run("no reserved", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewHAL(reporter, map[string]interface{}{"foo": "bar"})
+
+		value.Properties().HasValue("foo", "bar")
+		value.chain.assert(t, success)
+
+		value.Links().chain.assert(t, failure)
+		value.Href("self").chain.assert(t, failure)
+		value.Embedded().chain.assert(t, failure)
+		value.EmbeddedResource("foo").chain.assert(t, failure)
+	})
+}
@@ -0,0 +1,243 @@
+package httpexpect
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+)
+
+// HeadersSizeTransport implements http.RoundTripper on top of http.Transport,
+// and records size of response headers as received on the wire, which is
+// then available via Response.HeadersSize.
+//
+// Connections are established using dial function of underlying transport,
+// wrapped to count bytes read from connection. Size can be recorded only for
+// HTTP/1.x responses received over plain (non-TLS) connections: over TLS,
+// transport decrypts data after reading it from dialed connection, and with
+// HTTP/2, headers are compressed. For other responses, size is not recorded.
+//
+// Keep-alive connections are supported: size is recorded separately for every
+// response received over the same connection.
+//
+// Example:
+//
+//	e := WithConfig(Config{
+//		BaseURL:  "http://example.com",
+//		Reporter: NewAssertReporter(t),
+//		Client: &http.Client{
+//			Transport: NewHeadersSizeTransport(nil),
+//		},
+//	})
+//
+//	e.GET("/path").Expect().
+//		HeadersSize().Lt(8 * 1024)
+type HeadersSizeTransport struct {
+	// Transport used to send requests. Transport is cloned, and dial
+	// function of the clone is wrapped.
+	// If nil, http.DefaultTransport is used.
+	Transport *http.Transport
+
+	once    sync.Once
+	derived *http.Transport
+}
+
+// NewHeadersSizeTransport returns a new HeadersSizeTransport given
+// underlying transport.
+//
+// If transport is nil, http.DefaultTransport is used.
+func NewHeadersSizeTransport(transport *http.Transport) *HeadersSizeTransport {
+	return &HeadersSizeTransport{Transport: transport}
+}
+
+// RoundTrip implements http.RoundTripper.RoundTrip.
+func (t *HeadersSizeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.once.Do(t.init)
+
+	onSize, _ := req.Context().Value(headersSizeKey{}).(func(int))
+	if onSize == nil {
+		return t.derived.RoundTrip(req)
+	}
+
+	var conn *headersSizeConn
+
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			// over TLS, connection is wrapped by tls.Conn
+			if c, ok := info.Conn.(*headersSizeConn); ok {
+				conn = c
+				conn.start()
+			}
+		},
+	}
+
+	resp, err := t.derived.RoundTrip(
+		req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+
+	if conn != nil {
+		// transport returns response after headers are read
+		if size, ok := conn.stop(); ok && err == nil {
+			onSize(size)
+		}
+	}
+
+	return resp, err
+}
+
+// CloseIdleConnections closes idle connections of underlying transport.
+func (t *HeadersSizeTransport) CloseIdleConnections() {
+	t.once.Do(t.init)
+
+	t.derived.CloseIdleConnections()
+}
+
+func (t *HeadersSizeTransport) init() {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport.(*http.Transport)
+	}
+
+	t.derived = transport.Clone()
+
+	dial := dialFuncOrDefault(transportDialFunc(t.derived))
+
+	t.derived.DialContext = func(
+		ctx context.Context, network, addr string,
+	) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return &headersSizeConn{Conn: conn}, nil
+	}
+	t.derived.Dial = nil //nolint
+}
+
+// Context key for callback invoked by HeadersSizeTransport.
+type headersSizeKey struct{}
+
+// Returns request with attached callback, which is invoked by
+// HeadersSizeTransport with size of response headers.
+//
+// If request is redirected, callback is invoked for every response in
+// redirect chain. If transport is not HeadersSizeTransport, or size
+// can't be recorded, callback is not invoked at all.
+func recordHeadersSize(httpReq *http.Request, onSize func(size int)) *http.Request {
+	return httpReq.WithContext(
+		context.WithValue(httpReq.Context(), headersSizeKey{}, onSize))
+}
+
+// Limits amount of data buffered while looking for end of headers.
+const maxHeadersSizeCapture = 1 << 20
+
+// Connection which counts size of the first response header block
+// read after start() call.
+type headersSizeConn struct {
+	net.Conn
+
+	mu        sync.Mutex
+	capturing bool
+	buf       []byte
+	size      int
+}
+
+// Start capturing headers of the next response.
+// Invoked before request is written, so all data read before that
+// belongs to previous responses.
+func (c *headersSizeConn) start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.capturing = true
+	c.buf = c.buf[:0]
+	c.size = -1
+}
+
+// Stop capturing and return size of captured headers.
+func (c *headersSizeConn) stop() (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.capturing = false
+	c.buf = nil
+
+	return c.size, c.size >= 0
+}
+
+func (c *headersSizeConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+
+	if n > 0 {
+		c.mu.Lock()
+		if c.capturing {
+			c.capture(b[:n])
+		}
+		c.mu.Unlock()
+	}
+
+	return n, err
+}
+
+// Append data to buffer and look for end of final (non-1xx) header block.
+// Size of interim 1xx responses is not included.
+func (c *headersSizeConn) capture(data []byte) {
+	c.buf = append(c.buf, data...)
+
+	for {
+		size := headerBlockSize(c.buf)
+		if size < 0 {
+			if len(c.buf) > maxHeadersSizeCapture {
+				c.capturing = false
+				c.buf = nil
+			}
+			return
+		}
+
+		block := c.buf[:size]
+		c.buf = c.buf[size:]
+
+		if !isInterimResponse(block) {
+			c.size = size
+			c.capturing = false
+			c.buf = nil
+			return
+		}
+	}
+}
+
+// Returns size of header block at the beginning of data, including status
+// line and empty line terminating headers, or -1 if block is incomplete.
+// Lines may be terminated by CRLF or by bare LF.
+func headerBlockSize(data []byte) int {
+	pos := 0
+
+	for lineNum := 0; ; lineNum++ {
+		i := bytes.IndexByte(data[pos:], '\n')
+		if i < 0 {
+			return -1
+		}
+
+		line := bytes.TrimSuffix(data[pos:pos+i], []byte("\r"))
+		pos += i + 1
+
+		// first line is status line
+		if lineNum != 0 && len(line) == 0 {
+			return pos
+		}
+	}
+}
+
+// Check if header block belongs to interim 1xx response, except
+// "101 Switching Protocols", which is final.
+func isInterimResponse(block []byte) bool {
+	fields := bytes.Fields(block)
+	if len(fields) < 2 {
+		return false
+	}
+
+	status := fields[1]
+
+	return len(status) == 3 && status[0] == '1' && !bytes.Equal(status, []byte("101"))
+}
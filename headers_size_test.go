@@ -0,0 +1,183 @@
+package httpexpect
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Serves every request on every accepted connection with given raw
+// response, keeping connection open.
+func newRawHeadersServer(t *testing.T, response string) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				reader := bufio.NewReader(conn)
+				for {
+					req, err := http.ReadRequest(reader)
+					if err != nil {
+						return
+					}
+					_, _ = io.Copy(io.Discard, req.Body)
+
+					if _, err := conn.Write([]byte(response)); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	return listener
+}
+
+func TestHeadersSizeTransport(t *testing.T) {
+	const (
+		headers = "HTTP/1.1 200 OK\r\n" +
+			"Content-Length: 2\r\n" +
+			"X-Foo:   bar\r\n" +
+			"\r\n"
+		body = "ok"
+	)
+
+	t.Run("keep-alive", func(t *testing.T) {
+		listener := newRawHeadersServer(t, headers+body)
+		defer listener.Close()
+
+		transport := NewHeadersSizeTransport(nil)
+		defer transport.CloseIdleConnections()
+
+		e := WithConfig(Config{
+			BaseURL:  "http://" + listener.Addr().String(),
+			Reporter: newMockReporter(t),
+			Client:   &http.Client{Transport: transport},
+		})
+
+		for i := 0; i < 2; i++ {
+			resp := e.GET("/path").Expect()
+			resp.Body().IsEqual(body)
+			resp.ConnectionReused().IsEqual(i != 0)
+			resp.HeadersSize().IsEqual(len(headers))
+			resp.chain.assert(t, success)
+		}
+	})
+
+	t.Run("interim response", func(t *testing.T) {
+		const interim = "HTTP/1.1 103 Early Hints\r\n" +
+			"Link: </style.css>; rel=preload\r\n" +
+			"\r\n"
+
+		listener := newRawHeadersServer(t, interim+headers+body)
+		defer listener.Close()
+
+		transport := NewHeadersSizeTransport(nil)
+		defer transport.CloseIdleConnections()
+
+		config := Config{
+			BaseURL:  "http://" + listener.Addr().String(),
+			Reporter: newMockReporter(t),
+			Client:   &http.Client{Transport: transport},
+		}
+
+		resp := NewRequestC(config, "GET", "/path").Expect()
+		resp.HeadersSize().IsEqual(len(headers))
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("bare lf", func(t *testing.T) {
+		const lfHeaders = "HTTP/1.1 200 OK\n" +
+			"Content-Length: 2\n" +
+			"\n"
+
+		listener := newRawHeadersServer(t, lfHeaders+body)
+		defer listener.Close()
+
+		transport := NewHeadersSizeTransport(nil)
+		defer transport.CloseIdleConnections()
+
+		config := Config{
+			BaseURL:  "http://" + listener.Addr().String(),
+			Reporter: newMockReporter(t),
+			Client:   &http.Client{Transport: transport},
+		}
+
+		resp := NewRequestC(config, "GET", "/path").Expect()
+		resp.HeadersSize().IsEqual(len(lfHeaders))
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		transport := NewHeadersSizeTransport(
+			server.Client().Transport.(*http.Transport))
+		defer transport.CloseIdleConnections()
+
+		config := Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+			Client:   &http.Client{Transport: transport},
+		}
+
+		resp := NewRequestC(config, "GET", "/path").Expect()
+		resp.Status(http.StatusOK)
+		resp.chain.assert(t, success)
+
+		resp.HeadersSize()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("other transport", func(t *testing.T) {
+		listener := newRawHeadersServer(t, headers+body)
+		defer listener.Close()
+
+		transport := &http.Transport{}
+		defer transport.CloseIdleConnections()
+
+		config := Config{
+			BaseURL:  "http://" + listener.Addr().String(),
+			Reporter: newMockReporter(t),
+			Client:   &http.Client{Transport: transport},
+		}
+
+		resp := NewRequestC(config, "GET", "/path").Expect()
+		resp.chain.assert(t, success)
+
+		resp.HeadersSize()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("without request", func(t *testing.T) {
+		listener := newRawHeadersServer(t, headers+body)
+		defer listener.Close()
+
+		transport := NewHeadersSizeTransport(nil)
+		defer transport.CloseIdleConnections()
+
+		client := &http.Client{Transport: transport}
+
+		httpResp, err := client.Get("http://" + listener.Addr().String())
+		require.NoError(t, err)
+		defer httpResp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, httpResp.StatusCode)
+	})
+}
@@ -0,0 +1,253 @@
+package httpexpect
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// HMACPart defines a part of request included into canonical string
+// signed by Request.WithHMACSignature.
+type HMACPart int
+
+const (
+	// HMACMethod is request method, e.g. "POST".
+	HMACMethod HMACPart = iota
+
+	// HMACPath is escaped request URL path, e.g. "/users/john%20doe".
+	HMACPath
+
+	// HMACQuery is encoded request URL query, e.g. "a=1&b=2", with keys
+	// sorted.
+	HMACQuery
+
+	// HMACHeaders are values of headers listed in HMACOpts.Headers, one
+	// line per header, in form "name:value", where name is lower-case.
+	HMACHeaders
+
+	// HMACBodyHash is hex-encoded hash of request body, computed using
+	// HMACOpts.Hash. Empty body is hashed as well.
+	HMACBodyHash
+)
+
+// HMACOpts defines parameters for Request.WithHMACSignature.
+type HMACOpts struct {
+	// Name of header to which signature is written.
+	// If empty, "X-Signature" is used.
+	Header string
+
+	// Prefix prepended to signature in header, e.g. "sha256=".
+	// May be empty.
+	Prefix string
+
+	// Hash function used for HMAC and for body hash.
+	// If nil, sha256.New is used.
+	Hash func() hash.Hash
+
+	// Encoding of computed signature.
+	// If nil, hex.EncodeToString is used.
+	// You can use base64.StdEncoding.EncodeToString as an alternative.
+	Encoding func([]byte) string
+
+	// Parts of request included into canonical string, in given order.
+	// Parts are joined using "\n" separator.
+	// If empty, method, path, headers, and body hash are used.
+	Parts []HMACPart
+
+	// Names of headers included into canonical string, in given order.
+	// Header names are case-insensitive. Missing headers are included
+	// with empty value. Used only if Parts contains HMACHeaders.
+	Headers []string
+}
+
+const defaultHMACHeader = "X-Signature"
+
+var defaultHMACParts = []HMACPart{
+	HMACMethod,
+	HMACPath,
+	HMACHeaders,
+	HMACBodyHash,
+}
+
+// WithHMACSignature enables signing of the request using HMAC.
+//
+// Request is signed right before sending, after request body, query, and
+// headers are finalized and request transformers and hooks are applied.
+// If request is retried, it's re-signed before every attempt.
+//
+// Signature is computed over canonical string built from parts of the
+// request listed in HMACOpts.Parts, using given secret. Then it's encoded
+// and written to header defined by HMACOpts.Header. Request body may be
+// read multiple times, so it's safe to include body hash into signature.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/orders")
+//	req.WithHeader("X-Timestamp", "1700000000")
+//	req.WithHMACSignature([]byte("secret"), HMACOpts{
+//		Header:  "X-Hub-Signature",
+//		Prefix:  "sha256=",
+//		Parts:   []HMACPart{HMACMethod, HMACPath, HMACHeaders, HMACBodyHash},
+//		Headers: []string{"X-Timestamp"},
+//	})
+//	req.WithJSON(order)
+func (r *Request) WithHMACSignature(secret []byte, options ...HMACOpts) *Request {
+	opChain := r.chain.enter("WithHMACSignature()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithHMACSignature()") {
+		return r
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return r
+	}
+
+	if len(secret) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty secret"),
+			},
+		})
+		return r
+	}
+
+	var opts HMACOpts
+	if len(options) != 0 {
+		opts = options[0]
+	}
+
+	for _, part := range opts.Parts {
+		if part < HMACMethod || part > HMACBodyHash {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("unexpected HMACPart value %d", part),
+				},
+			})
+			return r
+		}
+	}
+
+	signer := &hmacSigner{
+		secret:   append([]byte(nil), secret...),
+		header:   defaultHMACHeader,
+		prefix:   opts.Prefix,
+		hash:     sha256.New,
+		encoding: hex.EncodeToString,
+		parts:    defaultHMACParts,
+		headers:  append([]string(nil), opts.Headers...),
+	}
+
+	if opts.Header != "" {
+		signer.header = opts.Header
+	}
+	if opts.Hash != nil {
+		signer.hash = opts.Hash
+	}
+	if opts.Encoding != nil {
+		signer.encoding = opts.Encoding
+	}
+	if len(opts.Parts) != 0 {
+		signer.parts = append([]HMACPart(nil), opts.Parts...)
+	}
+
+	r.signers = append(r.signers, signer)
+
+	return r
+}
+
+type hmacSigner struct {
+	secret   []byte
+	header   string
+	prefix   string
+	hash     func() hash.Hash
+	encoding func([]byte) string
+	parts    []HMACPart
+	headers  []string
+}
+
+// Sign request in-place.
+// If body is non-nil, it's read to compute body hash and rewound.
+func (s *hmacSigner) sign(req *http.Request, body *bodyWrapper) error {
+	canonical, err := s.canonicalString(req, body)
+	if err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	mac := hmac.New(s.hash, s.secret)
+	mac.Write([]byte(canonical))
+
+	req.Header.Set(s.header, s.prefix+s.encoding(mac.Sum(nil)))
+
+	return nil
+}
+
+func (s *hmacSigner) canonicalString(
+	req *http.Request, body *bodyWrapper,
+) (string, error) {
+	lines := make([]string, 0, len(s.parts))
+
+	for _, part := range s.parts {
+		switch part {
+		case HMACMethod:
+			lines = append(lines, req.Method)
+
+		case HMACPath:
+			lines = append(lines, req.URL.EscapedPath())
+
+		case HMACQuery:
+			lines = append(lines, req.URL.Query().Encode())
+
+		case HMACHeaders:
+			for _, name := range s.headers {
+				lines = append(lines, strings.ToLower(name)+":"+
+					strings.TrimSpace(req.Header.Get(name)))
+			}
+
+		case HMACBodyHash:
+			bodyHash, err := s.bodyHash(body)
+			if err != nil {
+				return "", err
+			}
+			lines = append(lines, bodyHash)
+		}
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (s *hmacSigner) bodyHash(body *bodyWrapper) (string, error) {
+	hash := s.hash()
+
+	if body != nil {
+		body.Rewind()
+		defer body.Rewind()
+
+		if _, err := io.Copy(hash, body); err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
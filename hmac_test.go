@@ -0,0 +1,192 @@
+package httpexpect
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_HMACSignature(t *testing.T) {
+	secret := []byte("secret")
+
+	hashHex := func(data string) string {
+		h := sha256.Sum256([]byte(data))
+		return hex.EncodeToString(h[:])
+	}
+
+	mac := func(canonical string) []byte {
+		m := hmac.New(sha256.New, secret)
+		m.Write([]byte(canonical))
+		return m.Sum(nil)
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = "http://example.com"
+		config.Client = client
+
+		req := NewRequestC(config, "POST", "/a b").
+			WithHMACSignature(secret).
+			WithText("hello")
+		req.chain.assert(t, success)
+
+		req.Expect().chain.assert(t, success)
+
+		canonical := "POST\n/a%20b\n" + hashHex("hello")
+
+		require.NotNil(t, client.req)
+		assert.Equal(t, hex.EncodeToString(mac(canonical)),
+			client.req.Header.Get("X-Signature"))
+
+		b, err := io.ReadAll(client.req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(b))
+	})
+
+	t.Run("options", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = client
+
+		req := NewRequestC(config, "GET", "/path").
+			WithQuery("b", "2").
+			WithQuery("a", "1").
+			WithHeader("X-Timestamp", " 123 ").
+			WithHMACSignature(secret, HMACOpts{
+				Header:   "Authorization",
+				Prefix:   "HMAC ",
+				Encoding: base64.StdEncoding.EncodeToString,
+				Parts: []HMACPart{
+					HMACQuery, HMACHeaders, HMACMethod,
+				},
+				Headers: []string{"X-Timestamp", "X-Missing"},
+			})
+		req.chain.assert(t, success)
+
+		req.Expect().chain.assert(t, success)
+
+		canonical := "a=1&b=2\nx-timestamp:123\nx-missing:\nGET"
+
+		assert.Equal(t, "HMAC "+base64.StdEncoding.EncodeToString(mac(canonical)),
+			client.req.Header.Get("Authorization"))
+	})
+
+	t.Run("hash", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = client
+
+		NewRequestC(config, "PUT", "/").
+			WithBytes([]byte("data")).
+			WithHMACSignature(secret, HMACOpts{
+				Hash:  sha1.New,
+				Parts: []HMACPart{HMACBodyHash},
+			}).
+			Expect().
+			chain.assert(t, success)
+
+		bodyHash := sha1.Sum([]byte("data"))
+
+		m := hmac.New(sha1.New, secret)
+		m.Write([]byte(hex.EncodeToString(bodyHash[:])))
+
+		assert.Equal(t, hex.EncodeToString(m.Sum(nil)),
+			client.req.Header.Get("X-Signature"))
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = client
+
+		NewRequestC(config, "GET", "/").
+			WithHMACSignature(secret, HMACOpts{
+				Parts: []HMACPart{HMACBodyHash},
+			}).
+			Expect().
+			chain.assert(t, success)
+
+		assert.Equal(t, hex.EncodeToString(mac(hashHex(""))),
+			client.req.Header.Get("X-Signature"))
+	})
+
+	t.Run("resign on retry", func(t *testing.T) {
+		var signatures []string
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &mockClient{
+			err: errors.New("test error"),
+			cb: func(req *http.Request) {
+				signatures = append(signatures, req.Header.Get("X-Signature"))
+				req.Header.Set("X-Attempt", "1")
+			},
+		}
+
+		NewRequestC(config, "POST", "/").
+			WithText("hello").
+			WithHMACSignature(secret, HMACOpts{
+				Parts:   []HMACPart{HMACHeaders, HMACBodyHash},
+				Headers: []string{"X-Attempt"},
+			}).
+			WithRetryPolicy(RetryAllErrors).
+			WithMaxRetries(1).
+			WithRetryDelay(0, 0).
+			Expect().
+			chain.assert(t, failure)
+
+		require.Equal(t, 2, len(signatures))
+		assert.Equal(t,
+			hex.EncodeToString(mac("x-attempt:\n"+hashHex("hello"))),
+			signatures[0])
+		assert.Equal(t,
+			hex.EncodeToString(mac("x-attempt:1\n"+hashHex("hello"))),
+			signatures[1])
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			secret  []byte
+			options []HMACOpts
+		}{
+			{
+				name:   "empty secret",
+				secret: nil,
+			},
+			{
+				name:    "multiple options",
+				secret:  secret,
+				options: []HMACOpts{{}, {}},
+			},
+			{
+				name:    "invalid part",
+				secret:  secret,
+				options: []HMACOpts{{Parts: []HMACPart{HMACPart(100)}}},
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				config := newMockConfig(newMockReporter(t))
+
+				req := NewRequestC(config, "GET", "/").
+					WithHMACSignature(tc.secret, tc.options...)
+				req.chain.assert(t, failure)
+			})
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Invoke Config.RequestHooks for request that is going to be sent.
+// Reports failure and returns false if any of the hooks fails.
+func (r *Request) runRequestHooks(opChain *chain) bool {
+	if len(r.config.RequestHooks) == 0 {
+		return true
+	}
+
+	// streaming body is re-opened after every hook instead of buffering
+	if r.bodyFunc == nil && r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
+		if _, ok := r.httpReq.Body.(*bodyWrapper); !ok {
+			r.httpReq.Body = newBodyWrapper(r.httpReq.Body, nil)
+		}
+	}
+
+	reqBody, _ := r.httpReq.Body.(*bodyWrapper)
+
+	for _, hook := range r.config.RequestHooks {
+		if hook == nil {
+			continue
+		}
+
+		err := hook(r.httpReq)
+
+		if reqBody != nil {
+			reqBody.Rewind()
+		}
+
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("request hook failed"),
+					err,
+				},
+			})
+			return false
+		}
+
+		if r.bodyFunc != nil && !r.bodyOneShot {
+			r.httpReq.Body.Close()
+
+			body, err := r.bodyFunc()
+			if err != nil {
+				opChain.fail(AssertionFailure{
+					Type: AssertOperation,
+					Errors: []error{
+						errors.New("failed to open request body"),
+						err,
+					},
+				})
+				return false
+			}
+
+			r.httpReq.Body = body
+		}
+	}
+
+	return true
+}
+
+// Invoke Config.ResponseHooks for received response.
+// Reports failure, closes response body, and returns false if any of the
+// hooks fails.
+func runResponseHooks(opChain *chain, config Config, httpResp *http.Response) bool {
+	if len(config.ResponseHooks) == 0 {
+		return true
+	}
+
+	if httpResp.Body != nil && httpResp.Body != http.NoBody {
+		if _, ok := httpResp.Body.(*bodyWrapper); !ok {
+			bw := newBodyWrapper(httpResp.Body, nil)
+			bw.SetMaxSize(config.MaxBufferedBodySize)
+			httpResp.Body = bw
+		}
+	}
+
+	respBody, _ := httpResp.Body.(*bodyWrapper)
+
+	for _, hook := range config.ResponseHooks {
+		if hook == nil {
+			continue
+		}
+
+		err := hook(httpResp)
+
+		if respBody != nil {
+			respBody.Rewind()
+		}
+
+		if err != nil {
+			if httpResp.Body != nil {
+				_ = httpResp.Body.Close()
+			}
+
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("response hook failed"),
+					err,
+				},
+			})
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,255 @@
+package httpexpect
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHooks_Request(t *testing.T) {
+	t.Run("modify request", func(t *testing.T) {
+		client := &mockClient{}
+
+		var bodies []string
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = client
+		config.RequestHooks = []func(*http.Request) error{
+			func(req *http.Request) error {
+				b, _ := io.ReadAll(req.Body)
+				bodies = append(bodies, string(b))
+				req.Header.Set("X-Signature", "sig:"+string(b))
+				return nil
+			},
+			nil,
+			func(req *http.Request) error {
+				b, _ := io.ReadAll(req.Body)
+				bodies = append(bodies, string(b))
+				return nil
+			},
+		}
+
+		req := NewRequestC(config, "POST", "/").
+			WithTransformer(func(req *http.Request) {
+				req.Header.Set("X-Transformed", "1")
+			}).
+			WithText("hello")
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		resp.Header("X-Signature").IsEqual("sig:hello")
+		resp.Header("X-Transformed").IsEqual("1")
+		resp.Body().IsEqual("hello")
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, []string{"hello", "hello"}, bodies)
+	})
+
+	t.Run("hook order", func(t *testing.T) {
+		var calls []string
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &mockClient{}
+		config.RequestHooks = []func(*http.Request) error{
+			func(req *http.Request) error {
+				assert.Equal(t, "1", req.Header.Get("X-Transformed"))
+				calls = append(calls, "hook1")
+				return nil
+			},
+			func(req *http.Request) error {
+				calls = append(calls, "hook2")
+				return nil
+			},
+		}
+
+		NewRequestC(config, "GET", "/").
+			WithTransformer(func(req *http.Request) {
+				calls = append(calls, "transformer")
+				req.Header.Set("X-Transformed", "1")
+			}).
+			Expect().
+			chain.assert(t, success)
+
+		assert.Equal(t, []string{"transformer", "hook1", "hook2"}, calls)
+	})
+
+	t.Run("hook error", func(t *testing.T) {
+		client := &mockClient{}
+
+		called := false
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = client
+		config.RequestHooks = []func(*http.Request) error{
+			func(req *http.Request) error {
+				return errors.New("test error")
+			},
+			func(req *http.Request) error {
+				called = true
+				return nil
+			},
+		}
+
+		req := NewRequestC(config, "GET", "/")
+
+		resp := req.Expect()
+		req.chain.assert(t, failure)
+		resp.chain.assert(t, failure)
+
+		assert.False(t, called)
+		assert.Nil(t, client.req)
+	})
+
+	t.Run("repeat", func(t *testing.T) {
+		client := &mockClient{}
+
+		calls := 0
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = client
+		config.RequestHooks = []func(*http.Request) error{
+			func(req *http.Request) error {
+				calls++
+				req.Header.Set("X-Hook", "1")
+				return nil
+			},
+		}
+
+		NewRequestC(config, "GET", "/").
+			Repeat(3, 1).
+			chain.assert(t, success)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, "1", client.req.Header.Get("X-Hook"))
+	})
+
+	t.Run("repeat, hook error", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = client
+		config.RequestHooks = []func(*http.Request) error{
+			func(req *http.Request) error {
+				return errors.New("test error")
+			},
+		}
+
+		NewRequestC(config, "GET", "/").
+			Repeat(3, 1).
+			chain.assert(t, failure)
+
+		assert.Nil(t, client.req)
+	})
+}
+
+func TestHooks_Response(t *testing.T) {
+	t.Run("inspect response", func(t *testing.T) {
+		var bodies []string
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &mockClient{}
+		config.ResponseHooks = []func(*http.Response) error{
+			func(resp *http.Response) error {
+				b, _ := io.ReadAll(resp.Body)
+				bodies = append(bodies, string(b))
+				return nil
+			},
+			nil,
+			func(resp *http.Response) error {
+				b, _ := io.ReadAll(resp.Body)
+				bodies = append(bodies, string(b))
+				return nil
+			},
+		}
+
+		resp := NewRequestC(config, "POST", "/").
+			WithText("hello").
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.Body().IsEqual("hello")
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, []string{"hello", "hello"}, bodies)
+	})
+
+	t.Run("hook order", func(t *testing.T) {
+		var calls []string
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &mockClient{}
+		config.ResponseHooks = []func(*http.Response) error{
+			func(resp *http.Response) error {
+				calls = append(calls, "hook")
+				return nil
+			},
+		}
+
+		NewRequestC(config, "GET", "/").
+			WithMatcher(func(resp *Response) {
+				calls = append(calls, "matcher")
+			}).
+			Expect().
+			chain.assert(t, success)
+
+		assert.Equal(t, []string{"hook", "matcher"}, calls)
+	})
+
+	t.Run("hook error", func(t *testing.T) {
+		called := false
+		matched := false
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &mockClient{}
+		config.ResponseHooks = []func(*http.Response) error{
+			func(resp *http.Response) error {
+				return errors.New("test error")
+			},
+			func(resp *http.Response) error {
+				called = true
+				return nil
+			},
+		}
+
+		req := NewRequestC(config, "GET", "/").
+			WithMatcher(func(resp *Response) {
+				matched = true
+			})
+
+		resp := req.Expect()
+		req.chain.assert(t, failure)
+		resp.chain.assert(t, failure)
+
+		assert.False(t, called)
+		assert.False(t, matched)
+	})
+
+	t.Run("repeat", func(t *testing.T) {
+		calls := 0
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &mockClient{}
+		config.ResponseHooks = []func(*http.Response) error{
+			func(resp *http.Response) error {
+				calls++
+				if calls == 2 {
+					return errors.New("test error")
+				}
+				return nil
+			},
+		}
+
+		stats := NewRequestC(config, "GET", "/").
+			Repeat(3, 1)
+		stats.chain.assert(t, success)
+
+		assert.Equal(t, 3, calls)
+
+		stats.SuccessRate().InDelta(2.0/3.0, 0.001)
+		stats.chain.assert(t, success)
+	})
+}
@@ -0,0 +1,260 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Maximum number of violating responses included into failure message.
+const maxInvariantViolations = 5
+
+// InvariantStats contains statistics collected by InvariantMonitor.
+type InvariantStats struct {
+	// Number of observed responses.
+	Total int
+
+	// Number of responses with non-2xx status, excluding expected statuses.
+	Errors int
+
+	// Number of responses slower than maximum response time.
+	Slow int
+
+	// Maximum observed response time.
+	MaxResponseTime time.Duration
+}
+
+// InvariantMonitor collects statistics of all responses from Expect
+// instance and checks that they satisfy declared invariants, like
+// response time budget and error budget.
+//
+// Monitor is attached using Expect.WithInvariants, and invariants are
+// verified using Expect.VerifyInvariants, usually at suite teardown.
+// Monitor may be shared between multiple Expect instances and used from
+// multiple goroutines.
+//
+// Example:
+//
+//	monitor := httpexpect.NewInvariantMonitor().
+//		WithMaxResponseTime(2 * time.Second).
+//		WithMaxErrorRate(0.01).
+//		WithExpectedStatus(http.StatusNotFound)
+//
+//	e := httpexpect.Default(t, "http://example.com").
+//		WithInvariants(monitor)
+//
+//	e.GET("/users").Expect().Status(http.StatusOK)
+//	e.GET("/users/missing").Expect().Status(http.StatusNotFound)
+//
+//	e.VerifyInvariants(t)
+type InvariantMonitor struct {
+	mu sync.Mutex
+
+	maxResponseTime  time.Duration
+	maxErrorRate     float64
+	hasMaxErrorRate  bool
+	expectedStatuses map[int]bool
+
+	stats      InvariantStats
+	slowList   []string
+	errorsList []string
+}
+
+// NewInvariantMonitor returns a new InvariantMonitor without invariants.
+//
+// Use WithMaxResponseTime and WithMaxErrorRate to declare invariants.
+func NewInvariantMonitor() *InvariantMonitor {
+	return &InvariantMonitor{
+		expectedStatuses: make(map[int]bool),
+	}
+}
+
+// WithMaxResponseTime declares that round-trip time of every response
+// should be less than or equal to given duration.
+//
+// If duration is not positive, the function panics.
+//
+// Example:
+//
+//	monitor := httpexpect.NewInvariantMonitor().
+//		WithMaxResponseTime(2 * time.Second)
+func (m *InvariantMonitor) WithMaxResponseTime(d time.Duration) *InvariantMonitor {
+	if d <= 0 {
+		panic("max response time should be positive")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.maxResponseTime = d
+	return m
+}
+
+// WithMaxErrorRate declares that the fraction of responses with non-2xx
+// status should be less than or equal to given rate, from 0 to 1.
+// Statuses passed to WithExpectedStatus are not counted as errors.
+//
+// If rate is not in range [0; 1], the function panics.
+//
+// Example:
+//
+//	// less than 1% of errors
+//	monitor := httpexpect.NewInvariantMonitor().
+//		WithMaxErrorRate(0.01)
+func (m *InvariantMonitor) WithMaxErrorRate(rate float64) *InvariantMonitor {
+	if !(rate >= 0 && rate <= 1) {
+		panic("max error rate should be in range [0; 1]")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.maxErrorRate = rate
+	m.hasMaxErrorRate = true
+	return m
+}
+
+// WithExpectedStatus excludes responses with given statuses from error
+// rate, e.g. when suite intentionally checks not found or forbidden cases.
+//
+// Example:
+//
+//	monitor := httpexpect.NewInvariantMonitor().
+//		WithMaxErrorRate(0).
+//		WithExpectedStatus(http.StatusNotFound, http.StatusForbidden)
+func (m *InvariantMonitor) WithExpectedStatus(statuses ...int) *InvariantMonitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, status := range statuses {
+		m.expectedStatuses[status] = true
+	}
+	return m
+}
+
+// Stats returns statistics collected so far.
+func (m *InvariantMonitor) Stats() InvariantStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.stats
+}
+
+// Invoked by matcher attached by Expect.WithInvariants for every response.
+func (m *InvariantMonitor) observe(resp *Response) {
+	if resp.httpResp == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.stats.Total++
+
+	status := resp.httpResp.StatusCode
+	if !(status >= 200 && status < 300) && !m.expectedStatuses[status] {
+		m.stats.Errors++
+		if len(m.errorsList) < maxInvariantViolations {
+			m.errorsList = append(m.errorsList,
+				fmt.Sprintf("%s %s", describeRequest(resp.httpResp.Request),
+					http.StatusText(status)))
+		}
+	}
+
+	if resp.rtt == nil {
+		return
+	}
+
+	rtt := *resp.rtt
+
+	if rtt > m.stats.MaxResponseTime {
+		m.stats.MaxResponseTime = rtt
+	}
+
+	if m.maxResponseTime > 0 && rtt > m.maxResponseTime {
+		m.stats.Slow++
+		if len(m.slowList) < maxInvariantViolations {
+			m.slowList = append(m.slowList,
+				fmt.Sprintf("%s (%s)", describeRequest(resp.httpResp.Request), rtt))
+		}
+	}
+}
+
+// Reports failure for every violated invariant.
+// Each invariant is checked using its own chain, so that all violations
+// are reported, not only the first one.
+func (m *InvariantMonitor) verify(reporter Reporter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.verifyResponseTime(
+		newChainWithDefaults("VerifyInvariants()", reporter).enter("MaxResponseTime()"))
+
+	m.verifyErrorRate(
+		newChainWithDefaults("VerifyInvariants()", reporter).enter("MaxErrorRate()"))
+}
+
+func (m *InvariantMonitor) verifyResponseTime(opChain *chain) {
+	defer opChain.leave()
+
+	if m.maxResponseTime == 0 || m.stats.Slow == 0 {
+		return
+	}
+
+	errs := []error{
+		fmt.Errorf("expected: all responses are not slower than %s",
+			m.maxResponseTime),
+		fmt.Errorf("but: %d of %d response(s) were slower, e.g.:",
+			m.stats.Slow, m.stats.Total),
+	}
+	for _, s := range m.slowList {
+		errs = append(errs, errors.New("  "+s))
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:     AssertLe,
+		Actual:   &AssertionValue{m.stats.MaxResponseTime},
+		Expected: &AssertionValue{m.maxResponseTime},
+		Errors:   errs,
+	})
+}
+
+func (m *InvariantMonitor) verifyErrorRate(opChain *chain) {
+	defer opChain.leave()
+
+	if !m.hasMaxErrorRate || m.stats.Total == 0 {
+		return
+	}
+
+	rate := float64(m.stats.Errors) / float64(m.stats.Total)
+	if rate <= m.maxErrorRate {
+		return
+	}
+
+	errs := []error{
+		fmt.Errorf("expected: error rate is not greater than %g",
+			m.maxErrorRate),
+		fmt.Errorf("but: %d of %d response(s) had unexpected status, e.g.:",
+			m.stats.Errors, m.stats.Total),
+	}
+	for _, s := range m.errorsList {
+		errs = append(errs, errors.New("  "+s))
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:     AssertLe,
+		Actual:   &AssertionValue{rate},
+		Expected: &AssertionValue{m.maxErrorRate},
+		Errors:   errs,
+	})
+}
+
+func describeRequest(req *http.Request) string {
+	if req == nil || req.URL == nil {
+		return "<unknown request>"
+	}
+
+	return req.Method + " " + req.URL.String()
+}
@@ -0,0 +1,183 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvariants_Constructors(t *testing.T) {
+	m := NewInvariantMonitor()
+
+	assert.Panics(t, func() {
+		m.WithMaxResponseTime(0)
+	})
+	assert.Panics(t, func() {
+		m.WithMaxErrorRate(-0.1)
+	})
+	assert.Panics(t, func() {
+		m.WithMaxErrorRate(1.1)
+	})
+
+	assert.NotPanics(t, func() {
+		m.WithMaxResponseTime(time.Second).
+			WithMaxErrorRate(0).
+			WithMaxErrorRate(1).
+			WithExpectedStatus(http.StatusNotFound)
+	})
+}
+
+func TestInvariants_Observe(t *testing.T) {
+	newResp := func(status int, rtt time.Duration) *Response {
+		return NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: status,
+			Request: &http.Request{
+				Method: "GET",
+				URL:    &url.URL{Scheme: "http", Host: "example.com", Path: "/path"},
+			},
+		}, rtt)
+	}
+
+	t.Run("response time", func(t *testing.T) {
+		m := NewInvariantMonitor().
+			WithMaxResponseTime(time.Second)
+
+		m.observe(newResp(http.StatusOK, 100*time.Millisecond))
+
+		reporter := newMockReporter(t)
+		m.verify(reporter)
+		assert.False(t, reporter.reported)
+
+		m.observe(newResp(http.StatusOK, 2*time.Second))
+		m.observe(newResp(http.StatusOK, 3*time.Second))
+
+		assert.Equal(t, InvariantStats{
+			Total:           3,
+			Slow:            2,
+			MaxResponseTime: 3 * time.Second,
+		}, m.Stats())
+
+		reporter = newMockReporter(t)
+		m.verify(reporter)
+		assert.Equal(t, 1, reporter.reportCalled)
+	})
+
+	t.Run("error rate", func(t *testing.T) {
+		m := NewInvariantMonitor().
+			WithMaxErrorRate(0.5).
+			WithExpectedStatus(http.StatusNotFound)
+
+		m.observe(newResp(http.StatusOK, 0))
+		m.observe(newResp(http.StatusNotFound, 0))
+		m.observe(newResp(http.StatusInternalServerError, 0))
+
+		assert.Equal(t, 1, m.Stats().Errors)
+
+		reporter := newMockReporter(t)
+		m.verify(reporter)
+		assert.False(t, reporter.reported)
+
+		m.observe(newResp(http.StatusBadGateway, 0))
+		m.observe(newResp(http.StatusBadGateway, 0))
+
+		reporter = newMockReporter(t)
+		m.verify(reporter)
+		assert.Equal(t, 1, reporter.reportCalled)
+	})
+
+	t.Run("all violated", func(t *testing.T) {
+		m := NewInvariantMonitor().
+			WithMaxResponseTime(time.Second).
+			WithMaxErrorRate(0)
+
+		m.observe(newResp(http.StatusInternalServerError, 2*time.Second))
+
+		reporter := newMockReporter(t)
+		m.verify(reporter)
+		assert.Equal(t, 2, reporter.reportCalled)
+	})
+
+	t.Run("no invariants", func(t *testing.T) {
+		m := NewInvariantMonitor()
+
+		m.observe(newResp(http.StatusInternalServerError, time.Hour))
+
+		reporter := newMockReporter(t)
+		m.verify(reporter)
+		assert.False(t, reporter.reported)
+	})
+}
+
+func TestInvariants_Expect(t *testing.T) {
+	t.Run("attached", func(t *testing.T) {
+		client := &mockClient{
+			resp: http.Response{StatusCode: http.StatusOK},
+		}
+
+		m := NewInvariantMonitor().WithMaxErrorRate(0)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}).WithInvariants(m)
+
+		// copies share monitor
+		e.Builder(func(*Request) {}).GET("/").Expect().chain.assert(t, success)
+
+		reporter := newMockReporter(t)
+		e.VerifyInvariants(reporter)
+		assert.False(t, reporter.reported)
+
+		client.resp.StatusCode = http.StatusInternalServerError
+		e.GET("/").Expect().chain.assert(t, success)
+
+		assert.Equal(t, 2, m.Stats().Total)
+		assert.Equal(t, 1, m.Stats().Errors)
+
+		reporter = newMockReporter(t)
+		e.VerifyInvariants(reporter)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("not attached", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		})
+
+		reporter := newMockReporter(t)
+		e.VerifyInvariants(reporter)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          "http://example.com",
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		})
+
+		var ret *Expect
+		assert.NotPanics(t, func() {
+			ret = e.WithInvariants(nil)
+		})
+
+		assert.Equal(t, 1, handler.failureCalled)
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertUsage, handler.failure.Type)
+		assert.Equal(t, []string{"WithInvariants()"}, handler.ctx.Path)
+		assert.Nil(t, ret.invariants)
+
+		assert.Panics(t, func() {
+			e.VerifyInvariants(nil)
+		})
+	})
+}
@@ -0,0 +1,56 @@
+package httpexpect
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Check epsilon passed to IsEqualApprox, reporting failure if it's invalid.
+func checkApproxEpsilon(opChain *chain, epsilon float64) bool {
+	if math.IsNaN(epsilon) || math.IsInf(epsilon, 0) {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-number epsilon argument: %v", epsilon),
+			},
+		})
+		return false
+	}
+
+	if epsilon < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected negative epsilon argument: %v", epsilon),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// Returns function that compares canonical values, treating numbers
+// as equal if they are within epsilon of each other.
+// NaN is not equal to anything, Inf is equal only to Inf of same sign.
+func approxEqualFunc(epsilon float64) func(expected, actual interface{}) bool {
+	return func(expected, actual interface{}) bool {
+		expectedNum, expectedOk := expected.(float64)
+		actualNum, actualOk := actual.(float64)
+
+		if !expectedOk || !actualOk {
+			return reflect.DeepEqual(expected, actual)
+		}
+
+		if math.IsNaN(expectedNum) || math.IsNaN(actualNum) {
+			return false
+		}
+
+		if expectedNum == actualNum {
+			return true
+		}
+
+		return math.Abs(expectedNum-actualNum) <= epsilon
+	}
+}
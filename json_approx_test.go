@@ -0,0 +1,38 @@
+package httpexpect
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONApprox_EqualFunc(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected interface{}
+		actual   interface{}
+		epsilon  float64
+		result   bool
+	}{
+		{"equal numbers", 1.0, 1.0, 0, true},
+		{"within epsilon", 1.0, 1.05, 0.1, true},
+		{"on epsilon", 1.0, 1.5, 0.5, true},
+		{"outside epsilon", 1.0, 1.2, 0.1, false},
+		{"negative", -1.0, -1.05, 0.1, true},
+		{"nan", math.NaN(), math.NaN(), 1, false},
+		{"inf", math.Inf(1), math.Inf(1), 0, true},
+		{"opposite inf", math.Inf(1), math.Inf(-1), 1, false},
+		{"number vs string", 1.0, "1", 1, false},
+		{"strings", "foo", "foo", 0, true},
+		{"different strings", "foo", "bar", 1, false},
+		{"nil", nil, nil, 0, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.result,
+				approxEqualFunc(tc.epsilon)(tc.expected, tc.actual))
+		})
+	}
+}
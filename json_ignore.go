@@ -0,0 +1,295 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Placeholder for ignored array elements. Ignored object fields are removed,
+// but array elements are replaced to keep indices of other elements.
+const ignoredPlaceholder = "<ignored>"
+
+// Element of JSON path pattern used by IsEqualIgnoring.
+type ignorePathToken struct {
+	key       string
+	index     int
+	isIndex   bool
+	wildcard  bool
+	recursive bool
+}
+
+// Element of concrete JSON path of a node.
+type ignorePathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// Parse JSON path pattern, e.g. "$.users[*].id" or "$..created_at".
+//
+// Supported syntax: "$" root, ".key" and "[\"key\"]" object fields,
+// "[N]" array elements, ".*" and "[*]" wildcards matching any field or
+// element, and ".." recursive descent matching any number of levels.
+func parseIgnorePath(path string) ([]ignorePathToken, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("json path should start with '$': %q", path)
+	}
+
+	var tokens []ignorePathToken
+
+	s := path[1:]
+
+	for len(s) != 0 {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			tokens = append(tokens, ignorePathToken{recursive: true})
+			s = s[1:]
+
+		case s[0] == '.':
+			s = s[1:]
+
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+
+			key := s[:end]
+			if key == "" {
+				return nil, fmt.Errorf("empty key in json path: %q", path)
+			}
+
+			if key == "*" {
+				tokens = append(tokens, ignorePathToken{wildcard: true})
+			} else {
+				tokens = append(tokens, ignorePathToken{key: key})
+			}
+
+			s = s[end:]
+
+		case s[0] == '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '[' in json path: %q", path)
+			}
+
+			elem := s[1:end]
+
+			if strings.HasPrefix(elem, `"`) {
+				key, err := strconv.Unquote(elem)
+				if err != nil {
+					return nil, fmt.Errorf("invalid quoted key in json path: %q", path)
+				}
+				tokens = append(tokens, ignorePathToken{key: key})
+			} else if elem == "*" {
+				tokens = append(tokens, ignorePathToken{wildcard: true})
+			} else {
+				index, err := strconv.Atoi(elem)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid array index in json path: %q", path)
+				}
+				tokens = append(tokens, ignorePathToken{index: index, isIndex: true})
+			}
+
+			s = s[end+1:]
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q in json path: %q",
+				s[0], path)
+		}
+	}
+
+	if len(tokens) != 0 && tokens[len(tokens)-1].recursive {
+		return nil, fmt.Errorf("json path can't end with '..': %q", path)
+	}
+
+	return tokens, nil
+}
+
+func (t ignorePathToken) matches(seg ignorePathSegment) bool {
+	if t.wildcard {
+		return true
+	}
+
+	if t.isIndex != seg.isIndex {
+		return false
+	}
+
+	if t.isIndex {
+		return t.index == seg.index
+	}
+
+	return t.key == seg.key
+}
+
+func matchIgnorePath(pattern []ignorePathToken, path []ignorePathSegment) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	if pattern[0].recursive {
+		for i := 0; i <= len(path); i++ {
+			if matchIgnorePath(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(path) == 0 || !pattern[0].matches(path[0]) {
+		return false
+	}
+
+	return matchIgnorePath(pattern[1:], path[1:])
+}
+
+// Returns a copy of canonical JSON value with nodes matching any of
+// patterns removed (for object fields) or replaced with placeholder
+// (for array elements). Input value is not modified.
+func ignoreNodes(patterns [][]ignorePathToken, value interface{}) interface{} {
+	return ignoreNode(patterns, nil, value)
+}
+
+func ignoreNode(
+	patterns [][]ignorePathToken, path []ignorePathSegment, value interface{},
+) interface{} {
+	isIgnored := func(path []ignorePathSegment) bool {
+		for _, pattern := range patterns {
+			if matchIgnorePath(pattern, path) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			elemPath := append(path[:len(path):len(path)],
+				ignorePathSegment{key: key})
+			if isIgnored(elemPath) {
+				continue
+			}
+			ret[key] = ignoreNode(patterns, elemPath, elem)
+		}
+		return ret
+
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for n, elem := range v {
+			elemPath := append(path[:len(path):len(path)],
+				ignorePathSegment{index: n, isIndex: true})
+			if isIgnored(elemPath) {
+				ret[n] = ignoredPlaceholder
+				continue
+			}
+			ret[n] = ignoreNode(patterns, elemPath, elem)
+		}
+		return ret
+
+	default:
+		return value
+	}
+}
+
+// Returns JSON path of the first node where canonical values differ,
+// or empty string if values are equal.
+func firstMismatchPath(path string, expected, actual interface{}) string {
+	return firstMismatchPathFunc(path, expected, actual, reflect.DeepEqual)
+}
+
+// Same as firstMismatchPath, but uses given function to compare values
+// other than objects and arrays.
+func firstMismatchPathFunc(
+	path string, expected, actual interface{},
+	equal func(expected, actual interface{}) bool,
+) string {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return path
+		}
+
+		keys := make([]string, 0, len(e)+len(a))
+		for key := range e {
+			keys = append(keys, key)
+		}
+		for key := range a {
+			if _, ok := e[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			elemPath := path + jsonPathKey(key)
+
+			expectedElem, expectedOk := e[key]
+			actualElem, actualOk := a[key]
+			if expectedOk != actualOk {
+				return elemPath
+			}
+
+			p := firstMismatchPathFunc(elemPath, expectedElem, actualElem, equal)
+			if p != "" {
+				return p
+			}
+		}
+
+		return ""
+
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			return path
+		}
+
+		for n := 0; n < len(e) && n < len(a); n++ {
+			elemPath := path + "[" + strconv.Itoa(n) + "]"
+
+			if p := firstMismatchPathFunc(elemPath, e[n], a[n], equal); p != "" {
+				return p
+			}
+		}
+
+		if len(e) != len(a) {
+			return path
+		}
+
+		return ""
+
+	default:
+		if !equal(expected, actual) {
+			return path
+		}
+
+		return ""
+	}
+}
+
+// Parse paths passed to IsEqualIgnoring, reporting failure on invalid path.
+func parseIgnorePaths(opChain *chain, paths []string) ([][]ignorePathToken, bool) {
+	patterns := make([][]ignorePathToken, 0, len(paths))
+
+	for _, path := range paths {
+		pattern, err := parseIgnorePath(path)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("invalid path argument"),
+					err,
+				},
+			})
+			return nil, false
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, true
+}
@@ -0,0 +1,194 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONIgnore_ParsePath(t *testing.T) {
+	cases := []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: "$"},
+		{path: "$.foo"},
+		{path: "$.foo.bar"},
+		{path: `$["foo.bar"]`},
+		{path: "$[0]"},
+		{path: "$.foo[1].bar"},
+		{path: "$.*"},
+		{path: "$[*]"},
+		{path: "$..id"},
+		{path: "$.foo..id"},
+		{path: "", wantErr: true},
+		{path: "foo", wantErr: true},
+		{path: "$.", wantErr: true},
+		{path: "$.foo..", wantErr: true},
+		{path: "$[", wantErr: true},
+		{path: "$[foo]", wantErr: true},
+		{path: "$[-1]", wantErr: true},
+		{path: `$["foo]`, wantErr: true},
+		{path: "$foo", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			_, err := parseIgnorePath(tc.path)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestJSONIgnore_Nodes(t *testing.T) {
+	value := map[string]interface{}{
+		"id": "1",
+		"user": map[string]interface{}{
+			"id":   "2",
+			"name": "john",
+		},
+		"items": []interface{}{
+			map[string]interface{}{"id": "3", "n": 1.0},
+			map[string]interface{}{"id": "4", "n": 2.0},
+		},
+		"a.b": "c",
+	}
+
+	cases := []struct {
+		name     string
+		paths    []string
+		expected interface{}
+	}{
+		{
+			name:     "none",
+			paths:    []string{},
+			expected: value,
+		},
+		{
+			name:  "root key",
+			paths: []string{"$.id"},
+			expected: map[string]interface{}{
+				"user": map[string]interface{}{
+					"id":   "2",
+					"name": "john",
+				},
+				"items": []interface{}{
+					map[string]interface{}{"id": "3", "n": 1.0},
+					map[string]interface{}{"id": "4", "n": 2.0},
+				},
+				"a.b": "c",
+			},
+		},
+		{
+			name:  "quoted key and element",
+			paths: []string{`$["a.b"]`, "$.items[0]"},
+			expected: map[string]interface{}{
+				"id": "1",
+				"user": map[string]interface{}{
+					"id":   "2",
+					"name": "john",
+				},
+				"items": []interface{}{
+					ignoredPlaceholder,
+					map[string]interface{}{"id": "4", "n": 2.0},
+				},
+			},
+		},
+		{
+			name:  "wildcard",
+			paths: []string{"$.items[*].id", "$.user.*"},
+			expected: map[string]interface{}{
+				"id":   "1",
+				"user": map[string]interface{}{},
+				"items": []interface{}{
+					map[string]interface{}{"n": 1.0},
+					map[string]interface{}{"n": 2.0},
+				},
+				"a.b": "c",
+			},
+		},
+		{
+			name:  "recursive",
+			paths: []string{"$..id"},
+			expected: map[string]interface{}{
+				"user": map[string]interface{}{
+					"name": "john",
+				},
+				"items": []interface{}{
+					map[string]interface{}{"n": 1.0},
+					map[string]interface{}{"n": 2.0},
+				},
+				"a.b": "c",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var patterns [][]ignorePathToken
+			for _, path := range tc.paths {
+				pattern, err := parseIgnorePath(path)
+				assert.NoError(t, err)
+				patterns = append(patterns, pattern)
+			}
+
+			assert.Equal(t, tc.expected, ignoreNodes(patterns, value))
+		})
+	}
+}
+
+func TestJSONIgnore_MismatchPath(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected interface{}
+		actual   interface{}
+		wantPath string
+	}{
+		{
+			name:     "equal",
+			expected: map[string]interface{}{"a": []interface{}{1.0}},
+			actual:   map[string]interface{}{"a": []interface{}{1.0}},
+			wantPath: "",
+		},
+		{
+			name:     "value",
+			expected: map[string]interface{}{"a": 1.0, "b": 2.0},
+			actual:   map[string]interface{}{"a": 1.0, "b": 3.0},
+			wantPath: "$.b",
+		},
+		{
+			name:     "missing key",
+			expected: map[string]interface{}{"a": 1.0},
+			actual:   map[string]interface{}{"a": 1.0, "b": 2.0},
+			wantPath: "$.b",
+		},
+		{
+			name:     "nested element",
+			expected: map[string]interface{}{"a": []interface{}{1.0, 2.0}},
+			actual:   map[string]interface{}{"a": []interface{}{1.0, 3.0}},
+			wantPath: "$.a[1]",
+		},
+		{
+			name:     "length",
+			expected: []interface{}{1.0},
+			actual:   []interface{}{1.0, 2.0},
+			wantPath: "$",
+		},
+		{
+			name:     "type",
+			expected: map[string]interface{}{"a b": 1.0},
+			actual:   map[string]interface{}{"a b": "1"},
+			wantPath: `$["a b"]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantPath, firstMismatchPath("$", tc.expected, tc.actual))
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// JSONAPI provides methods to inspect JSON:API document.
+//
+// See https://jsonapi.org/format/ for details.
+type JSONAPI struct {
+	noCopy noCopy
+	chain  *chain
+	value  map[string]interface{}
+}
+
+// NewJSONAPI returns a new JSONAPI instance.
+//
+// If reporter is nil, the function panics.
+// If value is nil or is not a valid JSON:API document, failure is reported.
+//
+// value should be map[string]interface{} or struct.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, map[string]interface{}{
+//		"data": map[string]interface{}{
+//			"type": "articles",
+//			"id":   "1",
+//		},
+//	})
+//	doc.Resource("articles", "1").HasValue("id", "1")
+func NewJSONAPI(reporter Reporter, value interface{}) *JSONAPI {
+	return newJSONAPI(newChainWithDefaults("JSONAPI()", reporter), value)
+}
+
+// NewJSONAPIC returns a new JSONAPI instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is nil or is not a valid JSON:API document, failure is reported.
+//
+// See NewJSONAPI for usage example.
+func NewJSONAPIC(config Config, value interface{}) *JSONAPI {
+	return newJSONAPI(newChainWithConfig("JSONAPI()", config.withDefaults()), value)
+}
+
+func newJSONAPI(parent *chain, val interface{}) *JSONAPI {
+	j := &JSONAPI{chain: parent.clone(), value: nil}
+
+	opChain := j.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil document"),
+			},
+		})
+		return j
+	}
+
+	doc, ok := canonMap(opChain, val)
+	if !ok {
+		return j
+	}
+
+	_, hasData := doc["data"]
+	_, hasErrors := doc["errors"]
+	_, hasMeta := doc["meta"]
+
+	if !hasData && !hasErrors && !hasMeta {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{doc},
+			Errors: []error{
+				errors.New("expected: valid json:api document"),
+				errors.New(
+					"document should contain at least one of: data, errors, meta"),
+			},
+		})
+		return j
+	}
+
+	if hasData && hasErrors {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{doc},
+			Errors: []error{
+				errors.New("expected: valid json:api document"),
+				errors.New("document should not contain both data and errors"),
+			},
+		})
+		return j
+	}
+
+	j.value = doc
+
+	return j
+}
+
+// Raw returns underlying document attached to JSONAPI.
+// This is the value originally passed to NewJSONAPI, converted to canonical form.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, document)
+//	assert.Equal(t, document, doc.Raw())
+func (j *JSONAPI) Raw() map[string]interface{} {
+	return j.value
+}
+
+// Alias is similar to Value.Alias.
+func (j *JSONAPI) Alias(name string) *JSONAPI {
+	opChain := j.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	j.chain.setAlias(name)
+	return j
+}
+
+// Data returns a new Value instance with primary data of the document.
+//
+// Primary data may be a single resource object, an array of resource
+// objects, or null. If document has no primary data, failure is reported.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Data().Array().Length().IsEqual(2)
+func (j *JSONAPI) Data() *Value {
+	opChain := j.chain.enter("Data()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	value, ok := j.getMember(opChain, "data")
+	if !ok {
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, value)
+}
+
+// Included returns a new Array instance with included resources
+// of compound document.
+//
+// If document has no included resources, failure is reported.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Included().Length().IsEqual(3)
+func (j *JSONAPI) Included() *Array {
+	opChain := j.chain.enter("Included()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	value, ok := j.getMember(opChain, "included")
+	if !ok {
+		return newArray(opChain, nil)
+	}
+
+	return j.toArray(opChain, "included", value)
+}
+
+// Resource returns a new Object instance with resource object with given
+// type and id.
+//
+// Resource is searched in primary data and then in included resources.
+// If there is no such resource, failure is reported.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Resource("people", "9").
+//		Path("$.attributes.name").String().IsEqual("Dan")
+func (j *JSONAPI) Resource(typ, id string) *Object {
+	opChain := j.chain.enter("Resource(%q, %q)", typ, id)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	var candidates []interface{}
+
+	switch data := j.value["data"].(type) {
+	case map[string]interface{}:
+		candidates = append(candidates, data)
+	case []interface{}:
+		candidates = append(candidates, data...)
+	}
+
+	if included, ok := j.value["included"].([]interface{}); ok {
+		candidates = append(candidates, included...)
+	}
+
+	identifiers := make([]interface{}, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		resource, ok := candidate.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if resource["type"] == typ && resource["id"] == id {
+			return newObject(opChain, resource)
+		}
+		identifiers = append(identifiers, map[string]interface{}{
+			"type": resource["type"],
+			"id":   resource["id"],
+		})
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertContainsElement,
+		Actual: &AssertionValue{identifiers},
+		Expected: &AssertionValue{map[string]interface{}{
+			"type": typ,
+			"id":   id,
+		}},
+		Errors: []error{
+			errors.New("expected: document contains resource with given type and id"),
+		},
+	})
+
+	return newObject(opChain, nil)
+}
+
+// Relationships returns a new Object instance with relationships of
+// primary resource.
+//
+// If primary data is not a single resource object, or it has no
+// relationships, failure is reported. For collections, use Resource
+// to select resource first.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Relationships().
+//		Path("$.author.data.id").String().IsEqual("9")
+func (j *JSONAPI) Relationships() *Object {
+	opChain := j.chain.enter("Relationships()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	value, ok := j.getMember(opChain, "data")
+	if !ok {
+		return newObject(opChain, nil)
+	}
+
+	resource, ok := value.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: primary data is single resource object"),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	relationships, ok := resource["relationships"]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{resource},
+			Expected: &AssertionValue{"relationships"},
+			Errors: []error{
+				errors.New("expected: primary resource has relationships"),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	return j.toObject(opChain, "relationships", relationships)
+}
+
+// Links returns a new Object instance with top-level links of the document.
+//
+// If document has no links, failure is reported.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Links().Value("next").String().IsURL()
+func (j *JSONAPI) Links() *Object {
+	opChain := j.chain.enter("Links()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	value, ok := j.getMember(opChain, "links")
+	if !ok {
+		return newObject(opChain, nil)
+	}
+
+	return j.toObject(opChain, "links", value)
+}
+
+// Meta returns a new Object instance with top-level meta of the document.
+//
+// If document has no meta, failure is reported.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Meta().Value("total").Number().IsEqual(42)
+func (j *JSONAPI) Meta() *Object {
+	opChain := j.chain.enter("Meta()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	value, ok := j.getMember(opChain, "meta")
+	if !ok {
+		return newObject(opChain, nil)
+	}
+
+	return j.toObject(opChain, "meta", value)
+}
+
+// Errors returns a new Array instance with error objects of the document.
+//
+// If document has no errors, failure is reported.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Errors().Value(0).Object().HasValue("status", "422")
+func (j *JSONAPI) Errors() *Array {
+	opChain := j.chain.enter("Errors()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	value, ok := j.getMember(opChain, "errors")
+	if !ok {
+		return newArray(opChain, nil)
+	}
+
+	return j.toArray(opChain, "errors", value)
+}
+
+func (j *JSONAPI) getMember(opChain *chain, key string) (interface{}, bool) {
+	value, ok := j.value[key]
+
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{j.value},
+			Expected: &AssertionValue{key},
+			Errors: []error{
+				fmt.Errorf("expected: document contains %q member", key),
+			},
+		})
+		return nil, false
+	}
+
+	return value, true
+}
+
+func (j *JSONAPI) toObject(opChain *chain, key string, value interface{}) *Object {
+	object, ok := value.(map[string]interface{})
+
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: %q member is object", key),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, object)
+}
+
+func (j *JSONAPI) toArray(opChain *chain, key string, value interface{}) *Array {
+	array, ok := value.([]interface{})
+
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: %q member is array", key),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	return newArray(opChain, array)
+}
@@ -0,0 +1,206 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONAPI_FailedChain(t *testing.T) {
+	chain := newMockChain(t, flagFailed)
+
+	value := newJSONAPI(chain, map[string]interface{}{"data": nil})
+	value.chain.assert(t, failure)
+
+	value.Alias("foo")
+	value.Data().chain.assert(t, failure)
+	value.Included().chain.assert(t, failure)
+	value.Resource("", "").chain.assert(t, failure)
+	value.Relationships().chain.assert(t, failure)
+	value.Links().chain.assert(t, failure)
+	value.Meta().chain.assert(t, failure)
+	value.Errors().chain.assert(t, failure)
+}
+
+func TestJSONAPI_Constructors(t *testing.T) {
+	doc := map[string]interface{}{
+		"data": nil,
+	}
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewJSONAPI(reporter, doc)
+		assert.Equal(t, doc, value.Raw())
+		value.chain.assert(t, success)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewJSONAPIC(Config{
+			Reporter: reporter,
+		}, doc)
+		assert.Equal(t, doc, value.Raw())
+		value.chain.assert(t, success)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newJSONAPI(chain, doc)
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestJSONAPI_Validation(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  interface{}
+		result chainResult
+	}{
+		{"nil", nil, failure},
+		{"not object", []interface{}{}, failure},
+		{"empty object", map[string]interface{}{}, failure},
+		{"unknown members only", map[string]interface{}{"foo": 1}, failure},
+		{"null data", map[string]interface{}{"data": nil}, success},
+		{"errors", map[string]interface{}{"errors": []interface{}{}}, success},
+		{"meta", map[string]interface{}{"meta": map[string]interface{}{}}, success},
+		{
+			"data and errors",
+			map[string]interface{}{"data": nil, "errors": []interface{}{}},
+			failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewJSONAPI(reporter, tc.value).chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestJSONAPI_Single(t *testing.T) {
+	doc := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "articles",
+			"id":   "1",
+			"attributes": map[string]interface{}{
+				"title": "Hello",
+			},
+			"relationships": map[string]interface{}{
+				"author": map[string]interface{}{
+					"data": map[string]interface{}{"type": "people", "id": "9"},
+				},
+			},
+		},
+		"included": []interface{}{
+			map[string]interface{}{
+				"type": "people",
+				"id":   "9",
+				"attributes": map[string]interface{}{
+					"name": "Dan",
+				},
+			},
+		},
+		"links": map[string]interface{}{
+			"self": "https://example.com/articles/1",
+		},
+	}
+
+	t.Run("accessors", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJSONAPI(reporter, doc)
+
+		value.Data().Object().HasValue("id", "1")
+		value.Included().Length().IsEqual(1)
+		value.Relationships().Path("$.author.data.id").String().IsEqual("9")
+		value.Links().HasValue("self", "https://example.com/articles/1")
+
+		value.chain.assert(t, success)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJSONAPI(reporter, doc)
+
+		value.Resource("articles", "1").
+			Path("$.attributes.title").String().IsEqual("Hello")
+		value.Resource("people", "9").
+			Path("$.attributes.name").String().IsEqual("Dan")
+
+		value.chain.assert(t, success)
+
+		value.Resource("people", "1").chain.assert(t, failure)
+		value.Resource("articles", "9").chain.assert(t, failure)
+	})
+
+	t.Run("missing members", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJSONAPI(reporter, doc)
+
+		value.Meta().chain.assert(t, failure)
+		value.Errors().chain.assert(t, failure)
+	})
+}
+
+func TestJSONAPI_Collection(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewJSONAPI(reporter, map[string]interface{}{
+		"data": []interface{}{
+			map[string]interface{}{"type": "articles", "id": "1"},
+			map[string]interface{}{"type": "articles", "id": "2"},
+		},
+		"meta": map[string]interface{}{
+			"total": 2,
+		},
+	})
+
+	value.Data().Array().Length().IsEqual(2)
+	value.Resource("articles", "2").chain.assert(t, success)
+	value.Meta().HasValue("total", 2)
+	value.chain.assert(t, success)
+
+	value.Included().chain.assert(t, failure)
+	value.Relationships().chain.assert(t, failure)
+}
+
+func TestJSONAPI_Errors(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewJSONAPI(reporter, map[string]interface{}{
+		"errors": []interface{}{
+			map[string]interface{}{
+				"status": "422",
+				"source": map[string]interface{}{"pointer": "/data/attributes/title"},
+			},
+		},
+	})
+
+	value.Errors().Value(0).Object().HasValue("status", "422")
+	value.chain.assert(t, success)
+
+	value.Data().chain.assert(t, failure)
+	value.Resource("articles", "1").chain.assert(t, failure)
+}
+
+func TestJSONAPI_InvalidMembers(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewJSONAPI(reporter, map[string]interface{}{
+		"data":     "foo",
+		"included": map[string]interface{}{},
+		"links":    []interface{}{},
+		"meta":     "bar",
+	})
+	value.chain.assert(t, success)
+
+	value.Included().chain.assert(t, failure)
+	value.Links().chain.assert(t, failure)
+	value.Meta().chain.assert(t, failure)
+	value.Relationships().chain.assert(t, failure)
+}
@@ -0,0 +1,232 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Tracks resources created by Expect instance and its copies, to be
+// released by Expect.Close.
+type lifecycle struct {
+	mu sync.Mutex
+
+	closed bool
+
+	// number of requests created but not sent yet
+	pendingRequests int
+
+	// websocket connections that were not disconnected yet
+	websockets map[io.Closer]struct{}
+
+	// transports derived for WithResolveTo and protocol selection
+	transportCache *transportCache
+}
+
+func newLifecycle() *lifecycle {
+	return &lifecycle{
+		websockets:     make(map[io.Closer]struct{}),
+		transportCache: newTransportCache(),
+	}
+}
+
+func (lc *lifecycle) requestCreated() {
+	if lc == nil {
+		return
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.pendingRequests++
+}
+
+func (lc *lifecycle) requestSent() {
+	if lc == nil {
+		return
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if lc.pendingRequests > 0 {
+		lc.pendingRequests--
+	}
+}
+
+// Returns cache of transports derived for WithResolveTo and protocol selection,
+// or nil if requests are not owned by Expect instance.
+func (lc *lifecycle) transports() *transportCache {
+	if lc == nil {
+		return nil
+	}
+
+	return lc.transportCache
+}
+
+func (lc *lifecycle) websocketOpened(conn io.Closer) {
+	if lc == nil || conn == nil {
+		return
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.websockets[conn] = struct{}{}
+}
+
+func (lc *lifecycle) websocketClosed(conn io.Closer) {
+	if lc == nil || conn == nil {
+		return
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	delete(lc.websockets, conn)
+}
+
+// Mark as closed and return resources that were not released.
+// Returns false if already closed.
+func (lc *lifecycle) close() (int, []io.Closer, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if lc.closed {
+		return 0, nil, false
+	}
+
+	lc.closed = true
+
+	websockets := make([]io.Closer, 0, len(lc.websockets))
+	for conn := range lc.websockets {
+		websockets = append(websockets, conn)
+	}
+	lc.websockets = make(map[io.Closer]struct{})
+
+	return lc.pendingRequests, websockets, true
+}
+
+// Close releases resources associated with Expect instance and all its
+// copies (see Builder and Matcher).
+//
+// Close performs the following steps:
+//   - disconnects websockets that were not disconnected by test
+//   - invokes Config.OnClose hooks
+//   - flushes printers and assertion handler (e.g. JUnitAssertionHandler):
+//     if printer or handler has Close() error method, it's invoked;
+//     otherwise, if it has Flush() error method, it's invoked
+//   - closes idle connections of Config.Client, if it has
+//     CloseIdleConnections() method (like *http.Client)
+//   - closes idle connections of transports derived for WithResolveTo,
+//     WithHTTP2, and DifferentialProtocols
+//
+// If Config.StrictClose is true, Close also reports failure if there were
+// websockets that were not disconnected, or requests that were created
+// but never sent.
+//
+// Errors are reported as failures. Subsequent calls are no-op.
+//
+// Example:
+//
+//	func TestSomething(t *testing.T) {
+//		e := httpexpect.WithConfig(httpexpect.Config{
+//			BaseURL:     "http://example.com",
+//			Reporter:    httpexpect.NewAssertReporter(t),
+//			StrictClose: true,
+//		})
+//		t.Cleanup(e.Close)
+//
+//		e.GET("/path").
+//			Expect().
+//			Status(http.StatusOK)
+//	}
+func (e *Expect) Close() {
+	lc := e.config.lifecycle
+	if lc == nil {
+		// Expect was not created using WithConfig or Default
+		lc = newLifecycle()
+		e.config.lifecycle = lc
+	}
+
+	pendingRequests, websockets, ok := lc.close()
+	if !ok {
+		return
+	}
+
+	opChain := e.chain.enter("Close()")
+
+	var errs []error
+
+	for _, conn := range websockets {
+		_ = conn.Close()
+	}
+
+	if e.config.StrictClose {
+		if len(websockets) != 0 {
+			errs = append(errs, fmt.Errorf(
+				"%d websocket(s) were not disconnected before Close()",
+				len(websockets)))
+		}
+
+		if pendingRequests != 0 {
+			errs = append(errs, fmt.Errorf(
+				"%d request(s) were created but not sent before Close()",
+				pendingRequests))
+		}
+	}
+
+	for _, hook := range e.config.OnClose {
+		if err := hook(); err != nil {
+			errs = append(errs, fmt.Errorf("close hook failed: %w", err))
+		}
+	}
+
+	for _, printer := range e.config.Printers {
+		if err := flushOnClose(printer); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush printer: %w", err))
+		}
+	}
+
+	if len(errs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: append([]error{
+				errors.New("expected: resources are released without errors"),
+			}, errs...),
+		})
+	}
+
+	opChain.leave()
+
+	// flush handler after leave(), so that it receives failures reported above
+	if err := flushOnClose(e.config.AssertionHandler); err != nil {
+		opChain := e.chain.enter("Close()")
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("expected: resources are released without errors"),
+				fmt.Errorf("failed to flush assertion handler: %w", err),
+			},
+		})
+		opChain.leave()
+	}
+
+	if client, ok := e.config.Client.(interface{ CloseIdleConnections() }); ok {
+		client.CloseIdleConnections()
+	}
+
+	lc.transportCache.close()
+}
+
+func flushOnClose(obj interface{}) error {
+	switch v := obj.(type) {
+	case io.Closer:
+		return v.Close()
+	case interface{ Flush() error }:
+		return v.Flush()
+	}
+
+	return nil
+}
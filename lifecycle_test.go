@@ -0,0 +1,261 @@
+package httpexpect
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockCloser struct {
+	closeCalled int
+	err         error
+}
+
+func (mc *mockCloser) Close() error {
+	mc.closeCalled++
+	return mc.err
+}
+
+type mockFlushPrinter struct {
+	flushCalled int
+	err         error
+}
+
+func (*mockFlushPrinter) Request(*http.Request) {}
+
+func (*mockFlushPrinter) Response(*http.Response, time.Duration) {}
+
+func (mp *mockFlushPrinter) Flush() error {
+	mp.flushCalled++
+	return mp.err
+}
+
+type mockClosingHandler struct {
+	mockAssertionHandler
+	closeCalled     int
+	failuresOnClose int
+}
+
+func (mh *mockClosingHandler) Close() error {
+	mh.closeCalled++
+	mh.failuresOnClose = mh.failureCalled
+	return nil
+}
+
+type mockIdleClient struct {
+	mockClient
+	closeIdleCalled int
+}
+
+func (mc *mockIdleClient) CloseIdleConnections() {
+	mc.closeIdleCalled++
+}
+
+func TestLifecycle_Close(t *testing.T) {
+	t.Run("resources", func(t *testing.T) {
+		printer := &mockFlushPrinter{}
+		handler := &mockClosingHandler{}
+		client := &mockIdleClient{}
+
+		var hooks []int
+
+		e := WithConfig(Config{
+			Client:           client,
+			Printers:         []Printer{printer},
+			AssertionHandler: handler,
+			OnClose: []func() error{
+				func() error {
+					hooks = append(hooks, 1)
+					return nil
+				},
+				func() error {
+					hooks = append(hooks, 2)
+					return nil
+				},
+			},
+		})
+
+		e.Close()
+
+		assert.Equal(t, []int{1, 2}, hooks)
+		assert.Equal(t, 1, printer.flushCalled)
+		assert.Equal(t, 1, handler.closeCalled)
+		assert.Equal(t, 1, client.closeIdleCalled)
+		assert.Equal(t, 0, handler.failureCalled)
+
+		// second call is no-op
+		e.Close()
+
+		assert.Equal(t, []int{1, 2}, hooks)
+		assert.Equal(t, 1, printer.flushCalled)
+		assert.Equal(t, 1, handler.closeCalled)
+		assert.Equal(t, 1, client.closeIdleCalled)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			AssertionHandler: handler,
+			Printers: []Printer{
+				&mockFlushPrinter{err: errors.New("flush error")},
+			},
+			OnClose: []func() error{
+				func() error {
+					return errors.New("hook error")
+				},
+			},
+		})
+
+		e.Close()
+
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Equal(t, 3, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[1].Error(), "hook error")
+		assert.Contains(t, handler.failure.Errors[2].Error(), "flush error")
+	})
+
+	t.Run("handler order", func(t *testing.T) {
+		handler := &mockClosingHandler{}
+
+		e := WithConfig(Config{
+			AssertionHandler: handler,
+			OnClose: []func() error{
+				func() error {
+					// handler must not be closed yet
+					assert.Equal(t, 0, handler.closeCalled)
+					return errors.New("hook error")
+				},
+			},
+		})
+
+		e.Close()
+
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Equal(t, 1, handler.closeCalled)
+		assert.Equal(t, 1, handler.failuresOnClose)
+	})
+
+	t.Run("websockets", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			AssertionHandler: handler,
+		})
+
+		conn1 := &mockCloser{}
+		conn2 := &mockCloser{}
+
+		e.config.lifecycle.websocketOpened(conn1)
+		e.config.lifecycle.websocketOpened(conn2)
+		e.config.lifecycle.websocketClosed(conn2)
+
+		e.Close()
+
+		// leaked connection is closed, but it's not a failure by default
+		assert.Equal(t, 1, conn1.closeCalled)
+		assert.Equal(t, 0, conn2.closeCalled)
+		assert.Equal(t, 0, handler.failureCalled)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+			AssertionHandler: handler,
+			StrictClose:      true,
+		})
+
+		e.GET("/sent").Expect()
+		e.Builder(func(*Request) {}).POST("/unsent")
+
+		// built, but not sent
+		_, err := e.GET("/built").Build()
+		assert.NoError(t, err)
+
+		conn := &mockCloser{}
+		e.config.lifecycle.websocketOpened(conn)
+
+		e.Close()
+
+		assert.Equal(t, 1, conn.closeCalled)
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Equal(t, 3, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[1].Error(), "1 websocket(s)")
+		assert.Contains(t, handler.failure.Errors[2].Error(), "2 request(s)")
+	})
+
+	t.Run("strict success", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+			AssertionHandler: handler,
+			StrictClose:      true,
+		})
+
+		e.GET("/path").Expect()
+		e.GET("/path").Repeat(2, 1)
+
+		e.Close()
+
+		assert.Equal(t, 0, handler.failureCalled)
+	})
+
+	t.Run("resolved transports", func(t *testing.T) {
+		var closed int32
+
+		server := httptest.NewUnstartedServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {}))
+		server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateClosed {
+				atomic.AddInt32(&closed, 1)
+			}
+		}
+		server.Start()
+		defer server.Close()
+
+		baseTransport := &http.Transport{}
+		defer baseTransport.CloseIdleConnections()
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   &http.Client{Transport: baseTransport},
+			Reporter: newMockReporter(t),
+		})
+
+		e.GET("/path").
+			WithResolveTo(server.Listener.Addr().String()).
+			Expect().
+			Status(http.StatusOK)
+
+		cache := e.config.lifecycle.transportCache
+		assert.Equal(t, 1, len(cache.transports))
+		assert.Equal(t, int32(0), atomic.LoadInt32(&closed))
+
+		e.Close()
+
+		assert.Equal(t, 0, len(cache.transports))
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&closed) == 1
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("standalone request", func(t *testing.T) {
+		// requests not created via Expect are not tracked
+		NewRequestC(newMockConfig(newMockReporter(t)), "GET", "/path")
+	})
+}
@@ -0,0 +1,322 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Single link parsed from "Link" header.
+type headerLink struct {
+	target string
+	rels   []string
+}
+
+// Parse "Link" header values, as defined in RFC 8288, e.g.:
+//
+//	<https://example.com/items?page=2>; rel="next", </items?page=9>; rel=last
+//
+// Relation types are converted to lower case. Links without "rel" parameter
+// are skipped.
+func parseLinkHeader(values []string) ([]headerLink, error) {
+	var links []headerLink
+
+	for _, value := range values {
+		s := value
+
+		for {
+			s = strings.TrimLeft(s, " \t,")
+			if s == "" {
+				break
+			}
+
+			if s[0] != '<' {
+				return nil, fmt.Errorf("expected '<' at %q", s)
+			}
+
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '<' at %q", s)
+			}
+
+			link := headerLink{
+				target: strings.TrimSpace(s[1:end]),
+			}
+
+			s = s[end+1:]
+
+			var err error
+			if s, err = parseLinkParams(s, &link); err != nil {
+				return nil, err
+			}
+
+			if len(link.rels) != 0 {
+				links = append(links, link)
+			}
+		}
+	}
+
+	return links, nil
+}
+
+func parseLinkParams(s string, link *headerLink) (string, error) {
+	for {
+		s = strings.TrimLeft(s, " \t")
+
+		if s == "" || s[0] == ',' {
+			return s, nil
+		}
+
+		if s[0] != ';' {
+			return "", fmt.Errorf("expected ';' or ',' at %q", s)
+		}
+
+		s = strings.TrimLeft(s[1:], " \t")
+
+		end := strings.IndexAny(s, "=;,")
+		if end < 0 {
+			end = len(s)
+		}
+
+		name := strings.ToLower(strings.TrimSpace(s[:end]))
+		if name == "" {
+			return "", fmt.Errorf("empty parameter name at %q", s)
+		}
+
+		s = s[end:]
+
+		var value string
+
+		if strings.HasPrefix(s, "=") {
+			s = strings.TrimLeft(s[1:], " \t")
+
+			if strings.HasPrefix(s, `"`) {
+				var err error
+				if value, s, err = parseLinkQuoted(s); err != nil {
+					return "", err
+				}
+			} else {
+				end := strings.IndexAny(s, ";,")
+				if end < 0 {
+					end = len(s)
+				}
+				value = strings.TrimSpace(s[:end])
+				s = s[end:]
+			}
+		}
+
+		// only first occurrence of rel is used
+		if name == "rel" && link.rels == nil {
+			link.rels = strings.Fields(strings.ToLower(value))
+		}
+	}
+}
+
+func parseLinkQuoted(s string) (string, string, error) {
+	var b strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), s[i+1:], nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return "", "", fmt.Errorf("unclosed quoted string at %q", s)
+}
+
+// Links returns a new Object instance with links from "Link" headers,
+// as defined in RFC 8288.
+//
+// Object keys are relation types, e.g. "next" or "last", and values are
+// link URLs. Relative URLs are resolved against request URL. If there are
+// multiple links with the same relation type, the first one is used.
+// If response has no "Link" header, empty object is returned.
+//
+// Example:
+//
+//	// Link: </users?page=3>; rel="next", </users?page=9>; rel="last"
+//	resp := e.GET("/users").WithQuery("page", 2).Expect()
+//
+//	resp.Links().HasValue("next", "http://example.com/users?page=3")
+//	resp.Links().NotContainsKey("prev")
+func (r *Response) Links() *Object {
+	opChain := r.chain.enter("Links()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	links, ok := r.getLinks(opChain)
+	if !ok {
+		return newObject(opChain, nil)
+	}
+
+	object := make(map[string]interface{}, len(links))
+	for rel, target := range links {
+		object[rel] = target
+	}
+
+	return newObject(opChain, object)
+}
+
+// Follow returns a new GET Request for link with given relation type from
+// "Link" header. See Links for details on how links are parsed.
+//
+// If response was received from request created by Expect instance, new
+// request is created by the same instance, with the same config, builders,
+// and matchers. If there is no such link, failure is reported.
+//
+// Example:
+//
+//	resp := e.GET("/users").Expect().Status(http.StatusOK)
+//
+//	for resp.Links().Raw()["next"] != nil {
+//		resp = resp.Follow("next").Expect().Status(http.StatusOK)
+//	}
+func (r *Response) Follow(rel string) *Request {
+	opChain := r.chain.enter("Follow(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r.newFollowRequest(opChain, nil)
+	}
+
+	links, ok := r.getLinks(opChain)
+	if !ok {
+		return r.newFollowRequest(opChain, nil)
+	}
+
+	target, ok := links[strings.ToLower(rel)]
+	if !ok {
+		rels := make([]interface{}, 0, len(links))
+		for rel := range links {
+			rels = append(rels, rel)
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:     AssertBelongs,
+			Actual:   &AssertionValue{rel},
+			Expected: &AssertionValue{AssertionList(rels)},
+			Errors: []error{
+				errors.New("expected: response has link with given relation type"),
+			},
+		})
+		return r.newFollowRequest(opChain, nil)
+	}
+
+	u, _ := url.Parse(target)
+
+	return r.newFollowRequest(opChain, u)
+}
+
+func (r *Response) newFollowRequest(opChain *chain, u *url.URL) *Request {
+	// absolute link URL replaces base URL; relative link (possible only if
+	// request URL is unknown) is appended to base URL as path
+	path := ""
+	if u != nil && !u.IsAbs() {
+		path = u.Path
+	}
+
+	// new request replaces this one in context of reported failures
+	opChain.clearRequest()
+
+	var req *Request
+
+	if r.owner != nil {
+		req = r.owner.newRequest(opChain, http.MethodGet, path)
+	} else {
+		req = newRequest(opChain, r.config, http.MethodGet, path)
+	}
+
+	if u == nil {
+		return req
+	}
+
+	if u.IsAbs() {
+		urlCopy := *u
+		urlCopy.RawQuery = ""
+		req.httpReq.URL = &urlCopy
+	}
+
+	// link URL already includes base path, if any
+	req.basePath = ""
+
+	// link query parameters are merged with ones added by builders
+	if query := u.Query(); len(query) != 0 {
+		if req.query == nil {
+			req.query = make(url.Values)
+		}
+		for k, v := range query {
+			req.query[k] = append(v, req.query[k]...)
+		}
+	}
+
+	return req
+}
+
+// Returns map of relation types to resolved link URLs.
+func (r *Response) getLinks(opChain *chain) (map[string]string, bool) {
+	values := r.httpResp.Header.Values("Link")
+
+	parsed, err := parseLinkHeader(values)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{values},
+			Errors: []error{
+				errors.New("expected: valid \"Link\" header"),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	var base *url.URL
+	if r.httpResp.Request != nil {
+		base = r.httpResp.Request.URL
+	}
+
+	links := make(map[string]string)
+
+	for _, link := range parsed {
+		var (
+			target *url.URL
+			err    error
+		)
+		if base != nil {
+			target, err = base.Parse(link.target)
+		} else {
+			target, err = url.Parse(link.target)
+		}
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{link.target},
+				Errors: []error{
+					errors.New("expected: valid url in \"Link\" header"),
+					err,
+				},
+			})
+			return nil, false
+		}
+
+		for _, rel := range link.rels {
+			if _, ok := links[rel]; !ok {
+				links[rel] = target.String()
+			}
+		}
+	}
+
+	return links, true
+}
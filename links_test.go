@@ -0,0 +1,235 @@
+package httpexpect
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinks_Parse(t *testing.T) {
+	cases := []struct {
+		name    string
+		values  []string
+		links   []headerLink
+		wantErr bool
+	}{
+		{
+			name:   "empty",
+			values: nil,
+			links:  nil,
+		},
+		{
+			name:   "single",
+			values: []string{`<https://example.com/a>; rel="next"`},
+			links: []headerLink{
+				{target: "https://example.com/a", rels: []string{"next"}},
+			},
+		},
+		{
+			name: "multiple links and values",
+			values: []string{
+				`</a>; rel="next", </b>; rel=last`,
+				`</c>;rel=prev`,
+			},
+			links: []headerLink{
+				{target: "/a", rels: []string{"next"}},
+				{target: "/b", rels: []string{"last"}},
+				{target: "/c", rels: []string{"prev"}},
+			},
+		},
+		{
+			name:   "multiple rels and case",
+			values: []string{`</a>; REL="Next Last"`},
+			links: []headerLink{
+				{target: "/a", rels: []string{"next", "last"}},
+			},
+		},
+		{
+			name:   "other params",
+			values: []string{`</a>; title="a, \"b\"; c"; rel="next"; hreflang=en`},
+			links: []headerLink{
+				{target: "/a", rels: []string{"next"}},
+			},
+		},
+		{
+			name:   "first rel wins",
+			values: []string{`</a>; rel=next; rel=prev`},
+			links: []headerLink{
+				{target: "/a", rels: []string{"next"}},
+			},
+		},
+		{
+			name:   "no rel",
+			values: []string{`</a>; title="x", </b>; rel=next`},
+			links: []headerLink{
+				{target: "/b", rels: []string{"next"}},
+			},
+		},
+		{
+			name:   "comma in url",
+			values: []string{`</a?x=1,2>; rel=next`},
+			links: []headerLink{
+				{target: "/a?x=1,2", rels: []string{"next"}},
+			},
+		},
+		{
+			name:    "no brackets",
+			values:  []string{`/a; rel=next`},
+			wantErr: true,
+		},
+		{
+			name:    "unclosed bracket",
+			values:  []string{`</a; rel=next`},
+			wantErr: true,
+		},
+		{
+			name:    "unclosed quote",
+			values:  []string{`</a>; rel="next`},
+			wantErr: true,
+		},
+		{
+			name:    "garbage after url",
+			values:  []string{`</a> rel=next`},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			links, err := parseLinkHeader(tc.values)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.links, links)
+		})
+	}
+}
+
+func TestLinks_Response(t *testing.T) {
+	newResp := func(t *testing.T, link ...string) *Response {
+		reqURL, _ := url.Parse("http://example.com/api/users?page=2")
+
+		return NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Link": link},
+			Request:    &http.Request{Method: http.MethodGet, URL: reqURL},
+		})
+	}
+
+	t.Run("links", func(t *testing.T) {
+		resp := newResp(t,
+			`<http://other.com/users?page=3>; rel="next"`,
+			`</api/users?page=1>; rel="prev first", <?page=9>; rel=last`)
+
+		resp.Links().IsEqual(map[string]interface{}{
+			"next":  "http://other.com/users?page=3",
+			"prev":  "http://example.com/api/users?page=1",
+			"first": "http://example.com/api/users?page=1",
+			"last":  "http://example.com/api/users?page=9",
+		})
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("no header", func(t *testing.T) {
+		resp := newResp(t)
+
+		resp.Links().IsEmpty()
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		resp := newResp(t, `garbage`)
+
+		resp.Links().chain.assert(t, failure)
+		assert.True(t, resp.chain.treeFailed())
+	})
+
+	t.Run("follow missing", func(t *testing.T) {
+		resp := newResp(t, `</a>; rel=prev`)
+
+		resp.Follow("next").chain.assert(t, failure)
+		assert.True(t, resp.chain.treeFailed())
+	})
+}
+
+func TestLinks_Follow(t *testing.T) {
+	const pages = 3
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+
+		if page < pages {
+			w.Header().Add("Link",
+				fmt.Sprintf(`</api/items?page=%d&size=10>; rel="next"`, page+1))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"page": %d, "token": %q}`,
+			page, r.Header.Get("X-Token"))
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			BasePath: "/api",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		}).Builder(func(req *Request) {
+			req.WithHeader("X-Token", "secret")
+		})
+
+		resp := e.GET("/items").Expect().Status(http.StatusOK)
+
+		var visited []float64
+		for {
+			obj := resp.JSON().Object()
+			obj.HasValue("token", "secret")
+			visited = append(visited, obj.Value("page").Number().Raw())
+
+			if _, ok := resp.Links().Raw()["next"]; !ok {
+				break
+			}
+			resp = resp.Follow("next").Expect().Status(http.StatusOK)
+		}
+
+		assert.Equal(t, []float64{1, 2, 3}, visited)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("builder query", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		}).Builder(func(req *Request) {
+			req.WithQuery("lang", "en")
+		})
+
+		req := e.GET("/api/items").Expect().Follow("next")
+
+		req.ExpectURL().Query("page").IsEqual("2")
+		req.ExpectURL().Query("size").IsEqual("10")
+		req.ExpectURL().Query("lang").IsEqual("en")
+		req.ExpectURL().Path().IsEqual("/api/items")
+
+		assert.False(t, reporter.reported)
+	})
+}
@@ -0,0 +1,199 @@
+package httpexpect
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StructuredLogger writes log records consisting of message and
+// alternating keys and values.
+//
+// Its method has the same signature as Info method of slog.Logger and
+// Infow method of zap.SugaredLogger, so that they can be used via
+// StructuredLoggerFunc.
+type StructuredLogger interface {
+	Log(msg string, keysAndValues ...interface{})
+}
+
+// StructuredLoggerFunc is an adapter that allows a function to be used
+// as the StructuredLogger.
+//
+// Example:
+//
+//	// log/slog
+//	logger := httpexpect.StructuredLoggerFunc(slog.Default().Info)
+//
+//	// go.uber.org/zap
+//	logger := httpexpect.StructuredLoggerFunc(zapLogger.Sugar().Infow)
+//
+//	// github.com/sirupsen/logrus
+//	logger := httpexpect.StructuredLoggerFunc(
+//		func(msg string, keysAndValues ...interface{}) {
+//			logrus.WithFields(httpexpect.KeyValuesToMap(keysAndValues...)).Info(msg)
+//		})
+type StructuredLoggerFunc func(msg string, keysAndValues ...interface{})
+
+// Log implements StructuredLogger.Log.
+func (f StructuredLoggerFunc) Log(msg string, keysAndValues ...interface{}) {
+	f(msg, keysAndValues...)
+}
+
+// FieldLogger is a Logger that can also write structured fields.
+//
+// Printers (CompactPrinter, DebugPrinter, CurlPrinter) and
+// DefaultAssertionHandler check whether their Logger implements
+// FieldLogger, and if so, use LogFields to attach fields like method,
+// url, status, and rtt to printed messages.
+//
+// LoggerAdapter implements this interface.
+type FieldLogger interface {
+	Logger
+
+	// LogFields writes message with alternating keys and values.
+	LogFields(msg string, keysAndValues ...interface{})
+}
+
+// LoggerAdapter implements Logger and FieldLogger on top of
+// StructuredLogger.
+//
+// It allows to route printers output and assertion messages to
+// structured loggers like slog, zap, or logrus.
+//
+// Example:
+//
+//	logger := httpexpect.NewLoggerAdapter(
+//		httpexpect.StructuredLoggerFunc(slog.Default().Info))
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:  "http://example.com",
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Printers: []httpexpect.Printer{
+//			httpexpect.NewDebugPrinter(logger, true),
+//		},
+//		AssertionHandler: &httpexpect.DefaultAssertionHandler{
+//			Formatter: &httpexpect.DefaultFormatter{},
+//			Reporter:  httpexpect.NewAssertReporter(t),
+//			Logger:    logger,
+//		},
+//	})
+type LoggerAdapter struct {
+	logger StructuredLogger
+}
+
+// NewLoggerAdapter returns a new LoggerAdapter given a structured logger.
+//
+// If logger is nil, the function panics.
+func NewLoggerAdapter(logger StructuredLogger) *LoggerAdapter {
+	if logger == nil {
+		panic("logger is nil")
+	}
+
+	return &LoggerAdapter{logger: logger}
+}
+
+// Logf implements Logger.Logf.
+// Formatted message is written without fields.
+func (a *LoggerAdapter) Logf(format string, args ...interface{}) {
+	a.logger.Log(fmt.Sprintf(format, args...))
+}
+
+// LogFields implements FieldLogger.LogFields.
+func (a *LoggerAdapter) LogFields(msg string, keysAndValues ...interface{}) {
+	a.logger.Log(msg, keysAndValues...)
+}
+
+// KeyValuesToMap converts alternating keys and values into a map, e.g.
+// to pass them to logrus.WithFields.
+//
+// Keys that are not strings are formatted using "%v". If the number of
+// arguments is odd, last key gets nil value.
+func KeyValuesToMap(keysAndValues ...interface{}) map[string]interface{} {
+	fields := make(map[string]interface{}, (len(keysAndValues)+1)/2)
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+
+		var value interface{}
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+
+		fields[key] = value
+	}
+
+	return fields
+}
+
+// Write message to logger, with fields if logger implements FieldLogger.
+func logWithFields(
+	logger Logger, fields []interface{}, format string, args ...interface{},
+) {
+	if fieldLogger, ok := logger.(FieldLogger); ok {
+		fieldLogger.LogFields(fmt.Sprintf(format, args...), fields...)
+		return
+	}
+
+	logger.Logf(format, args...)
+}
+
+func requestLogFields(req *http.Request) []interface{} {
+	if req == nil {
+		return nil
+	}
+
+	fields := []interface{}{"method", req.Method}
+	if req.URL != nil {
+		fields = append(fields, "url", req.URL.String())
+	}
+
+	return fields
+}
+
+func responseLogFields(resp *http.Response, rtt time.Duration) []interface{} {
+	fields := requestLogFields(resp.Request)
+
+	return append(fields, "status", resp.StatusCode, "rtt", rtt)
+}
+
+func assertionLogFields(
+	ctx *AssertionContext, failure *AssertionFailure,
+) []interface{} {
+	var fields []interface{}
+
+	if ctx.TestName != "" {
+		fields = append(fields, "test", ctx.TestName)
+	}
+
+	if ctx.RequestName != "" {
+		fields = append(fields, "request", ctx.RequestName)
+	}
+
+	if ctx.RequestID != "" {
+		fields = append(fields, "request_id", ctx.RequestID)
+	}
+
+	fields = append(fields, "assertion", strings.Join(ctx.AliasedPath, "."))
+
+	if failure != nil {
+		fields = append(fields,
+			"type", failure.Type.String(),
+			"severity", failure.Severity.String())
+	}
+
+	if resp := ctx.Response; resp != nil && resp.httpResp != nil {
+		var rtt time.Duration
+		if resp.rtt != nil {
+			rtt = *resp.rtt
+		}
+		fields = append(fields, responseLogFields(resp.httpResp, rtt)...)
+	} else if ctx.Request != nil && ctx.Request.httpReq != nil {
+		fields = append(fields, requestLogFields(ctx.Request.httpReq)...)
+	}
+
+	return fields
+}
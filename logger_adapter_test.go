@@ -0,0 +1,265 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStructuredRecord struct {
+	msg    string
+	fields map[string]interface{}
+}
+
+type mockStructuredLogger struct {
+	records []mockStructuredRecord
+}
+
+func (l *mockStructuredLogger) Log(msg string, keysAndValues ...interface{}) {
+	l.records = append(l.records, mockStructuredRecord{
+		msg:    msg,
+		fields: KeyValuesToMap(keysAndValues...),
+	})
+}
+
+func TestLoggerAdapter_Basic(t *testing.T) {
+	t.Run("logf", func(t *testing.T) {
+		logger := &mockStructuredLogger{}
+		adapter := NewLoggerAdapter(logger)
+
+		adapter.Logf("hello %s", "world")
+
+		require.Equal(t, 1, len(logger.records))
+		assert.Equal(t, "hello world", logger.records[0].msg)
+		assert.Equal(t, map[string]interface{}{}, logger.records[0].fields)
+	})
+
+	t.Run("log fields", func(t *testing.T) {
+		logger := &mockStructuredLogger{}
+		adapter := NewLoggerAdapter(logger)
+
+		adapter.LogFields("hello", "foo", 1, "bar", "2")
+
+		require.Equal(t, 1, len(logger.records))
+		assert.Equal(t, "hello", logger.records[0].msg)
+		assert.Equal(t, map[string]interface{}{
+			"foo": 1,
+			"bar": "2",
+		}, logger.records[0].fields)
+	})
+
+	t.Run("func", func(t *testing.T) {
+		var gotMsg string
+		var gotFields []interface{}
+
+		adapter := NewLoggerAdapter(StructuredLoggerFunc(
+			func(msg string, keysAndValues ...interface{}) {
+				gotMsg = msg
+				gotFields = keysAndValues
+			}))
+
+		adapter.LogFields("hello", "foo", 1)
+
+		assert.Equal(t, "hello", gotMsg)
+		assert.Equal(t, []interface{}{"foo", 1}, gotFields)
+	})
+
+	t.Run("nil logger", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewLoggerAdapter(nil)
+		})
+	})
+}
+
+func TestLoggerAdapter_KeyValuesToMap(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    []interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "empty",
+			input:    nil,
+			expected: map[string]interface{}{},
+		},
+		{
+			name:  "pairs",
+			input: []interface{}{"a", 1, "b", true},
+			expected: map[string]interface{}{
+				"a": 1,
+				"b": true,
+			},
+		},
+		{
+			name:  "odd",
+			input: []interface{}{"a", 1, "b"},
+			expected: map[string]interface{}{
+				"a": 1,
+				"b": nil,
+			},
+		},
+		{
+			name:  "non-string key",
+			input: []interface{}{123, "x"},
+			expected: map[string]interface{}{
+				"123": "x",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, KeyValuesToMap(tc.input...))
+		})
+	}
+}
+
+func TestLoggerAdapter_Printers(t *testing.T) {
+	reqURL, _ := url.Parse("http://example.com/path")
+
+	req := &http.Request{
+		Method: "GET",
+		URL:    reqURL,
+		Header: http.Header{},
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Request:    req,
+	}
+
+	requestFields := map[string]interface{}{
+		"method": "GET",
+		"url":    "http://example.com/path",
+	}
+
+	responseFields := map[string]interface{}{
+		"method": "GET",
+		"url":    "http://example.com/path",
+		"status": http.StatusOK,
+		"rtt":    time.Second,
+	}
+
+	t.Run("compact", func(t *testing.T) {
+		logger := &mockStructuredLogger{}
+
+		printer := NewCompactPrinter(NewLoggerAdapter(logger))
+		printer.Request(req)
+
+		require.Equal(t, 1, len(logger.records))
+		assert.Equal(t, "GET http://example.com/path", logger.records[0].msg)
+		assert.Equal(t, requestFields, logger.records[0].fields)
+	})
+
+	t.Run("curl", func(t *testing.T) {
+		logger := &mockStructuredLogger{}
+
+		printer := NewCurlPrinter(NewLoggerAdapter(logger))
+		printer.Request(req)
+
+		require.Equal(t, 1, len(logger.records))
+		assert.True(t, strings.HasPrefix(logger.records[0].msg, "curl"))
+		assert.Equal(t, requestFields, logger.records[0].fields)
+	})
+
+	t.Run("debug", func(t *testing.T) {
+		logger := &mockStructuredLogger{}
+
+		printer := NewDebugPrinter(NewLoggerAdapter(logger), false)
+		printer.Request(req)
+		printer.Response(resp, time.Second)
+
+		require.Equal(t, 2, len(logger.records))
+
+		assert.Contains(t, logger.records[0].msg, "GET /path")
+		assert.Equal(t, requestFields, logger.records[0].fields)
+
+		assert.Contains(t, logger.records[1].msg, "200 OK")
+		assert.Equal(t, responseFields, logger.records[1].fields)
+	})
+
+	t.Run("plain logger", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		printer := NewCompactPrinter(logger)
+		printer.Request(req)
+
+		assert.Equal(t, "GET http://example.com/path", logger.lastMessage)
+	})
+}
+
+func TestLoggerAdapter_AssertionHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		logger := &mockStructuredLogger{}
+
+		handler := &DefaultAssertionHandler{
+			Formatter: &mockFormatter{},
+			Reporter:  newMockReporter(t),
+			Logger:    NewLoggerAdapter(logger),
+		}
+
+		handler.Success(&AssertionContext{
+			TestName:    "TestFoo",
+			RequestName: "login",
+			RequestID:   "123",
+			AliasedPath: []string{"foo", "IsEqual()"},
+		})
+
+		require.Equal(t, 1, len(logger.records))
+		assert.Equal(t, map[string]interface{}{
+			"test":       "TestFoo",
+			"request":    "login",
+			"request_id": "123",
+			"assertion":  "foo.IsEqual()",
+		}, logger.records[0].fields)
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		logger := &mockStructuredLogger{}
+
+		handler := &DefaultAssertionHandler{
+			Formatter: &mockFormatter{},
+			Reporter:  newMockReporter(t),
+			Logger:    NewLoggerAdapter(logger),
+		}
+
+		reqURL, _ := url.Parse("http://example.com/path")
+
+		rtt := time.Second
+		resp := &Response{
+			httpResp: &http.Response{
+				StatusCode: http.StatusNotFound,
+				Request: &http.Request{
+					Method: "POST",
+					URL:    reqURL,
+				},
+			},
+			rtt: &rtt,
+		}
+
+		handler.Failure(&AssertionContext{
+			AliasedPath: []string{"Expect()", "Status()"},
+			Response:    resp,
+		}, &AssertionFailure{
+			Type:     AssertEqual,
+			Severity: SeverityWarning,
+		})
+
+		require.Equal(t, 1, len(logger.records))
+		assert.Equal(t, map[string]interface{}{
+			"assertion": "Expect().Status()",
+			"type":      "AssertEqual",
+			"severity":  "SeverityWarning",
+			"method":    "POST",
+			"url":       "http://example.com/path",
+			"status":    http.StatusNotFound,
+			"rtt":       time.Second,
+		}, logger.records[0].fields)
+	})
+}
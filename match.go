@@ -1,8 +1,11 @@
 package httpexpect
 
 import (
+	"encoding"
 	"errors"
+	"fmt"
 	"reflect"
+	"strconv"
 )
 
 // Match provides methods to inspect attached regexp match results.
@@ -169,26 +172,127 @@ func (m *Match) NamedSubmatch(name string) *String {
 		return newString(opChain, "")
 	}
 
-	index, ok := m.submatchNames[name]
+	value, ok := m.lookupName(opChain, name)
 	if !ok {
-		nameList := make([]interface{}, 0, len(m.submatchNames))
-		for n := range m.submatchNames {
-			nameList = append(nameList, n)
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, value)
+}
+
+// NamedSubmatches returns a new Object instance with named submatches.
+//
+// Object keys are submatch names, and values are submatch strings.
+// Unnamed submatches are not included.
+//
+// Example:
+//
+//	s := "http://example.com/users/john"
+//
+//	r := regexp.MustCompile(`http://(?P<host>.+)/users/(?P<user>.+)`)
+//	m := NewMatch(t, r.FindStringSubmatch(s), r.SubexpNames())
+//
+//	m.NamedSubmatches().IsEqual(map[string]interface{}{
+//		"host": "example.com",
+//		"user": "john",
+//	})
+func (m *Match) NamedSubmatches() *Object {
+	opChain := m.chain.enter("NamedSubmatches()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	object := make(map[string]interface{}, len(m.submatchNames))
+
+	for name, index := range m.submatchNames {
+		if index < len(m.submatchValues) {
+			object[name] = m.submatchValues[index]
 		}
+	}
+
+	return newObject(opChain, object)
+}
+
+// Capture stores submatch with given name into a Go variable.
+//
+// target should be a non-nil pointer to string, bool, integer or float
+// type, interface{}, or type implementing encoding.TextUnmarshaler.
+// Submatch is parsed according to target type. If there is no submatch
+// with given name, or it can't be parsed, failure is reported and target
+// is not modified.
+//
+// Example:
+//
+//	s := "/users/42/orders/7f3a"
+//
+//	r := regexp.MustCompile(`/users/(?P<user>\d+)/orders/(?P<order>\w+)`)
+//	m := NewMatch(t, r.FindStringSubmatch(s), r.SubexpNames())
+//
+//	var (
+//		userID  int
+//		orderID string
+//	)
+//	m.Capture("user", &userID).Capture("order", &orderID)
+func (m *Match) Capture(name string, target interface{}) *Match {
+	opChain := m.chain.enter("Capture(%q)", name)
+	defer opChain.leave()
 
+	if opChain.failed() {
+		return m
+	}
+
+	targetPtr := reflect.ValueOf(target)
+
+	if target == nil || targetPtr.Kind() != reflect.Ptr || targetPtr.IsNil() {
 		opChain.fail(AssertionFailure{
-			Type:     AssertBelongs,
-			Actual:   &AssertionValue{name},
-			Expected: &AssertionValue{AssertionList(nameList)},
+			Type: AssertUsage,
 			Errors: []error{
-				errors.New("expected: existing sub-match name"),
+				fmt.Errorf(
+					"unexpected target argument: expected non-nil pointer, got %T",
+					target),
 			},
 		})
+		return m
+	}
 
-		return newString(opChain, "")
+	if !canParseSubmatch(targetPtr.Elem()) {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					"unexpected target argument: unsupported type %s",
+					targetPtr.Elem().Type()),
+			},
+		})
+		return m
 	}
 
-	return newString(opChain, m.submatchValues[index])
+	value, ok := m.lookupName(opChain, name)
+	if !ok {
+		return m
+	}
+
+	// parse into temporary variable to leave target untouched on failure
+	tmp := reflect.New(targetPtr.Elem().Type())
+
+	if err := parseSubmatch(value, tmp.Elem()); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: sub-match can be parsed as %s",
+					targetPtr.Elem().Type()),
+				err,
+			},
+		})
+		return m
+	}
+
+	targetPtr.Elem().Set(tmp.Elem())
+
+	return m
 }
 
 // Deprecated: use Submatch instead.
@@ -348,3 +452,93 @@ func (m *Match) getValues() []string {
 	}
 	return []string{}
 }
+
+func (m *Match) lookupName(opChain *chain, name string) (string, bool) {
+	index, ok := m.submatchNames[name]
+
+	if !ok || index >= len(m.submatchValues) {
+		nameList := make([]interface{}, 0, len(m.submatchNames))
+		for n := range m.submatchNames {
+			nameList = append(nameList, n)
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:     AssertBelongs,
+			Actual:   &AssertionValue{name},
+			Expected: &AssertionValue{AssertionList(nameList)},
+			Errors: []error{
+				errors.New("expected: existing sub-match name"),
+			},
+		})
+
+		return "", false
+	}
+
+	return m.submatchValues[index], true
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+func canParseSubmatch(target reflect.Value) bool {
+	if reflect.PtrTo(target.Type()).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch target.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+
+	case reflect.Interface:
+		return target.NumMethod() == 0
+
+	default:
+		return false
+	}
+}
+
+func parseSubmatch(value string, target reflect.Value) error {
+	if u, ok := target.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(value)
+
+	case reflect.Interface:
+		target.Set(reflect.ValueOf(value))
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		target.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetFloat(f)
+	}
+
+	return nil
+}
@@ -1,7 +1,11 @@
 package httpexpect
 
 import (
+	"net"
+	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -17,11 +21,13 @@ func TestMatch_FailedChain(t *testing.T) {
 	value.Length().chain.assert(t, failure)
 	value.Submatch(0).chain.assert(t, failure)
 	value.NamedSubmatch("").chain.assert(t, failure)
+	value.NamedSubmatches().chain.assert(t, failure)
 
 	value.IsEmpty()
 	value.NotEmpty()
 	value.HasSubmatches("")
 	value.NotHasSubmatches("")
+	value.Capture("", new(string))
 }
 
 func TestMatch_Constructors(t *testing.T) {
@@ -133,6 +139,165 @@ func TestMatch_Getters(t *testing.T) {
 	value.chain.clear()
 }
 
+func TestMatch_NamedSubmatches(t *testing.T) {
+	t.Run("named", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		r := regexp.MustCompile(`http://(?P<host>.+)/users/(.+)/(?P<tab>.+)`)
+		m := NewMatch(reporter,
+			r.FindStringSubmatch("http://example.com/users/john/posts"),
+			r.SubexpNames())
+
+		m.NamedSubmatches().IsEqual(map[string]interface{}{
+			"host": "example.com",
+			"tab":  "posts",
+		})
+		m.chain.assert(t, success)
+	})
+
+	t.Run("unnamed", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		m := NewMatch(reporter, []string{"m0", "m1"}, nil)
+
+		m.NamedSubmatches().IsEmpty()
+		m.chain.assert(t, success)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		r := regexp.MustCompile(`(?P<id>\d+)`)
+		m := NewMatch(reporter, r.FindStringSubmatch("abc"), r.SubexpNames())
+
+		m.NamedSubmatches().IsEmpty()
+		m.chain.assert(t, success)
+	})
+}
+
+func TestMatch_Capture(t *testing.T) {
+	r := regexp.MustCompile(
+		`(?P<name>\w+) (?P<id>-?\d+) (?P<ratio>[\d.]+) (?P<ok>\w+) (?P<ip>[\d.]+)`)
+
+	newTestMatch := func(reporter Reporter) *Match {
+		return NewMatch(reporter,
+			r.FindStringSubmatch("john 42 0.5 true 10.0.0.1"),
+			r.SubexpNames())
+	}
+
+	t.Run("types", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		var (
+			name   string
+			id     int
+			id8    int8
+			uid    uint
+			ratio  float64
+			ok     bool
+			ip     net.IP
+			anyVal interface{}
+		)
+
+		m := newTestMatch(reporter).
+			Capture("name", &name).
+			Capture("id", &id).
+			Capture("id", &id8).
+			Capture("id", &uid).
+			Capture("ratio", &ratio).
+			Capture("ok", &ok).
+			Capture("ip", &ip).
+			Capture("name", &anyVal)
+
+		m.chain.assert(t, success)
+
+		assert.Equal(t, "john", name)
+		assert.Equal(t, 42, id)
+		assert.Equal(t, int8(42), id8)
+		assert.Equal(t, uint(42), uid)
+		assert.Equal(t, 0.5, ratio)
+		assert.Equal(t, true, ok)
+		assert.Equal(t, "10.0.0.1", ip.String())
+		assert.Equal(t, "john", anyVal)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			group  string
+			target interface{}
+		}{
+			{"int from word", "name", new(int)},
+			{"bool from number", "id", new(bool)},
+			{"float from word", "name", new(float64)},
+			{"time from word", "name", new(time.Time)},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				zero := reflect.New(reflect.TypeOf(tc.target).Elem()).Interface()
+
+				newTestMatch(reporter).Capture(tc.group, tc.target).
+					chain.assert(t, failure)
+
+				assert.Equal(t, zero, tc.target)
+			})
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		m := NewMatch(reporter, []string{"300", "300"}, []string{"", "n"})
+
+		var target int8 = 1
+		m.Capture("n", &target).chain.assert(t, failure)
+		assert.Equal(t, int8(1), target)
+	})
+
+	t.Run("negative unsigned", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		var target uint
+		newTestMatch(reporter).Capture("id", &target).chain.assert(t, success)
+
+		m := NewMatch(reporter, []string{"-1", "-1"}, []string{"", "n"})
+		m.Capture("n", &target).chain.assert(t, failure)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		var target string
+		newTestMatch(reporter).Capture("bad", &target).
+			chain.assert(t, failure)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			target interface{}
+		}{
+			{"nil", nil},
+			{"non-pointer", "str"},
+			{"nil pointer", (*string)(nil)},
+			{"unsupported type", new([]string)},
+			{"non-empty interface", new(error)},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				newTestMatch(reporter).Capture("name", tc.target).
+					chain.assert(t, failure)
+			})
+		}
+	})
+}
+
 func TestMatch_IsEmpty(t *testing.T) {
 	cases := []struct {
 		name      string
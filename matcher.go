@@ -0,0 +1,67 @@
+package httpexpect
+
+// MatcherContext provides information about request and response being
+// matched to context matchers.
+//
+// See Request.WithContextMatcher and Expect.ContextMatcher.
+type MatcherContext struct {
+	// Name of the running test
+	// Usually comes from testing.T
+	TestName string
+
+	// Name of request being matched
+	// Comes from Request.WithName()
+	RequestName string
+
+	// Number of attempts made to send request, starting from 1
+	// Greater than 1 if request was retried, see Request.WithMaxRetries()
+	Attempt int
+
+	// Chain of nested assertion names leading to response
+	// Example value:
+	//   {`Request("GET")`, `Expect()`}
+	Path []string
+
+	// Chain of nested assertion names leading to response, starting from alias
+	// When alias is not set, AliasedPath has the same value as Path
+	AliasedPath []string
+
+	resp *Response
+}
+
+func newMatcherContext(resp *Response, attempt int) *MatcherContext {
+	if attempt == 0 {
+		attempt = 1
+	}
+
+	return &MatcherContext{
+		TestName:    resp.chain.context.TestName,
+		RequestName: resp.chain.context.RequestName,
+		Attempt:     attempt,
+		Path:        append(([]string)(nil), resp.chain.context.Path...),
+		AliasedPath: append(([]string)(nil), resp.chain.context.AliasedPath...),
+		resp:        resp,
+	}
+}
+
+// Attach adds named attachment to failure reports.
+//
+// Attachment is reported with all failures of assertions on the response
+// made after this call, including assertions made by other matchers and
+// by the test itself. Attachments are passed to AssertionHandler only if
+// it implements AttachmentAssertionHandler.
+//
+// Example:
+//
+//	req.WithContextMatcher(
+//		func(ctx *httpexpect.MatcherContext, resp *httpexpect.Response) {
+//			ctx.Attach("server log", "text/plain", serverLog.Bytes())
+//			resp.Status(http.StatusOK)
+//		})
+func (ctx *MatcherContext) Attach(name, contentType string, content []byte) {
+	ctx.resp.chain.addAttachment(AssertionAttachment{
+		Name:        name,
+		ContentType: contentType,
+		Content:     append(([]byte)(nil), content...),
+	})
+}
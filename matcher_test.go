@@ -0,0 +1,183 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher_Context(t *testing.T) {
+	t.Run("fields", func(t *testing.T) {
+		calls := 0
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+
+		e := WithConfig(Config{
+			TestName: "TestName",
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+
+		var ctx *MatcherContext
+
+		e.GET("/path").
+			WithName("request name").
+			WithMaxRetries(5).
+			WithRetryPolicy(RetryAllErrors).
+			WithRetryDelay(0, 0).
+			WithContextMatcher(func(c *MatcherContext, resp *Response) {
+				ctx = c
+				resp.Status(http.StatusOK)
+			}).
+			Expect().
+			chain.assert(t, success)
+
+		require.NotNil(t, ctx)
+		assert.Equal(t, "TestName", ctx.TestName)
+		assert.Equal(t, "request name", ctx.RequestName)
+		assert.Equal(t, 3, ctx.Attempt)
+		assert.Equal(t, []string{`Request("GET")`, "Expect()"}, ctx.Path)
+		assert.Equal(t, []string{`Request("GET")`, "Expect()"}, ctx.AliasedPath)
+	})
+
+	t.Run("single attempt", func(t *testing.T) {
+		config := Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+		}
+
+		var ctx *MatcherContext
+
+		NewRequestC(config, "GET", "/path").
+			WithContextMatcher(func(c *MatcherContext, _ *Response) {
+				ctx = c
+			}).
+			Expect()
+
+		require.NotNil(t, ctx)
+		assert.Equal(t, 1, ctx.Attempt)
+	})
+
+	t.Run("expect matchers", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+		})
+
+		var order []string
+
+		m := e.Matcher(func(_ *Response) {
+			order = append(order, "plain")
+		}).ContextMatcher(func(ctx *MatcherContext, _ *Response) {
+			order = append(order, "context "+ctx.RequestName)
+		})
+
+		m.GET("/path").
+			WithName("foo").
+			Expect().
+			chain.assert(t, success)
+
+		assert.Equal(t, []string{"plain", "context foo"}, order)
+	})
+
+	t.Run("nil matcher", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+		})
+
+		e.Matcher(nil).GET("/path").
+			chain.assert(t, failure)
+
+		e.ContextMatcher(nil).GET("/path").
+			chain.assert(t, failure)
+
+		e.GET("/path").WithContextMatcher(nil).
+			chain.assert(t, failure)
+	})
+}
+
+func TestMatcher_Attach(t *testing.T) {
+	newConfig := func(handler AssertionHandler) Config {
+		return Config{
+			BaseURL: "http://example.com",
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+			AssertionHandler: handler,
+		}
+	}
+
+	t.Run("failure in matcher", func(t *testing.T) {
+		handler := &mockAttachmentHandler{}
+
+		NewRequestC(newConfig(handler), "GET", "/path").
+			WithContextMatcher(func(ctx *MatcherContext, resp *Response) {
+				ctx.Attach("server log", "text/plain", []byte("log line"))
+				resp.Status(http.StatusNotFound)
+			}).
+			Expect()
+
+		assert.Equal(t, 1, handler.failureCalled)
+
+		attachment := findAttachment(handler.attachments, "server log")
+		require.NotNil(t, attachment)
+		assert.Equal(t, "text/plain", attachment.ContentType)
+		assert.Equal(t, []byte("log line"), attachment.Content)
+	})
+
+	t.Run("failure in test", func(t *testing.T) {
+		handler := &mockAttachmentHandler{}
+
+		resp := NewRequestC(newConfig(handler), "GET", "/path").
+			WithContextMatcher(func(ctx *MatcherContext, resp *Response) {
+				ctx.Attach("first", "text/plain", []byte("1"))
+			}).
+			WithContextMatcher(func(ctx *MatcherContext, resp *Response) {
+				ctx.Attach("second", "text/plain", []byte("2"))
+			}).
+			Expect()
+
+		assert.Equal(t, 0, handler.failureCalled)
+
+		resp.Status(http.StatusNotFound)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.NotNil(t, findAttachment(handler.attachments, "first"))
+		assert.NotNil(t, findAttachment(handler.attachments, "second"))
+	})
+
+	t.Run("other responses", func(t *testing.T) {
+		handler := &mockAttachmentHandler{}
+
+		NewRequestC(newConfig(handler), "GET", "/path").
+			WithContextMatcher(func(ctx *MatcherContext, resp *Response) {
+				ctx.Attach("server log", "text/plain", []byte("log line"))
+			}).
+			Expect()
+
+		NewRequestC(newConfig(handler), "GET", "/path").
+			Expect().
+			Status(http.StatusNotFound)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Nil(t, findAttachment(handler.attachments, "server log"))
+	})
+}
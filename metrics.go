@@ -0,0 +1,300 @@
+package httpexpect
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MetricsCollector is used to collect metrics of HTTP traffic generated by
+// tests, see Config.MetricsCollector.
+//
+// MemoryMetrics implements this interface.
+type MetricsCollector interface {
+	// ObserveRequest is invoked after every HTTP round trip, including
+	// retries and requests sent by Request.Repeat.
+	// May be invoked concurrently from multiple goroutines.
+	ObserveRequest(RequestMetrics)
+}
+
+// MetricsLabels defines labels of observed HTTP round trip.
+type MetricsLabels struct {
+	// Request name (see Request.WithName), or request alias (see Request.Alias),
+	// or request method and path, whichever is set first
+	Name string
+
+	// Request method, e.g. "GET"
+	Method string
+
+	// Response status class, e.g. "2xx", or "error" if no response was
+	// received
+	StatusClass string
+
+	// Attempt number, zero for the first attempt and N for N-th retry
+	Attempt int
+}
+
+// RequestMetrics defines a single observation passed to MetricsCollector.
+type RequestMetrics struct {
+	MetricsLabels
+
+	// Response status code, or zero if no response was received
+	StatusCode int
+
+	// Error returned by client, if any
+	Err error
+
+	// Round-trip time
+	Duration time.Duration
+}
+
+// Get status class label for given status code, e.g. "4xx".
+func statusClass(statusCode int) string {
+	if statusCode <= 0 {
+		return "error"
+	}
+	return fmt.Sprintf("%dxx", statusCode/100)
+}
+
+// DefaultMetricsBuckets defines default upper bounds of MemoryMetrics
+// histogram buckets. They match default Prometheus buckets.
+var DefaultMetricsBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// MemoryMetrics is MetricsCollector that keeps observations in memory
+// and provides Prometheus-style counters and histograms for them.
+//
+// It is useful for asserting traffic generated by tests, for example,
+// number of retries.
+//
+// Example:
+//
+//	metrics := httpexpect.NewMemoryMetrics()
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:          "http://example.com",
+//		Reporter:         httpexpect.NewAssertReporter(t),
+//		MetricsCollector: metrics,
+//	})
+//
+//	e.GET("/flaky").
+//		WithName("flaky").
+//		WithMaxRetries(5).
+//		Expect().
+//		Status(http.StatusOK)
+//
+//	assert.Equal(t, 3, metrics.Retries())
+type MemoryMetrics struct {
+	// Upper bounds of histogram buckets, in increasing order.
+	// If nil, DefaultMetricsBuckets is used.
+	Buckets []time.Duration
+
+	mu      sync.Mutex
+	samples []RequestMetrics
+}
+
+// MetricsHistogram holds cumulative histogram of observed durations.
+type MetricsHistogram struct {
+	// Number of observations
+	Count int
+
+	// Sum of observed durations
+	Sum time.Duration
+
+	// Cumulative buckets, i.e. every bucket counts observations less than
+	// or equal to its upper bound; last bucket has infinite upper bound
+	Buckets []MetricsBucket
+}
+
+// MetricsBucket holds a single histogram bucket.
+type MetricsBucket struct {
+	// Upper bound; -1 for infinite upper bound
+	UpperBound time.Duration
+
+	// Number of observations less than or equal to upper bound
+	Count int
+}
+
+// NewMemoryMetrics returns a new empty MemoryMetrics instance.
+func NewMemoryMetrics() *MemoryMetrics {
+	return &MemoryMetrics{}
+}
+
+// ObserveRequest implements MetricsCollector.ObserveRequest.
+func (m *MemoryMetrics) ObserveRequest(sample RequestMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.samples = append(m.samples, sample)
+}
+
+// Samples returns copy of all observations, in the order they were made.
+func (m *MemoryMetrics) Samples() []RequestMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]RequestMetrics(nil), m.samples...)
+}
+
+// Reset removes all observations.
+func (m *MemoryMetrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.samples = nil
+}
+
+// Total returns number of observed round trips.
+func (m *MemoryMetrics) Total() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.samples)
+}
+
+// Retries returns number of observed round trips that were retries,
+// i.e. had non-zero attempt number.
+func (m *MemoryMetrics) Retries() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := 0
+	for _, s := range m.samples {
+		if s.Attempt > 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Count returns number of observed round trips with exactly given labels.
+//
+// Example:
+//
+//	n := metrics.Count(httpexpect.MetricsLabels{
+//		Name:        "login",
+//		Method:      "POST",
+//		StatusClass: "5xx",
+//		Attempt:     0,
+//	})
+func (m *MemoryMetrics) Count(labels MetricsLabels) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := 0
+	for _, s := range m.samples {
+		if s.MetricsLabels == labels {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Counters returns number of observed round trips for every label set,
+// like Prometheus counter vector.
+func (m *MemoryMetrics) Counters() map[MetricsLabels]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counters := make(map[MetricsLabels]int)
+	for _, s := range m.samples {
+		counters[s.MetricsLabels]++
+	}
+
+	return counters
+}
+
+// Histogram returns histogram of durations of observed round trips for
+// which match returns true. If match is nil, all round trips are included.
+//
+// Example:
+//
+//	hist := metrics.Histogram(func(l httpexpect.MetricsLabels) bool {
+//		return l.Name == "login"
+//	})
+func (m *MemoryMetrics) Histogram(match func(MetricsLabels) bool) MetricsHistogram {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bounds := m.Buckets
+	if bounds == nil {
+		bounds = DefaultMetricsBuckets
+	}
+
+	bounds = append([]time.Duration(nil), bounds...)
+	sort.Slice(bounds, func(i, j int) bool {
+		return bounds[i] < bounds[j]
+	})
+
+	hist := MetricsHistogram{
+		Buckets: make([]MetricsBucket, 0, len(bounds)+1),
+	}
+
+	for _, b := range bounds {
+		hist.Buckets = append(hist.Buckets, MetricsBucket{UpperBound: b})
+	}
+	hist.Buckets = append(hist.Buckets, MetricsBucket{UpperBound: -1})
+
+	for _, s := range m.samples {
+		if match != nil && !match(s.MetricsLabels) {
+			continue
+		}
+
+		hist.Count++
+		hist.Sum += s.Duration
+
+		for n := range hist.Buckets {
+			bound := hist.Buckets[n].UpperBound
+			if bound < 0 || s.Duration <= bound {
+				hist.Buckets[n].Count++
+			}
+		}
+	}
+
+	return hist
+}
+
+// Report HTTP round trip to Config.MetricsCollector, if it's set.
+func (r *Request) observeMetrics(
+	resp *http.Response, err error, attempt int, elapsed time.Duration,
+) {
+	if r.config.MetricsCollector == nil {
+		return
+	}
+
+	sample := RequestMetrics{
+		MetricsLabels: MetricsLabels{
+			Method:      r.httpReq.Method,
+			StatusClass: statusClass(0),
+			Attempt:     attempt,
+		},
+		Err:      err,
+		Duration: elapsed,
+	}
+
+	if r.logger != nil {
+		sample.Name = r.logger.label()
+	}
+
+	if resp != nil {
+		sample.StatusCode = resp.StatusCode
+		sample.StatusClass = statusClass(resp.StatusCode)
+	}
+
+	r.config.MetricsCollector.ObserveRequest(sample)
+}
@@ -0,0 +1,213 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type metricsTestClient struct {
+	mu    sync.Mutex
+	codes []int
+	err   error
+}
+
+func (c *metricsTestClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.codes) == 0 {
+		return nil, c.err
+	}
+
+	code := c.codes[0]
+	if len(c.codes) > 1 {
+		c.codes = c.codes[1:]
+	}
+
+	return &http.Response{
+		StatusCode: code,
+		Header:     http.Header{},
+		Body:       http.NoBody,
+	}, nil
+}
+
+func TestMetrics_Collector(t *testing.T) {
+	t.Run("retries", func(t *testing.T) {
+		metrics := NewMemoryMetrics()
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &metricsTestClient{
+			codes: []int{
+				http.StatusServiceUnavailable,
+				http.StatusBadGateway,
+				http.StatusOK,
+			},
+		}
+		config.MetricsCollector = metrics
+
+		req := NewRequestC(config, "POST", "/users/{id}", 1).
+			WithName("update").
+			WithMaxRetries(5).
+			WithRetryDelay(0, 0)
+		req.sleepFn = func(time.Duration) <-chan time.Time {
+			return time.After(0)
+		}
+		req.Expect().
+			Status(http.StatusOK).
+			chain.assert(t, success)
+
+		assert.Equal(t, 3, metrics.Total())
+		assert.Equal(t, 2, metrics.Retries())
+
+		samples := metrics.Samples()
+		require.Equal(t, 3, len(samples))
+
+		assert.Equal(t, MetricsLabels{
+			Name:        "update",
+			Method:      "POST",
+			StatusClass: "5xx",
+			Attempt:     0,
+		}, samples[0].MetricsLabels)
+		assert.Equal(t, http.StatusServiceUnavailable, samples[0].StatusCode)
+
+		assert.Equal(t, 1, samples[1].Attempt)
+		assert.Equal(t, http.StatusBadGateway, samples[1].StatusCode)
+
+		assert.Equal(t, MetricsLabels{
+			Name:        "update",
+			Method:      "POST",
+			StatusClass: "2xx",
+			Attempt:     2,
+		}, samples[2].MetricsLabels)
+
+		assert.Equal(t, 1, metrics.Count(MetricsLabels{
+			Name:        "update",
+			Method:      "POST",
+			StatusClass: "5xx",
+			Attempt:     1,
+		}))
+
+		assert.Equal(t, map[MetricsLabels]int{
+			{"update", "POST", "5xx", 0}: 1,
+			{"update", "POST", "5xx", 1}: 1,
+			{"update", "POST", "2xx", 2}: 1,
+		}, metrics.Counters())
+
+		metrics.Reset()
+
+		assert.Equal(t, 0, metrics.Total())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		metrics := NewMemoryMetrics()
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &metricsTestClient{
+			err: errors.New("connection refused"),
+		}
+		config.MetricsCollector = metrics
+
+		NewRequestC(config, "GET", "/path").
+			Expect().
+			chain.assert(t, failure)
+
+		samples := metrics.Samples()
+		require.Equal(t, 1, len(samples))
+
+		assert.Equal(t, MetricsLabels{
+			Name:        "GET /path",
+			Method:      "GET",
+			StatusClass: "error",
+			Attempt:     0,
+		}, samples[0].MetricsLabels)
+		assert.Equal(t, 0, samples[0].StatusCode)
+		assert.Error(t, samples[0].Err)
+	})
+
+	t.Run("repeat", func(t *testing.T) {
+		metrics := NewMemoryMetrics()
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &metricsTestClient{
+			codes: []int{http.StatusOK},
+		}
+		config.MetricsCollector = metrics
+
+		NewRequestC(config, "GET", "/path").
+			Alias("health").
+			Repeat(5, 2).
+			chain.assert(t, success)
+
+		assert.Equal(t, 5, metrics.Count(MetricsLabels{
+			Name:        "health",
+			Method:      "GET",
+			StatusClass: "2xx",
+			Attempt:     0,
+		}))
+	})
+}
+
+func TestMetrics_Histogram(t *testing.T) {
+	metrics := &MemoryMetrics{
+		Buckets: []time.Duration{
+			100 * time.Millisecond,
+			10 * time.Millisecond,
+		},
+	}
+
+	for _, d := range []time.Duration{
+		5 * time.Millisecond,
+		10 * time.Millisecond,
+		50 * time.Millisecond,
+		time.Second,
+	} {
+		metrics.ObserveRequest(RequestMetrics{
+			MetricsLabels: MetricsLabels{Name: "foo"},
+			Duration:      d,
+		})
+	}
+
+	metrics.ObserveRequest(RequestMetrics{
+		MetricsLabels: MetricsLabels{Name: "bar"},
+		Duration:      time.Millisecond,
+	})
+
+	hist := metrics.Histogram(func(l MetricsLabels) bool {
+		return l.Name == "foo"
+	})
+
+	assert.Equal(t, MetricsHistogram{
+		Count: 4,
+		Sum:   1065 * time.Millisecond,
+		Buckets: []MetricsBucket{
+			{UpperBound: 10 * time.Millisecond, Count: 2},
+			{UpperBound: 100 * time.Millisecond, Count: 3},
+			{UpperBound: -1, Count: 4},
+		},
+	}, hist)
+
+	hist = metrics.Histogram(nil)
+
+	assert.Equal(t, 5, hist.Count)
+	assert.Equal(t, 5, hist.Buckets[len(hist.Buckets)-1].Count)
+
+	hist = NewMemoryMetrics().Histogram(nil)
+
+	assert.Equal(t, 0, hist.Count)
+	assert.Equal(t, len(DefaultMetricsBuckets)+1, len(hist.Buckets))
+}
+
+func TestMetrics_StatusClass(t *testing.T) {
+	assert.Equal(t, "error", statusClass(0))
+	assert.Equal(t, "1xx", statusClass(http.StatusContinue))
+	assert.Equal(t, "2xx", statusClass(http.StatusNoContent))
+	assert.Equal(t, "3xx", statusClass(http.StatusFound))
+	assert.Equal(t, "4xx", statusClass(http.StatusNotFound))
+	assert.Equal(t, "5xx", statusClass(http.StatusInternalServerError))
+}
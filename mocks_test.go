@@ -61,6 +61,23 @@ func (mr *mockReporter) Errorf(message string, args ...interface{}) {
 	}
 }
 
+// mock testing.TB
+type mockTestingTB struct {
+	*mockReporter
+	*mockLogger
+}
+
+func newMockTestingTB(t *testing.T) *mockTestingTB {
+	return &mockTestingTB{
+		mockReporter: newMockReporter(t),
+		mockLogger:   newMockLogger(t),
+	}
+}
+
+func (mt *mockTestingTB) Name() string {
+	return mt.mockReporter.testing.Name()
+}
+
 // mock formatter
 type mockFormatter struct {
 	testing          *testing.T
@@ -170,6 +187,29 @@ func (mc *mockWebsocketConn) WriteMessage(messageType int, data []byte) error {
 	return mc.writeMsgErr
 }
 
+// mock http transport
+type mockTransport struct {
+	req  *http.Request
+	resp *http.Response
+	err  error
+}
+
+func (mt *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	mt.req = req
+	return mt.resp, mt.err
+}
+
+// mock skipper
+type mockSkipper struct {
+	skipped bool
+	reason  string
+}
+
+func (ms *mockSkipper) Skip(args ...interface{}) {
+	ms.skipped = true
+	ms.reason = fmt.Sprint(args...)
+}
+
 // mock http client
 type mockClient struct {
 	req  *http.Request
@@ -324,7 +364,7 @@ type mockWriter struct {
 
 func (mw *mockWriter) Write(p []byte) (n int, err error) {
 	if mw.err != nil {
-		return 0, err
+		return 0, mw.err
 	}
 
 	return mw.Writer.Write(p)
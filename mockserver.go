@@ -0,0 +1,590 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gobwas/glob"
+)
+
+// MockServer is an expectation-based mock HTTP server.
+//
+// You define expected requests using Expect method, and canned responses
+// for them. Then you pass server URL to the code under test, and after
+// the test is done, call AssertExpectations to check that every expected
+// request was received, and no unexpected requests were received.
+//
+// By default, expected requests may be received in any order. Use InOrder
+// to require them to be received in the same order as they were defined.
+//
+// Requests that don't match any expectation are answered with
+// "501 Not Implemented" and are reported by AssertExpectations.
+//
+// All received requests are also recorded and can be inspected using
+// Requests method, in the same format as RecordingHandler.Requests.
+//
+// MockServer is safe for concurrent use.
+//
+// Example:
+//
+//	mock := NewMockServer(t)
+//	defer mock.Close()
+//
+//	mock.Expect("GET", "/users/*").
+//		RespondJSON(http.StatusOK, map[string]interface{}{"name": "john"})
+//
+//	e := Default(t, mock.URL())
+//	e.GET("/users/1").Expect().Status(http.StatusOK)
+//
+//	mock.AssertExpectations()
+type MockServer struct {
+	mu           sync.Mutex
+	chain        *chain
+	server       *httptest.Server
+	expectations []*MockExpectation
+	received     []string
+	unexpected   []string
+	ordered      bool
+	recorder     requestRecorder
+}
+
+// NewMockServer returns a new MockServer, started on a random local port.
+//
+// If reporter is nil, the function panics.
+// Server should be closed using Close method when no longer needed.
+//
+// Example:
+//
+//	mock := NewMockServer(t)
+//	defer mock.Close()
+func NewMockServer(reporter Reporter) *MockServer {
+	return newMockServer(newChainWithDefaults("MockServer()", reporter))
+}
+
+// NewMockServerC returns a new MockServer with config, started on a random
+// local port.
+//
+// Requirements for config are same as for WithConfig function.
+// Server should be closed using Close method when no longer needed.
+//
+// Example:
+//
+//	mock := NewMockServerC(config)
+//	defer mock.Close()
+func NewMockServerC(config Config) *MockServer {
+	return newMockServer(newChainWithConfig("MockServer()", config.withDefaults()))
+}
+
+func newMockServer(parent *chain) *MockServer {
+	m := &MockServer{
+		chain: parent.clone(),
+	}
+
+	m.server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+
+	return m
+}
+
+// URL returns base URL of the server, e.g. "http://127.0.0.1:12345".
+//
+// Example:
+//
+//	mock := NewMockServer(t)
+//	e := Default(t, mock.URL())
+func (m *MockServer) URL() string {
+	return m.server.URL
+}
+
+// Client returns http.Client configured to send requests to the server.
+//
+// Example:
+//
+//	mock := NewMockServer(t)
+//	e := WithConfig(Config{
+//		BaseURL:  mock.URL(),
+//		Client:   mock.Client(),
+//		Reporter: NewAssertReporter(t),
+//	})
+func (m *MockServer) Client() *http.Client {
+	return m.server.Client()
+}
+
+// Close shuts down the server and blocks until all outstanding requests
+// on it have completed.
+func (m *MockServer) Close() {
+	m.server.Close()
+}
+
+// InOrder requires expected requests to be received in the same order as
+// they were defined using Expect.
+//
+// A request that matches an expectation defined later than the first
+// unsatisfied expectation is treated as unexpected.
+//
+// Example:
+//
+//	mock := NewMockServer(t)
+//	mock.InOrder()
+//	mock.Expect("POST", "/login")
+//	mock.Expect("GET", "/profile")
+func (m *MockServer) InOrder() *MockServer {
+	opChain := m.chain.enter("InOrder()")
+	defer opChain.leave()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ordered = true
+
+	return m
+}
+
+// Expect defines a new expected request and returns MockExpectation,
+// which can be used to refine request matching and define response.
+//
+// method is matched case-insensitively; empty method matches any method.
+// pathPattern is a glob pattern matched against request URL path, where
+// "*" matches any sequence of characters except "/", and "**" matches
+// any sequence of characters, e.g. "/users/*" or "/static/**".
+//
+// By default, expectation should be met exactly once, and is answered with
+// "200 OK" and empty body.
+//
+// Example:
+//
+//	mock.Expect("POST", "/users").
+//		WithJSON(map[string]interface{}{"name": "john"}).
+//		Respond(http.StatusCreated, "")
+func (m *MockServer) Expect(method, pathPattern string) *MockExpectation {
+	opChain := m.chain.enter("Expect(%q, %q)", method, pathPattern)
+	defer opChain.leave()
+
+	x := &MockExpectation{
+		server:      m,
+		method:      strings.ToUpper(method),
+		pathPattern: pathPattern,
+		times:       1,
+		status:      http.StatusOK,
+		header:      http.Header{},
+	}
+
+	glb, err := glob.Compile(pathPattern, '/')
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected invalid glob pattern"),
+				err,
+			},
+		})
+		return x
+	}
+
+	x.pathGlob = glb
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.expectations = append(m.expectations, x)
+
+	return x
+}
+
+// AssertExpectations succeeds if every expectation was met expected number
+// of times, and no unexpected requests were received.
+//
+// Example:
+//
+//	mock := NewMockServer(t)
+//	mock.Expect("GET", "/health")
+//
+//	e := Default(t, mock.URL())
+//	e.GET("/health").Expect()
+//
+//	mock.AssertExpectations()
+func (m *MockServer) AssertExpectations() *MockServer {
+	opChain := m.chain.enter("AssertExpectations()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return m
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var errs []error
+
+	for _, x := range m.expectations {
+		if x.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", x.String(), x.err))
+		} else if x.calls != x.times {
+			errs = append(errs,
+				fmt.Errorf("%s: expected %d request(s), received %d",
+					x.String(), x.times, x.calls))
+		}
+	}
+
+	for _, req := range m.unexpected {
+		errs = append(errs, fmt.Errorf("unexpected request: %s", req))
+	}
+
+	if len(errs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{append([]string{}, m.received...)},
+			Errors: append([]error{
+				errors.New("expected: all mock expectations are met"),
+			}, errs...),
+		})
+	}
+
+	return m
+}
+
+// Requests returns a new Array instance with all received requests,
+// in order in which they were received, including unexpected ones.
+//
+// See RecordingTransport.Requests for the format of array elements.
+//
+// Example:
+//
+//	mock.Requests().Length().IsEqual(2)
+func (m *MockServer) Requests() *Array {
+	opChain := m.chain.enter("Requests()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	return newArray(opChain, m.recorder.values())
+}
+
+func (m *MockServer) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	body := m.recorder.record(req, req.Host)
+	if body == nil {
+		body = []byte{}
+	}
+
+	desc := fmt.Sprintf("%s %s", req.Method, req.URL.RequestURI())
+
+	m.mu.Lock()
+
+	m.received = append(m.received, desc)
+
+	x := m.match(req, body)
+	if x == nil {
+		m.unexpected = append(m.unexpected, desc)
+	} else {
+		x.calls++
+	}
+
+	m.mu.Unlock()
+
+	if x == nil {
+		http.Error(w, "unexpected request", http.StatusNotImplemented)
+		return
+	}
+
+	// response fields are not modified after expectation is matched
+	for k, v := range x.header {
+		w.Header()[k] = v
+	}
+
+	w.WriteHeader(x.status)
+
+	_, _ = w.Write(x.body)
+}
+
+// Find expectation to which request should be attributed.
+// Should be called with mutex locked.
+func (m *MockServer) match(req *http.Request, body []byte) *MockExpectation {
+	for _, x := range m.expectations {
+		if x.calls >= x.times || x.err != nil {
+			continue
+		}
+
+		if x.matches(req, body) {
+			return x
+		}
+
+		if m.ordered {
+			// only first unsatisfied expectation may be matched
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// MockExpectation defines expected request and canned response for it.
+//
+// MockExpectation is created by MockServer.Expect. All its methods should
+// be called before the server receives requests.
+type MockExpectation struct {
+	server *MockServer
+
+	method      string
+	pathPattern string
+	pathGlob    glob.Glob
+
+	query       map[string]string
+	reqHeader   http.Header
+	bodyMatcher func(body []byte) bool
+
+	times int
+	calls int
+
+	status int
+	header http.Header
+	body   []byte
+
+	err error
+}
+
+// String returns human-readable description of the expectation,
+// e.g. "GET /users/*".
+func (x *MockExpectation) String() string {
+	method := x.method
+	if method == "" {
+		method = "*"
+	}
+
+	return fmt.Sprintf("%s %s", method, x.pathPattern)
+}
+
+// WithQuery requires request URL query to have parameter with given value.
+//
+// Example:
+//
+//	mock.Expect("GET", "/users").WithQuery("page", "2")
+func (x *MockExpectation) WithQuery(key, value string) *MockExpectation {
+	x.server.mu.Lock()
+	defer x.server.mu.Unlock()
+
+	if x.query == nil {
+		x.query = map[string]string{}
+	}
+	x.query[key] = value
+
+	return x
+}
+
+// WithHeader requires request to have header with given value.
+// Header name is case-insensitive.
+//
+// Example:
+//
+//	mock.Expect("GET", "/users").WithHeader("Authorization", "Bearer token")
+func (x *MockExpectation) WithHeader(key, value string) *MockExpectation {
+	x.server.mu.Lock()
+	defer x.server.mu.Unlock()
+
+	if x.reqHeader == nil {
+		x.reqHeader = http.Header{}
+	}
+	x.reqHeader.Add(key, value)
+
+	return x
+}
+
+// WithBody requires request body to be equal to given string.
+//
+// Example:
+//
+//	mock.Expect("POST", "/echo").WithBody("hello")
+func (x *MockExpectation) WithBody(body string) *MockExpectation {
+	return x.WithBodyMatcher(func(b []byte) bool {
+		return string(b) == body
+	})
+}
+
+// WithJSON requires request body to be JSON equal to given value.
+//
+// Value is marshaled to JSON and compared with request body after
+// unmarshaling both, so formatting and order of object keys don't matter.
+//
+// Example:
+//
+//	mock.Expect("POST", "/users").
+//		WithJSON(map[string]interface{}{"name": "john"})
+func (x *MockExpectation) WithJSON(value interface{}) *MockExpectation {
+	expected, err := canonJSON(value)
+	if err != nil {
+		x.server.mu.Lock()
+		defer x.server.mu.Unlock()
+
+		x.err = fmt.Errorf("can't marshal expected JSON: %w", err)
+		return x
+	}
+
+	return x.WithBodyMatcher(func(b []byte) bool {
+		var actual interface{}
+		if err := json.Unmarshal(b, &actual); err != nil {
+			return false
+		}
+		return reflect.DeepEqual(expected, actual)
+	})
+}
+
+// WithBodyMatcher requires request body to satisfy given function.
+//
+// Example:
+//
+//	mock.Expect("POST", "/upload").WithBodyMatcher(func(body []byte) bool {
+//		return len(body) > 0
+//	})
+func (x *MockExpectation) WithBodyMatcher(
+	matcher func(body []byte) bool,
+) *MockExpectation {
+	x.server.mu.Lock()
+	defer x.server.mu.Unlock()
+
+	if matcher == nil {
+		x.err = errors.New("unexpected nil body matcher")
+		return x
+	}
+
+	x.bodyMatcher = matcher
+
+	return x
+}
+
+// Times sets how many times expectation should be met.
+// Default is 1.
+//
+// Example:
+//
+//	mock.Expect("GET", "/health").Times(3)
+func (x *MockExpectation) Times(n int) *MockExpectation {
+	x.server.mu.Lock()
+	defer x.server.mu.Unlock()
+
+	if n < 1 {
+		x.err = errors.New("unexpected non-positive times value")
+		return x
+	}
+
+	x.times = n
+
+	return x
+}
+
+// Respond sets response status and body.
+//
+// Example:
+//
+//	mock.Expect("GET", "/health").Respond(http.StatusOK, "ok")
+func (x *MockExpectation) Respond(status int, body string) *MockExpectation {
+	x.server.mu.Lock()
+	defer x.server.mu.Unlock()
+
+	x.status = status
+	x.body = []byte(body)
+
+	return x
+}
+
+// RespondJSON sets response status and body with JSON-encoded value.
+// "Content-Type" header is set to "application/json; charset=utf-8".
+//
+// Example:
+//
+//	mock.Expect("GET", "/users/1").
+//		RespondJSON(http.StatusOK, map[string]interface{}{"name": "john"})
+func (x *MockExpectation) RespondJSON(status int, value interface{}) *MockExpectation {
+	body, err := json.Marshal(value)
+
+	x.server.mu.Lock()
+	defer x.server.mu.Unlock()
+
+	if err != nil {
+		x.err = fmt.Errorf("can't marshal response JSON: %w", err)
+		return x
+	}
+
+	x.status = status
+	x.body = body
+	x.header.Set("Content-Type", "application/json; charset=utf-8")
+
+	return x
+}
+
+// RespondHeader adds header to response.
+//
+// Example:
+//
+//	mock.Expect("GET", "/users/1").
+//		RespondHeader("ETag", `"v1"`)
+func (x *MockExpectation) RespondHeader(key, value string) *MockExpectation {
+	x.server.mu.Lock()
+	defer x.server.mu.Unlock()
+
+	x.header.Add(key, value)
+
+	return x
+}
+
+func (x *MockExpectation) matches(req *http.Request, body []byte) bool {
+	if x.method != "" && x.method != strings.ToUpper(req.Method) {
+		return false
+	}
+
+	if x.pathGlob == nil || !x.pathGlob.Match(req.URL.Path) {
+		return false
+	}
+
+	query := req.URL.Query()
+	for k, v := range x.query {
+		if query.Get(k) != v {
+			return false
+		}
+	}
+
+	for k, values := range x.reqHeader {
+		actual := req.Header.Values(k)
+		for _, v := range values {
+			if !containsString(actual, v) {
+				return false
+			}
+		}
+	}
+
+	if x.bodyMatcher != nil && !x.bodyMatcher(body) {
+		return false
+	}
+
+	return true
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Marshal value to JSON and unmarshal it back, to get canonical
+// representation comparable with unmarshaled request body.
+func canonJSON(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
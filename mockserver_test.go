@@ -0,0 +1,251 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockServer_Expectations(t *testing.T) {
+	t.Run("met", func(t *testing.T) {
+		mock := NewMockServer(newMockReporter(t))
+		defer mock.Close()
+
+		mock.Expect("GET", "/users/*").
+			WithQuery("full", "1").
+			WithHeader("Authorization", "Bearer token").
+			RespondHeader("ETag", `"v1"`).
+			RespondJSON(http.StatusOK, map[string]interface{}{"name": "john"})
+
+		mock.Expect("post", "/users").
+			WithJSON(map[string]interface{}{"name": "bob", "age": 30}).
+			Respond(http.StatusCreated, "created")
+
+		mock.Expect("", "/health").
+			Times(2)
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = mock.URL()
+		config.Client = mock.Client()
+
+		resp := NewRequestC(config, "POST", "/users").
+			WithBytes([]byte(`{ "age": 30, "name": "bob" }`)).
+			Expect()
+		resp.Status(http.StatusCreated)
+		resp.Body().IsEqual("created")
+		resp.chain.assert(t, success)
+
+		resp = NewRequestC(config, "GET", "/users/1").
+			WithQuery("full", "1").
+			WithHeader("Authorization", "Bearer token").
+			Expect()
+		resp.Status(http.StatusOK)
+		resp.Header("ETag").IsEqual(`"v1"`)
+		resp.JSON().Object().Value("name").IsEqual("john")
+		resp.chain.assert(t, success)
+
+		NewRequestC(config, "GET", "/health").
+			Expect().
+			Status(http.StatusOK).
+			chain.assert(t, success)
+
+		NewRequestC(config, "HEAD", "/health").
+			Expect().
+			Status(http.StatusOK).
+			chain.assert(t, success)
+
+		mock.AssertExpectations()
+		mock.chain.assert(t, success)
+
+		requests := mock.Requests()
+		requests.Length().IsEqual(4)
+		requests.Value(0).Object().Value("body").
+			IsEqual(`{ "age": 30, "name": "bob" }`)
+		requests.chain.assert(t, success)
+	})
+
+	t.Run("not met", func(t *testing.T) {
+		mock := NewMockServer(newMockReporter(t))
+		defer mock.Close()
+
+		mock.Expect("GET", "/a")
+		mock.Expect("GET", "/b").Times(2)
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = mock.URL()
+
+		NewRequestC(config, "GET", "/b").
+			Expect().
+			Status(http.StatusOK)
+
+		mock.AssertExpectations()
+		mock.chain.assert(t, failure)
+	})
+
+	t.Run("unexpected request", func(t *testing.T) {
+		mock := NewMockServer(newMockReporter(t))
+		defer mock.Close()
+
+		mock.Expect("GET", "/a")
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = mock.URL()
+
+		NewRequestC(config, "GET", "/a").
+			Expect().
+			Status(http.StatusOK)
+
+		NewRequestC(config, "GET", "/a").
+			Expect().
+			Status(http.StatusNotImplemented)
+
+		NewRequestC(config, "POST", "/a").
+			Expect().
+			Status(http.StatusNotImplemented)
+
+		mock.AssertExpectations()
+		mock.chain.assert(t, failure)
+
+		mock.Requests().Length().IsEqual(3)
+	})
+
+	t.Run("body mismatch", func(t *testing.T) {
+		mock := NewMockServer(newMockReporter(t))
+		defer mock.Close()
+
+		mock.Expect("POST", "/a").WithBody("hello")
+		mock.Expect("POST", "/b").WithJSON([]interface{}{1, 2})
+		mock.Expect("POST", "/c").WithBodyMatcher(func(body []byte) bool {
+			return len(body) > 10
+		})
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = mock.URL()
+
+		for _, path := range []string{"/a", "/b", "/c"} {
+			NewRequestC(config, "POST", path).
+				WithText("bye").
+				Expect().
+				Status(http.StatusNotImplemented).
+				chain.assert(t, success)
+		}
+
+		mock.AssertExpectations()
+		mock.chain.assert(t, failure)
+	})
+}
+
+func TestMockServer_InOrder(t *testing.T) {
+	t.Run("in order", func(t *testing.T) {
+		mock := NewMockServer(newMockReporter(t))
+		defer mock.Close()
+
+		mock.InOrder()
+		mock.Expect("POST", "/login")
+		mock.Expect("GET", "/profile").Times(2)
+		mock.Expect("POST", "/logout")
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = mock.URL()
+
+		NewRequestC(config, "POST", "/login").Expect().Status(http.StatusOK)
+		NewRequestC(config, "GET", "/profile").Expect().Status(http.StatusOK)
+		NewRequestC(config, "GET", "/profile").Expect().Status(http.StatusOK)
+		NewRequestC(config, "POST", "/logout").Expect().Status(http.StatusOK)
+
+		mock.AssertExpectations()
+		mock.chain.assert(t, success)
+	})
+
+	t.Run("out of order", func(t *testing.T) {
+		mock := NewMockServer(newMockReporter(t))
+		defer mock.Close()
+
+		mock.InOrder()
+		mock.Expect("POST", "/login")
+		mock.Expect("GET", "/profile")
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = mock.URL()
+
+		NewRequestC(config, "GET", "/profile").
+			Expect().
+			Status(http.StatusNotImplemented)
+		NewRequestC(config, "POST", "/login").
+			Expect().
+			Status(http.StatusOK)
+
+		mock.AssertExpectations()
+		mock.chain.assert(t, failure)
+	})
+
+	t.Run("any order", func(t *testing.T) {
+		mock := NewMockServer(newMockReporter(t))
+		defer mock.Close()
+
+		mock.Expect("POST", "/login")
+		mock.Expect("GET", "/profile")
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = mock.URL()
+
+		NewRequestC(config, "GET", "/profile").Expect().Status(http.StatusOK)
+		NewRequestC(config, "POST", "/login").Expect().Status(http.StatusOK)
+
+		mock.AssertExpectations()
+		mock.chain.assert(t, success)
+	})
+}
+
+func TestMockServer_Usage(t *testing.T) {
+	cases := []struct {
+		name   string
+		define func(mock *MockServer)
+	}{
+		{
+			name: "invalid glob",
+			define: func(mock *MockServer) {
+				mock.Expect("GET", "/[")
+			},
+		},
+		{
+			name: "invalid times",
+			define: func(mock *MockServer) {
+				mock.Expect("GET", "/").Times(0)
+			},
+		},
+		{
+			name: "nil body matcher",
+			define: func(mock *MockServer) {
+				mock.Expect("GET", "/").WithBodyMatcher(nil)
+			},
+		},
+		{
+			name: "invalid expected json",
+			define: func(mock *MockServer) {
+				mock.Expect("GET", "/").WithJSON(make(chan int))
+			},
+		},
+		{
+			name: "invalid response json",
+			define: func(mock *MockServer) {
+				mock.Expect("GET", "/").RespondJSON(http.StatusOK, make(chan int))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			mock := NewMockServer(reporter)
+			defer mock.Close()
+
+			tc.define(mock)
+
+			mock.AssertExpectations()
+			assert.True(t, reporter.reported)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package httpexpect
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"sync"
+)
+
+// Multipart form which parts are not buffered in memory, but are written
+// to a pipe while request is being sent. See Request.WithMultipartStream.
+type multipartStream struct {
+	boundary string
+	parts    []multipartStreamPart
+
+	// true if some parts can be read only once, and hence
+	// the body can't be re-opened for retries and redirects
+	oneShot bool
+}
+
+type multipartStreamPart struct {
+	key string
+
+	// for form fields
+	value string
+
+	// for files
+	isFile   bool
+	filename string
+	open     func() (io.Reader, error)
+}
+
+func newMultipartStream(boundary string) *multipartStream {
+	if boundary == "" {
+		boundary = multipart.NewWriter(io.Discard).Boundary()
+	}
+
+	return &multipartStream{
+		boundary: boundary,
+	}
+}
+
+func (s *multipartStream) addField(key, value string) {
+	s.parts = append(s.parts, multipartStreamPart{
+		key:   key,
+		value: value,
+	})
+}
+
+func (s *multipartStream) addFile(
+	key, filename string, open func() (io.Reader, error), reopenable bool,
+) {
+	s.parts = append(s.parts, multipartStreamPart{
+		key:      key,
+		isFile:   true,
+		filename: filename,
+		open:     open,
+	})
+
+	if !reopenable {
+		s.oneShot = true
+	}
+}
+
+func (s *multipartStream) contentType() string {
+	return "multipart/form-data; boundary=" + s.boundary
+}
+
+// Return new reader for the body. Parts are written to the reader from
+// a separate goroutine, which exits when body is fully read or closed.
+func (s *multipartStream) reader() io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(s.write(pw))
+	}()
+
+	return pr
+}
+
+func (s *multipartStream) write(w io.Writer) error {
+	mw := multipart.NewWriter(w)
+
+	if err := mw.SetBoundary(s.boundary); err != nil {
+		return err
+	}
+
+	for _, part := range s.parts {
+		if !part.isFile {
+			if err := mw.WriteField(part.key, part.value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := writeStreamFile(mw, part); err != nil {
+			return err
+		}
+	}
+
+	return mw.Close()
+}
+
+func writeStreamFile(mw *multipart.Writer, part multipartStreamPart) error {
+	wr, err := mw.CreateFormFile(part.key, part.filename)
+	if err != nil {
+		return err
+	}
+
+	rd, err := part.open()
+	if err != nil {
+		return fmt.Errorf("failed to open file %q: %w", part.filename, err)
+	}
+
+	if closer, ok := rd.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	if _, err := io.Copy(wr, rd); err != nil {
+		return fmt.Errorf("failed to read file %q: %w", part.filename, err)
+	}
+
+	return nil
+}
+
+// Return function that returns given reader on first call,
+// and error on subsequent calls.
+func openOnce(rd io.Reader) func() (io.Reader, error) {
+	var once sync.Once
+
+	return func() (io.Reader, error) {
+		var ret io.Reader
+		once.Do(func() {
+			// hide Close method, since reader is owned by caller
+			ret = struct{ io.Reader }{rd}
+		})
+		if ret == nil {
+			return nil, fmt.Errorf("reader can't be read more than once")
+		}
+		return ret, nil
+	}
+}
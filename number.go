@@ -87,6 +87,67 @@ func (n *Number) Alias(name string) *Number {
 	return n
 }
 
+// Warn is similar to Value.Warn.
+//
+// Example:
+//
+//	number := NewNumber(t, 123)
+//	number.Warn().Lt(100) // warning, test continues
+func (n *Number) Warn() *Number {
+	opChain := n.chain.enter("Warn()")
+	defer opChain.leave()
+
+	ret := newNumber(opChain, n.value)
+
+	ret.chain.setRoot()
+	ret.chain.setSeverity(SeverityWarning)
+
+	return ret
+}
+
+// Assert is similar to Value.Assert.
+//
+// Example:
+//
+//	number := NewNumber(t, 6)
+//	number.Assert(func(n float64) error {
+//		if int(n)%2 != 0 {
+//			return errors.New("expected even number")
+//		}
+//		return nil
+//	})
+func (n *Number) Assert(fn func(value float64) error) *Number {
+	opChain := n.chain.enter("Assert()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return n
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return n
+	}
+
+	if err := fn(n.value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{n.value},
+			Errors: []error{
+				errors.New("expected: value satisfies custom assertion"),
+				err,
+			},
+		})
+	}
+
+	return n
+}
+
 // Path is similar to Value.Path.
 func (n *Number) Path(path string) *Value {
 	opChain := n.chain.enter("Path(%q)", path)
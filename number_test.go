@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"errors"
 	"math"
 	"testing"
 
@@ -16,6 +17,7 @@ func TestNumber_FailedChain(t *testing.T) {
 	value.Path("$").chain.assert(t, failure)
 	value.Schema("")
 	value.Alias("foo")
+	value.Assert(nil)
 
 	var target interface{}
 	value.Decode(&target)
@@ -146,6 +148,43 @@ func TestNumber_Alias(t *testing.T) {
 	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
 }
 
+func TestNumber_Warn(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	number := NewNumber(reporter, 123)
+
+	warn := number.Warn()
+	warn.Lt(100)
+
+	warn.chain.assert(t, failure)
+	number.chain.assert(t, success)
+
+	assert.False(t, reporter.reported)
+}
+
+func TestNumber_Assert(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	var actual float64
+	NewNumber(reporter, 123).
+		Assert(func(n float64) error {
+			actual = n
+			return nil
+		}).
+		chain.assert(t, success)
+	assert.Equal(t, 123.0, actual)
+
+	NewNumber(reporter, 123).
+		Assert(func(n float64) error {
+			return errors.New("custom error")
+		}).
+		chain.assert(t, failure)
+
+	NewNumber(reporter, 123).
+		Assert(nil).
+		chain.assert(t, failure)
+}
+
 func TestNumber_Path(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 )
 
 // Object provides methods to inspect attached map[string]interface{} object
@@ -125,6 +128,67 @@ func (o *Object) Alias(name string) *Object {
 	return o
 }
 
+// Warn is similar to Value.Warn.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": 123})
+//	object.Warn().ContainsKey("bar") // warning, test continues
+func (o *Object) Warn() *Object {
+	opChain := o.chain.enter("Warn()")
+	defer opChain.leave()
+
+	ret := newObject(opChain, o.value)
+
+	ret.chain.setRoot()
+	ret.chain.setSeverity(SeverityWarning)
+
+	return ret
+}
+
+// Assert is similar to Value.Assert.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"min": 1, "max": 10})
+//	object.Assert(func(m map[string]interface{}) error {
+//		if m["min"].(float64) > m["max"].(float64) {
+//			return errors.New("min is greater than max")
+//		}
+//		return nil
+//	})
+func (o *Object) Assert(fn func(value map[string]interface{}) error) *Object {
+	opChain := o.chain.enter("Assert()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return o
+	}
+
+	if err := fn(o.value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{o.value},
+			Errors: []error{
+				errors.New("expected: value satisfies custom assertion"),
+				err,
+			},
+		})
+	}
+
+	return o
+}
+
 // Path is similar to Value.Path.
 func (o *Object) Path(path string) *Value {
 	opChain := o.chain.enter("Path(%q)", path)
@@ -799,15 +863,18 @@ func (o *Object) IsEqual(value interface{}) *Object {
 		return o
 	}
 
-	expected, ok := canonMap(opChain, value)
+	canonExpected, ok := canonMap(opChain, value)
 	if !ok {
 		return o
 	}
 
-	if !reflect.DeepEqual(expected, o.value) {
+	actual := opChain.redact(o.value)
+	expected := opChain.redact(canonExpected)
+
+	if !reflect.DeepEqual(expected, actual) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
-			Actual:   &AssertionValue{o.value},
+			Actual:   &AssertionValue{actual},
 			Expected: &AssertionValue{expected},
 			Errors: []error{
 				errors.New("expected: maps are equal"),
@@ -818,6 +885,126 @@ func (o *Object) IsEqual(value interface{}) *Object {
 	return o
 }
 
+// IsEqualIgnoring succeeds if object is equal to given value, except
+// nodes matching any of given JSON paths. Useful to skip volatile fields,
+// like ids or timestamps.
+//
+// Paths are relative to the object and start with "$". Ignored fields are
+// removed from both object and value, so they may be missing in value.
+// Supported syntax is "$.key", "$[\"key\"]", "$.items[0]", wildcards
+// "$.*" and "$.items[*]", and recursive descent "$..key".
+//
+// On failure, reports JSON path of the first mismatching node.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{
+//		"id":   "5f2b",
+//		"name": "john",
+//		"tags": []interface{}{
+//			map[string]interface{}{"id": 1, "name": "admin"},
+//		},
+//	})
+//	object.IsEqualIgnoring([]string{"$.id", "$.tags[*].id"},
+//		map[string]interface{}{
+//			"name": "john",
+//			"tags": []interface{}{
+//				map[string]interface{}{"name": "admin"},
+//			},
+//		})
+func (o *Object) IsEqualIgnoring(paths []string, value interface{}) *Object {
+	opChain := o.chain.enter("IsEqualIgnoring()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	patterns, ok := parseIgnorePaths(opChain, paths)
+	if !ok {
+		return o
+	}
+
+	canonExpected, ok := canonMap(opChain, value)
+	if !ok {
+		return o
+	}
+
+	actual := opChain.redact(ignoreNodes(patterns, o.value))
+	expected := opChain.redact(ignoreNodes(patterns, canonExpected))
+
+	if !reflect.DeepEqual(expected, actual) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{expected},
+			Errors: []error{
+				errors.New("expected: maps are equal, except ignored paths"),
+				fmt.Errorf("first mismatch at %s",
+					firstMismatchPath("$", expected, actual)),
+			},
+		})
+	}
+
+	return o
+}
+
+// IsEqualApprox succeeds if object is equal to given value, treating
+// numbers as equal if they differ by no more than epsilon. Tolerance is
+// applied recursively to nested objects and arrays.
+//
+// value should be map[string]interface{} or struct.
+//
+// On failure, reports JSON path of the first mismatching node.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{
+//		"lat": 52.52000659,
+//		"lon": 13.40495399,
+//	})
+//	object.IsEqualApprox(map[string]interface{}{
+//		"lat": 52.52,
+//		"lon": 13.405,
+//	}, 0.0001)
+func (o *Object) IsEqualApprox(value interface{}, epsilon float64) *Object {
+	opChain := o.chain.enter("IsEqualApprox()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	if !checkApproxEpsilon(opChain, epsilon) {
+		return o
+	}
+
+	canonExpected, ok := canonMap(opChain, value)
+	if !ok {
+		return o
+	}
+
+	actual := opChain.redact(o.value)
+	expected := opChain.redact(canonExpected)
+
+	path := firstMismatchPathFunc("$", expected, actual, approxEqualFunc(epsilon))
+
+	if path != "" {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{expected},
+			Delta:    &AssertionValue{epsilon},
+			Errors: []error{
+				errors.New("expected: maps are equal within epsilon"),
+				fmt.Errorf("first mismatch at %s", path),
+			},
+		})
+	}
+
+	return o
+}
+
 // NotEqual succeeds if object is not equal to given value.
 // Before comparison, both object and value are converted to canonical form.
 //
@@ -835,15 +1022,18 @@ func (o *Object) NotEqual(value interface{}) *Object {
 		return o
 	}
 
-	expected, ok := canonMap(opChain, value)
+	canonExpected, ok := canonMap(opChain, value)
 	if !ok {
 		return o
 	}
 
-	if reflect.DeepEqual(expected, o.value) {
+	actual := opChain.redact(o.value)
+	expected := opChain.redact(canonExpected)
+
+	if reflect.DeepEqual(expected, actual) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
-			Actual:   &AssertionValue{o.value},
+			Actual:   &AssertionValue{actual},
 			Expected: &AssertionValue{expected},
 			Errors: []error{
 				errors.New("expected: maps are non-equal"),
@@ -1028,6 +1218,136 @@ func (o *Object) NotContainsKey(key string) *Object {
 	return o
 }
 
+// ContainsKeyMatching succeeds if object contains at least one key
+// matching given regexp. regexp.Compile is used to construct regexp.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"x-request-id": "abc"})
+//	object.ContainsKeyMatching(`^x-`)
+func (o *Object) ContainsKeyMatching(re string) *Object {
+	opChain := o.chain.enter("ContainsKeyMatching()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	rx, err := regexp.Compile(re)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{re},
+			Errors: []error{
+				errors.New("expected: valid regexp"),
+				err,
+			},
+		})
+		return o
+	}
+
+	if len(matchingKeys(o.value, rx)) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{re},
+			Errors: []error{
+				errors.New("expected: map contains key matching regexp"),
+			},
+		})
+	}
+
+	return o
+}
+
+// NotContainsKeyMatching succeeds if object doesn't contain any key
+// matching given regexp. regexp.Compile is used to construct regexp.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"name": "john"})
+//	object.NotContainsKeyMatching(`(?i)password`)
+func (o *Object) NotContainsKeyMatching(re string) *Object {
+	opChain := o.chain.enter("NotContainsKeyMatching()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	rx, err := regexp.Compile(re)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{re},
+			Errors: []error{
+				errors.New("expected: valid regexp"),
+				err,
+			},
+		})
+		return o
+	}
+
+	if keys := matchingKeys(o.value, rx); len(keys) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{re},
+			Errors: []error{
+				errors.New("expected: map does not contain keys matching regexp"),
+				fmt.Errorf("matching keys: %q", keys),
+			},
+		})
+	}
+
+	return o
+}
+
+// NotContainsKeysOtherThan succeeds if object doesn't contain any keys
+// except given ones. Not all given keys are required to be present.
+//
+// It's useful to check that response doesn't have unexpected fields,
+// without defining JSON schema with additionalProperties.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"id": 1, "name": "john"})
+//	object.NotContainsKeysOtherThan("id", "name", "email")
+func (o *Object) NotContainsKeysOtherThan(keys ...string) *Object {
+	opChain := o.chain.enter("NotContainsKeysOtherThan()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	allowed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		allowed[key] = true
+	}
+
+	var unexpected []string
+	for _, kv := range o.sortedKV() {
+		if !allowed[kv.key] {
+			unexpected = append(unexpected, kv.key)
+		}
+	}
+
+	if len(unexpected) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{keys},
+			Errors: []error{
+				errors.New("expected: map does not contain keys other than given"),
+				fmt.Errorf("unexpected keys: %q", unexpected),
+			},
+		})
+	}
+
+	return o
+}
+
 // ContainsValue succeeds if object contains given value with any key.
 // Before comparison, both object and value are converted to canonical form.
 //
@@ -1087,11 +1407,33 @@ func (o *Object) NotContainsValue(value interface{}) *Object {
 	return o
 }
 
+// SubsetOpts defines parameters for Object.ContainsSubset and
+// Object.NotContainsSubset.
+type SubsetOpts struct {
+	// If true, array in given value matches array in object if every element
+	// of the former matches some element of the latter, in any order.
+	// Elements are matched using the same rules, i.e. nested objects are
+	// also compared as subsets.
+	// By default, arrays should match exactly.
+	UnorderedArrays bool
+
+	// Paths of values that are ignored during comparison, in form like
+	// "$.meta.updated_at" or "$.items[*].id". Only dot-notation keys,
+	// array indexes, and "*" wildcard matching any key or index
+	// (as ".*" or "[*]") are supported. Paths refer to locations in
+	// object, not in given value.
+	IgnorePaths []string
+}
+
 // ContainsSubset succeeds if given value is a subset of object.
 // Before comparison, both object and value are converted to canonical form.
 //
 // value should be map[string]interface{} or struct.
 //
+// Optional SubsetOpts argument allows to match arrays as unordered subsets
+// and to ignore some paths. On failure, path of the first mismatching
+// value is reported.
+//
 // Example:
 //
 //	object := NewObject(t, map[string]interface{}{
@@ -1118,7 +1460,13 @@ func (o *Object) NotContainsValue(value interface{}) *Object {
 //	object.ContainsSubset(map[string]interface{}{  // failure, slices should match exactly
 //		"bar": []interface{}{"x"},
 //	})
-func (o *Object) ContainsSubset(value interface{}) *Object {
+//
+//	object.ContainsSubset(map[string]interface{}{  // success
+//		"bar": []interface{}{"y"},
+//	}, SubsetOpts{
+//		UnorderedArrays: true,
+//	})
+func (o *Object) ContainsSubset(value interface{}, options ...SubsetOpts) *Object {
 	opChain := o.chain.enter("ContainsSubset()")
 	defer opChain.leave()
 
@@ -1126,14 +1474,24 @@ func (o *Object) ContainsSubset(value interface{}) *Object {
 		return o
 	}
 
-	if !containsSubset(opChain, o.value, value) {
+	matcher, ok := newSubsetMatcher(opChain, options)
+	if !ok {
+		return o
+	}
+
+	if path, ok := containsSubset(opChain, o.value, value, matcher); !ok {
+		errs := []error{
+			errors.New("expected: map contains sub-map"),
+		}
+		if path != "" {
+			errs = append(errs, fmt.Errorf("first mismatch at path %s", path))
+		}
+
 		opChain.fail(AssertionFailure{
 			Type:     AssertContainsSubset,
 			Actual:   &AssertionValue{o.value},
 			Expected: &AssertionValue{value},
-			Errors: []error{
-				errors.New("expected: map contains sub-map"),
-			},
+			Errors:   errs,
 		})
 	}
 
@@ -1145,11 +1503,13 @@ func (o *Object) ContainsSubset(value interface{}) *Object {
 //
 // value should be map[string]interface{} or struct.
 //
+// Optional SubsetOpts argument is handled the same way as in ContainsSubset.
+//
 // Example:
 //
 //	object := NewObject(t, map[string]interface{}{"foo": 123, "bar": 456})
 //	object.NotContainsSubset(map[string]interface{}{"foo": 123, "bar": "no-no-no"})
-func (o *Object) NotContainsSubset(value interface{}) *Object {
+func (o *Object) NotContainsSubset(value interface{}, options ...SubsetOpts) *Object {
 	opChain := o.chain.enter("NotContainsSubset()")
 	defer opChain.leave()
 
@@ -1157,7 +1517,12 @@ func (o *Object) NotContainsSubset(value interface{}) *Object {
 		return o
 	}
 
-	if containsSubset(opChain, o.value, value) {
+	matcher, ok := newSubsetMatcher(opChain, options)
+	if !ok {
+		return o
+	}
+
+	if _, ok := containsSubset(opChain, o.value, value, matcher); ok {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotContainsSubset,
 			Actual:   &AssertionValue{o.value},
@@ -1211,6 +1576,19 @@ func containsKey(
 	return false
 }
 
+func matchingKeys(obj map[string]interface{}, rx *regexp.Regexp) []string {
+	var keys []string
+	for k := range obj {
+		if rx.MatchString(k) {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
 func containsValue(
 	opChain *chain, obj map[string]interface{}, val interface{},
 ) (string, bool) {
@@ -1230,35 +1608,263 @@ func containsValue(
 
 func containsSubset(
 	opChain *chain, obj map[string]interface{}, val interface{},
-) bool {
+	matcher *subsetMatcher,
+) (string, bool) {
 	canonVal, ok := canonMap(opChain, val)
 	if !ok {
-		return false
+		return "", false
 	}
 
-	return isSubset(obj, canonVal)
+	return matcher.match(nil, obj, canonVal, true)
 }
 
-func isSubset(outer, inner map[string]interface{}) bool {
-	for k, iv := range inner {
-		ov, ok := outer[k]
-		if !ok {
-			return false
+type subsetMatcher struct {
+	unorderedArrays bool
+	ignorePaths     [][]string
+}
+
+func newSubsetMatcher(opChain *chain, options []SubsetOpts) (*subsetMatcher, bool) {
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return nil, false
+	}
+
+	matcher := &subsetMatcher{}
+
+	if len(options) == 0 {
+		return matcher, true
+	}
+
+	matcher.unorderedArrays = options[0].UnorderedArrays
+
+	for _, path := range options[0].IgnorePaths {
+		segments, err := parseSubsetPath(path)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{path},
+				Errors: []error{
+					errors.New("expected: valid ignore path"),
+					err,
+				},
+			})
+			return nil, false
 		}
 
-		if ovm, ok := ov.(map[string]interface{}); ok {
-			if ivm, ok := iv.(map[string]interface{}); ok {
-				if !isSubset(ovm, ivm) {
-					return false
+		matcher.ignorePaths = append(matcher.ignorePaths, segments)
+	}
+
+	return matcher, true
+}
+
+// parseSubsetPath splits path like "$.foo[0].bar" into segments like
+// "foo", "[0]", "bar".
+func parseSubsetPath(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("path %q should start with \"$\"", path)
+	}
+
+	var segments []string
+
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("path %q contains empty key", path)
+			}
+			segments = append(segments, key)
+			rest = rest[end+1:]
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("path %q contains unclosed bracket", path)
+			}
+			index := rest[1:end]
+			if index != "*" {
+				if _, err := strconv.Atoi(index); err != nil {
+					return nil, fmt.Errorf("path %q contains invalid index %q",
+						path, index)
+				}
+			}
+			segments = append(segments, "["+index+"]")
+			rest = rest[end+1:]
+
+		default:
+			return nil, fmt.Errorf("path %q contains unexpected character %q",
+				path, rest[0])
+		}
+	}
+
+	return segments, nil
+}
+
+func formatSubsetPath(segments []string) string {
+	var b strings.Builder
+
+	b.WriteString("$")
+	for _, segment := range segments {
+		if !strings.HasPrefix(segment, "[") {
+			b.WriteString(".")
+		}
+		b.WriteString(segment)
+	}
+
+	return b.String()
+}
+
+func (m *subsetMatcher) isIgnored(segments []string) bool {
+	for _, pattern := range m.ignorePaths {
+		if len(pattern) != len(segments) {
+			continue
+		}
+
+		matched := true
+		for i := range pattern {
+			if pattern[i] == "*" || pattern[i] == "[*]" {
+				if pattern[i] == "[*]" && !strings.HasPrefix(segments[i], "[") {
+					matched = false
+					break
 				}
 				continue
 			}
+			if pattern[i] != segments[i] {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// match checks that expected value matches actual value and returns path
+// of the first mismatch. If isSubset is true, expected objects may have
+// less keys than actual objects.
+func (m *subsetMatcher) match(
+	path []string, actual, expected interface{}, isSubset bool,
+) (string, bool) {
+	if m.isIgnored(path) {
+		return "", true
+	}
+
+	switch exp := expected.(type) {
+	case map[string]interface{}:
+		act, ok := actual.(map[string]interface{})
+		if !ok {
+			return formatSubsetPath(path), false
+		}
+		return m.matchMap(path, act, exp, isSubset)
+
+	case []interface{}:
+		act, ok := actual.([]interface{})
+		if !ok {
+			return formatSubsetPath(path), false
+		}
+		if m.unorderedArrays {
+			return m.matchUnorderedArray(path, act, exp)
+		}
+		return m.matchArray(path, act, exp)
+
+	default:
+		if !reflect.DeepEqual(actual, expected) {
+			return formatSubsetPath(path), false
+		}
+		return "", true
+	}
+}
+
+func (m *subsetMatcher) matchMap(
+	path []string, actual, expected map[string]interface{}, isSubset bool,
+) (string, bool) {
+	keys := make([]string, 0, len(expected))
+	for k := range expected {
+		keys = append(keys, k)
+	}
+
+	if !isSubset {
+		for k := range actual {
+			if _, ok := expected[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		keyPath := append(path[:len(path):len(path)], k)
+
+		if m.isIgnored(keyPath) {
+			continue
+		}
+
+		av, aok := actual[k]
+		ev, eok := expected[k]
+		if !aok || !eok {
+			return formatSubsetPath(keyPath), false
+		}
+
+		if p, ok := m.match(keyPath, av, ev, isSubset); !ok {
+			return p, false
+		}
+	}
+
+	return "", true
+}
+
+func (m *subsetMatcher) matchArray(
+	path []string, actual, expected []interface{},
+) (string, bool) {
+	if len(actual) != len(expected) {
+		return formatSubsetPath(path), false
+	}
+
+	for i := range expected {
+		elemPath := append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i))
+
+		if p, ok := m.match(elemPath, actual[i], expected[i], false); !ok {
+			return p, false
+		}
+	}
+
+	return "", true
+}
+
+func (m *subsetMatcher) matchUnorderedArray(
+	path []string, actual, expected []interface{},
+) (string, bool) {
+	for _, elem := range expected {
+		found := false
+
+		for j := range actual {
+			elemPath := append(path[:len(path):len(path)], fmt.Sprintf("[%d]", j))
+
+			if _, ok := m.match(elemPath, actual[j], elem, true); ok {
+				found = true
+				break
+			}
 		}
 
-		if !reflect.DeepEqual(ov, iv) {
-			return false
+		if !found {
+			return formatSubsetPath(path), false
 		}
 	}
 
-	return true
+	return "", true
 }
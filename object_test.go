@@ -1,10 +1,13 @@
 package httpexpect
 
 import (
+	"errors"
+	"math"
 	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestObject_FailedChain(t *testing.T) {
@@ -14,6 +17,7 @@ func TestObject_FailedChain(t *testing.T) {
 		value.Path("$").chain.assert(t, failure)
 		value.Schema("")
 		value.Alias("foo")
+		value.Assert(nil)
 
 		var target interface{}
 		value.Decode(&target)
@@ -25,11 +29,16 @@ func TestObject_FailedChain(t *testing.T) {
 		value.IsEmpty()
 		value.NotEmpty()
 		value.IsEqual(nil)
+		value.IsEqualIgnoring(nil, nil)
+		value.IsEqualApprox(nil, 0)
 		value.NotEqual(nil)
 		value.InList(nil)
 		value.NotInList(nil)
 		value.ContainsKey("foo")
 		value.NotContainsKey("foo")
+		value.ContainsKeyMatching("foo")
+		value.NotContainsKeyMatching("foo")
+		value.NotContainsKeysOtherThan("foo")
 		value.ContainsValue("foo")
 		value.NotContainsValue("foo")
 		value.ContainsSubset(nil)
@@ -274,6 +283,43 @@ func TestObject_Alias(t *testing.T) {
 	assert.Equal(t, []string{"bar", "Values()"}, childValue.chain.context.AliasedPath)
 }
 
+func TestObject_Warn(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	object := NewObject(reporter, map[string]interface{}{"foo": 123})
+
+	warn := object.Warn()
+	warn.ContainsKey("bar")
+
+	warn.chain.assert(t, failure)
+	object.chain.assert(t, success)
+
+	assert.False(t, reporter.reported)
+}
+
+func TestObject_Assert(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	var actual map[string]interface{}
+	NewObject(reporter, map[string]interface{}{"foo": 123}).
+		Assert(func(m map[string]interface{}) error {
+			actual = m
+			return nil
+		}).
+		chain.assert(t, success)
+	assert.Equal(t, map[string]interface{}{"foo": 123.0}, actual)
+
+	NewObject(reporter, map[string]interface{}{"foo": 123}).
+		Assert(func(m map[string]interface{}) error {
+			return errors.New("custom error")
+		}).
+		chain.assert(t, failure)
+
+	NewObject(reporter, map[string]interface{}{"foo": 123}).
+		Assert(nil).
+		chain.assert(t, failure)
+}
+
 func TestObject_Path(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -503,6 +549,286 @@ func TestObject_IsEqual(t *testing.T) {
 	})
 }
 
+func TestObject_IsEqualIgnoring(t *testing.T) {
+	value := map[string]interface{}{
+		"id":   "5f2b",
+		"name": "john",
+		"tags": []interface{}{
+			map[string]interface{}{"id": 1, "name": "admin"},
+			map[string]interface{}{"id": 2, "name": "dev"},
+		},
+	}
+
+	t.Run("basic", func(t *testing.T) {
+		cases := []struct {
+			name      string
+			paths     []string
+			testValue map[string]interface{}
+			result    chainResult
+		}{
+			{
+				name:      "no paths, not equal",
+				paths:     nil,
+				testValue: map[string]interface{}{"name": "john"},
+				result:    failure,
+			},
+			{
+				name:  "no paths, equal",
+				paths: nil,
+				testValue: map[string]interface{}{
+					"id":   "5f2b",
+					"name": "john",
+					"tags": []interface{}{
+						map[string]interface{}{"id": 1, "name": "admin"},
+						map[string]interface{}{"id": 2, "name": "dev"},
+					},
+				},
+				result: success,
+			},
+			{
+				name:  "ignored fields missing in value",
+				paths: []string{"$.id", "$.tags[*].id"},
+				testValue: map[string]interface{}{
+					"name": "john",
+					"tags": []interface{}{
+						map[string]interface{}{"name": "admin"},
+						map[string]interface{}{"name": "dev"},
+					},
+				},
+				result: success,
+			},
+			{
+				name:  "ignored fields differ in value",
+				paths: []string{"$..id"},
+				testValue: map[string]interface{}{
+					"id":   "0000",
+					"name": "john",
+					"tags": []interface{}{
+						map[string]interface{}{"id": 10, "name": "admin"},
+						map[string]interface{}{"id": 20, "name": "dev"},
+					},
+				},
+				result: success,
+			},
+			{
+				name:  "ignored element",
+				paths: []string{"$.id", "$.tags[1]"},
+				testValue: map[string]interface{}{
+					"name": "john",
+					"tags": []interface{}{
+						map[string]interface{}{"id": 1, "name": "admin"},
+						"anything",
+					},
+				},
+				result: success,
+			},
+			{
+				name:  "non-ignored field differs",
+				paths: []string{"$.id", "$.tags[*].id"},
+				testValue: map[string]interface{}{
+					"name": "bob",
+					"tags": []interface{}{
+						map[string]interface{}{"name": "admin"},
+						map[string]interface{}{"name": "dev"},
+					},
+				},
+				result: failure,
+			},
+			{
+				name:  "non-ignored field missing",
+				paths: []string{"$.id"},
+				testValue: map[string]interface{}{
+					"name": "john",
+				},
+				result: failure,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				NewObject(reporter, value).IsEqualIgnoring(tc.paths, tc.testValue).
+					chain.assert(t, tc.result)
+			})
+		}
+	})
+
+	t.Run("mismatch path", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewObjectC(Config{
+			AssertionHandler: handler,
+		}, value).IsEqualIgnoring([]string{"$.id"}, map[string]interface{}{
+			"name": "john",
+			"tags": []interface{}{
+				map[string]interface{}{"id": 1, "name": "admin"},
+				map[string]interface{}{"id": 2, "name": "qa"},
+			},
+		})
+
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Equal(t, AssertEqual, handler.failure.Type)
+		assert.Equal(t, 2, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[1].Error(), "$.tags[1].name")
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		type S struct {
+			Name string `json:"name"`
+		}
+
+		NewObject(reporter, map[string]interface{}{"id": 1, "name": "john"}).
+			IsEqualIgnoring([]string{"$.id"}, S{Name: "john"}).
+			chain.assert(t, success)
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewObject(reporter, value).
+			IsEqualIgnoring([]string{"id"}, value).
+			chain.assert(t, failure)
+
+		NewObject(reporter, value).
+			IsEqualIgnoring([]string{"$.tags["}, value).
+			chain.assert(t, failure)
+	})
+}
+
+func TestObject_IsEqualApprox(t *testing.T) {
+	value := map[string]interface{}{
+		"name":  "berlin",
+		"score": 0.30000000000000004,
+		"location": map[string]interface{}{
+			"lat": 52.52000659,
+			"lon": 13.40495399,
+		},
+		"history": []interface{}{1.001, 2.002},
+	}
+
+	t.Run("basic", func(t *testing.T) {
+		cases := []struct {
+			name      string
+			testValue map[string]interface{}
+			epsilon   float64
+			result    chainResult
+		}{
+			{
+				name: "within epsilon",
+				testValue: map[string]interface{}{
+					"name":  "berlin",
+					"score": 0.3,
+					"location": map[string]interface{}{
+						"lat": 52.52,
+						"lon": 13.405,
+					},
+					"history": []interface{}{1.0, 2.0},
+				},
+				epsilon: 0.01,
+				result:  success,
+			},
+			{
+				name: "outside epsilon",
+				testValue: map[string]interface{}{
+					"name":  "berlin",
+					"score": 0.3,
+					"location": map[string]interface{}{
+						"lat": 52.52,
+						"lon": 13.405,
+					},
+					"history": []interface{}{1.0, 2.0},
+				},
+				epsilon: 0.0001,
+				result:  failure,
+			},
+			{
+				name: "zero epsilon",
+				testValue: map[string]interface{}{
+					"name":  "berlin",
+					"score": 0.3,
+					"location": map[string]interface{}{
+						"lat": 52.52000659,
+						"lon": 13.40495399,
+					},
+					"history": []interface{}{1.001, 2.002},
+				},
+				epsilon: 0,
+				result:  failure,
+			},
+			{
+				name: "different string",
+				testValue: map[string]interface{}{
+					"name":  "paris",
+					"score": 0.3,
+					"location": map[string]interface{}{
+						"lat": 52.52,
+						"lon": 13.405,
+					},
+					"history": []interface{}{1.0, 2.0},
+				},
+				epsilon: 1,
+				result:  failure,
+			},
+			{
+				name: "missing key",
+				testValue: map[string]interface{}{
+					"name":  "berlin",
+					"score": 0.3,
+					"location": map[string]interface{}{
+						"lat": 52.52,
+					},
+					"history": []interface{}{1.0, 2.0},
+				},
+				epsilon: 1,
+				result:  failure,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				NewObject(reporter, value).IsEqualApprox(tc.testValue, tc.epsilon).
+					chain.assert(t, tc.result)
+			})
+		}
+	})
+
+	t.Run("mismatch path", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewObjectC(Config{
+			AssertionHandler: handler,
+		}, value).IsEqualApprox(map[string]interface{}{
+			"name":  "berlin",
+			"score": 0.3,
+			"location": map[string]interface{}{
+				"lat": 52.52,
+				"lon": 14.0,
+			},
+			"history": []interface{}{1.0, 2.0},
+		}, 0.01)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Equal(t, AssertEqual, handler.failure.Type)
+		assert.Equal(t, &AssertionValue{0.01}, handler.failure.Delta)
+		assert.Equal(t, 2, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[1].Error(), "$.location.lon")
+	})
+
+	t.Run("invalid epsilon", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		for _, epsilon := range []float64{-1, math.NaN(), math.Inf(1)} {
+			NewObject(reporter, value).IsEqualApprox(value, epsilon).
+				chain.assert(t, failure)
+		}
+	})
+}
+
 func TestObject_InList(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
 		cases := []struct {
@@ -688,6 +1014,117 @@ func TestObject_ContainsKey(t *testing.T) {
 	}
 }
 
+func TestObject_ContainsKeyMatching(t *testing.T) {
+	testObj := map[string]interface{}{"x-request-id": "abc", "name": "john"}
+
+	cases := []struct {
+		name                       string
+		object                     map[string]interface{}
+		re                         string
+		wantContainsKeyMatching    chainResult
+		wantNotContainsKeyMatching chainResult
+	}{
+		{
+			name:                       "prefix matches",
+			object:                     testObj,
+			re:                         `^x-`,
+			wantContainsKeyMatching:    success,
+			wantNotContainsKeyMatching: failure,
+		},
+		{
+			name:                       "full match",
+			object:                     testObj,
+			re:                         `^name$`,
+			wantContainsKeyMatching:    success,
+			wantNotContainsKeyMatching: failure,
+		},
+		{
+			name:                       "no match",
+			object:                     testObj,
+			re:                         `(?i)password`,
+			wantContainsKeyMatching:    failure,
+			wantNotContainsKeyMatching: success,
+		},
+		{
+			name:                       "empty object",
+			object:                     map[string]interface{}{},
+			re:                         `.*`,
+			wantContainsKeyMatching:    failure,
+			wantNotContainsKeyMatching: success,
+		},
+		{
+			name:                       "invalid regexp",
+			object:                     testObj,
+			re:                         `[`,
+			wantContainsKeyMatching:    failure,
+			wantNotContainsKeyMatching: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewObject(reporter, tc.object).ContainsKeyMatching(tc.re).
+				chain.assert(t, tc.wantContainsKeyMatching)
+
+			NewObject(reporter, tc.object).NotContainsKeyMatching(tc.re).
+				chain.assert(t, tc.wantNotContainsKeyMatching)
+		})
+	}
+}
+
+func TestObject_NotContainsKeysOtherThan(t *testing.T) {
+	testObj := map[string]interface{}{"id": 1, "name": "john"}
+
+	cases := []struct {
+		name       string
+		object     map[string]interface{}
+		keys       []string
+		wantResult chainResult
+	}{
+		{
+			name:       "exact keys",
+			object:     testObj,
+			keys:       []string{"id", "name"},
+			wantResult: success,
+		},
+		{
+			name:       "superset of keys",
+			object:     testObj,
+			keys:       []string{"email", "id", "name"},
+			wantResult: success,
+		},
+		{
+			name:       "empty object",
+			object:     map[string]interface{}{},
+			keys:       nil,
+			wantResult: success,
+		},
+		{
+			name:       "unexpected key",
+			object:     testObj,
+			keys:       []string{"id"},
+			wantResult: failure,
+		},
+		{
+			name:       "no keys allowed",
+			object:     testObj,
+			keys:       nil,
+			wantResult: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewObject(reporter, tc.object).NotContainsKeysOtherThan(tc.keys...).
+				chain.assert(t, tc.wantResult)
+		})
+	}
+}
+
 func TestObject_ContainsValue(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
 		testObj := map[string]interface{}{"foo": 123, "bar": "xxx"}
@@ -1114,6 +1551,266 @@ func TestObject_ContainsSubset(t *testing.T) {
 	})
 }
 
+func TestObject_ContainsSubsetOpts(t *testing.T) {
+	testObj := map[string]interface{}{
+		"id": 1,
+		"tags": []interface{}{
+			"a", "b", "c",
+		},
+		"items": []interface{}{
+			map[string]interface{}{"id": 10, "name": "foo", "qty": 1},
+			map[string]interface{}{"id": 20, "name": "bar", "qty": 2},
+		},
+		"meta": map[string]interface{}{
+			"created_at": "2024-01-01",
+			"updated_at": "2024-02-01",
+		},
+	}
+
+	cases := []struct {
+		name                  string
+		subset                map[string]interface{}
+		opts                  SubsetOpts
+		wantContainsSubset    chainResult
+		wantNotContainsSubset chainResult
+	}{
+		{
+			name: "ordered arrays, partial array",
+			subset: map[string]interface{}{
+				"tags": []interface{}{"c", "a"},
+			},
+			opts:                  SubsetOpts{},
+			wantContainsSubset:    failure,
+			wantNotContainsSubset: success,
+		},
+		{
+			name: "unordered arrays, partial array",
+			subset: map[string]interface{}{
+				"tags": []interface{}{"c", "a"},
+			},
+			opts: SubsetOpts{
+				UnorderedArrays: true,
+			},
+			wantContainsSubset:    success,
+			wantNotContainsSubset: failure,
+		},
+		{
+			name: "unordered arrays, missing element",
+			subset: map[string]interface{}{
+				"tags": []interface{}{"c", "d"},
+			},
+			opts: SubsetOpts{
+				UnorderedArrays: true,
+			},
+			wantContainsSubset:    failure,
+			wantNotContainsSubset: success,
+		},
+		{
+			name: "unordered arrays, partial nested objects",
+			subset: map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"name": "bar"},
+				},
+			},
+			opts: SubsetOpts{
+				UnorderedArrays: true,
+			},
+			wantContainsSubset:    success,
+			wantNotContainsSubset: failure,
+		},
+		{
+			name: "ignore paths",
+			subset: map[string]interface{}{
+				"id": 2,
+				"meta": map[string]interface{}{
+					"created_at": "2024-01-01",
+					"updated_at": "2000-01-01",
+				},
+			},
+			opts: SubsetOpts{
+				IgnorePaths: []string{"$.id", "$.meta.updated_at"},
+			},
+			wantContainsSubset:    success,
+			wantNotContainsSubset: failure,
+		},
+		{
+			name: "ignore paths, missing key",
+			subset: map[string]interface{}{
+				"meta": map[string]interface{}{
+					"deleted_at": "2024-01-01",
+				},
+			},
+			opts: SubsetOpts{
+				IgnorePaths: []string{"$.meta.*"},
+			},
+			wantContainsSubset:    success,
+			wantNotContainsSubset: failure,
+		},
+		{
+			name: "ignore paths with index wildcard",
+			subset: map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"id": 10, "name": "foo", "qty": 100},
+					map[string]interface{}{"id": 20, "name": "bar", "qty": 200},
+				},
+			},
+			opts: SubsetOpts{
+				IgnorePaths: []string{"$.items[*].qty"},
+			},
+			wantContainsSubset:    success,
+			wantNotContainsSubset: failure,
+		},
+		{
+			name: "ignore paths with index",
+			subset: map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"id": 10, "name": "foo", "qty": 100},
+					map[string]interface{}{"id": 20, "name": "bar", "qty": 200},
+				},
+			},
+			opts: SubsetOpts{
+				IgnorePaths: []string{"$.items[0].qty"},
+			},
+			wantContainsSubset:    failure,
+			wantNotContainsSubset: success,
+		},
+		{
+			name: "ignore paths, not ignored key",
+			subset: map[string]interface{}{
+				"id": 2,
+			},
+			opts: SubsetOpts{
+				IgnorePaths: []string{"$.meta.id", "$.items[*].id"},
+			},
+			wantContainsSubset:    failure,
+			wantNotContainsSubset: success,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewObject(reporter, testObj).ContainsSubset(tc.subset, tc.opts).
+				chain.assert(t, tc.wantContainsSubset)
+
+			NewObject(reporter, testObj).NotContainsSubset(tc.subset, tc.opts).
+				chain.assert(t, tc.wantNotContainsSubset)
+		})
+	}
+
+	t.Run("mismatch path", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			subset   map[string]interface{}
+			opts     SubsetOpts
+			wantPath string
+		}{
+			{
+				name: "missing key",
+				subset: map[string]interface{}{
+					"meta": map[string]interface{}{
+						"deleted_at": "2024-01-01",
+					},
+				},
+				wantPath: "$.meta.deleted_at",
+			},
+			{
+				name: "nested value in array",
+				subset: map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"id": 10, "name": "foo", "qty": 1},
+						map[string]interface{}{"id": 20, "name": "baz", "qty": 2},
+					},
+				},
+				wantPath: "$.items[1].name",
+			},
+			{
+				name: "extra key in array element",
+				subset: map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"id": 10, "name": "foo"},
+						map[string]interface{}{"id": 20, "name": "bar", "qty": 2},
+					},
+				},
+				wantPath: "$.items[0].qty",
+			},
+			{
+				name: "array length",
+				subset: map[string]interface{}{
+					"tags": []interface{}{"a", "b"},
+				},
+				wantPath: "$.tags",
+			},
+			{
+				name: "unordered array",
+				subset: map[string]interface{}{
+					"tags": []interface{}{"a", "x"},
+				},
+				opts: SubsetOpts{
+					UnorderedArrays: true,
+				},
+				wantPath: "$.tags",
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				handler := &mockAssertionHandler{}
+
+				NewObjectC(Config{
+					AssertionHandler: handler,
+				}, testObj).ContainsSubset(tc.subset, tc.opts)
+
+				assert.Equal(t, 1, handler.failureCalled)
+				require.Equal(t, 2, len(handler.failure.Errors))
+				assert.Equal(t, "first mismatch at path "+tc.wantPath,
+					handler.failure.Errors[1].Error())
+			})
+		}
+	})
+
+	t.Run("invalid opts", func(t *testing.T) {
+		cases := []struct {
+			name string
+			opts []SubsetOpts
+		}{
+			{
+				name: "multiple opts",
+				opts: []SubsetOpts{{}, {}},
+			},
+			{
+				name: "missing root",
+				opts: []SubsetOpts{{IgnorePaths: []string{"id"}}},
+			},
+			{
+				name: "empty key",
+				opts: []SubsetOpts{{IgnorePaths: []string{"$..id"}}},
+			},
+			{
+				name: "unclosed bracket",
+				opts: []SubsetOpts{{IgnorePaths: []string{"$.items[0"}}},
+			},
+			{
+				name: "invalid index",
+				opts: []SubsetOpts{{IgnorePaths: []string{"$.items[x]"}}},
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				NewObject(reporter, testObj).ContainsSubset(testObj, tc.opts...).
+					chain.assert(t, failure)
+
+				NewObject(reporter, testObj).NotContainsSubset(testObj, tc.opts...).
+					chain.assert(t, failure)
+			})
+		}
+	})
+}
+
 func TestObject_HasValue(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
 		testObj := map[string]interface{}{
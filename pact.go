@@ -0,0 +1,820 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Pact is a consumer-driven contract, loaded from Pact file.
+// See LoadPact and Expect.VerifyPact.
+type Pact struct {
+	// Names of consumer and provider.
+	Consumer string
+	Provider string
+
+	// Interactions expected by consumer.
+	Interactions []PactInteraction
+}
+
+// PactInteraction defines a request sent by consumer and a response
+// expected from provider.
+type PactInteraction struct {
+	// Interaction description, unique within Pact.
+	Description string
+
+	// Provider states required by interaction, see PactOpts.StateHandler.
+	ProviderStates []PactProviderState
+
+	Request  PactRequest
+	Response PactResponse
+}
+
+// PactProviderState defines state of provider required by interaction.
+type PactProviderState struct {
+	Name   string
+	Params map[string]interface{}
+}
+
+// PactRequest defines request of PactInteraction.
+type PactRequest struct {
+	Method  string
+	Path    string
+	Query   url.Values
+	Headers map[string]string
+
+	// Raw JSON value of request body, or nil if request has no body.
+	// If body is a JSON string and Content-Type is not JSON, the string
+	// is sent as is.
+	Body json.RawMessage
+}
+
+// PactResponse defines response of PactInteraction expected by consumer.
+type PactResponse struct {
+	Status  int
+	Headers map[string]string
+
+	// Raw JSON value of expected response body, or nil if body should
+	// not be checked.
+	Body json.RawMessage
+
+	// Rules for matching response body.
+	MatchingRules []PactMatchingRule
+}
+
+// PactMatchingRule defines how response body values at given path are
+// matched.
+//
+// Supported matchers are "equality", "type" (with optional Min and Max
+// for arrays), "regex", "integer", "decimal", and "number".
+type PactMatchingRule struct {
+	// JSON path relative to body, e.g. "$.items[*].id".
+	Path string
+
+	// Matcher name.
+	Match string
+
+	// Regular expression for "regex" matcher.
+	Regex string
+
+	// Minimum and maximum array length for "type" matcher.
+	// Zero means no limit.
+	Min int
+	Max int
+}
+
+// PactOpts defines parameters for Expect.VerifyPact.
+type PactOpts struct {
+	// Invoked before every interaction for each of its provider states,
+	// to set up provider. If it returns error, interaction fails.
+	// If nil, provider states are ignored.
+	StateHandler func(state PactProviderState) error
+}
+
+// Raw Pact file, specification versions 2 and 3.
+type pactFile struct {
+	Consumer struct {
+		Name string `json:"name"`
+	} `json:"consumer"`
+
+	Provider struct {
+		Name string `json:"name"`
+	} `json:"provider"`
+
+	Interactions []struct {
+		Description    string              `json:"description"`
+		ProviderState  string              `json:"providerState"`
+		ProviderStates []PactProviderState `json:"providerStates"`
+
+		Request struct {
+			Method  string                     `json:"method"`
+			Path    string                     `json:"path"`
+			Query   json.RawMessage            `json:"query"`
+			Headers map[string]json.RawMessage `json:"headers"`
+			Body    json.RawMessage            `json:"body"`
+		} `json:"request"`
+
+		Response struct {
+			Status        int                        `json:"status"`
+			Headers       map[string]json.RawMessage `json:"headers"`
+			Body          json.RawMessage            `json:"body"`
+			MatchingRules map[string]json.RawMessage `json:"matchingRules"`
+		} `json:"response"`
+	} `json:"interactions"`
+}
+
+// LoadPact reads and parses Pact file.
+// See ParsePact for supported format.
+func LoadPact(path string) (*Pact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePact(data)
+}
+
+// ParsePact parses Pact file contents.
+//
+// Pact specification versions 2 and 3 are supported. Only HTTP
+// interactions and matching rules for response body are used.
+// Every interaction should have unique non-empty description, method,
+// path, and status.
+func ParsePact(data []byte) (*Pact, error) {
+	var file pactFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	pact := &Pact{
+		Consumer: file.Consumer.Name,
+		Provider: file.Provider.Name,
+	}
+
+	descriptions := make(map[string]bool)
+
+	for _, raw := range file.Interactions {
+		switch {
+		case raw.Description == "":
+			return nil, errors.New("interaction without description")
+		case descriptions[raw.Description]:
+			return nil, fmt.Errorf("duplicate interaction %q", raw.Description)
+		case raw.Request.Method == "" || raw.Request.Path == "":
+			return nil, fmt.Errorf("interaction %q: missing request method or path",
+				raw.Description)
+		case raw.Response.Status == 0:
+			return nil, fmt.Errorf("interaction %q: missing response status",
+				raw.Description)
+		}
+		descriptions[raw.Description] = true
+
+		interaction := PactInteraction{
+			Description:    raw.Description,
+			ProviderStates: raw.ProviderStates,
+			Request: PactRequest{
+				Method: strings.ToUpper(raw.Request.Method),
+				Path:   raw.Request.Path,
+				Body:   compactPactBody(raw.Request.Body),
+			},
+			Response: PactResponse{
+				Status: raw.Response.Status,
+				Body:   compactPactBody(raw.Response.Body),
+			},
+		}
+
+		if raw.ProviderState != "" {
+			interaction.ProviderStates = append(interaction.ProviderStates,
+				PactProviderState{Name: raw.ProviderState})
+		}
+
+		var err error
+
+		if interaction.Request.Query, err = parsePactQuery(raw.Request.Query); err != nil {
+			return nil, fmt.Errorf("interaction %q: invalid query: %w",
+				raw.Description, err)
+		}
+
+		if interaction.Request.Headers, err =
+			parsePactHeaders(raw.Request.Headers); err != nil {
+			return nil, fmt.Errorf("interaction %q: invalid request headers: %w",
+				raw.Description, err)
+		}
+
+		if interaction.Response.Headers, err =
+			parsePactHeaders(raw.Response.Headers); err != nil {
+			return nil, fmt.Errorf("interaction %q: invalid response headers: %w",
+				raw.Description, err)
+		}
+
+		if interaction.Response.MatchingRules, err =
+			parsePactRules(raw.Response.MatchingRules); err != nil {
+			return nil, fmt.Errorf("interaction %q: invalid matching rules: %w",
+				raw.Description, err)
+		}
+
+		pact.Interactions = append(pact.Interactions, interaction)
+	}
+
+	return pact, nil
+}
+
+func compactPactBody(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return raw
+	}
+
+	return buf.Bytes()
+}
+
+// Query is a string in v2 and an object with string arrays in v3.
+func parsePactQuery(raw json.RawMessage) (url.Values, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return url.ParseQuery(str)
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+
+	query := make(url.Values, len(obj))
+	for key, value := range obj {
+		var values []string
+		if err := json.Unmarshal(value, &values); err != nil {
+			var single string
+			if err := json.Unmarshal(value, &single); err != nil {
+				return nil, fmt.Errorf("parameter %q: %w", key, err)
+			}
+			values = []string{single}
+		}
+		query[key] = values
+	}
+
+	return query, nil
+}
+
+// Header value is a string, or an array of strings in later versions.
+func parsePactHeaders(raw map[string]json.RawMessage) (map[string]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	headers := make(map[string]string, len(raw))
+	for key, value := range raw {
+		var str string
+		if err := json.Unmarshal(value, &str); err == nil {
+			headers[key] = str
+			continue
+		}
+
+		var values []string
+		if err := json.Unmarshal(value, &values); err != nil {
+			return nil, fmt.Errorf("header %q: %w", key, err)
+		}
+		headers[key] = strings.Join(values, ", ")
+	}
+
+	return headers, nil
+}
+
+type pactRawRule struct {
+	Match string `json:"match"`
+	Regex string `json:"regex"`
+	Min   int    `json:"min"`
+	Max   int    `json:"max"`
+}
+
+// In v2, rules are keyed by paths like "$.body.id".
+// In v3, rules for body are stored in "body" object keyed by paths
+// like "$.id", and every path has a list of matchers.
+func parsePactRules(raw map[string]json.RawMessage) ([]PactMatchingRule, error) {
+	var rules []PactMatchingRule
+
+	addRule := func(path string, r pactRawRule) error {
+		if r.Match == "" && r.Regex != "" {
+			r.Match = "regex"
+		}
+		if _, err := parseIgnorePath(path); err != nil {
+			return err
+		}
+		if r.Match == "regex" {
+			if _, err := regexp.Compile(r.Regex); err != nil {
+				return err
+			}
+		}
+		rules = append(rules, PactMatchingRule{
+			Path:  path,
+			Match: r.Match,
+			Regex: r.Regex,
+			Min:   r.Min,
+			Max:   r.Max,
+		})
+		return nil
+	}
+
+	if body, ok := raw["body"]; ok {
+		var paths map[string]struct {
+			Matchers []pactRawRule `json:"matchers"`
+		}
+		if err := json.Unmarshal(body, &paths); err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(paths))
+		for path := range paths {
+			keys = append(keys, path)
+		}
+		sort.Strings(keys)
+
+		for _, path := range keys {
+			for _, r := range paths[path].Matchers {
+				if err := addRule(path, r); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return rules, nil
+	}
+
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key != "$.body" && !strings.HasPrefix(key, "$.body.") &&
+			!strings.HasPrefix(key, "$.body[") {
+			continue
+		}
+
+		var r pactRawRule
+		if err := json.Unmarshal(raw[key], &r); err != nil {
+			return nil, err
+		}
+		if err := addRule("$"+strings.TrimPrefix(key, "$.body"), r); err != nil {
+			return nil, err
+		}
+	}
+
+	return rules, nil
+}
+
+// VerifyPact loads Pact file and verifies provider against it.
+//
+// Every interaction is sent through this Expect instance, so Config.BaseURL
+// should point to provider, and all configured builders, matchers,
+// printers, and retries are applied. Response status, headers, and body
+// are checked according to the contract, and failures are reported via
+// AssertionHandler, with interaction description used as request name.
+//
+// Unlike RunScenario, all interactions are verified even if some of them
+// fail. Returns map of responses, with interaction descriptions as keys.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, providerURL)
+//
+//	e.VerifyPact("pacts/frontend-users.json", httpexpect.PactOpts{
+//		StateHandler: func(state httpexpect.PactProviderState) error {
+//			return db.Load(state.Name)
+//		},
+//	})
+func (e *Expect) VerifyPact(path string, opts ...PactOpts) map[string]*Response {
+	opChain := e.chain.enter("VerifyPact(%q)", path)
+	defer opChain.leave()
+
+	pact, err := LoadPact(path)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to load pact"),
+				err,
+			},
+		})
+		return map[string]*Response{}
+	}
+
+	return e.verifyPact(opChain, pact, opts)
+}
+
+// VerifyPactContract is like VerifyPact, but verifies provider against
+// already loaded Pact.
+//
+// Example:
+//
+//	pact, err := httpexpect.ParsePact(data)
+//	require.NoError(t, err)
+//
+//	e := httpexpect.Default(t, providerURL)
+//	e.VerifyPactContract(pact)
+func (e *Expect) VerifyPactContract(
+	pact *Pact, opts ...PactOpts,
+) map[string]*Response {
+	opChain := e.chain.enter("VerifyPactContract()")
+	defer opChain.leave()
+
+	if pact == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return map[string]*Response{}
+	}
+
+	return e.verifyPact(opChain, pact, opts)
+}
+
+func (e *Expect) verifyPact(
+	opChain *chain, pact *Pact, opts []PactOpts,
+) map[string]*Response {
+	if len(opts) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple opts arguments"),
+			},
+		})
+		return map[string]*Response{}
+	}
+
+	var options PactOpts
+	if len(opts) != 0 {
+		options = opts[0]
+	}
+
+	resps := make(map[string]*Response, len(pact.Interactions))
+
+	for _, interaction := range pact.Interactions {
+		resps[interaction.Description] =
+			e.verifyPactInteraction(opChain, interaction, options)
+	}
+
+	return resps
+}
+
+func (e *Expect) verifyPactInteraction(
+	opChain *chain, interaction PactInteraction, opts PactOpts,
+) *Response {
+	spec := interaction.Request
+
+	req := e.newRequest(opChain, spec.Method, spec.Path).
+		WithName(interaction.Description)
+
+	if opts.StateHandler != nil {
+		for _, state := range interaction.ProviderStates {
+			if err := opts.StateHandler(state); err != nil {
+				stateChain := req.chain.enter("ProviderState(%q)", state.Name)
+				stateChain.fail(AssertionFailure{
+					Type: AssertOperation,
+					Errors: []error{
+						fmt.Errorf("failed to set up provider state %q", state.Name),
+						err,
+					},
+				})
+				stateChain.leave()
+				break
+			}
+		}
+	}
+
+	for _, key := range sortedQueryKeys(spec.Query) {
+		for _, value := range spec.Query[key] {
+			req.WithQuery(key, value)
+		}
+	}
+
+	for _, key := range sortedKeys(spec.Headers) {
+		req.WithHeader(key, spec.Headers[key])
+	}
+
+	if len(spec.Body) != 0 {
+		var text string
+		if json.Unmarshal(spec.Body, &text) == nil &&
+			!isJSONMediaType(spec.Headers["Content-Type"]) {
+			req.WithText(text)
+		} else {
+			if _, ok := spec.Headers["Content-Type"]; !ok {
+				req.WithHeader("Content-Type", "application/json")
+			}
+			req.WithBytes(spec.Body)
+		}
+	}
+
+	resp := req.Expect()
+
+	expected := interaction.Response
+
+	resp.Status(expected.Status)
+
+	for _, key := range sortedKeys(expected.Headers) {
+		value := expected.Headers[key]
+
+		if strings.EqualFold(key, "Content-Type") {
+			if mediaType, params, err := mime.ParseMediaType(value); err == nil {
+				if charset, ok := params["charset"]; ok {
+					resp.HasContentType(mediaType, charset)
+				} else {
+					resp.HasContentType(mediaType)
+				}
+				continue
+			}
+		}
+
+		resp.Header(key).IsEqual(value)
+	}
+
+	if len(expected.Body) != 0 {
+		resp.matchPactBody(expected.Body, expected.MatchingRules)
+	}
+
+	return resp
+}
+
+func isJSONMediaType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	return mediaType == "application/json" ||
+		strings.HasSuffix(mediaType, "+json")
+}
+
+// Check response body against expected body from Pact.
+func (r *Response) matchPactBody(expected json.RawMessage, rules []PactMatchingRule) {
+	opChain := r.chain.enter("MatchPactBody()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return
+	}
+
+	var expectedValue interface{}
+	if err := json.Unmarshal(expected, &expectedValue); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid expected body in pact"),
+				err,
+			},
+		})
+		return
+	}
+
+	content, ok := r.getContent(opChain, "MatchPactBody()")
+	if !ok {
+		return
+	}
+
+	// plain text body
+	if text, ok := expectedValue.(string); ok && !isJSONContent(r.httpResp.Header) {
+		if string(content) != text {
+			opChain.fail(AssertionFailure{
+				Type:     AssertEqual,
+				Actual:   &AssertionValue{string(content)},
+				Expected: &AssertionValue{text},
+				Errors: []error{
+					errors.New("expected: response body matches pact"),
+				},
+			})
+		}
+		return
+	}
+
+	var actualValue interface{}
+	if err := json.Unmarshal(content, &actualValue); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(content)},
+			Errors: []error{
+				errors.New("failed to decode json"),
+				err,
+			},
+		})
+		return
+	}
+
+	matcher, err := newPactMatcher(rules)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid matching rules in pact"),
+				err,
+			},
+		})
+		return
+	}
+
+	if path, err := matcher.match("$", nil, expectedValue, actualValue, false); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actualValue},
+			Expected: &AssertionValue{expectedValue},
+			Errors: []error{
+				errors.New("expected: response body matches pact"),
+				fmt.Errorf("mismatch at %s: %w", path, err),
+			},
+		})
+	}
+}
+
+type pactMatcher struct {
+	rules    []PactMatchingRule
+	patterns [][]ignorePathToken
+}
+
+func newPactMatcher(rules []PactMatchingRule) (*pactMatcher, error) {
+	m := &pactMatcher{rules: rules}
+
+	for _, rule := range rules {
+		pattern, err := parseIgnorePath(rule.Path)
+		if err != nil {
+			return nil, err
+		}
+		m.patterns = append(m.patterns, pattern)
+	}
+
+	return m, nil
+}
+
+// Match actual value against expected value. Objects may contain extra
+// fields, arrays should have same length unless matched by type.
+// Returns JSON path and description of the first mismatch.
+func (m *pactMatcher) match(
+	path string, segments []ignorePathSegment,
+	expected, actual interface{}, byType bool,
+) (string, error) {
+	for n, rule := range m.rules {
+		if !matchIgnorePath(m.patterns[n], segments) {
+			continue
+		}
+
+		switch rule.Match {
+		case "equality":
+			byType = false
+
+		case "type":
+			byType = true
+
+			if arr, ok := actual.([]interface{}); ok {
+				if rule.Min > 0 && len(arr) < rule.Min {
+					return path, fmt.Errorf("expected at least %d element(s), got %d",
+						rule.Min, len(arr))
+				}
+				if rule.Max > 0 && len(arr) > rule.Max {
+					return path, fmt.Errorf("expected at most %d element(s), got %d",
+						rule.Max, len(arr))
+				}
+			}
+
+		case "regex":
+			str, ok := actual.(string)
+			if !ok {
+				return path, fmt.Errorf("expected string, got %s", jsonKind(actual))
+			}
+			re := regexp.MustCompile("^(?:" + rule.Regex + ")$")
+			if !re.MatchString(str) {
+				return path, fmt.Errorf("%q doesn't match regex %q", str, rule.Regex)
+			}
+			return "", nil
+
+		case "integer", "decimal", "number":
+			num, ok := actual.(float64)
+			if !ok {
+				return path, fmt.Errorf("expected number, got %s", jsonKind(actual))
+			}
+			if rule.Match == "integer" && num != math.Trunc(num) {
+				return path, fmt.Errorf("expected integer, got %v", num)
+			}
+			if rule.Match == "decimal" && num == math.Trunc(num) {
+				return path, fmt.Errorf("expected decimal, got %v", num)
+			}
+			return "", nil
+
+		default:
+			return path, fmt.Errorf("unsupported matcher %q", rule.Match)
+		}
+	}
+
+	if jsonKind(expected) != jsonKind(actual) {
+		return path, fmt.Errorf("expected %s, got %s",
+			jsonKind(expected), jsonKind(actual))
+	}
+
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a := actual.(map[string]interface{})
+
+		keys := make([]string, 0, len(e))
+		for key := range e {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			elemPath := path + jsonPathKey(key)
+
+			actualElem, ok := a[key]
+			if !ok {
+				return elemPath, errors.New("missing field")
+			}
+
+			elemSegments := append(segments[:len(segments):len(segments)],
+				ignorePathSegment{key: key})
+
+			if p, err := m.match(
+				elemPath, elemSegments, e[key], actualElem, byType); err != nil {
+				return p, err
+			}
+		}
+
+	case []interface{}:
+		a := actual.([]interface{})
+
+		if !byType && len(e) != len(a) {
+			return path, fmt.Errorf("expected %d element(s), got %d", len(e), len(a))
+		}
+
+		for n := range a {
+			expectedElem := interface{}(nil)
+			switch {
+			case n < len(e):
+				expectedElem = e[n]
+			case len(e) != 0:
+				// matched by type: extra elements are matched against first one
+				expectedElem = e[0]
+			default:
+				continue
+			}
+
+			elemPath := path + "[" + strconv.Itoa(n) + "]"
+			elemSegments := append(segments[:len(segments):len(segments)],
+				ignorePathSegment{index: n, isIndex: true})
+
+			if p, err := m.match(
+				elemPath, elemSegments, expectedElem, a[n], byType); err != nil {
+				return p, err
+			}
+		}
+
+	default:
+		if !byType && !reflect.DeepEqual(expected, actual) {
+			return path, fmt.Errorf("expected %s, got %s",
+				pactValueString(expected), pactValueString(actual))
+		}
+	}
+
+	return "", nil
+}
+
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func pactValueString(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
@@ -0,0 +1,334 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// PactRecorder records requests and responses of named requests into
+// Pact contract, which can be later verified against provider using
+// Expect.VerifyPact.
+//
+// Recorder is attached using Expect.WithPactRecorder. Every named request
+// (see Request.WithName) becomes an interaction, with request name used
+// as description. If several requests have the same name, only the first
+// one is recorded.
+//
+// Only "Content-Type" and "Accept" request headers and "Content-Type"
+// response header are recorded, to avoid leaking credentials into
+// contract. Response is recorded as received, without matching rules.
+//
+// Example:
+//
+//	rec := httpexpect.NewPactRecorder("frontend", "users")
+//
+//	e := httpexpect.Default(t, "http://example.com").
+//		WithPactRecorder(rec)
+//
+//	e.GET("/users/1").
+//		WithName("get user").
+//		Expect().
+//		Status(http.StatusOK)
+//
+//	err := rec.WriteFile("pacts/frontend-users.json")
+type PactRecorder struct {
+	mu   sync.Mutex
+	pact Pact
+	seen map[string]bool
+}
+
+// NewPactRecorder returns a new PactRecorder for given consumer and
+// provider names.
+func NewPactRecorder(consumer, provider string) *PactRecorder {
+	return &PactRecorder{
+		pact: Pact{
+			Consumer: consumer,
+			Provider: provider,
+		},
+		seen: make(map[string]bool),
+	}
+}
+
+// Pact returns a copy of Pact recorded so far.
+func (rec *PactRecorder) Pact() *Pact {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	pact := rec.pact
+	pact.Interactions = append([]PactInteraction(nil), rec.pact.Interactions...)
+
+	return &pact
+}
+
+// WriteFile writes Pact recorded so far to file, creating parent
+// directories if needed. See MarshalPact for file format.
+func (rec *PactRecorder) WriteFile(path string) error {
+	data, err := MarshalPact(rec.Pact())
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (rec *PactRecorder) add(interaction PactInteraction) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if rec.seen[interaction.Description] {
+		return
+	}
+	rec.seen[interaction.Description] = true
+
+	rec.pact.Interactions = append(rec.pact.Interactions, interaction)
+}
+
+// WithPactRecorder returns a copy of Expect instance with given recorder
+// attached to it. Named requests created by returned instance and its
+// copies are recorded into Pact. See PactRecorder for details.
+//
+// If recorder is nil, the function panics.
+//
+// Example:
+//
+//	rec := httpexpect.NewPactRecorder("frontend", "users")
+//
+//	e := httpexpect.Default(t, "http://example.com").
+//		WithPactRecorder(rec)
+func (e *Expect) WithPactRecorder(rec *PactRecorder) *Expect {
+	if rec == nil {
+		panic("recorder is nil")
+	}
+
+	return e.ContextMatcher(func(ctx *MatcherContext, resp *Response) {
+		if ctx.RequestName == "" {
+			return
+		}
+
+		if interaction, ok := resp.pactInteraction(ctx.RequestName); ok {
+			rec.add(interaction)
+		}
+	})
+}
+
+// Build Pact interaction from response and request that produced it.
+func (r *Response) pactInteraction(name string) (PactInteraction, bool) {
+	opChain := r.chain.enter("RecordPact()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return PactInteraction{}, false
+	}
+
+	var httpReq *http.Request
+	if req := r.chain.context.Request; req != nil {
+		httpReq = req.httpReq
+	}
+	if httpReq == nil {
+		httpReq = r.httpResp.Request
+	}
+	if httpReq == nil || httpReq.URL == nil {
+		return PactInteraction{}, false
+	}
+
+	content, ok := r.getContent(opChain, "RecordPact()")
+	if !ok {
+		return PactInteraction{}, false
+	}
+
+	interaction := PactInteraction{
+		Description: name,
+		Request: PactRequest{
+			Method:  httpReq.Method,
+			Path:    httpReq.URL.Path,
+			Headers: pactHeaders(httpReq.Header, "Content-Type", "Accept"),
+		},
+		Response: PactResponse{
+			Status:  r.httpResp.StatusCode,
+			Headers: pactHeaders(r.httpResp.Header, "Content-Type"),
+			Body:    pactBody(r.httpResp.Header.Get("Content-Type"), content),
+		},
+	}
+
+	if query := httpReq.URL.Query(); len(query) != 0 {
+		interaction.Request.Query = query
+	}
+
+	getBody := httpReq.GetBody
+	if wrapper, ok := httpReq.Body.(*bodyWrapper); ok {
+		getBody = wrapper.GetBody
+	}
+
+	if getBody != nil {
+		if body, err := getBody(); err == nil {
+			data, err := io.ReadAll(body)
+			_ = body.Close()
+
+			if err == nil {
+				interaction.Request.Body =
+					pactBody(httpReq.Header.Get("Content-Type"), data)
+			}
+		}
+	}
+
+	return interaction, true
+}
+
+func pactHeaders(header http.Header, keys ...string) map[string]string {
+	var ret map[string]string
+
+	for _, key := range keys {
+		if value := header.Get(key); value != "" {
+			if ret == nil {
+				ret = make(map[string]string)
+			}
+			ret[key] = value
+		}
+	}
+
+	return ret
+}
+
+// JSON body is stored as is, other bodies are stored as JSON strings.
+func pactBody(contentType string, content []byte) json.RawMessage {
+	if len(content) == 0 {
+		return nil
+	}
+
+	if contentType != "" && isJSONMediaType(contentType) {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, content); err == nil {
+			return buf.Bytes()
+		}
+	}
+
+	data, _ := json.Marshal(string(content))
+
+	return data
+}
+
+// Pact file, specification version 3.
+type pactFileV3 struct {
+	Consumer pactParticipant     `json:"consumer"`
+	Provider pactParticipant     `json:"provider"`
+	Items    []pactInteractionV3 `json:"interactions"`
+	Metadata struct {
+		PactSpecification struct {
+			Version string `json:"version"`
+		} `json:"pactSpecification"`
+	} `json:"metadata"`
+}
+
+type pactParticipant struct {
+	Name string `json:"name"`
+}
+
+type pactInteractionV3 struct {
+	Description    string         `json:"description"`
+	ProviderStates []pactStateV3  `json:"providerStates,omitempty"`
+	Request        pactRequestV3  `json:"request"`
+	Response       pactResponseV3 `json:"response"`
+}
+
+type pactStateV3 struct {
+	Name   string                 `json:"name"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+type pactRequestV3 struct {
+	Method  string              `json:"method"`
+	Path    string              `json:"path"`
+	Query   map[string][]string `json:"query,omitempty"`
+	Headers map[string]string   `json:"headers,omitempty"`
+	Body    json.RawMessage     `json:"body,omitempty"`
+}
+
+type pactResponseV3 struct {
+	Status        int                              `json:"status"`
+	Headers       map[string]string                `json:"headers,omitempty"`
+	Body          json.RawMessage                  `json:"body,omitempty"`
+	MatchingRules map[string]map[string]pactRuleV3 `json:"matchingRules,omitempty"`
+}
+
+type pactRuleV3 struct {
+	Matchers []pactRawRuleV3 `json:"matchers"`
+}
+
+type pactRawRuleV3 struct {
+	Match string `json:"match"`
+	Regex string `json:"regex,omitempty"`
+	Min   int    `json:"min,omitempty"`
+	Max   int    `json:"max,omitempty"`
+}
+
+// MarshalPact formats Pact as Pact file, specification version 3.
+// Result can be parsed back using ParsePact.
+func MarshalPact(pact *Pact) ([]byte, error) {
+	var file pactFileV3
+
+	file.Consumer.Name = pact.Consumer
+	file.Provider.Name = pact.Provider
+	file.Metadata.PactSpecification.Version = "3.0.0"
+
+	file.Items = make([]pactInteractionV3, 0, len(pact.Interactions))
+
+	for _, interaction := range pact.Interactions {
+		item := pactInteractionV3{
+			Description: interaction.Description,
+			Request: pactRequestV3{
+				Method:  interaction.Request.Method,
+				Path:    interaction.Request.Path,
+				Query:   interaction.Request.Query,
+				Headers: interaction.Request.Headers,
+				Body:    interaction.Request.Body,
+			},
+			Response: pactResponseV3{
+				Status:  interaction.Response.Status,
+				Headers: interaction.Response.Headers,
+				Body:    interaction.Response.Body,
+			},
+		}
+
+		for _, state := range interaction.ProviderStates {
+			item.ProviderStates = append(item.ProviderStates,
+				pactStateV3(state))
+		}
+
+		if len(interaction.Response.MatchingRules) != 0 {
+			rules := make(map[string]pactRuleV3)
+
+			for _, rule := range interaction.Response.MatchingRules {
+				r := rules[rule.Path]
+				r.Matchers = append(r.Matchers, pactRawRuleV3{
+					Match: rule.Match,
+					Regex: rule.Regex,
+					Min:   rule.Min,
+					Max:   rule.Max,
+				})
+				rules[rule.Path] = r
+			}
+
+			item.Response.MatchingRules = map[string]map[string]pactRuleV3{
+				"body": rules,
+			}
+		}
+
+		file.Items = append(file.Items, item)
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
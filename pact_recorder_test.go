@@ -0,0 +1,169 @@
+package httpexpect
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPactRecorder_Marshal(t *testing.T) {
+	pact := &Pact{
+		Consumer: "frontend",
+		Provider: "users",
+		Interactions: []PactInteraction{
+			{
+				Description: "get user",
+				ProviderStates: []PactProviderState{
+					{Name: "user exists", Params: map[string]interface{}{"id": 1.0}},
+				},
+				Request: PactRequest{
+					Method:  "GET",
+					Path:    "/users/1",
+					Query:   url.Values{"fields": {"id", "name"}},
+					Headers: map[string]string{"Accept": "application/json"},
+				},
+				Response: PactResponse{
+					Status:  200,
+					Headers: map[string]string{"Content-Type": "application/json"},
+					Body:    []byte(`{"id":1}`),
+					MatchingRules: []PactMatchingRule{
+						{Path: "$.id", Match: "integer"},
+						{Path: "$.id", Match: "type"},
+					},
+				},
+			},
+			{
+				Description: "create user",
+				Request: PactRequest{
+					Method: "POST",
+					Path:   "/users",
+					Body:   []byte(`"text"`),
+				},
+				Response: PactResponse{
+					Status: 201,
+				},
+			},
+		},
+	}
+
+	data, err := MarshalPact(pact)
+	require.NoError(t, err)
+
+	parsed, err := ParsePact(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, pact, parsed)
+}
+
+func TestPactRecorder_Record(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/users/1":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": 1, "name": "alice"}`))
+
+		case r.Method == "POST" && r.URL.Path == "/users":
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(body)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	newExpect := func(t *testing.T) *Expect {
+		return WithConfig(Config{
+			BaseURL: "http://example.com",
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			Reporter: newMockReporter(t),
+		})
+	}
+
+	rec := NewPactRecorder("frontend", "users")
+
+	e := newExpect(t).WithPactRecorder(rec)
+
+	e.GET("/users/1").
+		WithName("get user").
+		WithQuery("fields", "name").
+		WithHeader("Accept", "application/json").
+		WithHeader("Authorization", "secret").
+		Expect().
+		Status(http.StatusOK)
+
+	e.GET("/users/1").
+		WithName("get user").
+		Expect().
+		Status(http.StatusOK)
+
+	e.POST("/users").
+		WithName("create user").
+		WithText("bob").
+		Expect().
+		Status(http.StatusCreated)
+
+	e.GET("/unnamed").
+		Expect().
+		Status(http.StatusNotFound)
+
+	e.chain.assert(t, success)
+
+	assert.Equal(t, &Pact{
+		Consumer: "frontend",
+		Provider: "users",
+		Interactions: []PactInteraction{
+			{
+				Description: "get user",
+				Request: PactRequest{
+					Method:  "GET",
+					Path:    "/users/1",
+					Query:   url.Values{"fields": {"name"}},
+					Headers: map[string]string{"Accept": "application/json"},
+				},
+				Response: PactResponse{
+					Status:  200,
+					Headers: map[string]string{"Content-Type": "application/json"},
+					Body:    []byte(`{"id":1,"name":"alice"}`),
+				},
+			},
+			{
+				Description: "create user",
+				Request: PactRequest{
+					Method: "POST",
+					Path:   "/users",
+					Headers: map[string]string{
+						"Content-Type": "text/plain; charset=utf-8",
+					},
+					Body: []byte(`"bob"`),
+				},
+				Response: PactResponse{
+					Status:  201,
+					Headers: map[string]string{"Content-Type": "text/plain"},
+					Body:    []byte(`"bob"`),
+				},
+			},
+		},
+	}, rec.Pact())
+
+	path := filepath.Join(t.TempDir(), "pacts", "frontend-users.json")
+	require.NoError(t, rec.WriteFile(path))
+
+	resps := newExpect(t).VerifyPact(path)
+
+	require.Equal(t, 2, len(resps))
+	resps["get user"].chain.assert(t, success)
+	resps["create user"].chain.assert(t, success)
+
+	assert.Panics(t, func() {
+		newExpect(t).WithPactRecorder(nil)
+	})
+}
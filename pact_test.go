@@ -0,0 +1,426 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPactV2 = `{
+  "consumer": {"name": "frontend"},
+  "provider": {"name": "users"},
+  "interactions": [
+    {
+      "description": "get user",
+      "providerState": "user 1 exists",
+      "request": {
+        "method": "get",
+        "path": "/users/1",
+        "query": "fields=id&fields=name",
+        "headers": {"Accept": "application/json"}
+      },
+      "response": {
+        "status": 200,
+        "headers": {"Content-Type": "application/json"},
+        "body": {"id": 1, "name": "alice", "tags": ["a"]},
+        "matchingRules": {
+          "$.body.name": {"match": "type"},
+          "$.body.tags": {"min": 1, "match": "type"},
+          "$.headers.Content-Type": {"regex": "application/json.*"}
+        }
+      }
+    }
+  ]
+}`
+
+const testPactV3 = `{
+  "consumer": {"name": "frontend"},
+  "provider": {"name": "users"},
+  "interactions": [
+    {
+      "description": "create user",
+      "providerStates": [{"name": "empty db", "params": {"n": 1}}],
+      "request": {
+        "method": "POST",
+        "path": "/users",
+        "query": {"dry": ["true"]},
+        "headers": {"Content-Type": ["application/json"]},
+        "body": {"name": "bob"}
+      },
+      "response": {
+        "status": 201,
+        "body": {"id": 2, "name": "bob"},
+        "matchingRules": {
+          "body": {
+            "$.id": {"matchers": [{"match": "integer"}]}
+          }
+        }
+      }
+    }
+  ]
+}`
+
+func TestPact_Parse(t *testing.T) {
+	t.Run("v2", func(t *testing.T) {
+		pact, err := ParsePact([]byte(testPactV2))
+		require.NoError(t, err)
+
+		assert.Equal(t, "frontend", pact.Consumer)
+		assert.Equal(t, "users", pact.Provider)
+		require.Equal(t, 1, len(pact.Interactions))
+
+		interaction := pact.Interactions[0]
+
+		assert.Equal(t, "get user", interaction.Description)
+		assert.Equal(t, []PactProviderState{{Name: "user 1 exists"}},
+			interaction.ProviderStates)
+		assert.Equal(t, "GET", interaction.Request.Method)
+		assert.Equal(t, url.Values{"fields": {"id", "name"}},
+			interaction.Request.Query)
+		assert.Equal(t, map[string]string{"Accept": "application/json"},
+			interaction.Request.Headers)
+		assert.Nil(t, interaction.Request.Body)
+
+		assert.Equal(t, 200, interaction.Response.Status)
+		assert.Equal(t, []PactMatchingRule{
+			{Path: "$.name", Match: "type"},
+			{Path: "$.tags", Match: "type", Min: 1},
+		}, interaction.Response.MatchingRules)
+	})
+
+	t.Run("v3", func(t *testing.T) {
+		pact, err := ParsePact([]byte(testPactV3))
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(pact.Interactions))
+
+		interaction := pact.Interactions[0]
+
+		assert.Equal(t, []PactProviderState{
+			{Name: "empty db", Params: map[string]interface{}{"n": 1.0}},
+		}, interaction.ProviderStates)
+		assert.Equal(t, url.Values{"dry": {"true"}}, interaction.Request.Query)
+		assert.Equal(t, map[string]string{"Content-Type": "application/json"},
+			interaction.Request.Headers)
+		assert.JSONEq(t, `{"name": "bob"}`, string(interaction.Request.Body))
+
+		assert.Equal(t, []PactMatchingRule{
+			{Path: "$.id", Match: "integer"},
+		}, interaction.Response.MatchingRules)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := []string{
+			`{`,
+			`{"interactions": [{"request": {"method": "GET", "path": "/"},
+				"response": {"status": 200}}]}`,
+			`{"interactions": [{"description": "a", "request": {"path": "/"},
+				"response": {"status": 200}}]}`,
+			`{"interactions": [{"description": "a",
+				"request": {"method": "GET", "path": "/"}, "response": {}}]}`,
+			`{"interactions": [
+				{"description": "a", "request": {"method": "GET", "path": "/"},
+					"response": {"status": 200}},
+				{"description": "a", "request": {"method": "GET", "path": "/"},
+					"response": {"status": 200}}]}`,
+			`{"interactions": [{"description": "a",
+				"request": {"method": "GET", "path": "/"},
+				"response": {"status": 200,
+					"matchingRules": {"$.body.id": {"regex": "("}}}}]}`,
+		}
+
+		for _, data := range cases {
+			_, err := ParsePact([]byte(data))
+			assert.Error(t, err, data)
+		}
+	})
+}
+
+func TestPact_Match(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		actual   string
+		rules    []PactMatchingRule
+		path     string
+	}{
+		{
+			name:     "equal",
+			expected: `{"a": 1, "b": [1, 2]}`,
+			actual:   `{"a": 1, "b": [1, 2]}`,
+		},
+		{
+			name:     "extra fields",
+			expected: `{"a": 1}`,
+			actual:   `{"a": 1, "b": 2}`,
+		},
+		{
+			name:     "missing field",
+			expected: `{"a": 1, "b": 2}`,
+			actual:   `{"a": 1}`,
+			path:     "$.b",
+		},
+		{
+			name:     "different value",
+			expected: `{"a": {"b": "x"}}`,
+			actual:   `{"a": {"b": "y"}}`,
+			path:     "$.a.b",
+		},
+		{
+			name:     "different length",
+			expected: `[1, 2]`,
+			actual:   `[1, 2, 3]`,
+			path:     "$",
+		},
+		{
+			name:     "type",
+			expected: `{"a": "x", "b": [{"id": 1}]}`,
+			actual:   `{"a": "y", "b": [{"id": 2}, {"id": 3}]}`,
+			rules: []PactMatchingRule{
+				{Path: "$.a", Match: "type"},
+				{Path: "$.b", Match: "type", Min: 1},
+			},
+		},
+		{
+			name:     "type mismatch",
+			expected: `{"b": [{"id": 1}]}`,
+			actual:   `{"b": [{"id": 2}, {"id": "3"}]}`,
+			rules: []PactMatchingRule{
+				{Path: "$.b", Match: "type"},
+			},
+			path: "$.b[1].id",
+		},
+		{
+			name:     "type min",
+			expected: `{"b": [1]}`,
+			actual:   `{"b": []}`,
+			rules: []PactMatchingRule{
+				{Path: "$.b", Match: "type", Min: 1},
+			},
+			path: "$.b",
+		},
+		{
+			name:     "type max",
+			expected: `{"b": [1]}`,
+			actual:   `{"b": [1, 2, 3]}`,
+			rules: []PactMatchingRule{
+				{Path: "$.b", Match: "type", Max: 2},
+			},
+			path: "$.b",
+		},
+		{
+			name:     "equality inside type",
+			expected: `{"a": {"kind": "user", "id": 1}}`,
+			actual:   `{"a": {"kind": "admin", "id": 2}}`,
+			rules: []PactMatchingRule{
+				{Path: "$.a", Match: "type"},
+				{Path: "$.a.kind", Match: "equality"},
+			},
+			path: "$.a.kind",
+		},
+		{
+			name:     "regex",
+			expected: `{"items": [{"date": "2000-01-01"}]}`,
+			actual:   `{"items": [{"date": "2023-05-06"}]}`,
+			rules: []PactMatchingRule{
+				{Path: "$.items[*].date", Match: "regex", Regex: `\d{4}-\d{2}-\d{2}`},
+			},
+		},
+		{
+			name:     "regex mismatch",
+			expected: `{"date": "2000-01-01"}`,
+			actual:   `{"date": "2023-05-06T00:00:00"}`,
+			rules: []PactMatchingRule{
+				{Path: "$.date", Match: "regex", Regex: `\d{4}-\d{2}-\d{2}`},
+			},
+			path: "$.date",
+		},
+		{
+			name:     "integer",
+			expected: `{"id": 1}`,
+			actual:   `{"id": 1.5}`,
+			rules: []PactMatchingRule{
+				{Path: "$.id", Match: "integer"},
+			},
+			path: "$.id",
+		},
+		{
+			name:     "unsupported",
+			expected: `{"id": 1}`,
+			actual:   `{"id": 1}`,
+			rules: []PactMatchingRule{
+				{Path: "$.id", Match: "semver"},
+			},
+			path: "$.id",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var expected, actual interface{}
+			require.NoError(t, json.Unmarshal([]byte(tc.expected), &expected))
+			require.NoError(t, json.Unmarshal([]byte(tc.actual), &actual))
+
+			m, err := newPactMatcher(tc.rules)
+			require.NoError(t, err)
+
+			path, err := m.match("$", nil, expected, actual, false)
+
+			if tc.path == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tc.path, path)
+			}
+		})
+	}
+}
+
+func TestPact_Verify(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/users/1":
+			if r.URL.RawQuery != "fields=id&fields=name" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			_, _ = w.Write([]byte(`{"id": 1, "name": "carol", "tags": ["x", "y"]}`))
+
+		case r.Method == "POST" && r.URL.Path == "/users":
+			body, _ := io.ReadAll(r.Body)
+			if r.Header.Get("Content-Type") != "application/json" ||
+				string(body) != `{"name":"bob"}` {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 2, "name": "bob", "extra": true}`))
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	newExpect := func(t *testing.T) *Expect {
+		return WithConfig(Config{
+			BaseURL: "http://example.com",
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			Reporter: newMockReporter(t),
+		})
+	}
+
+	loadPacts := func(t *testing.T) *Pact {
+		pact1, err := ParsePact([]byte(testPactV2))
+		require.NoError(t, err)
+
+		pact2, err := ParsePact([]byte(testPactV3))
+		require.NoError(t, err)
+
+		pact1.Interactions = append(pact1.Interactions, pact2.Interactions...)
+		return pact1
+	}
+
+	t.Run("success", func(t *testing.T) {
+		e := newExpect(t)
+
+		var states []string
+
+		resps := e.VerifyPactContract(loadPacts(t), PactOpts{
+			StateHandler: func(state PactProviderState) error {
+				states = append(states, state.Name)
+				return nil
+			},
+		})
+
+		require.Equal(t, 2, len(resps))
+		resps["get user"].chain.assert(t, success)
+		resps["create user"].chain.assert(t, success)
+
+		assert.Equal(t, []string{"user 1 exists", "empty db"}, states)
+
+		e.chain.assert(t, success)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		e := newExpect(t)
+
+		pact := loadPacts(t)
+		pact.Interactions[0].Response.MatchingRules = nil
+
+		resps := e.VerifyPactContract(pact)
+
+		require.Equal(t, 2, len(resps))
+		resps["get user"].chain.assert(t, failure)
+		resps["create user"].chain.assert(t, success)
+	})
+
+	t.Run("status mismatch", func(t *testing.T) {
+		e := newExpect(t)
+
+		pact := loadPacts(t)
+		pact.Interactions[1].Response.Status = http.StatusOK
+
+		resps := e.VerifyPactContract(pact)
+
+		resps["get user"].chain.assert(t, success)
+		resps["create user"].chain.assert(t, failure)
+	})
+
+	t.Run("state error", func(t *testing.T) {
+		e := newExpect(t)
+
+		resps := e.VerifyPactContract(loadPacts(t), PactOpts{
+			StateHandler: func(state PactProviderState) error {
+				if state.Name == "empty db" {
+					return errors.New("test")
+				}
+				return nil
+			},
+		})
+
+		resps["get user"].chain.assert(t, success)
+		resps["create user"].chain.assert(t, failure)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "pact.json")
+		require.NoError(t, os.WriteFile(path, []byte(testPactV2), 0o644))
+
+		e := newExpect(t)
+
+		resps := e.VerifyPact(path)
+
+		require.Equal(t, 1, len(resps))
+		resps["get user"].chain.assert(t, success)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		e := newExpect(t)
+
+		resps := e.VerifyPact(filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.Equal(t, 0, len(resps))
+		e.chain.assert(t, failure)
+	})
+
+	t.Run("nil pact", func(t *testing.T) {
+		e := newExpect(t)
+
+		resps := e.VerifyPactContract(nil)
+
+		assert.Equal(t, 0, len(resps))
+		e.chain.assert(t, failure)
+	})
+}
@@ -0,0 +1,88 @@
+package httpexpect
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PathEscaping defines how values of named parameters are escaped
+// by Request.WithPath and Request.WithPathObject.
+type PathEscaping int
+
+const (
+	// PathEscapingNone inserts value into path as is. Slashes in value
+	// create extra path segments, and other characters are escaped when
+	// request is sent, e.g. "a/b c" becomes "a/b%20c". This is the default.
+	PathEscapingNone PathEscaping = iota
+
+	// PathEscapingSegment escapes value as a single path segment, including
+	// slashes, e.g. "a/b c" becomes "a%2Fb%20c".
+	PathEscapingSegment
+
+	// PathEscapingRaw treats value as already escaped and inserts it into
+	// path verbatim, e.g. "a%2Fb" stays "a%2Fb". Value should be a valid
+	// escaped path, otherwise failure is reported.
+	PathEscapingRaw
+)
+
+// PathOpts defines options for Request.WithPath and Request.WithPathObject.
+type PathOpts struct {
+	// How parameter values are escaped.
+	// Default is PathEscapingNone.
+	Escaping PathEscaping
+}
+
+// Returns decoded and escaped forms of path parameter value.
+func escapePathValue(value string, escaping PathEscaping) (string, string, error) {
+	switch escaping {
+	case PathEscapingNone:
+		return value, (&url.URL{Path: value}).EscapedPath(), nil
+
+	case PathEscapingSegment:
+		return value, url.PathEscape(value), nil
+
+	case PathEscapingRaw:
+		decoded, err := url.PathUnescape(value)
+		if err != nil {
+			return "", "", err
+		}
+		return decoded, value, nil
+
+	default:
+		return "", "", fmt.Errorf("invalid PathEscaping value: %d", escaping)
+	}
+}
+
+// Escapes path, keeping '{name}' parameters intact, so that they can be
+// substituted later.
+func escapePathTemplate(path string) string {
+	var b strings.Builder
+
+	for {
+		start := strings.IndexByte(path, '{')
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(path[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		b.WriteString((&url.URL{Path: path[:start]}).EscapedPath())
+		b.WriteString(path[start : end+1])
+
+		path = path[end+1:]
+	}
+
+	b.WriteString((&url.URL{Path: path}).EscapedPath())
+
+	return b.String()
+}
+
+// Escapes braces of parameters that were not substituted.
+func escapePathBraces(path string) string {
+	return strings.NewReplacer("{", "%7B", "}", "%7D").Replace(path)
+}
@@ -0,0 +1,53 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathEscaping_Value(t *testing.T) {
+	cases := []struct {
+		escaping        PathEscaping
+		value           string
+		expectedDecoded string
+		expectedEscaped string
+	}{
+		{PathEscapingNone, "a/b c?", "a/b c?", "a/b%20c%3F"},
+		{PathEscapingSegment, "a/b c?", "a/b c?", "a%2Fb%20c%3F"},
+		{PathEscapingRaw, "a%2Fb%20c", "a/b c", "a%2Fb%20c"},
+	}
+
+	for _, tc := range cases {
+		decoded, escaped, err := escapePathValue(tc.value, tc.escaping)
+		require.NoError(t, err)
+
+		assert.Equal(t, tc.expectedDecoded, decoded)
+		assert.Equal(t, tc.expectedEscaped, escaped)
+	}
+
+	_, _, err := escapePathValue("a%zz", PathEscapingRaw)
+	assert.Error(t, err)
+
+	_, _, err = escapePathValue("a", PathEscaping(-1))
+	assert.Error(t, err)
+}
+
+func TestPathEscaping_Template(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{"", ""},
+		{"/a b/{key}/c d", "/a%20b/{key}/c%20d"},
+		{"/{a}{b}", "/{a}{b}"},
+		{"/a?{b", "/a%3F%7Bb"},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, escapePathTemplate(tc.path))
+	}
+
+	assert.Equal(t, "/%7Ba%7D", escapePathBraces("/{a}"))
+}
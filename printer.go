@@ -2,18 +2,24 @@ package httpexpect
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"net/http/httputil"
+	"os"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gorilla/websocket"
 	"moul.io/http2curl/v2"
 )
 
 // Printer is used to print requests and responses.
-// CompactPrinter, DebugPrinter, and CurlPrinter implement this interface.
+// CompactPrinter, DebugPrinter, LazyPrinter, and CurlPrinter implement this
+// interface.
 type Printer interface {
 	// Request is called before request is sent.
 	// It is allowed to read and close request body, or ignore it.
@@ -29,7 +35,7 @@ type Printer interface {
 // If WebSocket connection is used, all Printers that also implement WebsocketPrinter
 // are invoked on every WebSocket message read or written.
 //
-// DebugPrinter implements this interface.
+// DebugPrinter and LazyPrinter implement this interface.
 type WebsocketPrinter interface {
 	Printer
 
@@ -54,7 +60,7 @@ func NewCompactPrinter(logger Logger) CompactPrinter {
 // Request implements Printer.Request.
 func (p CompactPrinter) Request(req *http.Request) {
 	if req != nil {
-		p.logger.Logf("%s %s", req.Method, req.URL)
+		logWithFields(p.logger, requestLogFields(req), "%s %s", req.Method, req.URL)
 	}
 }
 
@@ -65,23 +71,52 @@ func (CompactPrinter) Response(*http.Response, time.Duration) {
 // CurlPrinter implements Printer.
 // Uses http2curl to dump requests as curl commands that can be inserted
 // into terminal.
+//
+// Sensitive headers and body fields can be masked, see CurlPrinterOpts.
 type CurlPrinter struct {
 	logger Logger
+	mask   *printerMask
+}
+
+// CurlPrinterOpts defines options for CurlPrinter.
+type CurlPrinterOpts struct {
+	// Headers and JSON body fields to be masked in printed commands.
+	Mask PrinterMaskOpts
 }
 
 // NewCurlPrinter returns a new CurlPrinter given a logger.
 func NewCurlPrinter(logger Logger) CurlPrinter {
-	return CurlPrinter{logger}
+	return CurlPrinter{logger: logger}
+}
+
+// NewCurlPrinterOpts returns a new CurlPrinter given a logger and options.
+//
+// Example:
+//
+//	printer := NewCurlPrinterOpts(t, CurlPrinterOpts{
+//		Mask: PrinterMaskOpts{
+//			Headers: DefaultMaskedHeaders,
+//			Fields:  []string{"$.password"},
+//		},
+//	})
+func NewCurlPrinterOpts(logger Logger, opts CurlPrinterOpts) CurlPrinter {
+	return CurlPrinter{
+		logger: logger,
+		mask:   newPrinterMask(opts.Mask),
+	}
 }
 
 // Request implements Printer.Request.
 func (p CurlPrinter) Request(req *http.Request) {
 	if req != nil {
+		if p.mask != nil {
+			req = p.maskRequest(req)
+		}
 		cmd, err := http2curl.GetCurlCommand(req)
 		if err != nil {
 			panic(err)
 		}
-		p.logger.Logf("%s", cmd.String())
+		logWithFields(p.logger, requestLogFields(req), "%s", cmd.String())
 	}
 }
 
@@ -89,18 +124,93 @@ func (p CurlPrinter) Request(req *http.Request) {
 func (CurlPrinter) Response(*http.Response, time.Duration) {
 }
 
+// Return shallow copy of request with masked headers and body.
+func (p CurlPrinter) maskRequest(req *http.Request) *http.Request {
+	body, origBody, err := readPrinterBody(req.Body)
+	if err != nil {
+		panic(err)
+	}
+	req.Body = origBody
+
+	masked := *req
+	masked.Header = p.mask.maskHeader(req.Header)
+
+	if body != nil {
+		masked.Body = io.NopCloser(
+			bytes.NewReader(p.mask.maskBody(req.Header, body)))
+	}
+
+	return &masked
+}
+
 // DebugPrinter implements Printer and WebsocketPrinter.
 // Uses net/http/httputil to dump both requests and responses.
 // Also prints all websocket messages.
+//
+// Binary bodies are not dumped; instead, their size, content type, and
+// hash are printed. Large text bodies can be truncated, bodies of selected
+// content types can be skipped, and full bodies can be saved to files,
+// see DebugPrinterOpts.
 type DebugPrinter struct {
 	logger Logger
-	body   bool
+	opts   DebugPrinterOpts
+	mask   *printerMask
+}
+
+// DebugPrinterOpts defines options for DebugPrinter.
+type DebugPrinterOpts struct {
+	// If true, request and response body is also printed.
+	Body bool
+
+	// If positive, text bodies larger than given number of bytes are
+	// truncated. Beginning and end of the body are printed.
+	MaxBodySize int
+
+	// If non-empty, every non-empty body is also saved to a new file in
+	// given directory, and path to the file is printed after the body.
+	// Useful to inspect truncated and binary bodies, e.g. as CI artifacts.
+	BodyDir string
+
+	// Media types which bodies should not be printed, e.g. "video/mp4".
+	// Wildcards like "image/*" are allowed.
+	// Such bodies are not even read by printer; only their content type and
+	// size (if known from Content-Length) are printed.
+	SkipContentTypes []string
+
+	// Headers and JSON body fields to be masked in printed requests and
+	// responses, and in saved bodies.
+	Mask PrinterMaskOpts
 }
 
 // NewDebugPrinter returns a new DebugPrinter given a logger and body
 // flag. If body is true, request and response body is also printed.
 func NewDebugPrinter(logger Logger, body bool) DebugPrinter {
-	return DebugPrinter{logger, body}
+	return DebugPrinter{
+		logger: logger,
+		opts:   DebugPrinterOpts{Body: body},
+	}
+}
+
+// NewDebugPrinterOpts returns a new DebugPrinter given a logger and options.
+//
+// Example:
+//
+//	printer := NewDebugPrinterOpts(t, DebugPrinterOpts{
+//		Body:        true,
+//		MaxBodySize: 4096,
+//		BodyDir:     os.Getenv("ARTIFACTS_DIR"),
+//		SkipContentTypes: []string{"image/*", "video/*"},
+//		Mask: PrinterMaskOpts{
+//			Headers: DefaultMaskedHeaders,
+//			Fields:  []string{"$..token"},
+//		},
+//	})
+func NewDebugPrinterOpts(logger Logger, opts DebugPrinterOpts) DebugPrinter {
+	return DebugPrinter{
+		logger: logger,
+		opts:   opts,
+		mask:   newPrinterMask(opts.Mask),
+	}
 }
 
 // Request implements Printer.Request.
@@ -109,11 +219,32 @@ func (p DebugPrinter) Request(req *http.Request) {
 		return
 	}
 
-	dump, err := httputil.DumpRequest(req, p.body)
+	var body []byte
+	var skipped string
+	if p.opts.Body {
+		if p.isSkipped(req.Header) {
+			skipped = formatSkippedBody(req.Header, req.ContentLength)
+		} else {
+			var err error
+			body, req.Body, err = readPrinterBody(req.Body)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	body = p.mask.maskBody(req.Header, body)
+
+	dumpReq := *req
+	dumpReq.Header = p.mask.maskHeader(req.Header)
+
+	dump, err := httputil.DumpRequest(&dumpReq, false)
 	if err != nil {
 		panic(err)
 	}
-	p.logger.Logf("%s", dump)
+
+	logWithFields(p.logger, requestLogFields(req),
+		"%s%s%s", dump, skipped, p.formatBody("request", req.Header, body))
 }
 
 // Response implements Printer.Response.
@@ -122,7 +253,26 @@ func (p DebugPrinter) Response(resp *http.Response, duration time.Duration) {
 		return
 	}
 
-	dump, err := httputil.DumpResponse(resp, p.body)
+	var body []byte
+	var skipped string
+	if p.opts.Body {
+		if p.isSkipped(resp.Header) {
+			skipped = formatSkippedBody(resp.Header, resp.ContentLength)
+		} else {
+			var err error
+			body, resp.Body, err = readPrinterBody(resp.Body)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	body = p.mask.maskBody(resp.Header, body)
+
+	dumpResp := *resp
+	dumpResp.Header = p.mask.maskHeader(resp.Header)
+
+	dump, err := httputil.DumpResponse(&dumpResp, false)
 	if err != nil {
 		panic(err)
 	}
@@ -130,7 +280,178 @@ func (p DebugPrinter) Response(resp *http.Response, duration time.Duration) {
 	text := strings.Replace(string(dump), "\r\n", "\n", -1)
 	lines := strings.SplitN(text, "\n", 2)
 
-	p.logger.Logf("%s %s\n%s", lines[0], duration, lines[1])
+	logWithFields(p.logger, responseLogFields(resp, duration),
+		"%s %s\n%s%s%s", lines[0], duration, lines[1],
+		skipped, p.formatBody("response", resp.Header, body))
+}
+
+func (p DebugPrinter) formatBody(kind string, header http.Header, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	b := &strings.Builder{}
+
+	contentType := header.Get("Content-Type")
+
+	switch {
+	case isBinaryBody(contentType, body):
+		fmt.Fprintf(b, "<binary body: %s", formatBodySize(int64(len(body))))
+		if contentType != "" {
+			fmt.Fprintf(b, ", %s", contentType)
+		}
+		fmt.Fprintf(b, ", sha256:%x>", sha256.Sum256(body))
+
+	case p.opts.MaxBodySize > 0 && len(body) > p.opts.MaxBodySize:
+		head, tail := truncateBody(body, p.opts.MaxBodySize)
+		fmt.Fprintf(b, "%s\n<... %s truncated ...>\n%s",
+			head, formatBodySize(int64(len(body)-len(head)-len(tail))), tail)
+
+	default:
+		b.Write(body)
+	}
+
+	if p.opts.BodyDir != "" {
+		path, err := saveBody(p.opts.BodyDir, kind, body)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Fprintf(b, "\n<full body saved to %s>", path)
+	}
+
+	return b.String()
+}
+
+// Check if body with given headers should not be printed.
+func (p DebugPrinter) isSkipped(header http.Header) bool {
+	if len(p.opts.SkipContentTypes) == 0 {
+		return false
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if mediaType == "" {
+		return false
+	}
+
+	for _, pattern := range p.opts.SkipContentTypes {
+		pattern = strings.ToLower(pattern)
+
+		if strings.HasSuffix(pattern, "/*") {
+			if strings.HasPrefix(mediaType, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		} else if mediaType == pattern {
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatSkippedBody(header http.Header, contentLength int64) string {
+	if contentLength > 0 {
+		return fmt.Sprintf("<body skipped: %s, %s>",
+			formatBodySize(contentLength), header.Get("Content-Type"))
+	}
+
+	return fmt.Sprintf("<body skipped: %s>", header.Get("Content-Type"))
+}
+
+// Format size in human-readable form, e.g. "100 bytes" or "1.2MB".
+func formatBodySize(size int64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d bytes", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit && exp < 3; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f%cB", float64(size)/float64(div), "KMGT"[exp])
+}
+
+// Read body fully and return its contents and a reader to be used instead.
+func readPrinterBody(body io.ReadCloser) ([]byte, io.ReadCloser, error) {
+	if body == nil || body == http.NoBody {
+		return nil, body, nil
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, body, err
+	}
+
+	if bw, ok := body.(*bodyWrapper); ok {
+		bw.Rewind()
+		return content, bw, nil
+	}
+
+	if err := body.Close(); err != nil {
+		return nil, body, err
+	}
+
+	return content, io.NopCloser(bytes.NewReader(content)), nil
+}
+
+// Check if body should not be printed as text.
+// Body is considered binary if its content type is known to be binary,
+// or if it is not valid UTF-8, or if it contains zero bytes.
+func isBinaryBody(contentType string, body []byte) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case strings.HasPrefix(mediaType, "image/"),
+		strings.HasPrefix(mediaType, "audio/"),
+		strings.HasPrefix(mediaType, "video/"),
+		strings.HasPrefix(mediaType, "font/"):
+		return true
+
+	case mediaType == "application/octet-stream",
+		mediaType == "application/zip",
+		mediaType == "application/gzip",
+		mediaType == "application/x-gzip",
+		mediaType == "application/x-tar",
+		mediaType == "application/pdf",
+		mediaType == "application/protobuf",
+		mediaType == "application/x-protobuf":
+		return true
+	}
+
+	return !utf8.Valid(body) || bytes.IndexByte(body, 0) >= 0
+}
+
+// Split body into head and tail, with total length not exceeding maxSize.
+// Split points are adjusted to not break UTF-8 sequences.
+func truncateBody(body []byte, maxSize int) (head, tail []byte) {
+	headLen := maxSize / 2
+	tailStart := len(body) - (maxSize - headLen)
+
+	for headLen > 0 && !utf8.RuneStart(body[headLen]) {
+		headLen--
+	}
+	for tailStart < len(body) && !utf8.RuneStart(body[tailStart]) {
+		tailStart++
+	}
+
+	return body[:headLen], body[tailStart:]
+}
+
+func saveBody(dir, kind string, body []byte) (string, error) {
+	f, err := os.CreateTemp(dir, "httpexpect-"+kind+"-*.body")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(body); err != nil {
+		return "", err
+	}
+
+	return f.Name(), nil
 }
 
 // WebsocketWrite implements WebsocketPrinter.WebsocketWrite.
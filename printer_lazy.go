@@ -0,0 +1,172 @@
+package httpexpect
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LazyPrinter implements Printer and WebsocketPrinter.
+// Prints requests and responses in the same form as DebugPrinter, but
+// only for requests that have failed assertions.
+//
+// When used in Config.Printers, output is buffered separately for every
+// request, and is written to logger only when an assertion on the request,
+// its response, or values derived from them fails. Output for requests
+// without failures is discarded. This allows to keep CI logs quiet, but
+// still have full dumps for failed requests.
+//
+// When LazyPrinter methods are invoked directly (not by Request), output
+// is written to logger immediately.
+type LazyPrinter struct {
+	logger Logger
+	opts   DebugPrinterOpts
+}
+
+// NewLazyPrinter returns a new LazyPrinter given a logger and body
+// flag. If body is true, request and response body is also printed.
+//
+// Example:
+//
+//	e := WithConfig(Config{
+//		BaseURL:  "http://example.com",
+//		Reporter: NewAssertReporter(t),
+//		Printers: []Printer{
+//			NewLazyPrinter(t, true),
+//		},
+//	})
+func NewLazyPrinter(logger Logger, body bool) LazyPrinter {
+	return LazyPrinter{
+		logger: logger,
+		opts:   DebugPrinterOpts{Body: body},
+	}
+}
+
+// NewLazyPrinterOpts returns a new LazyPrinter given a logger and options.
+// Options have the same meaning as for DebugPrinter.
+//
+// Example:
+//
+//	printer := NewLazyPrinterOpts(t, DebugPrinterOpts{
+//		Body:        true,
+//		MaxBodySize: 4096,
+//	})
+func NewLazyPrinterOpts(logger Logger, opts DebugPrinterOpts) LazyPrinter {
+	return LazyPrinter{
+		logger: logger,
+		opts:   opts,
+	}
+}
+
+// Request implements Printer.Request.
+func (p LazyPrinter) Request(req *http.Request) {
+	NewDebugPrinterOpts(p.logger, p.opts).Request(req)
+}
+
+// Response implements Printer.Response.
+func (p LazyPrinter) Response(resp *http.Response, duration time.Duration) {
+	NewDebugPrinterOpts(p.logger, p.opts).Response(resp, duration)
+}
+
+// WebsocketWrite implements WebsocketPrinter.WebsocketWrite.
+func (p LazyPrinter) WebsocketWrite(typ int, content []byte, closeCode int) {
+	NewDebugPrinterOpts(p.logger, p.opts).WebsocketWrite(typ, content, closeCode)
+}
+
+// WebsocketRead implements WebsocketPrinter.WebsocketRead.
+func (p LazyPrinter) WebsocketRead(typ int, content []byte, closeCode int) {
+	NewDebugPrinterOpts(p.logger, p.opts).WebsocketRead(typ, content, closeCode)
+}
+
+// Per-request buffer of LazyPrinter output.
+// Implements Printer and WebsocketPrinter by writing to itself.
+type lazyPrinterBuffer struct {
+	mu       sync.Mutex
+	logger   Logger
+	printer  DebugPrinter
+	messages []string
+}
+
+func newLazyPrinterBuffer(p LazyPrinter) *lazyPrinterBuffer {
+	b := &lazyPrinterBuffer{
+		logger: p.logger,
+	}
+
+	b.printer = NewDebugPrinterOpts(b, p.opts)
+
+	return b
+}
+
+// Replace LazyPrinters with per-request buffers.
+// Returns new list of printers and list of created buffers.
+func newLazyPrinterBuffers(printers []Printer) ([]Printer, []*lazyPrinterBuffer) {
+	var buffers []*lazyPrinterBuffer
+
+	for _, printer := range printers {
+		if _, ok := printer.(LazyPrinter); ok {
+			buffers = make([]*lazyPrinterBuffer, 0, len(printers))
+			break
+		}
+	}
+
+	if buffers == nil {
+		return printers, nil
+	}
+
+	result := make([]Printer, 0, len(printers))
+
+	for _, printer := range printers {
+		if p, ok := printer.(LazyPrinter); ok {
+			b := newLazyPrinterBuffer(p)
+			buffers = append(buffers, b)
+			result = append(result, b)
+		} else {
+			result = append(result, printer)
+		}
+	}
+
+	return result, buffers
+}
+
+// Write output of LazyPrinters buffered for request.
+func (r *Request) flushLazyPrinters() {
+	for _, b := range r.lazyPrinters {
+		b.flush()
+	}
+}
+
+func (b *lazyPrinterBuffer) Logf(format string, args ...interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.messages = append(b.messages, fmt.Sprintf(format, args...))
+}
+
+func (b *lazyPrinterBuffer) Request(req *http.Request) {
+	b.printer.Request(req)
+}
+
+func (b *lazyPrinterBuffer) Response(resp *http.Response, duration time.Duration) {
+	b.printer.Response(resp, duration)
+}
+
+func (b *lazyPrinterBuffer) WebsocketWrite(typ int, content []byte, closeCode int) {
+	b.printer.WebsocketWrite(typ, content, closeCode)
+}
+
+func (b *lazyPrinterBuffer) WebsocketRead(typ int, content []byte, closeCode int) {
+	b.printer.WebsocketRead(typ, content, closeCode)
+}
+
+// Write buffered messages to logger and clear buffer.
+func (b *lazyPrinterBuffer) flush() {
+	b.mu.Lock()
+	messages := b.messages
+	b.messages = nil
+	b.mu.Unlock()
+
+	for _, msg := range messages {
+		b.logger.Logf("%s", msg)
+	}
+}
@@ -0,0 +1,192 @@
+package httpexpect
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// DefaultMaskedHeaders defines headers that usually contain credentials.
+// Can be used as PrinterMaskOpts.Headers.
+var DefaultMaskedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// PrinterMaskOpts defines which parts of requests and responses are masked
+// by printers, see DebugPrinterOpts and CurlPrinterOpts.
+//
+// Masking affects only printed output; requests and responses themselves
+// are not modified.
+type PrinterMaskOpts struct {
+	// Names of headers which values should be masked, case-insensitive.
+	// Use DefaultMaskedHeaders to mask common credential headers.
+	Headers []string
+
+	// JSONPath expressions of JSON body fields which values should be masked.
+	//
+	// Supported syntax:
+	//  - "$.user.password" - field by path
+	//  - "$.users[0].token" or "$.users[*].token" - array elements
+	//  - "$.*.token" - any object field
+	//  - "$..token" - field at any depth
+	//
+	// Expression without "$" prefix, e.g. "token", is same as "$..token".
+	Fields []string
+
+	// Value to be printed instead of masked values.
+	// If empty, "***" is used.
+	Placeholder string
+}
+
+const defaultMaskPlaceholder = "***"
+
+// Compiled PrinterMaskOpts.
+type printerMask struct {
+	headers     map[string]struct{}
+	fields      []*regexp.Regexp
+	placeholder string
+}
+
+func newPrinterMask(opts PrinterMaskOpts) *printerMask {
+	if len(opts.Headers) == 0 && len(opts.Fields) == 0 {
+		return nil
+	}
+
+	m := &printerMask{
+		headers:     make(map[string]struct{}, len(opts.Headers)),
+		placeholder: opts.Placeholder,
+	}
+
+	if m.placeholder == "" {
+		m.placeholder = defaultMaskPlaceholder
+	}
+
+	for _, name := range opts.Headers {
+		m.headers[http.CanonicalHeaderKey(name)] = struct{}{}
+	}
+
+	for _, field := range opts.Fields {
+		m.fields = append(m.fields, compileMaskPath(field))
+	}
+
+	return m
+}
+
+// Return copy of header with masked values.
+// If nothing is masked, returns original header.
+func (m *printerMask) maskHeader(header http.Header) http.Header {
+	if m == nil || len(m.headers) == 0 {
+		return header
+	}
+
+	var ret http.Header
+
+	for key, values := range header {
+		if _, ok := m.headers[http.CanonicalHeaderKey(key)]; !ok {
+			continue
+		}
+
+		if ret == nil {
+			ret = header.Clone()
+		}
+
+		masked := make([]string, len(values))
+		for n := range masked {
+			masked[n] = m.placeholder
+		}
+		ret[key] = masked
+	}
+
+	if ret == nil {
+		return header
+	}
+
+	return ret
+}
+
+// Return body with masked JSON fields.
+// If body is not JSON, returns original body.
+func (m *printerMask) maskBody(header http.Header, body []byte) []byte {
+	if m == nil || len(m.fields) == 0 || len(body) == 0 {
+		return body
+	}
+
+	if !isJSONContent(header) {
+		return body
+	}
+
+	return redactBody([]redactorFunc{
+		func(path string, _ interface{}) (interface{}, bool) {
+			for _, re := range m.fields {
+				if re.MatchString(path) {
+					return m.placeholder, true
+				}
+			}
+			return nil, false
+		},
+	}, body)
+}
+
+// Regexp matching single JSON path element of object key, as produced
+// by jsonPathKey.
+const maskAnyKey = `(?:\.[A-Za-z_][A-Za-z0-9_]*|\["(?:[^"\\]|\\.)*"\])`
+
+// Convert JSONPath expression to regexp matching paths produced by
+// redactNodes, e.g. "$..token" matches "$.users[1].token".
+func compileMaskPath(expr string) *regexp.Regexp {
+	if !strings.HasPrefix(expr, "$") {
+		expr = "$.." + strings.TrimPrefix(expr, ".")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`^\$`)
+
+	rest := expr[1:]
+
+	for len(rest) != 0 {
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			sb.WriteString(`(?:` + maskAnyKey + `|\[\d+\])*`)
+			if strings.HasPrefix(rest, "..[") {
+				rest = rest[2:]
+			} else {
+				rest = rest[1:]
+			}
+
+		case strings.HasPrefix(rest, ".*"):
+			sb.WriteString(maskAnyKey)
+			rest = rest[2:]
+
+		case strings.HasPrefix(rest, "[*]"):
+			sb.WriteString(`\[\d+\]`)
+			rest = rest[3:]
+
+		case strings.HasPrefix(rest, "."):
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			sb.WriteString(regexp.QuoteMeta(jsonPathKey(rest[1 : end+1])))
+			rest = rest[end+1:]
+
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			sb.WriteString(regexp.QuoteMeta(rest[:end+1]))
+			rest = rest[end+1:]
+
+		default:
+			sb.WriteString(regexp.QuoteMeta(rest))
+			rest = ""
+		}
+	}
+
+	sb.WriteString(`$`)
+
+	return regexp.MustCompile(sb.String())
+}
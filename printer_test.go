@@ -5,6 +5,8 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -56,6 +58,362 @@ func TestPrinter_Debug(t *testing.T) {
 	printer.Response(nil, 0)
 }
 
+func TestPrinter_DebugBody(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinter(logger, true)
+
+		req, _ := http.NewRequest("POST", "http://example.com",
+			bytes.NewBufferString("hello"))
+
+		printer.Request(req)
+		assert.Contains(t, logger.lastMessage, "hello")
+
+		b, _ := io.ReadAll(req.Body)
+		assert.Equal(t, "hello", string(b))
+	})
+
+	t.Run("binary content type", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinter(logger, true)
+
+		resp := &http.Response{
+			Header: http.Header{"Content-Type": []string{"image/png"}},
+			Body:   io.NopCloser(bytes.NewBufferString("PNG")),
+		}
+
+		printer.Response(resp, 0)
+		assert.Contains(t, logger.lastMessage,
+			"<binary body: 3 bytes, image/png, sha256:")
+		assert.NotContains(t, logger.lastMessage, "PNG")
+
+		b, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "PNG", string(b))
+	})
+
+	t.Run("binary content", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinter(logger, true)
+
+		resp := &http.Response{
+			Body: io.NopCloser(bytes.NewReader([]byte{0xff, 0x00, 0x01})),
+		}
+
+		printer.Response(resp, 0)
+		assert.Contains(t, logger.lastMessage, "<binary body: 3 bytes, sha256:")
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinterOpts(logger, DebugPrinterOpts{
+			Body:        true,
+			MaxBodySize: 6,
+		})
+
+		resp := &http.Response{
+			Body: io.NopCloser(bytes.NewBufferString("aaabbbbbbccc")),
+		}
+
+		printer.Response(resp, 0)
+		assert.Contains(t, logger.lastMessage, "aaa\n<... 6 bytes truncated ...>\nccc")
+		assert.NotContains(t, logger.lastMessage, "bbb")
+
+		b, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "aaabbbbbbccc", string(b))
+	})
+
+	t.Run("truncate large", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinterOpts(logger, DebugPrinterOpts{
+			Body:        true,
+			MaxBodySize: 10,
+		})
+
+		resp := &http.Response{
+			Body: io.NopCloser(bytes.NewReader(bytes.Repeat([]byte("a"), 3000))),
+		}
+
+		printer.Response(resp, 0)
+		assert.Contains(t, logger.lastMessage, "<... 2.9KB truncated ...>")
+	})
+
+	t.Run("skip content types", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinterOpts(logger, DebugPrinterOpts{
+			Body:             true,
+			SkipContentTypes: []string{"image/*", "Application/PDF"},
+		})
+
+		resp := &http.Response{
+			Header:        http.Header{"Content-Type": []string{"image/png"}},
+			ContentLength: 1258291,
+			Body:          failingReader{},
+		}
+
+		// body is not read
+		printer.Response(resp, 0)
+		assert.Contains(t, logger.lastMessage, "<body skipped: 1.2MB, image/png>")
+
+		req, _ := http.NewRequest("POST", "http://example.com",
+			bytes.NewBufferString("%PDF"))
+		req.Header.Set("Content-Type", "application/pdf")
+		req.ContentLength = -1
+
+		printer.Request(req)
+		assert.Contains(t, logger.lastMessage, "<body skipped: application/pdf>")
+		assert.NotContains(t, logger.lastMessage, "%PDF")
+
+		req, _ = http.NewRequest("POST", "http://example.com",
+			bytes.NewBufferString("hello"))
+		req.Header.Set("Content-Type", "text/plain")
+
+		printer.Request(req)
+		assert.Contains(t, logger.lastMessage, "hello")
+	})
+
+	t.Run("size format", func(t *testing.T) {
+		assert.Equal(t, "0 bytes", formatBodySize(0))
+		assert.Equal(t, "1023 bytes", formatBodySize(1023))
+		assert.Equal(t, "1.0KB", formatBodySize(1024))
+		assert.Equal(t, "1.2MB", formatBodySize(1258291))
+		assert.Equal(t, "3.0GB", formatBodySize(3<<30))
+		assert.Equal(t, "2048.0TB", formatBodySize(2<<50))
+	})
+
+	t.Run("truncate utf8", func(t *testing.T) {
+		head, tail := truncateBody([]byte("яяяяя"), 5)
+		assert.Equal(t, "я", string(head))
+		assert.Equal(t, "я", string(tail))
+	})
+
+	t.Run("body dir", func(t *testing.T) {
+		dir := t.TempDir()
+
+		logger := newMockLogger(t)
+		printer := NewDebugPrinterOpts(logger, DebugPrinterOpts{
+			Body:        true,
+			MaxBodySize: 2,
+			BodyDir:     dir,
+		})
+
+		resp := &http.Response{
+			Body: io.NopCloser(bytes.NewBufferString("full body")),
+		}
+
+		printer.Response(resp, 0)
+
+		files, err := filepath.Glob(filepath.Join(dir, "httpexpect-response-*"))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(files))
+		assert.Contains(t, logger.lastMessage, files[0])
+
+		b, err := os.ReadFile(files[0])
+		assert.NoError(t, err)
+		assert.Equal(t, "full body", string(b))
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinter(logger, false)
+
+		req, _ := http.NewRequest("POST", "http://example.com",
+			bytes.NewBufferString("hello"))
+
+		printer.Request(req)
+		assert.NotContains(t, logger.lastMessage, "hello")
+	})
+}
+
+func TestPrinter_Mask(t *testing.T) {
+	mask := PrinterMaskOpts{
+		Headers: append([]string{"x-api-key"}, DefaultMaskedHeaders...),
+		Fields:  []string{"$.password", "$.users[*].token", "secret"},
+	}
+
+	newRequest := func() *http.Request {
+		req, _ := http.NewRequest("POST", "http://example.com",
+			bytes.NewBufferString(`{"password":"p1",`+
+				`"users":[{"token":"t1","name":"john"}],`+
+				`"nested":{"secret":"s1"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer abcdef")
+		req.Header.Set("X-Api-Key", "k1")
+		req.Header.Set("X-Request-Id", "r1")
+		return req
+	}
+
+	t.Run("debug request", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinterOpts(logger, DebugPrinterOpts{
+			Body: true,
+			Mask: mask,
+		})
+
+		req := newRequest()
+		printer.Request(req)
+
+		for _, secret := range []string{"abcdef", "k1", "p1", "t1", "s1"} {
+			assert.NotContains(t, logger.lastMessage, secret)
+		}
+		assert.Contains(t, logger.lastMessage, "Authorization: ***")
+		assert.Contains(t, logger.lastMessage, "X-Request-Id: r1")
+		assert.Contains(t, logger.lastMessage, `"name":"john"`)
+
+		// request itself is not modified
+		assert.Equal(t, "Bearer abcdef", req.Header.Get("Authorization"))
+		b, _ := io.ReadAll(req.Body)
+		assert.Contains(t, string(b), `"password":"p1"`)
+	})
+
+	t.Run("debug response", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinterOpts(logger, DebugPrinterOpts{
+			Body: true,
+			Mask: PrinterMaskOpts{
+				Headers:     DefaultMaskedHeaders,
+				Fields:      []string{"$..token"},
+				Placeholder: "<hidden>",
+			},
+		})
+
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": []string{"application/json"},
+				"Set-Cookie":   []string{"session=c1", "theme=c2"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(`{"token":"t1"}`)),
+		}
+
+		printer.Response(resp, 0)
+
+		assert.NotContains(t, logger.lastMessage, "c1")
+		assert.NotContains(t, logger.lastMessage, "t1")
+		assert.Contains(t, logger.lastMessage, "Set-Cookie: <hidden>")
+		assert.Contains(t, logger.lastMessage, `{"token":"<hidden>"}`)
+
+		assert.Equal(t, []string{"session=c1", "theme=c2"},
+			resp.Header.Values("Set-Cookie"))
+	})
+
+	t.Run("curl", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewCurlPrinterOpts(logger, CurlPrinterOpts{
+			Mask: mask,
+		})
+
+		req := newRequest()
+		printer.Request(req)
+
+		for _, secret := range []string{"abcdef", "k1", "p1", "t1", "s1"} {
+			assert.NotContains(t, logger.lastMessage, secret)
+		}
+		assert.Contains(t, logger.lastMessage, "Authorization: ***")
+
+		b, _ := io.ReadAll(req.Body)
+		assert.Contains(t, string(b), `"password":"p1"`)
+	})
+
+	t.Run("paths", func(t *testing.T) {
+		cases := []struct {
+			expr    string
+			path    string
+			matches bool
+		}{
+			{"$.password", "$.password", true},
+			{"$.password", "$.user.password", false},
+			{"$.users[*].token", "$.users[3].token", true},
+			{"$.users[*].token", "$.users.token", false},
+			{"$.users[1].token", "$.users[1].token", true},
+			{"$.users[1].token", "$.users[2].token", false},
+			{"$.*.token", "$.user.token", true},
+			{"$.*.token", "$.a.b.token", false},
+			{"$..token", "$.token", true},
+			{"$..token", "$.a[0].b.token", true},
+			{"$..token", "$.a.xtoken", false},
+			{"token", "$.a.token", true},
+			{"$..[0]", "$.a[0]", true},
+			{`$["a b"]`, `$["a b"]`, true},
+			{"$.a b", `$["a b"]`, true},
+		}
+
+		for _, tc := range cases {
+			assert.Equal(t, tc.matches, compileMaskPath(tc.expr).MatchString(tc.path),
+				"%s ~ %s", tc.expr, tc.path)
+		}
+	})
+}
+
+func TestPrinter_Lazy(t *testing.T) {
+	newExpect := func(logger Logger) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						_, _ = w.Write([]byte("response " + r.URL.Path))
+					})),
+			},
+			Printers: []Printer{
+				NewLazyPrinter(logger, true),
+			},
+		})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		e := newExpect(logger)
+
+		e.GET("/foo").
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("response /foo")
+
+		assert.False(t, logger.logged)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		e := newExpect(logger)
+
+		e.GET("/foo").
+			Expect().
+			Status(http.StatusOK)
+
+		resp := e.GET("/bar").
+			Expect()
+
+		assert.False(t, logger.logged)
+
+		resp.Body().IsEqual("response /foo")
+
+		assert.True(t, logger.logged)
+		assert.Contains(t, logger.lastMessage, "200 OK")
+		assert.Contains(t, logger.lastMessage, "response /bar")
+		assert.NotContains(t, logger.lastMessage, "response /foo")
+
+		logger.logged = false
+
+		resp.Status(http.StatusNotFound)
+
+		assert.False(t, logger.logged)
+	})
+
+	t.Run("direct", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		printer := NewLazyPrinter(logger, true)
+
+		req, _ := http.NewRequest("GET", "http://example.com", nil)
+		printer.Request(req)
+
+		assert.True(t, logger.logged)
+	})
+}
+
 func TestPrinter_Panics(t *testing.T) {
 	t.Run("CurlPrinter", func(t *testing.T) {
 		curl := NewCurlPrinter(t)
@@ -0,0 +1,443 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Environment variables used by FromEnv and FromProfile.
+const (
+	// Base URL, see Profile.BaseURL.
+	EnvBaseURL = "HTTPEXPECT_BASE_URL"
+
+	// Prefix for default headers, see Profile.Headers.
+	// E.g. HTTPEXPECT_HEADER_X_API_KEY defines X-Api-Key header.
+	EnvHeaderPrefix = "HTTPEXPECT_HEADER_"
+
+	// Request timeout, see Profile.Timeout.
+	EnvTimeout = "HTTPEXPECT_TIMEOUT"
+
+	// Proxy URL, see Profile.Proxy.
+	EnvProxy = "HTTPEXPECT_PROXY"
+
+	// TLS settings, see ProfileTLS.
+	EnvTLSInsecure   = "HTTPEXPECT_TLS_INSECURE"
+	EnvTLSCAFile     = "HTTPEXPECT_TLS_CA_FILE"
+	EnvTLSCertFile   = "HTTPEXPECT_TLS_CERT_FILE"
+	EnvTLSKeyFile    = "HTTPEXPECT_TLS_KEY_FILE"
+	EnvTLSServerName = "HTTPEXPECT_TLS_SERVER_NAME"
+
+	// Path to profile file, see FromProfile.
+	EnvProfileFile = "HTTPEXPECT_PROFILE_FILE"
+)
+
+// Profile files searched by FromProfile if EnvProfileFile is not set.
+var defaultProfileFiles = []string{
+	"httpexpect.yaml",
+	"httpexpect.yml",
+	"httpexpect.json",
+}
+
+// Profile defines connection settings for tested server, like base URL,
+// default headers, timeout, proxy, and TLS settings.
+//
+// Profile can be loaded from environment variables (see ProfileFromEnv)
+// or from profile file (see LoadProfile), and then converted to Config.
+//
+// Usually it's more convenient to use FromEnv and FromProfile helpers.
+type Profile struct {
+	// Base URL prepended to all requests, see Config.BaseURL.
+	BaseURL string `yaml:"base_url"`
+
+	// Headers added to every request, unless request sets them explicitly.
+	Headers map[string]string `yaml:"headers"`
+
+	// Timeout for every request, see http.Client.Timeout.
+	// Zero means no timeout.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Proxy URL. If empty, proxy is taken from HTTP_PROXY, HTTPS_PROXY,
+	// and NO_PROXY environment variables, as usual.
+	Proxy string `yaml:"proxy"`
+
+	// TLS settings.
+	TLS ProfileTLS `yaml:"tls"`
+}
+
+// ProfileTLS defines TLS settings of Profile.
+type ProfileTLS struct {
+	// Disable server certificate verification.
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
+
+	// Path to PEM file with CA certificates used to verify server.
+	// If empty, system pool is used.
+	CAFile string `yaml:"ca_file"`
+
+	// Paths to PEM files with client certificate and key.
+	// Should be either both set or both empty.
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+
+	// Server name used to verify server certificate.
+	ServerName string `yaml:"server_name"`
+}
+
+type profileFile struct {
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// FromEnv returns Config built from environment variables.
+//
+// It's similar to Default, but BaseURL, headers, timeout, proxy, and TLS
+// settings are loaded from HTTPEXPECT_* environment variables (see EnvBaseURL
+// and other Env* constants). If variables are invalid, failure is reported
+// to t, and Config without them is returned.
+//
+// Example:
+//
+//	// HTTPEXPECT_BASE_URL=http://example.com
+//	// HTTPEXPECT_HEADER_AUTHORIZATION="Bearer token"
+//	// HTTPEXPECT_TIMEOUT=10s
+//	func TestSomething(t *testing.T) {
+//		e := httpexpect.WithConfig(httpexpect.FromEnv(t))
+//
+//		e.GET("/path").
+//			Expect().
+//			Status(http.StatusOK)
+//	}
+func FromEnv(t TestingTB) Config {
+	profile, err := ProfileFromEnv()
+	if err != nil {
+		t.Errorf("failed to load httpexpect config from environment: %s", err)
+		return (&Profile{}).config(t)
+	}
+
+	config, err := profile.Config(t)
+	if err != nil {
+		t.Errorf("failed to load httpexpect config from environment: %s", err)
+		return (&Profile{}).config(t)
+	}
+
+	return config
+}
+
+// FromProfile returns Config built from named profile in profile file.
+//
+// Profile file is taken from HTTPEXPECT_PROFILE_FILE environment variable.
+// If it's not set, httpexpect.yaml, httpexpect.yml, or httpexpect.json is
+// searched in current directory (which is package directory under go test).
+// See LoadProfile for file format.
+//
+// Environment variables used by FromEnv, if set, override profile values.
+// If profile can't be loaded, failure is reported to t, and Config without
+// profile settings is returned.
+//
+// Example:
+//
+//	func TestSomething(t *testing.T) {
+//		e := httpexpect.WithConfig(httpexpect.FromProfile(t, "staging"))
+//
+//		e.GET("/path").
+//			Expect().
+//			Status(http.StatusOK)
+//	}
+func FromProfile(t TestingTB, name string) Config {
+	config, err := fromProfile(t, name)
+	if err != nil {
+		t.Errorf("failed to load httpexpect profile %q: %s", name, err)
+		return (&Profile{}).config(t)
+	}
+
+	return config
+}
+
+func fromProfile(t TestingTB, name string) (Config, error) {
+	path, err := findProfileFile()
+	if err != nil {
+		return Config{}, err
+	}
+
+	profile, err := LoadProfile(path, name)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if err := profile.loadEnv(); err != nil {
+		return Config{}, err
+	}
+
+	return profile.Config(t)
+}
+
+func findProfileFile() (string, error) {
+	if path := os.Getenv(EnvProfileFile); path != "" {
+		return path, nil
+	}
+
+	for _, path := range defaultProfileFiles {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("profile file not found (tried %s), and %s is not set",
+		strings.Join(defaultProfileFiles, ", "), EnvProfileFile)
+}
+
+// LoadProfile loads named profile from YAML or JSON file.
+//
+// File should contain "profiles" map with profile names as keys.
+// Field names are same as in Profile, but in snake case.
+//
+// Example file:
+//
+//	profiles:
+//	  staging:
+//	    base_url: https://staging.example.com
+//	    headers:
+//	      Authorization: Bearer token
+//	    timeout: 10s
+//	    proxy: http://proxy.example.com:8080
+//	    tls:
+//	      ca_file: /etc/ssl/staging-ca.pem
+//	      cert_file: client.pem
+//	      key_file: client-key.pem
+//	  local:
+//	    base_url: http://localhost:8080
+func LoadProfile(path, name string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// YAML is a superset of JSON, so both formats are handled here.
+	var file profileFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	profile, ok := file.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in %s", name, path)
+	}
+
+	return &profile, nil
+}
+
+// ProfileFromEnv loads Profile from environment variables.
+//
+// See EnvBaseURL and other Env* constants for list of variables.
+// Unset variables are left zero.
+func ProfileFromEnv() (*Profile, error) {
+	profile := &Profile{}
+
+	if err := profile.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// Override profile fields with environment variables, if they're set.
+func (p *Profile) loadEnv() error {
+	if v, ok := os.LookupEnv(EnvBaseURL); ok {
+		p.BaseURL = v
+	}
+
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(key, EnvHeaderPrefix) || key == EnvHeaderPrefix {
+			continue
+		}
+
+		name := strings.ReplaceAll(strings.TrimPrefix(key, EnvHeaderPrefix), "_", "-")
+
+		if p.Headers == nil {
+			p.Headers = make(map[string]string)
+		}
+		p.Headers[textproto.CanonicalMIMEHeaderKey(name)] = value
+	}
+
+	if v, ok := os.LookupEnv(EnvTimeout); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvTimeout, err)
+		}
+		p.Timeout = d
+	}
+
+	if v, ok := os.LookupEnv(EnvProxy); ok {
+		p.Proxy = v
+	}
+
+	if v, ok := os.LookupEnv(EnvTLSInsecure); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvTLSInsecure, err)
+		}
+		p.TLS.InsecureSkipVerify = b
+	}
+
+	if v, ok := os.LookupEnv(EnvTLSCAFile); ok {
+		p.TLS.CAFile = v
+	}
+
+	if v, ok := os.LookupEnv(EnvTLSCertFile); ok {
+		p.TLS.CertFile = v
+	}
+
+	if v, ok := os.LookupEnv(EnvTLSKeyFile); ok {
+		p.TLS.KeyFile = v
+	}
+
+	if v, ok := os.LookupEnv(EnvTLSServerName); ok {
+		p.TLS.ServerName = v
+	}
+
+	return nil
+}
+
+// Config returns Config built from profile.
+//
+// Like Default, it uses t.Name() for Config.TestName, NewAssertReporter(t)
+// for Config.Reporter, and NewCompactPrinter(t) for Config.Printers.
+// Profile settings are applied to Config.BaseURL, Config.Client, and
+// Config.RequestHooks.
+//
+// Returns error if profile is invalid, e.g. TLS files can't be loaded.
+//
+// Example:
+//
+//	profile, err := httpexpect.LoadProfile("testdata/profiles.yaml", "local")
+//	require.NoError(t, err)
+//
+//	config, err := profile.Config(t)
+//	require.NoError(t, err)
+//
+//	config.Printers = []httpexpect.Printer{
+//		httpexpect.NewDebugPrinter(t, true),
+//	}
+//
+//	e := httpexpect.WithConfig(config)
+func (p *Profile) Config(t TestingTB) (Config, error) {
+	if p.Timeout < 0 {
+		return Config{}, fmt.Errorf("negative timeout %s", p.Timeout)
+	}
+
+	transport, err := p.transport()
+	if err != nil {
+		return Config{}, err
+	}
+
+	config := p.config(t)
+
+	client := config.Client.(*http.Client)
+	client.Timeout = p.Timeout
+	if transport != nil {
+		client.Transport = transport
+	}
+
+	if len(p.Headers) != 0 {
+		config.RequestHooks = append(config.RequestHooks, headersHook(p.Headers))
+	}
+
+	return config, nil
+}
+
+func (p *Profile) config(t TestingTB) Config {
+	return Config{
+		TestName: t.Name(),
+		BaseURL:  p.BaseURL,
+		Client: &http.Client{
+			Jar: NewCookieJar(),
+		},
+		Reporter: NewAssertReporter(t),
+		Printers: []Printer{
+			NewCompactPrinter(t),
+		},
+	}
+}
+
+// Returns nil if default transport can be used.
+func (p *Profile) transport() (*http.Transport, error) {
+	if p.Proxy == "" && p.TLS == (ProfileTLS{}) {
+		return nil, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if p.Proxy != "" {
+		proxyURL, err := url.Parse(p.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if p.TLS != (ProfileTLS{}) {
+		tlsConfig, err := p.TLS.config()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
+
+func (t *ProfileTLS) config() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: t.InsecureSkipVerify, //nolint
+		ServerName:         t.ServerName,
+	}
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", t.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return nil, errors.New("TLS cert file and key file should be set together")
+	}
+
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS key pair: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// Returns request hook that sets headers missing in request.
+func headersHook(headers map[string]string) func(*http.Request) error {
+	return func(req *http.Request) error {
+		for k, v := range headers {
+			if req.Header.Get(k) == "" {
+				req.Header.Set(k, v)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,400 @@
+package httpexpect
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeProfileFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestProfile_FromEnv(t *testing.T) {
+	t.Run("all variables", func(t *testing.T) {
+		t.Setenv(EnvBaseURL, "http://example.com")
+		t.Setenv(EnvHeaderPrefix+"X_API_KEY", "secret")
+		t.Setenv(EnvTimeout, "5s")
+		t.Setenv(EnvProxy, "http://proxy.example.com:8080")
+		t.Setenv(EnvTLSInsecure, "true")
+		t.Setenv(EnvTLSServerName, "test.example.com")
+
+		tb := newMockTestingTB(t)
+		config := FromEnv(tb)
+
+		assert.False(t, tb.reported)
+
+		assert.Equal(t, t.Name(), config.TestName)
+		assert.Equal(t, "http://example.com", config.BaseURL)
+		assert.NotNil(t, config.Reporter)
+		assert.Equal(t, 1, len(config.Printers))
+
+		client, ok := config.Client.(*http.Client)
+		require.True(t, ok)
+		assert.Equal(t, 5*time.Second, client.Timeout)
+		assert.NotNil(t, client.Jar)
+
+		transport, ok := client.Transport.(*http.Transport)
+		require.True(t, ok)
+		require.NotNil(t, transport.TLSClientConfig)
+		assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+		assert.Equal(t, "test.example.com", transport.TLSClientConfig.ServerName)
+
+		req, err := http.NewRequest("GET", "http://example.com/path", nil)
+		require.NoError(t, err)
+
+		proxyURL, err := transport.Proxy(req)
+		require.NoError(t, err)
+		assert.Equal(t, "http://proxy.example.com:8080", proxyURL.String())
+
+		require.Equal(t, 1, len(config.RequestHooks))
+		require.NoError(t, config.RequestHooks[0](req))
+		assert.Equal(t, "secret", req.Header.Get("X-Api-Key"))
+	})
+
+	t.Run("no variables", func(t *testing.T) {
+		tb := newMockTestingTB(t)
+		config := FromEnv(tb)
+
+		assert.False(t, tb.reported)
+
+		client, ok := config.Client.(*http.Client)
+		require.True(t, ok)
+		assert.Nil(t, client.Transport)
+		assert.Equal(t, time.Duration(0), client.Timeout)
+	})
+
+	t.Run("invalid variables", func(t *testing.T) {
+		cases := []struct {
+			name  string
+			key   string
+			value string
+		}{
+			{
+				name:  "timeout",
+				key:   EnvTimeout,
+				value: "bad",
+			},
+			{
+				name:  "negative timeout",
+				key:   EnvTimeout,
+				value: "-1s",
+			},
+			{
+				name:  "insecure",
+				key:   EnvTLSInsecure,
+				value: "bad",
+			},
+			{
+				name:  "proxy",
+				key:   EnvProxy,
+				value: "://bad",
+			},
+			{
+				name:  "ca file",
+				key:   EnvTLSCAFile,
+				value: "/bad/ca.pem",
+			},
+			{
+				name:  "cert without key",
+				key:   EnvTLSCertFile,
+				value: "/bad/cert.pem",
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Setenv(EnvBaseURL, "http://example.com")
+				t.Setenv(tc.key, tc.value)
+
+				tb := newMockTestingTB(t)
+				config := FromEnv(tb)
+
+				assert.True(t, tb.reported)
+
+				assert.Equal(t, "", config.BaseURL)
+				assert.NotNil(t, config.Reporter)
+				assert.NotNil(t, config.Client)
+			})
+		}
+	})
+}
+
+func TestProfile_LoadProfile(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		path := writeProfileFile(t, "profiles.yaml", `
+profiles:
+  staging:
+    base_url: https://staging.example.com
+    headers:
+      Authorization: Bearer token
+    timeout: 10s
+    proxy: http://proxy.example.com
+    tls:
+      insecure_skip_verify: true
+      server_name: example.com
+  local:
+    base_url: http://localhost:8080
+`)
+
+		profile, err := LoadProfile(path, "staging")
+		require.NoError(t, err)
+
+		assert.Equal(t, &Profile{
+			BaseURL: "https://staging.example.com",
+			Headers: map[string]string{
+				"Authorization": "Bearer token",
+			},
+			Timeout: 10 * time.Second,
+			Proxy:   "http://proxy.example.com",
+			TLS: ProfileTLS{
+				InsecureSkipVerify: true,
+				ServerName:         "example.com",
+			},
+		}, profile)
+
+		profile, err = LoadProfile(path, "local")
+		require.NoError(t, err)
+
+		assert.Equal(t, &Profile{
+			BaseURL: "http://localhost:8080",
+		}, profile)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		path := writeProfileFile(t, "profiles.json", `{
+  "profiles": {
+    "staging": {
+      "base_url": "https://staging.example.com",
+      "headers": {"Authorization": "Bearer token"},
+      "timeout": "10s"
+    }
+  }
+}`)
+
+		profile, err := LoadProfile(path, "staging")
+		require.NoError(t, err)
+
+		assert.Equal(t, &Profile{
+			BaseURL: "https://staging.example.com",
+			Headers: map[string]string{
+				"Authorization": "Bearer token",
+			},
+			Timeout: 10 * time.Second,
+		}, profile)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		path := writeProfileFile(t, "profiles.yaml", `
+profiles:
+  staging:
+    base_url: https://staging.example.com
+`)
+
+		_, err := LoadProfile(path, "production")
+		assert.Error(t, err)
+
+		_, err = LoadProfile(filepath.Join(t.TempDir(), "missing.yaml"), "staging")
+		assert.Error(t, err)
+
+		path = writeProfileFile(t, "unknown.yaml", `
+profiles:
+  staging:
+    base_urll: https://staging.example.com
+`)
+
+		_, err = LoadProfile(path, "staging")
+		assert.Error(t, err)
+
+		path = writeProfileFile(t, "timeout.yaml", `
+profiles:
+  staging:
+    timeout: bad
+`)
+
+		_, err = LoadProfile(path, "staging")
+		assert.Error(t, err)
+	})
+}
+
+func TestProfile_FromProfile(t *testing.T) {
+	path := writeProfileFile(t, "profiles.yaml", `
+profiles:
+  staging:
+    base_url: https://staging.example.com
+    headers:
+      Authorization: Bearer token
+      X-Version: "1"
+    timeout: 10s
+`)
+
+	t.Run("profile file", func(t *testing.T) {
+		t.Setenv(EnvProfileFile, path)
+
+		tb := newMockTestingTB(t)
+		config := FromProfile(tb, "staging")
+
+		assert.False(t, tb.reported)
+		assert.Equal(t, "https://staging.example.com", config.BaseURL)
+
+		client, ok := config.Client.(*http.Client)
+		require.True(t, ok)
+		assert.Equal(t, 10*time.Second, client.Timeout)
+
+		req, err := http.NewRequest("GET", "http://example.com/path", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Version", "2")
+
+		require.Equal(t, 1, len(config.RequestHooks))
+		require.NoError(t, config.RequestHooks[0](req))
+		assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+		assert.Equal(t, "2", req.Header.Get("X-Version"))
+	})
+
+	t.Run("environment override", func(t *testing.T) {
+		t.Setenv(EnvProfileFile, path)
+		t.Setenv(EnvBaseURL, "http://localhost:8080")
+		t.Setenv(EnvHeaderPrefix+"AUTHORIZATION", "Bearer other")
+
+		tb := newMockTestingTB(t)
+		config := FromProfile(tb, "staging")
+
+		assert.False(t, tb.reported)
+		assert.Equal(t, "http://localhost:8080", config.BaseURL)
+
+		req, err := http.NewRequest("GET", "http://example.com/path", nil)
+		require.NoError(t, err)
+
+		require.NoError(t, config.RequestHooks[0](req))
+		assert.Equal(t, "Bearer other", req.Header.Get("Authorization"))
+		assert.Equal(t, "1", req.Header.Get("X-Version"))
+	})
+
+	t.Run("missing profile", func(t *testing.T) {
+		t.Setenv(EnvProfileFile, path)
+
+		tb := newMockTestingTB(t)
+		config := FromProfile(tb, "production")
+
+		assert.True(t, tb.reported)
+		assert.Equal(t, "", config.BaseURL)
+		assert.NotNil(t, config.Reporter)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+
+		require.NoError(t, os.Chdir(t.TempDir()))
+		defer func() {
+			require.NoError(t, os.Chdir(wd))
+		}()
+
+		tb := newMockTestingTB(t)
+		FromProfile(tb, "staging")
+
+		assert.True(t, tb.reported)
+	})
+
+	t.Run("default file", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+
+		dir := filepath.Dir(path)
+		require.NoError(t, os.Rename(path, filepath.Join(dir, "httpexpect.yml")))
+
+		require.NoError(t, os.Chdir(dir))
+		defer func() {
+			require.NoError(t, os.Chdir(wd))
+		}()
+
+		tb := newMockTestingTB(t)
+		config := FromProfile(tb, "staging")
+
+		assert.False(t, tb.reported)
+		assert.Equal(t, "https://staging.example.com", config.BaseURL)
+	})
+}
+
+func TestProfile_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	defer server.Close()
+
+	caFile := writeProfileFile(t, "ca.pem", string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	})))
+
+	t.Run("ca file", func(t *testing.T) {
+		profile := &Profile{
+			BaseURL: server.URL,
+			TLS: ProfileTLS{
+				CAFile: caFile,
+			},
+		}
+
+		config, err := profile.Config(newMockTestingTB(t))
+		require.NoError(t, err)
+
+		config.Reporter = newMockReporter(t)
+
+		e := WithConfig(config)
+
+		e.GET("/").
+			Expect().
+			Status(http.StatusOK).
+			chain.assert(t, success)
+	})
+
+	t.Run("no ca file", func(t *testing.T) {
+		profile := &Profile{
+			BaseURL: server.URL,
+		}
+
+		config, err := profile.Config(newMockTestingTB(t))
+		require.NoError(t, err)
+
+		config.Reporter = newMockReporter(t)
+
+		e := WithConfig(config)
+
+		e.GET("/").
+			Expect().
+			chain.assert(t, failure)
+	})
+
+	t.Run("invalid ca file", func(t *testing.T) {
+		profile := &Profile{
+			TLS: ProfileTLS{
+				CAFile: writeProfileFile(t, "bad.pem", "bad"),
+			},
+		}
+
+		_, err := profile.Config(newMockTestingTB(t))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid key pair", func(t *testing.T) {
+		profile := &Profile{
+			TLS: ProfileTLS{
+				CertFile: caFile,
+				KeyFile:  caFile,
+			},
+		}
+
+		_, err := profile.Config(newMockTestingTB(t))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,399 @@
+package httpexpect
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+// QueryNesting defines how keys of nested structs and maps are encoded
+// by Request.WithQueryObject.
+type QueryNesting int
+
+const (
+	// QueryNestingBracket encodes nested keys in brackets,
+	// e.g. "filter[name]=john".
+	QueryNestingBracket QueryNesting = iota
+
+	// QueryNestingDot encodes nested keys separated by dot,
+	// e.g. "filter.name=john".
+	QueryNestingDot
+)
+
+// QuerySlices defines how slices and arrays are encoded
+// by Request.WithQueryObject.
+type QuerySlices int
+
+const (
+	// QuerySlicesRepeat repeats key for every element,
+	// e.g. "id=1&id=2".
+	QuerySlicesRepeat QuerySlices = iota
+
+	// QuerySlicesBrackets repeats key with empty brackets for every element,
+	// e.g. "id[]=1&id[]=2".
+	QuerySlicesBrackets
+
+	// QuerySlicesIndex adds element index to key, using configured nesting
+	// style, e.g. "id[0]=1&id[1]=2" or "id.0=1&id.1=2".
+	QuerySlicesIndex
+
+	// QuerySlicesJoin joins all elements into a single value using
+	// QueryObjectOpts.SliceSeparator, e.g. "id=1,2".
+	QuerySlicesJoin
+)
+
+// QueryObjectOpts defines options for Request.WithQueryObject.
+type QueryObjectOpts struct {
+	// How keys of nested structs and maps are encoded.
+	// Default is QueryNestingBracket.
+	Nesting QueryNesting
+
+	// How slices and arrays are encoded.
+	// Default is QuerySlicesRepeat.
+	Slices QuerySlices
+
+	// Separator used with QuerySlicesJoin.
+	// If empty, "," is used.
+	SliceSeparator string
+
+	// Layout used to format time.Time values.
+	// If empty, time.RFC3339 is used.
+	// Can be overridden for struct field using "layout" struct tag.
+	TimeLayout string
+}
+
+var (
+	queryTimeType    = reflect.TypeOf(time.Time{})
+	queryEncoderType = reflect.TypeOf((*query.Encoder)(nil)).Elem()
+	queryTextType    = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Encodes struct or map into query parameters.
+//
+// Structs may use "url" struct tag with the same syntax as in
+// github.com/google/go-querystring (field name and "omitempty" option),
+// and "layout" tag for time.Time fields. Values implementing query.Encoder
+// or encoding.TextMarshaler are encoded using them. Map keys are sorted.
+type queryEncoder struct {
+	opts QueryObjectOpts
+}
+
+func newQueryEncoder(opts QueryObjectOpts) (*queryEncoder, error) {
+	switch opts.Nesting {
+	case QueryNestingBracket, QueryNestingDot:
+	default:
+		return nil, fmt.Errorf("invalid QueryNesting value: %d", opts.Nesting)
+	}
+
+	switch opts.Slices {
+	case QuerySlicesRepeat, QuerySlicesBrackets, QuerySlicesIndex, QuerySlicesJoin:
+	default:
+		return nil, fmt.Errorf("invalid QuerySlices value: %d", opts.Slices)
+	}
+
+	if opts.SliceSeparator == "" {
+		opts.SliceSeparator = ","
+	}
+
+	if opts.TimeLayout == "" {
+		opts.TimeLayout = time.RFC3339
+	}
+
+	return &queryEncoder{opts: opts}, nil
+}
+
+func (e *queryEncoder) encode(object interface{}) (url.Values, error) {
+	values := make(url.Values)
+
+	v := reflect.ValueOf(object)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return values, nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map:
+		if err := e.encodeValue(values, "", v, e.opts.TimeLayout); err != nil {
+			return nil, err
+		}
+		return values, nil
+
+	default:
+		return nil, fmt.Errorf("expected struct or map, got %s", v.Type())
+	}
+}
+
+func (e *queryEncoder) encodeValue(
+	values url.Values, key string, v reflect.Value, layout string,
+) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if key != "" {
+		if v.Type().Implements(queryEncoderType) {
+			return v.Interface().(query.Encoder).EncodeValues(key, &values)
+		}
+		if v.CanAddr() && v.Addr().Type().Implements(queryEncoderType) {
+			return v.Addr().Interface().(query.Encoder).EncodeValues(key, &values)
+		}
+	}
+
+	if v.Type() == queryTimeType {
+		values.Add(key, v.Interface().(time.Time).Format(layout))
+		return nil
+	}
+
+	if v.Type().Implements(queryTextType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return err
+		}
+		values.Add(key, string(text))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return e.encodeStruct(values, key, v)
+
+	case reflect.Map:
+		return e.encodeMap(values, key, v, layout)
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			values.Add(key, string(v.Bytes()))
+			return nil
+		}
+		return e.encodeSlice(values, key, v, layout)
+
+	default:
+		s, err := formatQueryScalar(v)
+		if err != nil {
+			return err
+		}
+		values.Add(key, s)
+		return nil
+	}
+}
+
+func (e *queryEncoder) encodeStruct(
+	values url.Values, key string, v reflect.Value,
+) error {
+	typ := v.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		tag := field.Tag.Get("url")
+		if tag == "-" {
+			continue
+		}
+
+		name, tagOpts, _ := strings.Cut(tag, ",")
+		omitEmpty := strings.Contains(","+tagOpts+",", ",omitempty,")
+
+		fieldValue := v.Field(i)
+
+		if omitEmpty && fieldValue.IsZero() {
+			continue
+		}
+
+		layout := e.opts.TimeLayout
+		if l := field.Tag.Get("layout"); l != "" {
+			layout = l
+		}
+
+		if name == "" && field.Anonymous {
+			fv := fieldValue
+			for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct && fv.Type() != queryTimeType {
+				if err := e.encodeStruct(values, key, fv); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		err := e.encodeValue(values, e.nestedKey(key, name), fieldValue, layout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *queryEncoder) encodeMap(
+	values url.Values, key string, v reflect.Value, layout string,
+) error {
+	type entry struct {
+		name  string
+		value reflect.Value
+	}
+
+	entries := make([]entry, 0, v.Len())
+
+	iter := v.MapRange()
+	for iter.Next() {
+		name, err := formatQueryScalar(iter.Key())
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{name, iter.Value()})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+
+	for _, ent := range entries {
+		err := e.encodeValue(values, e.nestedKey(key, ent.name), ent.value, layout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *queryEncoder) encodeSlice(
+	values url.Values, key string, v reflect.Value, layout string,
+) error {
+	if key == "" {
+		return errors.New("unexpected slice without key")
+	}
+
+	slices := e.opts.Slices
+
+	// composite elements can't be repeated or joined unambiguously
+	if slices != QuerySlicesIndex && isCompositeQuerySlice(v) {
+		slices = QuerySlicesIndex
+	}
+
+	switch slices {
+	case QuerySlicesRepeat, QuerySlicesBrackets:
+		elemKey := key
+		if slices == QuerySlicesBrackets {
+			elemKey = key + "[]"
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			if err := e.encodeValue(values, elemKey, v.Index(i), layout); err != nil {
+				return err
+			}
+		}
+
+	case QuerySlicesIndex:
+		for i := 0; i < v.Len(); i++ {
+			elemKey := e.nestedKey(key, strconv.Itoa(i))
+
+			if err := e.encodeValue(values, elemKey, v.Index(i), layout); err != nil {
+				return err
+			}
+		}
+
+	case QuerySlicesJoin:
+		elemValues := make(url.Values)
+
+		for i := 0; i < v.Len(); i++ {
+			if err := e.encodeValue(elemValues, key, v.Index(i), layout); err != nil {
+				return err
+			}
+		}
+
+		if v.Len() != 0 {
+			values.Add(key, strings.Join(elemValues[key], e.opts.SliceSeparator))
+		}
+	}
+
+	return nil
+}
+
+func (e *queryEncoder) nestedKey(key, name string) string {
+	if key == "" {
+		return name
+	}
+
+	switch e.opts.Nesting {
+	case QueryNestingDot:
+		return key + "." + name
+	default:
+		return key + "[" + name + "]"
+	}
+}
+
+func isCompositeQuerySlice(v reflect.Value) bool {
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		for elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+			if elem.IsNil() {
+				break
+			}
+			elem = elem.Elem()
+		}
+
+		switch elem.Kind() {
+		case reflect.Struct:
+			if elem.Type() != queryTimeType && !elem.Type().Implements(queryTextType) {
+				return true
+			}
+
+		case reflect.Map, reflect.Slice, reflect.Array:
+			if elem.Kind() == reflect.Slice && elem.Type().Elem().Kind() == reflect.Uint8 {
+				continue
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatQueryScalar(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), nil
+
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+
+	default:
+		return "", fmt.Errorf("unsupported query value type: %s", v.Type())
+	}
+}
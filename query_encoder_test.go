@@ -0,0 +1,224 @@
+package httpexpect
+
+import (
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryEncoder_Encode(t *testing.T) {
+	type Inner struct {
+		Name string `url:"name"`
+		Age  int    `url:"age,omitempty"`
+	}
+
+	type Base struct {
+		Page int `url:"page"`
+	}
+
+	type Outer struct {
+		Base
+		Filter  Inner     `url:"filter"`
+		Tags    []string  `url:"tags"`
+		Skip    string    `url:"-"`
+		Created time.Time `url:"created" layout:"2006-01-02"`
+		Custom  mockQueryEncoder
+		Ptr     *Inner `url:"ptr"`
+		private string
+	}
+
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	outer := Outer{
+		Base:    Base{Page: 2},
+		Filter:  Inner{Name: "foo"},
+		Tags:    []string{"a", "b"},
+		Skip:    "skip",
+		Created: created,
+		Custom:  mockQueryEncoder("custom"),
+		private: "private",
+	}
+
+	cases := []struct {
+		name     string
+		object   interface{}
+		opts     QueryObjectOpts
+		expected url.Values
+	}{
+		{
+			name:   "struct, default options",
+			object: outer,
+			opts:   QueryObjectOpts{},
+			expected: url.Values{
+				"page":         {"2"},
+				"filter[name]": {"foo"},
+				"tags":         {"a", "b"},
+				"created":      {"2023-01-02"},
+				"Custom":       {"custom"},
+			},
+		},
+		{
+			name:   "struct, dot nesting, brackets slices",
+			object: &outer,
+			opts: QueryObjectOpts{
+				Nesting: QueryNestingDot,
+				Slices:  QuerySlicesBrackets,
+			},
+			expected: url.Values{
+				"page":        {"2"},
+				"filter.name": {"foo"},
+				"tags[]":      {"a", "b"},
+				"created":     {"2023-01-02"},
+				"Custom":      {"custom"},
+			},
+		},
+		{
+			name: "map, index slices",
+			object: map[string]interface{}{
+				"ids": []int{1, 2},
+				"filter": map[string]interface{}{
+					"names": []string{"x", "y"},
+				},
+			},
+			opts: QueryObjectOpts{
+				Slices: QuerySlicesIndex,
+			},
+			expected: url.Values{
+				"ids[0]":           {"1"},
+				"ids[1]":           {"2"},
+				"filter[names][0]": {"x"},
+				"filter[names][1]": {"y"},
+			},
+		},
+		{
+			name: "map, dot nesting, index slices",
+			object: map[string]interface{}{
+				"ids": []int{1, 2},
+			},
+			opts: QueryObjectOpts{
+				Nesting: QueryNestingDot,
+				Slices:  QuerySlicesIndex,
+			},
+			expected: url.Values{
+				"ids.0": {"1"},
+				"ids.1": {"2"},
+			},
+		},
+		{
+			name: "map, join slices",
+			object: map[string]interface{}{
+				"ids":   []int{1, 2, 3},
+				"empty": []int{},
+			},
+			opts: QueryObjectOpts{
+				Slices:         QuerySlicesJoin,
+				SliceSeparator: "|",
+			},
+			expected: url.Values{
+				"ids": {"1|2|3"},
+			},
+		},
+		{
+			name: "slice of structs",
+			object: map[string]interface{}{
+				"users": []Inner{{Name: "a"}, {Name: "b", Age: 3}},
+			},
+			opts: QueryObjectOpts{
+				Slices: QuerySlicesJoin,
+			},
+			expected: url.Values{
+				"users[0][name]": {"a"},
+				"users[1][name]": {"b"},
+				"users[1][age]":  {"3"},
+			},
+		},
+		{
+			name: "scalars",
+			object: map[string]interface{}{
+				"bool":  true,
+				"float": 1.5,
+				"uint":  uint8(7),
+				"bytes": []byte("raw"),
+				"nil":   nil,
+			},
+			opts: QueryObjectOpts{},
+			expected: url.Values{
+				"bool":  {"true"},
+				"float": {"1.5"},
+				"uint":  {"7"},
+				"bytes": {"raw"},
+			},
+		},
+		{
+			name: "time and text marshaler",
+			object: map[string]interface{}{
+				"time": created,
+				"ip":   net.IPv4(127, 0, 0, 1),
+			},
+			opts: QueryObjectOpts{
+				TimeLayout: time.Kitchen,
+			},
+			expected: url.Values{
+				"time": {"3:04AM"},
+				"ip":   {"127.0.0.1"},
+			},
+		},
+		{
+			name: "sorted map keys",
+			object: map[int]string{
+				2: "b",
+				1: "a",
+			},
+			opts: QueryObjectOpts{},
+			expected: url.Values{
+				"1": {"a"},
+				"2": {"b"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoder, err := newQueryEncoder(tc.opts)
+			require.NoError(t, err)
+
+			values, err := encoder.encode(tc.object)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, values)
+		})
+	}
+}
+
+func TestQueryEncoder_Invalid(t *testing.T) {
+	t.Run("options", func(t *testing.T) {
+		_, err := newQueryEncoder(QueryObjectOpts{Nesting: QueryNesting(-1)})
+		assert.Error(t, err)
+
+		_, err = newQueryEncoder(QueryObjectOpts{Slices: QuerySlices(-1)})
+		assert.Error(t, err)
+	})
+
+	t.Run("objects", func(t *testing.T) {
+		encoder, err := newQueryEncoder(QueryObjectOpts{})
+		require.NoError(t, err)
+
+		objects := []interface{}{
+			123,
+			"str",
+			[]int{1, 2},
+			map[string]interface{}{"fn": func() {}},
+			map[string]interface{}{"ch": make(chan int)},
+			map[string]interface{}{"err": mockQueryEncoder("err")},
+		}
+
+		for _, object := range objects {
+			_, err := encoder.encode(object)
+			assert.Error(t, err)
+		}
+	})
+}
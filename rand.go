@@ -0,0 +1,78 @@
+package httpexpect
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"sync"
+)
+
+// Wraps rand.Source to be safe for concurrent use, because the same
+// Config.RandSource is shared by all requests.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func newLockedSource(src rand.Source) rand.Source {
+	if src == nil {
+		return nil
+	}
+	if _, ok := src.(*lockedSource); ok {
+		return src
+	}
+	return &lockedSource{src: src}
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.src.Seed(seed)
+}
+
+// Return function generating random float in range [0; 1) using given
+// source, or global generator if source is nil.
+func randFloat64(src rand.Source) func() float64 {
+	if src == nil {
+		return rand.Float64
+	}
+	return rand.New(src).Float64
+}
+
+// Generate random hex string of n bytes using given source.
+// If source is nil, returns empty string.
+func randomHex(src rand.Source, n int) string {
+	if src == nil {
+		return ""
+	}
+
+	buf := make([]byte, n)
+	_, _ = rand.New(src).Read(buf)
+
+	return hex.EncodeToString(buf)
+}
+
+// Generate random UUID (version 4) using given source.
+// If source is nil, crypto/rand is used.
+func randomUUID(src rand.Source) string {
+	var b [16]byte
+	if src != nil {
+		_, _ = rand.New(src).Read(b[:])
+	} else {
+		_, _ = cryptorand.Read(b[:])
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
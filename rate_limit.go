@@ -0,0 +1,129 @@
+package httpexpect
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimit defines client-side limit of request rate, see Config.RateLimit.
+//
+// Limit is enforced using token bucket: bucket holds up to Burst tokens
+// and is refilled with RequestsPerSecond tokens per second. Every attempt
+// to send request takes one token, waiting until it's available.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:  "https://staging.example.com",
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		RateLimit: httpexpect.RateLimit{
+//			RequestsPerSecond: 10,
+//			Burst:             5,
+//		},
+//	})
+type RateLimit struct {
+	// RequestsPerSecond defines sustained rate of requests.
+	// If zero, rate is not limited.
+	RequestsPerSecond float64
+
+	// Burst defines how many requests can be sent at once, without waiting,
+	// after a period of inactivity.
+	// If zero, 1 is used.
+	Burst int
+}
+
+func (limit RateLimit) isEnabled() bool {
+	return limit.RequestsPerSecond > 0
+}
+
+func (limit RateLimit) isValid() bool {
+	return limit.RequestsPerSecond >= 0 &&
+		!math.IsInf(limit.RequestsPerSecond, 0) &&
+		!math.IsNaN(limit.RequestsPerSecond) &&
+		limit.Burst >= 0
+}
+
+// Token bucket shared by all requests created from the same Config.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	burst := limit.Burst
+	if burst == 0 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:  limit.RequestsPerSecond,
+		burst: float64(burst),
+	}
+}
+
+// Take token and return how long caller should wait before using it.
+// Tokens are reserved in order of calls, so concurrent callers are
+// queued instead of competing for the same token.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.last.IsZero() {
+		l.tokens = l.burst
+		l.last = now
+	} else if now.After(l.last) {
+		l.tokens = math.Min(l.burst,
+			l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+	}
+
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// Wait until request can be sent according to config.RateLimit.
+// Returns error if config.Context is canceled while waiting.
+// If sleepFn is nil, config.Clock is used.
+func waitRateLimit(
+	config Config, sleepFn func(d time.Duration) <-chan time.Time,
+) error {
+	limiter := config.rateLimiter
+	if limiter == nil {
+		return nil
+	}
+
+	clock := clockOrDefault(config.Clock)
+	if sleepFn == nil {
+		sleepFn = clock.After
+	}
+
+	delay := limiter.reserve(clock.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	if configCtx := config.Context; configCtx != nil {
+		select {
+		case <-configCtx.Done():
+			return configCtx.Err()
+		case <-sleepFn(delay):
+		}
+	} else {
+		<-sleepFn(delay)
+	}
+
+	return nil
+}
+
+func (r *Request) waitRateLimit() error {
+	return waitRateLimit(r.config, r.sleepFn)
+}
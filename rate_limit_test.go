@@ -0,0 +1,271 @@
+package httpexpect
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit_Expect(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	newExpect := func(t *testing.T, clock Clock, limit RateLimit) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Clock:    clock,
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			RateLimit: limit,
+		})
+	}
+
+	t.Run("burst", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		e := newExpect(t, clock, RateLimit{RequestsPerSecond: 2, Burst: 2})
+
+		for n := 0; n < 5; n++ {
+			e.GET("/").Expect().chain.assert(t, success)
+		}
+
+		assert.InDelta(t, 1.5, clock.Slept().Seconds(), 0.001)
+	})
+
+	t.Run("default burst", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		e := newExpect(t, clock, RateLimit{RequestsPerSecond: 10})
+
+		for n := 0; n < 3; n++ {
+			e.GET("/").Expect().chain.assert(t, success)
+		}
+
+		assert.InDelta(t, 0.2, clock.Slept().Seconds(), 0.001)
+	})
+
+	t.Run("refill", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		e := newExpect(t, clock, RateLimit{RequestsPerSecond: 1, Burst: 2})
+
+		e.GET("/").Expect().chain.assert(t, success)
+		e.GET("/").Expect().chain.assert(t, success)
+
+		clock.Advance(time.Minute)
+
+		e.GET("/").Expect().chain.assert(t, success)
+		e.GET("/").Expect().chain.assert(t, success)
+
+		assert.Equal(t, time.Duration(0), clock.Slept())
+	})
+
+	t.Run("shared by copies", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		e := newExpect(t, clock, RateLimit{RequestsPerSecond: 1, Burst: 1})
+
+		e2 := e.Builder(func(req *Request) {
+			req.WithHeader("X-Test", "1")
+		})
+
+		e.GET("/").Expect().chain.assert(t, success)
+		e2.GET("/").Expect().chain.assert(t, success)
+		e.GET("/").Expect().chain.assert(t, success)
+		e2.GET("/").Expect().chain.assert(t, success)
+
+		assert.InDelta(t, 3, clock.Slept().Seconds(), 0.001)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		e := newExpect(t, clock, RateLimit{})
+
+		for n := 0; n < 10; n++ {
+			e.GET("/").Expect().chain.assert(t, success)
+		}
+
+		assert.Equal(t, time.Duration(0), clock.Slept())
+	})
+}
+
+func TestRateLimit_Retries(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	var attempts []time.Time
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts = append(attempts, clock.Now())
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Clock:    clock,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+		RateLimit: RateLimit{RequestsPerSecond: 1, Burst: 1},
+	})
+
+	e.GET("/").
+		WithMaxRetries(2).
+		WithRetryDelay(100*time.Millisecond, time.Second).
+		Expect().
+		Status(http.StatusServiceUnavailable)
+
+	require.Equal(t, 3, len(attempts))
+
+	// every attempt waits for a token, so attempts are a second apart,
+	// even though retry delay is shorter
+	for n := 1; n < len(attempts); n++ {
+		assert.InDelta(t, 1, attempts[n].Sub(attempts[n-1]).Seconds(), 0.001)
+	}
+}
+
+func TestRateLimit_Repeat(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	var attempts []time.Time
+
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		attempts = append(attempts, clock.Now())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       http.NoBody,
+		}, nil
+	})
+
+	e := WithConfig(Config{
+		BaseURL:   "http://example.com",
+		Reporter:  newMockReporter(t),
+		Clock:     clock,
+		Client:    client,
+		RateLimit: RateLimit{RequestsPerSecond: 1, Burst: 1},
+	})
+
+	stats := e.GET("/").Repeat(4, 1)
+	stats.chain.assert(t, success)
+
+	stats.Count().IsEqual(4)
+	stats.Errors().IsEqual(0)
+
+	require.Equal(t, 4, len(attempts))
+
+	for n := 1; n < len(attempts); n++ {
+		assert.InDelta(t, 1, attempts[n].Sub(attempts[n-1]).Seconds(), 0.001)
+	}
+
+	assert.InDelta(t, 3, clock.Slept().Seconds(), 0.001)
+}
+
+func TestRateLimit_Raw(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	srv := newMockRawServer(t,
+		"HTTP/1.1 400 Bad Request\r\nContent-Length: 0\r\n\r\n")
+	defer srv.close()
+
+	t.Run("raw request", func(t *testing.T) {
+		clock := NewFakeClock(start)
+
+		e := WithConfig(Config{
+			BaseURL:   srv.url(),
+			Reporter:  newMockReporter(t),
+			Clock:     clock,
+			RateLimit: RateLimit{RequestsPerSecond: 2, Burst: 1},
+		})
+
+		for n := 0; n < 3; n++ {
+			e.GET("/").
+				WithRawRequestBytes([]byte("GET / HTTP/1.1\r\nHost: x\r\n\r\n")).
+				Expect().
+				chain.assert(t, success)
+		}
+
+		assert.InDelta(t, 1, clock.Slept().Seconds(), 0.001)
+	})
+
+	t.Run("smuggling probe", func(t *testing.T) {
+		clock := NewFakeClock(start)
+
+		e := WithConfig(Config{
+			BaseURL:   srv.url(),
+			Reporter:  newMockReporter(t),
+			Clock:     clock,
+			RateLimit: RateLimit{RequestsPerSecond: 2, Burst: 1},
+		})
+
+		e.SmugglingProbe("/").
+			ExpectRejected().
+			chain.assert(t, success)
+
+		assert.Greater(t, clock.Slept().Seconds(), 0.0)
+	})
+}
+
+func TestRateLimit_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Context:  ctx,
+		Client: &http.Client{
+			Transport: NewBinder(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				})),
+		},
+		RateLimit: RateLimit{RequestsPerSecond: 0.001, Burst: 1},
+	})
+
+	e.GET("/").Expect().chain.assert(t, success)
+
+	cancel()
+
+	begin := time.Now()
+	e.GET("/").Expect().chain.assert(t, failure)
+
+	assert.Less(t, time.Since(begin).Seconds(), 10.0)
+}
+
+func TestRateLimit_Config(t *testing.T) {
+	cases := []struct {
+		name  string
+		limit RateLimit
+		valid bool
+	}{
+		{"zero", RateLimit{}, true},
+		{"valid", RateLimit{RequestsPerSecond: 0.5, Burst: 3}, true},
+		{"negative rate", RateLimit{RequestsPerSecond: -1}, false},
+		{"negative burst", RateLimit{RequestsPerSecond: 1, Burst: -1}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := Config{
+				Reporter:  newMockReporter(t),
+				RateLimit: tc.limit,
+			}
+
+			if tc.valid {
+				assert.NoError(t, config.Check())
+				assert.NotPanics(t, func() { WithConfig(config) })
+			} else {
+				assert.Error(t, config.Check())
+				assert.Panics(t, func() { WithConfig(config) })
+			}
+		})
+	}
+}
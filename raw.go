@@ -0,0 +1,297 @@
+package httpexpect
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WithRawRequestBytes sets raw bytes to be sent instead of the request
+// built by Request.
+//
+// Raw bytes are written as is to a new TCP (or TLS, for "https" URLs)
+// connection to the host of the request URL, and the response is read
+// back and returned as usual. This allows to send deliberately malformed
+// or non-canonical requests, like duplicate Content-Length headers,
+// invalid header characters, or preserved header casing, which would be
+// otherwise normalized or rejected by net/http.
+//
+// Data should contain complete HTTP/1.x request, including request line,
+// headers, and body. Request method and path are used only to build URL
+// for connection. Client, redirect policy, retries, transformers, request
+// hooks, request signing, and printers are not used; response hooks are
+// applied. TLS config is taken from Config.Client if it's *http.Client
+// with *http.Transport. WithTimeout, WithContext, WithResolveTo, and
+// Config.DNSOverrides are respected.
+//
+// WithRawRequestBytes can't be combined with other methods that set
+// request body, WithWebsocketUpgrade, WithHTTP2, and WithHTTP3.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/upload")
+//	req.WithRawRequestBytes([]byte("POST /upload HTTP/1.1\r\n" +
+//		"Host: example.com\r\n" +
+//		"Content-Length: 3\r\n" +
+//		"Content-Length: 5\r\n" +
+//		"\r\n" +
+//		"hello"))
+//	req.Expect().Status(http.StatusBadRequest)
+func (r *Request) WithRawRequestBytes(data []byte) *Request {
+	opChain := r.chain.enter("WithRawRequestBytes()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRawRequestBytes()") {
+		return r
+	}
+
+	if len(data) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty raw request"),
+			},
+		})
+		return r
+	}
+
+	r.setBody(opChain, "WithRawRequestBytes()", nil, 0, false)
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.rawRequest = append([]byte(nil), data...)
+
+	return r
+}
+
+func (r *Request) executeRaw(opChain *chain) *Response {
+	if r.wsUpgrade || r.protoMajor != 0 {
+		conflict := "WithWebsocketUpgrade()"
+		if !r.wsUpgrade {
+			conflict = fmt.Sprintf("WithHTTP%d()", r.protoMajor)
+		}
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("WithRawRequestBytes() can't be used with %s", conflict),
+			},
+		})
+		return nil
+	}
+
+	if !r.prepareHTTPRequest(opChain) {
+		return nil
+	}
+
+	if r.httpReq.URL.Scheme != "http" && r.httpReq.URL.Scheme != "https" {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{r.httpReq.URL.String()},
+			Errors: []error{
+				errors.New(`expected: url with "http" or "https" scheme`),
+			},
+		})
+		return nil
+	}
+
+	ctx := r.config.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if r.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, r.timeout)
+		defer cancelFn()
+	}
+
+	var (
+		httpResp *http.Response
+		elapsed  time.Duration
+	)
+
+	err := r.waitRateLimit()
+	if err == nil {
+		httpResp, elapsed, err = sendRawRequest(ctx, r.httpReq.URL,
+			rawResolveFunc(r.config, r.resolveTo),
+			clientTLSConfig(r.config.Client), r.rawRequest)
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to send raw http request"),
+				err,
+			},
+		})
+		return nil
+	}
+
+	if !runResponseHooks(opChain, r.config, httpResp) {
+		return nil
+	}
+
+	return newResponse(responseOpts{
+		config:   r.config,
+		chain:    opChain,
+		httpResp: httpResp,
+		rtt:      []time.Duration{elapsed},
+		logger:   r.logger,
+		owner:    r.owner,
+	})
+}
+
+// Get TLS config from client, if it's *http.Client with *http.Transport.
+func clientTLSConfig(client Client) *tls.Config {
+	if httpClient, ok := client.(*http.Client); ok {
+		if transport, ok := httpClient.Transport.(*http.Transport); ok {
+			return transport.TLSClientConfig
+		}
+	}
+	return nil
+}
+
+// Write payload to a new connection to the host of given URL and read
+// response. Response body is read completely before returning.
+func sendRawRequest(
+	ctx context.Context,
+	u *url.URL,
+	resolve resolveFunc,
+	tlsConfig *tls.Config,
+	payload []byte,
+) (*http.Response, time.Duration, error) {
+	start := time.Now()
+
+	conn, err := dialRaw(ctx, u, resolve, tlsConfig)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// unblock reads and writes when context is canceled
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	if _, err := conn.Write(payload); err != nil {
+		return nil, 0, err
+	}
+
+	// method is needed to correctly read response to HEAD request
+	req := &http.Request{
+		Method: rawRequestMethod(payload),
+	}
+
+	httpResp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	elapsed := time.Since(start)
+
+	httpResp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return httpResp, elapsed, nil
+}
+
+// Returns resolveFunc for raw connections: address passed to WithResolveTo,
+// if any, takes precedence over Config.DNSOverrides.
+// Returns nil if address should not be changed.
+func rawResolveFunc(config Config, resolveTo string) resolveFunc {
+	if resolveTo != "" {
+		return resolveToAddr(resolveTo)
+	}
+
+	if len(config.DNSOverrides) != 0 {
+		return resolveOverrides(config.DNSOverrides)
+	}
+
+	return nil
+}
+
+func dialRaw(
+	ctx context.Context, u *url.URL, resolve resolveFunc, tlsConfig *tls.Config,
+) (net.Conn, error) {
+	host := u.Host
+	if u.Port() == "" {
+		if u.Scheme == "https" {
+			host = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	// server name is still taken from URL
+	if resolve != nil {
+		host = resolve(host)
+	}
+
+	if u.Scheme != "https" {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "tcp", host)
+	}
+
+	if tlsConfig != nil {
+		tlsConfig = tlsConfig.Clone()
+	} else {
+		tlsConfig = &tls.Config{}
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = u.Hostname()
+	}
+	// raw request is always HTTP/1.x
+	tlsConfig.NextProtos = []string{"http/1.1"}
+
+	dialer := &tls.Dialer{
+		Config: tlsConfig,
+	}
+
+	return dialer.DialContext(ctx, "tcp", host)
+}
+
+func rawRequestMethod(payload []byte) string {
+	method := payload
+	if i := bytes.IndexAny(method, " \r\n"); i >= 0 {
+		method = method[:i]
+	}
+	if len(method) == 0 {
+		return http.MethodGet
+	}
+	return string(method)
+}
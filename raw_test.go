@@ -0,0 +1,261 @@
+package httpexpect
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_RawRequestBytes(t *testing.T) {
+	t.Run("send", func(t *testing.T) {
+		srv := newMockRawServer(t,
+			"HTTP/1.1 400 Bad Request\r\nContent-Length: 3\r\n\r\nbad")
+		defer srv.close()
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = srv.url()
+
+		payload := "GET /path HTTP/1.1\r\n" +
+			"host: example.com\r\n" +
+			"X-weird-CASE: 1\r\n" +
+			"Content-Length: 0\r\n" +
+			"Content-Length: 1\r\n" +
+			"\r\n"
+
+		var matched *Response
+
+		req := NewRequestC(config, "GET", "/path").
+			WithRawRequestBytes([]byte(payload)).
+			WithMatcher(func(resp *Response) {
+				matched = resp
+			})
+		req.chain.assert(t, success)
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		resp.Status(http.StatusBadRequest)
+		resp.Body().IsEqual("bad")
+		resp.chain.assert(t, success)
+
+		assert.Same(t, resp, matched)
+
+		srv.close()
+
+		require.Equal(t, 1, len(srv.requests))
+		assert.Equal(t, payload, srv.requests[0])
+	})
+
+	t.Run("head", func(t *testing.T) {
+		srv := newMockRawServer(t,
+			"HTTP/1.1 200 OK\r\nContent-Length: 10\r\n\r\n")
+		defer srv.close()
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = srv.url()
+
+		resp := NewRequestC(config, "HEAD", "/").
+			WithRawRequestBytes([]byte("HEAD / HTTP/1.1\r\nHost: x\r\n\r\n")).
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.Status(http.StatusOK)
+		resp.Body().IsEmpty()
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		srv := newMockRawServer(t, "")
+		defer srv.close()
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = srv.url()
+
+		resp := NewRequestC(config, "GET", "/").
+			WithRawRequestBytes([]byte("GET / HTTP/1.1\r\nHost: x\r\n\r\n")).
+			Expect()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		srv := newMockRawServer(t,
+			"HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n")
+		defer srv.close()
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = srv.url()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		resp := NewRequestC(config, "GET", "/").
+			WithContext(ctx).
+			WithTimeout(time.Minute).
+			WithRawRequestBytes([]byte("GET / HTTP/1.1\r\nHost: x\r\n\r\n")).
+			Expect()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("bad scheme", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = "ws://example.com"
+
+		resp := NewRequestC(config, "GET", "/").
+			WithRawRequestBytes([]byte("GET / HTTP/1.1\r\nHost: x\r\n\r\n")).
+			Expect()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("resolve to", func(t *testing.T) {
+		srv := newMockRawServer(t,
+			"HTTP/1.1 200 OK\r\nContent-Length: 2\r\n\r\nok")
+		defer srv.close()
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = "http://example.invalid"
+
+		resp := NewRequestC(config, "GET", "/").
+			WithResolveTo(srv.listener.Addr().String()).
+			WithRawRequestBytes([]byte("GET / HTTP/1.1\r\nHost: x\r\n\r\n")).
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.Status(http.StatusOK)
+		resp.chain.assert(t, success)
+
+		srv.close()
+		assert.Equal(t, 1, len(srv.requests))
+	})
+
+	t.Run("dns overrides", func(t *testing.T) {
+		srv := newMockRawServer(t,
+			"HTTP/1.1 200 OK\r\nContent-Length: 2\r\n\r\nok")
+		defer srv.close()
+
+		config := Config{
+			BaseURL:  "http://example.invalid",
+			Reporter: newMockReporter(t),
+			DNSOverrides: map[string]string{
+				"example.invalid": srv.listener.Addr().String(),
+			},
+		}
+
+		resp := NewRequestC(config, "GET", "/").
+			WithRawRequestBytes([]byte("GET / HTTP/1.1\r\nHost: x\r\n\r\n")).
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.Status(http.StatusOK)
+		resp.chain.assert(t, success)
+
+		srv.close()
+		assert.Equal(t, 1, len(srv.requests))
+	})
+}
+
+func TestRequest_RawRequestBytesUsage(t *testing.T) {
+	raw := []byte("GET / HTTP/1.1\r\nHost: x\r\n\r\n")
+
+	cases := []struct {
+		name        string
+		prepFunc    func(req *Request)
+		prepFails   bool
+		expectFails bool
+	}{
+		{
+			name: "empty data",
+			prepFunc: func(req *Request) {
+				req.WithRawRequestBytes(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "body before raw",
+			prepFunc: func(req *Request) {
+				req.WithText("hello")
+				req.WithRawRequestBytes(raw)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "body after raw",
+			prepFunc: func(req *Request) {
+				req.WithRawRequestBytes(raw)
+				req.WithText("hello")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "websocket",
+			prepFunc: func(req *Request) {
+				req.WithRawRequestBytes(raw)
+				req.WithWebsocketUpgrade()
+			},
+			prepFails:   false,
+			expectFails: true,
+		},
+		{
+			name: "http2",
+			prepFunc: func(req *Request) {
+				req.WithRawRequestBytes(raw)
+				req.WithHTTP2()
+			},
+			prepFails:   false,
+			expectFails: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := newMockConfig(newMockReporter(t))
+			config.BaseURL = "http://127.0.0.1:1"
+
+			req := NewRequestC(config, "GET", "/")
+
+			tc.prepFunc(req)
+
+			if tc.prepFails {
+				req.chain.assert(t, failure)
+			} else {
+				req.chain.assert(t, success)
+
+				resp := req.Expect()
+
+				if tc.expectFails {
+					req.chain.assert(t, failure)
+					resp.chain.assert(t, failure)
+				} else {
+					req.chain.assert(t, success)
+					resp.chain.assert(t, success)
+				}
+			}
+		})
+	}
+
+	t.Run("repeat", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		req := NewRequestC(config, "GET", "/").
+			WithRawRequestBytes(raw)
+
+		req.Repeat(1, 1)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("differential", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		req := NewRequestC(config, "GET", "/").
+			WithRawRequestBytes(raw)
+
+		req.DifferentialProtocols()
+		req.chain.assert(t, failure)
+	})
+}
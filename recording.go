@@ -0,0 +1,329 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// RecordingHandler implements http.Handler that wraps another handler and
+// records all received requests.
+//
+// Recorded requests can be inspected using Requests method. This allows to
+// verify that the server received exactly the expected requests, e.g. when
+// testing retries and redirects.
+//
+// RecordingHandler is safe for concurrent use.
+type RecordingHandler struct {
+	// HTTP handler invoked for every request.
+	// If nil, handler responds with "200 OK" and empty body.
+	Handler http.Handler
+
+	recorder requestRecorder
+}
+
+// NewRecordingHandler returns a new RecordingHandler given a http.Handler.
+//
+// Example:
+//
+//	handler := NewRecordingHandler(myHandler)
+//
+//	e := WithConfig(Config{
+//		Client: &http.Client{
+//			Transport: NewBinder(handler),
+//		},
+//	})
+//
+//	e.GET("/path").Expect()
+//
+//	handler.Requests(t).Length().IsEqual(1)
+func NewRecordingHandler(handler http.Handler) *RecordingHandler {
+	return &RecordingHandler{Handler: handler}
+}
+
+// ServeHTTP implements http.Handler.ServeHTTP.
+func (h *RecordingHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body := h.recorder.record(req, req.Host)
+
+	if body != nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if h.Handler != nil {
+		h.Handler.ServeHTTP(w, req)
+	}
+}
+
+// Requests returns a new Array instance with all recorded requests,
+// in order in which they were received.
+//
+// See RecordingTransport.Requests for the format of array elements.
+//
+// Example:
+//
+//	handler.Requests(t).Length().IsEqual(2)
+//	handler.Requests(t).Value(0).Object().Value("method").IsEqual("GET")
+func (h *RecordingHandler) Requests(reporter Reporter) *Array {
+	return newArray(newChainWithDefaults("Requests()", reporter), h.recorder.values())
+}
+
+// RequestsC is like Requests, but uses given config.
+//
+// Requirements for config are same as for WithConfig function.
+func (h *RecordingHandler) RequestsC(config Config) *Array {
+	return newArray(newChainWithConfig("Requests()", config.withDefaults()),
+		h.recorder.values())
+}
+
+// Reset removes all recorded requests.
+func (h *RecordingHandler) Reset() {
+	h.recorder.reset()
+}
+
+// RecordingTransport implements http.RoundTripper that wraps another
+// transport and records all sent requests.
+//
+// Unlike RecordingHandler, it records requests on client side, so it can
+// be used with any server, including remote ones. Note that when
+// redirects are followed by http.Client, every redirected request is
+// sent via transport and thus recorded.
+//
+// RecordingTransport is safe for concurrent use.
+type RecordingTransport struct {
+	// Transport used to send requests.
+	// If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	recorder requestRecorder
+}
+
+// NewRecordingTransport returns a new RecordingTransport given a
+// http.RoundTripper.
+//
+// Example:
+//
+//	transport := NewRecordingTransport(NewBinder(handler))
+//
+//	e := WithConfig(Config{
+//		Client: &http.Client{
+//			Transport: transport,
+//		},
+//	})
+//
+//	e.GET("/path").Expect()
+//
+//	transport.Requests(t).Length().IsEqual(1)
+func NewRecordingTransport(transport http.RoundTripper) *RecordingTransport {
+	return &RecordingTransport{Transport: transport}
+}
+
+// RoundTrip implements http.RoundTripper.RoundTrip.
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.Host
+	if host == "" && req.URL != nil {
+		host = req.URL.Host
+	}
+
+	body, recResp := t.recorder.recordRequest(req, host)
+
+	if body != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err == nil && resp != nil {
+		t.recorder.recordResponse(recResp, resp)
+	}
+
+	return resp, err
+}
+
+// Requests returns a new Array instance with all recorded requests,
+// in order in which they were sent.
+//
+// Every element is an object with the following keys:
+//   - "method" - request method, e.g. "GET"
+//   - "url" - full request URL (as seen by server for RecordingHandler)
+//   - "host" - request host
+//   - "path" - request URL path
+//   - "query" - raw (encoded) request URL query
+//   - "headers" - object with request headers, where every value is an
+//     array of strings
+//   - "body" - request body as string
+//
+// Example:
+//
+//	transport.Requests(t).Length().IsEqual(2)
+//	transport.Requests(t).Value(1).Object().Value("path").IsEqual("/redirect")
+func (t *RecordingTransport) Requests(reporter Reporter) *Array {
+	return newArray(newChainWithDefaults("Requests()", reporter), t.recorder.values())
+}
+
+// RequestsC is like Requests, but uses given config.
+//
+// Requirements for config are same as for WithConfig function.
+func (t *RecordingTransport) RequestsC(config Config) *Array {
+	return newArray(newChainWithConfig("Requests()", config.withDefaults()),
+		t.recorder.values())
+}
+
+// Reset removes all recorded requests.
+func (t *RecordingTransport) Reset() {
+	t.recorder.reset()
+}
+
+type recordedRequest struct {
+	method string
+	url    string
+	host   string
+	path   string
+	query  string
+	header http.Header
+	body   []byte
+
+	// filled by RecordingTransport when response is received
+	resp *recordedResponse
+}
+
+type recordedResponse struct {
+	received bool
+	status   int
+	header   http.Header
+	// body is appended while user reads response
+	body []byte
+}
+
+type requestRecorder struct {
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+// Record request and return its body, which was fully read.
+// Returns nil if request has no body.
+func (rec *requestRecorder) record(req *http.Request, host string) []byte {
+	body, _ := rec.recordRequest(req, host)
+	return body
+}
+
+// Like record, but also returns entry which should be passed to
+// recordResponse when response is received.
+func (rec *requestRecorder) recordRequest(
+	req *http.Request, host string,
+) ([]byte, *recordedResponse) {
+	var body []byte
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, _ = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+
+		if body == nil {
+			body = []byte{}
+		}
+	}
+
+	r := recordedRequest{
+		method: req.Method,
+		host:   host,
+		header: req.Header.Clone(),
+		body:   body,
+		resp:   &recordedResponse{},
+	}
+
+	if req.URL != nil {
+		r.url = req.URL.String()
+		r.path = req.URL.Path
+		r.query = req.URL.RawQuery
+	}
+
+	if r.header == nil {
+		r.header = http.Header{}
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.requests = append(rec.requests, r)
+
+	return body, r.resp
+}
+
+// Record response status and headers, and wrap response body so that
+// its content is recorded while it is being read.
+func (rec *requestRecorder) recordResponse(r *recordedResponse, resp *http.Response) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	r.received = true
+	r.status = resp.StatusCode
+	r.header = resp.Header.Clone()
+
+	if resp.Body != nil && resp.Body != http.NoBody {
+		resp.Body = &recordingBody{
+			ReadCloser: resp.Body,
+			recorder:   rec,
+			resp:       r,
+		}
+	}
+}
+
+type recordingBody struct {
+	io.ReadCloser
+	recorder *requestRecorder
+	resp     *recordedResponse
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+
+	if n > 0 {
+		b.recorder.mu.Lock()
+		b.resp.body = append(b.resp.body, p[:n]...)
+		b.recorder.mu.Unlock()
+	}
+
+	return n, err
+}
+
+func (rec *requestRecorder) values() []interface{} {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	values := make([]interface{}, 0, len(rec.requests))
+
+	for _, r := range rec.requests {
+		headers := map[string]interface{}{}
+		for k, v := range r.header {
+			items := make([]interface{}, 0, len(v))
+			for _, item := range v {
+				items = append(items, item)
+			}
+			headers[k] = items
+		}
+
+		values = append(values, map[string]interface{}{
+			"method":  r.method,
+			"url":     r.url,
+			"host":    r.host,
+			"path":    r.path,
+			"query":   r.query,
+			"headers": headers,
+			"body":    string(r.body),
+		})
+	}
+
+	return values
+}
+
+func (rec *requestRecorder) reset() {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.requests = nil
+}
@@ -0,0 +1,230 @@
+package httpexpect
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordingHandler_Requests(t *testing.T) {
+	handler := NewRecordingHandler(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(b)
+		}))
+
+	config := newMockConfig(newMockReporter(t))
+	config.BaseURL = "http://example.com"
+	config.Client = &http.Client{
+		Transport: NewBinder(handler),
+	}
+
+	NewRequestC(config, "GET", "/foo").
+		WithQuery("a", "1").
+		WithHeader("X-Test", "bar").
+		Expect().
+		Body().IsEmpty()
+
+	NewRequestC(config, "POST", "/bar").
+		WithText("hello").
+		Expect().
+		Body().IsEqual("hello")
+
+	reporter := newMockReporter(t)
+
+	requests := handler.Requests(reporter)
+	requests.chain.assert(t, success)
+
+	requests.Length().IsEqual(2)
+
+	req0 := requests.Value(0).Object()
+	req0.Value("method").IsEqual("GET")
+	req0.Value("url").IsEqual("http://example.com/foo?a=1")
+	req0.Value("host").IsEqual("example.com")
+	req0.Value("path").IsEqual("/foo")
+	req0.Value("query").IsEqual("a=1")
+	req0.Value("headers").Object().Value("X-Test").IsEqual([]string{"bar"})
+	req0.Value("body").IsEqual("")
+
+	req1 := requests.Value(1).Object()
+	req1.Value("method").IsEqual("POST")
+	req1.Value("path").IsEqual("/bar")
+	req1.Value("body").IsEqual("hello")
+
+	requests.chain.assert(t, success)
+
+	handler.RequestsC(newMockConfig(reporter)).Length().IsEqual(2)
+
+	handler.Reset()
+
+	handler.Requests(reporter).IsEmpty()
+
+	assert.False(t, reporter.reported)
+}
+
+func TestRecordingHandler_NilHandler(t *testing.T) {
+	handler := NewRecordingHandler(nil)
+
+	config := newMockConfig(newMockReporter(t))
+	config.BaseURL = "http://example.com"
+	config.Client = &http.Client{
+		Transport: NewBinder(handler),
+	}
+
+	NewRequestC(config, "GET", "/").
+		Expect().
+		Status(http.StatusOK)
+
+	reporter := newMockReporter(t)
+
+	handler.Requests(reporter).Length().IsEqual(1)
+
+	assert.False(t, reporter.reported)
+}
+
+func TestRecordingHandler_Concurrent(t *testing.T) {
+	handler := NewRecordingHandler(nil)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequest("POST", "http://example.com/",
+				strings.NewReader("body"))
+			_, _ = NewBinder(handler).RoundTrip(req)
+		}()
+	}
+
+	wg.Wait()
+
+	reporter := newMockReporter(t)
+
+	handler.Requests(reporter).Length().IsEqual(10)
+
+	assert.False(t, reporter.reported)
+}
+
+func TestRecordingTransport_Requests(t *testing.T) {
+	t.Run("retries", func(t *testing.T) {
+		attempt := 0
+
+		transport := NewRecordingTransport(NewBinder(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				attempt++
+				if attempt < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				b, _ := io.ReadAll(r.Body)
+				_, _ = w.Write(b)
+			})))
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = "http://example.com"
+		config.Client = &http.Client{
+			Transport: transport,
+		}
+
+		NewRequestC(config, "PUT", "/retry").
+			WithText("hello").
+			WithRetryPolicy(RetryAllErrors).
+			WithMaxRetries(5).
+			WithRetryDelay(0, 0).
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("hello")
+
+		requests := transport.Requests(newMockReporter(t))
+		requests.Length().IsEqual(3)
+
+		for _, req := range requests.Iter() {
+			req.Object().Value("method").IsEqual("PUT")
+			req.Object().Value("url").IsEqual("http://example.com/retry")
+			req.Object().Value("body").IsEqual("hello")
+		}
+
+		requests.chain.assert(t, success)
+	})
+
+	t.Run("redirects", func(t *testing.T) {
+		transport := NewRecordingTransport(NewBinder(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/from" {
+					http.Redirect(w, r, "/to", http.StatusFound)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			})))
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = "http://example.com"
+		config.Client = &http.Client{
+			Transport: transport,
+		}
+
+		NewRequestC(config, "GET", "/from").
+			Expect().
+			Status(http.StatusNoContent)
+
+		requests := transport.Requests(newMockReporter(t))
+		requests.Length().IsEqual(2)
+		requests.Value(0).Object().Value("path").IsEqual("/from")
+		requests.Value(1).Object().Value("path").IsEqual("/to")
+		requests.chain.assert(t, success)
+
+		reporter := newMockReporter(t)
+
+		transport.Reset()
+		transport.RequestsC(newMockConfig(reporter)).IsEmpty()
+
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		transport := NewRecordingTransport(&mockTransport{
+			err: errors.New("test error"),
+		})
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = "http://example.com"
+		config.Client = &http.Client{
+			Transport: transport,
+		}
+
+		NewRequestC(config, "GET", "/").
+			Expect().
+			chain.assert(t, failure)
+
+		reporter := newMockReporter(t)
+
+		transport.Requests(reporter).Length().IsEqual(1)
+
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("original request", func(t *testing.T) {
+		transport := NewRecordingTransport(NewBinder(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {})))
+
+		body := strings.NewReader("hello")
+
+		req, _ := http.NewRequest("POST", "http://example.com/", body)
+		_, err := transport.RoundTrip(req)
+		assert.NoError(t, err)
+
+		reporter := newMockReporter(t)
+
+		transport.Requests(reporter).
+			Value(0).Object().Value("body").IsEqual("hello")
+
+		assert.False(t, reporter.reported)
+	})
+}
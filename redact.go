@@ -0,0 +1,158 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type redactorFunc = func(path string, value interface{}) (interface{}, bool)
+
+// Apply redactors to every node of JSON-like value, starting from the root.
+// Returns a new value; input value is not modified.
+//
+// For every node, redactors are invoked in order with JSON path of the node,
+// e.g. "$.users[1].name". First redactor that returns true replaces the node;
+// replaced nodes are not descended into.
+func redactNodes(redactors []redactorFunc, value interface{}) interface{} {
+	if len(redactors) == 0 {
+		return value
+	}
+
+	return redactNode(redactors, "$", value)
+}
+
+func redactNode(redactors []redactorFunc, path string, value interface{}) interface{} {
+	for _, fn := range redactors {
+		if fn == nil {
+			continue
+		}
+		if replacement, ok := fn(path, value); ok {
+			return replacement
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			ret[key] = redactNode(redactors, path+jsonPathKey(key), elem)
+		}
+		return ret
+
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for n, elem := range v {
+			ret[n] = redactNode(redactors, path+"["+strconv.Itoa(n)+"]", elem)
+		}
+		return ret
+
+	default:
+		return value
+	}
+}
+
+// Format object key as JSON path element.
+func jsonPathKey(key string) string {
+	if jsonIdentRegexp.MatchString(key) {
+		return "." + key
+	}
+
+	return "[" + strconv.Quote(key) + "]"
+}
+
+// Apply redactors to JSON body. Returns original body if it's not JSON.
+func redactBody(redactors []redactorFunc, body []byte) []byte {
+	var value interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	if err := dec.Decode(&value); err != nil {
+		return body
+	}
+
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(redactNodes(redactors, value)); err != nil {
+		return body
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// Return copy of request with redacted JSON body, to be passed to printers.
+// If there is nothing to redact, returns original request.
+func redactPrintedRequest(
+	redactors []redactorFunc, req *http.Request, body *bodyWrapper,
+) *http.Request {
+	if len(redactors) == 0 || body == nil || !isJSONContent(req.Header) {
+		return req
+	}
+
+	rd, err := body.GetBody()
+	if err != nil {
+		return req
+	}
+
+	content, err := io.ReadAll(rd)
+	if err != nil {
+		return req
+	}
+
+	content = redactBody(redactors, content)
+
+	ret := req.Clone(req.Context())
+	ret.Body = io.NopCloser(bytes.NewReader(content))
+	ret.ContentLength = int64(len(content))
+
+	return ret
+}
+
+// Return copy of response with redacted JSON body, to be passed to printers.
+// If there is nothing to redact, returns original response.
+func redactPrintedResponse(
+	redactors []redactorFunc, resp *http.Response,
+) *http.Response {
+	if len(redactors) == 0 || !isJSONContent(resp.Header) {
+		return resp
+	}
+
+	body, ok := resp.Body.(*bodyWrapper)
+	if !ok {
+		return resp
+	}
+
+	rd, err := body.GetBody()
+	if err != nil {
+		return resp
+	}
+
+	content, err := io.ReadAll(rd)
+	if err != nil {
+		return resp
+	}
+
+	content = redactBody(redactors, content)
+
+	ret := *resp
+	ret.Body = io.NopCloser(bytes.NewReader(content))
+	ret.ContentLength = int64(len(content))
+
+	return &ret
+}
+
+// Apply chain redactors to array elements.
+// If redactor replaced the whole array with non-array, it's ignored.
+func redactArray(c *chain, value []interface{}) []interface{} {
+	if ret, ok := c.redact(value).([]interface{}); ok {
+		return ret
+	}
+
+	return value
+}
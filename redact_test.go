@@ -0,0 +1,352 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func redactSuffix(suffix string, replacement interface{}) redactorFunc {
+	return func(path string, value interface{}) (interface{}, bool) {
+		if strings.HasSuffix(path, suffix) {
+			return replacement, true
+		}
+		return nil, false
+	}
+}
+
+func TestRedact_Nodes(t *testing.T) {
+	t.Run("paths", func(t *testing.T) {
+		var paths []string
+
+		value := map[string]interface{}{
+			"a": []interface{}{1.0, map[string]interface{}{"b c": "d"}},
+		}
+
+		redactNodes([]redactorFunc{
+			func(path string, value interface{}) (interface{}, bool) {
+				paths = append(paths, path)
+				return nil, false
+			},
+		}, value)
+
+		assert.Equal(t, []string{
+			"$",
+			"$.a",
+			"$.a[0]",
+			"$.a[1]",
+			`$.a[1]["b c"]`,
+		}, paths)
+	})
+
+	t.Run("order", func(t *testing.T) {
+		value := map[string]interface{}{
+			"token": "secret",
+			"user": map[string]interface{}{
+				"token": "secret",
+				"name":  "john",
+			},
+		}
+
+		result := redactNodes([]redactorFunc{
+			nil,
+			redactSuffix("$.user", "hidden"),
+			redactSuffix(".token", "***"),
+			redactSuffix(".user", "unreachable"),
+		}, value)
+
+		assert.Equal(t, map[string]interface{}{
+			"token": "***",
+			"user":  "hidden",
+		}, result)
+
+		// original value is not modified
+		assert.Equal(t, "secret", value["token"])
+		assert.Equal(t, "secret",
+			value["user"].(map[string]interface{})["token"])
+	})
+
+	t.Run("no redactors", func(t *testing.T) {
+		value := map[string]interface{}{"a": "b"}
+
+		assert.Equal(t, value, redactNodes(nil, value))
+	})
+
+	t.Run("body", func(t *testing.T) {
+		redactors := []redactorFunc{redactSuffix(".id", "<uuid>")}
+
+		assert.Equal(t, `{"id":"<uuid>","n":12345678901234567890}`,
+			string(redactBody(redactors,
+				[]byte(`{"id": "f81d4fae", "n": 12345678901234567890}`))))
+
+		assert.Equal(t, "not json",
+			string(redactBody(redactors, []byte("not json"))))
+	})
+}
+
+func TestRedact_Equal(t *testing.T) {
+	newConfig := func(reporter Reporter) Config {
+		config := newMockConfig(reporter)
+		config.Redactors = []func(string, interface{}) (interface{}, bool){
+			redactSuffix(".id", "<id>"),
+			redactSuffix(".created_at", "<time>"),
+		}
+		return config
+	}
+
+	t.Run("object", func(t *testing.T) {
+		object := NewObjectC(newConfig(newMockReporter(t)), map[string]interface{}{
+			"id":         "f81d4fae",
+			"name":       "john",
+			"created_at": "2023-01-01",
+		})
+
+		object.IsEqual(map[string]interface{}{
+			"id":         "<id>",
+			"name":       "john",
+			"created_at": "<time>",
+		})
+		object.chain.assert(t, success)
+		object.chain.clear()
+
+		object.IsEqual(map[string]interface{}{
+			"id":         "0000",
+			"name":       "john",
+			"created_at": "2000-01-01",
+		})
+		object.chain.assert(t, success)
+		object.chain.clear()
+
+		object.NotEqual(map[string]interface{}{
+			"id":         "0000",
+			"name":       "john",
+			"created_at": "2000-01-01",
+		})
+		object.chain.assert(t, failure)
+		object.chain.clear()
+
+		object.IsEqual(map[string]interface{}{
+			"id":   "f81d4fae",
+			"name": "bob",
+		})
+		object.chain.assert(t, failure)
+	})
+
+	t.Run("report", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := newConfig(newMockReporter(t))
+		config.AssertionHandler = handler
+
+		NewObjectC(config, map[string]interface{}{"id": "f81d4fae"}).
+			IsEqual(map[string]interface{}{"name": "john"})
+
+		// secrets are masked in report
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, map[string]interface{}{"id": "<id>"},
+			handler.failure.Actual.Value)
+	})
+
+	t.Run("array", func(t *testing.T) {
+		array := NewArrayC(newConfig(newMockReporter(t)), []interface{}{
+			map[string]interface{}{"id": "1", "name": "john"},
+			map[string]interface{}{"id": "2", "name": "bob"},
+		})
+
+		array.IsEqual([]interface{}{
+			map[string]interface{}{"id": "3", "name": "john"},
+			map[string]interface{}{"id": "4", "name": "bob"},
+		})
+		array.chain.assert(t, success)
+		array.chain.clear()
+
+		array.IsEqualUnordered([]interface{}{
+			map[string]interface{}{"id": "5", "name": "bob"},
+			map[string]interface{}{"id": "6", "name": "john"},
+		})
+		array.chain.assert(t, success)
+		array.chain.clear()
+
+		array.NotEqualUnordered([]interface{}{
+			map[string]interface{}{"id": "5", "name": "bob"},
+			map[string]interface{}{"id": "6", "name": "john"},
+		})
+		array.chain.assert(t, failure)
+		array.chain.clear()
+
+		array.NotEqual([]interface{}{
+			map[string]interface{}{"id": "1", "name": "john"},
+		})
+		array.chain.assert(t, success)
+	})
+
+	t.Run("value", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+		config.Redactors = []func(string, interface{}) (interface{}, bool){
+			redactSuffix("$", "<root>"),
+		}
+
+		value := NewValueC(config, "foo")
+
+		value.IsEqual("bar")
+		value.chain.assert(t, success)
+		value.chain.clear()
+
+		value.NotEqual("bar")
+		value.chain.assert(t, failure)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		object := NewObjectC(newConfig(newMockReporter(t)), map[string]interface{}{
+			"user": map[string]interface{}{"id": "1", "name": "john"},
+		})
+
+		object.Value("user").Object().
+			IsEqual(map[string]interface{}{"id": "2", "name": "john"}).
+			chain.assert(t, success)
+	})
+}
+
+func TestRedact_Snapshot(t *testing.T) {
+	dir := t.TempDir()
+
+	config := newMockConfig(newMockReporter(t))
+	config.SnapshotDir = dir
+	config.Redactors = []func(string, interface{}) (interface{}, bool){
+		redactSuffix(".id", "[UUID]"),
+	}
+
+	newResp := func(body string) *Response {
+		return NewResponseC(config, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": []string{"application/json"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(body)),
+		})
+	}
+
+	resp := newResp(`{"id":"f81d4fae","name":"john"}`)
+	resp.MatchSnapshot("user")
+	resp.chain.assert(t, success)
+
+	b, err := os.ReadFile(filepath.Join(dir, "user.snap"))
+	require.NoError(t, err)
+	assert.Equal(t,
+		"{\n  \"id\": \"[UUID]\",\n  \"name\": \"john\"\n}\n", string(b))
+
+	resp = newResp(`{"id":"0a1b2c3d","name":"john"}`)
+	resp.MatchSnapshot("user")
+	resp.chain.assert(t, success)
+
+	t.Run("snapshot value", func(t *testing.T) {
+		body := `{"id":"f81d4fae","name":"john","token":"secret"}`
+
+		resp := NewResponseC(config, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type":   []string{"application/json"},
+				"Content-Length": []string{strconv.Itoa(len(body))},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(body)),
+		})
+
+		snap := resp.SnapshotValue()
+		resp.chain.assert(t, success)
+
+		assert.JSONEq(t,
+			`{"id":"[UUID]","name":"john","token":"secret"}`, string(snap.Body()))
+		assert.Equal(t,
+			strconv.Itoa(len(snap.Body())), snap.Header().Get("Content-Length"))
+
+		snap = resp.SnapshotValue(SnapshotOpts{
+			RedactKeys: []string{"token"},
+		})
+		resp.chain.assert(t, success)
+
+		assert.JSONEq(t,
+			`{"id":"[UUID]","name":"john","token":"[REDACTED]"}`, string(snap.Body()))
+
+		// response itself is not redacted
+		resp.JSON().Object().Value("token").IsEqual("secret")
+		resp.chain.assert(t, success)
+	})
+}
+
+type capturePrinter struct {
+	reqBody  string
+	respBody string
+}
+
+func (p *capturePrinter) Request(req *http.Request) {
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		p.reqBody = string(b)
+	}
+}
+
+func (p *capturePrinter) Response(resp *http.Response, _ time.Duration) {
+	if resp.Body != nil {
+		b, _ := io.ReadAll(resp.Body)
+		p.respBody = string(b)
+	}
+}
+
+func TestRedact_Printers(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		printer := &capturePrinter{}
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &mockClient{
+			resp: http.Response{StatusCode: http.StatusOK},
+		}
+		config.Printers = []Printer{printer}
+		config.Redactors = []func(string, interface{}) (interface{}, bool){
+			redactSuffix(".password", "***"),
+		}
+
+		resp := NewRequestC(config, "POST", "/login").
+			WithJSON(map[string]interface{}{
+				"user":     "john",
+				"password": "secret",
+			}).
+			Expect()
+
+		assert.Equal(t, `{"password":"***","user":"john"}`, printer.reqBody)
+		assert.Equal(t, `{"password":"***","user":"john"}`, printer.respBody)
+
+		// actual request and response are not modified
+		resp.JSON().Object().Value("password").IsEqual("secret")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		printer := &capturePrinter{}
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &mockClient{
+			resp: http.Response{StatusCode: http.StatusOK},
+		}
+		config.Printers = []Printer{printer}
+		config.Redactors = []func(string, interface{}) (interface{}, bool){
+			redactSuffix("$", "***"),
+		}
+
+		NewRequestC(config, "POST", "/path").
+			WithText("password").
+			Expect().
+			chain.assert(t, success)
+
+		assert.Equal(t, "password", printer.reqBody)
+		assert.Equal(t, "password", printer.respBody)
+	})
+}
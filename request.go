@@ -14,8 +14,10 @@ import (
 	"os"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/ajg/form"
@@ -40,28 +42,84 @@ type Request struct {
 	maxRetries    int
 	minRetryDelay time.Duration
 	maxRetryDelay time.Duration
+	retryJitter   float64
+	retryBudget   time.Duration
+	retryAfter    bool
 	sleepFn       func(d time.Duration) <-chan time.Time
+	randFn        func() float64
 
 	timeout time.Duration
 
-	httpReq *http.Request
-	path    string
-	query   url.Values
+	unavailableSkipper Skipper
+
+	protoMajor     int
+	http3Transport http.RoundTripper
+
+	resolveTo string
+
+	httpReq   *http.Request
+	basePath  string
+	path      string
+	pathRaw   string
+	pathStyle PathTemplateStyle
+	pathVars  map[string]interface{}
+	query     url.Values
 
 	form        url.Values
 	formbuf     *bytes.Buffer
 	multipart   *multipart.Writer
 	multipartFn func(w io.Writer) *multipart.Writer
 
-	bodySetter   string
-	typeSetter   string
-	forceType    bool
-	expectCalled bool
+	multipartBoundary string
+	multipartStream   *multipartStream
+
+	bodyFunc     func() (io.ReadCloser, error)
+	bodyOneShot  bool
+	bodyFuncUsed bool
+
+	bodySetter string
+	typeSetter string
+	forceType  bool
+
+	// method that finalized request, e.g. "Expect()" or "Build()";
+	// empty if request is not finalized yet
+	finalizedBy string
+
+	wsUpgrade      bool
+	wsSubprotocols []string
+
+	rawRequest []byte
 
-	wsUpgrade bool
+	// protected by mu, because CheckRedirect may be invoked concurrently
+	// by Repeat; recording is disabled in Repeat
+	redirectChain       []*http.Response
+	redirectChainPaused bool
+
+	connReused  *bool
+	timings     *requestTimings
+	headersSize *int
+
+	signers []requestSigner
+
+	// true if request ID was generated instead of set explicitly
+	requestIDGenerated bool
+
+	// true if alias was set explicitly by Alias(), and thus is not
+	// derived from test name, see Config.AutoAliasFromTest
+	aliasExplicit bool
 
 	transformers []func(*http.Request)
-	matchers     []func(*Response)
+	matchers     []func(*MatcherContext, *Response)
+
+	retryObservers []func(int, *http.Response, error, time.Duration)
+	attempts       int
+
+	lazyPrinters []*lazyPrinterBuffer
+
+	logger *RequestLogger
+
+	// Expect instance that created this request, if any
+	owner *Expect
 }
 
 // Deprecated: use NewRequestC instead.
@@ -97,7 +155,7 @@ func NewRequest(config Config, method, path string, pathargs ...interface{}) *Re
 // separated by slash. If BaseURL ends with a slash and path (after interpolation)
 // starts with a slash, only single slash is inserted.
 func NewRequestC(config Config, method, path string, pathargs ...interface{}) *Request {
-	config = config.withDefaults()
+	config = config.withDefaults().withDNSOverrides()
 
 	return newRequest(
 		newChainWithConfig(fmt.Sprintf("Request(%q)", method), config),
@@ -124,22 +182,29 @@ func newRequest(
 		maxRetries:    0,
 		minRetryDelay: time.Millisecond * 50,
 		maxRetryDelay: time.Second * 5,
-		sleepFn: func(d time.Duration) <-chan time.Time {
-			return time.After(d)
-		},
+		sleepFn:       clockOrDefault(config.Clock).After,
+		randFn:        randFloat64(config.RandSource),
 		multipartFn: func(w io.Writer) *multipart.Writer {
 			return multipart.NewWriter(w)
 		},
+
+		logger: newRequestLogger(config, method, path),
 	}
 
+	r.basePath = normalizeBasePath(config.BasePath)
+
+	r.config.Printers, r.lazyPrinters = newLazyPrinterBuffers(config.Printers)
+
+	r.chain.setRequest(r)
+
+	config.lifecycle.requestCreated()
+
 	opChain := r.chain.enter("")
 	defer opChain.leave()
 
 	r.initPath(opChain, path, pathargs...)
 	r.initReq(opChain, method)
 
-	r.chain.setRequest(r)
-
 	return r
 }
 
@@ -183,6 +248,7 @@ func (r *Request) initPath(opChain *chain, path string, pathargs ...interface{})
 	}
 
 	r.path = path
+	r.pathRaw = escapePathTemplate(path)
 }
 
 func (r *Request) initReq(opChain *chain, method string) {
@@ -210,9 +276,25 @@ func (r *Request) Alias(name string) *Request {
 	defer r.mu.Unlock()
 
 	r.chain.setAlias(name)
+	r.logger.setAlias(name)
+	r.aliasExplicit = true
 	return r
 }
 
+// Set alias derived from test name, unless alias was set explicitly.
+// Does nothing if Config.AutoAliasFromTest is disabled, or test name
+// doesn't contain subtest name.
+func (r *Request) setAutoAlias(testName string) {
+	if !r.config.AutoAliasFromTest || r.aliasExplicit {
+		return
+	}
+
+	if alias := subtestName(testName); alias != "" {
+		r.chain.setAlias(alias)
+		r.logger.setAlias(alias)
+	}
+}
+
 // WithName sets convenient request name.
 // This name will be included in assertion reports for this request.
 // It does not affect assertion chain path, inlike Alias.
@@ -237,10 +319,30 @@ func (r *Request) WithName(name string) *Request {
 	}
 
 	r.chain.setRequestName(name)
+	r.logger.setName(name)
 
 	return r
 }
 
+// Logger returns RequestLogger for this request.
+//
+// RequestLogger writes messages to Config.Logger, prefixed with request
+// name or alias and correlation ID. It's intended to be used by custom
+// matchers and transformers, so that their debug messages can be matched
+// with the printer output for the same request.
+//
+// The same logger is available in transformers via request context
+// (see RequestLoggerFromContext) and in matchers via Response.Logger.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithHeader("X-Request-Id", req.Logger().ID())
+//	req.Logger().Logf("starting request")
+func (r *Request) Logger() *RequestLogger {
+	return r.logger
+}
+
 // WithReporter sets reporter to be used for this request.
 //
 // The new reporter overwrites AssertionHandler.
@@ -282,6 +384,7 @@ func (r *Request) WithReporter(reporter Reporter) *Request {
 		Formatter: r.config.Formatter,
 	}
 	r.chain.setHandler(handler)
+	r.setAutoAlias(handlerTestName(handler))
 
 	return r
 }
@@ -325,6 +428,7 @@ func (r *Request) WithAssertionHandler(handler AssertionHandler) *Request {
 	}
 
 	r.chain.setHandler(handler)
+	r.setAutoAlias(handlerTestName(handler))
 
 	return r
 }
@@ -343,15 +447,54 @@ func (r *Request) WithMatcher(matcher func(*Response)) *Request {
 	opChain := r.chain.enter("WithMatcher()")
 	defer opChain.leave()
 
+	if matcher == nil {
+		r.addMatcher(opChain, "WithMatcher()", nil)
+		return r
+	}
+
+	r.addMatcher(opChain, "WithMatcher()", func(_ *MatcherContext, resp *Response) {
+		matcher(resp)
+	})
+	return r
+}
+
+// WithContextMatcher is like WithMatcher, but matcher also receives
+// MatcherContext, which provides request name, attempt number, and
+// assertion path, and allows to add attachments to failure reports.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithContextMatcher(
+//		func(ctx *httpexpect.MatcherContext, resp *httpexpect.Response) {
+//			if ctx.Attempt > 1 {
+//				ctx.Attach("attempts", "text/plain",
+//					[]byte(strconv.Itoa(ctx.Attempt)))
+//			}
+//			resp.Header("API-Version").NotEmpty()
+//		})
+func (r *Request) WithContextMatcher(
+	matcher func(*MatcherContext, *Response),
+) *Request {
+	opChain := r.chain.enter("WithContextMatcher()")
+	defer opChain.leave()
+
+	r.addMatcher(opChain, "WithContextMatcher()", matcher)
+	return r
+}
+
+func (r *Request) addMatcher(
+	opChain *chain, method string, matcher func(*MatcherContext, *Response),
+) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if opChain.failed() {
-		return r
+		return
 	}
 
-	if !r.checkOrder(opChain, "WithMatcher()") {
-		return r
+	if !r.checkOrder(opChain, method) {
+		return
 	}
 
 	if matcher == nil {
@@ -361,11 +504,16 @@ func (r *Request) WithMatcher(matcher func(*Response)) *Request {
 				errors.New("unexpected nil argument"),
 			},
 		})
-		return r
+		return
 	}
 
 	r.matchers = append(r.matchers, matcher)
-	return r
+}
+
+func (r *Request) runMatchers(resp *Response) {
+	for _, matcher := range r.matchers {
+		matcher(newMatcherContext(resp, r.attempts), resp)
+	}
 }
 
 // WithTransformer attaches a transform to the Request.
@@ -455,6 +603,10 @@ func (r *Request) WithClient(client Client) *Request {
 // because the client may contain some state shared among requests like a cookie
 // jar. Otherwise, the whole client is overwritten with a new client.
 //
+// Unless websocket dialer was set explicitly (see Config.WebsocketDialer
+// and WithWebsocketDialer), websocket requests (see WithWebsocketUpgrade)
+// invoke the handler too.
+//
 // Example:
 //
 //	req := NewRequestC(config, "GET", "/path")
@@ -576,6 +728,106 @@ func (r *Request) WithTimeout(timeout time.Duration) *Request {
 	return r
 }
 
+// WithVerbosity overrides Config.Verbosity for this request.
+//
+// It replaces printers of the request with builtin printers for given
+// verbosity level. Assertion handler is not affected. VerbosityDefault
+// can't be used here, because it means "use Config.Printers".
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithVerbosity(VerbosityDebug)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithVerbosity(verbosity Verbosity) *Request {
+	opChain := r.chain.enter("WithVerbosity()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithVerbosity()") {
+		return r
+	}
+
+	if verbosity == VerbosityDefault || !verbosity.isValid() {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected verbosity argument %s", verbosity),
+			},
+		})
+		return r
+	}
+
+	r.config.Verbosity = verbosity
+	r.config.Printers, r.lazyPrinters = newLazyPrinterBuffers(
+		verbosityPrinters(r.config, verbosity))
+
+	return r
+}
+
+// SkipIfUnavailable enables skipping test when server is unavailable.
+//
+// If request can't be sent because server can't be reached, i.e. DNS lookup
+// fails, connection is refused, or dialing fails otherwise, skipper.Skip is
+// invoked with the reason instead of reporting failure. Other errors, like
+// timeouts after connection is established, are reported as usual.
+//
+// This is useful for optional integration tests that depend on external
+// services, e.g. a staging environment, which should not break CI when
+// such service is down.
+//
+// skipper is usually *testing.T. Like t.Skip, skipper should stop test
+// execution, hence Expect should be called from the goroutine running the
+// test. If skipper returns, failure is reported as usual.
+//
+// To enable skipping for all requests, use Expect.Builder.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://staging.example.com/path")
+//	req.SkipIfUnavailable(t)
+//	req.Expect().Status(http.StatusOK)
+//
+//	e := httpexpect.Default(t, "http://staging.example.com")
+//	e = e.Builder(func(req *httpexpect.Request) {
+//		req.SkipIfUnavailable(t)
+//	})
+func (r *Request) SkipIfUnavailable(skipper Skipper) *Request {
+	opChain := r.chain.enter("SkipIfUnavailable()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "SkipIfUnavailable()") {
+		return r
+	}
+
+	if skipper == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.unavailableSkipper = skipper
+
+	return r
+}
+
 // RedirectPolicy defines how redirection responses are handled.
 //
 // Status codes 307, 308 require resending body. They are followed only if
@@ -852,28 +1104,44 @@ func (r *Request) WithRetryDelay(minDelay, maxDelay time.Duration) *Request {
 	return r
 }
 
-// WithWebsocketUpgrade enables upgrades the connection to websocket.
-//
-// At least the following fields are added to the request header:
+// OnRetryAttempt registers a callback invoked after every attempt to send
+// the request, including the first and the last one. It allows to log or
+// inspect intermediate responses, which are otherwise discarded when
+// request is retried.
 //
-//	Upgrade: websocket
-//	Connection: Upgrade
+// Callback arguments are:
+//   - attempt: attempt number, starting from 1
+//   - resp: response, or nil if attempt failed with error
+//   - err: error, or nil if response was received
+//   - nextDelay: delay before next attempt; zero if there will be no more
+//     attempts (or if next attempt is performed without delay)
 //
-// The actual set of header fields is define by the protocol implementation
-// in the gorilla/websocket package.
+// Response body can be read by callback; it's rewound after the callback
+// returns. Body of intermediate responses is closed before next attempt,
+// so callback should not retain it.
 //
-// The user should then call the Response.Websocket() method which returns
-// the Websocket instance. This instance can be used to send messages to the
-// server, to inspect the received messages, and to close the websocket.
+// Callback is invoked from Expect and should not call Request methods.
+// Multiple callbacks may be registered; they are invoked in order.
 //
 // Example:
 //
+//	var statuses []int
+//
 //	req := NewRequestC(config, "GET", "/path")
-//	req.WithWebsocketUpgrade()
-//	ws := req.Expect().Status(http.StatusSwitchingProtocols).Websocket()
-//	defer ws.Disconnect()
-func (r *Request) WithWebsocketUpgrade() *Request {
-	opChain := r.chain.enter("WithWebsocketUpgrade()")
+//	req.WithMaxRetries(1)
+//	req.OnRetryAttempt(
+//		func(attempt int, resp *http.Response, err error, nextDelay time.Duration) {
+//			if resp != nil {
+//				statuses = append(statuses, resp.StatusCode)
+//			}
+//		})
+//	req.Expect().Status(http.StatusOK)
+//
+//	assert.Equal(t, []int{http.StatusServiceUnavailable, http.StatusOK}, statuses)
+func (r *Request) OnRetryAttempt(
+	observer func(attempt int, resp *http.Response, err error, nextDelay time.Duration),
+) *Request {
+	opChain := r.chain.enter("OnRetryAttempt()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -883,31 +1151,42 @@ func (r *Request) WithWebsocketUpgrade() *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithWebsocketUpgrade()") {
+	if !r.checkOrder(opChain, "OnRetryAttempt()") {
 		return r
 	}
 
-	r.wsUpgrade = true
+	if observer == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.retryObservers = append(r.retryObservers, observer)
 
 	return r
 }
 
-// WithWebsocketDialer sets the custom websocket dialer.
+// WithRetryJitter enables randomization of delay between retries.
 //
-// The new dialer overwrites Config.WebsocketDialer. It will be used once to establish
-// the WebSocket connection and receive a response of handshake result.
+// fraction should be in range [0; 1]. Every delay computed according to
+// WithRetryDelay() is replaced with a random value in range
+// [delay*(1-fraction); delay*(1+fraction)].
+//
+// Jitter helps to avoid synchronized retries from multiple clients.
+// By default, jitter is zero, i.e. delays are not randomized.
 //
 // Example:
 //
-//	req := NewRequestC(config, "GET", "/path")
-//	req.WithWebsocketUpgrade()
-//	req.WithWebsocketDialer(&websocket.Dialer{
-//	  EnableCompression: false,
-//	})
-//	ws := req.Expect().Status(http.StatusSwitchingProtocols).Websocket()
-//	defer ws.Disconnect()
-func (r *Request) WithWebsocketDialer(dialer WebsocketDialer) *Request {
-	opChain := r.chain.enter("WithWebsocketDialer()")
+//	req := NewRequestC(config, "POST", "/path")
+//	req.WithMaxRetries(5)
+//	req.WithRetryJitter(0.2)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithRetryJitter(fraction float64) *Request {
+	opChain := r.chain.enter("WithRetryJitter()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -917,40 +1196,42 @@ func (r *Request) WithWebsocketDialer(dialer WebsocketDialer) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithWebsocketDialer()") {
+	if !r.checkOrder(opChain, "WithRetryJitter()") {
 		return r
 	}
 
-	if dialer == nil {
+	if !(fraction >= 0 && fraction <= 1) {
 		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
+			Type:   AssertValid,
+			Actual: &AssertionValue{fraction},
 			Errors: []error{
-				errors.New("unexpected nil argument"),
+				errors.New("invalid jitter fraction, expected value in range [0; 1]"),
 			},
 		})
 		return r
 	}
 
-	r.config.WebsocketDialer = dialer
+	r.retryJitter = fraction
 
 	return r
 }
 
-// WithPath substitutes named parameters in url path.
+// WithRetryBudget limits cumulative time spent on retries.
 //
-// value is converted to string using fmt.Sprint(). If there is no named
-// parameter '{key}' in url path, failure is reported.
+// Budget includes round-trip time of every attempt and delays between
+// attempts. If next delay would exceed the budget, retrying stops, and the
+// last response (or error) is used.
 //
-// Named parameters are case-insensitive.
+// Zero budget means no limit, which is the default.
 //
 // Example:
 //
-//	req := NewRequestC(config, "POST", "/repos/{user}/{repo}")
-//	req.WithPath("user", "gavv")
-//	req.WithPath("repo", "httpexpect")
-//	// path will be "/repos/gavv/httpexpect"
-func (r *Request) WithPath(key string, value interface{}) *Request {
-	opChain := r.chain.enter("WithPath()")
+//	req := NewRequestC(config, "POST", "/path")
+//	req.WithMaxRetries(100)
+//	req.WithRetryBudget(10 * time.Second)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithRetryBudget(maxTotal time.Duration) *Request {
+	opChain := r.chain.enter("WithRetryBudget()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -960,43 +1241,47 @@ func (r *Request) WithPath(key string, value interface{}) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithPath()") {
+	if !r.checkOrder(opChain, "WithRetryBudget()") {
+		return r
+	}
+
+	if maxTotal < 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{maxTotal},
+			Errors: []error{
+				errors.New("invalid negative argument"),
+			},
+		})
 		return r
 	}
 
-	r.withPath(opChain, key, value)
+	r.retryBudget = maxTotal
 
 	return r
 }
 
-// WithPathObject substitutes multiple named parameters in url path.
-//
-// object should be map or struct. If object is struct, it's converted
-// to map using https://github.com/fatih/structs. Structs may contain
-// "path" struct tag, similar to "json" struct tag for json.Marshal().
-//
-// Each map value is converted to string using fmt.Sprint(). If there
-// is no named parameter for some map '{key}' in url path, failure is
-// reported.
+// WithRetryRespectRetryAfter enables honoring of "Retry-After" response
+// header, which is typically sent by rate-limited APIs along with
+// 429 Too Many Requests or 503 Service Unavailable status.
 //
-// Named parameters are case-insensitive.
+// When enabled:
+//   - if response has "Retry-After" header (either delay in seconds or
+//     HTTP date), it's used as delay before next attempt instead of delay
+//     computed according to WithRetryDelay() and WithRetryJitter();
+//     the delay is not limited by maximum delay, but is limited by
+//     WithRetryBudget()
+//   - 429 Too Many Requests status is retried with any retry policy except
+//     DontRetry
 //
 // Example:
 //
-//	type MyPath struct {
-//		Login string `path:"user"`
-//		Repo  string
-//	}
-//
-//	req := NewRequestC(config, "POST", "/repos/{user}/{repo}")
-//	req.WithPathObject(MyPath{"gavv", "httpexpect"})
-//	// path will be "/repos/gavv/httpexpect"
-//
-//	req := NewRequestC(config, "POST", "/repos/{user}/{repo}")
-//	req.WithPathObject(map[string]string{"user": "gavv", "repo": "httpexpect"})
-//	// path will be "/repos/gavv/httpexpect"
-func (r *Request) WithPathObject(object interface{}) *Request {
-	opChain := r.chain.enter("WithPathObject()")
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithMaxRetries(3)
+//	req.WithRetryRespectRetryAfter()
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithRetryRespectRetryAfter() *Request {
+	opChain := r.chain.enter("WithRetryRespectRetryAfter()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1006,97 +1291,93 @@ func (r *Request) WithPathObject(object interface{}) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithPathObject()") {
-		return r
-	}
-
-	if object == nil {
+	if !r.checkOrder(opChain, "WithRetryRespectRetryAfter()") {
 		return r
 	}
 
-	var (
-		m  map[string]interface{}
-		ok bool
-	)
-	if reflect.Indirect(reflect.ValueOf(object)).Kind() == reflect.Struct {
-		s := structs.New(object)
-		s.TagName = "path"
-		m = s.Map()
-	} else {
-		m, ok = canonMap(opChain, object)
-		if !ok {
-			return r
-		}
-	}
-
-	for key, value := range m {
-		r.withPath(opChain, key, value)
-	}
+	r.retryAfter = true
 
 	return r
 }
 
-func (r *Request) withPath(opChain *chain, key string, value interface{}) {
-	found := false
-
-	path, err := interpol.WithFunc(r.path, func(k string, w io.Writer) error {
-		if strings.EqualFold(k, key) {
-			if value == nil {
-				opChain.fail(AssertionFailure{
-					Type: AssertUsage,
-					Errors: []error{
-						fmt.Errorf("unexpected nil interpol argument %q", k),
-					},
-				})
-			} else {
-				mustWrite(w, fmt.Sprint(value))
-				found = true
-			}
-		} else {
-			mustWrite(w, "{")
-			mustWrite(w, k)
-			mustWrite(w, "}")
-		}
-		return nil
-	})
+// WithHTTP2 forces sending request over HTTP/2.
+//
+// For "https" URLs, HTTP/2 is negotiated during TLS handshake, and request
+// fails if server doesn't support it. For "http" URLs, HTTP/2 with prior
+// knowledge (h2c) is used.
+//
+// Transport of the client is replaced with HTTP/2 transport derived from it.
+// Client should be *http.Client with *http.Transport (or nil transport), or
+// with *http2.Transport, otherwise failure is reported. Other settings of
+// the client (redirects, cookie jar, timeout), and TLS config and dial
+// functions of its transport are preserved. Derived transport is reused by
+// all requests of the same Expect instance with the same transport, and
+// its idle connections are closed by Expect.Close.
+//
+// WithHTTP2 can't be used together with WithWebsocketUpgrade or WithHTTP3.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithHTTP2()
+//	req.Expect().ProtoIs("HTTP/2.0")
+func (r *Request) WithHTTP2() *Request {
+	opChain := r.chain.enter("WithHTTP2()")
+	defer opChain.leave()
 
-	if err != nil {
-		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{path},
-			Errors: []error{
-				errors.New("invalid interpol string"),
-				err,
-			},
-		})
-		return
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
 	}
 
-	if !found {
+	if !r.checkOrder(opChain, "WithHTTP2()") {
+		return r
+	}
+
+	if r.protoMajor == 3 {
 		opChain.fail(AssertionFailure{
 			Type: AssertUsage,
 			Errors: []error{
-				fmt.Errorf("key %q not found in interpol string", key),
+				errors.New("unexpected WithHTTP2() call after WithHTTP3()"),
 			},
 		})
-		return
+		return r
 	}
 
-	r.path = path
+	r.protoMajor = 2
+
+	return r
 }
 
-// WithQuery adds query parameter to request URL.
+// WithResolveTo directs connection to given address instead of address
+// from request URL, similar to curl --resolve.
+//
+// ipPort should be in "host:port" form. Request URL, Host header, and TLS
+// server name are not changed. This allows to test virtual host routing
+// and deployments without DNS records.
+//
+// Transport of the client is cloned and its dial functions are wrapped,
+// so dialer timeouts, keep-alive, and custom DialTLSContext are preserved.
+// Cloned transport is reused by all requests of the same Expect instance
+// with the same transport and address, so that they share connections,
+// and its idle connections are closed by Expect.Close. Requests created
+// by NewRequestC clone transport every time. Client should be *http.Client
+// with *http.Transport or HTTP/2 transport (or nil transport), and
+// websocket dialer should be *websocket.Dialer, otherwise failure is
+// reported.
 //
-// value is converted to string using fmt.Sprint() and urlencoded.
+// Proxy settings are ignored: connection is always established directly
+// to given address, even if transport or dialer has a proxy.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithQuery("a", 123)
-//	req.WithQuery("b", "foo")
-//	// URL is now http://example.com/path?a=123&b=foo
-func (r *Request) WithQuery(key string, value interface{}) *Request {
-	opChain := r.chain.enter("WithQuery()")
+//	req := NewRequestC(config, "GET", "https://api.example.com/path")
+//	req.WithResolveTo("127.0.0.1:8443")
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithResolveTo(ipPort string) *Request {
+	opChain := r.chain.enter("WithResolveTo()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1106,52 +1387,45 @@ func (r *Request) WithQuery(key string, value interface{}) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithQuery()") {
+	if !r.checkOrder(opChain, "WithResolveTo()") {
 		return r
 	}
 
-	if value == nil {
+	if _, _, err := net.SplitHostPort(ipPort); err != nil {
 		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
+			Type:   AssertValid,
+			Actual: &AssertionValue{ipPort},
 			Errors: []error{
-				errors.New("unexpected nil argument"),
+				errors.New("invalid address: expected host:port"),
+				err,
 			},
 		})
 		return r
 	}
 
-	if r.query == nil {
-		r.query = make(url.Values)
-	}
-	r.query.Add(key, fmt.Sprint(value))
+	r.resolveTo = ipPort
 
 	return r
 }
 
-// WithQueryObject adds multiple query parameters to request URL.
-//
-// object is converted to query string using github.com/google/go-querystring
-// if it's a struct or pointer to struct, or github.com/ajg/form otherwise.
+// WithHTTP3 forces sending request over HTTP/3 using given transport.
 //
-// Various object types are supported. Structs may contain "url" struct tag,
-// similar to "json" struct tag for json.Marshal().
+// httpexpect doesn't depend on QUIC implementation, so HTTP/3 transport
+// should be provided by user, e.g. http3.RoundTripper from quic-go.
 //
-// Example:
+// Transport of the client is replaced with given transport. If client is
+// *http.Client, its other settings (redirects, cookie jar, timeout) are
+// preserved.
 //
-//	type MyURL struct {
-//		A int    `url:"a"`
-//		B string `url:"b"`
-//	}
+// WithHTTP3 can't be used together with WithWebsocketUpgrade or WithHTTP2.
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithQueryObject(MyURL{A: 123, B: "foo"})
-//	// URL is now http://example.com/path?a=123&b=foo
+// Example:
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithQueryObject(map[string]interface{}{"a": 123, "b": "foo"})
-//	// URL is now http://example.com/path?a=123&b=foo
-func (r *Request) WithQueryObject(object interface{}) *Request {
-	opChain := r.chain.enter("WithQueryObject()")
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithHTTP3(&http3.RoundTripper{})
+//	req.Expect().ProtoIs("HTTP/3.0")
+func (r *Request) WithHTTP3(transport http.RoundTripper) *Request {
+	opChain := r.chain.enter("WithHTTP3()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1161,66 +1435,58 @@ func (r *Request) WithQueryObject(object interface{}) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithQueryObject()") {
+	if !r.checkOrder(opChain, "WithHTTP3()") {
 		return r
 	}
 
-	if object == nil {
+	if transport == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
 		return r
 	}
 
-	var (
-		q   url.Values
-		err error
-	)
-	if reflect.Indirect(reflect.ValueOf(object)).Kind() == reflect.Struct {
-		q, err = query.Values(object)
-		if err != nil {
-			opChain.fail(AssertionFailure{
-				Type:   AssertValid,
-				Actual: &AssertionValue{object},
-				Errors: []error{
-					errors.New("invalid query object"),
-					err,
-				},
-			})
-			return r
-		}
-	} else {
-		q, err = form.EncodeToValues(object)
-		if err != nil {
-			opChain.fail(AssertionFailure{
-				Type:   AssertValid,
-				Actual: &AssertionValue{object},
-				Errors: []error{
-					errors.New("invalid query object"),
-					err,
-				},
-			})
-			return r
-		}
+	if r.protoMajor == 2 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected WithHTTP3() call after WithHTTP2()"),
+			},
+		})
+		return r
 	}
 
-	if r.query == nil {
-		r.query = make(url.Values)
-	}
-	for k, v := range q {
-		r.query[k] = append(r.query[k], v...)
-	}
+	r.protoMajor = 3
+	r.http3Transport = transport
 
 	return r
 }
 
-// WithQueryString parses given query string and adds it to request URL.
+// WithWebsocketUpgrade enables upgrades the connection to websocket.
+//
+// At least the following fields are added to the request header:
+//
+//	Upgrade: websocket
+//	Connection: Upgrade
+//
+// The actual set of header fields is define by the protocol implementation
+// in the gorilla/websocket package.
+//
+// The user should then call the Response.Websocket() method which returns
+// the Websocket instance. This instance can be used to send messages to the
+// server, to inspect the received messages, and to close the websocket.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithQuery("a", 11)
-//	req.WithQueryString("b=22&c=33")
-//	// URL is now http://example.com/path?a=11&bb=22&c=33
-func (r *Request) WithQueryString(query string) *Request {
-	opChain := r.chain.enter("WithQueryString()")
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithWebsocketUpgrade()
+//	ws := req.Expect().Status(http.StatusSwitchingProtocols).Websocket()
+//	defer ws.Disconnect()
+func (r *Request) WithWebsocketUpgrade() *Request {
+	opChain := r.chain.enter("WithWebsocketUpgrade()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1230,46 +1496,32 @@ func (r *Request) WithQueryString(query string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithQueryString()") {
-		return r
-	}
-
-	v, err := url.ParseQuery(query)
-
-	if err != nil {
-		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{query},
-			Errors: []error{
-				errors.New("invalid query string"),
-				err,
-			},
-		})
+	if !r.checkOrder(opChain, "WithWebsocketUpgrade()") {
 		return r
 	}
 
-	if r.query == nil {
-		r.query = make(url.Values)
-	}
-	for k, v := range v {
-		r.query[k] = append(r.query[k], v...)
-	}
+	r.wsUpgrade = true
 
 	return r
 }
 
-// WithURL sets request URL.
+// WithWebsocketSubprotocols sets list of WebSocket subprotocols requested
+// by client, in order of preference.
 //
-// This URL overwrites Config.BaseURL. Request path passed to request constructor
-// is appended to this URL, separated by slash if necessary.
+// Subprotocols are sent in "Sec-WebSocket-Protocol" header. Subprotocol
+// selected by server can be then inspected using Websocket.Subprotocol().
+//
+// WithWebsocketSubprotocols requires WithWebsocketUpgrade to be called too.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "/path")
-//	req.WithURL("http://example.com")
-//	// URL is now http://example.com/path
-func (r *Request) WithURL(urlStr string) *Request {
-	opChain := r.chain.enter("WithURL()")
+//	req := NewRequestC(config, "GET", "/graphql")
+//	req.WithWebsocketUpgrade()
+//	req.WithWebsocketSubprotocols("graphql-transport-ws", "graphql-ws")
+//	ws := req.Expect().Status(http.StatusSwitchingProtocols).Websocket()
+//	ws.Subprotocol().IsEqual("graphql-ws")
+func (r *Request) WithWebsocketSubprotocols(protocols ...string) *Request {
+	opChain := r.chain.enter("WithWebsocketSubprotocols()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1279,38 +1531,41 @@ func (r *Request) WithURL(urlStr string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithURL()") {
+	if !r.checkOrder(opChain, "WithWebsocketSubprotocols()") {
 		return r
 	}
 
-	u, err := url.Parse(urlStr)
-	if err != nil {
+	if len(protocols) == 0 {
 		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{urlStr},
+			Type: AssertUsage,
 			Errors: []error{
-				errors.New("invalid url string"),
-				err,
+				errors.New("unexpected empty subprotocols list"),
 			},
 		})
 		return r
 	}
 
-	r.httpReq.URL = u
+	r.wsSubprotocols = append(r.wsSubprotocols, protocols...)
 
 	return r
 }
 
-// WithHeaders adds given headers to request.
+// WithWebsocketDialer sets the custom websocket dialer.
+//
+// The new dialer overwrites Config.WebsocketDialer. It will be used once to establish
+// the WebSocket connection and receive a response of handshake result.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithHeaders(map[string]string{
-//		"Content-Type": "application/json",
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithWebsocketUpgrade()
+//	req.WithWebsocketDialer(&websocket.Dialer{
+//	  EnableCompression: false,
 //	})
-func (r *Request) WithHeaders(headers map[string]string) *Request {
-	opChain := r.chain.enter("WithHeaders()")
+//	ws := req.Expect().Status(http.StatusSwitchingProtocols).Websocket()
+//	defer ws.Disconnect()
+func (r *Request) WithWebsocketDialer(dialer WebsocketDialer) *Request {
+	opChain := r.chain.enter("WithWebsocketDialer()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1320,25 +1575,47 @@ func (r *Request) WithHeaders(headers map[string]string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithHeaders()") {
+	if !r.checkOrder(opChain, "WithWebsocketDialer()") {
 		return r
 	}
 
-	for k, v := range headers {
-		r.withHeader(k, v)
+	if dialer == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
 	}
 
+	r.config.WebsocketDialer = dialer
+	r.config.websocketDialerDefault = false
+
 	return r
 }
 
-// WithHeader adds given single header to request.
+// WithPathTemplateStyle sets how named parameters in url path are
+// substituted by WithPath and WithPathObject.
+//
+// By default, PathTemplateSimple is used, which replaces every '{key}' with
+// the parameter value. PathTemplateRFC6570 treats path as URI template, as
+// defined by RFC 6570, and supports expressions like '{/id}', '{+path}',
+// '{?query*}', and '{#fragment}'. Template is expanded when request is sent,
+// and expansion may add query parameters and fragment to request URL.
+//
+// Positional arguments passed to NewRequestC always use simple substitution.
+// WithPathTemplateStyle should be called before WithPath and WithPathObject.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithHeader("Content-Type", "application/json")
-func (r *Request) WithHeader(k, v string) *Request {
-	opChain := r.chain.enter("WithHeader()")
+//	req := NewRequestC(config, "GET", "/users{/id}{?fields*}")
+//	req.WithPathTemplateStyle(PathTemplateRFC6570)
+//	req.WithPath("id", 123)
+//	req.WithPath("fields", map[string]string{"name": "john", "age": "30"})
+//	// URL will be "/users/123?age=30&name=john"
+func (r *Request) WithPathTemplateStyle(style PathTemplateStyle) *Request {
+	opChain := r.chain.enter("WithPathTemplateStyle()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1348,75 +1625,82 @@ func (r *Request) WithHeader(k, v string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithHeader()") {
+	if !r.checkOrder(opChain, "WithPathTemplateStyle()") {
 		return r
 	}
 
-	r.withHeader(k, v)
-
-	return r
-}
-
-func (r *Request) withHeader(k, v string) {
-	switch http.CanonicalHeaderKey(k) {
-	case "Host":
-		r.httpReq.Host = v
+	switch style {
+	case PathTemplateSimple:
+		if len(r.pathVars) != 0 {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New(
+						"unexpected call to WithPathTemplateStyle()" +
+							" after WithPath() or WithPathObject()"),
+				},
+			})
+			return r
+		}
 
-	case "Content-Type":
-		if !r.forceType {
-			delete(r.httpReq.Header, "Content-Type")
+	case PathTemplateRFC6570:
+		if _, err := parseURITemplate(r.path); err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{r.path},
+				Errors: []error{
+					errors.New("invalid uri template"),
+					err,
+				},
+			})
+			return r
 		}
-		r.forceType = true
-		r.typeSetter = "WithHeader()"
-		r.httpReq.Header.Add(k, v)
 
 	default:
-		r.httpReq.Header.Add(k, v)
-	}
-}
-
-// WithCookies adds given cookies to request.
-//
-// Example:
-//
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithCookies(map[string]string{
-//		"foo": "aa",
-//		"bar": "bb",
-//	})
-func (r *Request) WithCookies(cookies map[string]string) *Request {
-	opChain := r.chain.enter("WithCookies()")
-	defer opChain.leave()
-
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	if opChain.failed() {
-		return r
-	}
-
-	if !r.checkOrder(opChain, "WithCookies()") {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected path template style: %d", style),
+			},
+		})
 		return r
 	}
 
-	for k, v := range cookies {
-		r.httpReq.AddCookie(&http.Cookie{
-			Name:  k,
-			Value: v,
-		})
-	}
+	r.pathStyle = style
 
 	return r
 }
 
-// WithCookie adds given single cookie to request.
+// WithPath substitutes named parameters in url path.
+//
+// value is converted to string using fmt.Sprint(). If there is no named
+// parameter '{key}' in url path, failure is reported.
+//
+// Named parameters are case-insensitive.
+//
+// Optional PathOpts argument defines how value is escaped. By default,
+// PathEscapingNone is used, and slashes in value create extra path segments.
+// PathEscapingSegment escapes value as a single path segment, and
+// PathEscapingRaw inserts already escaped value verbatim.
+//
+// If PathTemplateRFC6570 style is enabled, parameter names are
+// case-sensitive, and value may also be a slice or a map, which are
+// expanded as lists and associative arrays. See WithPathTemplateStyle.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithCookie("name", "value")
-func (r *Request) WithCookie(k, v string) *Request {
-	opChain := r.chain.enter("WithCookie()")
+//	req := NewRequestC(config, "POST", "/repos/{user}/{repo}")
+//	req.WithPath("user", "gavv")
+//	req.WithPath("repo", "httpexpect")
+//	// path will be "/repos/gavv/httpexpect"
+//
+//	req := NewRequestC(config, "GET", "/files/{name}")
+//	req.WithPath("name", "dir/file", PathOpts{Escaping: PathEscapingSegment})
+//	// path will be "/files/dir%2Ffile"
+func (r *Request) WithPath(
+	key string, value interface{}, options ...PathOpts,
+) *Request {
+	opChain := r.chain.enter("WithPath()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1426,30 +1710,53 @@ func (r *Request) WithCookie(k, v string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithCookie()") {
+	if !r.checkOrder(opChain, "WithPath()") {
 		return r
 	}
 
-	r.httpReq.AddCookie(&http.Cookie{
-		Name:  k,
-		Value: v,
-	})
+	escaping, ok := r.pathEscaping(opChain, options)
+	if !ok {
+		return r
+	}
+
+	r.withPath(opChain, key, value, escaping)
 
 	return r
 }
 
-// WithBasicAuth sets the request's Authorization header to use HTTP
-// Basic Authentication with the provided username and password.
+// WithPathObject substitutes multiple named parameters in url path.
 //
-// With HTTP Basic Authentication the provided username and password
-// are not encrypted.
+// object should be map or struct. If object is struct, it's converted
+// to map using https://github.com/fatih/structs. Structs may contain
+// "path" struct tag, similar to "json" struct tag for json.Marshal().
+//
+// Each map value is converted to string using fmt.Sprint(). If there
+// is no named parameter for some map '{key}' in url path, failure is
+// reported.
+//
+// Named parameters are case-insensitive.
+//
+// Optional PathOpts argument defines how values are escaped, in the same
+// way as for WithPath.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithBasicAuth("john", "secret")
-func (r *Request) WithBasicAuth(username, password string) *Request {
-	opChain := r.chain.enter("WithBasicAuth()")
+//	type MyPath struct {
+//		Login string `path:"user"`
+//		Repo  string
+//	}
+//
+//	req := NewRequestC(config, "POST", "/repos/{user}/{repo}")
+//	req.WithPathObject(MyPath{"gavv", "httpexpect"})
+//	// path will be "/repos/gavv/httpexpect"
+//
+//	req := NewRequestC(config, "POST", "/repos/{user}/{repo}")
+//	req.WithPathObject(map[string]string{"user": "gavv", "repo": "httpexpect"})
+//	// path will be "/repos/gavv/httpexpect"
+func (r *Request) WithPathObject(
+	object interface{}, options ...PathOpts,
+) *Request {
+	opChain := r.chain.enter("WithPathObject()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1459,206 +1766,227 @@ func (r *Request) WithBasicAuth(username, password string) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithBasicAuth()") {
+	if !r.checkOrder(opChain, "WithPathObject()") {
 		return r
 	}
 
-	r.httpReq.SetBasicAuth(username, password)
-
-	return r
-}
-
-// WithHost sets request host to given string.
-//
-// Example:
-//
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithHost("example.com")
-func (r *Request) WithHost(host string) *Request {
-	opChain := r.chain.enter("WithHost()")
-	defer opChain.leave()
-
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	if opChain.failed() {
+	escaping, ok := r.pathEscaping(opChain, options)
+	if !ok {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithHost()") {
+	if object == nil {
 		return r
 	}
 
-	r.httpReq.Host = host
-
-	return r
-}
-
-// WithProto sets HTTP protocol version.
-//
-// proto should have form of "HTTP/{major}.{minor}", e.g. "HTTP/1.1".
-//
-// Example:
-//
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithProto("HTTP/2.0")
-func (r *Request) WithProto(proto string) *Request {
-	opChain := r.chain.enter("WithProto()")
-	defer opChain.leave()
-
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	if opChain.failed() {
-		return r
+	var m map[string]interface{}
+	if reflect.Indirect(reflect.ValueOf(object)).Kind() == reflect.Struct {
+		s := structs.New(object)
+		s.TagName = "path"
+		m = s.Map()
+	} else {
+		m, ok = canonMap(opChain, object)
+		if !ok {
+			return r
+		}
 	}
 
-	if !r.checkOrder(opChain, "WithProto()") {
-		return r
+	for key, value := range m {
+		r.withPath(opChain, key, value, escaping)
 	}
 
-	major, minor, ok := http.ParseHTTPVersion(proto)
+	return r
+}
 
-	if !ok {
+func (r *Request) pathEscaping(
+	opChain *chain, options []PathOpts,
+) (PathEscaping, bool) {
+	if len(options) > 1 {
 		opChain.fail(AssertionFailure{
 			Type: AssertUsage,
 			Errors: []error{
-				fmt.Errorf(
-					`unexpected protocol version %q, expected "HTTP/{major}.{minor}"`,
-					proto),
+				errors.New("unexpected multiple options arguments"),
 			},
 		})
-		return r
+		return PathEscapingNone, false
 	}
 
-	r.httpReq.ProtoMajor = major
-	r.httpReq.ProtoMinor = minor
-
-	return r
-}
-
-// WithChunked enables chunked encoding and sets request body reader.
-//
-// Expect() will read all available data from given reader. Content-Length
-// is not set, and "chunked" Transfer-Encoding is used.
-//
-// If protocol version is not at least HTTP/1.1 (required for chunked
-// encoding), failure is reported.
-//
-// Example:
-//
-//	req := NewRequestC(config, "PUT", "http://example.com/upload")
-//	fh, _ := os.Open("data")
-//	defer fh.Close()
-//	req.WithHeader("Content-Type", "application/octet-stream")
-//	req.WithChunked(fh)
-func (r *Request) WithChunked(reader io.Reader) *Request {
-	opChain := r.chain.enter("WithChunked()")
-	defer opChain.leave()
+	if len(options) == 0 {
+		return PathEscapingNone, true
+	}
 
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	escaping := options[0].Escaping
 
-	if opChain.failed() {
-		return r
+	switch escaping {
+	case PathEscapingNone, PathEscapingSegment, PathEscapingRaw:
+	default:
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected path escaping: %d", escaping),
+			},
+		})
+		return PathEscapingNone, false
 	}
 
-	if !r.checkOrder(opChain, "WithChunked()") {
-		return r
-	}
-	if !r.httpReq.ProtoAtLeast(1, 1) {
+	if escaping != PathEscapingNone && r.pathStyle == PathTemplateRFC6570 {
 		opChain.fail(AssertionFailure{
 			Type: AssertUsage,
 			Errors: []error{
-				fmt.Errorf(
-					`chunked Transfer-Encoding requires at least "HTTP/1.1",`+
-						` but "HTTP/%d.%d" is used`,
-					r.httpReq.ProtoMajor, r.httpReq.ProtoMinor),
+				errors.New(
+					"unexpected path escaping option with PathTemplateRFC6570 style"),
 			},
 		})
-		return r
+		return PathEscapingNone, false
 	}
 
-	r.setBody(opChain, "WithChunked()", reader, -1, false)
-
-	return r
+	return escaping, true
 }
 
-// WithBytes sets request body to given slice of bytes.
-//
-// Example:
-//
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithHeader("Content-Type", "application/json")
-//	req.WithBytes([]byte(`{"foo": 123}`))
-func (r *Request) WithBytes(b []byte) *Request {
-	opChain := r.chain.enter("WithBytes()")
-	defer opChain.leave()
+func (r *Request) withPath(
+	opChain *chain, key string, value interface{}, escaping PathEscaping,
+) {
+	if r.pathStyle == PathTemplateRFC6570 {
+		r.withPathVar(opChain, key, value)
+		return
+	}
 
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	var decoded, escaped string
 
-	if opChain.failed() {
-		return r
-	}
+	if value != nil {
+		var err error
+		decoded, escaped, err = escapePathValue(fmt.Sprint(value), escaping)
 
-	if !r.checkOrder(opChain, "WithBytes()") {
-		return r
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{value},
+				Errors: []error{
+					fmt.Errorf("invalid escaped value of path parameter %q", key),
+					err,
+				},
+			})
+			return
+		}
 	}
 
-	if b == nil {
-		r.setBody(opChain, "WithBytes()", nil, 0, false)
-	} else {
-		r.setBody(opChain, "WithBytes()", bytes.NewReader(b), len(b), false)
+	found := false
+
+	path, err := interpol.WithFunc(r.path, func(k string, w io.Writer) error {
+		if strings.EqualFold(k, key) {
+			if value == nil {
+				opChain.fail(AssertionFailure{
+					Type: AssertUsage,
+					Errors: []error{
+						fmt.Errorf("unexpected nil interpol argument %q", k),
+					},
+				})
+			} else {
+				mustWrite(w, decoded)
+				found = true
+			}
+		} else {
+			mustWrite(w, "{")
+			mustWrite(w, k)
+			mustWrite(w, "}")
+		}
+		return nil
+	})
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{path},
+			Errors: []error{
+				errors.New("invalid interpol string"),
+				err,
+			},
+		})
+		return
 	}
 
-	return r
-}
+	if !found {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("key %q not found in interpol string", key),
+			},
+		})
+		return
+	}
 
-// WithText sets Content-Type header to "text/plain; charset=utf-8" and
-// sets body to given string.
-//
-// Example:
-//
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithText("hello, world!")
-func (r *Request) WithText(s string) *Request {
-	opChain := r.chain.enter("WithText()")
-	defer opChain.leave()
+	pathRaw, err := interpol.WithFunc(r.pathRaw, func(k string, w io.Writer) error {
+		if strings.EqualFold(k, key) {
+			mustWrite(w, escaped)
+		} else {
+			mustWrite(w, "{")
+			mustWrite(w, k)
+			mustWrite(w, "}")
+		}
+		return nil
+	})
 
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	if err != nil {
+		pathRaw = escapePathTemplate(path)
+	}
 
-	if opChain.failed() {
-		return r
+	r.path = path
+	r.pathRaw = pathRaw
+}
+
+func (r *Request) withPathVar(opChain *chain, key string, value interface{}) {
+	tmpl, err := parseURITemplate(r.path)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{r.path},
+			Errors: []error{
+				errors.New("invalid uri template"),
+				err,
+			},
+		})
+		return
 	}
 
-	if !r.checkOrder(opChain, "WithText()") {
-		return r
+	if !tmpl.hasVar(key) {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("key %q not found in uri template", key),
+			},
+		})
+		return
 	}
 
-	r.setType(opChain, "WithText()", "text/plain; charset=utf-8", false)
-	r.setBody(opChain, "WithText()", strings.NewReader(s), len(s), false)
+	if value == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected nil uri template argument %q", key),
+			},
+		})
+		return
+	}
 
-	return r
+	if r.pathVars == nil {
+		r.pathVars = make(map[string]interface{})
+	}
+
+	r.pathVars[key] = value
 }
 
-// WithJSON sets Content-Type header to "application/json; charset=utf-8"
-// and sets body to object, marshaled using json.Marshal().
-//
-// Example:
+// WithQuery adds query parameter to request URL.
 //
-//	type MyJSON struct {
-//		Foo int `json:"foo"`
-//	}
+// value is converted to string using fmt.Sprint() and urlencoded.
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithJSON(MyJSON{Foo: 123})
+// Example:
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithJSON(map[string]interface{}{"foo": 123})
-func (r *Request) WithJSON(object interface{}) *Request {
-	opChain := r.chain.enter("WithJSON()")
+//	req.WithQuery("a", 123)
+//	req.WithQuery("b", "foo")
+//	// URL is now http://example.com/path?a=123&b=foo
+func (r *Request) WithQuery(key string, value interface{}) *Request {
+	opChain := r.chain.enter("WithQuery()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1668,54 +1996,71 @@ func (r *Request) WithJSON(object interface{}) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithJSON()") {
+	if !r.checkOrder(opChain, "WithQuery()") {
 		return r
 	}
 
-	b, err := json.Marshal(object)
-
-	if err != nil {
+	if value == nil {
 		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{object},
+			Type: AssertUsage,
 			Errors: []error{
-				errors.New("invalid json object"),
-				err,
+				errors.New("unexpected nil argument"),
 			},
 		})
 		return r
 	}
 
-	r.setType(opChain, "WithJSON()", "application/json; charset=utf-8", false)
-	r.setBody(opChain, "WithJSON()", bytes.NewReader(b), len(b), false)
+	if r.query == nil {
+		r.query = make(url.Values)
+	}
+	r.query.Add(key, fmt.Sprint(value))
 
 	return r
 }
 
-// WithForm sets Content-Type header to "application/x-www-form-urlencoded"
-// or (if WithMultipart() was called) "multipart/form-data", converts given
-// object to url.Values using github.com/ajg/form, and adds it to request body.
+// WithQueryObject adds multiple query parameters to request URL.
 //
-// Various object types are supported, including maps and structs. Structs may
-// contain "form" struct tag, similar to "json" struct tag for json.Marshal().
-// See https://github.com/ajg/form for details.
+// object is converted to query string using github.com/google/go-querystring
+// if it's a struct or pointer to struct, or github.com/ajg/form otherwise.
 //
-// Multiple WithForm(), WithFormField(), and WithFile() calls may be combined.
-// If WithMultipart() is called, it should be called first.
+// Various object types are supported. Structs may contain "url" struct tag,
+// similar to "json" struct tag for json.Marshal().
+//
+// If options are given, object should be a struct or a map, and it is
+// converted using builtin encoder instead, which encodes nested structs and
+// maps, slices, and time.Time values as configured by QueryObjectOpts. Map
+// keys are sorted, so that result is deterministic. Values implementing
+// query.Encoder from github.com/google/go-querystring or
+// encoding.TextMarshaler are encoded using them.
 //
 // Example:
 //
-//	type MyForm struct {
-//		Foo int `form:"foo"`
+//	type MyURL struct {
+//		A int    `url:"a"`
+//		B string `url:"b"`
 //	}
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithForm(MyForm{Foo: 123})
+//	req.WithQueryObject(MyURL{A: 123, B: "foo"})
+//	// URL is now http://example.com/path?a=123&b=foo
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithForm(map[string]interface{}{"foo": 123})
-func (r *Request) WithForm(object interface{}) *Request {
-	opChain := r.chain.enter("WithForm()")
+//	req.WithQueryObject(map[string]interface{}{"a": 123, "b": "foo"})
+//	// URL is now http://example.com/path?a=123&b=foo
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithQueryObject(map[string]interface{}{
+//		"filter": map[string]interface{}{"name": "foo"},
+//		"id":     []int{1, 2},
+//	}, QueryObjectOpts{
+//		Nesting: QueryNestingBracket,
+//		Slices:  QuerySlicesJoin,
+//	})
+//	// URL is now http://example.com/path?filter[name]=foo&id=1,2 (escaped)
+func (r *Request) WithQueryObject(
+	object interface{}, options ...QueryObjectOpts,
+) *Request {
+	opChain := r.chain.enter("WithQueryObject()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1725,73 +2070,101 @@ func (r *Request) WithForm(object interface{}) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithForm()") {
+	if !r.checkOrder(opChain, "WithQueryObject()") {
 		return r
 	}
 
-	f, err := form.EncodeToValues(object)
-
-	if err != nil {
+	if len(options) > 1 {
 		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{object},
+			Type: AssertUsage,
 			Errors: []error{
-				errors.New("invalid form object"),
-				err,
+				errors.New("unexpected multiple options arguments"),
 			},
 		})
 		return r
 	}
 
-	if r.multipart != nil {
-		r.setType(opChain, "WithForm()", "multipart/form-data", false)
-
-		var keys []string
-		for k := range f {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
+	if object == nil {
+		return r
+	}
 
-		for _, k := range keys {
-			if err := r.multipart.WriteField(k, f[k][0]); err != nil {
-				opChain.fail(AssertionFailure{
-					Type: AssertOperation,
-					Errors: []error{
-						fmt.Errorf("failed to write multipart form field %q", k),
-						err,
-					},
-				})
-				return r
-			}
+	var (
+		q   url.Values
+		err error
+	)
+	if len(options) != 0 {
+		encoder, err := newQueryEncoder(options[0])
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("invalid query object options"),
+					err,
+				},
+			})
+			return r
 		}
-	} else {
-		r.setType(opChain, "WithForm()", "application/x-www-form-urlencoded", false)
 
-		if r.form == nil {
-			r.form = make(url.Values)
+		q, err = encoder.encode(object)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{object},
+				Errors: []error{
+					errors.New("invalid query object"),
+					err,
+				},
+			})
+			return r
 		}
-		for k, v := range f {
-			r.form[k] = append(r.form[k], v...)
+	} else if reflect.Indirect(reflect.ValueOf(object)).Kind() == reflect.Struct {
+		q, err = query.Values(object)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{object},
+				Errors: []error{
+					errors.New("invalid query object"),
+					err,
+				},
+			})
+			return r
 		}
-	}
-
-	return r
+	} else {
+		q, err = form.EncodeToValues(object)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{object},
+				Errors: []error{
+					errors.New("invalid query object"),
+					err,
+				},
+			})
+			return r
+		}
+	}
+
+	if r.query == nil {
+		r.query = make(url.Values)
+	}
+	for k, v := range q {
+		r.query[k] = append(r.query[k], v...)
+	}
+
+	return r
 }
 
-// WithFormField sets Content-Type header to "application/x-www-form-urlencoded"
-// or (if WithMultipart() was called) "multipart/form-data", converts given
-// value to string using fmt.Sprint(), and adds it to request body.
-//
-// Multiple WithForm(), WithFormField(), and WithFile() calls may be combined.
-// If WithMultipart() is called, it should be called first.
+// WithQueryString parses given query string and adds it to request URL.
 //
 // Example:
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithFormField("foo", 123).
-//		WithFormField("bar", 456)
-func (r *Request) WithFormField(key string, value interface{}) *Request {
-	opChain := r.chain.enter("WithFormField()")
+//	req.WithQuery("a", 11)
+//	req.WithQueryString("b=22&c=33")
+//	// URL is now http://example.com/path?a=11&bb=22&c=33
+func (r *Request) WithQueryString(query string) *Request {
+	opChain := r.chain.enter("WithQueryString()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1801,58 +2174,47 @@ func (r *Request) WithFormField(key string, value interface{}) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithFormField()") {
+	if !r.checkOrder(opChain, "WithQueryString()") {
 		return r
 	}
 
-	if r.multipart != nil {
-		r.setType(opChain, "WithFormField()", "multipart/form-data", false)
+	v, err := url.ParseQuery(query)
 
-		err := r.multipart.WriteField(key, fmt.Sprint(value))
-		if err != nil {
-			opChain.fail(AssertionFailure{
-				Type: AssertOperation,
-				Errors: []error{
-					fmt.Errorf("failed to write multipart form field %q", key),
-					err,
-				},
-			})
-			return r
-		}
-	} else {
-		r.setType(opChain, "WithFormField()", "application/x-www-form-urlencoded", false)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{query},
+			Errors: []error{
+				errors.New("invalid query string"),
+				err,
+			},
+		})
+		return r
+	}
 
-		if r.form == nil {
-			r.form = make(url.Values)
-		}
-		r.form[key] = append(r.form[key], fmt.Sprint(value))
+	if r.query == nil {
+		r.query = make(url.Values)
+	}
+	for k, v := range v {
+		r.query[k] = append(r.query[k], v...)
 	}
 
 	return r
 }
 
-// WithFile sets Content-Type header to "multipart/form-data", reads given
-// file and adds its contents to request body.
-//
-// If reader is given, it's used to read file contents. Otherwise, os.Open()
-// is used to read a file with given path.
+// WithURL sets request URL.
 //
-// Multiple WithForm(), WithFormField(), and WithFile() calls may be combined.
-// WithMultipart() should be called before WithFile(), otherwise WithFile()
-// fails.
+// This URL overwrites Config.BaseURL. Request path passed to request constructor
+// is appended to this URL, separated by slash if necessary. Base path set by
+// Config.BasePath or WithBasePath is inserted between them.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithFile("avatar", "./john.png")
-//
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	fh, _ := os.Open("./john.png")
-//	req.WithMultipart().
-//		WithFile("avatar", "john.png", fh)
-//	fh.Close()
-func (r *Request) WithFile(key, path string, reader ...io.Reader) *Request {
-	opChain := r.chain.enter("WithFile()")
+//	req := NewRequestC(config, "PUT", "/path")
+//	req.WithURL("http://example.com")
+//	// URL is now http://example.com/path
+func (r *Request) WithURL(urlStr string) *Request {
+	opChain := r.chain.enter("WithURL()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1862,38 +2224,44 @@ func (r *Request) WithFile(key, path string, reader ...io.Reader) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithFile()") {
+	if !r.checkOrder(opChain, "WithURL()") {
 		return r
 	}
 
-	if len(reader) > 1 {
+	u, err := url.Parse(urlStr)
+	if err != nil {
 		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
+			Type:   AssertValid,
+			Actual: &AssertionValue{urlStr},
 			Errors: []error{
-				errors.New("unexpected multiple reader arguments"),
+				errors.New("invalid url string"),
+				err,
 			},
 		})
 		return r
 	}
 
-	r.withFile(opChain, "WithFile()", key, path, reader...)
+	r.httpReq.URL = u
 
 	return r
 }
 
-// WithFileBytes is like WithFile, but uses given slice of bytes as the
-// file contents.
+// WithBasePath sets path prefix inserted between base URL and request path.
+//
+// This prefix overwrites Config.BasePath. Empty prefix disables base path.
+// Leading and trailing slashes are optional; base URL, base path, and
+// request path are joined using a single slash.
+//
+// Base path is preserved when base URL is overwritten using WithURL.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	fh, _ := os.Open("./john.png")
-//	b, _ := io.ReadAll(fh)
-//	req.WithMultipart().
-//		WithFileBytes("avatar", "john.png", b)
-//	fh.Close()
-func (r *Request) WithFileBytes(key, path string, data []byte) *Request {
-	opChain := r.chain.enter("WithFileBytes()")
+//	req := NewRequestC(config, "GET", "/users")
+//	req.WithURL("http://example.com")
+//	req.WithBasePath("api/v2/")
+//	// URL is now http://example.com/api/v2/users
+func (r *Request) WithBasePath(prefix string) *Request {
+	opChain := r.chain.enter("WithBasePath()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1903,92 +2271,64 @@ func (r *Request) WithFileBytes(key, path string, data []byte) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithFileBytes()") {
+	if !r.checkOrder(opChain, "WithBasePath()") {
 		return r
 	}
 
-	r.withFile(opChain, "WithFileBytes()", key, path, bytes.NewReader(data))
-
-	return r
-}
-
-func (r *Request) withFile(
-	opChain *chain, method, key, path string, reader ...io.Reader,
-) {
-	r.setType(opChain, method, "multipart/form-data", false)
-
-	if r.multipart == nil {
+	if strings.ContainsAny(prefix, "?#") {
 		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
+			Type:   AssertValid,
+			Actual: &AssertionValue{prefix},
 			Errors: []error{
-				fmt.Errorf("%s requires WithMultipart() to be called first", method),
+				errors.New("invalid base path: unexpected query or fragment"),
 			},
 		})
-		return
+		return r
 	}
 
-	wr, err := r.multipart.CreateFormFile(key, path)
-	if err != nil {
-		opChain.fail(AssertionFailure{
-			Type: AssertOperation,
-			Errors: []error{
-				fmt.Errorf(
-					"failed to create form file with key %q and path %q",
-					key, path),
-				err,
-			},
-		})
-		return
+	r.basePath = normalizeBasePath(prefix)
+
+	return r
+}
+
+// WithHeaders adds given headers to request.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithHeaders(map[string]string{
+//		"Content-Type": "application/json",
+//	})
+func (r *Request) WithHeaders(headers map[string]string) *Request {
+	opChain := r.chain.enter("WithHeaders()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
 	}
 
-	var rd io.Reader
-	if len(reader) != 0 && reader[0] != nil {
-		rd = reader[0]
-	} else {
-		f, err := os.Open(path)
-		if err != nil {
-			opChain.fail(AssertionFailure{
-				Type: AssertOperation,
-				Errors: []error{
-					fmt.Errorf("failed to open file %q", path),
-					err,
-				},
-			})
-			return
-		}
-		rd = f
-		defer f.Close()
+	if !r.checkOrder(opChain, "WithHeaders()") {
+		return r
 	}
 
-	if _, err := io.Copy(wr, rd); err != nil {
-		opChain.fail(AssertionFailure{
-			Type: AssertOperation,
-			Errors: []error{
-				fmt.Errorf("failed to read file %q", path),
-				err,
-			},
-		})
-		return
+	for k, v := range headers {
+		r.withHeader(k, v)
 	}
+
+	return r
 }
 
-// WithMultipart sets Content-Type header to "multipart/form-data".
-//
-// After this call, WithForm() and WithFormField() switch to multipart
-// form instead of urlencoded form.
-//
-// If WithMultipart() is called, it should be called before WithForm(),
-// WithFormField(), and WithFile().
-//
-// WithFile() always requires WithMultipart() to be called first.
+// WithHeader adds given single header to request.
 //
 // Example:
 //
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithMultipart().
-//		WithForm(map[string]interface{}{"foo": 123})
-func (r *Request) WithMultipart() *Request {
-	opChain := r.chain.enter("WithMultipart()")
+//	req.WithHeader("Content-Type", "application/json")
+func (r *Request) WithHeader(k, v string) *Request {
+	opChain := r.chain.enter("WithHeader()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -1998,173 +2338,1844 @@ func (r *Request) WithMultipart() *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithMultipart()") {
+	if !r.checkOrder(opChain, "WithHeader()") {
 		return r
 	}
 
-	r.setType(opChain, "WithMultipart()", "multipart/form-data", false)
-
-	if r.multipart == nil {
-		r.formbuf = &bytes.Buffer{}
-		r.multipart = r.multipartFn(r.formbuf)
-		r.setBody(opChain, "WithMultipart()", r.formbuf, 0, false)
-	}
+	r.withHeader(k, v)
 
 	return r
 }
 
-// Expect constructs http.Request, sends it, receives http.Response, and
-// returns a new Response instance.
-//
-// Request is sent using Client interface, or WebsocketDialer in case of
-// WebSocket request.
+func (r *Request) withHeader(k, v string) {
+	switch http.CanonicalHeaderKey(k) {
+	case "Host":
+		r.httpReq.Host = v
+
+	case "Content-Type":
+		if !r.forceType {
+			delete(r.httpReq.Header, "Content-Type")
+		}
+		r.forceType = true
+		r.typeSetter = "WithHeader()"
+		r.httpReq.Header.Add(k, v)
+
+	default:
+		r.httpReq.Header.Add(k, v)
+	}
+}
+
+// WithCORSPreflight sets headers of CORS preflight request: Origin,
+// Access-Control-Request-Method, and, if any headers are given,
+// Access-Control-Request-Headers.
 //
-// After calling Expect, there should not be any more calls of Expect or
-// other WithXXX methods on the same Request instance.
+// Preflight request should use OPTIONS method, otherwise failure is reported.
+// Response headers can be then inspected using Response.CORS.
 //
 // Example:
 //
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithJSON(map[string]interface{}{"foo": 123})
-//	resp := req.Expect()
-//	resp.Status(http.StatusOK)
+//	req := NewRequestC(config, "OPTIONS", "http://example.com/path")
+//	req.WithCORSPreflight("https://app.example.com", "PUT", "Content-Type")
+//	req.Expect().CORS().AllowsMethod("PUT")
+func (r *Request) WithCORSPreflight(
+	origin, method string, headers ...string,
+) *Request {
+	opChain := r.chain.enter("WithCORSPreflight()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithCORSPreflight()") {
+		return r
+	}
+
+	if r.httpReq.Method != http.MethodOptions {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					"unexpected request method %q, CORS preflight request"+
+						" should use OPTIONS method", r.httpReq.Method),
+			},
+		})
+		return r
+	}
+
+	r.httpReq.Header.Set("Origin", origin)
+	r.httpReq.Header.Set("Access-Control-Request-Method", method)
+
+	if len(headers) != 0 {
+		names := make([]string, 0, len(headers))
+		for _, h := range headers {
+			names = append(names, strings.ToLower(h))
+		}
+
+		r.httpReq.Header.Set("Access-Control-Request-Headers",
+			strings.Join(names, ","))
+	}
+
+	return r
+}
+
+// WithCookies adds given cookies to request.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithCookies(map[string]string{
+//		"foo": "aa",
+//		"bar": "bb",
+//	})
+func (r *Request) WithCookies(cookies map[string]string) *Request {
+	opChain := r.chain.enter("WithCookies()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithCookies()") {
+		return r
+	}
+
+	for k, v := range cookies {
+		r.httpReq.AddCookie(&http.Cookie{
+			Name:  k,
+			Value: v,
+		})
+	}
+
+	return r
+}
+
+// WithCookie adds given single cookie to request.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithCookie("name", "value")
+func (r *Request) WithCookie(k, v string) *Request {
+	opChain := r.chain.enter("WithCookie()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithCookie()") {
+		return r
+	}
+
+	r.httpReq.AddCookie(&http.Cookie{
+		Name:  k,
+		Value: v,
+	})
+
+	return r
+}
+
+// WithBasicAuth sets the request's Authorization header to use HTTP
+// Basic Authentication with the provided username and password.
+//
+// With HTTP Basic Authentication the provided username and password
+// are not encrypted.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithBasicAuth("john", "secret")
+func (r *Request) WithBasicAuth(username, password string) *Request {
+	opChain := r.chain.enter("WithBasicAuth()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithBasicAuth()") {
+		return r
+	}
+
+	r.httpReq.SetBasicAuth(username, password)
+
+	return r
+}
+
+// WithHost sets request host to given string.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithHost("example.com")
+func (r *Request) WithHost(host string) *Request {
+	opChain := r.chain.enter("WithHost()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithHost()") {
+		return r
+	}
+
+	r.httpReq.Host = host
+
+	return r
+}
+
+// WithProto sets HTTP protocol version.
+//
+// proto should have form of "HTTP/{major}.{minor}", e.g. "HTTP/1.1".
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithProto("HTTP/2.0")
+func (r *Request) WithProto(proto string) *Request {
+	opChain := r.chain.enter("WithProto()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithProto()") {
+		return r
+	}
+
+	major, minor, ok := http.ParseHTTPVersion(proto)
+
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					`unexpected protocol version %q, expected "HTTP/{major}.{minor}"`,
+					proto),
+			},
+		})
+		return r
+	}
+
+	r.httpReq.ProtoMajor = major
+	r.httpReq.ProtoMinor = minor
+
+	return r
+}
+
+// WithChunked enables chunked encoding and sets request body reader.
+//
+// Expect() will read all available data from given reader. Content-Length
+// is not set, and "chunked" Transfer-Encoding is used.
+//
+// If protocol version is not at least HTTP/1.1 (required for chunked
+// encoding), failure is reported.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/upload")
+//	fh, _ := os.Open("data")
+//	defer fh.Close()
+//	req.WithHeader("Content-Type", "application/octet-stream")
+//	req.WithChunked(fh)
+func (r *Request) WithChunked(reader io.Reader) *Request {
+	opChain := r.chain.enter("WithChunked()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithChunked()") {
+		return r
+	}
+	if !r.httpReq.ProtoAtLeast(1, 1) {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					`chunked Transfer-Encoding requires at least "HTTP/1.1",`+
+						` but "HTTP/%d.%d" is used`,
+					r.httpReq.ProtoMajor, r.httpReq.ProtoMinor),
+			},
+		})
+		return r
+	}
+
+	r.setBody(opChain, "WithChunked()", reader, -1, false)
+
+	return r
+}
+
+// WithBodyFunc sets function that opens request body.
+//
+// Unlike WithChunked, body is not buffered in memory to be re-sent on
+// retries and redirects. Instead, the function is invoked to obtain a fresh
+// reader every time request body should be sent: for every retry attempt
+// (see WithMaxRetries), every redirect that preserves body (see
+// WithRedirectPolicy), every sample of Repeat, every protocol of
+// DifferentialProtocols, and after every request hook (see
+// Config.RequestHooks). This allows to test large uploads with
+// constant memory.
+//
+// Body is sent using chunked Transfer-Encoding. Every returned reader is
+// closed after use. Request body is not passed to printers, and request
+// signing (WithAWSSigV4, WithHMACSignature) is not supported.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/upload")
+//	req.WithHeader("Content-Type", "application/octet-stream")
+//	req.WithMaxRetries(3).WithRetryPolicy(RetryAllErrors)
+//	req.WithBodyFunc(func() (io.ReadCloser, error) {
+//		return os.Open("large-file.bin")
+//	})
+func (r *Request) WithBodyFunc(fn func() (io.ReadCloser, error)) *Request {
+	opChain := r.chain.enter("WithBodyFunc()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithBodyFunc()") {
+		return r
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.setBody(opChain, "WithBodyFunc()", nil, 0, false)
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.bodyFunc = fn
+
+	return r
+}
+
+// WithBytes sets request body to given slice of bytes.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithHeader("Content-Type", "application/json")
+//	req.WithBytes([]byte(`{"foo": 123}`))
+func (r *Request) WithBytes(b []byte) *Request {
+	opChain := r.chain.enter("WithBytes()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithBytes()") {
+		return r
+	}
+
+	if b == nil {
+		r.setBody(opChain, "WithBytes()", nil, 0, false)
+	} else {
+		r.setBody(opChain, "WithBytes()", bytes.NewReader(b), len(b), false)
+	}
+
+	return r
+}
+
+// WithText sets Content-Type header to "text/plain; charset=utf-8" and
+// sets body to given string.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithText("hello, world!")
+func (r *Request) WithText(s string) *Request {
+	opChain := r.chain.enter("WithText()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithText()") {
+		return r
+	}
+
+	r.setType(opChain, "WithText()", "text/plain; charset=utf-8", false)
+	r.setBody(opChain, "WithText()", strings.NewReader(s), len(s), false)
+
+	return r
+}
+
+// WithJSON sets Content-Type header to "application/json; charset=utf-8"
+// and sets body to object, marshaled using json.Marshal().
+//
+// Example:
+//
+//	type MyJSON struct {
+//		Foo int `json:"foo"`
+//	}
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithJSON(MyJSON{Foo: 123})
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithJSON(map[string]interface{}{"foo": 123})
+func (r *Request) WithJSON(object interface{}) *Request {
+	opChain := r.chain.enter("WithJSON()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithJSON()") {
+		return r
+	}
+
+	b, err := json.Marshal(object)
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{object},
+			Errors: []error{
+				errors.New("invalid json object"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.setType(opChain, "WithJSON()", "application/json; charset=utf-8", false)
+	r.setBody(opChain, "WithJSON()", bytes.NewReader(b), len(b), false)
+
+	return r
+}
+
+// WithForm sets Content-Type header to "application/x-www-form-urlencoded"
+// or (if WithMultipart() was called) "multipart/form-data", converts given
+// object to url.Values using github.com/ajg/form, and adds it to request body.
+//
+// Various object types are supported, including maps and structs. Structs may
+// contain "form" struct tag, similar to "json" struct tag for json.Marshal().
+// See https://github.com/ajg/form for details.
+//
+// Multiple WithForm(), WithFormField(), and WithFile() calls may be combined.
+// If WithMultipart() is called, it should be called first.
+//
+// Example:
+//
+//	type MyForm struct {
+//		Foo int `form:"foo"`
+//	}
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithForm(MyForm{Foo: 123})
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithForm(map[string]interface{}{"foo": 123})
+func (r *Request) WithForm(object interface{}) *Request {
+	opChain := r.chain.enter("WithForm()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithForm()") {
+		return r
+	}
+
+	f, err := form.EncodeToValues(object)
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{object},
+			Errors: []error{
+				errors.New("invalid form object"),
+				err,
+			},
+		})
+		return r
+	}
+
+	if r.isMultipart() {
+		r.setType(opChain, "WithForm()", "multipart/form-data", false)
+
+		var keys []string
+		for k := range f {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if err := r.writeMultipartField(k, f[k][0]); err != nil {
+				opChain.fail(AssertionFailure{
+					Type: AssertOperation,
+					Errors: []error{
+						fmt.Errorf("failed to write multipart form field %q", k),
+						err,
+					},
+				})
+				return r
+			}
+		}
+	} else {
+		r.setType(opChain, "WithForm()", "application/x-www-form-urlencoded", false)
+
+		if r.form == nil {
+			r.form = make(url.Values)
+		}
+		for k, v := range f {
+			r.form[k] = append(r.form[k], v...)
+		}
+	}
+
+	return r
+}
+
+// WithFormField sets Content-Type header to "application/x-www-form-urlencoded"
+// or (if WithMultipart() was called) "multipart/form-data", converts given
+// value to string using fmt.Sprint(), and adds it to request body.
+//
+// Multiple WithForm(), WithFormField(), and WithFile() calls may be combined.
+// If WithMultipart() is called, it should be called first.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithFormField("foo", 123).
+//		WithFormField("bar", 456)
+func (r *Request) WithFormField(key string, value interface{}) *Request {
+	opChain := r.chain.enter("WithFormField()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithFormField()") {
+		return r
+	}
+
+	if r.isMultipart() {
+		r.setType(opChain, "WithFormField()", "multipart/form-data", false)
+
+		err := r.writeMultipartField(key, fmt.Sprint(value))
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("failed to write multipart form field %q", key),
+					err,
+				},
+			})
+			return r
+		}
+	} else {
+		r.setType(opChain, "WithFormField()", "application/x-www-form-urlencoded", false)
+
+		if r.form == nil {
+			r.form = make(url.Values)
+		}
+		r.form[key] = append(r.form[key], fmt.Sprint(value))
+	}
+
+	return r
+}
+
+// WithFile sets Content-Type header to "multipart/form-data", reads given
+// file and adds its contents to request body.
+//
+// If reader is given, it's used to read file contents. Otherwise, os.Open()
+// is used to read a file with given path.
+//
+// Multiple WithForm(), WithFormField(), and WithFile() calls may be combined.
+// WithMultipart() should be called before WithFile(), otherwise WithFile()
+// fails.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithFile("avatar", "./john.png")
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	fh, _ := os.Open("./john.png")
+//	req.WithMultipart().
+//		WithFile("avatar", "john.png", fh)
+//	fh.Close()
+func (r *Request) WithFile(key, path string, reader ...io.Reader) *Request {
+	opChain := r.chain.enter("WithFile()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithFile()") {
+		return r
+	}
+
+	if len(reader) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple reader arguments"),
+			},
+		})
+		return r
+	}
+
+	if len(reader) != 0 && reader[0] != nil {
+		r.withFile(opChain, "WithFile()", key, path, openOnce(reader[0]), false)
+	} else {
+		r.withFile(opChain, "WithFile()", key, path,
+			func() (io.Reader, error) {
+				return os.Open(path)
+			}, true)
+	}
+
+	return r
+}
+
+// WithFileBytes is like WithFile, but uses given slice of bytes as the
+// file contents.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	fh, _ := os.Open("./john.png")
+//	b, _ := io.ReadAll(fh)
+//	req.WithMultipart().
+//		WithFileBytes("avatar", "john.png", b)
+//	fh.Close()
+func (r *Request) WithFileBytes(key, path string, data []byte) *Request {
+	opChain := r.chain.enter("WithFileBytes()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithFileBytes()") {
+		return r
+	}
+
+	r.withFile(opChain, "WithFileBytes()", key, path,
+		func() (io.Reader, error) {
+			return bytes.NewReader(data), nil
+		}, true)
+
+	return r
+}
+
+// WithFileStream is like WithFile, but uses given function to open
+// the file contents.
+//
+// If WithMultipartStream() was called, open is invoked when request is
+// being sent, and may be invoked multiple times, for every retry or
+// redirect. This allows to upload large files with constant memory.
+// If returned reader implements io.Closer, it's closed after reading.
+//
+// Otherwise, open is invoked once, and file contents is copied into
+// the request body.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithMultipartStream().
+//		WithFileStream("data", "data.bin", func() (io.Reader, error) {
+//			return io.LimitReader(rand.Reader, 4<<30), nil
+//		})
+func (r *Request) WithFileStream(
+	key, path string, open func() (io.Reader, error),
+) *Request {
+	opChain := r.chain.enter("WithFileStream()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithFileStream()") {
+		return r
+	}
+
+	if open == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.withFile(opChain, "WithFileStream()", key, path, open, true)
+
+	return r
+}
+
+func (r *Request) withFile(
+	opChain *chain, method, key, path string,
+	open func() (io.Reader, error), reopenable bool,
+) {
+	r.setType(opChain, method, "multipart/form-data", false)
+
+	if !r.isMultipart() {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("%s requires WithMultipart() to be called first", method),
+			},
+		})
+		return
+	}
+
+	if r.multipartStream != nil {
+		r.multipartStream.addFile(key, path, open, reopenable)
+		return
+	}
+
+	wr, err := r.multipart.CreateFormFile(key, path)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf(
+					"failed to create form file with key %q and path %q",
+					key, path),
+				err,
+			},
+		})
+		return
+	}
+
+	rd, err := open()
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to open file %q", path),
+				err,
+			},
+		})
+		return
+	}
+
+	if closer, ok := rd.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	if _, err := io.Copy(wr, rd); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to read file %q", path),
+				err,
+			},
+		})
+		return
+	}
+}
+
+func (r *Request) isMultipart() bool {
+	return r.multipart != nil || r.multipartStream != nil
+}
+
+func (r *Request) writeMultipartField(key, value string) error {
+	if r.multipartStream != nil {
+		r.multipartStream.addField(key, value)
+		return nil
+	}
+
+	return r.multipart.WriteField(key, value)
+}
+
+// WithMultipart sets Content-Type header to "multipart/form-data".
+//
+// After this call, WithForm() and WithFormField() switch to multipart
+// form instead of urlencoded form.
+//
+// If WithMultipart() is called, it should be called before WithForm(),
+// WithFormField(), and WithFile().
+//
+// WithFile() always requires WithMultipart() to be called first.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithMultipart().
+//		WithForm(map[string]interface{}{"foo": 123})
+func (r *Request) WithMultipart() *Request {
+	opChain := r.chain.enter("WithMultipart()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithMultipart()") {
+		return r
+	}
+
+	r.setType(opChain, "WithMultipart()", "multipart/form-data", false)
+
+	if r.multipart == nil {
+		r.formbuf = &bytes.Buffer{}
+		r.multipart = r.multipartFn(r.formbuf)
+		r.setBody(opChain, "WithMultipart()", r.formbuf, 0, false)
+
+		boundary := r.multipartBoundary
+		if boundary == "" {
+			// 30 bytes, same as in multipart.Writer
+			boundary = randomHex(r.config.RandSource, 30)
+		}
+		if boundary != "" {
+			_ = r.multipart.SetBoundary(boundary)
+		}
+	}
+
+	return r
+}
+
+// WithMultipartStream is like WithMultipart, but enables streaming mode,
+// in which multipart form is not accumulated in memory.
+//
+// In streaming mode, form fields and files are not read until request is
+// sent. Then, parts are written to request body on the fly, using chunked
+// Transfer-Encoding. This allows to test multi-gigabyte uploads with
+// constant memory.
+//
+// If request is retried or redirected, files are re-opened: WithFile()
+// with path re-opens the file, WithFileBytes() re-reads the slice, and
+// WithFileStream() invokes the function again. However, if WithFile() is
+// used with reader, it can be read only once; in this case retries,
+// Repeat() and DifferentialProtocols() are not allowed, and redirects that
+// require re-sending body are not followed.
+//
+// Readers passed to WithFile() should remain valid until Expect() returns.
+//
+// Streaming mode has the same limitations as WithBodyFunc(): request
+// body is not passed to printers, and request signing is not supported.
+// Repeat() is supported unless WithFile() is used with reader.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithMultipartStream().
+//		WithFormField("name", "backup").
+//		WithFile("data", "/path/to/large/file")
+func (r *Request) WithMultipartStream() *Request {
+	opChain := r.chain.enter("WithMultipartStream()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithMultipartStream()") {
+		return r
+	}
+
+	r.setType(opChain, "WithMultipartStream()", "multipart/form-data", false)
+
+	if r.multipartStream == nil {
+		r.setBody(opChain, "WithMultipartStream()", nil, 0, false)
+		if opChain.failed() {
+			return r
+		}
+
+		boundary := r.multipartBoundary
+		if boundary == "" {
+			boundary = randomHex(r.config.RandSource, 30)
+		}
+
+		r.multipartStream = newMultipartStream(boundary)
+	}
+
+	return r
+}
+
+// WithMultipartBoundary sets boundary used to separate parts of
+// multipart form.
+//
+// By default, random boundary is generated (see also Config.RandSource).
+// Fixed boundary makes request body byte-stable, which is useful for
+// snapshot tests and golden-file comparisons of outgoing traffic.
+//
+// Boundary should be 1 to 70 bytes long and consist of characters allowed
+// by RFC 2046. WithMultipartBoundary should be called before WithForm(),
+// WithFormField(), and WithFile().
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithMultipart().
+//		WithMultipartBoundary("my-boundary").
+//		WithFormField("foo", 123)
+//	// Content-Type will be "multipart/form-data; boundary=my-boundary"
+func (r *Request) WithMultipartBoundary(boundary string) *Request {
+	opChain := r.chain.enter("WithMultipartBoundary()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithMultipartBoundary()") {
+		return r
+	}
+
+	if err := multipart.NewWriter(io.Discard).SetBoundary(boundary); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{boundary},
+			Errors: []error{
+				errors.New("invalid multipart boundary"),
+				err,
+			},
+		})
+		return r
+	}
+
+	if r.multipartStream != nil {
+		r.multipartStream.boundary = boundary
+	}
+
+	if r.multipart != nil {
+		if r.formbuf.Len() != 0 {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected call to WithMultipartBoundary():" +
+						" should be called before adding form fields and files"),
+				},
+			})
+			return r
+		}
+
+		_ = r.multipart.SetBoundary(boundary)
+	}
+
+	r.multipartBoundary = boundary
+
+	return r
+}
+
+// ExpectURL returns a new URL instance with URL to which request would be
+// sent, without sending it.
+//
+// URL is constructed from base URL, path, and query parameters, in the same
+// way as by Expect. Request transformers are applied to a copy of request
+// without body, and URL is taken from the transformed copy. Redirects and
+// changes made by request hooks and signers are not reflected.
+//
+// ExpectURL may be called multiple times, and should be called before Expect.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/users/{id}", 123)
+//	req.WithQuery("fields", "name")
+//
+//	req.ExpectURL().Path().IsEqual("/users/123")
+//	req.ExpectURL().Query("fields").IsEqual("name")
+//
+//	req.Expect()
+func (r *Request) ExpectURL() *URL {
+	opChain := r.chain.enter("ExpectURL()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return newURL(opChain, nil)
+	}
+
+	if !r.checkOrder(opChain, "ExpectURL()") {
+		return newURL(opChain, nil)
+	}
+
+	httpReq := r.httpReq.Clone(r.httpReq.Context())
+	httpReq.Body = http.NoBody
+	httpReq.GetBody = nil
+	httpReq.ContentLength = 0
+
+	if !r.encodeURL(opChain, httpReq.URL) {
+		return newURL(opChain, nil)
+	}
+
+	for _, transform := range r.transformers {
+		transform(httpReq)
+
+		if opChain.failed() {
+			return newURL(opChain, nil)
+		}
+	}
+
+	return newURL(opChain, httpReq.URL)
+}
+
+// Build constructs http.Request without sending it.
+//
+// Build performs the same steps as Expect before sending: path interpolation,
+// query and body encoding, closing multipart form, applying request
+// transformers, invoking request hooks, and signing request. Returned request
+// can be inspected by tests or sent using custom client.
+//
+// If request can't be built, failure is reported, and error describing the
+// failure is returned.
+//
+// Build can't be used with WithWebsocketUpgrade and WithRawRequestBytes.
+// After calling Build, there should not be any more calls of Expect, Build,
+// or other WithXXX methods on the same Request instance.
+//
+// Build doesn't mark request as sent: if Config.StrictClose is enabled,
+// built request is reported by Expect.Close as not sent.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/users/{id}", 123)
+//	req.WithJSON(map[string]interface{}{"name": "john"})
+//
+//	httpReq, err := req.Build()
+//	assert.NoError(t, err)
+//	assert.Equal(t, "/users/123", httpReq.URL.Path)
+func (r *Request) Build() (*http.Request, error) {
+	opChain := r.chain.enter("Build()")
+	defer opChain.leave()
+
+	if !r.prepare(opChain, "Build()") {
+		return nil, opChain.failureError()
+	}
+
+	if r.wsUpgrade {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("Build() can't be used with WithWebsocketUpgrade()"),
+			},
+		})
+		return nil, opChain.failureError()
+	}
+
+	if r.rawRequest != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("Build() can't be used with WithRawRequestBytes()"),
+			},
+		})
+		return nil, opChain.failureError()
+	}
+
+	if !r.prepareHTTPRequest(opChain) {
+		return nil, opChain.failureError()
+	}
+
+	if r.bodyFunc == nil && r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
+		if _, ok := r.httpReq.Body.(*bodyWrapper); !ok {
+			r.httpReq.Body = newBodyWrapper(r.httpReq.Body, nil)
+		}
+	}
+
+	reqBody, _ := r.httpReq.Body.(*bodyWrapper)
+
+	if err := r.signRequest(reqBody); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				err,
+			},
+		})
+		return nil, opChain.failureError()
+	}
+
+	if reqBody != nil {
+		reqBody.Rewind()
+	}
+
+	return r.httpReq, nil
+}
+
+// Expect constructs http.Request, sends it, receives http.Response, and
+// returns a new Response instance.
+//
+// Request is sent using Client interface, or WebsocketDialer in case of
+// WebSocket request.
+//
+// After calling Expect, there should not be any more calls of Expect or
+// other WithXXX methods on the same Request instance.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithJSON(map[string]interface{}{"foo": 123})
+//	resp := req.Expect()
+//	resp.Status(http.StatusOK)
 func (r *Request) Expect() *Response {
 	opChain := r.chain.enter("Expect()")
 	defer opChain.leave()
 
-	resp := r.expect(opChain)
+	resp := r.expect(opChain)
+
+	if resp == nil {
+		resp = newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	return resp
+}
+
+func (r *Request) expect(opChain *chain) *Response {
+	if !r.prepareSend(opChain, "Expect()") {
+		return nil
+	}
+
+	// after return from prepareSend(), all subsequent calls to WithXXX and Expect will
+	// abort early due to checkOrder(); so we can safely proceed without a lock
+
+	resp := r.execute(opChain)
+
+	if resp == nil {
+		return nil
+	}
+
+	r.runMatchers(resp)
+
+	return resp
+}
+
+// Check call order and finalize request, so that subsequent calls to
+// WithXXX, Expect, and Build are rejected. Doesn't send request.
+func (r *Request) prepare(opChain *chain, funcCall string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return false
+	}
+
+	if !r.checkOrder(opChain, funcCall) {
+		return false
+	}
+
+	r.finalizedBy = funcCall
+
+	return true
+}
+
+// Same as prepare, but also marks request as sent.
+func (r *Request) prepareSend(opChain *chain, funcCall string) bool {
+	if !r.prepare(opChain, funcCall) {
+		return false
+	}
+
+	r.config.lifecycle.requestSent()
+
+	return true
+}
+
+func (r *Request) execute(opChain *chain) *Response {
+	if r.rawRequest != nil {
+		return r.executeRaw(opChain)
+	}
+
+	if !r.prepareHTTPRequest(opChain) {
+		return nil
+	}
+
+	var (
+		httpResp *http.Response
+		websock  *websocket.Conn
+		elapsed  time.Duration
+	)
+	if r.wsUpgrade {
+		httpResp, websock, elapsed = r.sendWebsocketRequest(opChain)
+	} else {
+		httpResp, elapsed = r.sendRequest(opChain)
+	}
+
+	if httpResp == nil {
+		return nil
+	}
+
+	if websock != nil {
+		r.config.lifecycle.websocketOpened(websock)
+	}
+
+	if !runResponseHooks(opChain, r.config, httpResp) {
+		if websock != nil {
+			_ = websock.Close()
+		}
+		return nil
+	}
+
+	return newResponse(responseOpts{
+		config:      r.config,
+		chain:       opChain,
+		httpResp:    httpResp,
+		websocket:   websock,
+		rtt:         []time.Duration{elapsed},
+		redirects:   r.redirectChain,
+		connReused:  r.connReused,
+		timings:     r.timings,
+		headersSize: r.headersSize,
+		logger:      r.logger,
+		owner:       r.owner,
+	})
+}
+
+// Prepare http.Request for sending: derive client for selected protocol,
+// encode URL and body, set request ID, and apply request transformers and
+// hooks. Shared by Expect, Build, Repeat, and DifferentialProtocols.
+//
+// Raw request payload is sent as is, so for raw requests, only URL is
+// encoded and other steps are skipped.
+func (r *Request) prepareHTTPRequest(opChain *chain) bool {
+	if r.rawRequest != nil {
+		return r.encodeRequest(opChain)
+	}
+
+	if r.wsUpgrade && r.protoMajor != 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("WithHTTP%d() can't be used with WithWebsocketUpgrade()",
+					r.protoMajor),
+			},
+		})
+		return false
+	}
+
+	if !r.setupProtocol(opChain) {
+		return false
+	}
+	r.setupLogger()
+
+	if !r.encodeRequest(opChain) {
+		return false
+	}
+
+	r.setupRequestID(opChain)
+
+	if r.wsUpgrade {
+		if !r.encodeWebsocketRequest(opChain) {
+			return false
+		}
+	} else if len(r.wsSubprotocols) != 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New(
+					"WithWebsocketSubprotocols() requires WithWebsocketUpgrade()"),
+			},
+		})
+		return false
+	}
+
+	for _, transform := range r.transformers {
+		transform(r.httpReq)
+
+		if opChain.failed() {
+			return false
+		}
+	}
+
+	return r.runRequestHooks(opChain)
+}
+
+// Repeat sends the request n times using up to concurrency simultaneous
+// workers, and returns a new Stats instance with aggregated results.
+//
+// Repeat is a lightweight load mode: it allows to check success rate,
+// latency percentiles, and status code distribution of an endpoint.
+// Response bodies are read and discarded. Redirect policy, timeout,
+// transformers, request hooks, and request signing are applied to every
+// sent request. Response hooks are applied to every received response; if
+// a hook fails, the sample is counted as failed. Every sent request is
+// reported to metrics collector. Every sent request waits for
+// Config.RateLimit, if set. Retries and printers are not used, and
+// redirect chains are not recorded.
+//
+// Repeat can't be used with WithWebsocketUpgrade and WithRawRequestBytes.
+// After calling Repeat, there should not be any more calls of Expect, Repeat,
+// or other WithXXX methods on the same Request instance.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/health")
+//	stats := req.Repeat(1000, 10)
+//	stats.SuccessRate().IsEqual(1)
+//	stats.P95().Le(50 * time.Millisecond)
+func (r *Request) Repeat(n int, concurrency int) *Stats {
+	opChain := r.chain.enter("Repeat()")
+	defer opChain.leave()
+
+	switch {
+	case n <= 0:
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{n},
+			Errors: []error{
+				errors.New("invalid non-positive count argument"),
+			},
+		})
+		return newStats(opChain, nil)
+
+	case concurrency <= 0:
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{concurrency},
+			Errors: []error{
+				errors.New("invalid non-positive concurrency argument"),
+			},
+		})
+		return newStats(opChain, nil)
+	}
+
+	if !r.prepareSend(opChain, "Repeat()") {
+		return newStats(opChain, nil)
+	}
+
+	if r.wsUpgrade {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("Repeat() can't be used with WithWebsocketUpgrade()"),
+			},
+		})
+		return newStats(opChain, nil)
+	}
+
+	if r.rawRequest != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("Repeat() can't be used with WithRawRequestBytes()"),
+			},
+		})
+		return newStats(opChain, nil)
+	}
+
+	if !r.prepareHTTPRequest(opChain) {
+		return newStats(opChain, nil)
+	}
+
+	if !r.checkBodyReusable(opChain, "Repeat()") {
+		return newStats(opChain, nil)
+	}
+
+	// first sample uses the same request ID as other requests,
+	// other samples get their own, unless it was set explicitly
+	freshIDs := r.requestIDGenerated
+
+	if r.bodyFunc == nil && r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
+		if _, ok := r.httpReq.Body.(*bodyWrapper); !ok {
+			r.httpReq.Body = newBodyWrapper(r.httpReq.Body, nil)
+		}
+	}
+
+	reqBody, _ := r.httpReq.Body.(*bodyWrapper)
+
+	if r.bodyFunc != nil {
+		// every sample opens its own body
+		r.httpReq.Body.Close()
+	}
+
+	if err := r.signRequest(reqBody); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				err,
+			},
+		})
+		return newStats(opChain, nil)
+	}
+
+	if concurrency > n {
+		concurrency = n
+	}
+
+	// samples are not turned into responses, so redirects are not recorded
+	r.mu.Lock()
+	r.redirectChainPaused = true
+	r.mu.Unlock()
+
+	var (
+		samples = make([]StatsSample, n)
+		queue   = make(chan int, n)
+		wg      sync.WaitGroup
+	)
+
+	for i := 0; i < n; i++ {
+		queue <- i
+	}
+	close(queue)
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range queue {
+				samples[i] = r.repeatRequest(reqBody, freshIDs && i != 0)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return newStats(opChain, samples)
+}
+
+func (r *Request) repeatRequest(reqBody *bodyWrapper, freshID bool) StatsSample {
+	if err := r.waitRateLimit(); err != nil {
+		return StatsSample{Err: err}
+	}
+
+	ctx := r.httpReq.Context()
+
+	if r.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, r.timeout)
+		defer cancelFn()
+	}
+
+	httpReq := r.httpReq.Clone(ctx)
+
+	if freshID {
+		httpReq.Header.Set(r.config.RequestIDHeader, randomUUID(r.config.RandSource))
+	}
+
+	if reqBody != nil {
+		body, err := reqBody.GetBody()
+		if err != nil {
+			return StatsSample{Err: err}
+		}
+		httpReq.Body = body
+	} else if r.bodyFunc != nil {
+		body, err := r.bodyFunc()
+		if err != nil {
+			return StatsSample{Err: err}
+		}
+		httpReq.Body = body
+	}
+
+	clock := clockOrDefault(r.config.Clock)
+
+	start := clock.Now()
+	resp, err := r.doRequest(r.traceConnection(httpReq, nil))
+	elapsed := clock.Now().Sub(start)
+
+	r.observeMetrics(resp, err, 0, elapsed)
+
+	if err != nil {
+		return StatsSample{Err: err}
+	}
+
+	for _, hook := range r.config.ResponseHooks {
+		if hook == nil {
+			continue
+		}
+		if err := hook(resp); err != nil {
+			if resp.Body != nil {
+				_ = resp.Body.Close()
+			}
+			return StatsSample{Err: err}
+		}
+	}
+
+	if resp.Body != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	return StatsSample{
+		StatusCode:    resp.StatusCode,
+		RoundTripTime: elapsed,
+	}
+}
+
+func (r *Request) setupProtocol(opChain *chain) bool {
+	if r.wsUpgrade {
+		if r.resolveTo == "" {
+			return true
+		}
+
+		dialer, err := resolveWebsocketDialer(
+			r.websocketDialer(), resolveToAddr(r.resolveTo))
+		if err != nil {
+			r.failResolve(opChain, err)
+			return false
+		}
+		r.config.WebsocketDialer = dialer
+		r.config.websocketDialerDefault = false
+
+		return true
+	}
+
+	client := r.config.Client
+
+	if r.protoMajor != 0 {
+		var ok bool
+		if client, ok = r.protocolClient(opChain, client, r.protoMajor); !ok {
+			return false
+		}
+	}
+
+	if r.resolveTo != "" {
+		var ok bool
+		if client, ok = r.resolveClient(opChain, client); !ok {
+			return false
+		}
+	}
+
+	r.config.Client = client
+
+	return true
+}
+
+// Derive client for WithResolveTo from given client.
+// Derived transport is shared by requests of the same Expect instance with
+// the same client transport and target address, see transportCache.
+func (r *Request) resolveClient(opChain *chain, client Client) (Client, bool) {
+	key := transportCacheKey{
+		target: r.resolveTo,
+	}
+
+	if httpClient, ok := client.(*http.Client); ok {
+		key.transport = httpClient.Transport
+	}
+
+	client, err := r.config.lifecycle.transports().client(
+		client, resolveToAddr(r.resolveTo), key)
+	if err != nil {
+		r.failResolve(opChain, err)
+		return nil, false
+	}
+
+	return client, true
+}
+
+func (r *Request) failResolve(opChain *chain, err error) {
+	opChain.fail(AssertionFailure{
+		Type: AssertUsage,
+		Errors: []error{
+			errors.New("WithResolveTo() can't be used with this client"),
+			err,
+		},
+	})
+}
+
+// Make request logger available to transformers via request context.
+// Logger is stored in Config.Context, so that it's preserved when request
+// context is replaced by timeout context during retries.
+func (r *Request) setupLogger() {
+	r.config.Context = withRequestLogger(r.config.Context, r.logger)
+}
+
+// Set request ID header, if enabled, and store request ID in assertion
+// context, so that it's included in failure reports.
+func (r *Request) setupRequestID(opChain *chain) {
+	header := r.config.RequestIDHeader
+	if header == "" {
+		return
+	}
+
+	id := r.httpReq.Header.Get(header)
+
+	if id == "" {
+		id = randomUUID(r.config.RandSource)
+		r.httpReq.Header.Set(header, id)
+		r.requestIDGenerated = true
+	}
+
+	r.chain.setRequestID(id)
+	opChain.setRequestID(id)
+}
+
+func (r *Request) encodeRequest(opChain *chain) bool {
+	if !r.encodeURL(opChain, r.httpReq.URL) {
+		return false
+	}
+
+	if r.multipartStream != nil {
+		r.encodeMultipartStream(opChain)
+	} else if r.multipart != nil {
+		if err := r.multipart.Close(); err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("failed to close multipart form"),
+					err,
+				},
+			})
+			return false
+		}
+
+		r.setType(opChain, "Expect()", r.multipart.FormDataContentType(), true)
+		r.setBody(opChain, "Expect()", r.formbuf, r.formbuf.Len(), true)
+	} else if r.form != nil {
+		s := r.form.Encode()
+		r.setBody(opChain,
+			"WithForm() or WithFormField()", strings.NewReader(s), len(s), false)
+	}
+
+	if r.bodyFunc != nil {
+		if !r.encodeBodyFunc(opChain) {
+			return false
+		}
+	}
+
+	if r.httpReq.Body == nil {
+		r.httpReq.Body = http.NoBody
+	}
 
-	if resp == nil {
-		resp = newResponse(responseOpts{
-			config: r.config,
-			chain:  opChain,
-		})
+	if r.config.Context != nil {
+		r.httpReq = r.httpReq.WithContext(r.config.Context)
 	}
 
-	return resp
+	r.setupRedirects(opChain)
+
+	return true
 }
 
-func (r *Request) expect(opChain *chain) *Response {
-	if !r.prepare(opChain) {
-		return nil
+// Append path and query to base URL.
+func (r *Request) encodeURL(opChain *chain, u *url.URL) bool {
+	if r.basePath != "" {
+		basePath := u.EscapedPath()
+
+		u.Path = concatPaths(u.Path, r.basePath)
+		u.RawPath = concatPaths(basePath, (&url.URL{Path: r.basePath}).EscapedPath())
 	}
 
-	// after return from prepare(), all subsequent calls to WithXXX and Expect will
-	// abort early due to checkOrder(); so we can safely proceed without a lock
+	if r.pathStyle == PathTemplateRFC6570 {
+		return r.expandURL(opChain, u)
+	}
 
-	resp := r.execute(opChain)
+	basePath := u.EscapedPath()
 
-	if resp == nil {
-		return nil
-	}
+	u.Path = concatPaths(u.Path, r.path)
+	u.RawPath = concatPaths(basePath, escapePathBraces(r.pathRaw))
 
-	for _, matcher := range r.matchers {
-		matcher(resp)
+	if r.query != nil {
+		u.RawQuery = r.query.Encode()
 	}
 
-	return resp
+	return true
 }
 
-func (r *Request) prepare(opChain *chain) bool {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// Expand RFC 6570 path template and append resulting path, query,
+// and fragment to base URL.
+func (r *Request) expandURL(opChain *chain, u *url.URL) bool {
+	tmpl, err := parseURITemplate(r.path)
+	if err == nil {
+		var expanded string
+		expanded, err = tmpl.expand(r.pathVars)
 
-	if opChain.failed() {
-		return false
+		if err == nil {
+			err = setExpandedURL(u, expanded, r.query)
+		}
 	}
 
-	if !r.checkOrder(opChain, "Expect()") {
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{r.path},
+			Errors: []error{
+				errors.New("failed to expand uri template"),
+				err,
+			},
+		})
 		return false
 	}
 
-	r.expectCalled = true
-
 	return true
 }
 
-func (r *Request) execute(opChain *chain) *Response {
-	if !r.encodeRequest(opChain) {
-		return nil
-	}
+func setExpandedURL(u *url.URL, expanded string, query url.Values) error {
+	expanded, rawFragment, hasFragment := strings.Cut(expanded, "#")
+	rawPath, rawQuery, hasQuery := strings.Cut(expanded, "?")
 
-	if r.wsUpgrade {
-		if !r.encodeWebsocketRequest(opChain) {
-			return nil
-		}
+	path, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return err
 	}
 
-	for _, transform := range r.transformers {
-		transform(r.httpReq)
+	basePath := u.EscapedPath()
 
-		if opChain.failed() {
-			return nil
+	u.Path = concatPaths(u.Path, path)
+	u.RawPath = concatPaths(basePath, rawPath)
+
+	if hasQuery {
+		u.RawQuery = rawQuery
+		if query != nil {
+			u.RawQuery = concatQueries(u.RawQuery, query.Encode())
 		}
+	} else if query != nil {
+		u.RawQuery = query.Encode()
 	}
 
-	var (
-		httpResp *http.Response
-		websock  *websocket.Conn
-		elapsed  time.Duration
-	)
-	if r.wsUpgrade {
-		httpResp, websock, elapsed = r.sendWebsocketRequest(opChain)
-	} else {
-		httpResp, elapsed = r.sendRequest(opChain)
-	}
+	if hasFragment {
+		fragment, err := url.PathUnescape(rawFragment)
+		if err != nil {
+			return err
+		}
 
-	if httpResp == nil {
-		return nil
+		u.Fragment = fragment
+		u.RawFragment = rawFragment
 	}
 
-	return newResponse(responseOpts{
-		config:    r.config,
-		chain:     opChain,
-		httpResp:  httpResp,
-		websocket: websock,
-		rtt:       []time.Duration{elapsed},
-	})
+	return nil
 }
 
-func (r *Request) encodeRequest(opChain *chain) bool {
-	r.httpReq.URL.Path = concatPaths(r.httpReq.URL.Path, r.path)
+func (r *Request) encodeMultipartStream(opChain *chain) {
+	stream := r.multipartStream
 
-	if r.query != nil {
-		r.httpReq.URL.RawQuery = r.query.Encode()
-	}
+	r.setType(opChain, "Expect()", stream.contentType(), true)
 
-	if r.multipart != nil {
-		if err := r.multipart.Close(); err != nil {
-			opChain.fail(AssertionFailure{
-				Type: AssertOperation,
-				Errors: []error{
-					errors.New("failed to close multipart form"),
-					err,
-				},
-			})
-			return false
-		}
+	r.bodyFunc = func() (io.ReadCloser, error) {
+		return stream.reader(), nil
+	}
+	r.bodyOneShot = stream.oneShot
+}
 
-		r.setType(opChain, "Expect()", r.multipart.FormDataContentType(), true)
-		r.setBody(opChain, "Expect()", r.formbuf, r.formbuf.Len(), true)
-	} else if r.form != nil {
-		s := r.form.Encode()
-		r.setBody(opChain,
-			"WithForm() or WithFormField()", strings.NewReader(s), len(s), false)
+// Open first body reader from bodyFunc. Subsequent readers are opened
+// by retryRequest and http.Client (via GetBody) for retries and redirects.
+func (r *Request) encodeBodyFunc(opChain *chain) bool {
+	if r.maxRetries > 0 && !r.checkBodyReusable(opChain, "retries") {
+		return false
 	}
 
-	if r.httpReq.Body == nil {
-		r.httpReq.Body = http.NoBody
+	if len(r.signers) != 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("%s can't be used with request signing", r.bodySetter),
+			},
+		})
+		return false
 	}
 
-	if r.config.Context != nil {
-		r.httpReq = r.httpReq.WithContext(r.config.Context)
+	body, err := r.bodyFunc()
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to open request body"),
+				err,
+			},
+		})
+		return false
 	}
 
-	r.setupRedirects(opChain)
+	r.httpReq.Body = body
+	r.httpReq.ContentLength = -1
+
+	if r.bodyOneShot {
+		r.httpReq.GetBody = nil
+	} else {
+		r.httpReq.GetBody = r.bodyFunc
+	}
 
 	return true
 }
 
+// Report failure if request body can be sent only once, because it's
+// streamed from a reader, but given feature needs to send it again.
+func (r *Request) checkBodyReusable(opChain *chain, feature string) bool {
+	if r.bodyFunc == nil || !r.bodyOneShot {
+		return true
+	}
+
+	opChain.fail(AssertionFailure{
+		Type: AssertUsage,
+		Errors: []error{
+			fmt.Errorf("%s can't be used with WithFile() given a reader"+
+				" in streaming mode; use file path or WithFileStream() instead",
+				feature),
+		},
+	})
+
+	return false
+}
+
 var websocketErr = `webocket request can not have body:
   body was set by %s
   webocket was enabled by WithWebsocketUpgrade()`
@@ -2187,15 +4198,47 @@ func (r *Request) encodeWebsocketRequest(opChain *chain) bool {
 		r.httpReq.URL.Scheme = "ws"
 	}
 
+	if len(r.wsSubprotocols) != 0 {
+		r.httpReq.Header.Set("Sec-WebSocket-Protocol",
+			strings.Join(r.wsSubprotocols, ", "))
+	}
+
 	return true
 }
 
 func (r *Request) sendRequest(opChain *chain) (*http.Response, time.Duration) {
 	resp, elapsed, err := r.retryRequest(func() (*http.Response, error) {
-		return r.config.Client.Do(r.httpReq)
+		r.mu.Lock()
+		r.redirectChain = nil
+		r.mu.Unlock()
+		r.connReused = nil
+		r.timings = newRequestTimings(clockOrDefault(r.config.Clock))
+		r.headersSize = nil
+
+		httpReq := r.traceConnection(r.httpReq, func(reused bool) {
+			r.connReused = &reused
+		})
+		httpReq = recordHeadersSize(httpReq, func(size int) {
+			r.headersSize = &size
+		})
+
+		return r.doRequest(r.timings.trace(httpReq))
 	})
 
+	if errors.Is(err, errFastBinderHijack) {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("failed to send http request"),
+				errFastBinderHijack,
+			},
+		})
+		return nil, 0
+	}
+
 	if err != nil {
+		r.skipIfUnavailable(err)
+
 		opChain.fail(AssertionFailure{
 			Type: AssertOperation,
 			Errors: []error{
@@ -2209,17 +4252,60 @@ func (r *Request) sendRequest(opChain *chain) (*http.Response, time.Duration) {
 	return resp, elapsed
 }
 
+// Invoke skipper if enabled and err means that server can't be reached.
+func (r *Request) skipIfUnavailable(err error) {
+	if r.unavailableSkipper == nil || !isUnavailableError(err) {
+		return
+	}
+
+	r.unavailableSkipper.Skip(
+		fmt.Sprintf("skipping test: server %s is unavailable: %v",
+			r.httpReq.URL.Host, err))
+}
+
+// Check whether error is caused by failure to reach server, i.e. DNS
+// lookup or dialing failure, as opposed to failure on established
+// connection.
+func isUnavailableError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
 func (r *Request) sendWebsocketRequest(opChain *chain) (
 	*http.Response, *websocket.Conn, time.Duration,
 ) {
+	if r.config.WebsocketDialer == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("can't send websocket request: Config.WebsocketDialer is nil," +
+					" set it or use WithWebsocketDialer()"),
+			},
+		})
+		return nil, nil, 0
+	}
+
+	dialer := r.websocketDialer()
+
 	var conn *websocket.Conn
 	resp, elapsed, err := r.retryRequest(func() (resp *http.Response, err error) {
-		conn, resp, err = r.config.WebsocketDialer.Dial(
+		conn, resp, err = dialer.Dial(
 			r.httpReq.URL.String(), r.httpReq.Header)
 		return resp, err
 	})
 
 	if err != nil && err != websocket.ErrBadHandshake {
+		r.skipIfUnavailable(err)
+
 		opChain.fail(AssertionFailure{
 			Type: AssertOperation,
 			Errors: []error{
@@ -2243,10 +4329,42 @@ func (r *Request) sendWebsocketRequest(opChain *chain) (
 	return resp, conn, elapsed
 }
 
+// Returns dialer for websocket request. If dialer was not set explicitly,
+// and client is bound to a handler, returns dialer bound to the same handler.
+func (r *Request) websocketDialer() WebsocketDialer {
+	if r.config.websocketDialerDefault {
+		if dialer := newBinderWebsocketDialer(r.config.Client); dialer != nil {
+			return dialer
+		}
+	}
+
+	return r.config.WebsocketDialer
+}
+
+// Request signer, e.g. AWS SigV4 or HMAC.
+// Invoked before every attempt to send request.
+type requestSigner interface {
+	sign(req *http.Request, body *bodyWrapper) error
+}
+
+// Sign request using all registered signers, in order of registration.
+// If body is non-nil, signer may read it and should rewind it afterwards.
+func (r *Request) signRequest(body *bodyWrapper) error {
+	for _, signer := range r.signers {
+		if err := signer.sign(r.httpReq, body); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 	*http.Response, time.Duration, error,
 ) {
-	if r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
+	// streaming body is re-opened for every attempt instead of buffering
+	if r.bodyFunc == nil &&
+		r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
 		if _, ok := r.httpReq.Body.(*bodyWrapper); !ok {
 			r.httpReq.Body = newBodyWrapper(r.httpReq.Body, nil)
 		}
@@ -2254,15 +4372,46 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 
 	reqBody, _ := r.httpReq.Body.(*bodyWrapper)
 
+	clock := clockOrDefault(r.config.Clock)
+
 	delay := r.minRetryDelay
+	spent := time.Duration(0)
 	i := 0
 
 	for {
-		for _, printer := range r.config.Printers {
-			if reqBody != nil {
-				reqBody.Rewind()
+		if err := r.waitRateLimit(); err != nil {
+			return nil, 0, err
+		}
+
+		// body is re-opened for every attempt, as well as when request is
+		// sent again by DifferentialProtocols
+		if r.bodyFunc != nil && r.bodyFuncUsed {
+			body, err := r.bodyFunc()
+			if err != nil {
+				return nil, 0, err
+			}
+			r.httpReq.Body = body
+		}
+
+		if err := r.signRequest(reqBody); err != nil {
+			return nil, 0, err
+		}
+
+		if len(r.config.Printers) != 0 {
+			printedReq := redactPrintedRequest(r.config.Redactors, r.httpReq, reqBody)
+
+			if r.bodyFunc != nil {
+				// don't let printers consume streaming body
+				printedReq = printedReq.Clone(printedReq.Context())
+				printedReq.Body = http.NoBody
+			}
+
+			for _, printer := range r.config.Printers {
+				if reqBody != nil {
+					reqBody.Rewind()
+				}
+				printer.Request(printedReq)
 			}
-			printer.Request(r.httpReq)
 		}
 
 		if reqBody != nil {
@@ -2282,31 +4431,65 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 			r.httpReq = r.httpReq.WithContext(ctx)
 		}
 
-		start := time.Now()
+		start := clock.Now()
 		resp, err := reqFunc()
-		elapsed := time.Since(start)
+		elapsed := clock.Now().Sub(start)
+
+		r.bodyFuncUsed = true
+
+		if timings := r.timings; timings != nil {
+			// body wrapper invokes cancel function when body is fully read
+			cancel := cancelFn
+			cancelFn = func() {
+				if cancel != nil {
+					cancel()
+				}
+				timings.done()
+			}
+		}
 
 		if resp != nil && resp.Body != nil {
-			resp.Body = newBodyWrapper(resp.Body, cancelFn)
+			bw := newBodyWrapper(resp.Body, cancelFn)
+			bw.SetMaxSize(r.config.MaxBufferedBodySize)
+			resp.Body = bw
 		} else if cancelFn != nil {
 			cancelFn()
 		}
 
-		if resp != nil {
+		if resp != nil && len(r.config.Printers) != 0 {
+			printedResp := redactPrintedResponse(r.config.Redactors, resp)
+
 			for _, printer := range r.config.Printers {
 				if resp.Body != nil {
 					resp.Body.(*bodyWrapper).Rewind()
 				}
-				printer.Response(resp, elapsed)
+				printer.Response(printedResp, elapsed)
 			}
 		}
 
+		r.observeMetrics(resp, err, i, elapsed)
+
 		i++
-		if i == r.maxRetries+1 {
-			return resp, elapsed, err
+		r.attempts = i
+
+		retry := i != r.maxRetries+1 && r.shouldRetry(resp, err)
+
+		var sleepDelay time.Duration
+
+		if retry {
+			sleepDelay = r.retryDelay(delay, resp)
+
+			spent += elapsed
+			if r.retryBudget > 0 && spent+sleepDelay > r.retryBudget {
+				retry = false
+				sleepDelay = 0
+			}
+			spent += sleepDelay
 		}
 
-		if !r.shouldRetry(resp, err) {
+		r.notifyRetryObservers(i, resp, err, sleepDelay)
+
+		if !retry {
 			return resp, elapsed, err
 		}
 
@@ -2318,10 +4501,10 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 			select {
 			case <-configCtx.Done():
 				return nil, elapsed, configCtx.Err()
-			case <-r.sleepFn(delay):
+			case <-r.sleepFn(sleepDelay):
 			}
 		} else {
-			<-r.sleepFn(delay)
+			<-r.sleepFn(sleepDelay)
 		}
 
 		delay *= 2
@@ -2331,7 +4514,68 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 	}
 }
 
+func (r *Request) notifyRetryObservers(
+	attempt int, resp *http.Response, err error, nextDelay time.Duration,
+) {
+	for _, observer := range r.retryObservers {
+		if resp != nil && resp.Body != nil {
+			resp.Body.(*bodyWrapper).Rewind()
+		}
+		observer(attempt, resp, err, nextDelay)
+	}
+
+	if len(r.retryObservers) != 0 && resp != nil && resp.Body != nil {
+		resp.Body.(*bodyWrapper).Rewind()
+	}
+}
+
+// Compute delay before next attempt, given current backoff delay
+// and last response.
+func (r *Request) retryDelay(delay time.Duration, resp *http.Response) time.Duration {
+	if r.retryAfter && resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"),
+			clockOrDefault(r.config.Clock).Now()); ok {
+			return d
+		}
+	}
+
+	if r.retryJitter > 0 {
+		factor := 1 + r.retryJitter*(2*r.randFn()-1)
+		delay = time.Duration(float64(delay) * factor)
+	}
+
+	return delay
+}
+
+// Parse "Retry-After" header value, which is either non-negative number
+// of seconds or HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		d := date.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
 func (r *Request) shouldRetry(resp *http.Response, err error) bool {
+	if r.retryAfter && r.retryPolicy != DontRetry &&
+		resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
 	var (
 		isTemporaryNetworkError bool // Deprecated
 		isTimeoutError          bool
@@ -2399,11 +4643,10 @@ func (r *Request) setupRedirects(opChain *chain) {
 			return
 		}
 	} else {
-		if r.redirectPolicy != defaultRedirectPolicy || r.maxRedirects != -1 {
-			clientCopy := *httpClient
-			httpClient = &clientCopy
-			r.config.Client = &clientCopy
-		}
+		// client is copied to install own CheckRedirect
+		clientCopy := *httpClient
+		httpClient = &clientCopy
+		r.config.Client = &clientCopy
 	}
 
 	if r.redirectPolicy == DontFollowRedirects {
@@ -2421,7 +4664,11 @@ func (r *Request) setupRedirects(opChain *chain) {
 		httpClient.CheckRedirect = nil
 	}
 
-	if r.redirectPolicy == FollowAllRedirects {
+	switch {
+	case r.bodyFunc != nil:
+		// GetBody is set by encodeBodyFunc()
+
+	case r.redirectPolicy == FollowAllRedirects:
 		if r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
 			if _, ok := r.httpReq.Body.(*bodyWrapper); !ok {
 				r.httpReq.Body = newBodyWrapper(r.httpReq.Body, nil)
@@ -2433,9 +4680,39 @@ func (r *Request) setupRedirects(opChain *chain) {
 				return http.NoBody, nil
 			}
 		}
-	} else if r.redirectPolicy != defaultRedirectPolicy {
+
+	case r.redirectPolicy != defaultRedirectPolicy:
 		r.httpReq.GetBody = nil
 	}
+
+	if httpClient != nil {
+		r.setupRedirectChain(httpClient)
+	}
+}
+
+// Wrap CheckRedirect to record every followed redirect response.
+func (r *Request) setupRedirectChain(httpClient *http.Client) {
+	checkRedirect := httpClient.CheckRedirect
+
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		var err error
+		if checkRedirect != nil {
+			err = checkRedirect(req, via)
+		} else if len(via) >= 10 {
+			// same as default policy of http.Client
+			err = errors.New("stopped after 10 redirects")
+		}
+
+		if err == nil && req.Response != nil {
+			r.mu.Lock()
+			if !r.redirectChainPaused {
+				r.redirectChain = append(r.redirectChain, req.Response)
+			}
+			r.mu.Unlock()
+		}
+
+		return err
+	}
 }
 
 var typeErr = `ambiguous request "Content-Type" header values:
@@ -2503,11 +4780,12 @@ func (r *Request) setBody(
 }
 
 func (r *Request) checkOrder(opChain *chain, funcCall string) bool {
-	if r.expectCalled {
+	if r.finalizedBy != "" {
 		opChain.fail(AssertionFailure{
 			Type: AssertUsage,
 			Errors: []error{
-				fmt.Errorf("unexpected call to %s: Expect() has already been called", funcCall),
+				fmt.Errorf("unexpected call to %s: %s has already been called",
+					funcCall, r.finalizedBy),
 			},
 		})
 		return false
@@ -2527,6 +4805,25 @@ func concatPaths(a, b string) string {
 	return a + "/" + b
 }
 
+// Ensure that base path has leading slash and no trailing slash.
+func normalizeBasePath(path string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return ""
+	}
+	return "/" + path
+}
+
+func concatQueries(a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b == "" {
+		return a
+	}
+	return a + "&" + b
+}
+
 func mustWrite(w io.Writer, s string) {
 	_, err := w.Write([]byte(s))
 	if err != nil {
@@ -0,0 +1,156 @@
+package httpexpect
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	mathrand "math/rand"
+	"sync"
+)
+
+// RequestLogger writes debug messages on behalf of a single request.
+//
+// Every message is prefixed with request label and correlation ID, so that
+// messages from custom matchers and transformers can be easily associated
+// with the request and with the printer output for it.
+//
+// Request label is request name (see Request.WithName), or request alias
+// (see Request.Alias), or request method and path, whichever is set first.
+//
+// Messages are written to Config.Logger. If it is nil, they are written to
+// Config.Reporter if it implements Logger interface (like *testing.T does),
+// and discarded otherwise.
+//
+// RequestLogger methods may be invoked on nil instance, in this case they
+// do nothing.
+type RequestLogger struct {
+	mu     sync.Mutex
+	logger Logger
+	id     string
+	method string
+	path   string
+	alias  string
+	name   string
+}
+
+type requestLoggerKey struct{}
+
+func newRequestLogger(config Config, method, path string) *RequestLogger {
+	logger := config.Logger
+	if logger == nil {
+		logger, _ = config.Reporter.(Logger)
+	}
+
+	return &RequestLogger{
+		logger: logger,
+		id:     newCorrelationID(config.RandSource),
+		method: method,
+		path:   path,
+	}
+}
+
+func newCorrelationID(src mathrand.Source) string {
+	if src != nil {
+		return randomHex(src, 4)
+	}
+
+	var buf [4]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "00000000"
+	}
+	return hex.EncodeToString(buf[:])
+}
+
+// RequestLoggerFromContext returns RequestLogger of the request which
+// context is ctx.
+//
+// Context of every http.Request passed to transformers (see
+// Request.WithTransformer) carries logger of the corresponding Request.
+//
+// If there is no logger in ctx, returns nil. Methods of nil RequestLogger
+// do nothing, so it's safe to use returned value without checks.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithTransformer(func(r *http.Request) {
+//		logger := RequestLoggerFromContext(r.Context())
+//		logger.Logf("sending to %s", r.URL)
+//	})
+func RequestLoggerFromContext(ctx context.Context) *RequestLogger {
+	if ctx == nil {
+		return nil
+	}
+	logger, _ := ctx.Value(requestLoggerKey{}).(*RequestLogger)
+	return logger
+}
+
+func withRequestLogger(ctx context.Context, logger *RequestLogger) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, requestLoggerKey{}, logger)
+}
+
+// ID returns correlation ID of the request.
+//
+// Correlation ID is generated randomly for every Request and is included
+// in every message. You can send it to server, e.g. in a header, to match
+// server logs with test logs.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithHeader("X-Request-Id", req.Logger().ID())
+func (l *RequestLogger) ID() string {
+	if l == nil {
+		return ""
+	}
+	return l.id
+}
+
+// Logf formats and writes message, prefixed with request label and
+// correlation ID.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithMatcher(func(resp *Response) {
+//		resp.Logger().Logf("got status %d", resp.Raw().StatusCode)
+//	})
+func (l *RequestLogger) Logf(format string, args ...interface{}) {
+	if l == nil || l.logger == nil {
+		return
+	}
+
+	l.logger.Logf("[%s #%s] %s", l.label(), l.id, fmt.Sprintf(format, args...))
+}
+
+func (l *RequestLogger) label() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch {
+	case l.name != "":
+		return l.name
+	case l.alias != "":
+		return l.alias
+	default:
+		return l.method + " " + l.path
+	}
+}
+
+func (l *RequestLogger) setAlias(alias string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.alias = alias
+}
+
+func (l *RequestLogger) setName(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.name = name
+}
@@ -0,0 +1,205 @@
+package httpexpect
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLogger_Label(t *testing.T) {
+	cases := []struct {
+		name   string
+		setup  func(req *Request)
+		prefix string
+	}{
+		{
+			name:   "method and path",
+			setup:  func(req *Request) {},
+			prefix: "[GET /path #",
+		},
+		{
+			name: "alias",
+			setup: func(req *Request) {
+				req.Alias("foo")
+			},
+			prefix: "[foo #",
+		},
+		{
+			name: "name",
+			setup: func(req *Request) {
+				req.WithName("Login Request")
+			},
+			prefix: "[Login Request #",
+		},
+		{
+			name: "name and alias",
+			setup: func(req *Request) {
+				req.Alias("foo")
+				req.WithName("Login Request")
+			},
+			prefix: "[Login Request #",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := newMockLogger(t)
+
+			config := newMockConfig(newMockReporter(t))
+			config.Logger = logger
+
+			req := NewRequestC(config, "GET", "/path")
+			tc.setup(req)
+
+			req.Logger().Logf("hello %d", 123)
+
+			assert.True(t, logger.logged)
+			assert.Equal(t,
+				tc.prefix+req.Logger().ID()+"] hello 123", logger.lastMessage)
+		})
+	}
+}
+
+func TestRequestLogger_ID(t *testing.T) {
+	config := newMockConfig(newMockReporter(t))
+
+	req1 := NewRequestC(config, "GET", "/path")
+	req2 := NewRequestC(config, "GET", "/path")
+
+	assert.Equal(t, 8, len(req1.Logger().ID()))
+	assert.Equal(t, 8, len(req2.Logger().ID()))
+	assert.NotEqual(t, req1.Logger().ID(), req2.Logger().ID())
+}
+
+func TestRequestLogger_IDRandSource(t *testing.T) {
+	newID := func() string {
+		config := newMockConfig(newMockReporter(t))
+		config.RandSource = rand.NewSource(1)
+
+		return NewRequestC(config, "GET", "/path").Logger().ID()
+	}
+
+	id1 := newID()
+	id2 := newID()
+
+	assert.Equal(t, 8, len(id1))
+	assert.Equal(t, id1, id2)
+}
+
+func TestRequestLogger_Output(t *testing.T) {
+	t.Run("reporter", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		config := Config{
+			Reporter: struct {
+				Reporter
+				Logger
+			}{
+				newMockReporter(t),
+				logger,
+			},
+		}
+
+		req := NewRequestC(config, "GET", "/path")
+		req.Logger().Logf("hello")
+
+		assert.True(t, logger.logged)
+	})
+
+	t.Run("discard", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		req := NewRequestC(config, "GET", "/path")
+
+		assert.NotPanics(t, func() {
+			req.Logger().Logf("hello")
+		})
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var logger *RequestLogger
+
+		assert.Equal(t, "", logger.ID())
+
+		assert.NotPanics(t, func() {
+			logger.Logf("hello")
+		})
+	})
+}
+
+func TestRequestLogger_Context(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, RequestLoggerFromContext(context.Background()))
+		assert.Nil(t, RequestLoggerFromContext(nil)) // nolint
+	})
+
+	t.Run("transformer and matcher", func(t *testing.T) {
+		type ctxKey struct{}
+
+		var (
+			transformerLogger *RequestLogger
+			transportLogger   *RequestLogger
+			matcherLogger     *RequestLogger
+			transportValue    interface{}
+		)
+
+		config := newMockConfig(newMockReporter(t))
+		config.Context = context.WithValue(context.Background(), ctxKey{}, "value")
+		config.Client = ClientFunc(func(req *http.Request) (*http.Response, error) {
+			transportLogger = RequestLoggerFromContext(req.Context())
+			transportValue = req.Context().Value(ctxKey{})
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+			}, nil
+		})
+
+		req := NewRequestC(config, "GET", "/path").
+			WithTimeout(time.Minute).
+			WithTransformer(func(r *http.Request) {
+				transformerLogger = RequestLoggerFromContext(r.Context())
+			}).
+			WithMatcher(func(resp *Response) {
+				matcherLogger = resp.Logger()
+			})
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		require.NotNil(t, req.Logger())
+		assert.Same(t, req.Logger(), transformerLogger)
+		assert.Same(t, req.Logger(), transportLogger)
+		assert.Same(t, req.Logger(), matcherLogger)
+		assert.Same(t, req.Logger(), resp.Logger())
+		assert.Equal(t, "value", transportValue)
+	})
+}
+
+func TestRequestLogger_Response(t *testing.T) {
+	logger := newMockLogger(t)
+
+	config := newMockConfig(newMockReporter(t))
+	config.Logger = logger
+
+	httpReq, err := http.NewRequest("PUT", "http://example.com/path", nil)
+	require.NoError(t, err)
+
+	resp := NewResponseC(config, &http.Response{
+		Request:    httpReq,
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(nil)),
+	})
+
+	require.NotNil(t, resp.Logger())
+
+	resp.Logger().Logf("hello")
+	assert.Equal(t,
+		"[PUT /path #"+resp.Logger().ID()+"] hello", logger.lastMessage)
+}
@@ -5,14 +5,20 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"math/rand"
 	"mime"
 	"mime/multipart"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	neturl "net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
 
@@ -42,23 +48,43 @@ func TestRequest_FailedChain(t *testing.T) {
 	req.WithHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
 	req.WithContext(context.TODO())
 	req.WithTimeout(0)
+	req.WithVerbosity(VerbosityDebug)
+	req.SkipIfUnavailable(&mockSkipper{})
+	req.WithRawRequestBytes([]byte("GET / HTTP/1.1\r\n\r\n"))
+	req.WithIfNoneMatch(`"foo"`)
+	req.WithIfMatch(`"foo"`)
+	req.WithIfModifiedSince(time.Now())
+	req.WithAWSSigV4(AWSCredentials{AccessKeyID: "a", SecretAccessKey: "b"}, "r", "s")
+	req.WithHMACSignature([]byte("secret"))
 	req.WithRedirectPolicy(FollowAllRedirects)
 	req.WithMaxRedirects(1)
 	req.WithRetryPolicy(RetryAllErrors)
 	req.WithMaxRetries(1)
 	req.WithRetryDelay(time.Millisecond, time.Millisecond)
+	req.WithRetryJitter(0.1)
+	req.WithRetryBudget(time.Second)
+	req.WithRetryRespectRetryAfter()
+	req.OnRetryAttempt(
+		func(int, *http.Response, error, time.Duration) {})
+	req.WithHTTP2()
+	req.WithHTTP3(&mockTransport{})
+	req.WithResolveTo("127.0.0.1:80")
 	req.WithWebsocketUpgrade()
+	req.WithWebsocketSubprotocols("foo")
 	req.WithWebsocketDialer(
 		NewWebsocketDialer(
 			http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
+	req.WithPathTemplateStyle(PathTemplateRFC6570)
 	req.WithPath("foo", "bar")
 	req.WithPathObject(map[string]interface{}{"foo": "bar"})
 	req.WithQuery("foo", "bar")
 	req.WithQueryObject(map[string]interface{}{"foo": "bar"})
 	req.WithQueryString("foo=bar")
 	req.WithURL("http://example.com")
+	req.WithBasePath("/api")
 	req.WithHeaders(map[string]string{"foo": "bar"})
 	req.WithHeader("foo", "bar")
+	req.WithCORSPreflight("http://example.com", "PUT")
 	req.WithCookies(map[string]string{"foo": "bar"})
 	req.WithCookie("foo", "bar")
 	req.WithBasicAuth("foo", "bar")
@@ -73,6 +99,17 @@ func TestRequest_FailedChain(t *testing.T) {
 	req.WithFile("foo", "bar", strings.NewReader("baz"))
 	req.WithFileBytes("foo", "bar", []byte("baz"))
 	req.WithMultipart()
+	req.WithMultipartBoundary("foo")
+	req.WithBodyFunc(func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("foo")), nil
+	})
+	req.WithMultipartStream()
+	req.WithFileStream("foo", "bar", func() (io.Reader, error) {
+		return strings.NewReader("baz"), nil
+	})
+
+	req.Repeat(1, 1).chain.assert(t, failure)
+	req.DifferentialProtocols().chain.assert(t, failure)
 
 	resp := req.Expect()
 	resp.chain.assert(t, failure)
@@ -543,6 +580,55 @@ func TestRequest_Handler(t *testing.T) {
 		req.WithHandler(handler2)
 		assert.Same(t, client.Jar, req.config.Client.(*http.Client).Jar)
 	})
+
+	t.Run("websocket dialer", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+		config := Config{
+			Reporter: newMockReporter(t),
+			Client:   &mockClient{},
+		}
+
+		req := NewRequestC(config, "GET", "/")
+		assert.Nil(t, req.websocketDialer().(*websocket.Dialer).NetDial)
+
+		req.WithHandler(handler)
+		req.chain.assert(t, success)
+
+		assert.NotNil(t, req.websocketDialer().(*websocket.Dialer).NetDial)
+	})
+
+	t.Run("websocket dialer before handler", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+		dialer := &websocket.Dialer{}
+
+		config := Config{
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/").
+			WithWebsocketDialer(dialer).
+			WithHandler(handler)
+		req.chain.assert(t, success)
+
+		assert.Same(t, dialer, req.websocketDialer())
+	})
+
+	t.Run("websocket dialer after handler", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+		dialer := &websocket.Dialer{}
+
+		config := Config{
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/").
+			WithHandler(handler).
+			WithWebsocketDialer(dialer)
+		req.chain.assert(t, success)
+
+		assert.Same(t, dialer, req.websocketDialer())
+	})
 }
 
 func TestRequest_Proto(t *testing.T) {
@@ -927,6 +1013,225 @@ func TestRequest_URLQuery(t *testing.T) {
 			WithQueryObject(queryObj)
 		checkFailed(req)
 	})
+
+	t.Run("WithQueryObject options", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path").
+			WithQueryObject(map[string]interface{}{
+				"filter": map[string]interface{}{"name": "foo"},
+				"id":     []int{1, 2},
+			}, QueryObjectOpts{
+				Nesting: QueryNestingBracket,
+				Slices:  QuerySlicesJoin,
+			})
+		checkOK(req,
+			"http://example.com/path?filter%5Bname%5D=foo&id=1%2C2")
+	})
+
+	t.Run("WithQueryObject invalid options", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path").
+			WithQueryObject(map[string]interface{}{"a": 1}, QueryObjectOpts{
+				Nesting: QueryNesting(-1),
+			})
+		checkFailed(req)
+	})
+
+	t.Run("WithQueryObject multiple options", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path").
+			WithQueryObject(map[string]interface{}{"a": 1},
+				QueryObjectOpts{}, QueryObjectOpts{})
+		checkFailed(req)
+	})
+
+	t.Run("WithQueryObject options invalid object", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/path").
+			WithQueryObject([]int{1, 2}, QueryObjectOpts{})
+		checkFailed(req)
+	})
+}
+
+func TestRequest_ExpectURL(t *testing.T) {
+	newConfig := func(client Client) Config {
+		return Config{
+			BaseURL:  "http://example.com/api",
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+	}
+
+	t.Run("url", func(t *testing.T) {
+		client := &mockClient{}
+
+		req := NewRequestC(newConfig(client), "GET", "/users/{id}", 123).
+			WithQuery("a", 1).
+			WithQueryObject(map[string]interface{}{"b": "x y"})
+
+		u := req.ExpectURL()
+		u.chain.assert(t, success)
+
+		u.Full().IsEqual("http://example.com/api/users/123?a=1&b=x+y")
+		u.Path().IsEqual("/api/users/123")
+		u.Query("a").IsEqual("1")
+		u.Query("b").IsEqual("x y")
+		u.chain.assert(t, success)
+
+		assert.Nil(t, client.req)
+
+		req.ExpectURL().Path().IsEqual("/api/users/123")
+
+		req.Expect().chain.assert(t, success)
+		assert.Equal(t, "http://example.com/api/users/123?a=1&b=x+y",
+			client.req.URL.String())
+	})
+
+	t.Run("transformer", func(t *testing.T) {
+		client := &mockClient{}
+
+		req := NewRequestC(newConfig(client), "POST", "/path").
+			WithText("body").
+			WithTransformer(func(r *http.Request) {
+				q := r.URL.Query()
+				q.Set("signed", "true")
+				r.URL.RawQuery = q.Encode()
+			})
+
+		req.ExpectURL().Query("signed").IsEqual("true")
+
+		req.Expect().chain.assert(t, success)
+		assert.Equal(t, "true", client.req.URL.Query().Get("signed"))
+
+		body, err := io.ReadAll(client.req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "body", string(body))
+	})
+
+	t.Run("after expect", func(t *testing.T) {
+		req := NewRequestC(newConfig(&mockClient{}), "GET", "/path")
+
+		req.Expect()
+
+		u := req.ExpectURL()
+		u.chain.assert(t, failure)
+		req.chain.assert(t, failure)
+	})
+}
+
+func TestRequest_Build(t *testing.T) {
+	newConfig := func(client Client) Config {
+		return Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: newMockReporter(t),
+			RequestHooks: []func(*http.Request) error{
+				func(r *http.Request) error {
+					r.Header.Set("X-Hook", "hook")
+					return nil
+				},
+			},
+		}
+	}
+
+	t.Run("json", func(t *testing.T) {
+		client := &mockClient{}
+
+		req := NewRequestC(newConfig(client), "POST", "/users/{id}", 123).
+			WithQuery("a", 1).
+			WithJSON(map[string]interface{}{"name": "john"}).
+			WithTransformer(func(r *http.Request) {
+				r.Header.Set("X-Transformer", "transformer")
+			})
+
+		httpReq, err := req.Build()
+		require.NoError(t, err)
+		require.NotNil(t, httpReq)
+
+		req.chain.assert(t, success)
+
+		assert.Equal(t, "POST", httpReq.Method)
+		assert.Equal(t, "http://example.com/users/123?a=1", httpReq.URL.String())
+		assert.Equal(t, "application/json; charset=utf-8",
+			httpReq.Header.Get("Content-Type"))
+		assert.Equal(t, "transformer", httpReq.Header.Get("X-Transformer"))
+		assert.Equal(t, "hook", httpReq.Header.Get("X-Hook"))
+
+		body, err := io.ReadAll(httpReq.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name": "john"}`, string(body))
+
+		assert.Nil(t, client.req)
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		req := NewRequestC(newConfig(&mockClient{}), "POST", "/upload").
+			WithMultipart().
+			WithFormField("a", "1")
+
+		httpReq, err := req.Build()
+		require.NoError(t, err)
+
+		require.NoError(t, httpReq.ParseMultipartForm(1024))
+		assert.Equal(t, "1", httpReq.FormValue("a"))
+	})
+
+	t.Run("after build", func(t *testing.T) {
+		client := &mockClient{}
+		handler := &mockAssertionHandler{}
+
+		config := newConfig(client)
+		config.AssertionHandler = handler
+
+		req := NewRequestC(config, "GET", "/path")
+
+		_, err := req.Build()
+		require.NoError(t, err)
+
+		req.Expect()
+		req.chain.assert(t, failure)
+		assert.Nil(t, client.req)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertUsage, handler.failure.Type)
+		assert.EqualError(t, handler.failure.Errors[0],
+			"unexpected call to Expect(): Build() has already been called")
+
+		_, err = req.Build()
+		assert.Error(t, err)
+	})
+
+	t.Run("failed request", func(t *testing.T) {
+		req := NewRequestC(newConfig(&mockClient{}), "GET", "/path").
+			WithQueryObject(func() {})
+
+		httpReq, err := req.Build()
+		assert.Error(t, err)
+		assert.Nil(t, httpReq)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("failed hook", func(t *testing.T) {
+		config := newConfig(&mockClient{})
+		config.RequestHooks = []func(*http.Request) error{
+			func(r *http.Request) error {
+				return errors.New("hook error")
+			},
+		}
+
+		req := NewRequestC(config, "GET", "/path")
+
+		httpReq, err := req.Build()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "hook error")
+		assert.Nil(t, httpReq)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		req := NewRequestC(newConfig(&mockClient{}), "GET", "/path").
+			WithWebsocketUpgrade()
+
+		_, err := req.Build()
+		assert.Error(t, err)
+		req.chain.assert(t, failure)
+	})
 }
 
 func TestRequest_PathConstruct(t *testing.T) {
@@ -1283,50 +1588,600 @@ func TestRequest_PathCombined(t *testing.T) {
 	})
 }
 
-func TestRequest_Headers(t *testing.T) {
+func TestRequest_BasePath(t *testing.T) {
+	cases := []struct {
+		name        string
+		baseURL     string
+		basePath    string
+		withURL     string
+		withPath    string
+		setPath     bool
+		path        string
+		expectedURL string
+	}{
+		{
+			name:        "no base path",
+			baseURL:     "http://example.com",
+			path:        "/users",
+			expectedURL: "http://example.com/users",
+		},
+		{
+			name:        "config",
+			baseURL:     "http://example.com",
+			basePath:    "/api/v2",
+			path:        "/users",
+			expectedURL: "http://example.com/api/v2/users",
+		},
+		{
+			name:        "config, extra slashes",
+			baseURL:     "http://example.com/",
+			basePath:    "/api/v2/",
+			path:        "/users",
+			expectedURL: "http://example.com/api/v2/users",
+		},
+		{
+			name:        "config, no slashes",
+			baseURL:     "http://example.com",
+			basePath:    "api/v2",
+			path:        "users",
+			expectedURL: "http://example.com/api/v2/users",
+		},
+		{
+			name:        "config, base url with path",
+			baseURL:     "http://example.com/root",
+			basePath:    "api",
+			path:        "/users",
+			expectedURL: "http://example.com/root/api/users",
+		},
+		{
+			name:        "config, empty request path",
+			baseURL:     "http://example.com",
+			basePath:    "/api",
+			path:        "",
+			expectedURL: "http://example.com/api",
+		},
+		{
+			name:        "config, escaping",
+			baseURL:     "http://example.com",
+			basePath:    "/my api",
+			path:        "/users",
+			expectedURL: "http://example.com/my%20api/users",
+		},
+		{
+			name:        "WithURL",
+			baseURL:     "http://example.com",
+			basePath:    "/api/v2",
+			withURL:     "http://staging.example.com",
+			path:        "/users",
+			expectedURL: "http://staging.example.com/api/v2/users",
+		},
+		{
+			name:        "WithBasePath",
+			baseURL:     "http://example.com",
+			basePath:    "/api/v2",
+			withPath:    "/api/v3/",
+			setPath:     true,
+			path:        "/users",
+			expectedURL: "http://example.com/api/v3/users",
+		},
+		{
+			name:        "WithBasePath, empty",
+			baseURL:     "http://example.com",
+			basePath:    "/api/v2",
+			withPath:    "",
+			setPath:     true,
+			path:        "/users",
+			expectedURL: "http://example.com/users",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{}
+
+			req := NewRequestC(Config{
+				BaseURL:  tc.baseURL,
+				BasePath: tc.basePath,
+				Client:   client,
+				Reporter: newMockReporter(t),
+			}, "GET", tc.path)
+
+			if tc.withURL != "" {
+				req.WithURL(tc.withURL)
+			}
+			if tc.setPath {
+				req.WithBasePath(tc.withPath)
+			}
+
+			req.Expect().chain.assert(t, success)
+			assert.Equal(t, tc.expectedURL, client.req.URL.String())
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}, "GET", "/users")
+
+		req.WithBasePath("/api?v=2")
+		req.chain.assert(t, failure)
+	})
+}
+
+func TestRequest_PathEscaping(t *testing.T) {
 	client := &mockClient{}
 
 	config := Config{
+		BaseURL:  "http://example.com/api",
 		Client:   client,
 		Reporter: newMockReporter(t),
 	}
 
-	req := NewRequestC(config, "GET", "url")
+	cases := []struct {
+		name        string
+		escaping    PathEscaping
+		value       string
+		expectedURL string
+		expectedRaw string
+	}{
+		{
+			name:        "none",
+			escaping:    PathEscapingNone,
+			value:       "a/b c",
+			expectedURL: "http://example.com/api/files/a/b%20c/info",
+			expectedRaw: "/api/files/a/b c/info",
+		},
+		{
+			name:        "segment",
+			escaping:    PathEscapingSegment,
+			value:       "a/b c",
+			expectedURL: "http://example.com/api/files/a%2Fb%20c/info",
+			expectedRaw: "/api/files/a/b c/info",
+		},
+		{
+			name:        "raw",
+			escaping:    PathEscapingRaw,
+			value:       "a%2Fb",
+			expectedURL: "http://example.com/api/files/a%2Fb/info",
+			expectedRaw: "/api/files/a/b/info",
+		},
+	}
 
-	req.WithHeader("first-header", "foo")
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := NewRequestC(config, "GET", "/files/{name}/{kind}")
+			req.WithPath("name", tc.value, PathOpts{Escaping: tc.escaping})
+			req.WithPath("kind", "info")
+			req.Expect().chain.assert(t, success)
+			assert.Equal(t, tc.expectedURL, client.req.URL.String())
+			assert.Equal(t, tc.expectedRaw, client.req.URL.Path)
+		})
 
-	req.WithHeaders(map[string]string{
-		"Second-Header": "bar",
-		"content-Type":  "baz",
-		"HOST":          "example.com",
+		t.Run(tc.name+", object", func(t *testing.T) {
+			req := NewRequestC(config, "GET", "/files/{name}/{kind}")
+			req.WithPathObject(map[string]string{
+				"name": tc.value,
+				"kind": "info",
+			}, PathOpts{Escaping: tc.escaping})
+			req.Expect().chain.assert(t, success)
+			assert.Equal(t, tc.expectedURL, client.req.URL.String())
+		})
+	}
+
+	t.Run("incomplete", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/files/{name}/{kind}")
+		req.WithPath("name", "a/b", PathOpts{Escaping: PathEscapingSegment})
+		req.Expect().chain.assert(t, success)
+		assert.Equal(t, "http://example.com/api/files/a%2Fb/%7Bkind%7D",
+			client.req.URL.String())
 	})
 
-	expectedHeaders := map[string][]string{
-		"First-Header":  {"foo"},
-		"Second-Header": {"bar"},
-		"Content-Type":  {"baz"},
-	}
+	t.Run("invalid raw value", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/files/{name}")
+		req.WithPath("name", "a%zz", PathOpts{Escaping: PathEscapingRaw})
+		req.chain.assert(t, failure)
+	})
 
-	resp := req.Expect()
-	resp.chain.assert(t, success)
+	t.Run("invalid escaping", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/files/{name}")
+		req.WithPath("name", "a", PathOpts{Escaping: PathEscaping(-1)})
+		req.chain.assert(t, failure)
+	})
 
-	assert.Equal(t, "GET", client.req.Method)
-	assert.Equal(t, "example.com", client.req.Host)
-	assert.Equal(t, "url", client.req.URL.String())
-	assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
+	t.Run("multiple options", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/files/{name}")
+		req.WithPathObject(map[string]string{"name": "a"}, PathOpts{}, PathOpts{})
+		req.chain.assert(t, failure)
+	})
 
-	assert.Same(t, &client.resp, resp.Raw())
+	t.Run("rfc6570 style", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/files{/name}")
+		req.WithPathTemplateStyle(PathTemplateRFC6570)
+		req.WithPath("name", "a", PathOpts{Escaping: PathEscapingSegment})
+		req.chain.assert(t, failure)
+	})
 }
 
-func TestRequest_Cookies(t *testing.T) {
+func TestRequest_PathTemplateStyle(t *testing.T) {
 	client := &mockClient{}
 
 	config := Config{
+		BaseURL:  "http://example.com/api",
 		Client:   client,
 		Reporter: newMockReporter(t),
 	}
 
-	req := NewRequestC(config, "GET", "url")
+	t.Run("simple", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/users/{id}")
+		req.WithPathTemplateStyle(PathTemplateSimple)
+		req.WithPath("id", 123)
+		req.Expect().chain.assert(t, success)
+		assert.Equal(t, "http://example.com/api/users/123",
+			client.req.URL.String())
+	})
+
+	t.Run("rfc6570", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/users{/id}{?fields*}{#section}")
+		req.WithPathTemplateStyle(PathTemplateRFC6570)
+		req.WithPath("id", "john doe")
+		req.WithPath("fields", map[string]string{"name": "x", "age": "30"})
+		req.WithPath("section", "top")
+		req.Expect().chain.assert(t, success)
+		assert.Equal(t, "http://example.com/api/users/john%20doe?age=30&name=x#top",
+			client.req.URL.String())
+		assert.Equal(t, "/api/users/john doe", client.req.URL.Path)
+		assert.Equal(t, "top", client.req.URL.Fragment)
+	})
+
+	t.Run("rfc6570, reserved expansion", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/files/{+path}")
+		req.WithPathTemplateStyle(PathTemplateRFC6570)
+		req.WithPath("path", "a/b c/d")
+		req.Expect().chain.assert(t, success)
+		assert.Equal(t, "http://example.com/api/files/a/b%20c/d",
+			client.req.URL.String())
+	})
+
+	t.Run("rfc6570, undefined variables", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/users{/id}{?q,limit}")
+		req.WithPathTemplateStyle(PathTemplateRFC6570)
+		req.Expect().chain.assert(t, success)
+		assert.Equal(t, "http://example.com/api/users",
+			client.req.URL.String())
+	})
+
+	t.Run("rfc6570, path object", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/users{/id}{?ids}")
+		req.WithPathTemplateStyle(PathTemplateRFC6570)
+		req.WithPathObject(map[string]interface{}{
+			"id":  1,
+			"ids": []int{2, 3},
+		})
+		req.Expect().chain.assert(t, success)
+		assert.Equal(t, "http://example.com/api/users/1?ids=2,3",
+			client.req.URL.String())
+	})
+
+	t.Run("rfc6570, with query", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/search{?q}")
+		req.WithPathTemplateStyle(PathTemplateRFC6570)
+		req.WithPath("q", "foo")
+		req.WithQuery("page", 2)
+		req.ExpectURL().Full().
+			IsEqual("http://example.com/api/search?q=foo&page=2")
+		req.chain.assert(t, success)
+	})
+
+	t.Run("rfc6570, invalid template", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/users{/id")
+		req.WithPathTemplateStyle(PathTemplateRFC6570)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("rfc6570, invalid key", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/users{/id}")
+		req.WithPathTemplateStyle(PathTemplateRFC6570)
+		req.WithPath("ID", 1)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("rfc6570, invalid value", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/users{/id}")
+		req.WithPathTemplateStyle(PathTemplateRFC6570)
+		req.WithPath("id", nil)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("rfc6570, invalid prefix", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/users{/id:2}")
+		req.WithPathTemplateStyle(PathTemplateRFC6570)
+		req.WithPath("id", []int{1, 2})
+		req.chain.assert(t, success)
+		req.Expect().chain.assert(t, failure)
+	})
+
+	t.Run("simple after WithPath", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/users{/id}")
+		req.WithPathTemplateStyle(PathTemplateRFC6570)
+		req.WithPath("id", 1)
+		req.WithPathTemplateStyle(PathTemplateSimple)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("invalid style", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/users")
+		req.WithPathTemplateStyle(PathTemplateStyle(-1))
+		req.chain.assert(t, failure)
+	})
+}
+
+func TestRequest_Headers(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	req := NewRequestC(config, "GET", "url")
+
+	req.WithHeader("first-header", "foo")
+
+	req.WithHeaders(map[string]string{
+		"Second-Header": "bar",
+		"content-Type":  "baz",
+		"HOST":          "example.com",
+	})
+
+	expectedHeaders := map[string][]string{
+		"First-Header":  {"foo"},
+		"Second-Header": {"bar"},
+		"Content-Type":  {"baz"},
+	}
+
+	resp := req.Expect()
+	resp.chain.assert(t, success)
+
+	assert.Equal(t, "GET", client.req.Method)
+	assert.Equal(t, "example.com", client.req.Host)
+	assert.Equal(t, "url", client.req.URL.String())
+	assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
+
+	assert.Same(t, &client.resp, resp.Raw())
+}
+
+func TestRequest_RequestID(t *testing.T) {
+	t.Run("generated", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:          client,
+			Reporter:        newMockReporter(t),
+			RequestIDHeader: "X-Request-Id",
+		}
+
+		req := NewRequestC(config, "GET", "url")
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		id := client.req.Header.Get("X-Request-Id")
+		assert.Regexp(t, uuidRegexp, id)
+		assert.Equal(t, "4", id[14:15])
+
+		assert.Equal(t, id, req.chain.context.RequestID)
+		assert.Equal(t, id, resp.chain.context.RequestID)
+
+		req2 := NewRequestC(config, "GET", "url")
+		req2.Expect().chain.assert(t, success)
+
+		assert.NotEqual(t, id, client.req.Header.Get("X-Request-Id"))
+	})
+
+	t.Run("explicit", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:          client,
+			Reporter:        newMockReporter(t),
+			RequestIDHeader: "X-Request-Id",
+		}
+
+		req := NewRequestC(config, "GET", "url")
+		req.WithHeader("X-Request-Id", "my-id")
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, []string{"my-id"}, client.req.Header.Values("X-Request-Id"))
+		assert.Equal(t, "my-id", resp.chain.context.RequestID)
+	})
+
+	t.Run("rand source", func(t *testing.T) {
+		generate := func() string {
+			client := &mockClient{}
+
+			config := Config{
+				Client:          client,
+				Reporter:        newMockReporter(t),
+				RequestIDHeader: "X-Request-Id",
+				RandSource:      rand.NewSource(1),
+			}
+
+			NewRequestC(config, "GET", "url").Expect()
+
+			return client.req.Header.Get("X-Request-Id")
+		}
+
+		assert.Equal(t, generate(), generate())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "url")
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, http.Header{}, client.req.Header)
+		assert.Equal(t, "", resp.chain.context.RequestID)
+	})
+
+	t.Run("failure report", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+			RequestIDHeader:  "X-Request-Id",
+		}
+
+		resp := NewRequestC(config, "GET", "url").Expect()
+		resp.Status(http.StatusTeapot)
+
+		require.NotNil(t, handler.ctx)
+		assert.Equal(t, resp.chain.context.RequestID, handler.ctx.RequestID)
+		assert.NotEqual(t, "", handler.ctx.RequestID)
+	})
+
+	t.Run("build", func(t *testing.T) {
+		config := Config{
+			Client:          &mockClient{},
+			Reporter:        newMockReporter(t),
+			RequestIDHeader: "X-Request-Id",
+		}
+
+		req := NewRequestC(config, "GET", "url")
+
+		httpReq, err := req.Build()
+		require.NoError(t, err)
+
+		id := httpReq.Header.Get("X-Request-Id")
+		assert.Regexp(t, uuidRegexp, id)
+		assert.Equal(t, id, req.chain.context.RequestID)
+	})
+
+	t.Run("repeat", func(t *testing.T) {
+		var (
+			mu  sync.Mutex
+			ids []string
+		)
+
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			ids = append(ids, req.Header.Get("X-Request-Id"))
+			mu.Unlock()
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       http.NoBody,
+			}, nil
+		})
+
+		config := Config{
+			Client:          client,
+			Reporter:        newMockReporter(t),
+			RequestIDHeader: "X-Request-Id",
+		}
+
+		req := NewRequestC(config, "GET", "url")
+
+		req.Repeat(10, 3).chain.assert(t, success)
+
+		require.Equal(t, 10, len(ids))
+
+		seen := map[string]bool{}
+		for _, id := range ids {
+			assert.Regexp(t, uuidRegexp, id)
+			assert.False(t, seen[id])
+			seen[id] = true
+		}
+
+		assert.True(t, seen[req.chain.context.RequestID])
+	})
+
+	t.Run("repeat explicit", func(t *testing.T) {
+		var (
+			mu  sync.Mutex
+			ids []string
+		)
+
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			ids = append(ids, req.Header.Get("X-Request-Id"))
+			mu.Unlock()
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       http.NoBody,
+			}, nil
+		})
+
+		config := Config{
+			Client:          client,
+			Reporter:        newMockReporter(t),
+			RequestIDHeader: "X-Request-Id",
+		}
+
+		req := NewRequestC(config, "GET", "url").
+			WithHeader("X-Request-Id", "my-id")
+
+		req.Repeat(3, 1).chain.assert(t, success)
+
+		assert.Equal(t, []string{"my-id", "my-id", "my-id"}, ids)
+	})
+
+	t.Run("differential", func(t *testing.T) {
+		var ids []string
+
+		newClient := func(protoMajor int) Client {
+			return ClientFunc(func(req *http.Request) (*http.Response, error) {
+				ids = append(ids, req.Header.Get("X-Request-Id"))
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					ProtoMajor: protoMajor,
+					Body:       http.NoBody,
+				}, nil
+			})
+		}
+
+		config := Config{
+			Client:          &mockClient{},
+			Reporter:        newMockReporter(t),
+			RequestIDHeader: "X-Request-Id",
+		}
+
+		resp := NewRequestC(config, "GET", "url").
+			DifferentialProtocols(DifferentialOpts{
+				HTTP1Client: newClient(1),
+				HTTP2Client: newClient(2),
+			})
+		resp.chain.assert(t, success)
+
+		require.Equal(t, 2, len(ids))
+		assert.Regexp(t, uuidRegexp, ids[0])
+		assert.Equal(t, ids[0], ids[1])
+		assert.Equal(t, ids[0], resp.chain.context.RequestID)
+	})
+}
+
+func TestRequest_Cookies(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	req := NewRequestC(config, "GET", "url")
 
 	req.WithCookie("foo", "1")
 	req.WithCookie("bar", "2 ")
@@ -1349,6 +2204,69 @@ func TestRequest_Cookies(t *testing.T) {
 	assert.Same(t, &client.resp, resp.Raw())
 }
 
+func TestRequest_CORSPreflight(t *testing.T) {
+	t.Run("with headers", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "OPTIONS", "url")
+
+		req.WithCORSPreflight("https://app.example.com", "PUT",
+			"Content-Type", "X-Request-Id")
+
+		expectedHeaders := map[string][]string{
+			"Origin":                         {"https://app.example.com"},
+			"Access-Control-Request-Method":  {"PUT"},
+			"Access-Control-Request-Headers": {"content-type,x-request-id"},
+		}
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, "OPTIONS", client.req.Method)
+		assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
+	})
+
+	t.Run("without headers", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "OPTIONS", "url")
+
+		req.WithCORSPreflight("https://app.example.com", "DELETE")
+
+		expectedHeaders := map[string][]string{
+			"Origin":                        {"https://app.example.com"},
+			"Access-Control-Request-Method": {"DELETE"},
+		}
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
+	})
+
+	t.Run("not options method", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithCORSPreflight("https://app.example.com", "PUT")
+		req.chain.assert(t, failure)
+	})
+}
+
 func TestRequest_BasicAuth(t *testing.T) {
 	client := &mockClient{}
 
@@ -1852,37 +2770,790 @@ func TestRequest_BodyMultipart(t *testing.T) {
 		}
 	})
 
-	t.Run("missing WithMultipart", func(t *testing.T) {
+	t.Run("boundary", func(t *testing.T) {
 		cases := []struct {
 			name  string
 			reqFn func(*Request)
 		}{
 			{
-				name: "WithFile",
+				name: "before WithMultipart",
 				reqFn: func(req *Request) {
-					req.WithFile("test_key", "test_file", strings.NewReader("test_data"))
+					req.WithMultipartBoundary("test-boundary")
+					req.WithMultipart()
+				},
+			},
+			{
+				name: "after WithMultipart",
+				reqFn: func(req *Request) {
+					req.WithMultipart()
+					req.WithMultipartBoundary("test-boundary")
+				},
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				req := NewRequestC(config, "POST", "url")
+
+				tc.reqFn(req)
+				req.WithFormField("a", 1)
+
+				resp := req.Expect()
+				resp.chain.assert(t, success)
+
+				assert.Equal(t, "multipart/form-data; boundary=test-boundary",
+					client.req.Header.Get("Content-Type"))
+
+				assert.Equal(t,
+					"--test-boundary\r\n"+
+						"Content-Disposition: form-data; name=\"a\"\r\n\r\n"+
+						"1\r\n"+
+						"--test-boundary--\r\n",
+					resp.Body().Raw())
+			})
+		}
+	})
+
+	t.Run("invalid boundary", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
+
+		req.WithMultipart()
+		req.WithMultipartBoundary("invalid boundary\n")
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("boundary after form field", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url")
+
+		req.WithMultipart()
+		req.WithFormField("a", 1)
+		req.WithMultipartBoundary("test-boundary")
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("rand source", func(t *testing.T) {
+		send := func() (string, string) {
+			config := Config{
+				Client:     client,
+				Reporter:   newMockReporter(t),
+				RandSource: rand.NewSource(1),
+			}
+
+			req := NewRequestC(config, "POST", "url")
+
+			req.WithMultipart()
+			req.WithFormField("a", 1)
+
+			resp := req.Expect()
+			resp.chain.assert(t, success)
+
+			return client.req.Header.Get("Content-Type"), resp.Body().Raw()
+		}
+
+		contentType1, body1 := send()
+		contentType2, body2 := send()
+
+		assert.Equal(t, contentType1, contentType2)
+		assert.Equal(t, body1, body2)
+
+		_, params, err := mime.ParseMediaType(contentType1)
+		assert.NoError(t, err)
+		assert.Equal(t, 60, len(params["boundary"]))
+	})
+
+	t.Run("missing WithMultipart", func(t *testing.T) {
+		cases := []struct {
+			name  string
+			reqFn func(*Request)
+		}{
+			{
+				name: "WithFile",
+				reqFn: func(req *Request) {
+					req.WithFile("test_key", "test_file", strings.NewReader("test_data"))
 				},
 			},
 			{
 				name: "WithFileBytes",
 				reqFn: func(req *Request) {
-					req.WithFileBytes("test_key", "test_file", []byte("test_data"))
+					req.WithFileBytes("test_key", "test_file", []byte("test_data"))
+				},
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				req := NewRequestC(config, "POST", "url")
+
+				tc.reqFn(req)
+				req.chain.assert(t, failure)
+			})
+		}
+
+	})
+}
+
+func TestRequest_BodyMultipartStream(t *testing.T) {
+	readParts := func(t *testing.T, contentType string, body io.Reader) map[string]string {
+		mediatype, params, err := mime.ParseMediaType(contentType)
+		require.NoError(t, err)
+		require.Equal(t, "multipart/form-data", mediatype)
+
+		parts := map[string]string{}
+
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			b, err := io.ReadAll(part)
+			require.NoError(t, err)
+
+			name := part.FormName()
+			if part.FileName() != "" {
+				name += ":" + filepath.Base(part.FileName())
+			}
+			parts[name] = string(b)
+		}
+
+		return parts
+	}
+
+	t.Run("parts", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		fh, err := os.CreateTemp("", "httpexpect")
+		require.NoError(t, err)
+		_, _ = fh.WriteString("file contents")
+		fh.Close()
+		defer os.Remove(fh.Name())
+
+		req := NewRequestC(config, "POST", "url").
+			WithMultipartStream().
+			WithForm(map[string]string{"a": "1"}).
+			WithFormField("b", 2).
+			WithFile("c", fh.Name()).
+			WithFile("d", "reader.txt", strings.NewReader("reader contents")).
+			WithFileBytes("e", "bytes.txt", []byte("bytes contents")).
+			WithFileStream("f", "stream.txt", func() (io.Reader, error) {
+				return strings.NewReader("stream contents"), nil
+			})
+		req.chain.assert(t, success)
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, int64(-1), client.req.ContentLength)
+
+		assert.Equal(t, map[string]string{
+			"a":                             "1",
+			"b":                             "2",
+			"c:" + filepath.Base(fh.Name()): "file contents",
+			"d:reader.txt":                  "reader contents",
+			"e:bytes.txt":                   "bytes contents",
+			"f:stream.txt":                  "stream contents",
+		}, readParts(t, client.req.Header.Get("Content-Type"),
+			strings.NewReader(resp.Body().Raw())))
+	})
+
+	t.Run("large body", func(t *testing.T) {
+		const size = 16 << 20
+
+		var received int64
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+			part, err := multipart.NewReader(r.Body, params["boundary"]).NextPart()
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			received, _ = io.Copy(io.Discard, part)
+		})
+
+		config := Config{
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			Reporter: newMockReporter(t),
+		}
+
+		NewRequestC(config, "POST", "http://example.com").
+			WithMultipartStream().
+			WithFileStream("data", "data.bin", func() (io.Reader, error) {
+				return io.LimitReader(zeroReader{}, size), nil
+			}).
+			Expect().
+			Status(http.StatusOK).
+			chain.assert(t, success)
+
+		assert.Equal(t, int64(size), received)
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		var bodies []map[string]string
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bodies = append(bodies, readParts(t, r.Header.Get("Content-Type"), r.Body))
+
+			if len(bodies) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		})
+
+		config := Config{
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			Reporter: newMockReporter(t),
+		}
+
+		openCount := 0
+
+		req := NewRequestC(config, "POST", "http://example.com").
+			WithMaxRetries(5).
+			WithMultipartStream().
+			WithFormField("a", 1).
+			WithFileStream("b", "b.txt", func() (io.Reader, error) {
+				openCount++
+				return strings.NewReader("contents"), nil
+			})
+		req.sleepFn = mockSleep
+
+		req.Expect().
+			Status(http.StatusOK).
+			chain.assert(t, success)
+
+		assert.Equal(t, 3, openCount)
+		assert.Equal(t, 3, len(bodies))
+
+		for _, body := range bodies {
+			assert.Equal(t, map[string]string{
+				"a":       "1",
+				"b:b.txt": "contents",
+			}, body)
+		}
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		var bodies []map[string]string
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bodies = append(bodies, readParts(t, r.Header.Get("Content-Type"), r.Body))
+
+			if r.URL.Path == "/from" {
+				http.Redirect(w, r, "/to", http.StatusPermanentRedirect)
+			}
+		})
+
+		config := Config{
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+		}
+
+		NewRequestC(config, "POST", "/from").
+			WithRedirectPolicy(FollowAllRedirects).
+			WithMultipartStream().
+			WithFileBytes("a", "a.txt", []byte("contents")).
+			Expect().
+			Status(http.StatusOK).
+			chain.assert(t, success)
+
+		assert.Equal(t, []map[string]string{
+			{"a:a.txt": "contents"},
+			{"a:a.txt": "contents"},
+		}, bodies)
+	})
+
+	t.Run("differential", func(t *testing.T) {
+		var bodies []map[string]string
+
+		newClient := func(protoMajor int) Client {
+			return ClientFunc(func(req *http.Request) (*http.Response, error) {
+				bodies = append(bodies,
+					readParts(t, req.Header.Get("Content-Type"), req.Body))
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					ProtoMajor: protoMajor,
+					Body:       http.NoBody,
+				}, nil
+			})
+		}
+
+		NewRequestC(newMockConfig(newMockReporter(t)), "POST", "url").
+			WithMultipartStream().
+			WithFileStream("a", "a.txt", func() (io.Reader, error) {
+				return strings.NewReader("contents"), nil
+			}).
+			DifferentialProtocols(DifferentialOpts{
+				HTTP1Client: newClient(1),
+				HTTP2Client: newClient(2),
+			}).
+			chain.assert(t, success)
+
+		assert.Equal(t, []map[string]string{
+			{"a:a.txt": "contents"},
+			{"a:a.txt": "contents"},
+		}, bodies)
+	})
+
+	t.Run("boundary", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		NewRequestC(config, "POST", "url").
+			WithMultipartStream().
+			WithMultipartBoundary("test-boundary").
+			WithFormField("a", 1).
+			Expect().
+			Body().IsEqual(
+			"--test-boundary\r\n" +
+				"Content-Disposition: form-data; name=\"a\"\r\n\r\n" +
+				"1\r\n" +
+				"--test-boundary--\r\n")
+
+		assert.Equal(t, "multipart/form-data; boundary=test-boundary",
+			client.req.Header.Get("Content-Type"))
+	})
+
+	t.Run("printers", func(t *testing.T) {
+		client := &mockClient{}
+
+		printer := NewDebugPrinter(newMockLogger(t), true)
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Printers: []Printer{printer},
+		}
+
+		NewRequestC(config, "POST", "url").
+			WithMultipartStream().
+			WithFormField("a", 1).
+			Expect().
+			Body().Contains(`name="a"`).
+			chain.assert(t, success)
+	})
+
+	t.Run("open error", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "POST", "url").
+			WithMultipartStream().
+			WithFileStream("a", "a.txt", func() (io.Reader, error) {
+				return nil, errors.New("test error")
+			})
+		req.chain.assert(t, success)
+
+		resp := req.Expect()
+		resp.Body()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("usage errors", func(t *testing.T) {
+		cases := []struct {
+			name  string
+			reqFn func(*Request)
+		}{
+			{
+				name: "after WithMultipart",
+				reqFn: func(req *Request) {
+					req.WithMultipart().WithMultipartStream()
+				},
+			},
+			{
+				name: "before WithMultipart",
+				reqFn: func(req *Request) {
+					req.WithMultipartStream().WithMultipart()
+				},
+			},
+			{
+				name: "one-shot reader with retries",
+				reqFn: func(req *Request) {
+					req.WithMaxRetries(1).
+						WithMultipartStream().
+						WithFile("a", "a.txt", strings.NewReader("contents")).
+						Expect()
+				},
+			},
+			{
+				name: "signing",
+				reqFn: func(req *Request) {
+					req.WithHMACSignature([]byte("secret")).
+						WithMultipartStream().
+						WithFormField("a", 1).
+						Expect()
+				},
+			},
+			{
+				name: "one-shot reader with repeat",
+				reqFn: func(req *Request) {
+					req.WithMultipartStream().
+						WithFile("a", "a.txt", strings.NewReader("contents")).
+						Repeat(1, 1)
+				},
+			},
+			{
+				name: "one-shot reader with differential",
+				reqFn: func(req *Request) {
+					req.WithMultipartStream().
+						WithFile("a", "a.txt", strings.NewReader("contents")).
+						DifferentialProtocols(DifferentialOpts{
+							HTTP1Client: &mockClient{},
+							HTTP2Client: &mockClient{},
+						})
+				},
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				config := Config{
+					Client:   &mockClient{},
+					Reporter: newMockReporter(t),
+				}
+
+				req := NewRequestC(config, "POST", "url")
+
+				tc.reqFn(req)
+				req.chain.assert(t, failure)
+			})
+		}
+	})
+}
+
+func TestRequest_BodyFunc(t *testing.T) {
+	type counter struct {
+		opened int
+		closed int
+	}
+
+	newBodyFunc := func(cnt *counter, data string) func() (io.ReadCloser, error) {
+		return func() (io.ReadCloser, error) {
+			cnt.opened++
+			return &countingBody{
+				Reader: strings.NewReader(data),
+				closed: &cnt.closed,
+			}, nil
+		}
+	}
+
+	t.Run("send", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		var cnt counter
+
+		req := NewRequestC(config, "PUT", "url").
+			WithBodyFunc(newBodyFunc(&cnt, "test body"))
+		req.chain.assert(t, success)
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		resp.Body().IsEqual("test body")
+
+		assert.Equal(t, int64(-1), client.req.ContentLength)
+		assert.Equal(t, 1, cnt.opened)
+		assert.Equal(t, 1, cnt.closed)
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		var bodies []string
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(b))
+
+			if len(bodies) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		})
+
+		config := Config{
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			Reporter: newMockReporter(t),
+		}
+
+		var cnt counter
+
+		req := NewRequestC(config, "PUT", "http://example.com").
+			WithRetryPolicy(RetryAllErrors).
+			WithMaxRetries(5).
+			WithBodyFunc(newBodyFunc(&cnt, "test body"))
+		req.sleepFn = mockSleep
+
+		req.Expect().
+			Status(http.StatusOK).
+			chain.assert(t, success)
+
+		assert.Equal(t, []string{"test body", "test body", "test body"}, bodies)
+		assert.Equal(t, 3, cnt.opened)
+	})
+
+	t.Run("differential", func(t *testing.T) {
+		var bodies []string
+
+		newClient := func(protoMajor int) Client {
+			return ClientFunc(func(req *http.Request) (*http.Response, error) {
+				b, _ := io.ReadAll(req.Body)
+				_ = req.Body.Close()
+				bodies = append(bodies, string(b))
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					ProtoMajor: protoMajor,
+					Body:       io.NopCloser(bytes.NewReader(b)),
+				}, nil
+			})
+		}
+
+		var cnt counter
+
+		req := NewRequestC(newMockConfig(newMockReporter(t)), "PUT", "url").
+			WithBodyFunc(newBodyFunc(&cnt, "test body"))
+
+		req.DifferentialProtocols(DifferentialOpts{
+			HTTP1Client: newClient(1),
+			HTTP2Client: newClient(2),
+		}).
+			chain.assert(t, success)
+
+		assert.Equal(t, []string{"test body", "test body"}, bodies)
+		assert.Equal(t, 2, cnt.opened)
+		assert.Equal(t, 2, cnt.closed)
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		var bodies []string
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(b))
+
+			if r.URL.Path == "/from" {
+				http.Redirect(w, r, "/to", http.StatusTemporaryRedirect)
+			}
+		})
+
+		config := Config{
+			BaseURL: "http://example.com",
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			Reporter: newMockReporter(t),
+		}
+
+		var cnt counter
+
+		NewRequestC(config, "PUT", "/from").
+			WithRedirectPolicy(FollowAllRedirects).
+			WithBodyFunc(newBodyFunc(&cnt, "test body")).
+			Expect().
+			Status(http.StatusOK).
+			chain.assert(t, success)
+
+		assert.Equal(t, []string{"test body", "test body"}, bodies)
+		assert.Equal(t, 2, cnt.opened)
+	})
+
+	t.Run("repeat", func(t *testing.T) {
+		var (
+			mu     sync.Mutex
+			bodies []string
+		)
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+
+			mu.Lock()
+			bodies = append(bodies, string(b))
+			mu.Unlock()
+		})
+
+		config := Config{
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			Reporter: newMockReporter(t),
+		}
+
+		var cnt counter
+
+		stats := NewRequestC(config, "PUT", "http://example.com").
+			WithBodyFunc(newBodyFunc(&cnt, "test body")).
+			Repeat(3, 1)
+		stats.chain.assert(t, success)
+
+		stats.SuccessRate().IsEqual(1)
+
+		assert.Equal(t, []string{"test body", "test body", "test body"}, bodies)
+
+		// one body is opened when request is encoded, then one per sample
+		assert.Equal(t, 4, cnt.opened)
+	})
+
+	t.Run("hooks", func(t *testing.T) {
+		client := &mockClient{}
+
+		var hookBodies []string
+
+		hook := func(req *http.Request) error {
+			b, err := io.ReadAll(req.Body)
+			hookBodies = append(hookBodies, string(b))
+			return err
+		}
+
+		config := Config{
+			Client:       client,
+			Reporter:     newMockReporter(t),
+			RequestHooks: []func(*http.Request) error{hook, hook},
+		}
+
+		var cnt counter
+
+		resp := NewRequestC(config, "PUT", "url").
+			WithBodyFunc(newBodyFunc(&cnt, "test body")).
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.Body().IsEqual("test body")
+
+		assert.Equal(t, []string{"test body", "test body"}, hookBodies)
+		// initial body, and one body after every hook
+		assert.Equal(t, 3, cnt.opened)
+	})
+
+	t.Run("printers", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Printers: []Printer{
+				NewDebugPrinter(newMockLogger(t), true),
+				NewCurlPrinter(newMockLogger(t)),
+			},
+		}
+
+		var cnt counter
+
+		resp := NewRequestC(config, "PUT", "url").
+			WithBodyFunc(newBodyFunc(&cnt, "test body")).
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.Body().IsEqual("test body")
+		assert.Equal(t, 1, cnt.opened)
+	})
+
+	t.Run("open error", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "PUT", "url").
+			WithBodyFunc(func() (io.ReadCloser, error) {
+				return nil, errors.New("test error")
+			})
+		req.chain.assert(t, success)
+
+		req.Expect().chain.assert(t, failure)
+	})
+
+	t.Run("usage errors", func(t *testing.T) {
+		bodyFunc := func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("test body")), nil
+		}
+
+		cases := []struct {
+			name  string
+			reqFn func(*Request)
+		}{
+			{
+				name: "after WithBytes",
+				reqFn: func(req *Request) {
+					req.WithBytes([]byte("foo")).WithBodyFunc(bodyFunc)
+				},
+			},
+			{
+				name: "before WithText",
+				reqFn: func(req *Request) {
+					req.WithBodyFunc(bodyFunc).WithText("foo")
+				},
+			},
+			{
+				name: "signing",
+				reqFn: func(req *Request) {
+					req.WithHMACSignature([]byte("secret")).
+						WithBodyFunc(bodyFunc).
+						Expect()
 				},
 			},
 		}
 
 		for _, tc := range cases {
 			t.Run(tc.name, func(t *testing.T) {
-				req := NewRequestC(config, "POST", "url")
+				config := Config{
+					Client:   &mockClient{},
+					Reporter: newMockReporter(t),
+				}
+
+				req := NewRequestC(config, "PUT", "url")
 
 				tc.reqFn(req)
 				req.chain.assert(t, failure)
 			})
 		}
-
 	})
 }
 
+type countingBody struct {
+	io.Reader
+	closed *int
+}
+
+func (b *countingBody) Close() error {
+	*b.closed++
+	return nil
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
 func TestRequest_BodyJSON(t *testing.T) {
 	client := &mockClient{}
 
@@ -2109,6 +3780,15 @@ func TestRequest_Websocket(t *testing.T) {
 		req.Expect().chain.assert(t, failure)
 	})
 
+	t.Run("nil dialer", func(t *testing.T) {
+		config := Config{
+			Reporter: newMockReporter(t),
+		}
+		req := NewRequestC(config, "GET", "url").WithWebsocketUpgrade()
+		req.config.WebsocketDialer = nil
+		req.Expect().chain.assert(t, failure)
+	})
+
 	t.Run("request body not allowed", func(t *testing.T) {
 		dialer := WebsocketDialerFunc(func(
 			_ string, _ http.Header,
@@ -2124,6 +3804,312 @@ func TestRequest_Websocket(t *testing.T) {
 			WithWebsocketUpgrade()
 		req.Expect().chain.assert(t, failure)
 	})
+
+	t.Run("subprotocols", func(t *testing.T) {
+		var header http.Header
+		dialer := WebsocketDialerFunc(func(
+			_ string, h http.Header,
+		) (*websocket.Conn, *http.Response, error) {
+			header = h
+			return &websocket.Conn{}, &http.Response{}, nil
+		})
+		config := Config{
+			Reporter:        newMockReporter(t),
+			WebsocketDialer: dialer,
+		}
+		req := NewRequestC(config, "GET", "url").
+			WithWebsocketUpgrade().
+			WithWebsocketSubprotocols("foo", "bar")
+		req.Expect().chain.assert(t, success)
+		assert.Equal(t, "foo, bar", header.Get("Sec-WebSocket-Protocol"))
+	})
+
+	t.Run("subprotocols without upgrade", func(t *testing.T) {
+		config := Config{
+			Reporter: newMockReporter(t),
+			Client:   &mockClient{},
+		}
+		req := NewRequestC(config, "GET", "url").
+			WithWebsocketSubprotocols("foo")
+		req.Expect().chain.assert(t, failure)
+	})
+
+	t.Run("empty subprotocols", func(t *testing.T) {
+		config := Config{
+			Reporter: newMockReporter(t),
+		}
+		req := NewRequestC(config, "GET", "url").
+			WithWebsocketSubprotocols()
+		req.chain.assert(t, failure)
+	})
+}
+
+func TestRequest_Protocols(t *testing.T) {
+	t.Run("http3", func(t *testing.T) {
+		transport := &mockTransport{
+			resp: &http.Response{
+				Proto:      "HTTP/3.0",
+				ProtoMajor: 3,
+				StatusCode: http.StatusOK,
+				Body:       http.NoBody,
+			},
+		}
+
+		config := Config{
+			Reporter: newMockReporter(t),
+			BaseURL:  "https://example.com",
+			Client: &http.Client{
+				Transport: &mockTransport{err: errors.New("unexpected transport")},
+			},
+		}
+
+		req := NewRequestC(config, "GET", "/path").WithHTTP3(transport)
+
+		resp := req.Expect()
+		req.chain.assert(t, success)
+
+		resp.ProtoIs("HTTP/3.0")
+		resp.chain.assert(t, success)
+
+		require.NotNil(t, transport.req)
+		assert.Equal(t, "/path", transport.req.URL.Path)
+	})
+
+	t.Run("http3 nil transport", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		req := NewRequestC(config, "GET", "/path").WithHTTP3(nil)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("http2 and http3", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		req := NewRequestC(config, "GET", "/path").WithHTTP2()
+		req.chain.assert(t, success)
+
+		req.WithHTTP3(&mockTransport{})
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("http3 and http2", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		req := NewRequestC(config, "GET", "/path").WithHTTP3(&mockTransport{})
+		req.chain.assert(t, success)
+
+		req.WithHTTP2()
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("http2 and websocket", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		req := NewRequestC(config, "GET", "/path").
+			WithHTTP2().
+			WithWebsocketUpgrade()
+		req.chain.assert(t, success)
+
+		req.Expect()
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("http2 unsupported transport", func(t *testing.T) {
+		handlerCalled := false
+
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			BaseURL:          "http://example.invalid",
+			AssertionHandler: handler,
+			Client: &http.Client{
+				Transport: NewBinder(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						handlerCalled = true
+					})),
+			},
+		}
+
+		req := NewRequestC(config, "GET", "/path").WithHTTP2()
+		req.Expect()
+		req.chain.assert(t, failure)
+
+		assert.False(t, handlerCalled)
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertUsage, handler.failure.Type)
+	})
+
+	t.Run("http2 unsupported client", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := newMockConfig(newMockReporter(t))
+		config.Client = client
+
+		req := NewRequestC(config, "GET", "/path").WithHTTP2()
+		req.Expect()
+		req.chain.assert(t, failure)
+
+		assert.Nil(t, client.req)
+	})
+
+	t.Run("http2 shared transport", func(t *testing.T) {
+		var dialed []string
+
+		baseTransport := &http.Transport{
+			DialContext: func(
+				ctx context.Context, network, addr string,
+			) (net.Conn, error) {
+				dialed = append(dialed, addr)
+				return nil, errors.New("test error")
+			},
+		}
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   &http.Client{Transport: baseTransport},
+			Reporter: newMockReporter(t),
+		})
+
+		for i := 0; i < 2; i++ {
+			e.GET("/path").WithHTTP2().Expect().
+				chain.assert(t, failure)
+		}
+
+		// derived transport uses dial function of base transport
+		assert.Equal(t, []string{"example.com:80", "example.com:80"}, dialed)
+
+		cache := e.config.lifecycle.transportCache
+		assert.Equal(t, 1, len(cache.transports))
+
+		e.Close()
+
+		assert.Equal(t, 0, len(cache.transports))
+	})
+}
+
+func TestRequest_ResolveTo(t *testing.T) {
+	t.Run("invalid address", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		for _, addr := range []string{"", "127.0.0.1", "http://127.0.0.1:80"} {
+			req := NewRequestC(config, "GET", "/path").WithResolveTo(addr)
+			req.chain.assert(t, failure)
+		}
+	})
+
+	t.Run("unsupported client", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		req := NewRequestC(config, "GET", "/path").WithResolveTo("127.0.0.1:80")
+		req.chain.assert(t, success)
+
+		req.Expect()
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("unsupported transport", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+		config.Client = &http.Client{Transport: &mockTransport{}}
+
+		req := NewRequestC(config, "GET", "/path").WithResolveTo("127.0.0.1:80")
+		req.chain.assert(t, success)
+
+		_, err := req.Build()
+		assert.Error(t, err)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("unsupported websocket dialer", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+		config.WebsocketDialer = WebsocketDialerFunc(nil)
+
+		req := NewRequestC(config, "GET", "/path").
+			WithResolveTo("127.0.0.1:80").
+			WithWebsocketUpgrade()
+		req.chain.assert(t, success)
+
+		req.Expect()
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("shared transport", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(r.Host))
+			}))
+		defer server.Close()
+
+		var dialed []string
+
+		baseDialer := &net.Dialer{}
+		baseTransport := &http.Transport{
+			DialContext: func(
+				ctx context.Context, network, addr string,
+			) (net.Conn, error) {
+				dialed = append(dialed, addr)
+				return baseDialer.DialContext(ctx, network, addr)
+			},
+			Proxy: func(*http.Request) (*neturl.URL, error) {
+				return nil, errors.New("proxy should be bypassed")
+			},
+		}
+		defer baseTransport.CloseIdleConnections()
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   &http.Client{Transport: baseTransport},
+			Reporter: newMockReporter(t),
+		})
+		defer e.Close()
+
+		addr := server.Listener.Addr().String()
+
+		req1 := e.GET("/path").WithResolveTo(addr)
+		resp1 := req1.Expect()
+		resp1.Body().IsEqual("example.com")
+		resp1.ConnectionReused().IsFalse()
+		resp1.chain.assert(t, success)
+
+		req2 := e.GET("/path").WithResolveTo(addr)
+		resp2 := req2.Expect()
+		resp2.Body().IsEqual("example.com")
+		resp2.ConnectionReused().IsTrue()
+		resp2.chain.assert(t, success)
+
+		assert.Equal(t, []string{addr}, dialed)
+
+		transport1 := req1.config.Client.(*http.Client).Transport
+		transport2 := req2.config.Client.(*http.Client).Transport
+		assert.Same(t, transport1, transport2)
+		assert.NotSame(t, baseTransport, transport1)
+
+		req3 := e.GET("/path").WithResolveTo("127.0.0.1:1")
+		_, err := req3.Build()
+		require.NoError(t, err)
+
+		transport3 := req3.config.Client.(*http.Client).Transport
+		assert.NotSame(t, transport1, transport3)
+	})
+
+	t.Run("standalone request", func(t *testing.T) {
+		config := Config{
+			BaseURL:  "http://example.com",
+			Client:   &http.Client{Transport: &http.Transport{}},
+			Reporter: newMockReporter(t),
+		}
+
+		req1 := NewRequestC(config, "GET", "/path").WithResolveTo("127.0.0.1:1")
+		_, err := req1.Build()
+		require.NoError(t, err)
+
+		req2 := NewRequestC(config, "GET", "/path").WithResolveTo("127.0.0.1:1")
+		_, err = req2.Build()
+		require.NoError(t, err)
+
+		assert.NotSame(t,
+			req1.config.Client.(*http.Client).Transport,
+			req2.config.Client.(*http.Client).Transport)
+	})
 }
 
 func TestRequest_RedirectsDontFollow(t *testing.T) {
@@ -2606,6 +4592,129 @@ func TestRequest_RedirectsFollowWithoutBody(t *testing.T) {
 	})
 }
 
+func TestRequest_RedirectChain(t *testing.T) {
+	t.Run("follow", func(t *testing.T) {
+		tp := newMockRedirectTransport()
+		tp.maxRedirects = 2
+
+		config := Config{
+			BaseURL:  "http://example.com",
+			Client:   &http.Client{Transport: tp},
+			Reporter: newMockReporter(t),
+		}
+
+		resp := NewRequestC(config, http.MethodGet, "/url").
+			WithRedirectPolicy(FollowAllRedirects).
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.Status(http.StatusOK)
+
+		chain := resp.RedirectChain()
+		chain.chain.assert(t, success)
+
+		chain.Length().IsEqual(2)
+
+		first := chain.Value(0).Object()
+		first.HasValue("url", "http://example.com/url")
+		first.HasValue("status", http.StatusPermanentRedirect)
+		first.HasValue("location", "/redirect")
+		first.Value("headers").Object().ContainsKey("Location")
+
+		second := chain.Value(1).Object()
+		second.HasValue("url", "http://example.com/redirect")
+		second.HasValue("status", http.StatusPermanentRedirect)
+		second.HasValue("location", "/redirect")
+
+		chain.chain.assert(t, success)
+	})
+
+	t.Run("default policy", func(t *testing.T) {
+		tp := newMockRedirectTransport()
+		tp.maxRedirects = 1
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: newMockReporter(t),
+		}
+
+		resp := NewRequestC(config, http.MethodGet, "/url").
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.RedirectChain().Length().IsEqual(1).
+			chain.assert(t, success)
+	})
+
+	t.Run("dont follow", func(t *testing.T) {
+		tp := newMockRedirectTransport()
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: newMockReporter(t),
+		}
+
+		resp := NewRequestC(config, http.MethodGet, "/url").
+			WithRedirectPolicy(DontFollowRedirects).
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.Status(http.StatusPermanentRedirect)
+		resp.RedirectChain().IsEmpty().
+			chain.assert(t, success)
+	})
+
+	t.Run("client check redirect", func(t *testing.T) {
+		tp := newMockRedirectTransport()
+
+		var checked int
+
+		client := &http.Client{
+			Transport: tp,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				checked++
+				if len(via) >= 3 {
+					return http.ErrUseLastResponse
+				}
+				return nil
+			},
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		resp := NewRequestC(config, http.MethodGet, "/url").
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.Status(http.StatusPermanentRedirect)
+		resp.RedirectChain().Length().IsEqual(2).
+			chain.assert(t, success)
+
+		assert.Equal(t, 3, checked)
+		assert.Equal(t, 3, tp.tripCount)
+
+		// client passed by user should not be modified
+		assert.NotSame(t, client, resp.config.Client)
+	})
+
+	t.Run("not http.Client", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		resp := NewRequestC(config, http.MethodGet, "/url").
+			Expect()
+		resp.chain.assert(t, success)
+
+		resp.RedirectChain().IsEmpty().
+			chain.assert(t, success)
+	})
+}
+
 func TestRequest_RetriesDisabled(t *testing.T) {
 	t.Run("no error", func(t *testing.T) {
 		callCount := 0
@@ -3243,68 +5352,438 @@ func TestRequest_RetriesDelay(t *testing.T) {
 			Reporter: newMockReporter(t),
 		}
 
-		var totalSleepTime time.Duration
+		var totalSleepTime time.Duration
+
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithText("test body").
+			WithRetryPolicy(RetryAllErrors).
+			WithMaxRetries(3).
+			WithRetryDelay(100*time.Millisecond, 1000*time.Millisecond)
+		req.sleepFn = func(d time.Duration) <-chan time.Time {
+			totalSleepTime += d
+			return time.After(0)
+		}
+		req.chain.assert(t, success)
+
+		resp := req.Expect().
+			Status(http.StatusBadRequest)
+		resp.chain.assert(t, success)
+
+		// Should retry with delay
+		assert.Equal(t, int64(100+200+400), totalSleepTime.Milliseconds())
+	})
+
+	t.Run("exceeded", func(t *testing.T) {
+		callCount := 0
+
+		client := &mockClient{
+			resp: http.Response{
+				StatusCode: http.StatusBadRequest,
+			},
+			cb: func(req *http.Request) {
+				callCount++
+
+				b, err := io.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			},
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		var totalSleepTime time.Duration
+
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithText("test body").
+			WithRetryPolicy(RetryAllErrors).
+			WithMaxRetries(3).
+			WithRetryDelay(100*time.Millisecond, 300*time.Millisecond)
+		req.sleepFn = func(d time.Duration) <-chan time.Time {
+			totalSleepTime += d
+			return time.After(0)
+		}
+
+		req.chain.assert(t, success)
+
+		resp := req.Expect().
+			Status(http.StatusBadRequest)
+		resp.chain.assert(t, success)
+
+		// Should retry with delay
+		assert.Equal(t, int64(100+200+300), totalSleepTime.Milliseconds())
+	})
+}
+
+func TestRequest_RetriesJitter(t *testing.T) {
+	cases := []struct {
+		name      string
+		randValue float64
+		expected  []time.Duration
+	}{
+		{
+			name:      "min",
+			randValue: 0,
+			expected:  []time.Duration{75, 150, 300},
+		},
+		{
+			name:      "middle",
+			randValue: 0.5,
+			expected:  []time.Duration{100, 200, 400},
+		},
+		{
+			name:      "max",
+			randValue: 1,
+			expected:  []time.Duration{125, 250, 500},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{
+				resp: http.Response{
+					StatusCode: http.StatusBadRequest,
+				},
+			}
+
+			config := Config{
+				Client:   client,
+				Reporter: newMockReporter(t),
+			}
+
+			var sleeps []time.Duration
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithRetryPolicy(RetryAllErrors).
+				WithMaxRetries(3).
+				WithRetryDelay(100*time.Millisecond, 1000*time.Millisecond).
+				WithRetryJitter(0.25)
+			req.sleepFn = func(d time.Duration) <-chan time.Time {
+				sleeps = append(sleeps, d)
+				return time.After(0)
+			}
+			req.randFn = func() float64 {
+				return tc.randValue
+			}
+			req.chain.assert(t, success)
+
+			resp := req.Expect().
+				Status(http.StatusBadRequest)
+			resp.chain.assert(t, success)
+
+			expected := make([]time.Duration, len(tc.expected))
+			for n := range tc.expected {
+				expected[n] = tc.expected[n] * time.Millisecond
+			}
+			assert.Equal(t, expected, sleeps)
+		})
+	}
+}
+
+func TestRequest_RetriesBudget(t *testing.T) {
+	callCount := 0
+
+	client := &mockClient{
+		resp: http.Response{
+			StatusCode: http.StatusBadRequest,
+		},
+		cb: func(req *http.Request) {
+			callCount++
+		},
+	}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	var totalSleepTime time.Duration
+
+	req := NewRequestC(config, http.MethodPost, "/url").
+		WithRetryPolicy(RetryAllErrors).
+		WithMaxRetries(10).
+		WithRetryDelay(100*time.Millisecond, 1000*time.Millisecond).
+		WithRetryBudget(time.Second)
+	req.sleepFn = func(d time.Duration) <-chan time.Time {
+		totalSleepTime += d
+		return time.After(0)
+	}
+	req.chain.assert(t, success)
+
+	resp := req.Expect().
+		Status(http.StatusBadRequest)
+	resp.chain.assert(t, success)
+
+	// 100+200+400 fits into budget, next 800 doesn't
+	assert.Equal(t, 4, callCount)
+	assert.Equal(t, int64(100+200+400), totalSleepTime.Milliseconds())
+}
+
+func TestRequest_RetriesRetryAfter(t *testing.T) {
+	cases := []struct {
+		name        string
+		status      int
+		retryAfter  string
+		policy      RetryPolicy
+		budget      time.Duration
+		respect     bool
+		expectCalls int
+		expectSleep time.Duration
+	}{
+		{
+			name:        "seconds",
+			status:      http.StatusServiceUnavailable,
+			retryAfter:  "3",
+			policy:      RetryTimeoutAndServerErrors,
+			respect:     true,
+			expectCalls: 3,
+			expectSleep: 6 * time.Second,
+		},
+		{
+			name:        "date in past",
+			status:      http.StatusServiceUnavailable,
+			retryAfter:  "Wed, 21 Oct 2015 07:28:00 GMT",
+			policy:      RetryTimeoutAndServerErrors,
+			respect:     true,
+			expectCalls: 3,
+			expectSleep: 0,
+		},
+		{
+			name:        "invalid value",
+			status:      http.StatusServiceUnavailable,
+			retryAfter:  "soon",
+			policy:      RetryTimeoutAndServerErrors,
+			respect:     true,
+			expectCalls: 3,
+			expectSleep: 100*time.Millisecond + 200*time.Millisecond,
+		},
+		{
+			name:        "not respected",
+			status:      http.StatusServiceUnavailable,
+			retryAfter:  "3",
+			policy:      RetryTimeoutAndServerErrors,
+			respect:     false,
+			expectCalls: 3,
+			expectSleep: 100*time.Millisecond + 200*time.Millisecond,
+		},
+		{
+			name:        "too many requests",
+			status:      http.StatusTooManyRequests,
+			retryAfter:  "1",
+			policy:      RetryTimeoutAndServerErrors,
+			respect:     true,
+			expectCalls: 3,
+			expectSleep: 2 * time.Second,
+		},
+		{
+			name:        "too many requests not respected",
+			status:      http.StatusTooManyRequests,
+			retryAfter:  "1",
+			policy:      RetryTimeoutAndServerErrors,
+			respect:     false,
+			expectCalls: 1,
+			expectSleep: 0,
+		},
+		{
+			name:        "too many requests dont retry",
+			status:      http.StatusTooManyRequests,
+			retryAfter:  "1",
+			policy:      DontRetry,
+			respect:     true,
+			expectCalls: 1,
+			expectSleep: 0,
+		},
+		{
+			name:        "exceeds budget",
+			status:      http.StatusTooManyRequests,
+			retryAfter:  "60",
+			policy:      RetryAllErrors,
+			budget:      10 * time.Second,
+			respect:     true,
+			expectCalls: 1,
+			expectSleep: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			callCount := 0
+
+			client := &mockClient{
+				resp: http.Response{
+					StatusCode: tc.status,
+				},
+				cb: func(req *http.Request) {
+					callCount++
+				},
+			}
+
+			config := Config{
+				Client:   client,
+				Reporter: newMockReporter(t),
+			}
+
+			var totalSleepTime time.Duration
+
+			req := NewRequestC(config, http.MethodGet, "/url").
+				WithRetryPolicy(tc.policy).
+				WithMaxRetries(2).
+				WithRetryDelay(100*time.Millisecond, 1000*time.Millisecond).
+				WithRetryBudget(tc.budget).
+				// mockClient copies request headers to response
+				WithHeader("Retry-After", tc.retryAfter)
+			if tc.respect {
+				req.WithRetryRespectRetryAfter()
+			}
+			req.sleepFn = func(d time.Duration) <-chan time.Time {
+				totalSleepTime += d
+				return time.After(0)
+			}
+			req.chain.assert(t, success)
+
+			resp := req.Expect().
+				Status(tc.status)
+			resp.chain.assert(t, success)
+
+			assert.Equal(t, tc.expectCalls, callCount)
+			assert.Equal(t, tc.expectSleep, totalSleepTime)
+		})
+	}
+}
+
+func TestRequest_RetriesObserver(t *testing.T) {
+	type attempt struct {
+		attempt   int
+		status    int
+		body      string
+		err       error
+		nextDelay time.Duration
+	}
+
+	t.Run("responses", func(t *testing.T) {
+		callCount := 0
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			callCount++
+			if callCount < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte("unavailable"))
+			} else {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte("ok"))
+			}
+		})
+
+		config := Config{
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			Reporter: newMockReporter(t),
+		}
+
+		var attempts []attempt
 
-		req := NewRequestC(config, http.MethodPost, "/url").
-			WithText("test body").
-			WithRetryPolicy(RetryAllErrors).
-			WithMaxRetries(3).
-			WithRetryDelay(100*time.Millisecond, 1000*time.Millisecond)
-		req.sleepFn = func(d time.Duration) <-chan time.Time {
-			totalSleepTime += d
-			return time.After(0)
+		observer := func(n int, resp *http.Response, err error, delay time.Duration) {
+			b, readErr := io.ReadAll(resp.Body)
+			assert.NoError(t, readErr)
+
+			attempts = append(attempts, attempt{
+				attempt:   n,
+				status:    resp.StatusCode,
+				body:      string(b),
+				err:       err,
+				nextDelay: delay,
+			})
 		}
+
+		req := NewRequestC(config, http.MethodGet, "/url").
+			WithMaxRetries(5).
+			WithRetryDelay(100*time.Millisecond, 1000*time.Millisecond).
+			OnRetryAttempt(observer)
+		req.sleepFn = mockSleep
 		req.chain.assert(t, success)
 
-		resp := req.Expect().
-			Status(http.StatusBadRequest)
+		resp := req.Expect()
 		resp.chain.assert(t, success)
 
-		// Should retry with delay
-		assert.Equal(t, int64(100+200+400), totalSleepTime.Milliseconds())
-	})
-
-	t.Run("exceeded", func(t *testing.T) {
-		callCount := 0
+		// body is still readable after observer
+		resp.Status(http.StatusOK).
+			Body().IsEqual("ok")
+		resp.chain.assert(t, success)
 
-		client := &mockClient{
-			resp: http.Response{
-				StatusCode: http.StatusBadRequest,
-			},
-			cb: func(req *http.Request) {
-				callCount++
+		assert.Equal(t, []attempt{
+			{1, http.StatusServiceUnavailable, "unavailable", nil, 100 * time.Millisecond},
+			{2, http.StatusServiceUnavailable, "unavailable", nil, 200 * time.Millisecond},
+			{3, http.StatusOK, "ok", nil, 0},
+		}, attempts)
+	})
 
-				b, err := io.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
-			},
-		}
+	t.Run("errors", func(t *testing.T) {
+		testErr := errors.New("test error")
 
 		config := Config{
-			Client:   client,
+			Client:   &mockClient{err: testErr},
 			Reporter: newMockReporter(t),
 		}
 
-		var totalSleepTime time.Duration
+		var attempts []attempt
 
-		req := NewRequestC(config, http.MethodPost, "/url").
-			WithText("test body").
+		req := NewRequestC(config, http.MethodGet, "/url").
 			WithRetryPolicy(RetryAllErrors).
-			WithMaxRetries(3).
-			WithRetryDelay(100*time.Millisecond, 300*time.Millisecond)
-		req.sleepFn = func(d time.Duration) <-chan time.Time {
-			totalSleepTime += d
-			return time.After(0)
+			WithMaxRetries(1).
+			WithRetryDelay(time.Second, time.Second).
+			OnRetryAttempt(
+				func(n int, resp *http.Response, err error, delay time.Duration) {
+					assert.Nil(t, resp)
+					attempts = append(attempts, attempt{
+						attempt:   n,
+						err:       err,
+						nextDelay: delay,
+					})
+				})
+		req.sleepFn = mockSleep
+		req.chain.assert(t, success)
+
+		resp := req.Expect()
+		resp.chain.assert(t, failure)
+
+		assert.Equal(t, []attempt{
+			{attempt: 1, err: testErr, nextDelay: time.Second},
+			{attempt: 2, err: testErr, nextDelay: 0},
+		}, attempts)
+	})
+
+	t.Run("multiple observers", func(t *testing.T) {
+		config := Config{
+			Client: &mockClient{
+				resp: http.Response{
+					StatusCode: http.StatusBadRequest,
+				},
+			},
+			Reporter: newMockReporter(t),
 		}
 
+		var calls []string
+
+		req := NewRequestC(config, http.MethodGet, "/url").
+			WithRetryPolicy(RetryAllErrors).
+			WithMaxRetries(1).
+			WithRetryDelay(0, 0).
+			OnRetryAttempt(func(n int, _ *http.Response, _ error, _ time.Duration) {
+				calls = append(calls, fmt.Sprintf("first %d", n))
+			}).
+			OnRetryAttempt(func(n int, _ *http.Response, _ error, _ time.Duration) {
+				calls = append(calls, fmt.Sprintf("second %d", n))
+			})
+		req.sleepFn = mockSleep
 		req.chain.assert(t, success)
 
-		resp := req.Expect().
+		req.Expect().
 			Status(http.StatusBadRequest)
-		resp.chain.assert(t, success)
 
-		// Should retry with delay
-		assert.Equal(t, int64(100+200+300), totalSleepTime.Milliseconds())
+		assert.Equal(t, []string{"first 1", "second 1", "first 2", "second 2"}, calls)
 	})
 }
 
@@ -3349,6 +5828,225 @@ func TestRequest_RetriesCancellation(t *testing.T) {
 	assert.Equal(t, 1, callCount)
 }
 
+func TestRequest_SkipIfUnavailable(t *testing.T) {
+	cases := []struct {
+		name    string
+		err     error
+		skipped bool
+	}{
+		{
+			name: "dns error",
+			err: &neturl.Error{Op: "Get", URL: "http://example.invalid", Err: &net.OpError{
+				Op:  "dial",
+				Net: "tcp",
+				Err: &net.DNSError{Err: "no such host", Name: "example.invalid"},
+			}},
+			skipped: true,
+		},
+		{
+			name: "connection refused",
+			err: &neturl.Error{Op: "Get", URL: "http://127.0.0.1:1", Err: &net.OpError{
+				Op:  "dial",
+				Net: "tcp",
+				Err: os.NewSyscallError("connect", syscall.ECONNREFUSED),
+			}},
+			skipped: true,
+		},
+		{
+			name:    "bare connection refused",
+			err:     syscall.ECONNREFUSED,
+			skipped: true,
+		},
+		{
+			name: "read error",
+			err: &neturl.Error{Op: "Get", URL: "http://127.0.0.1:1", Err: &net.OpError{
+				Op:  "read",
+				Net: "tcp",
+				Err: os.NewSyscallError("read", syscall.ECONNRESET),
+			}},
+			skipped: false,
+		},
+		{
+			name:    "other error",
+			err:     errors.New("test error"),
+			skipped: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, enabled := range []bool{true, false} {
+				skipper := &mockSkipper{}
+
+				config := Config{
+					Client: &mockClient{
+						err: tc.err,
+					},
+					BaseURL:  "http://example.com",
+					Reporter: newMockReporter(t),
+				}
+
+				req := NewRequestC(config, "GET", "/path")
+				if enabled {
+					req.SkipIfUnavailable(skipper)
+				}
+
+				resp := req.Expect()
+
+				// mockSkipper doesn't stop execution, so failure is reported
+				resp.chain.assert(t, failure)
+
+				assert.Equal(t, tc.skipped && enabled, skipper.skipped)
+				if skipper.skipped {
+					assert.Contains(t, skipper.reason, "example.com")
+				}
+			}
+		})
+	}
+
+	t.Run("real connection", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := listener.Addr().String()
+		_ = listener.Close()
+
+		skipper := &mockSkipper{}
+
+		config := Config{
+			BaseURL:  "http://" + addr,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/path").
+			SkipIfUnavailable(skipper)
+
+		req.Expect()
+
+		assert.True(t, skipper.skipped)
+	})
+}
+
+func TestRequest_Repeat(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		var (
+			mu     sync.Mutex
+			bodies []string
+		)
+
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+
+			mu.Lock()
+			bodies = append(bodies, string(b))
+			n := len(bodies)
+			mu.Unlock()
+
+			status := http.StatusOK
+			if n%5 == 0 {
+				status = http.StatusServiceUnavailable
+			}
+
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader("response")),
+			}, nil
+		})
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "POST", "/path").
+			WithText("request")
+
+		stats := req.Repeat(10, 3)
+		stats.chain.assert(t, success)
+
+		assert.Equal(t, 10, len(bodies))
+		for _, b := range bodies {
+			assert.Equal(t, "request", b)
+		}
+
+		stats.Count().IsEqual(10)
+		stats.Errors().IsEqual(0)
+		stats.SuccessRate().IsEqual(0.8)
+		stats.StatusCount(http.StatusOK).IsEqual(8)
+		stats.StatusCount(http.StatusServiceUnavailable).IsEqual(2)
+		stats.P50().Ge(0)
+		stats.chain.assert(t, success)
+
+		req.Expect().chain.assert(t, failure)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("test error")
+		})
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		stats := NewRequestC(config, "GET", "/path").Repeat(5, 5)
+		stats.chain.assert(t, success)
+
+		stats.Errors().IsEqual(5)
+		stats.SuccessRate().IsEqual(0)
+		stats.chain.assert(t, success)
+
+		stats.P99().chain.assert(t, failure)
+	})
+
+	t.Run("redirects", func(t *testing.T) {
+		// run with -race to check that concurrent redirects don't race
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/old" {
+				http.Redirect(w, r, "/new", http.StatusFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+
+		config := Config{
+			BaseURL: "http://example.com",
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/old").
+			WithRedirectPolicy(FollowAllRedirects)
+
+		stats := req.Repeat(50, 10)
+		stats.chain.assert(t, success)
+
+		stats.Errors().IsEqual(0)
+		stats.SuccessRate().IsEqual(1)
+		stats.chain.assert(t, success)
+
+		assert.Empty(t, req.redirectChain)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		NewRequestC(config, "GET", "/path").Repeat(0, 1).
+			chain.assert(t, failure)
+
+		NewRequestC(config, "GET", "/path").Repeat(1, 0).
+			chain.assert(t, failure)
+
+		NewRequestC(config, "GET", "/path").WithWebsocketUpgrade().Repeat(1, 1).
+			chain.assert(t, failure)
+	})
+}
+
 func TestRequest_Conflicts(t *testing.T) {
 	client := &mockClient{}
 
@@ -3558,6 +6256,14 @@ func TestRequest_Usage(t *testing.T) {
 			prepFails:   true,
 			expectFails: true,
 		},
+		{
+			name: "SkipIfUnavailable - nil argument",
+			prepFunc: func(req *Request) {
+				req.SkipIfUnavailable(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
 		{
 			name: "WithMaxRedirects - negative argument",
 			prepFunc: func(req *Request) {
@@ -3582,6 +6288,47 @@ func TestRequest_Usage(t *testing.T) {
 			prepFails:   true,
 			expectFails: true,
 		},
+		{
+			name: "WithBodyFunc - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithBodyFunc(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithFileStream - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithMultipart()
+				req.WithFileStream("foo", "bar", nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "OnRetryAttempt - nil argument",
+			prepFunc: func(req *Request) {
+				req.OnRetryAttempt(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithRetryJitter - invalid fraction",
+			prepFunc: func(req *Request) {
+				req.WithRetryJitter(1.5)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithRetryBudget - negative argument",
+			prepFunc: func(req *Request) {
+				req.WithRetryBudget(-1)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
 		{
 			name: "WithWebsocketDialer - nil argument",
 			prepFunc: func(req *Request) {
@@ -3711,6 +6458,13 @@ func TestRequest_Order(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "WithContextMatcher after Expect",
+			afterFunc: func(req *Request) {
+				req.WithContextMatcher(func(*MatcherContext, *Response) {
+				})
+			},
+		},
 		{
 			name: "WithTransformer after Expect",
 			afterFunc: func(req *Request) {
@@ -3742,6 +6496,57 @@ func TestRequest_Order(t *testing.T) {
 				req.WithTimeout(3 * time.Second)
 			},
 		},
+		{
+			name: "WithVerbosity after Expect",
+			afterFunc: func(req *Request) {
+				req.WithVerbosity(VerbosityDebug)
+			},
+		},
+		{
+			name: "WithIfNoneMatch after Expect",
+			afterFunc: func(req *Request) {
+				req.WithIfNoneMatch(`"foo"`)
+			},
+		},
+		{
+			name: "WithIfMatch after Expect",
+			afterFunc: func(req *Request) {
+				req.WithIfMatch(`"foo"`)
+			},
+		},
+		{
+			name: "WithIfModifiedSince after Expect",
+			afterFunc: func(req *Request) {
+				req.WithIfModifiedSince(time.Now())
+			},
+		},
+		{
+			name: "WithAWSSigV4 after Expect",
+			afterFunc: func(req *Request) {
+				req.WithAWSSigV4(AWSCredentials{
+					AccessKeyID:     "a",
+					SecretAccessKey: "b",
+				}, "r", "s")
+			},
+		},
+		{
+			name: "WithHMACSignature after Expect",
+			afterFunc: func(req *Request) {
+				req.WithHMACSignature([]byte("secret"))
+			},
+		},
+		{
+			name: "WithRawRequestBytes after Expect",
+			afterFunc: func(req *Request) {
+				req.WithRawRequestBytes([]byte("GET / HTTP/1.1\r\n\r\n"))
+			},
+		},
+		{
+			name: "SkipIfUnavailable after Expect",
+			afterFunc: func(req *Request) {
+				req.SkipIfUnavailable(&mockSkipper{})
+			},
+		},
 		{
 			name: "WithRedirectPolicy after Expect",
 			afterFunc: func(req *Request) {
@@ -3784,6 +6589,24 @@ func TestRequest_Order(t *testing.T) {
 				req.WithWebsocketDialer(&websocket.Dialer{})
 			},
 		},
+		{
+			name: "WithPathTemplateStyle after Expect",
+			afterFunc: func(req *Request) {
+				req.WithPathTemplateStyle(PathTemplateRFC6570)
+			},
+		},
+		{
+			name: "WithResolveTo after Expect",
+			afterFunc: func(req *Request) {
+				req.WithResolveTo("127.0.0.1:80")
+			},
+		},
+		{
+			name: "WithBasePath after Expect",
+			afterFunc: func(req *Request) {
+				req.WithBasePath("/api")
+			},
+		},
 		{
 			name: "WithPath after Expect",
 			afterFunc: func(req *Request) {
@@ -3838,6 +6661,12 @@ func TestRequest_Order(t *testing.T) {
 				req.WithHeader("Content-Type", "application/json")
 			},
 		},
+		{
+			name: "WithCORSPreflight after Expect",
+			afterFunc: func(req *Request) {
+				req.WithCORSPreflight("http://example.com", "PUT")
+			},
+		},
 		{
 			name: "WithCookies after Expect",
 			afterFunc: func(req *Request) {
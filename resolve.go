@@ -0,0 +1,371 @@
+package httpexpect
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"golang.org/x/net/http2"
+)
+
+// Maps address derived from request URL to address to connect to.
+type resolveFunc func(addr string) string
+
+// Returns resolveFunc that maps all addresses to given address.
+func resolveToAddr(resolveTo string) resolveFunc {
+	return func(string) string {
+		return resolveTo
+	}
+}
+
+// Returns resolveFunc that maps hosts from overrides map to corresponding
+// IP addresses, optionally with port. Other addresses are not changed.
+func resolveOverrides(overrides map[string]string) resolveFunc {
+	hosts := make(map[string]string, len(overrides))
+	for host, target := range overrides {
+		hosts[strings.ToLower(host)] = target
+	}
+
+	return func(addr string) string {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return addr
+		}
+
+		target, ok := hosts[strings.ToLower(host)]
+		if !ok {
+			return addr
+		}
+
+		if _, _, err := net.SplitHostPort(target); err == nil {
+			return target
+		}
+
+		return net.JoinHostPort(target, port)
+	}
+}
+
+// Check that DNS override target is IP address, optionally with port.
+func validateResolveOverride(target string) error {
+	host := target
+	if h, _, err := net.SplitHostPort(target); err == nil {
+		host = h
+	}
+
+	if net.ParseIP(host) == nil {
+		return fmt.Errorf("expected ip or ip:port, got %q", target)
+	}
+
+	return nil
+}
+
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// Returns given dial function, or, if it's nil, dial function of dialer
+// with the same timeout and keep-alive settings as in http.DefaultTransport.
+func dialFuncOrDefault(dial dialFunc) dialFunc {
+	if dial != nil {
+		return dial
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return dialer.DialContext
+}
+
+// Returns dial function that connects to address returned by resolveFunc
+// using given dial function. If dial function is nil, default dialer is
+// used, see dialFuncOrDefault.
+func resolveDialFunc(dial dialFunc, resolve resolveFunc) dialFunc {
+	dial = dialFuncOrDefault(dial)
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return dial(ctx, network, resolve(addr))
+	}
+}
+
+// Derive client which connects to address returned by resolveFunc instead
+// of address from request URL. URL, Host header, and TLS server name are not
+// changed. Redirect policy and cookie jar of original client are preserved.
+func resolveClient(client Client, resolve resolveFunc) (Client, error) {
+	httpClient, ok := client.(*http.Client)
+	if !ok {
+		return nil, fmt.Errorf(
+			"unsupported client type %T, expected *http.Client", client)
+	}
+
+	clientCopy := *httpClient
+
+	switch transport := clientCopy.Transport.(type) {
+	case nil:
+		clientCopy.Transport = resolveTransport(
+			http.DefaultTransport.(*http.Transport), resolve)
+
+	case *http.Transport:
+		clientCopy.Transport = resolveTransport(transport, resolve)
+
+	case *http2.Transport:
+		clientCopy.Transport = resolveHTTP2Transport(transport, resolve)
+
+	default:
+		return nil, fmt.Errorf(
+			"unsupported transport type %T, expected *http.Transport"+
+				" or *http2.Transport", transport)
+	}
+
+	return &clientCopy, nil
+}
+
+// Key of derived transport.
+type transportCacheKey struct {
+	// original transport
+	transport http.RoundTripper
+	// protocol and URL scheme, if transport was derived by protocolClient
+	protoMajor int
+	scheme     string
+	// address passed to WithResolveTo, if transport was derived for it
+	target string
+}
+
+// Transports derived for WithResolveTo, WithHTTP2, and DifferentialProtocols.
+// Every derived transport has its own connection pool, so deriving it for
+// every request would leave idle connections open after each request.
+// Instead, requests with the same original transport and derivation
+// parameters reuse the same derived transport and its connections. Cache
+// is owned by Expect lifecycle, and its transports are released by
+// Expect.Close.
+type transportCache struct {
+	mu         sync.Mutex
+	transports map[transportCacheKey]http.RoundTripper
+}
+
+func newTransportCache() *transportCache {
+	return &transportCache{
+		transports: make(map[transportCacheKey]http.RoundTripper),
+	}
+}
+
+// Returns transport derived for given key, or derives it using given
+// function and caches it. If cache is nil, transport is derived on every
+// call.
+func (c *transportCache) transport(
+	key transportCacheKey, derive func() (http.RoundTripper, error),
+) (http.RoundTripper, error) {
+	// transports of other kinds may be not comparable
+	if c == nil ||
+		(key.transport != nil && reflect.ValueOf(key.transport).Kind() != reflect.Ptr) {
+		return derive()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if transport, ok := c.transports[key]; ok {
+		return transport, nil
+	}
+
+	transport, err := derive()
+	if err != nil {
+		return nil, err
+	}
+
+	c.transports[key] = transport
+
+	return transport, nil
+}
+
+// Like resolveClient, but reuses transport derived for the same key.
+// Redirect policy, cookie jar, and timeout of given client are preserved.
+func (c *transportCache) client(
+	client Client, resolve resolveFunc, key transportCacheKey,
+) (Client, error) {
+	httpClient, ok := client.(*http.Client)
+	if !ok {
+		return nil, fmt.Errorf(
+			"unsupported client type %T, expected *http.Client", client)
+	}
+
+	transport, err := c.transport(key, func() (http.RoundTripper, error) {
+		derived, err := resolveClient(client, resolve)
+		if err != nil {
+			return nil, err
+		}
+		return derived.(*http.Client).Transport, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clientCopy := *httpClient
+	clientCopy.Transport = transport
+
+	return &clientCopy, nil
+}
+
+// Close idle connections of derived transports and remove them from cache.
+func (c *transportCache) close() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	transports := c.transports
+	c.transports = make(map[transportCacheKey]http.RoundTripper)
+	c.mu.Unlock()
+
+	for _, transport := range transports {
+		if t, ok := transport.(interface{ CloseIdleConnections() }); ok {
+			t.CloseIdleConnections()
+		}
+	}
+}
+
+// Returns dial function of transport, or nil if it's not set.
+func transportDialFunc(transport *http.Transport) dialFunc {
+	if transport.DialContext != nil {
+		return transport.DialContext
+	}
+
+	if dialNoCtx := transport.Dial; dialNoCtx != nil { //nolint
+		return func(_ context.Context, network, addr string) (net.Conn, error) {
+			return dialNoCtx(network, addr)
+		}
+	}
+
+	return nil
+}
+
+// Derive transport which dials address returned by resolveFunc.
+// Dial functions of original transport are wrapped, so that their timeout,
+// keep-alive, and other settings are preserved. Proxy is bypassed, because
+// connection should be established directly to resolved address.
+func resolveTransport(transport *http.Transport, resolve resolveFunc) *http.Transport {
+	transport = transport.Clone()
+
+	dial := transportDialFunc(transport)
+
+	// If DialTLSContext is not set, TLS handshake is performed by transport
+	// over connection returned by DialContext, using host from request URL
+	// as server name
+	transport.DialContext = resolveDialFunc(dial, resolve)
+	transport.Dial = nil //nolint
+
+	if dialTLS := transport.DialTLSContext; dialTLS != nil {
+		transport.DialTLSContext = resolveDialFunc(dialTLS, resolve)
+	}
+
+	transport.Proxy = nil
+
+	return transport
+}
+
+// Derive HTTP/2 transport which dials address returned by resolveFunc.
+// http2.Transport can't be copied, so its settings are transferred to
+// a new transport.
+func resolveHTTP2Transport(
+	transport *http2.Transport, resolve resolveFunc,
+) *http2.Transport {
+	derived := &http2.Transport{
+		TLSClientConfig:            transport.TLSClientConfig,
+		DisableCompression:         transport.DisableCompression,
+		AllowHTTP:                  transport.AllowHTTP,
+		MaxHeaderListSize:          transport.MaxHeaderListSize,
+		MaxReadFrameSize:           transport.MaxReadFrameSize,
+		StrictMaxConcurrentStreams: transport.StrictMaxConcurrentStreams,
+		IdleConnTimeout:            transport.IdleConnTimeout,
+		ReadIdleTimeout:            transport.ReadIdleTimeout,
+		PingTimeout:                transport.PingTimeout,
+		WriteByteTimeout:           transport.WriteByteTimeout,
+		CountError:                 transport.CountError,
+	}
+
+	if dialTLS := transport.DialTLSContext; dialTLS != nil {
+		derived.DialTLSContext = func(
+			ctx context.Context, network, addr string, cfg *tls.Config,
+		) (net.Conn, error) {
+			return dialTLS(ctx, network, resolve(addr), cfg)
+		}
+
+		return derived
+	}
+
+	dial := resolveDialFunc(nil, resolve)
+
+	// transport created by newHTTP2Transport() for h2c enables AllowHTTP
+	// and dials plain connections
+	allowHTTP := transport.AllowHTTP
+
+	derived.DialTLSContext = func(
+		ctx context.Context, network, addr string, cfg *tls.Config,
+	) (net.Conn, error) {
+		if allowHTTP {
+			return dial(ctx, network, addr)
+		}
+		return dialHTTP2TLS(ctx, dial, network, addr, cfg)
+	}
+
+	return derived
+}
+
+// Dial connection using given dial function and perform TLS handshake
+// with HTTP/2 negotiation, like http2.Transport does by default.
+func dialHTTP2TLS(
+	ctx context.Context, dial dialFunc, network, addr string, cfg *tls.Config,
+) (net.Conn, error) {
+	conn, err := dial(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConn := tls.Client(conn, cfg)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	if proto := tlsConn.ConnectionState().NegotiatedProtocol; proto != http2.NextProtoTLS {
+		_ = conn.Close()
+		return nil, fmt.Errorf(
+			"unexpected ALPN protocol %q, expected %q", proto, http2.NextProtoTLS)
+	}
+
+	return tlsConn, nil
+}
+
+// Derive websocket dialer which connects to address returned by resolveFunc
+// instead of address from request URL.
+func resolveWebsocketDialer(
+	dialer WebsocketDialer, resolve resolveFunc,
+) (WebsocketDialer, error) {
+	wsDialer, ok := dialer.(*websocket.Dialer)
+	if !ok {
+		return nil, fmt.Errorf(
+			"unsupported websocket dialer type %T, expected *websocket.Dialer", dialer)
+	}
+
+	dialerCopy := *wsDialer
+
+	dial := dialerCopy.NetDialContext
+	if dial == nil && dialerCopy.NetDial != nil {
+		dialNoCtx := dialerCopy.NetDial
+		dial = func(_ context.Context, network, addr string) (net.Conn, error) {
+			return dialNoCtx(network, addr)
+		}
+	}
+
+	dialerCopy.NetDial = nil
+	dialerCopy.NetDialContext = resolveDialFunc(dial, resolve)
+	dialerCopy.Proxy = nil
+
+	return &dialerCopy, nil
+}
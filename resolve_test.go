@@ -0,0 +1,237 @@
+package httpexpect
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+)
+
+func TestResolve_Client(t *testing.T) {
+	t.Run("nil transport", func(t *testing.T) {
+		jar := NewCookieJar()
+
+		client, err := resolveClient(&http.Client{Jar: jar}, resolveToAddr("127.0.0.1:80"))
+		require.NoError(t, err)
+
+		httpClient := client.(*http.Client)
+		assert.Same(t, jar, httpClient.Jar)
+
+		transport := httpClient.Transport.(*http.Transport)
+		assert.NotNil(t, transport.DialContext)
+		assert.Nil(t, transport.Proxy)
+	})
+
+	t.Run("http transport", func(t *testing.T) {
+		baseTransport := &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		}
+
+		client, err := resolveClient(
+			&http.Client{Transport: baseTransport}, resolveToAddr("127.0.0.1:80"))
+		require.NoError(t, err)
+
+		transport := client.(*http.Client).Transport.(*http.Transport)
+		assert.NotSame(t, baseTransport, transport)
+		assert.NotNil(t, transport.DialContext)
+		assert.Nil(t, transport.Proxy)
+
+		assert.NotNil(t, baseTransport.Proxy)
+		assert.Nil(t, baseTransport.DialContext)
+	})
+
+	t.Run("http2 transport", func(t *testing.T) {
+		baseTransport := &http2.Transport{AllowHTTP: true}
+
+		client, err := resolveClient(
+			&http.Client{Transport: baseTransport}, resolveToAddr("127.0.0.1:80"))
+		require.NoError(t, err)
+
+		transport := client.(*http.Client).Transport.(*http2.Transport)
+		assert.NotSame(t, baseTransport, transport)
+		assert.True(t, transport.AllowHTTP)
+		assert.NotNil(t, transport.DialTLSContext)
+	})
+
+	t.Run("http transport dialers", func(t *testing.T) {
+		var dialed, dialedTLS string
+
+		baseTransport := &http.Transport{
+			DialContext: func(
+				ctx context.Context, network, addr string,
+			) (net.Conn, error) {
+				dialed = addr
+				return nil, errors.New("test error")
+			},
+			DialTLSContext: func(
+				ctx context.Context, network, addr string,
+			) (net.Conn, error) {
+				dialedTLS = addr
+				return nil, errors.New("test error")
+			},
+		}
+
+		client, err := resolveClient(
+			&http.Client{Transport: baseTransport}, resolveToAddr("127.0.0.1:80"))
+		require.NoError(t, err)
+
+		transport := client.(*http.Client).Transport.(*http.Transport)
+
+		_, _ = transport.DialContext(context.Background(), "tcp", "example.com:80")
+		assert.Equal(t, "127.0.0.1:80", dialed)
+
+		_, _ = transport.DialTLSContext(context.Background(), "tcp", "example.com:443")
+		assert.Equal(t, "127.0.0.1:80", dialedTLS)
+	})
+
+	t.Run("http2 transport dialer", func(t *testing.T) {
+		var dialed string
+
+		baseTransport := &http2.Transport{
+			ReadIdleTimeout: time.Minute,
+			DialTLSContext: func(
+				ctx context.Context, network, addr string, cfg *tls.Config,
+			) (net.Conn, error) {
+				dialed = addr
+				return nil, errors.New("test error")
+			},
+		}
+
+		client, err := resolveClient(
+			&http.Client{Transport: baseTransport}, resolveToAddr("127.0.0.1:80"))
+		require.NoError(t, err)
+
+		transport := client.(*http.Client).Transport.(*http2.Transport)
+		assert.Equal(t, time.Minute, transport.ReadIdleTimeout)
+
+		_, _ = transport.DialTLSContext(
+			context.Background(), "tcp", "example.com:443", &tls.Config{})
+		assert.Equal(t, "127.0.0.1:80", dialed)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		baseTransport := &http.Transport{}
+		jar := NewCookieJar()
+
+		key := transportCacheKey{
+			transport: baseTransport,
+			target:    "127.0.0.1:80",
+		}
+
+		cache := newTransportCache()
+
+		client1, err := cache.client(
+			&http.Client{Transport: baseTransport},
+			resolveToAddr("127.0.0.1:80"), key)
+		require.NoError(t, err)
+
+		client2, err := cache.client(
+			&http.Client{Transport: baseTransport, Jar: jar},
+			resolveToAddr("127.0.0.1:80"), key)
+		require.NoError(t, err)
+
+		assert.NotSame(t, client1, client2)
+		assert.Same(t, jar, client2.(*http.Client).Jar)
+
+		assert.Same(t,
+			client1.(*http.Client).Transport, client2.(*http.Client).Transport)
+		assert.NotSame(t, baseTransport, client1.(*http.Client).Transport)
+	})
+
+	t.Run("unsupported transport", func(t *testing.T) {
+		_, err := resolveClient(
+			&http.Client{Transport: &mockTransport{}}, resolveToAddr("127.0.0.1:80"))
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported client", func(t *testing.T) {
+		_, err := resolveClient(&mockClient{}, resolveToAddr("127.0.0.1:80"))
+		assert.Error(t, err)
+	})
+}
+
+func TestResolve_WebsocketDialer(t *testing.T) {
+	t.Run("dialer", func(t *testing.T) {
+		baseDialer := &websocket.Dialer{
+			Proxy: http.ProxyFromEnvironment,
+		}
+
+		dialer, err := resolveWebsocketDialer(baseDialer, resolveToAddr("127.0.0.1:80"))
+		require.NoError(t, err)
+
+		wsDialer := dialer.(*websocket.Dialer)
+		assert.NotSame(t, baseDialer, wsDialer)
+		assert.NotNil(t, wsDialer.NetDialContext)
+		assert.Nil(t, wsDialer.Proxy)
+
+		assert.NotNil(t, baseDialer.Proxy)
+	})
+
+	t.Run("dialer with net dial", func(t *testing.T) {
+		var dialed string
+
+		baseDialer := &websocket.Dialer{
+			NetDial: func(network, addr string) (net.Conn, error) {
+				dialed = addr
+				return nil, errors.New("test error")
+			},
+		}
+
+		dialer, err := resolveWebsocketDialer(baseDialer, resolveToAddr("127.0.0.1:80"))
+		require.NoError(t, err)
+
+		wsDialer := dialer.(*websocket.Dialer)
+		assert.Nil(t, wsDialer.NetDial)
+
+		_, _ = wsDialer.NetDialContext(context.Background(), "tcp", "example.com:80")
+		assert.Equal(t, "127.0.0.1:80", dialed)
+	})
+
+	t.Run("unsupported dialer", func(t *testing.T) {
+		_, err := resolveWebsocketDialer(
+			WebsocketDialerFunc(nil), resolveToAddr("127.0.0.1:80"))
+		assert.Error(t, err)
+	})
+}
+
+func TestResolve_Overrides(t *testing.T) {
+	resolve := resolveOverrides(map[string]string{
+		"Example.com":     "10.0.0.1",
+		"api.example.com": "10.0.0.2:8443",
+		"v6.example.com":  "::1",
+	})
+
+	cases := []struct {
+		addr     string
+		expected string
+	}{
+		{"example.com:80", "10.0.0.1:80"},
+		{"EXAMPLE.COM:443", "10.0.0.1:443"},
+		{"api.example.com:443", "10.0.0.2:8443"},
+		{"v6.example.com:80", "[::1]:80"},
+		{"other.com:80", "other.com:80"},
+		{"example.com", "example.com"},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, resolve(tc.addr))
+	}
+}
+
+func TestResolve_ValidateOverride(t *testing.T) {
+	for _, target := range []string{"10.0.0.1", "10.0.0.1:80", "::1", "[::1]:80"} {
+		assert.NoError(t, validateResolveOverride(target))
+	}
+
+	for _, target := range []string{"", "example.com", "example.com:80"} {
+		assert.Error(t, validateResolveOverride(target))
+	}
+}
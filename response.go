@@ -2,9 +2,15 @@ package httpexpect
 
 import (
 	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"hash"
+	"hash/crc32"
 	"io"
 	"mime"
 	"net/http"
@@ -32,7 +38,19 @@ type Response struct {
 	contentState  contentState
 	contentMethod string
 
+	isRewindDisabled bool
+
 	cookies []*http.Cookie
+
+	redirects []*http.Response
+
+	connReused  *bool
+	timings     *requestTimings
+	headersSize *int
+
+	logger *RequestLogger
+
+	owner *Expect
 }
 
 type contentState int
@@ -46,6 +64,8 @@ const (
 	contentFailed
 	// We transferred body reader to user and will not use it by ourselves
 	contentHijacked
+	// We retrieved response content, but didn't retain it
+	contentDiscarded
 )
 
 // NewResponse returns a new Response instance.
@@ -90,11 +110,17 @@ func NewResponseC(
 }
 
 type responseOpts struct {
-	config    Config
-	chain     *chain
-	httpResp  *http.Response
-	websocket *websocket.Conn
-	rtt       []time.Duration
+	config      Config
+	chain       *chain
+	httpResp    *http.Response
+	websocket   *websocket.Conn
+	rtt         []time.Duration
+	redirects   []*http.Response
+	connReused  *bool
+	timings     *requestTimings
+	headersSize *int
+	logger      *RequestLogger
+	owner       *Expect
 }
 
 func newResponse(opts responseOpts) *Response {
@@ -104,6 +130,23 @@ func newResponse(opts responseOpts) *Response {
 		config:       opts.config,
 		chain:        opts.chain.clone(),
 		contentState: contentPending,
+		redirects:    opts.redirects,
+		connReused:   opts.connReused,
+		timings:      opts.timings,
+		headersSize:  opts.headersSize,
+		logger:       opts.logger,
+		owner:        opts.owner,
+	}
+
+	if r.logger == nil {
+		var method, path string
+		if opts.httpResp != nil && opts.httpResp.Request != nil {
+			method = opts.httpResp.Request.Method
+			if opts.httpResp.Request.URL != nil {
+				path = opts.httpResp.Request.URL.Path
+			}
+		}
+		r.logger = newRequestLogger(opts.config, method, path)
 	}
 
 	opChain := r.chain.enter("")
@@ -138,11 +181,14 @@ func newResponse(opts responseOpts) *Response {
 	r.httpResp = opts.httpResp
 
 	if r.httpResp.Body != nil && r.httpResp.Body != http.NoBody {
-		if _, ok := r.httpResp.Body.(*bodyWrapper); !ok {
+		bw, ok := r.httpResp.Body.(*bodyWrapper)
+		if !ok {
 			respCopy := *r.httpResp
 			r.httpResp = &respCopy
-			r.httpResp.Body = newBodyWrapper(r.httpResp.Body, nil)
+			bw = newBodyWrapper(r.httpResp.Body, nil)
+			r.httpResp.Body = bw
 		}
+		bw.SetMaxSize(r.config.MaxBufferedBodySize)
 	}
 
 	r.websocket = opts.websocket
@@ -172,6 +218,15 @@ func (r *Response) getContent(opChain *chain, method string) ([]byte, bool) {
 			},
 		})
 		return nil, false
+
+	case contentDiscarded:
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				r.discardedError(method),
+			},
+		})
+		return nil, false
 	}
 
 	resp := r.httpResp
@@ -191,6 +246,22 @@ func (r *Response) getContent(opChain *chain, method string) ([]byte, bool) {
 		err = closeErr
 	}
 
+	if err == errBodyTooLarge {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("cannot call %s because response body was already read"+
+					" and its size exceeds Config.MaxBufferedBodySize (%d bytes)",
+					method, r.config.MaxBufferedBodySize),
+			},
+		})
+
+		r.content = nil
+		r.contentState = contentFailed
+
+		return nil, false
+	}
+
 	if err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertOperation,
@@ -206,19 +277,138 @@ func (r *Response) getContent(opChain *chain, method string) ([]byte, bool) {
 		return nil, false
 	}
 
+	r.contentMethod = method
+
+	// Don't retain content if rewinds are disabled or content is too large.
+	if r.isRewindDisabled || r.isTooLarge(content) {
+		r.content = nil
+		r.contentState = contentDiscarded
+
+		return content, true
+	}
+
 	r.content = content
 	r.contentState = contentRetreived
-	r.contentMethod = method
 
 	return r.content, true
 }
 
+// Stream response body into writer, without retaining it.
+// If content was already retrieved, it is written from memory.
+// Body rewinds remain available, unless the body exceeds
+// Config.MaxBufferedBodySize or rewinds are disabled.
+func (r *Response) streamContent(opChain *chain, method string, w io.Writer) bool {
+	switch r.contentState {
+	case contentRetreived:
+		_, _ = w.Write(r.content)
+		return true
+
+	case contentPending:
+		break
+
+	default:
+		// Report the same failures as getContent.
+		_, ok := r.getContent(opChain, method)
+		return ok
+	}
+
+	resp := r.httpResp
+
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return true
+	}
+
+	if bw, ok := resp.Body.(*bodyWrapper); ok {
+		bw.Rewind()
+	}
+
+	_, err := io.Copy(w, resp.Body)
+
+	closeErr := resp.Body.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == errBodyTooLarge {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("cannot call %s because response body was already read"+
+					" and its size exceeds Config.MaxBufferedBodySize (%d bytes)",
+					method, r.config.MaxBufferedBodySize),
+			},
+		})
+
+		r.contentState = contentFailed
+
+		return false
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to read response body"),
+				err,
+			},
+		})
+
+		r.contentState = contentFailed
+
+		return false
+	}
+
+	r.contentMethod = method
+
+	// If body exceeded Config.MaxBufferedBodySize, body wrapper dropped it,
+	// and next rewind will report it.
+	if r.isRewindDisabled {
+		r.contentState = contentDiscarded
+	}
+
+	return true
+}
+
+func (r *Response) isTooLarge(content []byte) bool {
+	return r.config.MaxBufferedBodySize > 0 &&
+		int64(len(content)) > r.config.MaxBufferedBodySize
+}
+
+func (r *Response) discardedError(method string) error {
+	if r.isRewindDisabled {
+		return fmt.Errorf(
+			"cannot call %s because %s was already called and body rewinds"+
+				" are disabled by DisableBodyRewinds()",
+			method, r.contentMethod)
+	}
+
+	return fmt.Errorf(
+		"cannot call %s because %s was already called and response body size"+
+			" exceeds Config.MaxBufferedBodySize (%d bytes)",
+		method, r.contentMethod, r.config.MaxBufferedBodySize)
+}
+
 // Raw returns underlying http.Response object.
 // This is the value originally passed to NewResponse.
 func (r *Response) Raw() *http.Response {
 	return r.httpResp
 }
 
+// Logger returns RequestLogger of the request that produced this response.
+//
+// It's intended to be used by custom matchers (see Request.WithMatcher).
+// If response was not produced by Request, a new logger is created.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithMatcher(func(resp *Response) {
+//		resp.Logger().Logf("checking response")
+//	})
+func (r *Response) Logger() *RequestLogger {
+	return r.logger
+}
+
 // Alias is similar to Value.Alias.
 func (r *Response) Alias(name string) *Response {
 	opChain := r.chain.enter("Alias(%q)", name)
@@ -267,11 +457,15 @@ func (r *Response) Duration() *Number {
 
 // Status succeeds if response contains given status code.
 //
+// If more than one status code is given, Status succeeds if response
+// status code is equal to any of them, like StatusList.
+//
 // Example:
 //
 //	resp := NewResponse(t, response)
 //	resp.Status(http.StatusOK)
-func (r *Response) Status(status int) *Response {
+//	resp.Status(http.StatusOK, http.StatusNoContent)
+func (r *Response) Status(status int, oneOf ...int) *Response {
 	opChain := r.chain.enter("Status()")
 	defer opChain.leave()
 
@@ -279,12 +473,45 @@ func (r *Response) Status(status int) *Response {
 		return r
 	}
 
+	if len(oneOf) != 0 {
+		r.checkStatusList(opChain, append([]int{status}, oneOf...))
+		return r
+	}
+
 	r.checkEqual(opChain, "http status",
 		statusCodeText(status), statusCodeText(r.httpResp.StatusCode))
 
 	return r
 }
 
+// StatusText returns a new String instance with response reason phrase.
+//
+// Reason phrase is taken from status line of the response, e.g. "Not Found"
+// for "404 Not Found". If response doesn't have status line, standard
+// reason phrase for status code is used.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.StatusText().IsEqual("Not Found")
+func (r *Response) StatusText() *String {
+	opChain := r.chain.enter("StatusText()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	text := http.StatusText(r.httpResp.StatusCode)
+
+	if code, reason, ok := strings.Cut(r.httpResp.Status, " "); ok &&
+		code == strconv.Itoa(r.httpResp.StatusCode) {
+		text = reason
+	}
+
+	return newString(opChain, text)
+}
+
 // StatusRange is enum for response status ranges.
 type StatusRange int
 
@@ -328,6 +555,112 @@ func (r *Response) StatusRange(rn StatusRange) *Response {
 		return r
 	}
 
+	r.checkStatusRange(opChain, rn)
+
+	return r
+}
+
+// StatusIsInformational succeeds if response status is 1xx.
+// Same as StatusRange(Status1xx).
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.StatusIsInformational()
+func (r *Response) StatusIsInformational() *Response {
+	opChain := r.chain.enter("StatusIsInformational()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.checkStatusRange(opChain, Status1xx)
+
+	return r
+}
+
+// StatusIsSuccess succeeds if response status is 2xx.
+// Same as StatusRange(Status2xx).
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.StatusIsSuccess()
+func (r *Response) StatusIsSuccess() *Response {
+	opChain := r.chain.enter("StatusIsSuccess()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.checkStatusRange(opChain, Status2xx)
+
+	return r
+}
+
+// StatusIsRedirection succeeds if response status is 3xx.
+// Same as StatusRange(Status3xx).
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.StatusIsRedirection()
+func (r *Response) StatusIsRedirection() *Response {
+	opChain := r.chain.enter("StatusIsRedirection()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.checkStatusRange(opChain, Status3xx)
+
+	return r
+}
+
+// StatusIsClientError succeeds if response status is 4xx.
+// Same as StatusRange(Status4xx).
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.StatusIsClientError()
+func (r *Response) StatusIsClientError() *Response {
+	opChain := r.chain.enter("StatusIsClientError()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.checkStatusRange(opChain, Status4xx)
+
+	return r
+}
+
+// StatusIsServerError succeeds if response status is 5xx.
+// Same as StatusRange(Status5xx).
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.StatusIsServerError()
+func (r *Response) StatusIsServerError() *Response {
+	opChain := r.chain.enter("StatusIsServerError()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.checkStatusRange(opChain, Status5xx)
+
+	return r
+}
+
+func (r *Response) checkStatusRange(opChain *chain, rn StatusRange) {
 	status := statusCodeText(r.httpResp.StatusCode)
 
 	actual := statusRangeText(r.httpResp.StatusCode)
@@ -345,8 +678,6 @@ func (r *Response) StatusRange(rn StatusRange) *Response {
 			},
 		})
 	}
-
-	return r
 }
 
 // StatusList succeeds if response matches with any given status code list
@@ -363,6 +694,12 @@ func (r *Response) StatusList(values ...int) *Response {
 		return r
 	}
 
+	r.checkStatusList(opChain, values)
+
+	return r
+}
+
+func (r *Response) checkStatusList(opChain *chain, values []int) {
 	if len(values) == 0 {
 		opChain.fail(AssertionFailure{
 			Type: AssertUsage,
@@ -370,7 +707,7 @@ func (r *Response) StatusList(values ...int) *Response {
 				errors.New("unexpected empty status list"),
 			},
 		})
-		return r
+		return
 	}
 
 	var found bool
@@ -391,10 +728,64 @@ func (r *Response) StatusList(values ...int) *Response {
 			},
 		})
 	}
+}
+
+// ProtoIs succeeds if response was received using given protocol version.
+//
+// Protocol is specified in the same form as in http.Response.Proto, e.g.
+// "HTTP/1.1" or "HTTP/2.0". Minor version may be omitted, e.g. "HTTP/2"
+// is the same as "HTTP/2.0".
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.ProtoIs("HTTP/2.0")
+func (r *Response) ProtoIs(proto string) *Response {
+	opChain := r.chain.enter("ProtoIs()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	expectedMajor, expectedMinor, ok := parseProto(proto)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{proto},
+			Errors: []error{
+				errors.New("invalid protocol version"),
+			},
+		})
+		return r
+	}
+
+	actualMajor, actualMinor := r.httpResp.ProtoMajor, r.httpResp.ProtoMinor
+	if major, minor, ok := parseProto(r.httpResp.Proto); ok {
+		actualMajor, actualMinor = major, minor
+	}
+
+	if actualMajor != expectedMajor || actualMinor != expectedMinor {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{r.httpResp.Proto},
+			Expected: &AssertionValue{proto},
+			Errors: []error{
+				errors.New("unexpected protocol of received response"),
+			},
+		})
+	}
 
 	return r
 }
 
+func parseProto(proto string) (major, minor int, ok bool) {
+	if !strings.Contains(proto, ".") {
+		proto += ".0"
+	}
+	return http.ParseHTTPVersion(proto)
+}
+
 func statusCodeText(code int) string {
 	if s := http.StatusText(code); s != "" {
 		return strconv.Itoa(code) + " " + s
@@ -467,192 +858,870 @@ func (r *Response) Header(header string) *String {
 	return newString(opChain, value)
 }
 
-// Cookies returns a new Array instance with all cookie names set by this response.
-// Returned Array contains a String value for every cookie name.
+// HeadersSize returns a new Number instance with size of response headers,
+// in bytes, as received on the wire.
 //
-// Note that this returns only cookies set by Set-Cookie headers of this response.
-// It doesn't return session cookies from previous responses, which may be stored
-// in a cookie jar.
+// Size includes status line, header lines, and empty line terminating
+// headers. Headers of interim 1xx responses are not included. If request
+// was redirected, size corresponds to the last response in redirect chain.
+//
+// Size is measured by HeadersSizeTransport, so it's available only for
+// responses returned by Request.Expect, if client is http.Client with
+// HeadersSizeTransport, and only for HTTP/1.x responses received over
+// plain (non-TLS) connections. Otherwise failure is reported.
 //
 // Example:
 //
-//	resp := NewResponse(t, response)
-//	resp.Cookies().Contains("session")
-func (r *Response) Cookies() *Array {
-	opChain := r.chain.enter("Cookies()")
+//	e := WithConfig(Config{
+//		BaseURL:  "http://example.com",
+//		Reporter: NewAssertReporter(t),
+//		Client: &http.Client{
+//			Transport: NewHeadersSizeTransport(nil),
+//		},
+//	})
+//
+//	resp := e.GET("/path").Expect()
+//	resp.HeadersSize().Lt(8 * 1024)
+func (r *Response) HeadersSize() *Number {
+	opChain := r.chain.enter("HeadersSize()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return newArray(opChain, nil)
+		return newNumber(opChain, 0)
 	}
 
-	names := []interface{}{}
-	for _, c := range r.cookies {
-		names = append(names, c.Name)
+	if r.headersSize == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{r.headersSize},
+			Errors: []error{
+				errors.New("expected: headers size is recorded for response" +
+					" (see HeadersSizeTransport)"),
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(*r.headersSize))
+}
+
+// HeadersCount returns a new Number instance with number of response
+// header lines.
+//
+// Every header value is counted separately, e.g. two Set-Cookie headers
+// are counted as two lines. Transfer-Encoding header, which is removed
+// from header map by http.Client, is counted as well.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HeadersCount().Lt(50)
+func (r *Response) HeadersCount() *Number {
+	opChain := r.chain.enter("HeadersCount()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	count := 0
+	for _, values := range r.headerLines() {
+		count += len(values)
+	}
+
+	return newNumber(opChain, float64(count))
+}
+
+// Header lines as they would be sent in HTTP/1.1 response, reconstructed
+// from parsed header map.
+func (r *Response) headerLines() http.Header {
+	lines := r.httpResp.Header
+
+	if len(r.httpResp.TransferEncoding) != 0 &&
+		r.httpResp.Header.Get("Transfer-Encoding") == "" {
+		lines = lines.Clone()
+		if lines == nil {
+			lines = http.Header{}
+		}
+		lines.Set("Transfer-Encoding", strings.Join(r.httpResp.TransferEncoding, ", "))
+	}
+
+	return lines
+}
+
+// TLS returns a new TLS instance with state of TLS connection over which
+// response was received.
+//
+// If response was not received over TLS, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.TLS().HasMinVersion(tls.VersionTLS12)
+//	resp.TLS().Certificate(0).IsValidFor(30 * 24 * time.Hour)
+func (r *Response) TLS() *TLS {
+	opChain := r.chain.enter("TLS()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newTLS(opChain, nil)
+	}
+
+	if r.httpResp.TLS == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{r.httpResp.TLS},
+			Errors: []error{
+				errors.New("expected: response is received over TLS"),
+			},
+		})
+		return newTLS(opChain, nil)
+	}
+
+	return newTLS(opChain, r.httpResp.TLS)
+}
+
+// HasRequestID succeeds if response contains the same request ID header
+// as the request, i.e. the server echoed request ID back.
+//
+// Header name is defined by Config.RequestIDHeader. If it's empty, or if
+// request doesn't have the header, failure is reported.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:         "http://example.com",
+//		Reporter:        httpexpect.NewAssertReporter(t),
+//		RequestIDHeader: "X-Request-Id",
+//	})
+//
+//	e.GET("/path").Expect().HasRequestID()
+func (r *Response) HasRequestID() *Response {
+	opChain := r.chain.enter("HasRequestID()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	header := r.config.RequestIDHeader
+	if header == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("Config.RequestIDHeader is empty"),
+			},
+		})
+		return r
+	}
+
+	var expected string
+	if r.httpResp.Request != nil {
+		expected = r.httpResp.Request.Header.Get(header)
+	}
+
+	if expected == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("request doesn't have %q header", header),
+			},
+		})
+		return r
+	}
+
+	actual := r.httpResp.Header.Get(header)
+
+	if actual != expected {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{expected},
+			Errors: []error{
+				fmt.Errorf("expected: response %q header is equal to request id",
+					header),
+			},
+		})
+	}
+
+	return r
+}
+
+// Timings returns a new Timings instance with durations of request phases:
+// DNS lookup, TCP connect, TLS handshake, time to first byte, and body
+// transfer.
+//
+// Timings are collected using httptrace hooks, so they're available only
+// for responses returned by Request.Expect, and only if client invokes
+// these hooks (http.Client does, Binder and FastBinder don't). Otherwise
+// failure is reported. If request was retried, timings correspond to the
+// last attempt. If request was redirected, phase durations correspond to
+// the last request in redirect chain, and total duration includes all of
+// them.
+//
+// If response body was not read yet, Timings reads it to measure transfer
+// duration. If body is not retained (see DisableBodyRewinds) and was not
+// read, transfer and total durations are zero.
+//
+// Example:
+//
+//	timings := e.GET("/path").Expect().Timings()
+//
+//	timings.DNS().Le(10 * time.Millisecond)
+//	timings.TTFB().Le(100 * time.Millisecond)
+//	timings.Total().Le(time.Second)
+func (r *Response) Timings() *Timings {
+	opChain := r.chain.enter("Timings()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newTimings(opChain, nil)
+	}
+
+	var timings *ResponseTimings
+
+	if r.timings != nil {
+		if r.contentState == contentPending && !r.isRewindDisabled {
+			if _, ok := r.getContent(opChain, "Timings()"); !ok {
+				return newTimings(opChain, nil)
+			}
+		}
+
+		timings = r.timings.durations()
+	}
+
+	if timings == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{timings},
+			Errors: []error{
+				errors.New("expected: timings are available for response"),
+			},
+		})
+		return newTimings(opChain, nil)
+	}
+
+	return newTimings(opChain, timings)
+}
+
+// ConnectionReused returns a new Boolean instance that is true if response
+// was received over a keep-alive connection reused from connection pool,
+// and false if new connection was established for the request.
+//
+// Information is collected using httptrace hooks, so it's available only
+// for responses returned by Request.Expect, and only if client invokes
+// these hooks (http.Client does, Binder and FastBinder don't). Otherwise
+// failure is reported. If request was redirected, the value corresponds
+// to the last request in redirect chain.
+//
+// Note that connection is returned to the pool only after response body
+// is fully read, so body of previous response should be retrieved before
+// sending the next request.
+//
+// Example:
+//
+//	resp := e.GET("/path").Expect()
+//	resp.ConnectionReused().IsFalse()
+//	resp.Body().IsEqual("ok")
+//
+//	resp = e.GET("/path").Expect()
+//	resp.ConnectionReused().IsTrue()
+func (r *Response) ConnectionReused() *Boolean {
+	opChain := r.chain.enter("ConnectionReused()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBoolean(opChain, false)
+	}
+
+	if r.connReused == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{r.connReused},
+			Errors: []error{
+				errors.New("expected: connection info is available for response"),
+			},
+		})
+		return newBoolean(opChain, false)
+	}
+
+	return newBoolean(opChain, *r.connReused)
+}
+
+// RedirectChain returns a new Array instance with intermediate redirect
+// responses that were followed before receiving this response.
+//
+// Every element is an Object with the following keys:
+//   - "url" - URL of request that received redirect response (String)
+//   - "status" - status code of redirect response (Number)
+//   - "location" - value of "Location" header (String)
+//   - "headers" - headers of redirect response (Object)
+//
+// Redirects are ordered as they were followed. Final response is not
+// included. If there were no redirects (e.g. DontFollowRedirects is used),
+// the array is empty.
+//
+// Redirects are recorded only if Client is *http.Client. Bodies of
+// intermediate responses are not available.
+//
+// Example:
+//
+//	resp := req.WithRedirectPolicy(FollowAllRedirects).Expect()
+//	chain := resp.RedirectChain()
+//	chain.Length().IsEqual(2)
+//	chain.Value(0).Object().HasValue("status", http.StatusMovedPermanently)
+//	chain.Value(0).Object().HasValue("location", "/v2/users")
+func (r *Response) RedirectChain() *Array {
+	opChain := r.chain.enter("RedirectChain()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	redirects := []interface{}{}
+	for _, resp := range r.redirects {
+		url := ""
+		if resp.Request != nil && resp.Request.URL != nil {
+			url = resp.Request.URL.String()
+		}
+
+		headers, _ := canonMap(opChain, resp.Header)
+		if headers == nil {
+			headers = map[string]interface{}{}
+		}
+
+		redirects = append(redirects, map[string]interface{}{
+			"url":      url,
+			"status":   resp.StatusCode,
+			"location": resp.Header.Get("Location"),
+			"headers":  headers,
+		})
+	}
+
+	return newArray(opChain, redirects)
+}
+
+// Cookies returns a new Array instance with all cookie names set by this response.
+// Returned Array contains a String value for every cookie name.
+//
+// Note that this returns only cookies set by Set-Cookie headers of this response.
+// It doesn't return session cookies from previous responses, which may be stored
+// in a cookie jar.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Cookies().Contains("session")
+func (r *Response) Cookies() *Array {
+	opChain := r.chain.enter("Cookies()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	names := []interface{}{}
+	for _, c := range r.cookies {
+		names = append(names, c.Name)
+	}
+
+	return newArray(opChain, names)
+}
+
+// Cookie returns a new Cookie instance with specified cookie from response.
+//
+// Note that this returns only cookies set by Set-Cookie headers of this response.
+// It doesn't return session cookies from previous responses, which may be stored
+// in a cookie jar.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Cookie("session").Domain().IsEqual("example.com")
+func (r *Response) Cookie(name string) *Cookie {
+	opChain := r.chain.enter("Cookie(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newCookie(opChain, nil)
+	}
+
+	var cookie *Cookie
+
+	names := []string{}
+	for _, c := range r.cookies {
+		if c.Name == name {
+			cookie = newCookie(opChain, c)
+			break
+		}
+		names = append(names, c.Name)
+	}
+
+	if cookie == nil {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{names},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				errors.New("expected: response contains cookie with given name"),
+			},
+		})
+		return newCookie(opChain, nil)
+	}
+
+	return cookie
+}
+
+// Websocket returns Websocket instance for interaction with WebSocket server.
+//
+// May be called only if the WithWebsocketUpgrade was called on the request.
+// That is responsibility of the caller to explicitly disconnect websocket after use.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithWebsocketUpgrade()
+//	ws := req.Expect().Websocket()
+//	defer ws.Disconnect()
+func (r *Response) Websocket() *Websocket {
+	opChain := r.chain.enter("Websocket()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newWebsocket(opChain, r.config, nil)
+	}
+
+	if r.websocket == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New(
+					"Websocket() requires WithWebsocketUpgrade() to be called on request"),
+			},
+		})
+		return newWebsocket(opChain, r.config, nil)
+	}
+
+	return newWebsocket(opChain, r.config, r.websocket)
+}
+
+// DisableBodyRewinds disables retaining response body in memory.
+//
+// By default, response body is read once and kept in memory, so that
+// multiple assertions (e.g. Body and JSON) can inspect it. After calling
+// DisableBodyRewinds, body can be read by only one assertion, and then it
+// is released. Subsequent assertions that need body report usage failure.
+//
+// If body was already read, it is released immediately.
+//
+// Useful for soak tests with huge responses. See also
+// Config.MaxBufferedBodySize.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.DisableBodyRewinds()
+//	resp.JSON().Array().Length().IsEqual(100000)
+func (r *Response) DisableBodyRewinds() *Response {
+	opChain := r.chain.enter("DisableBodyRewinds()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.isRewindDisabled = true
+
+	if bw, _ := r.httpResp.Body.(*bodyWrapper); bw != nil {
+		bw.DisableRewinds()
+	}
+
+	if r.contentState == contentRetreived {
+		r.content = nil
+		r.contentState = contentDiscarded
+	}
+
+	return r
+}
+
+// Reader returns the body reader from the response.
+//
+// This method is mutually exclusive with methods that read entire
+// response body, like Text, Body, JSON, etc. It can be used when
+// you need to parse body manually or retrieve infinite responses.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	reader := resp.Reader()
+func (r *Response) Reader() io.ReadCloser {
+	opChain := r.chain.enter("Reader()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return errBodyReader{errors.New("cannot read from failed Response")}
+	}
+
+	if r.contentState != contentPending {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("cannot call Reader() because %s was already called",
+					r.contentMethod),
+			},
+		})
+		return errBodyReader{errors.New("cannot read from failed Response")}
+	}
+
+	if bw, _ := r.httpResp.Body.(*bodyWrapper); bw != nil {
+		bw.DisableRewinds()
+	}
+
+	r.contentState = contentHijacked
+
+	return r.httpResp.Body
+}
+
+// Body returns a new String instance with response body.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Body().NotEmpty()
+//	resp.Body().Length().IsEqual(100)
+func (r *Response) Body() *String {
+	opChain := r.chain.enter("Body()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	content, ok := r.getContent(opChain, "Body()")
+	if !ok {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, string(content))
+}
+
+// ContentDisposition returns a new ContentDisposition instance with parsed
+// "Content-Disposition" header.
+//
+// If header is missing or can't be parsed, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.ContentDisposition().Filename().IsEqual("report.pdf")
+//	resp.Body().HasLength(1024).WriteToFile("report.pdf")
+func (r *Response) ContentDisposition() *ContentDisposition {
+	opChain := r.chain.enter("ContentDisposition()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newContentDisposition(opChain, "")
+	}
+
+	value := r.httpResp.Header.Get("Content-Disposition")
+
+	if value == "" {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{r.httpResp.Header},
+			Expected: &AssertionValue{"Content-Disposition"},
+			Errors: []error{
+				errors.New("expected: response has Content-Disposition header"),
+			},
+		})
+		return newContentDisposition(opChain, "")
+	}
+
+	return newContentDisposition(opChain, value)
+}
+
+// CORS returns a new CORS instance with response headers, which can be used
+// to inspect CORS headers of response.
+//
+// Example:
+//
+//	resp := e.OPTIONS("/users").
+//		WithCORSPreflight("https://app.example.com", "PUT", "Content-Type").
+//		Expect()
+//
+//	resp.CORS().
+//		AllowsOrigin("https://app.example.com").
+//		AllowsMethod("PUT").
+//		AllowsHeader("Content-Type")
+func (r *Response) CORS() *CORS {
+	opChain := r.chain.enter("CORS()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newCORS(opChain, http.Header{})
+	}
+
+	return newCORS(opChain, r.httpResp.Header)
+}
+
+// NoContent succeeds if response contains empty Content-Type header and
+// empty body.
+func (r *Response) NoContent() *Response {
+	opChain := r.chain.enter("NoContent()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	contentType := r.httpResp.Header.Get("Content-Type")
+	if !r.checkEqual(opChain, `"Content-Type" header`, "", contentType) {
+		return r
+	}
+
+	content, ok := r.getContent(opChain, "NoContent()")
+	if !ok {
+		return r
+	}
+	if !r.checkEqual(opChain, "body", "", string(content)) {
+		return r
+	}
+
+	return r
+}
+
+// NoBody succeeds if response has no body.
+//
+// Unlike Body().IsEmpty(), NoBody also fails if response declares a body,
+// even if it's empty: when Content-Length header is non-zero, or when
+// Transfer-Encoding is chunked. Content-Length is not checked for responses
+// to HEAD requests, which may declare length of the omitted body.
+//
+// Useful for responses like 204 No Content and 304 Not Modified, for which
+// any body is a bug.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Status(http.StatusNoContent).NoBody()
+func (r *Response) NoBody() *Response {
+	opChain := r.chain.enter("NoBody()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	isHead := r.httpResp.Request != nil && r.httpResp.Request.Method == http.MethodHead
+
+	if !isHead && r.httpResp.ContentLength > 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{r.httpResp.ContentLength},
+			Expected: &AssertionValue{int64(0)},
+			Errors: []error{
+				errors.New("expected: response has zero Content-Length"),
+			},
+		})
+		return r
+	}
+
+	for _, encoding := range r.httpResp.TransferEncoding {
+		if strings.EqualFold(encoding, "chunked") {
+			opChain.fail(AssertionFailure{
+				Type:     AssertNotContainsElement,
+				Actual:   &AssertionValue{r.httpResp.TransferEncoding},
+				Expected: &AssertionValue{"chunked"},
+				Errors: []error{
+					errors.New("expected: response has no chunked body"),
+				},
+			})
+			return r
+		}
+	}
+
+	content, ok := r.getContent(opChain, "NoBody()")
+	if !ok {
+		return r
+	}
+
+	if len(content) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertEmpty,
+			Actual: &AssertionValue{string(content)},
+			Errors: []error{
+				errors.New("expected: response has empty body"),
+			},
+		})
 	}
 
-	return newArray(opChain, names)
+	return r
 }
 
-// Cookie returns a new Cookie instance with specified cookie from response.
+// HasSHA256 succeeds if SHA-256 checksum of response body is equal to
+// given hex-encoded value. Comparison is case-insensitive.
 //
-// Note that this returns only cookies set by Set-Cookie headers of this response.
-// It doesn't return session cookies from previous responses, which may be stored
-// in a cookie jar.
+// Checksum is computed while reading body, and body is not retained by
+// this method, so it's suitable for large downloads, including bodies
+// exceeding Config.MaxBufferedBodySize.
 //
 // Example:
 //
 //	resp := NewResponse(t, response)
-//	resp.Cookie("session").Domain().IsEqual("example.com")
-func (r *Response) Cookie(name string) *Cookie {
-	opChain := r.chain.enter("Cookie(%q)", name)
+//	resp.HasSHA256(
+//		"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")
+func (r *Response) HasSHA256(checksum string) *Response {
+	opChain := r.chain.enter("HasSHA256()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return newCookie(opChain, nil)
-	}
-
-	var cookie *Cookie
-
-	names := []string{}
-	for _, c := range r.cookies {
-		if c.Name == name {
-			cookie = newCookie(opChain, c)
-			break
-		}
-		names = append(names, c.Name)
+		return r
 	}
 
-	if cookie == nil {
-		opChain.fail(AssertionFailure{
-			Type:     AssertContainsElement,
-			Actual:   &AssertionValue{names},
-			Expected: &AssertionValue{name},
-			Errors: []error{
-				errors.New("expected: response contains cookie with given name"),
-			},
-		})
-		return newCookie(opChain, nil)
-	}
+	r.checkChecksum(opChain, "HasSHA256()", "SHA-256", sha256.New(), checksum)
 
-	return cookie
+	return r
 }
 
-// Websocket returns Websocket instance for interaction with WebSocket server.
+// HasMD5 succeeds if MD5 checksum of response body is equal to given
+// hex-encoded value. Comparison is case-insensitive.
 //
-// May be called only if the WithWebsocketUpgrade was called on the request.
-// That is responsibility of the caller to explicitly disconnect websocket after use.
+// See HasSHA256 for details.
 //
 // Example:
 //
-//	req := NewRequestC(config, "GET", "/path")
-//	req.WithWebsocketUpgrade()
-//	ws := req.Expect().Websocket()
-//	defer ws.Disconnect()
-func (r *Response) Websocket() *Websocket {
-	opChain := r.chain.enter("Websocket()")
+//	resp := NewResponse(t, response)
+//	resp.HasMD5("5d41402abc4b2a76b9719d911017c592")
+func (r *Response) HasMD5(checksum string) *Response {
+	opChain := r.chain.enter("HasMD5()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return newWebsocket(opChain, r.config, nil)
+		return r
 	}
 
-	if r.websocket == nil {
-		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
-			Errors: []error{
-				errors.New(
-					"Websocket() requires WithWebsocketUpgrade() to be called on request"),
-			},
-		})
-		return newWebsocket(opChain, r.config, nil)
-	}
+	r.checkChecksum(opChain, "HasMD5()", "MD5", md5.New(), checksum)
 
-	return newWebsocket(opChain, r.config, r.websocket)
+	return r
 }
 
-// Reader returns the body reader from the response.
+// HasCRC32 succeeds if CRC-32 (IEEE) checksum of response body is equal
+// to given hex-encoded value, e.g. "3610a686". Comparison is
+// case-insensitive.
 //
-// This method is mutually exclusive with methods that read entire
-// response body, like Text, Body, JSON, etc. It can be used when
-// you need to parse body manually or retrieve infinite responses.
+// See HasSHA256 for details.
 //
 // Example:
 //
 //	resp := NewResponse(t, response)
-//	reader := resp.Reader()
-func (r *Response) Reader() io.ReadCloser {
-	opChain := r.chain.enter("Reader()")
+//	resp.HasCRC32("3610a686")
+func (r *Response) HasCRC32(checksum string) *Response {
+	opChain := r.chain.enter("HasCRC32()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return errBodyReader{errors.New("cannot read from failed Response")}
+		return r
 	}
 
-	if r.contentState != contentPending {
+	r.checkChecksum(opChain, "HasCRC32()", "CRC-32", crc32.NewIEEE(), checksum)
+
+	return r
+}
+
+func (r *Response) checkChecksum(
+	opChain *chain, method string, name string, h hash.Hash, expected string,
+) {
+	expectedBytes, err := hex.DecodeString(expected)
+	if err != nil || len(expectedBytes) != h.Size() {
 		opChain.fail(AssertionFailure{
 			Type: AssertUsage,
 			Errors: []error{
-				fmt.Errorf("cannot call Reader() because %s was already called",
-					r.contentMethod),
+				fmt.Errorf("invalid %s checksum %q: expected %d hex-encoded bytes",
+					name, expected, h.Size()),
 			},
 		})
-		return errBodyReader{errors.New("cannot read from failed Response")}
+		return
 	}
 
-	if bw, _ := r.httpResp.Body.(*bodyWrapper); bw != nil {
-		bw.DisableRewinds()
+	if !r.streamContent(opChain, method, h) {
+		return
 	}
 
-	r.contentState = contentHijacked
+	actual := hex.EncodeToString(h.Sum(nil))
 
-	return r.httpResp.Body
+	if actual != strings.ToLower(expected) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{strings.ToLower(expected)},
+			Errors: []error{
+				fmt.Errorf("expected: response body %s checksum is equal to given value",
+					name),
+			},
+		})
+	}
 }
 
-// Body returns a new String instance with response body.
+// NoContentType succeeds if response has no Content-Type header.
 //
 // Example:
 //
 //	resp := NewResponse(t, response)
-//	resp.Body().NotEmpty()
-//	resp.Body().Length().IsEqual(100)
-func (r *Response) Body() *String {
-	opChain := r.chain.enter("Body()")
+//	resp.NoContentType()
+func (r *Response) NoContentType() *Response {
+	opChain := r.chain.enter("NoContentType()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return newString(opChain, "")
+		return r
 	}
 
-	content, ok := r.getContent(opChain, "Body()")
-	if !ok {
-		return newString(opChain, "")
+	if values, ok := r.httpResp.Header["Content-Type"]; ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{values},
+			Expected: &AssertionValue{"Content-Type"},
+			Errors: []error{
+				errors.New(`expected: response has no "Content-Type" header`),
+			},
+		})
 	}
 
-	return newString(opChain, string(content))
+	return r
 }
 
-// NoContent succeeds if response contains empty Content-Type header and
-// empty body.
-func (r *Response) NoContent() *Response {
-	opChain := r.chain.enter("NoContent()")
+// BodyIsNotJSON succeeds if response body is not a valid JSON document.
+// Empty body is not a valid JSON document. Content-Type header is ignored.
+//
+// Useful for endpoints that must not leak JSON error objects, e.g.
+// plain text or HTML error pages.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.BodyIsNotJSON()
+func (r *Response) BodyIsNotJSON() *Response {
+	opChain := r.chain.enter("BodyIsNotJSON()")
 	defer opChain.leave()
 
 	if opChain.failed() {
 		return r
 	}
 
-	contentType := r.httpResp.Header.Get("Content-Type")
-	if !r.checkEqual(opChain, `"Content-Type" header`, "", contentType) {
-		return r
-	}
-
-	content, ok := r.getContent(opChain, "NoContent()")
+	content, ok := r.getContent(opChain, "BodyIsNotJSON()")
 	if !ok {
 		return r
 	}
-	if !r.checkEqual(opChain, "body", "", string(content)) {
-		return r
+
+	if json.Valid(content) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{string(content)},
+			Errors: []error{
+				errors.New("expected: response body is not valid json"),
+			},
+		})
 	}
 
 	return r
@@ -892,6 +1961,95 @@ func (r *Response) JSON(options ...ContentOpts) *Value {
 	return newValue(opChain, value)
 }
 
+// JSONAPI returns a new JSONAPI instance with JSON:API document decoded
+// from response body.
+//
+// JSONAPI succeeds if response contains "application/vnd.api+json"
+// Content-Type header with empty or "utf-8" charset, and if body is a valid
+// JSON:API document. Expected media type may be overridden using options.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.JSONAPI().Resource("articles", "1").
+//		Path("$.attributes.title").String().IsEqual("Hello")
+func (r *Response) JSONAPI(options ...ContentOpts) *JSONAPI {
+	opChain := r.chain.enter("JSONAPI()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newJSONAPI(opChain, nil)
+	}
+
+	opts, ok := r.mediaTypeOptions(opChain, options, "application/vnd.api+json")
+	if !ok {
+		return newJSONAPI(opChain, nil)
+	}
+
+	value := r.getJSON(opChain, "JSONAPI()", opts)
+
+	return newJSONAPI(opChain, value)
+}
+
+// HAL returns a new HAL instance with HAL resource decoded from
+// response body.
+//
+// HAL succeeds if response contains "application/hal+json" Content-Type
+// header with empty or "utf-8" charset, and if body is a JSON object.
+// Expected media type may be overridden using options.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HAL().Href("self").IsEqual("/orders/123")
+//	resp.HAL(ContentOpts{
+//		MediaType: "application/json",
+//	}).Embedded().ContainsKey("items")
+func (r *Response) HAL(options ...ContentOpts) *HAL {
+	opChain := r.chain.enter("HAL()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newHAL(opChain, nil)
+	}
+
+	opts, ok := r.mediaTypeOptions(opChain, options, "application/hal+json")
+	if !ok {
+		return newHAL(opChain, nil)
+	}
+
+	value := r.getJSON(opChain, "HAL()", opts)
+
+	return newHAL(opChain, value)
+}
+
+// Returns options with given default media type, unless it's overridden
+// by user options.
+func (r *Response) mediaTypeOptions(
+	opChain *chain, options []ContentOpts, mediaType string,
+) (ContentOpts, bool) {
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return ContentOpts{}, false
+	}
+
+	opts := ContentOpts{MediaType: mediaType}
+
+	if len(options) != 0 {
+		if options[0].MediaType != "" {
+			opts.MediaType = options[0].MediaType
+		}
+		opts.Charset = options[0].Charset
+	}
+
+	return opts, true
+}
+
 func (r *Response) getJSON(
 	opChain *chain, method string, options ...ContentOpts,
 ) interface{} {
@@ -1017,6 +2175,143 @@ func (r *Response) getJSONP(
 	return value
 }
 
+// S3Error returns a new Object instance with fields of S3-style XML error
+// envelope decoded from response body.
+//
+// S3Error succeeds if response contains "application/xml" Content-Type header
+// with empty or "utf-8" charset, and response body has the following form:
+//
+//	<Error>
+//	  <Code>NoSuchKey</Code>
+//	  <Message>The specified key does not exist.</Message>
+//	  <RequestId>4442587FB7D0A2F9</RequestId>
+//	</Error>
+//
+// This form is used by AWS S3, Google Cloud Storage XML API, and other
+// object storage services with S3-compatible API.
+//
+// Returned object maps names of child elements of <Error> element to their
+// text content, e.g. "Code", "Message", "RequestId", "HostId", "Resource".
+// "Code" element is required; if it's missing or empty, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.S3Error().Value("Code").IsEqual("NoSuchKey")
+//	resp.S3Error().Value("RequestId").String().NotEmpty()
+//	resp.S3Error(ContentOpts{
+//	  MediaType: "text/xml",
+//	}).Value("Code").IsEqual("AccessDenied")
+func (r *Response) S3Error(options ...ContentOpts) *Object {
+	opChain := r.chain.enter("S3Error()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	object := r.getS3Error(opChain, "S3Error()", options...)
+
+	return newObject(opChain, object)
+}
+
+func (r *Response) getS3Error(
+	opChain *chain, method string, options ...ContentOpts,
+) map[string]interface{} {
+	if !r.checkContentOptions(opChain, options, "application/xml") {
+		return nil
+	}
+
+	content, ok := r.getContent(opChain, method)
+	if !ok {
+		return nil
+	}
+
+	object, err := decodeXMLEnvelope(content, "Error")
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(content),
+			},
+			Errors: []error{
+				errors.New("failed to decode xml error envelope"),
+				err,
+			},
+		})
+		return nil
+	}
+
+	if code, _ := object["Code"].(string); code == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(content),
+			},
+			Errors: []error{
+				errors.New("expected: xml error envelope with non-empty <Code> element"),
+			},
+		})
+		return nil
+	}
+
+	return object
+}
+
+// Decode XML document with given root element into a map from names
+// of child elements to their text content.
+func decodeXMLEnvelope(content []byte, root string) (map[string]interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	var start xml.StartElement
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if se, ok := tok.(xml.StartElement); ok {
+			start = se
+			break
+		}
+	}
+
+	if start.Name.Local != root {
+		return nil, fmt.Errorf("unexpected root element <%s>, expected <%s>",
+			start.Name.Local, root)
+	}
+
+	object := map[string]interface{}{}
+
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var text string
+			if err := decoder.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			object[t.Name.Local] = strings.TrimSpace(text)
+
+		case xml.EndElement:
+			return object, nil
+		}
+	}
+}
+
 func (r *Response) checkContentOptions(
 	opChain *chain, options []ContentOpts, expectedType string, expectedCharset ...string,
 ) bool {
@@ -0,0 +1,366 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ResponseCache is an in-memory client-side cache of GET and HEAD responses,
+// which can be shared by Expect instances using Config.ResponseCache.
+//
+// Responses with status 200 and ETag or Last-Modified header are stored.
+// When the same URL is requested again with the same method, cache sends
+// conditional request with If-None-Match and If-Modified-Since headers, and
+// if server responds with "304 Not Modified", returns stored response
+// instead. This is transparent for tests, which see the full response.
+//
+// Requests with other methods, requests with body, Range, If-None-Match,
+// or If-Modified-Since headers, or "Cache-Control: no-store" bypass cache.
+// Stored response is used only if request headers listed in its Vary
+// header, and Authorization header, are the same.
+//
+// Cache key and Vary headers are taken from the request as it will be sent:
+// Host override set by WithHost is part of the key, and cookies that
+// http.Client adds from its Jar are included into Cookie header. Cookies
+// added by other Client implementations are not visible to cache.
+//
+// To be stored, response body is read into memory when response is received.
+// If Config.MaxBufferedBodySize is set and body exceeds it, response is not
+// stored, and its body is streamed to the test as usual.
+//
+// Cache is safe for concurrent use.
+//
+// Example:
+//
+//	cache := httpexpect.NewResponseCache()
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:       "https://staging.example.com",
+//		Reporter:      httpexpect.NewAssertReporter(t),
+//		ResponseCache: cache,
+//	})
+//
+//	e.GET("/catalog").Expect().Status(http.StatusOK)
+//	e.GET("/catalog").Expect().Status(http.StatusOK)
+//
+//	assert.Equal(t, 1, cache.Hits())
+type ResponseCache struct {
+	mu      sync.Mutex
+	entries map[string]*responseCacheEntry
+	hits    int
+	misses  int
+}
+
+type responseCacheEntry struct {
+	status     string
+	statusCode int
+	header     http.Header
+	body       []byte
+	vary       http.Header
+}
+
+// NewResponseCache returns a new empty ResponseCache instance.
+func NewResponseCache() *ResponseCache {
+	return &ResponseCache{
+		entries: make(map[string]*responseCacheEntry),
+	}
+}
+
+// Hits returns number of requests served from cache, i.e. requests for which
+// server responded with "304 Not Modified".
+func (c *ResponseCache) Hits() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hits
+}
+
+// Misses returns number of cacheable requests which were not served from
+// cache, because there was no stored response, or resource was changed.
+func (c *ResponseCache) Misses() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.misses
+}
+
+// Len returns number of stored responses.
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// Reset removes all stored responses and resets counters.
+func (c *ResponseCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*responseCacheEntry)
+	c.hits = 0
+	c.misses = 0
+}
+
+// Send request using client, serving it from cache if possible.
+// Responses with body larger than maxSize are not stored, unless
+// maxSize is zero.
+func (c *ResponseCache) do(
+	client Client, req *http.Request, maxSize int64,
+) (*http.Response, error) {
+	if !isCacheableRequest(req) {
+		return client.Do(req)
+	}
+
+	key, header := outgoingRequest(client, req)
+
+	c.mu.Lock()
+	entry := c.entries[key]
+	if entry != nil && !entry.matches(header) {
+		entry = nil
+	}
+	c.mu.Unlock()
+
+	sentReq := req
+	if entry != nil {
+		sentReq = req.Clone(req.Context())
+		if etag := entry.header.Get("ETag"); etag != "" {
+			sentReq.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.header.Get("Last-Modified"); lastModified != "" {
+			sentReq.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := client.Do(sentReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry != nil && resp.StatusCode == http.StatusNotModified {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+
+		c.mu.Lock()
+		c.hits++
+		entry.update(resp.Header)
+		cachedResp := entry.response(req, resp)
+		c.mu.Unlock()
+
+		return cachedResp, nil
+	}
+
+	c.mu.Lock()
+	c.misses++
+	delete(c.entries, key)
+	c.mu.Unlock()
+
+	if !isCacheableResponse(resp) {
+		return resp, nil
+	}
+
+	if maxSize > 0 && req.Method != http.MethodHead && resp.ContentLength > maxSize {
+		return resp, nil
+	}
+
+	body, complete, err := readCacheableBody(resp.Body, maxSize)
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	if !complete {
+		// already read part is prepended to the rest of body
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{
+			io.MultiReader(bytes.NewReader(body), resp.Body),
+			resp.Body,
+		}
+		return resp, nil
+	}
+
+	_ = resp.Body.Close()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	entry = &responseCacheEntry{
+		status:     resp.Status,
+		statusCode: resp.StatusCode,
+		header:     resp.Header.Clone(),
+		body:       body,
+		vary:       make(http.Header),
+	}
+
+	for _, name := range varyHeaders(resp.Header) {
+		entry.vary[name] = header.Values(name)
+	}
+
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	return resp, nil
+}
+
+// Returns cache key and headers of request as they will be sent by client:
+// with Host override, and with cookies added from jar of http.Client.
+func outgoingRequest(client Client, req *http.Request) (string, http.Header) {
+	host := req.URL.Host
+	if req.Host != "" {
+		host = req.Host
+	}
+
+	key := req.Method + " " + req.URL.String()
+	if host != req.URL.Host {
+		key += " (Host: " + host + ")"
+	}
+
+	header := req.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set("Host", host)
+
+	if httpClient, ok := client.(*http.Client); ok && httpClient.Jar != nil {
+		// the same as http.Client does before sending request
+		jarReq := &http.Request{Header: header}
+		for _, cookie := range httpClient.Jar.Cookies(req.URL) {
+			jarReq.AddCookie(cookie)
+		}
+	}
+
+	return key, header
+}
+
+// Read body, but no more than maxSize bytes, unless maxSize is zero.
+// Returns false if body is larger than maxSize.
+func readCacheableBody(body io.Reader, maxSize int64) ([]byte, bool, error) {
+	if maxSize <= 0 {
+		b, err := io.ReadAll(body)
+		return b, true, err
+	}
+
+	b, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		return nil, false, err
+	}
+
+	return b, int64(len(b)) <= maxSize, nil
+}
+
+func isCacheableRequest(req *http.Request) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		return false
+	}
+
+	for _, name := range []string{"Range", "If-None-Match", "If-Modified-Since"} {
+		if req.Header.Get(name) != "" {
+			return false
+		}
+	}
+
+	return !hasCacheDirective(req.Header, "no-store")
+}
+
+func isCacheableResponse(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+
+	if resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" {
+		return false
+	}
+
+	for _, name := range varyHeaders(resp.Header) {
+		if name == "*" {
+			return false
+		}
+	}
+
+	return !hasCacheDirective(resp.Header, "no-store")
+}
+
+// Returns canonical names of headers listed in Vary header, and Authorization.
+func varyHeaders(header http.Header) []string {
+	names := []string{"Authorization"}
+
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+
+	return names
+}
+
+func hasCacheDirective(header http.Header, directive string) bool {
+	for _, value := range header.Values("Cache-Control") {
+		for _, d := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(d), directive) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (e *responseCacheEntry) matches(header http.Header) bool {
+	for name, values := range e.vary {
+		if strings.Join(values, ",") != strings.Join(header.Values(name), ",") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Update stored headers from "304 Not Modified" response.
+func (e *responseCacheEntry) update(header http.Header) {
+	for name, values := range header {
+		if name == "Content-Length" {
+			continue
+		}
+		e.header[name] = values
+	}
+}
+
+// Build response from stored entry.
+func (e *responseCacheEntry) response(
+	req *http.Request, notModified *http.Response,
+) *http.Response {
+	return &http.Response{
+		Status:        e.status,
+		StatusCode:    e.statusCode,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+		TLS:           notModified.TLS,
+	}
+}
+
+// Send HTTP request using Config.Client, through Config.ResponseCache if set.
+func (r *Request) doRequest(httpReq *http.Request) (*http.Response, error) {
+	if r.config.ResponseCache != nil {
+		return r.config.ResponseCache.do(
+			r.config.Client, httpReq, r.config.MaxBufferedBodySize)
+	}
+
+	return r.config.Client.Do(httpReq)
+}
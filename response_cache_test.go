@@ -0,0 +1,394 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cacheTestServer struct {
+	etag         string
+	lastModified string
+	vary         string
+	cacheControl string
+	body         string
+	full         int
+	notModified  int
+}
+
+func (s *cacheTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.etag != "" {
+		w.Header().Set("ETag", s.etag)
+	}
+	if s.lastModified != "" {
+		w.Header().Set("Last-Modified", s.lastModified)
+	}
+	if s.vary != "" {
+		w.Header().Set("Vary", s.vary)
+	}
+	if s.cacheControl != "" {
+		w.Header().Set("Cache-Control", s.cacheControl)
+	}
+
+	if (s.etag != "" && r.Header.Get("If-None-Match") == s.etag) ||
+		(s.lastModified != "" && r.Header.Get("If-Modified-Since") == s.lastModified) {
+		s.notModified++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	s.full++
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(s.body))
+}
+
+func TestResponseCache_Revalidation(t *testing.T) {
+	newExpect := func(
+		t *testing.T, server *cacheTestServer, cache *ResponseCache,
+	) *Expect {
+		return WithConfig(Config{
+			BaseURL:       "http://example.com",
+			Reporter:      newMockReporter(t),
+			ResponseCache: cache,
+			Client: &http.Client{
+				Transport: NewBinder(server),
+			},
+		})
+	}
+
+	t.Run("etag", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, body: "hello"}
+		cache := NewResponseCache()
+		e := newExpect(t, server, cache)
+
+		for i := 0; i < 3; i++ {
+			resp := e.GET("/").Expect().Status(http.StatusOK)
+			resp.Header("ETag").IsEqual(`"v1"`)
+			resp.Body().IsEqual("hello")
+		}
+
+		assert.Equal(t, 1, server.full)
+		assert.Equal(t, 2, server.notModified)
+		assert.Equal(t, 2, cache.Hits())
+		assert.Equal(t, 1, cache.Misses())
+		assert.Equal(t, 1, cache.Len())
+	})
+
+	t.Run("last-modified", func(t *testing.T) {
+		server := &cacheTestServer{
+			lastModified: "Wed, 21 Oct 2015 07:28:00 GMT",
+			body:         "hello",
+		}
+		cache := NewResponseCache()
+		e := newExpect(t, server, cache)
+
+		e.GET("/").Expect().Body().IsEqual("hello")
+		e.GET("/").Expect().Body().IsEqual("hello")
+
+		assert.Equal(t, 1, server.full)
+		assert.Equal(t, 1, cache.Hits())
+	})
+
+	t.Run("changed resource", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, body: "hello"}
+		cache := NewResponseCache()
+		e := newExpect(t, server, cache)
+
+		e.GET("/").Expect().Body().IsEqual("hello")
+
+		server.etag = `"v2"`
+		server.body = "world"
+
+		e.GET("/").Expect().Body().IsEqual("world")
+		e.GET("/").Expect().Body().IsEqual("world")
+
+		assert.Equal(t, 2, server.full)
+		assert.Equal(t, 1, cache.Hits())
+		assert.Equal(t, 2, cache.Misses())
+	})
+
+	t.Run("head", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, body: "hello"}
+		cache := NewResponseCache()
+		e := newExpect(t, server, cache)
+
+		e.HEAD("/").Expect().Status(http.StatusOK)
+		e.HEAD("/").Expect().Status(http.StatusOK).
+			Header("ETag").IsEqual(`"v1"`)
+
+		// HEAD and GET responses are stored separately
+		e.GET("/").Expect().Body().IsEqual("hello")
+
+		assert.Equal(t, 2, server.full)
+		assert.Equal(t, 1, cache.Hits())
+		assert.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("different urls", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, body: "hello"}
+		cache := NewResponseCache()
+		e := newExpect(t, server, cache)
+
+		e.GET("/a").Expect()
+		e.GET("/b").Expect()
+		e.GET("/a").WithQuery("x", 1).Expect()
+
+		assert.Equal(t, 3, server.full)
+		assert.Equal(t, 0, cache.Hits())
+		assert.Equal(t, 3, cache.Len())
+	})
+
+	t.Run("vary", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, vary: "Accept-Language", body: "hi"}
+		cache := NewResponseCache()
+		e := newExpect(t, server, cache)
+
+		e.GET("/").WithHeader("Accept-Language", "en").Expect()
+		e.GET("/").WithHeader("Accept-Language", "de").Expect()
+		e.GET("/").WithHeader("Accept-Language", "de").Expect()
+
+		assert.Equal(t, 2, server.full)
+		assert.Equal(t, 1, cache.Hits())
+	})
+
+	t.Run("vary cookie from jar", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, vary: "Cookie", body: "hi"}
+		cache := NewResponseCache()
+
+		jar := NewCookieJar()
+		e := WithConfig(Config{
+			BaseURL:       "http://example.com",
+			Reporter:      newMockReporter(t),
+			ResponseCache: cache,
+			Client: &http.Client{
+				Transport: NewBinder(server),
+				Jar:       jar,
+			},
+		})
+
+		e.GET("/").Expect()
+
+		u, err := url.Parse("http://example.com/")
+		require.NoError(t, err)
+		jar.SetCookies(u, []*http.Cookie{{Name: "session", Value: "john"}})
+
+		e.GET("/").Expect()
+		e.GET("/").Expect()
+
+		assert.Equal(t, 2, server.full)
+		assert.Equal(t, 1, cache.Hits())
+	})
+
+	t.Run("host", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, body: "hi"}
+		cache := NewResponseCache()
+		e := newExpect(t, server, cache)
+
+		e.GET("/").WithHost("a.example.com").Expect()
+		e.GET("/").WithHost("b.example.com").Expect()
+		e.GET("/").Expect()
+		e.GET("/").WithHost("b.example.com").Expect()
+
+		assert.Equal(t, 3, server.full)
+		assert.Equal(t, 1, cache.Hits())
+		assert.Equal(t, 3, cache.Len())
+	})
+
+	t.Run("authorization", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, body: "hi"}
+		cache := NewResponseCache()
+		e := newExpect(t, server, cache)
+
+		e.GET("/").WithBasicAuth("john", "secret").Expect()
+		e.GET("/").WithBasicAuth("bob", "secret").Expect()
+
+		assert.Equal(t, 2, server.full)
+		assert.Equal(t, 0, cache.Hits())
+	})
+
+	t.Run("shared between instances", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, body: "hi"}
+		cache := NewResponseCache()
+
+		newExpect(t, server, cache).GET("/").Expect()
+		newExpect(t, server, cache).GET("/").Expect().Body().IsEqual("hi")
+
+		assert.Equal(t, 1, cache.Hits())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, body: "hi"}
+		cache := NewResponseCache()
+		e := newExpect(t, server, cache)
+
+		e.GET("/").Expect()
+		e.GET("/").Expect()
+
+		cache.Reset()
+		assert.Equal(t, 0, cache.Hits())
+		assert.Equal(t, 0, cache.Misses())
+		assert.Equal(t, 0, cache.Len())
+
+		e.GET("/").Expect()
+		assert.Equal(t, 2, server.full)
+	})
+}
+
+func TestResponseCache_Bypass(t *testing.T) {
+	cases := []struct {
+		name   string
+		server *cacheTestServer
+		req    func(e *Expect) *Request
+	}{
+		{
+			name:   "no validators",
+			server: &cacheTestServer{body: "hi"},
+			req: func(e *Expect) *Request {
+				return e.GET("/")
+			},
+		},
+		{
+			name:   "response no-store",
+			server: &cacheTestServer{etag: `"v1"`, cacheControl: "private, no-store"},
+			req: func(e *Expect) *Request {
+				return e.GET("/")
+			},
+		},
+		{
+			name:   "vary star",
+			server: &cacheTestServer{etag: `"v1"`, vary: "*"},
+			req: func(e *Expect) *Request {
+				return e.GET("/")
+			},
+		},
+		{
+			name:   "request no-store",
+			server: &cacheTestServer{etag: `"v1"`},
+			req: func(e *Expect) *Request {
+				return e.GET("/").WithHeader("Cache-Control", "no-store")
+			},
+		},
+		{
+			name:   "range",
+			server: &cacheTestServer{etag: `"v1"`},
+			req: func(e *Expect) *Request {
+				return e.GET("/").WithHeader("Range", "bytes=0-1")
+			},
+		},
+		{
+			name:   "post",
+			server: &cacheTestServer{etag: `"v1"`},
+			req: func(e *Expect) *Request {
+				return e.POST("/").WithText("data")
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := NewResponseCache()
+
+			e := WithConfig(Config{
+				BaseURL:       "http://example.com",
+				Reporter:      newMockReporter(t),
+				ResponseCache: cache,
+				Client: &http.Client{
+					Transport: NewBinder(tc.server),
+				},
+			})
+
+			tc.req(e).Expect().Status(http.StatusOK)
+			tc.req(e).Expect().Status(http.StatusOK)
+
+			assert.Equal(t, 2, tc.server.full)
+			assert.Equal(t, 0, cache.Hits())
+			assert.Equal(t, 0, cache.Len())
+		})
+	}
+
+	t.Run("user validators", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, body: "hi"}
+		cache := NewResponseCache()
+
+		e := WithConfig(Config{
+			BaseURL:       "http://example.com",
+			Reporter:      newMockReporter(t),
+			ResponseCache: cache,
+			Client: &http.Client{
+				Transport: NewBinder(server),
+			},
+		})
+
+		e.GET("/").Expect().Status(http.StatusOK)
+
+		e.GET("/").WithIfNoneMatch(`"v1"`).Expect().
+			Status(http.StatusNotModified)
+
+		assert.Equal(t, 0, cache.Hits())
+	})
+}
+
+func TestResponseCache_MaxBodySize(t *testing.T) {
+	newExpect := func(
+		t *testing.T, client Client, cache *ResponseCache,
+	) *Expect {
+		return WithConfig(Config{
+			BaseURL:             "http://example.com",
+			Reporter:            newMockReporter(t),
+			ResponseCache:       cache,
+			MaxBufferedBodySize: 5,
+			Client:              client,
+		})
+	}
+
+	t.Run("within limit", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, body: "hello"}
+		cache := NewResponseCache()
+		e := newExpect(t, &http.Client{Transport: NewBinder(server)}, cache)
+
+		e.GET("/").Expect().Body().IsEqual("hello")
+		e.GET("/").Expect().Body().IsEqual("hello")
+
+		assert.Equal(t, 1, server.full)
+		assert.Equal(t, 1, cache.Hits())
+		assert.Equal(t, 1, cache.Len())
+	})
+
+	t.Run("content length exceeds limit", func(t *testing.T) {
+		server := &cacheTestServer{etag: `"v1"`, body: "hello world"}
+		cache := NewResponseCache()
+		e := newExpect(t, &http.Client{Transport: NewBinder(server)}, cache)
+
+		e.GET("/").Expect().Body().IsEqual("hello world")
+		e.GET("/").Expect().Body().IsEqual("hello world")
+
+		assert.Equal(t, 2, server.full)
+		assert.Equal(t, 0, cache.Hits())
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("unknown length exceeds limit", func(t *testing.T) {
+		body := newMockBody("hello world")
+
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				Header:        http.Header{"Etag": {`"v1"`}},
+				ContentLength: -1,
+				Body:          body,
+				Request:       req,
+			}, nil
+		})
+
+		cache := NewResponseCache()
+		e := newExpect(t, client, cache)
+
+		e.GET("/").Expect().Body().IsEqual("hello world")
+
+		assert.Equal(t, 0, cache.Len())
+		assert.Equal(t, 1, body.closeCount)
+	})
+}
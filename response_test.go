@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"bytes"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -19,24 +20,58 @@ func TestResponse_FailedChain(t *testing.T) {
 		resp.chain.assert(t, failure)
 
 		resp.Alias("foo")
+		resp.DisableBodyRewinds()
 
 		resp.RoundTripTime().chain.assert(t, failure)
 		resp.Duration().chain.assert(t, failure)
 		resp.Headers().chain.assert(t, failure)
 		resp.Header("foo").chain.assert(t, failure)
+		resp.HeadersSize().chain.assert(t, failure)
+		resp.HeadersCount().chain.assert(t, failure)
+		resp.TLS().chain.assert(t, failure)
+		resp.HasRequestID()
+		resp.Timings().chain.assert(t, failure)
+		resp.ConnectionReused().chain.assert(t, failure)
+		resp.RedirectChain().chain.assert(t, failure)
+		resp.ExpectNotModifiedWhenRevalidated().chain.assert(t, failure)
 		resp.Cookies().chain.assert(t, failure)
 		resp.Cookie("foo").chain.assert(t, failure)
 		resp.Body().chain.assert(t, failure)
+		resp.ContentDisposition().chain.assert(t, failure)
+		resp.CORS().chain.assert(t, failure)
+		resp.Links().chain.assert(t, failure)
+		resp.Follow("next").chain.assert(t, failure)
 		resp.Text().chain.assert(t, failure)
 		resp.Form().chain.assert(t, failure)
 		resp.JSON().chain.assert(t, failure)
+		resp.JSONAPI().chain.assert(t, failure)
+		resp.HAL().chain.assert(t, failure)
 		resp.JSONP("").chain.assert(t, failure)
+		resp.S3Error().chain.assert(t, failure)
 		resp.Websocket().chain.assert(t, failure)
 
+		resp.SnapshotValue()
+		resp.MatchSnapshot("foo")
+
+		resp.StatusText().chain.assert(t, failure)
+
 		resp.Status(123)
+		resp.Status(123, 456)
 		resp.StatusRange(Status2xx)
+		resp.StatusIsInformational()
+		resp.StatusIsSuccess()
+		resp.StatusIsRedirection()
+		resp.StatusIsClientError()
+		resp.StatusIsServerError()
 		resp.StatusList(http.StatusOK, http.StatusBadGateway)
+		resp.ProtoIs("HTTP/1.1")
 		resp.NoContent()
+		resp.NoBody()
+		resp.HasSHA256("")
+		resp.HasMD5("")
+		resp.HasCRC32("")
+		resp.NoContentType()
+		resp.BodyIsNotJSON()
 		resp.HasContentType("", "")
 		resp.HasContentEncoding("")
 		resp.HasTransferEncoding("")
@@ -193,6 +228,124 @@ func TestResponse_Status(t *testing.T) {
 	}
 }
 
+func TestResponse_StatusOneOf(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cases := []struct {
+		status int
+		oneOf  []int
+		result chainResult
+	}{
+		{http.StatusOK, []int{http.StatusOK, http.StatusNoContent}, success},
+		{http.StatusNoContent, []int{http.StatusOK, http.StatusNoContent}, success},
+		{http.StatusCreated, []int{http.StatusOK, http.StatusNoContent}, failure},
+	}
+
+	for _, tc := range cases {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: tc.status,
+		})
+
+		resp.Status(tc.oneOf[0], tc.oneOf[1:]...)
+		resp.chain.assert(t, tc.result)
+	}
+}
+
+func TestResponse_StatusText(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		status     string
+		expected   string
+	}{
+		{
+			name:       "from status line",
+			statusCode: http.StatusNotFound,
+			status:     "404 Not Found",
+			expected:   "Not Found",
+		},
+		{
+			name:       "custom reason phrase",
+			statusCode: http.StatusNotFound,
+			status:     "404 No Such Thing",
+			expected:   "No Such Thing",
+		},
+		{
+			name:       "empty status line",
+			statusCode: http.StatusNotFound,
+			status:     "",
+			expected:   "Not Found",
+		},
+		{
+			name:       "mismatched status line",
+			statusCode: http.StatusOK,
+			status:     "404 Not Found",
+			expected:   "OK",
+		},
+		{
+			name:       "unknown status code",
+			statusCode: 599,
+			status:     "",
+			expected:   "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: tc.statusCode,
+				Status:     tc.status,
+			})
+
+			resp.StatusText().IsEqual(tc.expected)
+			resp.chain.assert(t, success)
+		})
+	}
+}
+
+func TestResponse_StatusClass(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	checks := map[StatusRange]func(*Response) *Response{
+		Status1xx: (*Response).StatusIsInformational,
+		Status2xx: (*Response).StatusIsSuccess,
+		Status3xx: (*Response).StatusIsRedirection,
+		Status4xx: (*Response).StatusIsClientError,
+		Status5xx: (*Response).StatusIsServerError,
+	}
+
+	cases := []struct {
+		status      int
+		statusRange StatusRange
+	}{
+		{99, StatusRange(-1)},
+		{100, Status1xx},
+		{204, Status2xx},
+		{302, Status3xx},
+		{404, Status4xx},
+		{503, Status5xx},
+		{600, StatusRange(-1)},
+	}
+
+	for _, tc := range cases {
+		for rn, check := range checks {
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: tc.status,
+			})
+
+			check(resp)
+
+			if tc.statusRange == rn {
+				resp.chain.assert(t, success)
+			} else {
+				resp.chain.assert(t, failure)
+			}
+		}
+	}
+}
+
 func TestResponse_StatusRange(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -278,6 +431,46 @@ func TestResponse_StatusList(t *testing.T) {
 	}
 }
 
+func TestResponse_ProtoIs(t *testing.T) {
+	cases := []struct {
+		name     string
+		proto    string
+		major    int
+		minor    int
+		expected string
+		result   chainResult
+	}{
+		{"http/1.1", "HTTP/1.1", 1, 1, "HTTP/1.1", success},
+		{"http/2.0", "HTTP/2.0", 2, 0, "HTTP/2.0", success},
+		{"http/2", "HTTP/2.0", 2, 0, "HTTP/2", success},
+		{"http/3", "HTTP/3.0", 3, 0, "HTTP/3", success},
+		{"only numbers", "", 2, 0, "HTTP/2.0", success},
+		{"mismatch", "HTTP/1.1", 1, 1, "HTTP/2.0", failure},
+		{"minor mismatch", "HTTP/1.0", 1, 0, "HTTP/1.1", failure},
+		{"invalid", "HTTP/1.1", 1, 1, "foo", failure},
+		{"empty", "HTTP/1.1", 1, 1, "", failure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			httpResp := &http.Response{
+				Proto:      tc.proto,
+				ProtoMajor: tc.major,
+				ProtoMinor: tc.minor,
+				StatusCode: http.StatusOK,
+				Body:       http.NoBody,
+			}
+
+			resp := NewResponse(reporter, httpResp)
+
+			resp.ProtoIs(tc.expected)
+			resp.chain.assert(t, tc.result)
+		})
+	}
+}
+
 func TestResponse_Headers(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -308,6 +501,335 @@ func TestResponse_Headers(t *testing.T) {
 		chain.assert(t, success)
 }
 
+func TestResponse_HeadersCount(t *testing.T) {
+	cases := []struct {
+		name     string
+		header   http.Header
+		encoding []string
+		count    int
+	}{
+		{
+			name:   "no headers",
+			header: nil,
+			count:  0,
+		},
+		{
+			name: "single header",
+			header: http.Header{
+				"Foo": {"bar"},
+			},
+			count: 1,
+		},
+		{
+			name: "multiple values",
+			header: http.Header{
+				"Content-Type": {"text/plain"},
+				"Set-Cookie":   {"a=1", "b=2"},
+			},
+			count: 3,
+		},
+		{
+			name: "transfer encoding",
+			header: http.Header{
+				"Foo": {"bar"},
+			},
+			encoding: []string{"chunked"},
+			count:    2,
+		},
+		{
+			name:     "transfer encoding without headers",
+			header:   nil,
+			encoding: []string{"gzip", "chunked"},
+			count:    1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			httpResp := &http.Response{
+				StatusCode:       http.StatusOK,
+				Header:           tc.header,
+				TransferEncoding: tc.encoding,
+				Body:             nil,
+			}
+
+			resp := NewResponse(reporter, httpResp)
+
+			resp.HeadersCount().IsEqual(tc.count).
+				chain.assert(t, success)
+
+			resp.HeadersCount().Lt(tc.count).
+				chain.assert(t, failure)
+
+			assert.Nil(t, httpResp.Header.Values("Transfer-Encoding"))
+		})
+	}
+}
+
+func TestResponse_HeadersSize(t *testing.T) {
+	t.Run("recorded", func(t *testing.T) {
+		size := 123
+
+		resp := newResponse(responseOpts{
+			config:      newMockConfig(newMockReporter(t)),
+			chain:       newMockChain(t),
+			httpResp:    &http.Response{StatusCode: http.StatusOK},
+			headersSize: &size,
+		})
+
+		resp.HeadersSize().IsEqual(123).
+			chain.assert(t, success)
+	})
+
+	t.Run("not recorded", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Foo": {"bar"}},
+		})
+
+		resp.HeadersSize().
+			chain.assert(t, failure)
+	})
+}
+
+func TestResponse_TLS(t *testing.T) {
+	t.Run("tls", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		state := &tls.ConnectionState{
+			Version:            tls.VersionTLS13,
+			NegotiatedProtocol: "h2",
+		}
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			TLS:        state,
+		})
+
+		value := resp.TLS()
+		value.chain.assert(t, success)
+
+		assert.Same(t, state, value.Raw())
+
+		value.HasMinVersion(tls.VersionTLS12)
+		value.NegotiatedProtocol().IsEqual("h2")
+		value.chain.assert(t, success)
+	})
+
+	t.Run("no tls", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
+
+		value := resp.TLS()
+		value.chain.assert(t, failure)
+
+		assert.Nil(t, value.Raw())
+	})
+}
+
+func TestResponse_HasRequestID(t *testing.T) {
+	cases := []struct {
+		name       string
+		header     string
+		reqHeader  http.Header
+		respHeader http.Header
+		result     chainResult
+	}{
+		{
+			name:       "echoed",
+			header:     "X-Request-Id",
+			reqHeader:  http.Header{"X-Request-Id": {"123"}},
+			respHeader: http.Header{"X-Request-Id": {"123"}},
+			result:     success,
+		},
+		{
+			name:       "different",
+			header:     "X-Request-Id",
+			reqHeader:  http.Header{"X-Request-Id": {"123"}},
+			respHeader: http.Header{"X-Request-Id": {"456"}},
+			result:     failure,
+		},
+		{
+			name:       "missing in response",
+			header:     "X-Request-Id",
+			reqHeader:  http.Header{"X-Request-Id": {"123"}},
+			respHeader: http.Header{},
+			result:     failure,
+		},
+		{
+			name:       "missing in request",
+			header:     "X-Request-Id",
+			reqHeader:  http.Header{},
+			respHeader: http.Header{"X-Request-Id": {"123"}},
+			result:     failure,
+		},
+		{
+			name:       "header not configured",
+			header:     "",
+			reqHeader:  http.Header{"X-Request-Id": {"123"}},
+			respHeader: http.Header{"X-Request-Id": {"123"}},
+			result:     failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := NewResponseC(Config{
+				Reporter:        reporter,
+				RequestIDHeader: tc.header,
+			}, &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     tc.respHeader,
+				Request:    &http.Request{Header: tc.reqHeader},
+			})
+
+			resp.HasRequestID()
+			resp.chain.assert(t, tc.result)
+		})
+	}
+
+	t.Run("no request", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponseC(Config{
+			Reporter:        reporter,
+			RequestIDHeader: "X-Request-Id",
+		}, &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Request-Id": {"123"}},
+		})
+
+		resp.HasRequestID()
+		resp.chain.assert(t, failure)
+	})
+}
+
+func TestResponse_Timings(t *testing.T) {
+	t.Run("available", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		timings := newRequestTimings(clock)
+		timings.wroteRequest = clock.Now()
+		clock.Advance(10 * time.Millisecond)
+		timings.firstByte = clock.Now()
+
+		body := newBodyWrapper(io.NopCloser(bytes.NewReader([]byte("body"))),
+			func() {
+				clock.Advance(5 * time.Millisecond)
+				timings.done()
+			})
+
+		resp := newResponse(responseOpts{
+			config: config,
+			chain:  newChainWithDefaults("test", reporter),
+			httpResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       body,
+			},
+			timings: timings,
+		})
+
+		value := resp.Timings()
+		value.chain.assert(t, success)
+
+		value.TTFB().IsEqual(10 * time.Millisecond)
+		value.Transfer().IsEqual(5 * time.Millisecond)
+		value.Total().IsEqual(15 * time.Millisecond)
+		value.DNS().IsEqual(0)
+
+		// body is retained after reading
+		resp.Body().IsEqual("body")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("no hooks", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		resp := newResponse(responseOpts{
+			config:   config,
+			chain:    newChainWithDefaults("test", reporter),
+			httpResp: &http.Response{StatusCode: http.StatusOK},
+			timings:  newRequestTimings(SystemClock{}),
+		})
+
+		value := resp.Timings()
+		value.chain.assert(t, failure)
+
+		assert.Nil(t, value.Raw())
+	})
+
+	t.Run("no timings", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
+
+		value := resp.Timings()
+		value.chain.assert(t, failure)
+
+		assert.Nil(t, value.Raw())
+	})
+}
+
+func TestResponse_ConnectionReused(t *testing.T) {
+	cases := []struct {
+		name       string
+		connReused *bool
+		result     chainResult
+		expected   bool
+	}{
+		{
+			name:       "new connection",
+			connReused: func() *bool { v := false; return &v }(),
+			result:     success,
+			expected:   false,
+		},
+		{
+			name:       "reused connection",
+			connReused: func() *bool { v := true; return &v }(),
+			result:     success,
+			expected:   true,
+		},
+		{
+			name:       "unknown",
+			connReused: nil,
+			result:     failure,
+			expected:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+			config := newMockConfig(reporter)
+
+			resp := newResponse(responseOpts{
+				config:     config,
+				chain:      newChainWithDefaults("test", reporter),
+				httpResp:   &http.Response{StatusCode: http.StatusOK},
+				connReused: tc.connReused,
+			})
+
+			value := resp.ConnectionReused()
+			value.chain.assert(t, tc.result)
+
+			assert.Equal(t, tc.expected, value.Raw())
+		})
+	}
+}
+
 func TestResponse_Cookies(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -781,31 +1303,302 @@ func TestResponse_NoContent(t *testing.T) {
 
 		resp := NewResponse(reporter, httpResp)
 
-		resp.NoContent()
-		resp.chain.assert(t, failure)
-	})
+		resp.NoContent()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("empty Content-Type, Body close failure", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		headers := map[string][]string{
+			"Content-Type": {""},
+		}
+
+		body := newMockBody("")
+		body.closeErr = errors.New("test_error")
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       body,
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.NoContent()
+		resp.chain.assert(t, failure)
+	})
+}
+
+func TestResponse_NoBody(t *testing.T) {
+	cases := []struct {
+		name             string
+		method           string
+		contentLength    int64
+		transferEncoding []string
+		body             string
+		result           chainResult
+	}{
+		{
+			name:          "no body",
+			contentLength: 0,
+			body:          "",
+			result:        success,
+		},
+		{
+			name:          "unknown length, empty body",
+			contentLength: -1,
+			body:          "",
+			result:        success,
+		},
+		{
+			name:          "non-zero content length",
+			contentLength: 3,
+			body:          "",
+			result:        failure,
+		},
+		{
+			name:          "non-zero content length, head request",
+			method:        http.MethodHead,
+			contentLength: 3,
+			body:          "",
+			result:        success,
+		},
+		{
+			name:             "chunked empty body",
+			contentLength:    -1,
+			transferEncoding: []string{"chunked"},
+			body:             "",
+			result:           failure,
+		},
+		{
+			name:          "non-empty body",
+			contentLength: -1,
+			body:          "foo",
+			result:        failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			httpResp := &http.Response{
+				StatusCode:       http.StatusNoContent,
+				ContentLength:    tc.contentLength,
+				TransferEncoding: tc.transferEncoding,
+				Body:             io.NopCloser(bytes.NewBufferString(tc.body)),
+				Request:          &http.Request{Method: method},
+			}
+
+			resp := NewResponse(reporter, httpResp)
+
+			resp.NoBody()
+			resp.chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestResponse_Checksum(t *testing.T) {
+	const (
+		sha256Hello = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+		md5Hello    = "5d41402abc4b2a76b9719d911017c592"
+		crc32Hello  = "3610a686"
+	)
+
+	newResp := func(t *testing.T, body string) *Response {
+		return NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       newMockBody(body),
+		})
+	}
+
+	t.Run("match", func(t *testing.T) {
+		newResp(t, "hello").HasSHA256(sha256Hello).
+			chain.assert(t, success)
+		newResp(t, "hello").HasSHA256(strings.ToUpper(sha256Hello)).
+			chain.assert(t, success)
+		newResp(t, "hello").HasMD5(md5Hello).
+			chain.assert(t, success)
+		newResp(t, "hello").HasCRC32(crc32Hello).
+			chain.assert(t, success)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		newResp(t, "hellO").HasSHA256(sha256Hello).
+			chain.assert(t, failure)
+		newResp(t, "hellO").HasMD5(md5Hello).
+			chain.assert(t, failure)
+		newResp(t, "hellO").HasCRC32(crc32Hello).
+			chain.assert(t, failure)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		newResp(t, "hello").HasSHA256("").
+			chain.assert(t, failure)
+		newResp(t, "hello").HasSHA256(md5Hello).
+			chain.assert(t, failure)
+		newResp(t, "hello").HasMD5("not hex").
+			chain.assert(t, failure)
+		newResp(t, "hello").HasCRC32("3610a6").
+			chain.assert(t, failure)
+	})
+
+	t.Run("multiple", func(t *testing.T) {
+		resp := newResp(t, "hello")
+
+		resp.HasSHA256(sha256Hello).HasCRC32(crc32Hello)
+		resp.chain.assert(t, success)
+
+		resp.Body().IsEqual("hello")
+		resp.chain.assert(t, success)
+
+		resp.HasMD5(md5Hello)
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       http.NoBody,
+		})
+
+		resp.HasMD5("d41d8cd98f00b204e9800998ecf8427e")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("max buffered body size", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+		config.MaxBufferedBodySize = 3
+
+		resp := NewResponseC(config, &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       newMockBody("hello"),
+		})
+
+		resp.HasSHA256(sha256Hello)
+		resp.chain.assert(t, success)
+
+		// body was streamed and dropped
+		resp.HasSHA256(sha256Hello)
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("body rewinds disabled", func(t *testing.T) {
+		resp := newResp(t, "hello")
+		resp.DisableBodyRewinds()
+
+		resp.HasCRC32(crc32Hello)
+		resp.chain.assert(t, success)
+
+		resp.Body()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		NewResponseC(Config{AssertionHandler: handler}, &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       newMockBody("hellO"),
+		}).HasCRC32(crc32Hello)
+
+		assert.Equal(t, AssertEqual, handler.failure.Type)
+		assert.Equal(t, crc32Hello, handler.failure.Expected.Value)
+		assert.NotEqual(t, crc32Hello, handler.failure.Actual.Value)
+	})
+}
+
+func TestResponse_NoContentType(t *testing.T) {
+	cases := []struct {
+		name   string
+		header http.Header
+		result chainResult
+	}{
+		{
+			name:   "no header",
+			header: http.Header{},
+			result: success,
+		},
+		{
+			name:   "empty header",
+			header: http.Header{"Content-Type": {""}},
+			result: failure,
+		},
+		{
+			name:   "non-empty header",
+			header: http.Header{"Content-Type": {"text/plain"}},
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
 
-	t.Run("empty Content-Type, Body close failure", func(t *testing.T) {
-		reporter := newMockReporter(t)
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     tc.header,
+			})
 
-		headers := map[string][]string{
-			"Content-Type": {""},
-		}
+			resp.NoContentType()
+			resp.chain.assert(t, tc.result)
+		})
+	}
+}
 
-		body := newMockBody("")
-		body.closeErr = errors.New("test_error")
+func TestResponse_BodyIsNotJSON(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		result chainResult
+	}{
+		{
+			name:   "empty body",
+			body:   "",
+			result: success,
+		},
+		{
+			name:   "plain text",
+			body:   "not found",
+			result: success,
+		},
+		{
+			name:   "truncated json",
+			body:   `{"error":`,
+			result: success,
+		},
+		{
+			name:   "json object",
+			body:   `{"error": "not found"}`,
+			result: failure,
+		},
+		{
+			name:   "json scalar",
+			body:   `123`,
+			result: failure,
+		},
+	}
 
-		httpResp := &http.Response{
-			StatusCode: http.StatusOK,
-			Header:     http.Header(headers),
-			Body:       body,
-		}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
 
-		resp := NewResponse(reporter, httpResp)
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"text/plain"}},
+				Body:       io.NopCloser(bytes.NewBufferString(tc.body)),
+			})
 
-		resp.NoContent()
-		resp.chain.assert(t, failure)
-	})
+			resp.BodyIsNotJSON()
+			resp.chain.assert(t, tc.result)
+		})
+	}
 }
 
 func TestResponse_ContentType(t *testing.T) {
@@ -1291,6 +2084,139 @@ func TestResponse_JSON(t *testing.T) {
 	})
 }
 
+func TestResponse_JSONAPI(t *testing.T) {
+	body := `{"data": {"type": "articles", "id": "1"}}`
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		options     []ContentOpts
+		result      chainResult
+	}{
+		{
+			name:        "json:api",
+			contentType: "application/vnd.api+json",
+			body:        body,
+			result:      success,
+		},
+		{
+			name:        "plain json",
+			contentType: "application/json",
+			body:        body,
+			result:      failure,
+		},
+		{
+			name:        "plain json with options",
+			contentType: "application/json",
+			body:        body,
+			options:     []ContentOpts{{MediaType: "application/json"}},
+			result:      success,
+		},
+		{
+			name:        "multiple options",
+			contentType: "application/vnd.api+json",
+			body:        body,
+			options:     []ContentOpts{{}, {}},
+			result:      failure,
+		},
+		{
+			name:        "invalid document",
+			contentType: "application/vnd.api+json",
+			body:        `{"foo": "bar"}`,
+			result:      failure,
+		},
+		{
+			name:        "bad body",
+			contentType: "application/vnd.api+json",
+			body:        `{`,
+			result:      failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			httpResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {tc.contentType}},
+				Body:       io.NopCloser(bytes.NewBufferString(tc.body)),
+			}
+
+			resp := NewResponse(reporter, httpResp)
+
+			doc := resp.JSONAPI(tc.options...)
+			doc.chain.assert(t, tc.result)
+			resp.chain.assert(t, tc.result)
+
+			if tc.result == success {
+				doc.Resource("articles", "1").chain.assert(t, success)
+			}
+		})
+	}
+}
+
+func TestResponse_HAL(t *testing.T) {
+	body := `{"_links": {"self": {"href": "/orders/1"}}}`
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		options     []ContentOpts
+		result      chainResult
+	}{
+		{
+			name:        "hal",
+			contentType: "application/hal+json",
+			body:        body,
+			result:      success,
+		},
+		{
+			name:        "plain json",
+			contentType: "application/json",
+			body:        body,
+			result:      failure,
+		},
+		{
+			name:        "plain json with options",
+			contentType: "application/json",
+			body:        body,
+			options:     []ContentOpts{{MediaType: "application/json"}},
+			result:      success,
+		},
+		{
+			name:        "not object",
+			contentType: "application/hal+json",
+			body:        `[]`,
+			result:      failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			httpResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {tc.contentType}},
+				Body:       io.NopCloser(bytes.NewBufferString(tc.body)),
+			}
+
+			resp := NewResponse(reporter, httpResp)
+
+			res := resp.HAL(tc.options...)
+			res.chain.assert(t, tc.result)
+			resp.chain.assert(t, tc.result)
+
+			if tc.result == success {
+				res.Href("self").IsEqual("/orders/1").chain.assert(t, success)
+			}
+		})
+	}
+}
+
 func TestResponse_JSONP(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -1456,6 +2382,140 @@ func TestResponse_JSONP(t *testing.T) {
 	})
 }
 
+func TestResponse_S3Error(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		body := `<?xml version="1.0" encoding="UTF-8"?>
+<Error>
+  <Code>NoSuchKey</Code>
+  <Message>The specified key does not exist.</Message>
+  <Key>foo.txt</Key>
+  <RequestId>4442587FB7D0A2F9</RequestId>
+</Error>`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header: http.Header{
+				"Content-Type": {"application/xml"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.S3Error()
+		resp.chain.assert(t, success)
+		resp.chain.clear()
+
+		assert.Equal(t,
+			map[string]interface{}{
+				"Code":      "NoSuchKey",
+				"Message":   "The specified key does not exist.",
+				"Key":       "foo.txt",
+				"RequestId": "4442587FB7D0A2F9",
+			},
+			resp.S3Error().Raw())
+
+		resp.S3Error().Value("Code").IsEqual("NoSuchKey").
+			chain.assert(t, success)
+
+		resp.S3Error().Value("Code").IsEqual("AccessDenied").
+			chain.assert(t, failure)
+	})
+
+	t.Run("content type", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		body := `<Error><Code>AccessDenied</Code></Error>`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusForbidden,
+			Header: http.Header{
+				"Content-Type": {"text/xml"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.S3Error()
+		resp.chain.assert(t, failure)
+		resp.chain.clear()
+
+		resp.S3Error(ContentOpts{
+			MediaType: "text/xml",
+		})
+		resp.chain.assert(t, success)
+		resp.chain.clear()
+	})
+
+	t.Run("bad body", func(t *testing.T) {
+		cases := []struct {
+			name string
+			body string
+		}{
+			{
+				name: "malformed",
+				body: `<Error><Code>NoSuchKey</Error>`,
+			},
+			{
+				name: "wrong root",
+				body: `<Result><Code>NoSuchKey</Code></Result>`,
+			},
+			{
+				name: "missing code",
+				body: `<Error><Message>oops</Message></Error>`,
+			},
+			{
+				name: "empty code",
+				body: `<Error><Code> </Code></Error>`,
+			},
+			{
+				name: "empty body",
+				body: ``,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				httpResp := &http.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						"Content-Type": {"application/xml"},
+					},
+					Body: io.NopCloser(bytes.NewBufferString(tc.body)),
+				}
+
+				resp := NewResponse(reporter, httpResp)
+
+				resp.S3Error()
+				resp.chain.assert(t, failure)
+			})
+		}
+	})
+
+	t.Run("multiple options", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/xml"},
+			},
+			Body: io.NopCloser(
+				bytes.NewBufferString(`<Error><Code>NoSuchKey</Code></Error>`)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.S3Error(ContentOpts{}, ContentOpts{})
+		resp.chain.assert(t, failure)
+	})
+}
+
 func TestResponse_ContentOpts(t *testing.T) {
 	type testCase struct {
 		respContentType   string
@@ -1757,6 +2817,138 @@ func TestResponse_Reader(t *testing.T) {
 	})
 }
 
+func TestResponse_DisableBodyRewinds(t *testing.T) {
+	t.Run("before read", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		wrp := newBodyWrapper(newMockBody("test body"), nil)
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       wrp,
+		}
+		resp := NewResponse(reporter, httpResp)
+
+		resp.DisableBodyRewinds()
+		resp.chain.assert(t, success)
+		assert.True(t, wrp.isRewindDisabled)
+
+		resp.Body().IsEqual("test body")
+		resp.chain.assert(t, success)
+		assert.Nil(t, resp.content)
+
+		resp.Text()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("after read", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       newMockBody("test body"),
+		}
+		resp := NewResponse(reporter, httpResp)
+
+		resp.Body().IsEqual("test body")
+		resp.chain.assert(t, success)
+		assert.NotNil(t, resp.content)
+
+		resp.DisableBodyRewinds()
+		resp.chain.assert(t, success)
+		assert.Nil(t, resp.content)
+
+		resp.Body()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("after reader", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       newMockBody("test body"),
+		}
+		resp := NewResponse(reporter, httpResp)
+
+		reader := resp.Reader()
+		resp.DisableBodyRewinds()
+		resp.chain.assert(t, success)
+
+		b, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, "test body", string(b))
+	})
+}
+
+func TestResponse_MaxBufferedBodySize(t *testing.T) {
+	cases := []struct {
+		name        string
+		maxSize     int64
+		secondFails bool
+	}{
+		{
+			name:        "unlimited",
+			maxSize:     0,
+			secondFails: false,
+		},
+		{
+			name:        "below limit",
+			maxSize:     100,
+			secondFails: false,
+		},
+		{
+			name:        "equal to limit",
+			maxSize:     9,
+			secondFails: false,
+		},
+		{
+			name:        "above limit",
+			maxSize:     5,
+			secondFails: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := newMockConfig(newMockReporter(t))
+			config.MaxBufferedBodySize = tc.maxSize
+
+			httpResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       newMockBody("test body"),
+			}
+			resp := NewResponseC(config, httpResp)
+
+			resp.Body().IsEqual("test body")
+			resp.chain.assert(t, success)
+
+			resp.Body()
+			if tc.secondFails {
+				resp.chain.assert(t, failure)
+			} else {
+				resp.chain.assert(t, success)
+			}
+		})
+	}
+
+	t.Run("body read by printer", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+		config.MaxBufferedBodySize = 5
+
+		wrp := newBodyWrapper(newMockBody("test body"), nil)
+		wrp.SetMaxSize(config.MaxBufferedBodySize)
+
+		content, _, err := readPrinterBody(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, "test body", string(content))
+
+		resp := NewResponseC(config, &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       wrp,
+		})
+
+		resp.Body()
+		resp.chain.assert(t, failure)
+	})
+}
+
 func TestResponse_Usage(t *testing.T) {
 	t.Run("NewResponse multiple rtt arguments", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -0,0 +1,437 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ScenarioStep defines a single declarative test case: request to be sent
+// and expectations for the response. See Expect.RunScenario.
+type ScenarioStep struct {
+	// Step name, used as alias in failure messages and as key in the map
+	// returned by RunScenario. If empty, "step N" is used (starting from 1).
+	Name string `yaml:"name"`
+
+	// Request method and path. Path is appended to Config.BaseURL.
+	Method string `yaml:"method"`
+	Path   string `yaml:"path"`
+
+	// Query parameters and headers, see Request.WithQuery and
+	// Request.WithHeader.
+	Query   map[string]string `yaml:"query"`
+	Headers map[string]string `yaml:"headers"`
+
+	// Request body. At most one of these can be set.
+	// See Request.WithJSON, Request.WithText, and Request.WithFormField.
+	JSON interface{}       `yaml:"json"`
+	Text string            `yaml:"text"`
+	Form map[string]string `yaml:"form"`
+
+	// Maximum number of retries, see Request.WithMaxRetries.
+	MaxRetries int `yaml:"max_retries"`
+
+	// Response expectations.
+	Expect ScenarioExpect `yaml:"expect"`
+}
+
+// ScenarioExpect defines expectations for response of ScenarioStep.
+// Zero fields are not checked.
+type ScenarioExpect struct {
+	// Expected status code, see Response.Status.
+	Status int `yaml:"status"`
+
+	// Expected header values, see Response.Header.
+	Headers map[string]string `yaml:"headers"`
+
+	// Expected body substring, see String.Contains.
+	BodyContains string `yaml:"body_contains"`
+
+	// Assertions for JSON body.
+	JSON []ScenarioJSONAssertion `yaml:"json"`
+}
+
+// ScenarioJSONAssertion defines assertion for JSON response body value
+// matched by JSONPath expression (see Value.Path).
+//
+// Path should match body. Other fields are optional; zero fields are not
+// checked. To check that value is null, use Type "null".
+type ScenarioJSONAssertion struct {
+	// JSONPath expression, e.g. "$.users[0].name".
+	Path string `yaml:"path"`
+
+	// Expected value, see Value.IsEqual.
+	Equal interface{} `yaml:"equal"`
+
+	// Unexpected value, see Value.NotEqual.
+	NotEqual interface{} `yaml:"not_equal"`
+
+	// Expected type: "object", "array", "string", "number", "boolean",
+	// or "null".
+	Type string `yaml:"type"`
+}
+
+type scenarioFile struct {
+	Steps []ScenarioStep `yaml:"steps"`
+}
+
+// LoadScenario loads scenario steps from YAML or JSON file.
+// See ParseScenario for file format.
+func LoadScenario(path string) ([]ScenarioStep, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := ParseScenario(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return steps, nil
+}
+
+// ParseScenario parses scenario steps from YAML or JSON document.
+//
+// Document should contain "steps" list. Field names are same as in
+// ScenarioStep, but in snake case. Steps are validated: method is required,
+// names should be unique, and at most one body type can be set.
+//
+// Example document:
+//
+//	steps:
+//	  - name: create user
+//	    method: POST
+//	    path: /users
+//	    headers:
+//	      Authorization: Bearer token
+//	    json:
+//	      name: john
+//	    expect:
+//	      status: 201
+//	      json:
+//	        - path: $.id
+//	          type: number
+//
+//	  - name: list users
+//	    method: GET
+//	    path: /users
+//	    query:
+//	      limit: "10"
+//	    max_retries: 3
+//	    expect:
+//	      status: 200
+//	      headers:
+//	        Content-Type: application/json
+//	      json:
+//	        - path: $[0].name
+//	          equal: john
+func ParseScenario(data []byte) ([]ScenarioStep, error) {
+	// YAML is a superset of JSON, so both formats are handled here.
+	var file scenarioFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+
+	for i := range file.Steps {
+		step := &file.Steps[i]
+
+		if step.Name == "" {
+			step.Name = fmt.Sprintf("step %d", i+1)
+		}
+
+		if err := validateScenarioStep(step); err != nil {
+			return nil, fmt.Errorf("invalid step %q: %w", step.Name, err)
+		}
+
+		if names[step.Name] {
+			return nil, fmt.Errorf("duplicate step name %q", step.Name)
+		}
+		names[step.Name] = true
+
+		// YAML decodes objects into map[interface{}]interface{},
+		// which can't be encoded to JSON and compared with JSON values.
+		step.JSON = normalizeYAML(step.JSON)
+
+		for j := range step.Expect.JSON {
+			assertion := &step.Expect.JSON[j]
+
+			assertion.Equal = normalizeYAML(assertion.Equal)
+			assertion.NotEqual = normalizeYAML(assertion.NotEqual)
+		}
+	}
+
+	return file.Steps, nil
+}
+
+func validateScenarioStep(step *ScenarioStep) error {
+	if step.Method == "" {
+		return errors.New("missing method")
+	}
+
+	bodyCount := 0
+	if step.JSON != nil {
+		bodyCount++
+	}
+	if step.Text != "" {
+		bodyCount++
+	}
+	if len(step.Form) != 0 {
+		bodyCount++
+	}
+	if bodyCount > 1 {
+		return errors.New("json, text, and form are mutually exclusive")
+	}
+
+	if step.MaxRetries < 0 {
+		return fmt.Errorf("negative max_retries %d", step.MaxRetries)
+	}
+
+	for _, assertion := range step.Expect.JSON {
+		if assertion.Path == "" {
+			return errors.New("missing json assertion path")
+		}
+
+		switch assertion.Type {
+		case "", "object", "array", "string", "number", "boolean", "null":
+			break
+
+		default:
+			return fmt.Errorf("invalid json assertion type %q", assertion.Type)
+		}
+	}
+
+	return nil
+}
+
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[fmt.Sprint(key)] = normalizeYAML(val)
+		}
+		return m
+
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, val := range v {
+			a[i] = normalizeYAML(val)
+		}
+		return a
+
+	default:
+		return value
+	}
+}
+
+// RunScenario loads scenario steps from YAML or JSON file and executes them.
+//
+// For every step, request is created using this Expect instance (so that
+// builders and matchers are applied), sent, and response is checked against
+// step expectations. Step name is used as alias for request and response,
+// so that failure messages include it. Failures are reported via
+// Config.AssertionHandler, as usual.
+//
+// Steps are executed sequentially. If a step fails, remaining steps are
+// skipped. Returns map of responses of executed steps, with step names as
+// keys, which can be used for further checks.
+//
+// See ParseScenario for file format.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	resps := e.RunScenario("testdata/users.yaml")
+//
+//	resps["create user"].JSON().Object().ContainsKey("id")
+func (e *Expect) RunScenario(path string) map[string]*Response {
+	opChain := e.chain.enter("RunScenario(%q)", path)
+	defer opChain.leave()
+
+	steps, err := LoadScenario(path)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to load scenario"),
+				err,
+			},
+		})
+		return map[string]*Response{}
+	}
+
+	return e.runScenario(opChain, steps)
+}
+
+// RunScenarioSteps is like RunScenario, but executes given steps instead
+// of loading them from file.
+//
+// Steps should be valid according to ParseScenario rules.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.RunScenarioSteps([]httpexpect.ScenarioStep{
+//		{
+//			Name:   "health",
+//			Method: "GET",
+//			Path:   "/health",
+//			Expect: httpexpect.ScenarioExpect{
+//				Status: http.StatusOK,
+//			},
+//		},
+//	})
+func (e *Expect) RunScenarioSteps(steps []ScenarioStep) map[string]*Response {
+	opChain := e.chain.enter("RunScenarioSteps()")
+	defer opChain.leave()
+
+	names := make(map[string]bool)
+
+	for i, step := range steps {
+		name := step.Name
+		if name == "" {
+			name = fmt.Sprintf("step %d", i+1)
+		}
+
+		err := validateScenarioStep(&step)
+		if err == nil && names[name] {
+			err = fmt.Errorf("duplicate step name %q", name)
+		}
+		names[name] = true
+
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{step},
+				Errors: []error{
+					fmt.Errorf("invalid step %q", name),
+					err,
+				},
+			})
+			return map[string]*Response{}
+		}
+	}
+
+	return e.runScenario(opChain, steps)
+}
+
+func (e *Expect) runScenario(
+	opChain *chain, steps []ScenarioStep,
+) map[string]*Response {
+	resps := make(map[string]*Response, len(steps))
+
+	for i, step := range steps {
+		if step.Name == "" {
+			step.Name = fmt.Sprintf("step %d", i+1)
+		}
+
+		resp := e.runScenarioStep(opChain, step)
+		resps[step.Name] = resp
+
+		if resp.chain.treeFailed() {
+			break
+		}
+	}
+
+	return resps
+}
+
+func (e *Expect) runScenarioStep(opChain *chain, step ScenarioStep) *Response {
+	req := e.newRequest(opChain, step.Method, step.Path).
+		Alias(step.Name)
+
+	for _, key := range sortedKeys(step.Query) {
+		req.WithQuery(key, step.Query[key])
+	}
+
+	for _, key := range sortedKeys(step.Headers) {
+		req.WithHeader(key, step.Headers[key])
+	}
+
+	switch {
+	case step.JSON != nil:
+		req.WithJSON(step.JSON)
+
+	case step.Text != "":
+		req.WithText(step.Text)
+
+	case len(step.Form) != 0:
+		for _, key := range sortedKeys(step.Form) {
+			req.WithFormField(key, step.Form[key])
+		}
+	}
+
+	if step.MaxRetries != 0 {
+		req.WithMaxRetries(step.MaxRetries)
+	}
+
+	resp := req.Expect()
+
+	expect := step.Expect
+
+	if expect.Status != 0 {
+		resp.Status(expect.Status)
+	}
+
+	for _, key := range sortedKeys(expect.Headers) {
+		resp.Header(key).IsEqual(expect.Headers[key])
+	}
+
+	if expect.BodyContains != "" {
+		resp.Body().Contains(expect.BodyContains)
+	}
+
+	if len(expect.JSON) != 0 {
+		body := resp.JSON()
+
+		for _, assertion := range expect.JSON {
+			checkScenarioJSON(body.Path(assertion.Path), assertion)
+		}
+	}
+
+	return resp
+}
+
+func checkScenarioJSON(value *Value, assertion ScenarioJSONAssertion) {
+	if assertion.Equal != nil {
+		value.IsEqual(assertion.Equal)
+	}
+
+	if assertion.NotEqual != nil {
+		value.NotEqual(assertion.NotEqual)
+	}
+
+	switch assertion.Type {
+	case "object":
+		value.IsObject()
+	case "array":
+		value.IsArray()
+	case "string":
+		value.IsString()
+	case "number":
+		value.IsNumber()
+	case "boolean":
+		value.IsBoolean()
+	case "null":
+		value.IsNull()
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,446 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newScenarioHandler(calls *int) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+
+		switch r.Method {
+		case http.MethodPost:
+			var user map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			user["id"] = 1
+			user["token"] = r.Header.Get("Authorization")
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(user)
+
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"name": "john", "limit": "` +
+				r.URL.Query().Get("limit") + `", "admin": null}]`))
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+
+		b, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(b)
+	})
+
+	return mux
+}
+
+func newScenarioExpect(t *testing.T, calls *int) (*Expect, *mockReporter) {
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(newScenarioHandler(calls)),
+		},
+	})
+
+	return e, reporter
+}
+
+func writeScenarioFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestScenario_Parse(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		steps, err := ParseScenario([]byte(`
+steps:
+  - name: create
+    method: POST
+    path: /users
+    headers:
+      Authorization: Bearer token
+    json:
+      name: john
+      tags: [a, b]
+    max_retries: 2
+    expect:
+      status: 201
+      json:
+        - path: $.name
+          equal: john
+        - path: $.profile
+          equal: {age: 30}
+  - method: GET
+    path: /users
+`))
+		require.NoError(t, err)
+		require.Equal(t, 2, len(steps))
+
+		assert.Equal(t, ScenarioStep{
+			Name:   "create",
+			Method: "POST",
+			Path:   "/users",
+			Headers: map[string]string{
+				"Authorization": "Bearer token",
+			},
+			JSON: map[string]interface{}{
+				"name": "john",
+				"tags": []interface{}{"a", "b"},
+			},
+			MaxRetries: 2,
+			Expect: ScenarioExpect{
+				Status: 201,
+				JSON: []ScenarioJSONAssertion{
+					{
+						Path:  "$.name",
+						Equal: "john",
+					},
+					{
+						Path:  "$.profile",
+						Equal: map[string]interface{}{"age": 30},
+					},
+				},
+			},
+		}, steps[0])
+
+		assert.Equal(t, "step 2", steps[1].Name)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		steps, err := ParseScenario([]byte(`{
+  "steps": [
+    {"name": "list", "method": "GET", "path": "/users",
+     "expect": {"status": 200}}
+  ]
+}`))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(steps))
+
+		assert.Equal(t, "list", steps[0].Name)
+		assert.Equal(t, 200, steps[0].Expect.Status)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			scenario string
+		}{
+			{
+				name:     "syntax",
+				scenario: `steps: [`,
+			},
+			{
+				name: "unknown field",
+				scenario: `
+steps:
+  - method: GET
+    pathh: /users
+`,
+			},
+			{
+				name: "missing method",
+				scenario: `
+steps:
+  - path: /users
+`,
+			},
+			{
+				name: "duplicate name",
+				scenario: `
+steps:
+  - name: list
+    method: GET
+  - name: list
+    method: GET
+`,
+			},
+			{
+				name: "multiple bodies",
+				scenario: `
+steps:
+  - method: POST
+    text: hello
+    form:
+      key: value
+`,
+			},
+			{
+				name: "negative retries",
+				scenario: `
+steps:
+  - method: GET
+    max_retries: -1
+`,
+			},
+			{
+				name: "missing json path",
+				scenario: `
+steps:
+  - method: GET
+    expect:
+      json:
+        - equal: 1
+`,
+			},
+			{
+				name: "invalid json type",
+				scenario: `
+steps:
+  - method: GET
+    expect:
+      json:
+        - path: $.id
+          type: integer
+`,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := ParseScenario([]byte(tc.scenario))
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestScenario_Run(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		calls := 0
+		e, reporter := newScenarioExpect(t, &calls)
+
+		path := writeScenarioFile(t, `
+steps:
+  - name: create user
+    method: POST
+    path: /users
+    headers:
+      Authorization: Bearer token
+    json:
+      name: john
+    expect:
+      status: 201
+      headers:
+        Content-Type: application/json
+      json:
+        - path: $.id
+          equal: 1
+          type: number
+        - path: $.name
+          not_equal: bob
+        - path: $.token
+          equal: Bearer token
+
+  - name: list users
+    method: GET
+    path: /users
+    query:
+      limit: "10"
+    expect:
+      status: 200
+      body_contains: john
+      json:
+        - path: $[0].limit
+          equal: "10"
+        - path: $[0].admin
+          type: "null"
+
+  - name: echo text
+    method: POST
+    path: /echo
+    text: hello
+    expect:
+      body_contains: hello
+
+  - name: echo form
+    method: POST
+    path: /echo
+    form:
+      b: "2"
+      a: "1"
+    expect:
+      body_contains: a=1&b=2
+`)
+
+		resps := e.RunScenario(path)
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, 4, calls)
+		require.Equal(t, 4, len(resps))
+
+		resps["create user"].JSON().Object().HasValue("name", "john")
+		resps["list users"].JSON().Array().Length().IsEqual(1)
+
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("failure stops scenario", func(t *testing.T) {
+		calls := 0
+		e, reporter := newScenarioExpect(t, &calls)
+
+		path := writeScenarioFile(t, `
+steps:
+  - name: list users
+    method: GET
+    path: /users
+    expect:
+      json:
+        - path: $[0].name
+          equal: bob
+  - name: create user
+    method: POST
+    path: /users
+    json:
+      name: john
+`)
+
+		resps := e.RunScenario(path)
+
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 1, calls)
+
+		assert.Equal(t, 1, len(resps))
+		assert.NotNil(t, resps["list users"])
+		assert.Nil(t, resps["create user"])
+	})
+
+	t.Run("step name alias", func(t *testing.T) {
+		calls := 0
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          "http://example.com",
+			AssertionHandler: handler,
+			Client: &http.Client{
+				Transport: NewBinder(newScenarioHandler(&calls)),
+			},
+		})
+
+		e.RunScenarioSteps([]ScenarioStep{
+			{
+				Name:   "list users",
+				Method: "GET",
+				Path:   "/users",
+				Expect: ScenarioExpect{
+					Status: http.StatusTeapot,
+				},
+			},
+		})
+
+		assert.Equal(t, 1, handler.failureCalled)
+		require.NotNil(t, handler.ctx)
+		assert.Contains(t, handler.ctx.AliasedPath, "list users")
+	})
+
+	t.Run("wrong status", func(t *testing.T) {
+		calls := 0
+		e, reporter := newScenarioExpect(t, &calls)
+
+		path := writeScenarioFile(t, `
+steps:
+  - method: DELETE
+    path: /users
+    expect:
+      status: 204
+`)
+
+		resps := e.RunScenario(path)
+
+		assert.True(t, reporter.reported)
+		assert.NotNil(t, resps["step 1"])
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		calls := 0
+		e, reporter := newScenarioExpect(t, &calls)
+
+		resps := e.RunScenario(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 0, len(resps))
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		calls := 0
+		e, reporter := newScenarioExpect(t, &calls)
+
+		resps := e.RunScenario(writeScenarioFile(t, `steps: [`))
+
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 0, len(resps))
+	})
+}
+
+func TestScenario_RunSteps(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		calls := 0
+		e, reporter := newScenarioExpect(t, &calls)
+
+		resps := e.RunScenarioSteps([]ScenarioStep{
+			{
+				Method: "POST",
+				Path:   "/users",
+				JSON: map[string]interface{}{
+					"name": "john",
+				},
+				Expect: ScenarioExpect{
+					Status: http.StatusCreated,
+					JSON: []ScenarioJSONAssertion{
+						{
+							Path:  "$.name",
+							Equal: "john",
+						},
+					},
+				},
+			},
+		})
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, 1, calls)
+		assert.NotNil(t, resps["step 1"])
+	})
+
+	t.Run("invalid steps", func(t *testing.T) {
+		calls := 0
+		e, reporter := newScenarioExpect(t, &calls)
+
+		resps := e.RunScenarioSteps([]ScenarioStep{
+			{
+				Method: "GET",
+				Path:   "/users",
+			},
+			{
+				Name:   "step 1",
+				Method: "GET",
+				Path:   "/users",
+			},
+		})
+
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 0, len(resps))
+		assert.Equal(t, 0, calls)
+	})
+}
@@ -0,0 +1,311 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SchemaDriftOpts defines parameters for Expect.WithSchemaDrift.
+type SchemaDriftOpts struct {
+	// Directory where recorded response shapes are stored.
+	// If empty, "testdata/schemas" is used.
+	Dir string
+
+	// Severity of reported drift.
+	// By default (SeverityError), drift causes test failure. Set to
+	// SeverityWarning to only report it.
+	Severity AssertionSeverity
+
+	// If true, fields that are present in response but not in recorded
+	// shape are not reported. Removed fields and changed types are still
+	// reported.
+	IgnoreAdded bool
+}
+
+const defaultSchemaDir = "testdata/schemas"
+
+// JSON shape maps JSON path of every node to its type, e.g.:
+//
+//	{"$": "object", "$.items": "array", "$.items[*].id": "number"}
+//
+// Elements of an array share path "[*]". If nodes with the same path have
+// different types, types are sorted and joined with "|".
+type jsonShape map[string]string
+
+// Infer shape of canonical JSON value.
+// Also returns paths of empty arrays, which elements shape is unknown.
+func inferJSONShape(value interface{}) (jsonShape, map[string]bool) {
+	shape := jsonShape{}
+	empty := map[string]bool{}
+
+	inferJSONNode(shape, empty, "$", value)
+
+	return shape, empty
+}
+
+func inferJSONNode(
+	shape jsonShape, empty map[string]bool, path string, value interface{},
+) {
+	var typ string
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		typ = "object"
+		for key, elem := range v {
+			inferJSONNode(shape, empty, path+jsonPathKey(key), elem)
+		}
+
+	case []interface{}:
+		typ = "array"
+		if len(v) == 0 {
+			empty[path] = true
+		}
+		for _, elem := range v {
+			inferJSONNode(shape, empty, path+"[*]", elem)
+		}
+
+	case string:
+		typ = "string"
+
+	case float64, json.Number:
+		typ = "number"
+
+	case bool:
+		typ = "boolean"
+
+	case nil:
+		typ = "null"
+
+	default:
+		typ = fmt.Sprintf("%T", v)
+	}
+
+	shape[path] = joinShapeTypes(shape[path], typ)
+}
+
+func joinShapeTypes(a, b string) string {
+	set := splitShapeTypes(a)
+	for typ := range splitShapeTypes(b) {
+		set[typ] = true
+	}
+
+	types := make([]string, 0, len(set))
+	for typ := range set {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+
+	return strings.Join(types, "|")
+}
+
+func splitShapeTypes(s string) map[string]bool {
+	set := map[string]bool{}
+	if s != "" {
+		for _, typ := range strings.Split(s, "|") {
+			set[typ] = true
+		}
+	}
+	return set
+}
+
+// Compare recorded and actual shapes and return human-readable
+// description of every difference.
+//
+// Null values are compatible with any type, and fields missing under
+// null values or empty arrays are not reported as removed, since shape
+// of such nodes is unknown.
+func diffJSONShapes(
+	expected, actual jsonShape, empty map[string]bool, ignoreAdded bool,
+) []string {
+	isUnknown := func(path string) bool {
+		for p, typ := range actual {
+			if !isShapeChild(path, p) {
+				continue
+			}
+			if empty[p] || splitShapeTypes(typ)["null"] {
+				return true
+			}
+		}
+		return false
+	}
+
+	isCompatible := func(a, b string) bool {
+		aTypes, bTypes := splitShapeTypes(a), splitShapeTypes(b)
+		delete(aTypes, "null")
+		delete(bTypes, "null")
+
+		if len(aTypes) == 0 || len(bTypes) == 0 {
+			return true
+		}
+		if len(aTypes) != len(bTypes) {
+			return false
+		}
+		for typ := range aTypes {
+			if !bTypes[typ] {
+				return false
+			}
+		}
+		return true
+	}
+
+	paths := make([]string, 0, len(expected)+len(actual))
+	for path := range expected {
+		paths = append(paths, path)
+	}
+	for path := range actual {
+		if _, ok := expected[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var diff []string
+
+	for _, path := range paths {
+		expectedType, expectedOk := expected[path]
+		actualType, actualOk := actual[path]
+
+		switch {
+		case !actualOk:
+			if !isUnknown(path) {
+				diff = append(diff,
+					fmt.Sprintf("removed field: %s (%s)", path, expectedType))
+			}
+
+		case !expectedOk:
+			if !ignoreAdded {
+				diff = append(diff,
+					fmt.Sprintf("added field: %s (%s)", path, actualType))
+			}
+
+		case !isCompatible(expectedType, actualType):
+			diff = append(diff,
+				fmt.Sprintf("changed type: %s (%s -> %s)",
+					path, expectedType, actualType))
+		}
+	}
+
+	return diff
+}
+
+// Check if path is a descendant of parent path.
+func isShapeChild(path, parent string) bool {
+	return len(path) > len(parent) && strings.HasPrefix(path, parent) &&
+		(path[len(parent)] == '.' || path[len(parent)] == '[')
+}
+
+// Check response shape against shape recorded for given request name.
+// Invoked by matcher attached by Expect.WithSchemaDrift.
+func (r *Response) checkSchemaDrift(name string, opts SchemaDriftOpts) {
+	opChain := r.chain.enter("SchemaDrift(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return
+	}
+
+	if opts.Severity != SeverityError {
+		opChain.setRoot()
+		opChain.setSeverity(opts.Severity)
+	}
+
+	if name == "" || filepath.IsAbs(name) ||
+		strings.HasPrefix(filepath.Clean(name), "..") {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid request name for schema file %q", name),
+			},
+		})
+		return
+	}
+
+	content, ok := r.getContent(opChain, "SchemaDrift()")
+	if !ok {
+		return
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(content, &value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(content),
+			},
+			Errors: []error{
+				errors.New("failed to decode json"),
+				err,
+			},
+		})
+		return
+	}
+
+	actual, empty := inferJSONShape(value)
+
+	dir := opts.Dir
+	if dir == "" {
+		dir = defaultSchemaDir
+	}
+
+	path := filepath.Join(dir, filepath.FromSlash(name)+".json")
+
+	data, err := os.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) || updateSnapshots() {
+		data, _ := json.MarshalIndent(actual, "", "  ")
+
+		if err := writeSnapshot(path, string(data)+"\n"); err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("failed to write schema file %q", path),
+					err,
+				},
+			})
+		}
+		return
+	}
+
+	var expected jsonShape
+
+	if err == nil {
+		err = json.Unmarshal(data, &expected)
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to read schema file %q", path),
+				err,
+			},
+		})
+		return
+	}
+
+	diff := diffJSONShapes(expected, actual, empty, opts.IgnoreAdded)
+
+	if len(diff) != 0 {
+		errs := []error{
+			fmt.Errorf("expected: response shape matches recorded shape of %q", name),
+			fmt.Errorf("schema file: %s", path),
+		}
+		for _, d := range diff {
+			errs = append(errs, errors.New(d))
+		}
+		errs = append(errs,
+			fmt.Errorf("set %s=1 to update schema", updateSnapshotsEnv))
+
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{map[string]string(actual)},
+			Expected: &AssertionValue{map[string]string(expected)},
+			Errors:   errs,
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package httpexpect
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaDrift_Infer(t *testing.T) {
+	shape, empty := inferJSONShape(map[string]interface{}{
+		"id":   1.0,
+		"name": "foo",
+		"tags": []interface{}{},
+		"items": []interface{}{
+			map[string]interface{}{"a": true},
+			map[string]interface{}{"a": nil, "b": "x"},
+			"str",
+		},
+		"weird-key": nil,
+	})
+
+	assert.Equal(t, jsonShape{
+		"$":              "object",
+		"$.id":           "number",
+		"$.name":         "string",
+		"$.tags":         "array",
+		"$.items":        "array",
+		"$.items[*]":     "object|string",
+		"$.items[*].a":   "boolean|null",
+		"$.items[*].b":   "string",
+		`$["weird-key"]`: "null",
+	}, shape)
+
+	assert.Equal(t, map[string]bool{"$.tags": true}, empty)
+}
+
+func TestSchemaDrift_Diff(t *testing.T) {
+	expected := jsonShape{
+		"$":            "object",
+		"$.id":         "number",
+		"$.name":       "string",
+		"$.item":       "string",
+		"$.tags":       "array",
+		"$.tags[*]":    "string",
+		"$.owner":      "object",
+		"$.owner.name": "string",
+	}
+
+	cases := []struct {
+		name        string
+		actual      jsonShape
+		empty       map[string]bool
+		ignoreAdded bool
+		diff        []string
+	}{
+		{
+			name:   "equal",
+			actual: expected,
+			diff:   nil,
+		},
+		{
+			name: "added, removed, changed",
+			actual: jsonShape{
+				"$":            "object",
+				"$.id":         "string",
+				"$.item":       "string",
+				"$.items":      "array",
+				"$.tags":       "array",
+				"$.tags[*]":    "string",
+				"$.owner":      "object",
+				"$.owner.name": "string",
+			},
+			diff: []string{
+				"changed type: $.id (number -> string)",
+				"added field: $.items (array)",
+				"removed field: $.name (string)",
+			},
+		},
+		{
+			name: "ignore added",
+			actual: jsonShape{
+				"$":            "object",
+				"$.id":         "number",
+				"$.name":       "string",
+				"$.item":       "string",
+				"$.items":      "array",
+				"$.tags":       "array",
+				"$.tags[*]":    "string",
+				"$.owner":      "object",
+				"$.owner.name": "string",
+			},
+			ignoreAdded: true,
+			diff:        nil,
+		},
+		{
+			name: "null and empty",
+			actual: jsonShape{
+				"$":       "object",
+				"$.id":    "number",
+				"$.name":  "null",
+				"$.item":  "string",
+				"$.tags":  "array",
+				"$.owner": "null",
+			},
+			empty: map[string]bool{"$.tags": true},
+			diff:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.diff,
+				diffJSONShapes(expected, tc.actual, tc.empty, tc.ignoreAdded))
+		})
+	}
+}
+
+func TestSchemaDrift_Expect(t *testing.T) {
+	newExpect := func(t *testing.T, opts SchemaDriftOpts) *Expect {
+		return WithConfig(Config{
+			BaseURL: "http://example.com",
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+			Reporter: newMockReporter(t),
+		}).WithSchemaDrift(opts)
+	}
+
+	send := func(e *Expect, name, body string) *Response {
+		req := e.POST("/")
+		if name != "" {
+			req.WithName(name)
+		}
+		return req.
+			WithHeader("Content-Type", "application/json").
+			WithText(body).
+			Expect()
+	}
+
+	t.Run("record and compare", func(t *testing.T) {
+		dir := t.TempDir()
+		e := newExpect(t, SchemaDriftOpts{Dir: dir})
+
+		send(e, "get_user", `{"id": 1, "name": "foo"}`).
+			chain.assert(t, success)
+
+		data, err := os.ReadFile(filepath.Join(dir, "get_user.json"))
+		require.NoError(t, err)
+		assert.JSONEq(t,
+			`{"$": "object", "$.id": "number", "$.name": "string"}`, string(data))
+
+		send(e, "get_user", `{"id": 2, "name": "bar"}`).
+			chain.assert(t, success)
+
+		send(e, "get_user", `{"id": 3}`).
+			chain.assert(t, failure)
+	})
+
+	t.Run("unnamed", func(t *testing.T) {
+		dir := t.TempDir()
+		e := newExpect(t, SchemaDriftOpts{Dir: dir})
+
+		send(e, "", `{"id": 1}`).
+			chain.assert(t, success)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		dir := t.TempDir()
+		e := newExpect(t, SchemaDriftOpts{
+			Dir:      dir,
+			Severity: SeverityWarning,
+		})
+
+		send(e, "get_user", `{"id": 1}`).
+			chain.assert(t, success)
+
+		send(e, "get_user", `{"id": "1"}`).
+			chain.assert(t, success)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		dir := t.TempDir()
+		e := newExpect(t, SchemaDriftOpts{Dir: dir})
+
+		send(e, "get_user", `{"id": 1}`).
+			chain.assert(t, success)
+
+		t.Setenv("UPDATE_SNAPSHOTS", "1")
+
+		send(e, "get_user", `{"id": "1"}`).
+			chain.assert(t, success)
+
+		t.Setenv("UPDATE_SNAPSHOTS", "")
+
+		send(e, "get_user", `{"id": "2"}`).
+			chain.assert(t, success)
+	})
+
+	t.Run("multiple options", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+		})
+
+		assert.Panics(t, func() {
+			e.WithSchemaDrift(SchemaDriftOpts{}, SchemaDriftOpts{})
+		})
+	})
+}
@@ -0,0 +1,364 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// SQLInjectionPayloads is a list of common SQL injection payloads.
+// See FuzzSQLInjection.
+var SQLInjectionPayloads = []string{
+	`' OR '1'='1`,
+	`' OR 1=1--`,
+	`" OR "1"="1`,
+	`') OR ('1'='1`,
+	`admin'--`,
+	`1 OR 1=1`,
+	`1; DROP TABLE users--`,
+	`' UNION SELECT NULL--`,
+	`' UNION SELECT NULL,NULL--`,
+	`1' AND SLEEP(5)--`,
+	`'; WAITFOR DELAY '0:0:5'--`,
+}
+
+// XSSPayloads is a list of common cross-site scripting payloads.
+// See FuzzXSS and Response.NoReflectedPayloads.
+var XSSPayloads = []string{
+	`<script>alert(1)</script>`,
+	`"><script>alert(1)</script>`,
+	`'><script>alert(1)</script>`,
+	`<img src=x onerror=alert(1)>`,
+	`<svg onload=alert(1)>`,
+	`<body onload=alert(1)>`,
+	`<iframe src="javascript:alert(1)">`,
+	`javascript:alert(1)`,
+	`" onmouseover="alert(1)`,
+}
+
+// PathTraversalPayloads is a list of common path traversal payloads.
+// See FuzzPathTraversal.
+var PathTraversalPayloads = []string{
+	`../../../../etc/passwd`,
+	`..%2f..%2f..%2f..%2fetc%2fpasswd`,
+	`%2e%2e%2f%2e%2e%2f%2e%2e%2fetc%2fpasswd`,
+	`....//....//....//etc/passwd`,
+	`..\..\..\..\windows\win.ini`,
+	`/etc/passwd`,
+	`file:///etc/passwd`,
+	`../../../../etc/passwd%00.png`,
+}
+
+// Default sizes used by FuzzOversized, from small to large.
+var defaultOversizedSizes = []int{8 << 10, 16 << 10, 64 << 10, 1 << 20}
+
+// FuzzSQLInjection returns generator that chooses one of SQL injection
+// payloads from SQLInjectionPayloads.
+//
+// Example:
+//
+//	e.Fuzz("GET", "/users").
+//		WithQueryParam("name", httpexpect.FuzzSQLInjection()).
+//		WithProperty(httpexpect.FuzzNoServerErrors).
+//		WithProperty(httpexpect.FuzzNoStackTraces).
+//		Run(20)
+func FuzzSQLInjection() FuzzGenerator {
+	return fuzzPayloads(SQLInjectionPayloads)
+}
+
+// FuzzXSS returns generator that chooses one of cross-site scripting
+// payloads from XSSPayloads.
+//
+// Example:
+//
+//	e.Fuzz("POST", "/comments").
+//		WithJSONParam("$.text", httpexpect.FuzzXSS()).
+//		WithProperty(httpexpect.FuzzNoReflectedPayloads).
+//		Run(20)
+func FuzzXSS() FuzzGenerator {
+	return fuzzPayloads(XSSPayloads)
+}
+
+// FuzzPathTraversal returns generator that chooses one of path traversal
+// payloads from PathTraversalPayloads.
+//
+// Example:
+//
+//	e.Fuzz("GET", "/files/{name}").
+//		WithPathParam("name", httpexpect.FuzzPathTraversal()).
+//		WithProperty(func(resp *httpexpect.Response) {
+//			resp.Body().NotContains("root:")
+//		}).
+//		Run(20)
+func FuzzPathTraversal() FuzzGenerator {
+	return fuzzPayloads(PathTraversalPayloads)
+}
+
+// FuzzOversized returns generator of long strings with one of given
+// sizes, e.g. to check how server handles oversized headers.
+// If no sizes are given, 8KiB, 16KiB, 64KiB, and 1MiB are used.
+//
+// If any size is not positive, the function panics.
+//
+// Example:
+//
+//	e.Fuzz("GET", "/users").
+//		WithHeaderParam("X-Request-Id", httpexpect.FuzzOversized()).
+//		WithProperty(httpexpect.FuzzNoServerErrors).
+//		Run(10)
+func FuzzOversized(sizes ...int) FuzzGenerator {
+	if len(sizes) == 0 {
+		sizes = defaultOversizedSizes
+	}
+
+	for _, size := range sizes {
+		if size <= 0 {
+			panic("non-positive size")
+		}
+	}
+
+	sizes = append([]int(nil), sizes...)
+
+	return func(src FuzzSource) interface{} {
+		return strings.Repeat("A", sizes[src.Intn(len(sizes))])
+	}
+}
+
+func fuzzPayloads(payloads []string) FuzzGenerator {
+	values := make([]interface{}, 0, len(payloads))
+	for _, p := range payloads {
+		values = append(values, p)
+	}
+
+	return FuzzOneOf(values...)
+}
+
+// Patterns of stack traces and internal error details which should not
+// leak into response bodies. Patterns are not anchored to line starts,
+// because stack traces in JSON bodies are usually escaped into one line.
+var securityLeakPatterns = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"Go", regexp.MustCompile(`goroutine \d+ \[[\w ,]+\]:`)},
+	{"Java", regexp.MustCompile(`\bat [\w$.]+\([\w$]+\.(?:java|kt|scala):\d+\)`)},
+	{"Java", regexp.MustCompile(`Exception in thread "`)},
+	{"Python", regexp.MustCompile(`Traceback \(most recent call last\):`)},
+	{"Node.js", regexp.MustCompile(`\bat [^()]+ \([^()]+\.[cm]?[jt]s:\d+:\d+\)`)},
+	{".NET", regexp.MustCompile(`\bat [\w.<>]+\([^()]*\) in .+?:line \d+`)},
+	{"PHP", regexp.MustCompile(`#\d+ \S+\.php\(\d+\): `)},
+	{"Ruby", regexp.MustCompile("\\S+\\.rb:\\d+:in `")},
+	{"SQL", regexp.MustCompile(`You have an error in your SQL syntax` +
+		`|SQLSTATE\[\w+\]|ORA-\d{5}:|syntax error at or near "` +
+		`|unterminated quoted string at or near|SQLite3::|near "[^"]*": syntax error`)},
+}
+
+// NoStackTraces succeeds if response body does not contain stack traces
+// or database error messages.
+//
+// Leaked stack traces and database errors disclose internal details
+// of the server and often indicate unhandled errors, e.g. caused by
+// injection payloads. Detected are Go, Java, Python, Node.js, .NET,
+// PHP, and Ruby stack traces, and MySQL, PostgreSQL, Oracle, and
+// SQLite error messages.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.NoStackTraces()
+func (r *Response) NoStackTraces() *Response {
+	opChain := r.chain.enter("NoStackTraces()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	content, ok := r.getContent(opChain, "NoStackTraces()")
+	if !ok {
+		return r
+	}
+
+	for _, pattern := range securityLeakPatterns {
+		if pattern.re.Match(content) {
+			opChain.fail(AssertionFailure{
+				Type:     AssertNotMatchRegexp,
+				Actual:   &AssertionValue{string(content)},
+				Expected: &AssertionValue{pattern.re.String()},
+				Errors: []error{
+					fmt.Errorf("expected: response body does not contain"+
+						" %s stack trace or error details", pattern.name),
+				},
+			})
+			return r
+		}
+	}
+
+	return r
+}
+
+// NoReflectedPayloads succeeds if response body does not contain
+// verbatim any of given payloads that were sent in request.
+//
+// Payload is considered sent if it is present in request path, query,
+// headers, or body (JSON bodies are decoded). Payload reflected without
+// escaping is a sign of injection vulnerability, e.g. reflected XSS.
+//
+// If no payloads are given, XSSPayloads are used.
+//
+// Example:
+//
+//	resp := e.GET("/search").
+//		WithQuery("q", "<script>alert(1)</script>").
+//		Expect()
+//
+//	resp.NoReflectedPayloads()
+func (r *Response) NoReflectedPayloads(payloads ...string) *Response {
+	opChain := r.chain.enter("NoReflectedPayloads()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if len(payloads) == 0 {
+		payloads = XSSPayloads
+	}
+
+	var httpReq *http.Request
+	if req := r.chain.context.Request; req != nil {
+		httpReq = req.httpReq
+	}
+	if httpReq == nil {
+		httpReq = r.httpResp.Request
+	}
+	if httpReq == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected response without request"),
+			},
+		})
+		return r
+	}
+
+	content, ok := r.getContent(opChain, "NoReflectedPayloads()")
+	if !ok {
+		return r
+	}
+
+	sent := securityRequestText(httpReq)
+
+	for _, payload := range payloads {
+		if payload == "" || !strings.Contains(sent, payload) {
+			continue
+		}
+
+		if strings.Contains(string(content), payload) {
+			opChain.fail(AssertionFailure{
+				Type:     AssertNotContainsSubset,
+				Actual:   &AssertionValue{string(content)},
+				Expected: &AssertionValue{payload},
+				Errors: []error{
+					errors.New("expected: response body does not reflect" +
+						" payload sent in request"),
+				},
+			})
+			return r
+		}
+	}
+
+	return r
+}
+
+// FuzzNoStackTraces is a property for Fuzzer.WithProperty that
+// invokes Response.NoStackTraces.
+//
+// Example:
+//
+//	fuzzer.WithProperty(httpexpect.FuzzNoStackTraces)
+func FuzzNoStackTraces(resp *Response) {
+	resp.NoStackTraces()
+}
+
+// FuzzNoReflectedPayloads is a property for Fuzzer.WithProperty that
+// invokes Response.NoReflectedPayloads with XSSPayloads.
+//
+// Example:
+//
+//	fuzzer.WithProperty(httpexpect.FuzzNoReflectedPayloads)
+func FuzzNoReflectedPayloads(resp *Response) {
+	resp.NoReflectedPayloads()
+}
+
+// Collect decoded text of request path, query, headers, and body.
+func securityRequestText(httpReq *http.Request) string {
+	var sb strings.Builder
+
+	if httpReq.URL != nil {
+		sb.WriteString(httpReq.URL.Path)
+		sb.WriteByte('\n')
+
+		if query, err := url.QueryUnescape(httpReq.URL.RawQuery); err == nil {
+			sb.WriteString(query)
+		} else {
+			sb.WriteString(httpReq.URL.RawQuery)
+		}
+		sb.WriteByte('\n')
+	}
+
+	for _, values := range httpReq.Header {
+		for _, value := range values {
+			sb.WriteString(value)
+			sb.WriteByte('\n')
+		}
+	}
+
+	getBody := httpReq.GetBody
+	if wrapper, ok := httpReq.Body.(*bodyWrapper); ok {
+		getBody = wrapper.GetBody
+	}
+
+	if getBody != nil {
+		if body, err := getBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			_ = body.Close()
+
+			sb.Write(data)
+			sb.WriteByte('\n')
+
+			// JSON encoders usually escape characters like "<" and ">"
+			var value interface{}
+			if isJSONContent(httpReq.Header) && json.Unmarshal(data, &value) == nil {
+				securityJSONStrings(&sb, value)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func securityJSONStrings(sb *strings.Builder, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		sb.WriteString(v)
+		sb.WriteByte('\n')
+
+	case []interface{}:
+		for _, elem := range v {
+			securityJSONStrings(sb, elem)
+		}
+
+	case map[string]interface{}:
+		for key, elem := range v {
+			sb.WriteString(key)
+			sb.WriteByte('\n')
+			securityJSONStrings(sb, elem)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package httpexpect
+
+import (
+	"bytes"
+	"html"
+	"io"
+	"math/rand"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurity_NoStackTraces(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		result chainResult
+	}{
+		{"empty", ``, success},
+		{"plain", `user not found`, success},
+		{"json", `{"error":"invalid input","at":"line 3"}`, success},
+		{"go",
+			"panic: oops\n\ngoroutine 1 [running]:\nmain.main()", failure},
+		{"java",
+			"java.lang.NullPointerException\n" +
+				"\tat com.example.Foo.bar(Foo.java:42)", failure},
+		{"java thread",
+			`Exception in thread "main" java.lang.Error`, failure},
+		{"python",
+			"Traceback (most recent call last):\n  File \"app.py\"", failure},
+		{"node json",
+			`{"stack":"TypeError: x\n    at Object.<anonymous> (/app/index.js:3:9)"}`,
+			failure},
+		{"dotnet",
+			`   at App.Controllers.Users.Get(Int32 id) in C:\app\Users.cs:line 17`,
+			failure},
+		{"php",
+			"Stack trace:\n#0 /var/www/index.php(12): query()", failure},
+		{"ruby",
+			"app/models/user.rb:10:in `find'", failure},
+		{"mysql",
+			`You have an error in your SQL syntax; check the manual`, failure},
+		{"postgres",
+			`ERROR: syntax error at or near "OR"`, failure},
+		{"oracle",
+			`ORA-01756: quoted string not properly terminated`, failure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       io.NopCloser(bytes.NewBufferString(tc.body)),
+			})
+
+			resp.NoStackTraces()
+			resp.chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestSecurity_NoReflectedPayloads(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/raw":
+			_, _ = w.Write([]byte("<p>" + r.URL.Query().Get("q") + "</p>"))
+
+		case "/escaped":
+			_, _ = w.Write([]byte("<p>" + html.EscapeString(r.URL.Query().Get("q")) + "</p>"))
+
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+
+		case "/static":
+			_, _ = w.Write([]byte(`<a href="javascript:alert(1)">x</a>`))
+		}
+	})
+
+	newExpect := func(t *testing.T) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+	}
+
+	t.Run("reflected", func(t *testing.T) {
+		resp := newExpect(t).GET("/raw").
+			WithQuery("q", XSSPayloads[0]).
+			Expect().
+			NoReflectedPayloads()
+
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("escaped", func(t *testing.T) {
+		resp := newExpect(t).GET("/escaped").
+			WithQuery("q", XSSPayloads[0]).
+			Expect().
+			NoReflectedPayloads()
+
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("not sent", func(t *testing.T) {
+		resp := newExpect(t).GET("/static").
+			Expect().
+			NoReflectedPayloads()
+
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("json body", func(t *testing.T) {
+		resp := newExpect(t).POST("/echo").
+			WithJSON(map[string]interface{}{"text": XSSPayloads[3]}).
+			Expect().
+			NoReflectedPayloads()
+
+		// JSON encoder escapes "<" and ">", so echoed body is safe
+		resp.chain.assert(t, success)
+
+		resp = newExpect(t).POST("/echo").
+			WithText(XSSPayloads[3]).
+			Expect().
+			NoReflectedPayloads()
+
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("custom payloads", func(t *testing.T) {
+		resp := newExpect(t).GET("/raw").
+			WithQuery("q", "canary123").
+			Expect().
+			NoReflectedPayloads("canary123")
+
+		resp.chain.assert(t, failure)
+	})
+}
+
+func TestSecurity_Fuzz(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<p>" + r.URL.Query().Get("q") + "</p>"))
+	})
+
+	assertionHandler := &mockAssertionHandler{}
+
+	e := WithConfig(Config{
+		BaseURL:          "http://example.com",
+		AssertionHandler: assertionHandler,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	resps := e.Fuzz("GET", "/").
+		WithSeed(1).
+		WithQueryParam("q", FuzzXSS()).
+		WithProperty(FuzzNoStackTraces).
+		WithProperty(FuzzNoReflectedPayloads).
+		Run(10)
+
+	assert.Equal(t, 1, assertionHandler.failureCalled)
+	assert.Equal(t, 1, len(resps))
+
+	httpReq := resps[0].chain.context.Request.httpReq
+	assert.Equal(t, XSSPayloads[0], httpReq.URL.Query().Get("q"))
+}
+
+func TestSecurity_Generators(t *testing.T) {
+	src := rand.New(rand.NewSource(1)) //nolint
+
+	for n := 0; n < 20; n++ {
+		assert.Contains(t, SQLInjectionPayloads, FuzzSQLInjection()(src))
+		assert.Contains(t, XSSPayloads, FuzzXSS()(src))
+		assert.Contains(t, PathTraversalPayloads, FuzzPathTraversal()(src))
+
+		s := FuzzOversized(10, 20)(src).(string)
+		assert.Contains(t, []int{10, 20}, len(s))
+
+		s = FuzzOversized()(src).(string)
+		assert.GreaterOrEqual(t, len(s), 8<<10)
+	}
+
+	assert.Panics(t, func() { FuzzOversized(0) })
+}
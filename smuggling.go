@@ -0,0 +1,420 @@
+package httpexpect
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SmugglingVariant defines kind of ambiguous request framing sent by
+// SmugglingProbe.
+type SmugglingVariant int
+
+const (
+	// SmugglingCLTE sends request with both "Content-Length" and
+	// "Transfer-Encoding: chunked" headers, which disagree on body length.
+	SmugglingCLTE SmugglingVariant = iota + 1
+
+	// SmugglingDuplicateCL sends request with two "Content-Length" headers
+	// with different values.
+	SmugglingDuplicateCL
+
+	// SmugglingTESpace sends request with whitespace between
+	// "Transfer-Encoding" header name and colon.
+	SmugglingTESpace
+
+	// SmugglingTEObfuscated sends request with two "Transfer-Encoding"
+	// headers, "chunked" and unknown one.
+	SmugglingTEObfuscated
+
+	// SmugglingObsFold sends request with "Transfer-Encoding" header value
+	// on continuation line (obsolete line folding).
+	SmugglingObsFold
+)
+
+// SmugglingVariants is a list of all supported smuggling variants.
+var SmugglingVariants = []SmugglingVariant{
+	SmugglingCLTE,
+	SmugglingDuplicateCL,
+	SmugglingTESpace,
+	SmugglingTEObfuscated,
+	SmugglingObsFold,
+}
+
+func (v SmugglingVariant) String() string {
+	switch v {
+	case SmugglingCLTE:
+		return "CL.TE"
+	case SmugglingDuplicateCL:
+		return "duplicate Content-Length"
+	case SmugglingTESpace:
+		return "Transfer-Encoding with space before colon"
+	case SmugglingTEObfuscated:
+		return "obfuscated Transfer-Encoding"
+	case SmugglingObsFold:
+		return "obs-fold Transfer-Encoding"
+	}
+	return fmt.Sprintf("SmugglingVariant(%d)", int(v))
+}
+
+const defaultSmugglingTimeout = 5 * time.Second
+
+// SmugglingProbe sends requests with ambiguous framing over raw connection
+// and checks how server handles them.
+//
+// Such requests are used in HTTP request smuggling attacks, when frontend
+// (e.g. proxy) and backend disagree on where one request ends and another
+// begins. Robust server should reject them with "400 Bad Request".
+//
+// Requests are written directly to TCP (or TLS) connection, bypassing
+// http.Client, because it would refuse to send malformed requests.
+// Config.Client, printers, and retries are not used, but
+// Config.DNSOverrides and Config.RateLimit are respected.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.SmugglingProbe("/upload").
+//		ExpectRejected()
+//
+//	e.SmugglingProbe("/upload").
+//		Send(httpexpect.SmugglingTEObfuscated).
+//		StatusList(http.StatusBadRequest, http.StatusNotImplemented)
+type SmugglingProbe struct {
+	noCopy noCopy
+	config Config
+	chain  *chain
+
+	mu sync.Mutex
+
+	url       *url.URL
+	timeout   time.Duration
+	tlsConfig *tls.Config
+}
+
+// NewSmugglingProbeC returns a new SmugglingProbe instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// path is appended to Config.BaseURL.
+//
+// Example:
+//
+//	probe := NewSmugglingProbeC(config, "/upload")
+//	probe.ExpectRejected()
+func NewSmugglingProbeC(config Config, path string) *SmugglingProbe {
+	config = config.withDefaults()
+
+	return newSmugglingProbe(
+		newChainWithConfig("SmugglingProbe()", config), config, path)
+}
+
+func newSmugglingProbe(parent *chain, config Config, path string) *SmugglingProbe {
+	config.validate()
+
+	p := &SmugglingProbe{
+		config:  config,
+		chain:   parent.clone(),
+		timeout: defaultSmugglingTimeout,
+	}
+
+	opChain := p.chain.enter("")
+	defer opChain.leave()
+
+	u, err := url.Parse(concatPaths(config.BaseURL, path))
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{path},
+			Errors: []error{
+				errors.New("invalid url"),
+				err,
+			},
+		})
+		return p
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{u.String()},
+			Errors: []error{
+				errors.New(`expected: url with "http" or "https" scheme`),
+			},
+		})
+		return p
+	}
+
+	p.url = u
+
+	p.tlsConfig = clientTLSConfig(config.Client)
+
+	return p
+}
+
+// SmugglingProbe returns a new SmugglingProbe instance for given path.
+// path is appended to Config.BaseURL.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//	e.SmugglingProbe("/upload").ExpectRejected()
+func (e *Expect) SmugglingProbe(path string) *SmugglingProbe {
+	opChain := e.chain.enter("SmugglingProbe(%q)", path)
+	defer opChain.leave()
+
+	return newSmugglingProbe(opChain, e.config, path)
+}
+
+// Alias is similar to Value.Alias.
+func (p *SmugglingProbe) Alias(name string) *SmugglingProbe {
+	opChain := p.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.chain.setAlias(name)
+	return p
+}
+
+// WithTimeout sets timeout for connecting, sending request, and receiving
+// response. Default is 5 seconds.
+//
+// Example:
+//
+//	probe := NewSmugglingProbeC(config, "/upload")
+//	probe.WithTimeout(time.Second)
+func (p *SmugglingProbe) WithTimeout(timeout time.Duration) *SmugglingProbe {
+	opChain := p.chain.enter("WithTimeout()")
+	defer opChain.leave()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if opChain.failed() {
+		return p
+	}
+
+	if timeout <= 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{timeout},
+			Errors: []error{
+				errors.New("invalid non-positive timeout argument"),
+			},
+		})
+		return p
+	}
+
+	p.timeout = timeout
+
+	return p
+}
+
+// WithTLSConfig sets TLS config used for "https" URLs.
+//
+// By default, TLS config of Config.Client is used, if it's *http.Client
+// with *http.Transport.
+//
+// Example:
+//
+//	probe := NewSmugglingProbeC(config, "/upload")
+//	probe.WithTLSConfig(&tls.Config{RootCAs: pool})
+func (p *SmugglingProbe) WithTLSConfig(tlsConfig *tls.Config) *SmugglingProbe {
+	opChain := p.chain.enter("WithTLSConfig()")
+	defer opChain.leave()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if opChain.failed() {
+		return p
+	}
+
+	p.tlsConfig = tlsConfig
+
+	return p
+}
+
+// Send sends request with given ambiguous framing and returns a new
+// Response instance for received response.
+//
+// If connection is closed without response, failure is reported.
+//
+// Example:
+//
+//	probe := NewSmugglingProbeC(config, "/upload")
+//	probe.Send(SmugglingCLTE).Status(http.StatusBadRequest)
+func (p *SmugglingProbe) Send(variant SmugglingVariant) *Response {
+	opChain := p.chain.enter("Send(%s)", variant)
+	defer opChain.leave()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if opChain.failed() || !checkSmugglingVariants(opChain, variant) {
+		return newResponse(responseOpts{
+			config: p.config,
+			chain:  opChain,
+		})
+	}
+
+	httpResp, elapsed, err := p.send(variant)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to send %s request", variant),
+				err,
+			},
+		})
+		return newResponse(responseOpts{
+			config: p.config,
+			chain:  opChain,
+		})
+	}
+
+	return newResponse(responseOpts{
+		config:   p.config,
+		chain:    opChain,
+		httpResp: httpResp,
+		rtt:      []time.Duration{elapsed},
+	})
+}
+
+// ExpectRejected sends request with every given ambiguous framing, and
+// succeeds if server responds to each with "400 Bad Request".
+//
+// If no variants are given, SmugglingVariants is used. Failure lists all
+// variants that were not rejected.
+//
+// Example:
+//
+//	probe := NewSmugglingProbeC(config, "/upload")
+//	probe.ExpectRejected(SmugglingCLTE, SmugglingDuplicateCL)
+func (p *SmugglingProbe) ExpectRejected(variants ...SmugglingVariant) *SmugglingProbe {
+	opChain := p.chain.enter("ExpectRejected()")
+	defer opChain.leave()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if opChain.failed() {
+		return p
+	}
+
+	if len(variants) == 0 {
+		variants = SmugglingVariants
+	}
+
+	if !checkSmugglingVariants(opChain, variants...) {
+		return p
+	}
+
+	var errs []error
+
+	for _, variant := range variants {
+		httpResp, _, err := p.send(variant)
+
+		switch {
+		case err != nil:
+			errs = append(errs,
+				fmt.Errorf("%s: failed to receive response: %s", variant, err))
+
+		case httpResp.StatusCode != http.StatusBadRequest:
+			errs = append(errs,
+				fmt.Errorf("%s: got status %q", variant,
+					statusCodeText(httpResp.StatusCode)))
+		}
+	}
+
+	if len(errs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: append([]error{
+				fmt.Errorf(
+					"expected: requests with ambiguous framing are rejected with %q",
+					statusCodeText(http.StatusBadRequest)),
+			}, errs...),
+		})
+	}
+
+	return p
+}
+
+func checkSmugglingVariants(opChain *chain, variants ...SmugglingVariant) bool {
+	for _, variant := range variants {
+		if variant < SmugglingCLTE || variant > SmugglingObsFold {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("unexpected smuggling variant %d", int(variant)),
+				},
+			})
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *SmugglingProbe) send(
+	variant SmugglingVariant,
+) (*http.Response, time.Duration, error) {
+	if err := waitRateLimit(p.config, nil); err != nil {
+		return nil, 0, err
+	}
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), p.timeout)
+	defer cancelFn()
+
+	return sendRawRequest(ctx, p.url,
+		rawResolveFunc(p.config, ""), p.tlsConfig, p.payload(variant))
+}
+
+func (p *SmugglingProbe) payload(variant SmugglingVariant) []byte {
+	var b strings.Builder
+
+	b.WriteString("POST " + p.url.RequestURI() + " HTTP/1.1\r\n")
+	b.WriteString("Host: " + p.url.Host + "\r\n")
+
+	switch variant {
+	case SmugglingCLTE:
+		b.WriteString("Content-Length: 6\r\n")
+		b.WriteString("Transfer-Encoding: chunked\r\n")
+		b.WriteString("\r\n")
+		b.WriteString("0\r\n\r\nX")
+
+	case SmugglingDuplicateCL:
+		b.WriteString("Content-Length: 1\r\n")
+		b.WriteString("Content-Length: 2\r\n")
+		b.WriteString("\r\n")
+		b.WriteString("XX")
+
+	case SmugglingTESpace:
+		b.WriteString("Transfer-Encoding : chunked\r\n")
+		b.WriteString("\r\n")
+		b.WriteString("0\r\n\r\n")
+
+	case SmugglingTEObfuscated:
+		b.WriteString("Transfer-Encoding: chunked\r\n")
+		b.WriteString("Transfer-Encoding: x-smuggle\r\n")
+		b.WriteString("\r\n")
+		b.WriteString("0\r\n\r\n")
+
+	case SmugglingObsFold:
+		b.WriteString("Transfer-Encoding:\r\n")
+		b.WriteString(" chunked\r\n")
+		b.WriteString("\r\n")
+		b.WriteString("0\r\n\r\n")
+	}
+
+	return []byte(b.String())
+}
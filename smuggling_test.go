@@ -0,0 +1,285 @@
+package httpexpect
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRawServer struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	requests []string
+}
+
+// starts raw TCP server which reads request headers and replies with
+// given raw response; if response is empty, closes connection
+func newMockRawServer(t *testing.T, response string) *mockRawServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &mockRawServer{
+		listener: listener,
+	}
+
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			reader := bufio.NewReader(conn)
+
+			var request strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				request.WriteString(line)
+				if err != nil || line == "\r\n" {
+					break
+				}
+			}
+
+			srv.mu.Lock()
+			srv.requests = append(srv.requests, request.String())
+			srv.mu.Unlock()
+
+			if response != "" {
+				_, _ = conn.Write([]byte(response))
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	return srv
+}
+
+func (srv *mockRawServer) url() string {
+	return "http://" + srv.listener.Addr().String()
+}
+
+func (srv *mockRawServer) close() {
+	_ = srv.listener.Close()
+	srv.wg.Wait()
+}
+
+func TestSmugglingProbe_FailedChain(t *testing.T) {
+	reporter := newMockReporter(t)
+	config := newMockConfig(reporter)
+	chain := newChainWithDefaults("test", reporter, flagFailed)
+
+	probe := newSmugglingProbe(chain, config, "/")
+
+	probe.Alias("foo")
+	probe.WithTimeout(time.Second)
+	probe.WithTLSConfig(nil)
+
+	probe.Send(SmugglingCLTE).chain.assert(t, failure)
+	probe.ExpectRejected()
+
+	probe.chain.assert(t, failure)
+}
+
+func TestSmugglingProbe_Constructors(t *testing.T) {
+	t.Run("Expect.SmugglingProbe", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+		})
+
+		probe := e.SmugglingProbe("/path")
+		probe.chain.assert(t, success)
+
+		assert.Equal(t, "http://example.com/path", probe.url.String())
+	})
+
+	t.Run("NewSmugglingProbeC", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := Config{
+			BaseURL:  "https://example.com/api",
+			Reporter: reporter,
+		}
+
+		probe := NewSmugglingProbeC(config, "path")
+		probe.chain.assert(t, success)
+
+		assert.Equal(t, "https://example.com/api/path", probe.url.String())
+	})
+
+	t.Run("bad scheme", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := Config{
+			BaseURL:  "ws://example.com",
+			Reporter: reporter,
+		}
+
+		probe := NewSmugglingProbeC(config, "/path")
+		probe.chain.assert(t, failure)
+	})
+}
+
+func TestSmugglingProbe_Send(t *testing.T) {
+	t.Run("response", func(t *testing.T) {
+		srv := newMockRawServer(t,
+			"HTTP/1.1 400 Bad Request\r\nContent-Length: 3\r\n\r\nbad")
+		defer srv.close()
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = srv.url()
+
+		probe := NewSmugglingProbeC(config, "/upload")
+
+		resp := probe.Send(SmugglingCLTE)
+		resp.chain.assert(t, success)
+
+		resp.Status(http.StatusBadRequest)
+		resp.Body().IsEqual("bad")
+		resp.chain.assert(t, success)
+
+		require.Equal(t, 1, len(srv.requests))
+		assert.True(t, strings.HasPrefix(srv.requests[0], "POST /upload HTTP/1.1\r\n"))
+		assert.Contains(t, srv.requests[0], "Content-Length: 6\r\n")
+		assert.Contains(t, srv.requests[0], "Transfer-Encoding: chunked\r\n")
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		srv := newMockRawServer(t, "")
+		defer srv.close()
+
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = srv.url()
+
+		probe := NewSmugglingProbeC(config, "/upload")
+
+		resp := probe.Send(SmugglingCLTE)
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("dns overrides", func(t *testing.T) {
+		srv := newMockRawServer(t,
+			"HTTP/1.1 400 Bad Request\r\nContent-Length: 3\r\n\r\nbad")
+		defer srv.close()
+
+		config := Config{
+			BaseURL:  "http://example.invalid",
+			Reporter: newMockReporter(t),
+			DNSOverrides: map[string]string{
+				"example.invalid": srv.listener.Addr().String(),
+			},
+		}
+
+		probe := NewSmugglingProbeC(config, "/upload")
+
+		resp := probe.Send(SmugglingCLTE)
+		resp.chain.assert(t, success)
+
+		resp.Status(http.StatusBadRequest)
+		resp.chain.assert(t, success)
+
+		srv.close()
+
+		require.Equal(t, 1, len(srv.requests))
+		assert.Contains(t, srv.requests[0], "Host: example.invalid\r\n")
+	})
+
+	t.Run("invalid variant", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+		config.BaseURL = "http://example.com"
+
+		probe := NewSmugglingProbeC(config, "/upload")
+
+		resp := probe.Send(SmugglingVariant(0))
+		resp.chain.assert(t, failure)
+	})
+}
+
+func TestSmugglingProbe_ExpectRejected(t *testing.T) {
+	cases := []struct {
+		name     string
+		response string
+		variants []SmugglingVariant
+		requests int
+		result   chainResult
+	}{
+		{
+			name:     "rejected",
+			response: "HTTP/1.1 400 Bad Request\r\nContent-Length: 0\r\n\r\n",
+			requests: len(SmugglingVariants),
+			result:   success,
+		},
+		{
+			name:     "rejected, selected variants",
+			response: "HTTP/1.1 400 Bad Request\r\nContent-Length: 0\r\n\r\n",
+			variants: []SmugglingVariant{SmugglingCLTE, SmugglingObsFold},
+			requests: 2,
+			result:   success,
+		},
+		{
+			name:     "accepted",
+			response: "HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n",
+			requests: len(SmugglingVariants),
+			result:   failure,
+		},
+		{
+			name:     "not implemented",
+			response: "HTTP/1.1 501 Not Implemented\r\nContent-Length: 0\r\n\r\n",
+			requests: len(SmugglingVariants),
+			result:   failure,
+		},
+		{
+			name:     "no response",
+			response: "",
+			requests: len(SmugglingVariants),
+			result:   failure,
+		},
+		{
+			name:     "invalid variant",
+			response: "HTTP/1.1 400 Bad Request\r\nContent-Length: 0\r\n\r\n",
+			variants: []SmugglingVariant{SmugglingCLTE, SmugglingVariant(100)},
+			requests: 0,
+			result:   failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newMockRawServer(t, tc.response)
+
+			config := newMockConfig(newMockReporter(t))
+			config.BaseURL = srv.url()
+
+			probe := NewSmugglingProbeC(config, "/upload").
+				WithTimeout(time.Second)
+
+			probe.ExpectRejected(tc.variants...)
+			probe.chain.assert(t, tc.result)
+
+			srv.close()
+
+			assert.Equal(t, tc.requests, len(srv.requests))
+		})
+	}
+}
+
+func TestSmugglingProbe_Usage(t *testing.T) {
+	config := newMockConfig(newMockReporter(t))
+	config.BaseURL = "http://example.com"
+
+	probe := NewSmugglingProbeC(config, "/upload")
+
+	probe.WithTimeout(0)
+	probe.chain.assert(t, failure)
+}
@@ -0,0 +1,454 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SnapshotOpts defines redaction parameters for Response.SnapshotValue
+// and Response.MatchSnapshot.
+type SnapshotOpts struct {
+	// Names of headers which values should be redacted.
+	// Header names are case-insensitive.
+	RedactHeaders []string
+
+	// Keys of JSON object fields which values should be redacted.
+	// Keys are matched at any nesting level and are case-sensitive.
+	// Used only if response has "application/json" Content-Type.
+	RedactKeys []string
+
+	// Function to redact volatile JSON values, like timestamps or ids.
+	// Invoked for every JSON object field at any nesting level; if it
+	// returns true, field value is redacted.
+	// Used only if response has "application/json" Content-Type.
+	RedactFunc func(key string, value interface{}) bool
+
+	// Value to be used instead of redacted values.
+	// If empty, "[REDACTED]" is used.
+	Placeholder string
+
+	// Names of headers to be stored in snapshot file, in addition to body.
+	// Used only by MatchSnapshot.
+	Headers []string
+}
+
+const defaultRedactPlaceholder = "[REDACTED]"
+
+// ResponseSnapshot is an immutable deep copy of http response.
+//
+// Unlike Response, ResponseSnapshot doesn't refer to assertion chain,
+// reporter, or underlying http.Response, so it can be safely passed to
+// other goroutines and used after the test is finished.
+//
+// All methods return copies of stored data, so the snapshot can not be
+// modified after creation.
+type ResponseSnapshot struct {
+	status     string
+	statusCode int
+	proto      string
+	header     http.Header
+	trailer    http.Header
+	body       []byte
+	rtt        *time.Duration
+}
+
+// SnapshotValue returns a new ResponseSnapshot with deep copy of response
+// status, headers, trailers, and body.
+//
+// If options are given, specified headers and JSON fields are redacted
+// in the snapshot. JSON body is also redacted using Config.Redactors.
+// Response itself is not modified. If JSON fields are redacted,
+// Content-Length header of the snapshot is updated to match the
+// redacted body.
+//
+// Example:
+//
+//	snap := resp.SnapshotValue(SnapshotOpts{
+//		RedactHeaders: []string{"Authorization", "Set-Cookie"},
+//		RedactKeys:    []string{"token"},
+//	})
+//
+//	go func() {
+//		NewResponse(NewPanicReporter(), snap.Response()).
+//			Status(http.StatusOK)
+//	}()
+func (r *Response) SnapshotValue(options ...SnapshotOpts) *ResponseSnapshot {
+	opChain := r.chain.enter("SnapshotValue()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return &ResponseSnapshot{}
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return &ResponseSnapshot{}
+	}
+
+	var opts SnapshotOpts
+	if len(options) != 0 {
+		opts = options[0]
+	}
+	if opts.Placeholder == "" {
+		opts.Placeholder = defaultRedactPlaceholder
+	}
+
+	content, ok := r.getContent(opChain, "SnapshotValue()")
+	if !ok {
+		return &ResponseSnapshot{}
+	}
+
+	snap := &ResponseSnapshot{
+		status:     r.httpResp.Status,
+		statusCode: r.httpResp.StatusCode,
+		proto:      r.httpResp.Proto,
+		header:     redactHeader(r.httpResp.Header, opts),
+		trailer:    redactHeader(r.httpResp.Trailer, opts),
+		body:       append([]byte{}, content...),
+	}
+
+	if r.rtt != nil {
+		rtt := *r.rtt
+		snap.rtt = &rtt
+	}
+
+	if (len(opts.RedactKeys) != 0 || opts.RedactFunc != nil ||
+		len(r.config.Redactors) != 0) && isJSONContent(r.httpResp.Header) {
+		snap.body = redactJSON(r.config.Redactors, snap.body, opts)
+
+		// redacted body is re-encoded and may have different length
+		if values := snap.header["Content-Length"]; len(values) != 0 &&
+			!isRedactedHeader("Content-Length", opts) {
+			snap.header.Set("Content-Length", strconv.Itoa(len(snap.body)))
+		}
+	}
+
+	return snap
+}
+
+// MatchSnapshot succeeds if response matches golden file with given name.
+//
+// Golden file is stored in Config.SnapshotDir (by default, "testdata/snapshots")
+// and is named "<name>.snap". It contains canonicalized response body
+// (for JSON, pretty-printed with sorted keys) preceded by headers listed in
+// options, if any.
+//
+// If golden file doesn't exist, it is created and assertion succeeds.
+// If UPDATE_SNAPSHOTS environment variable is set to a non-empty value other
+// than "0" and "false", golden file is overwritten and assertion succeeds.
+//
+// Volatile values, like timestamps and ids, can be redacted using options
+// before comparison. See SnapshotOpts.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.MatchSnapshot("get_user", SnapshotOpts{
+//		Headers:    []string{"Content-Type"},
+//		RedactKeys: []string{"id", "created_at"},
+//	})
+func (r *Response) MatchSnapshot(name string, options ...SnapshotOpts) *Response {
+	opChain := r.chain.enter("MatchSnapshot(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return r
+	}
+
+	if name == "" || filepath.IsAbs(name) ||
+		strings.HasPrefix(filepath.Clean(name), "..") {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid snapshot name %q", name),
+			},
+		})
+		return r
+	}
+
+	var opts SnapshotOpts
+	if len(options) != 0 {
+		opts = options[0]
+	}
+	if opts.Placeholder == "" {
+		opts.Placeholder = defaultRedactPlaceholder
+	}
+
+	content, ok := r.getContent(opChain, "MatchSnapshot()")
+	if !ok {
+		return r
+	}
+
+	actual := r.formatSnapshot(content, opts)
+
+	dir := r.config.SnapshotDir
+	if dir == "" {
+		dir = defaultSnapshotDir
+	}
+
+	path := filepath.Join(dir, filepath.FromSlash(name)+".snap")
+
+	expected, err := os.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) || updateSnapshots() {
+		if err := writeSnapshot(path, actual); err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("failed to write snapshot file %q", path),
+					err,
+				},
+			})
+		}
+		return r
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to read snapshot file %q", path),
+				err,
+			},
+		})
+		return r
+	}
+
+	if string(expected) != actual {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{string(expected)},
+			Errors: []error{
+				fmt.Errorf("expected: response matches snapshot %q", name),
+				fmt.Errorf("snapshot file: %s", path),
+				fmt.Errorf("set %s=1 to update snapshot", updateSnapshotsEnv),
+			},
+		})
+	}
+
+	return r
+}
+
+const (
+	defaultSnapshotDir = "testdata/snapshots"
+	updateSnapshotsEnv = "UPDATE_SNAPSHOTS"
+)
+
+func updateSnapshots() bool {
+	v := os.Getenv(updateSnapshotsEnv)
+	return v != "" && v != "0" && v != "false"
+}
+
+func writeSnapshot(path string, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+// Build canonical snapshot representation of response: selected headers,
+// empty line, and body. JSON body is redacted using both opts and
+// Config.Redactors, and is pretty-printed with sorted keys.
+func (r *Response) formatSnapshot(content []byte, opts SnapshotOpts) string {
+	var sb strings.Builder
+
+	if len(opts.Headers) != 0 {
+		header := redactHeader(r.httpResp.Header, opts)
+
+		for _, name := range opts.Headers {
+			key := http.CanonicalHeaderKey(name)
+			for _, value := range header[key] {
+				sb.WriteString(key + ": " + value + "\n")
+			}
+		}
+
+		sb.WriteString("\n")
+	}
+
+	body := string(content)
+
+	if isJSONContent(r.httpResp.Header) {
+		if value, ok := decodeRedactedJSON(content, opts); ok {
+			value = redactNodes(r.config.Redactors, value)
+			if b, err := json.MarshalIndent(value, "", "  "); err == nil {
+				body = string(b)
+			}
+		}
+	}
+
+	sb.WriteString(body)
+
+	if !strings.HasSuffix(body, "\n") {
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// StatusCode returns response status code.
+func (s *ResponseSnapshot) StatusCode() int {
+	return s.statusCode
+}
+
+// Header returns copy of response headers.
+func (s *ResponseSnapshot) Header() http.Header {
+	return s.header.Clone()
+}
+
+// Trailer returns copy of response trailers.
+func (s *ResponseSnapshot) Trailer() http.Header {
+	return s.trailer.Clone()
+}
+
+// Body returns copy of response body.
+func (s *ResponseSnapshot) Body() []byte {
+	return append([]byte{}, s.body...)
+}
+
+// RoundTripTime returns response round-trip time, or nil if it is unknown.
+func (s *ResponseSnapshot) RoundTripTime() *time.Duration {
+	if s.rtt == nil {
+		return nil
+	}
+	rtt := *s.rtt
+	return &rtt
+}
+
+// Response returns a new http.Response constructed from the snapshot.
+//
+// Every call returns a new independent http.Response, which can be passed
+// to NewResponse to perform assertions, e.g. from another goroutine.
+func (s *ResponseSnapshot) Response() *http.Response {
+	body := s.Body()
+
+	return &http.Response{
+		Status:        s.status,
+		StatusCode:    s.statusCode,
+		Proto:         s.proto,
+		Header:        s.Header(),
+		Trailer:       s.Trailer(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+}
+
+func isJSONContent(header http.Header) bool {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+
+	return mediaType == "application/json" ||
+		strings.HasSuffix(mediaType, "+json")
+}
+
+func redactHeader(header http.Header, opts SnapshotOpts) http.Header {
+	if header == nil {
+		return nil
+	}
+
+	ret := header.Clone()
+
+	for _, name := range opts.RedactHeaders {
+		key := http.CanonicalHeaderKey(name)
+
+		for n := range ret[key] {
+			ret[key][n] = opts.Placeholder
+		}
+	}
+
+	return ret
+}
+
+func isRedactedHeader(name string, opts SnapshotOpts) bool {
+	for _, redacted := range opts.RedactHeaders {
+		if http.CanonicalHeaderKey(redacted) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func redactJSON(
+	redactors []redactorFunc, content []byte, opts SnapshotOpts,
+) []byte {
+	value, ok := decodeRedactedJSON(content, opts)
+	if !ok {
+		return content
+	}
+
+	b, err := json.Marshal(redactNodes(redactors, value))
+	if err != nil {
+		return content
+	}
+
+	return b
+}
+
+func decodeRedactedJSON(content []byte, opts SnapshotOpts) (interface{}, bool) {
+	var value interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+
+	if err := dec.Decode(&value); err != nil {
+		return nil, false
+	}
+
+	keys := make(map[string]struct{}, len(opts.RedactKeys))
+	for _, k := range opts.RedactKeys {
+		keys[k] = struct{}{}
+	}
+
+	redactValue(value, keys, opts.RedactFunc, opts.Placeholder)
+
+	return value, true
+}
+
+func redactValue(
+	value interface{}, keys map[string]struct{},
+	fn func(string, interface{}) bool, placeholder string,
+) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, elem := range v {
+			if _, ok := keys[k]; ok {
+				v[k] = placeholder
+			} else if fn != nil && fn(k, elem) {
+				v[k] = placeholder
+			} else {
+				redactValue(elem, keys, fn, placeholder)
+			}
+		}
+
+	case []interface{}:
+		for _, elem := range v {
+			redactValue(elem, keys, fn, placeholder)
+		}
+	}
+}
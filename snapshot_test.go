@@ -0,0 +1,324 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_Basic(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": []string{"text/plain"},
+		},
+		Body: io.NopCloser(bytes.NewBufferString("hello")),
+	}
+
+	resp := NewResponse(reporter, httpResp, time.Second)
+
+	snap := resp.SnapshotValue()
+	resp.chain.assert(t, success)
+
+	assert.Equal(t, http.StatusOK, snap.StatusCode())
+	assert.Equal(t, "text/plain", snap.Header().Get("Content-Type"))
+	assert.Equal(t, []byte("hello"), snap.Body())
+	assert.Equal(t, time.Second, *snap.RoundTripTime())
+
+	// response is still usable after snapshot
+	resp.Body().IsEqual("hello")
+	resp.chain.assert(t, success)
+}
+
+func TestSnapshot_Immutable(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"X-Foo": []string{"foo"},
+		},
+		Body: io.NopCloser(bytes.NewBufferString("hello")),
+	}
+
+	snap := NewResponse(reporter, httpResp).SnapshotValue()
+
+	httpResp.Header.Set("X-Foo", "bar")
+
+	header := snap.Header()
+	header.Set("X-Foo", "baz")
+
+	body := snap.Body()
+	body[0] = 'X'
+
+	assert.Equal(t, "foo", snap.Header().Get("X-Foo"))
+	assert.Equal(t, []byte("hello"), snap.Body())
+}
+
+func TestSnapshot_Redact(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type":  []string{"application/json"},
+			"Authorization": []string{"secret"},
+		},
+		Body: io.NopCloser(bytes.NewBufferString(
+			`{"token":"secret","user":{"name":"john","token":"secret"}}`)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	snap := resp.SnapshotValue(SnapshotOpts{
+		RedactHeaders: []string{"authorization"},
+		RedactKeys:    []string{"token"},
+	})
+	resp.chain.assert(t, success)
+
+	assert.Equal(t, "[REDACTED]", snap.Header().Get("Authorization"))
+	assert.JSONEq(t,
+		`{"token":"[REDACTED]","user":{"name":"john","token":"[REDACTED]"}}`,
+		string(snap.Body()))
+
+	// response itself is not redacted
+	resp.Header("Authorization").IsEqual("secret")
+	resp.JSON().Object().Value("token").IsEqual("secret")
+	resp.chain.assert(t, success)
+
+	t.Run("custom placeholder", func(t *testing.T) {
+		httpResp := &http.Response{
+			Header: http.Header{
+				"X-Key": []string{"secret"},
+			},
+			Body: http.NoBody,
+		}
+
+		snap := NewResponse(reporter, httpResp).SnapshotValue(SnapshotOpts{
+			RedactHeaders: []string{"X-Key"},
+			Placeholder:   "***",
+		})
+
+		assert.Equal(t, "***", snap.Header().Get("X-Key"))
+	})
+
+	t.Run("content length", func(t *testing.T) {
+		body := `{"token":"secret"}`
+
+		newHTTPResp := func() *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type":   []string{"application/json"},
+					"Content-Length": []string{strconv.Itoa(len(body))},
+				},
+				Body:          io.NopCloser(bytes.NewBufferString(body)),
+				ContentLength: int64(len(body)),
+			}
+		}
+
+		snap := NewResponse(reporter, newHTTPResp()).SnapshotValue(SnapshotOpts{
+			RedactKeys: []string{"token"},
+		})
+
+		assert.Equal(t, `{"token":"[REDACTED]"}`, string(snap.Body()))
+		assert.Equal(t,
+			strconv.Itoa(len(snap.Body())), snap.Header().Get("Content-Length"))
+
+		resp := NewResponse(reporter, snap.Response())
+		resp.Header("Content-Length").IsEqual(strconv.Itoa(len(snap.Body())))
+		resp.JSON().Object().Value("token").IsEqual("[REDACTED]")
+		resp.chain.assert(t, success)
+
+		snap = NewResponse(reporter, newHTTPResp()).SnapshotValue(SnapshotOpts{
+			RedactHeaders: []string{"Content-Length"},
+			RedactKeys:    []string{"token"},
+		})
+
+		assert.Equal(t, "[REDACTED]", snap.Header().Get("Content-Length"))
+	})
+}
+
+func TestSnapshot_Goroutines(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusCreated,
+		Body:       io.NopCloser(bytes.NewBufferString("hello")),
+	}
+
+	snap := NewResponse(reporter, httpResp).SnapshotValue()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			resp := NewResponse(NewPanicReporter(), snap.Response())
+
+			resp.Status(http.StatusCreated)
+			resp.Body().IsEqual("hello")
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestSnapshot_Usage(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	httpResp := &http.Response{
+		Body: http.NoBody,
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.SnapshotValue(SnapshotOpts{}, SnapshotOpts{})
+	resp.chain.assert(t, failure)
+}
+
+func TestSnapshot_Match(t *testing.T) {
+	newResp := func(config Config, body string) *Response {
+		return NewResponseC(config, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": []string{"application/json; charset=utf-8"},
+				"Date":         []string{"Mon, 02 Jan 2006 15:04:05 GMT"},
+				"X-Request-Id": []string{"123"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(body)),
+		})
+	}
+
+	opts := SnapshotOpts{
+		Headers:       []string{"content-type", "X-Request-Id"},
+		RedactHeaders: []string{"X-Request-Id"},
+		RedactKeys:    []string{"id"},
+		RedactFunc: func(key string, value interface{}) bool {
+			return strings.HasSuffix(key, "_at")
+		},
+	}
+
+	t.Run("create and match", func(t *testing.T) {
+		dir := t.TempDir()
+
+		config := newMockConfig(newMockReporter(t))
+		config.SnapshotDir = dir
+
+		resp := newResp(config,
+			`{"id":1,"name":"john","created_at":"2023","big":12345678901234567890}`)
+		resp.MatchSnapshot("users/get", opts)
+		resp.chain.assert(t, success)
+
+		b, err := os.ReadFile(filepath.Join(dir, "users", "get.snap"))
+		require.NoError(t, err)
+
+		assert.Equal(t, strings.Join([]string{
+			"Content-Type: application/json; charset=utf-8",
+			"X-Request-Id: [REDACTED]",
+			"",
+			"{",
+			`  "big": 12345678901234567890,`,
+			`  "created_at": "[REDACTED]",`,
+			`  "id": "[REDACTED]",`,
+			`  "name": "john"`,
+			"}",
+			"",
+		}, "\n"), string(b))
+
+		// volatile fields differ, but are redacted
+		resp = newResp(config,
+			`{"name":"john","id":2,"big":12345678901234567890,"created_at":"2024"}`)
+		resp.MatchSnapshot("users/get", opts)
+		resp.chain.assert(t, success)
+
+		resp = newResp(config,
+			`{"name":"bob","id":1,"big":12345678901234567890,"created_at":"2023"}`)
+		resp.MatchSnapshot("users/get", opts)
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("text body", func(t *testing.T) {
+		dir := t.TempDir()
+
+		config := newMockConfig(newMockReporter(t))
+		config.SnapshotDir = dir
+
+		httpResp := &http.Response{
+			Header: http.Header{"Content-Type": []string{"text/plain"}},
+			Body:   io.NopCloser(bytes.NewBufferString("hello")),
+		}
+
+		resp := NewResponseC(config, httpResp)
+		resp.MatchSnapshot("text")
+		resp.chain.assert(t, success)
+
+		b, err := os.ReadFile(filepath.Join(dir, "text.snap"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello\n", string(b))
+
+		// response is still usable after snapshot
+		resp.Body().IsEqual("hello")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		dir := t.TempDir()
+
+		config := newMockConfig(newMockReporter(t))
+		config.SnapshotDir = dir
+
+		require.NoError(t,
+			os.WriteFile(filepath.Join(dir, "user.snap"), []byte("old\n"), 0o644))
+
+		resp := newResp(config, `{"name":"john"}`)
+		resp.MatchSnapshot("user")
+		resp.chain.assert(t, failure)
+
+		t.Setenv("UPDATE_SNAPSHOTS", "1")
+
+		resp = newResp(config, `{"name":"john"}`)
+		resp.MatchSnapshot("user")
+		resp.chain.assert(t, success)
+
+		b, err := os.ReadFile(filepath.Join(dir, "user.snap"))
+		require.NoError(t, err)
+		assert.Equal(t, "{\n  \"name\": \"john\"\n}\n", string(b))
+
+		t.Setenv("UPDATE_SNAPSHOTS", "0")
+
+		resp = newResp(config, `{"name":"bob"}`)
+		resp.MatchSnapshot("user")
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+		config.SnapshotDir = t.TempDir()
+
+		for _, name := range []string{"", "../foo", "/foo"} {
+			resp := newResp(config, `{}`)
+			resp.MatchSnapshot(name)
+			resp.chain.assert(t, failure)
+		}
+
+		resp := newResp(config, `{}`)
+		resp.MatchSnapshot("foo", SnapshotOpts{}, SnapshotOpts{})
+		resp.chain.assert(t, failure)
+	})
+}
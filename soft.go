@@ -0,0 +1,221 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Collects failures of assertions made in soft mode, see Expect.Soft.
+type softAssertionHandler struct {
+	mu       sync.Mutex
+	handler  AssertionHandler
+	failures []softFailure
+	hooks    []func()
+}
+
+type softFailure struct {
+	context AssertionContext
+	failure AssertionFailure
+}
+
+func (h *softAssertionHandler) Success(ctx *AssertionContext) {
+	h.handler.Success(ctx)
+}
+
+// Failures with SeverityLog and SeverityWarning are passed to underlying
+// handler, failures with SeverityError are collected until Verify.
+func (h *softAssertionHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	if failure.Severity != SeverityError {
+		h.handler.Failure(ctx, failure)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures = append(h.failures, softFailure{
+		context: *ctx,
+		failure: *failure,
+	})
+}
+
+// Remember Config.OnFailure invocation for collected failure,
+// to be done when failure is reported by Verify.
+func (h *softAssertionHandler) deferHook(hook func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.hooks = append(h.hooks, hook)
+}
+
+func (h *softAssertionHandler) takeFailures() []softFailure {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	failures := h.failures
+	h.failures = nil
+
+	return failures
+}
+
+func (h *softAssertionHandler) takeHooks() []func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hooks := h.hooks
+	h.hooks = nil
+
+	return hooks
+}
+
+// Returns Cleanup method of testing.TB used as Reporter, if any.
+func reporterCleanup(handler AssertionHandler) func(func()) {
+	h, ok := handler.(*DefaultAssertionHandler)
+	if !ok {
+		return nil
+	}
+
+	if tb, ok := h.Reporter.(interface{ Cleanup(func()) }); ok {
+		return tb.Cleanup
+	}
+
+	return nil
+}
+
+// Soft returns a copy of Expect instance in soft assertions mode.
+//
+// In soft mode, failures of assertions made via returned instance (and via
+// requests and responses created from it) are not reported immediately.
+// Instead, they are collected and reported all at once, as a single grouped
+// failure, when Verify is called. This allows to see every broken field in
+// long scenario tests, even when Reporter stops test on first failure.
+//
+// Note that subsequent assertions on a failed value are still skipped, as
+// usual; only sibling assertions continue to run.
+//
+// Only failures with SeverityError are collected. Failures with other
+// severities (SeverityLog and SeverityWarning) are passed through
+// immediately. Every call to Soft creates independent collection of
+// failures.
+//
+// Config.OnFailure hook is not invoked when failure is collected; instead,
+// it's invoked for every collected failure when Verify reports it.
+//
+// IMPORTANT: collected failures are reported only by Verify, so Verify must
+// be called, typically using defer. If Verify is never called, failures are
+// lost and test passes. The only exception is when testing.TB (e.g. *testing.T)
+// is used directly as Config.Reporter: then Verify is also registered using
+// t.Cleanup and reports failures that were not verified explicitly.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	soft := e.Soft()
+//	defer soft.Verify()
+//
+//	user := soft.GET("/users/john").
+//		Expect().
+//		Status(http.StatusOK).
+//		JSON().Object()
+//
+//	user.Value("name").IsEqual("John")      // collected if fails
+//	user.Value("email").IsEqual("j@x.com")  // still checked
+func (e *Expect) Soft() *Expect {
+	ret := e.clone()
+
+	handler := ret.chain.handler
+	if soft, ok := handler.(*softAssertionHandler); ok {
+		handler = soft.handler
+	}
+
+	ret.chain.setHandler(&softAssertionHandler{
+		handler: handler,
+	})
+
+	if cleanup := reporterCleanup(handler); cleanup != nil {
+		cleanup(func() {
+			ret.Verify()
+		})
+	}
+
+	return ret
+}
+
+// Verify reports failures collected in soft mode, if any, as a single
+// grouped failure, and clears them.
+//
+// Verify can be used only with Expect instance returned by Soft.
+// It can be called multiple times; each call reports only failures
+// collected since previous call.
+//
+// Before reporting grouped failure, Verify invokes Config.OnFailure hook,
+// if any, for every collected failure. Grouped failure itself is not
+// passed to hook.
+//
+// Example:
+//
+//	soft := e.Soft()
+//
+//	soft.GET("/users").
+//		Expect().
+//		Status(http.StatusOK)
+//
+//	soft.Verify()
+func (e *Expect) Verify() *Expect {
+	opChain := e.chain.enter("Verify()")
+	defer opChain.leave()
+
+	soft, ok := opChain.handler.(*softAssertionHandler)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected Verify() call on Expect not in soft mode"),
+			},
+		})
+		return e
+	}
+
+	// grouped failure is reported directly to underlying handler,
+	// and hook is invoked for collected failures instead of it
+	opChain.setHandler(soft.handler)
+	opChain.setOnFailure(nil)
+
+	failures := soft.takeFailures()
+	hooks := soft.takeHooks()
+
+	if len(failures) == 0 {
+		return e
+	}
+
+	// hooks are invoked before reporting, which may stop the test
+	for _, hook := range hooks {
+		hook()
+	}
+
+	formatter := e.config.Formatter
+	if formatter == nil {
+		formatter = &DefaultFormatter{}
+	}
+
+	errs := []error{
+		fmt.Errorf("expected: no soft assertion failures, but got %d", len(failures)),
+	}
+
+	for n := range failures {
+		errs = append(errs, fmt.Errorf("soft failure %d of %d:\n%s",
+			n+1, len(failures),
+			formatter.FormatFailure(&failures[n].context, &failures[n].failure)))
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertOperation,
+		Errors: errs,
+	})
+
+	return e
+}
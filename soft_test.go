@@ -0,0 +1,264 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cleanupReporter struct {
+	*mockReporter
+	cleanups []func()
+}
+
+func (r *cleanupReporter) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+func TestSoft_Verify(t *testing.T) {
+	newExpect := func(handler AssertionHandler) *Expect {
+		return WithConfig(Config{
+			BaseURL:          "http://example.com",
+			AssertionHandler: handler,
+			Client: &http.Client{
+				Transport: NewBinder(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set("Content-Type", "application/json")
+						_, _ = w.Write([]byte(`{"name": "john", "age": 30}`))
+					})),
+			},
+		})
+	}
+
+	t.Run("collected failures", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		soft := newExpect(handler).Soft()
+
+		obj := soft.GET("/user").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		obj.Value("name").IsEqual("bob")
+		obj.Value("age").IsEqual(40)
+		obj.Value("name").IsEqual("john")
+
+		assert.Equal(t, 0, handler.failureCalled)
+		assert.NotEqual(t, 0, handler.successCalled)
+
+		soft.Verify()
+
+		assert.Equal(t, 1, handler.failureCalled)
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		require.Equal(t, 3, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[0].Error(), "got 2")
+		assert.Contains(t, handler.failure.Errors[1].Error(), "soft failure 1 of 2")
+		assert.Contains(t, handler.failure.Errors[1].Error(), "bob")
+		assert.Contains(t, handler.failure.Errors[2].Error(), "soft failure 2 of 2")
+		assert.Contains(t, handler.failure.Errors[2].Error(), "40")
+		assert.Equal(t, []string{"Verify()"}, handler.ctx.Path)
+
+		soft.Verify()
+
+		assert.Equal(t, 1, handler.failureCalled)
+	})
+
+	t.Run("no failures", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		soft := newExpect(handler).Soft()
+
+		soft.GET("/user").
+			Expect().
+			Status(http.StatusOK)
+
+		soft.Verify()
+
+		assert.Equal(t, 0, handler.failureCalled)
+	})
+
+	t.Run("independent collections", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := newExpect(handler)
+
+		soft1 := e.Soft()
+		soft2 := soft1.Soft()
+
+		soft1.GET("/user").
+			Expect().
+			Status(http.StatusNotFound)
+
+		soft2.Verify()
+		assert.Equal(t, 0, handler.failureCalled)
+
+		soft1.Verify()
+		assert.Equal(t, 1, handler.failureCalled)
+	})
+
+	t.Run("not soft", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := newExpect(handler)
+
+		e.Verify()
+
+		assert.Equal(t, 1, handler.failureCalled)
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertUsage, handler.failure.Type)
+	})
+
+	t.Run("failure hook", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		var calls []FailureContext
+
+		soft := WithConfig(Config{
+			BaseURL:          "http://example.com",
+			AssertionHandler: handler,
+			Client: &http.Client{
+				Transport: NewBinder(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set("Content-Type", "application/json")
+						_, _ = w.Write([]byte(`{"name": "john", "age": 30}`))
+					})),
+			},
+			OnFailure: func(ctx FailureContext) {
+				calls = append(calls, ctx)
+			},
+		}).Soft()
+
+		obj := soft.GET("/user").
+			Expect().
+			JSON().Object()
+
+		obj.Value("name").IsEqual("bob")
+		obj.Value("age").IsEqual(40)
+
+		// not invoked until Verify
+		assert.Equal(t, 0, len(calls))
+
+		soft.Verify()
+
+		assert.Equal(t, 1, handler.failureCalled)
+		require.Equal(t, 2, len(calls))
+		assert.Equal(t, AssertEqual, calls[0].Failure.Type)
+		assert.NotNil(t, calls[0].ResponseDump)
+		assert.Equal(t, AssertEqual, calls[1].Failure.Type)
+
+		soft.Verify()
+
+		assert.Equal(t, 2, len(calls))
+	})
+
+	t.Run("cleanup", func(t *testing.T) {
+		reporter := &cleanupReporter{mockReporter: newMockReporter(t)}
+
+		soft := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+					})),
+			},
+		}).Soft()
+
+		soft.GET("/user").
+			Expect().
+			Status(http.StatusOK)
+
+		assert.False(t, reporter.reported)
+		require.Equal(t, 1, len(reporter.cleanups))
+
+		// failures not verified explicitly are reported on cleanup
+		reporter.cleanups[0]()
+
+		assert.Equal(t, 1, reporter.reportCalled)
+	})
+
+	t.Run("cleanup after verify", func(t *testing.T) {
+		reporter := &cleanupReporter{mockReporter: newMockReporter(t)}
+
+		soft := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+					})),
+			},
+		}).Soft()
+
+		soft.GET("/user").
+			Expect().
+			Status(http.StatusOK)
+
+		soft.Verify()
+		assert.Equal(t, 1, reporter.reportCalled)
+
+		require.Equal(t, 1, len(reporter.cleanups))
+		reporter.cleanups[0]()
+
+		assert.Equal(t, 1, reporter.reportCalled)
+	})
+
+	t.Run("original instance", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := newExpect(handler)
+		_ = e.Soft()
+
+		e.GET("/user").
+			Expect().
+			Status(http.StatusNotFound)
+
+		assert.Equal(t, 1, handler.failureCalled)
+	})
+}
+
+func TestSoft_Handler(t *testing.T) {
+	t.Run("log severity", func(t *testing.T) {
+		underlying := &mockAssertionHandler{}
+		handler := &softAssertionHandler{handler: underlying}
+
+		handler.Failure(&AssertionContext{}, &AssertionFailure{
+			Type:     AssertOperation,
+			Severity: SeverityLog,
+		})
+
+		assert.Equal(t, 1, underlying.failureCalled)
+		assert.Equal(t, 0, len(handler.takeFailures()))
+	})
+
+	t.Run("error severity", func(t *testing.T) {
+		underlying := &mockAssertionHandler{}
+		handler := &softAssertionHandler{handler: underlying}
+
+		handler.Failure(&AssertionContext{}, &AssertionFailure{
+			Type:     AssertOperation,
+			Severity: SeverityError,
+		})
+
+		assert.Equal(t, 0, underlying.failureCalled)
+		assert.Equal(t, 1, len(handler.takeFailures()))
+		assert.Equal(t, 0, len(handler.takeFailures()))
+	})
+
+	t.Run("testing tb", func(t *testing.T) {
+		handler := &softAssertionHandler{
+			handler: &DefaultAssertionHandler{
+				Reporter:  t,
+				Formatter: &DefaultFormatter{},
+			},
+		}
+
+		assert.True(t, isTestingTB(handler))
+	})
+}
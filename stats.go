@@ -0,0 +1,299 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Stats provides methods to inspect results of repeated request.
+//
+// Stats is returned by Request.Repeat. It contains number of sent
+// requests, number of transport errors, distribution of status codes,
+// and round-trip time of every received response.
+//
+// Example:
+//
+//	stats := e.GET("/health").Repeat(1000, 10)
+//
+//	stats.SuccessRate().Ge(0.99)
+//	stats.P99().Le(100 * time.Millisecond)
+//	stats.StatusCount(http.StatusOK).IsEqual(1000)
+type Stats struct {
+	noCopy noCopy
+	chain  *chain
+
+	total  int
+	errors int
+	codes  map[int]int
+	rtts   []time.Duration // sorted
+}
+
+// StatsSample defines result of a single request used to build Stats.
+type StatsSample struct {
+	// Response status code
+	// Zero if request failed
+	StatusCode int
+
+	// Response round-trip time
+	RoundTripTime time.Duration
+
+	// Error returned by client, if any
+	Err error
+}
+
+// NewStats returns a new Stats instance.
+//
+// If reporter is nil, the function panics.
+//
+// Example:
+//
+//	stats := NewStats(t, []StatsSample{
+//		{StatusCode: http.StatusOK, RoundTripTime: time.Millisecond},
+//	})
+//	stats.SuccessRate().IsEqual(1)
+func NewStats(reporter Reporter, samples []StatsSample) *Stats {
+	return newStats(newChainWithDefaults("Stats()", reporter), samples)
+}
+
+// NewStatsC returns a new Stats instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+//
+// Example:
+//
+//	stats := NewStatsC(config, samples)
+//	stats.SuccessRate().IsEqual(1)
+func NewStatsC(config Config, samples []StatsSample) *Stats {
+	return newStats(newChainWithConfig("Stats()", config.withDefaults()), samples)
+}
+
+func newStats(parent *chain, samples []StatsSample) *Stats {
+	s := &Stats{
+		chain: parent.clone(),
+		total: len(samples),
+		codes: make(map[int]int),
+	}
+
+	for _, sample := range samples {
+		if sample.Err != nil {
+			s.errors++
+			continue
+		}
+		s.codes[sample.StatusCode]++
+		s.rtts = append(s.rtts, sample.RoundTripTime)
+	}
+
+	sort.Slice(s.rtts, func(i, j int) bool {
+		return s.rtts[i] < s.rtts[j]
+	})
+
+	return s
+}
+
+// Alias is similar to Value.Alias.
+func (s *Stats) Alias(name string) *Stats {
+	opChain := s.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	s.chain.setAlias(name)
+	return s
+}
+
+// Count returns a new Number instance with total number of sent requests.
+//
+// Example:
+//
+//	stats := e.GET("/path").Repeat(100, 10)
+//	stats.Count().IsEqual(100)
+func (s *Stats) Count() *Number {
+	opChain := s.chain.enter("Count()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(s.total))
+}
+
+// Errors returns a new Number instance with number of requests that
+// failed without receiving response, e.g. due to network error.
+//
+// Example:
+//
+//	stats := e.GET("/path").Repeat(100, 10)
+//	stats.Errors().IsEqual(0)
+func (s *Stats) Errors() *Number {
+	opChain := s.chain.enter("Errors()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(s.errors))
+}
+
+// SuccessRate returns a new Number instance with fraction of requests
+// that received response with status code below 400.
+//
+// Returned number is in range [0; 1].
+//
+// Example:
+//
+//	stats := e.GET("/path").Repeat(100, 10)
+//	stats.SuccessRate().Ge(0.95)
+func (s *Stats) SuccessRate() *Number {
+	opChain := s.chain.enter("SuccessRate()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if s.total == 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{s.total},
+			Errors: []error{
+				errors.New("expected: non-empty stats"),
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	succeeded := 0
+	for code, count := range s.codes {
+		if code < 400 {
+			succeeded += count
+		}
+	}
+
+	return newNumber(opChain, float64(succeeded)/float64(s.total))
+}
+
+// StatusCodes returns a new Object instance with distribution of
+// response status codes.
+//
+// Object keys are status codes converted to strings, and values are
+// number of responses with that status code.
+//
+// Example:
+//
+//	stats := e.GET("/path").Repeat(100, 10)
+//	stats.StatusCodes().IsEqual(map[string]int{"200": 100})
+func (s *Stats) StatusCodes() *Object {
+	opChain := s.chain.enter("StatusCodes()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	object := make(map[string]interface{}, len(s.codes))
+	for code, count := range s.codes {
+		object[strconv.Itoa(code)] = float64(count)
+	}
+
+	return newObject(opChain, object)
+}
+
+// StatusCount returns a new Number instance with number of responses
+// with given status code.
+//
+// Example:
+//
+//	stats := e.GET("/path").Repeat(100, 10)
+//	stats.StatusCount(http.StatusTooManyRequests).Le(5)
+func (s *Stats) StatusCount(code int) *Number {
+	opChain := s.chain.enter("StatusCount(%d)", code)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(s.codes[code]))
+}
+
+// Percentile returns a new Duration instance with given percentile
+// of response round-trip time.
+//
+// Percentile should be in range (0; 100]. Nearest-rank method is used.
+// Requests that failed without response are not taken into account.
+//
+// Example:
+//
+//	stats := e.GET("/path").Repeat(100, 10)
+//	stats.Percentile(90).Le(50 * time.Millisecond)
+func (s *Stats) Percentile(percentile float64) *Duration {
+	opChain := s.chain.enter("Percentile(%v)", percentile)
+	defer opChain.leave()
+
+	return s.percentile(opChain, percentile)
+}
+
+// P50 is a shorthand for Percentile(50).
+func (s *Stats) P50() *Duration {
+	opChain := s.chain.enter("P50()")
+	defer opChain.leave()
+
+	return s.percentile(opChain, 50)
+}
+
+// P95 is a shorthand for Percentile(95).
+func (s *Stats) P95() *Duration {
+	opChain := s.chain.enter("P95()")
+	defer opChain.leave()
+
+	return s.percentile(opChain, 95)
+}
+
+// P99 is a shorthand for Percentile(99).
+func (s *Stats) P99() *Duration {
+	opChain := s.chain.enter("P99()")
+	defer opChain.leave()
+
+	return s.percentile(opChain, 99)
+}
+
+func (s *Stats) percentile(opChain *chain, percentile float64) *Duration {
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	if !(percentile > 0 && percentile <= 100) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{percentile},
+			Errors: []error{
+				fmt.Errorf("invalid percentile %v, expected range (0; 100]", percentile),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	if len(s.rtts) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{s.rtts},
+			Errors: []error{
+				errors.New("expected: at least one response received"),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(s.rtts))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	value := s.rtts[rank-1]
+
+	return newDuration(opChain, &value)
+}
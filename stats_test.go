@@ -0,0 +1,113 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats_FailedChain(t *testing.T) {
+	reporter := newMockReporter(t)
+	chain := newChainWithDefaults("test", reporter, flagFailed)
+
+	stats := newStats(chain, nil)
+	stats.chain.assert(t, failure)
+
+	stats.Alias("foo")
+
+	stats.Count().chain.assert(t, failure)
+	stats.Errors().chain.assert(t, failure)
+	stats.SuccessRate().chain.assert(t, failure)
+	stats.StatusCodes().chain.assert(t, failure)
+	stats.StatusCount(http.StatusOK).chain.assert(t, failure)
+	stats.Percentile(50).chain.assert(t, failure)
+	stats.P50().chain.assert(t, failure)
+	stats.P95().chain.assert(t, failure)
+	stats.P99().chain.assert(t, failure)
+}
+
+func TestStats_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	stats := NewStats(reporter, nil)
+	assert.Equal(t, []string{"Stats()"}, stats.chain.context.Path)
+	assert.Equal(t, []string{"Stats()"}, stats.chain.context.AliasedPath)
+
+	stats.Alias("foo")
+	assert.Equal(t, []string{"Stats()"}, stats.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, stats.chain.context.AliasedPath)
+}
+
+func TestStats_Values(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	var samples []StatsSample
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, StatsSample{
+			StatusCode:    http.StatusOK,
+			RoundTripTime: time.Duration(i) * time.Millisecond,
+		})
+	}
+	samples[0].StatusCode = http.StatusNotFound
+	samples[1].StatusCode = http.StatusInternalServerError
+	samples = append(samples, StatsSample{Err: errors.New("test error")})
+
+	stats := NewStats(reporter, samples)
+
+	assert.Equal(t, float64(101), stats.Count().Raw())
+	assert.Equal(t, float64(1), stats.Errors().Raw())
+	assert.Equal(t, float64(98)/101, stats.SuccessRate().Raw())
+
+	assert.Equal(t, map[string]interface{}{
+		"200": float64(98),
+		"404": float64(1),
+		"500": float64(1),
+	}, stats.StatusCodes().Raw())
+
+	assert.Equal(t, float64(98), stats.StatusCount(http.StatusOK).Raw())
+	assert.Equal(t, float64(0), stats.StatusCount(http.StatusCreated).Raw())
+
+	assert.Equal(t, 50*time.Millisecond, stats.P50().Raw())
+	assert.Equal(t, 95*time.Millisecond, stats.P95().Raw())
+	assert.Equal(t, 99*time.Millisecond, stats.P99().Raw())
+	assert.Equal(t, 100*time.Millisecond, stats.Percentile(100).Raw())
+	assert.Equal(t, 1*time.Millisecond, stats.Percentile(0.1).Raw())
+
+	stats.chain.assert(t, success)
+}
+
+func TestStats_Empty(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("success rate", func(t *testing.T) {
+		stats := NewStats(reporter, nil)
+
+		stats.SuccessRate()
+		stats.chain.assert(t, failure)
+	})
+
+	t.Run("percentile", func(t *testing.T) {
+		stats := NewStats(reporter, nil)
+
+		stats.P50()
+		stats.chain.assert(t, failure)
+	})
+}
+
+func TestStats_InvalidPercentile(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	samples := []StatsSample{
+		{StatusCode: http.StatusOK, RoundTripTime: time.Millisecond},
+	}
+
+	for _, p := range []float64{0, -1, 101} {
+		stats := NewStats(reporter, samples)
+
+		stats.Percentile(p)
+		stats.chain.assert(t, failure)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -91,6 +92,65 @@ func (s *String) Alias(name string) *String {
 	return s
 }
 
+// Warn is similar to Value.Warn.
+//
+// Example:
+//
+//	str := NewString(t, "Hello")
+//	str.Warn().IsEmpty() // warning, test continues
+func (s *String) Warn() *String {
+	opChain := s.chain.enter("Warn()")
+	defer opChain.leave()
+
+	ret := newString(opChain, s.value)
+
+	ret.chain.setRoot()
+	ret.chain.setSeverity(SeverityWarning)
+
+	return ret
+}
+
+// Assert is similar to Value.Assert.
+//
+// Example:
+//
+//	str := NewString(t, "550e8400-e29b-41d4-a716-446655440000")
+//	str.Assert(func(s string) error {
+//		_, err := uuid.Parse(s)
+//		return err
+//	})
+func (s *String) Assert(fn func(value string) error) *String {
+	opChain := s.chain.enter("Assert()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return s
+	}
+
+	if err := fn(s.value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.value},
+			Errors: []error{
+				errors.New("expected: value satisfies custom assertion"),
+				err,
+			},
+		})
+	}
+
+	return s
+}
+
 // Path is similar to Value.Path.
 func (s *String) Path(path string) *Value {
 	opChain := s.chain.enter("Path(%q)", path)
@@ -125,6 +185,108 @@ func (s *String) Length() *Number {
 	return newNumber(opChain, float64(len(s.value)))
 }
 
+// HasLength succeeds if string length in bytes is equal to given value.
+//
+// Useful to check size of downloaded files, see Response.Body.
+//
+// Example:
+//
+//	str := NewString(t, "Hello")
+//	str.HasLength(5)
+func (s *String) HasLength(length int) *String {
+	opChain := s.chain.enter("HasLength()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if len(s.value) != length {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{len(s.value)},
+			Expected: &AssertionValue{length},
+			Errors: []error{
+				errors.New("expected: string length is equal to given value"),
+			},
+		})
+	}
+
+	return s
+}
+
+// LengthInRange succeeds if string length in bytes is within given range
+// [min; max].
+//
+// Example:
+//
+//	str := NewString(t, "Hello")
+//	str.LengthInRange(1, 10)
+func (s *String) LengthInRange(min, max int) *String {
+	opChain := s.chain.enter("LengthInRange()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if min > max {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid range: min is greater than max"),
+			},
+		})
+		return s
+	}
+
+	if len(s.value) < min || len(s.value) > max {
+		opChain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{len(s.value)},
+			Expected: &AssertionValue{AssertionRange{
+				Min: min,
+				Max: max,
+			}},
+			Errors: []error{
+				errors.New("expected: string length is within given range"),
+			},
+		})
+	}
+
+	return s
+}
+
+// WriteToFile writes string to file with given path. If file exists,
+// it is truncated. Parent directory must exist.
+//
+// Useful to save downloaded files for further inspection, see Response.Body.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Body().WriteToFile(filepath.Join(t.TempDir(), "report.pdf"))
+func (s *String) WriteToFile(path string) *String {
+	opChain := s.chain.enter("WriteToFile()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if err := os.WriteFile(path, []byte(s.value), 0o644); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to write file %q", path),
+				err,
+			},
+		})
+	}
+
+	return s
+}
+
 // IsEmpty succeeds if string is empty.
 //
 // Example:
@@ -1192,6 +1354,26 @@ func (s *String) AsNumber(base ...int) *Number {
 	return newNumber(opChain, fnum)
 }
 
+// AsBytes returns a new Bytes instance with raw string bytes.
+//
+// Go strings may hold arbitrary bytes, so conversion is lossless, and
+// it's safe to use it for binary response bodies.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Body().AsBytes().IsPNG()
+func (s *String) AsBytes() *Bytes {
+	opChain := s.chain.enter("AsBytes()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBytes(opChain, nil)
+	}
+
+	return newBytes(opChain, []byte(s.value))
+}
+
 // AsBoolean parses true/false value string and returns a new Boolean instance
 // with result.
 //
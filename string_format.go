@@ -0,0 +1,247 @@
+package httpexpect
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// IsJSON succeeds if string is a valid JSON document.
+//
+// Example:
+//
+//	str := NewString(t, `{"foo": [1, 2]}`)
+//	str.IsJSON()
+func (s *String) IsJSON() *String {
+	return s.checkFormat("IsJSON()", "expected: string is valid json",
+		func(value string) error {
+			var v interface{}
+			return json.Unmarshal([]byte(value), &v)
+		})
+}
+
+var uuidRegexp = regexp.MustCompile(
+	`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsUUID succeeds if string is a UUID in canonical textual form,
+// e.g. "6ba7b810-9dad-11d1-80b4-00c04fd430c8". Any version is accepted.
+//
+// Example:
+//
+//	str := NewString(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+//	str.IsUUID()
+func (s *String) IsUUID() *String {
+	return s.checkFormat("IsUUID()", "expected: string is valid uuid",
+		checkUUID)
+}
+
+// IsUUIDv4 succeeds if string is a random (version 4) UUID in canonical
+// textual form, e.g. "f47ac10b-58cc-4372-a567-0e02b2c3d479".
+//
+// Example:
+//
+//	str := NewString(t, "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+//	str.IsUUIDv4()
+func (s *String) IsUUIDv4() *String {
+	return s.checkFormat("IsUUIDv4()", "expected: string is valid version 4 uuid",
+		func(value string) error {
+			if err := checkUUID(value); err != nil {
+				return err
+			}
+			if value[14] != '4' {
+				return fmt.Errorf("uuid version is %c, expected 4", value[14])
+			}
+			if !strings.ContainsRune("89abAB", rune(value[19])) {
+				return errors.New("uuid variant is not RFC 4122")
+			}
+			return nil
+		})
+}
+
+// IsEmail succeeds if string is a bare email address, e.g. "john@example.com".
+// Address with display name, like "John <john@example.com>", is not accepted.
+//
+// Example:
+//
+//	str := NewString(t, "john@example.com")
+//	str.IsEmail()
+func (s *String) IsEmail() *String {
+	return s.checkFormat("IsEmail()", "expected: string is valid email address",
+		func(value string) error {
+			addr, err := mail.ParseAddress(value)
+			if err != nil {
+				return err
+			}
+			if addr.Name != "" || addr.Address != value {
+				return errors.New("address should not have display name")
+			}
+			return nil
+		})
+}
+
+// IsURL succeeds if string is an absolute URL with scheme and host,
+// e.g. "https://example.com/path".
+//
+// If schemes are given, URL scheme should be one of them. Scheme comparison
+// is case-insensitive.
+//
+// Example:
+//
+//	str := NewString(t, "https://example.com/users")
+//	str.IsURL()
+//	str.IsURL("http", "https")
+func (s *String) IsURL(schemes ...string) *String {
+	return s.checkFormat("IsURL()", "expected: string is valid absolute url",
+		func(value string) error {
+			u, err := url.Parse(value)
+			if err != nil {
+				return err
+			}
+			if u.Scheme == "" {
+				return errors.New("url has no scheme")
+			}
+			if u.Host == "" {
+				return errors.New("url has no host")
+			}
+			if len(schemes) == 0 {
+				return nil
+			}
+			for _, scheme := range schemes {
+				if strings.EqualFold(scheme, u.Scheme) {
+					return nil
+				}
+			}
+			return fmt.Errorf("url scheme %q is not one of %q", u.Scheme, schemes)
+		})
+}
+
+// IsIP succeeds if string is an IPv4 or IPv6 address.
+//
+// Example:
+//
+//	str := NewString(t, "192.168.0.1")
+//	str.IsIP()
+func (s *String) IsIP() *String {
+	return s.checkFormat("IsIP()", "expected: string is valid ip address",
+		checkIP)
+}
+
+// IsIPv4 succeeds if string is an IPv4 address in dotted decimal form,
+// e.g. "192.168.0.1".
+//
+// Example:
+//
+//	str := NewString(t, "192.168.0.1")
+//	str.IsIPv4()
+func (s *String) IsIPv4() *String {
+	return s.checkFormat("IsIPv4()", "expected: string is valid ipv4 address",
+		func(value string) error {
+			if err := checkIP(value); err != nil {
+				return err
+			}
+			if strings.Contains(value, ":") {
+				return errors.New("address is ipv6")
+			}
+			return nil
+		})
+}
+
+// IsIPv6 succeeds if string is an IPv6 address, e.g. "2001:db8::1".
+//
+// Example:
+//
+//	str := NewString(t, "2001:db8::1")
+//	str.IsIPv6()
+func (s *String) IsIPv6() *String {
+	return s.checkFormat("IsIPv6()", "expected: string is valid ipv6 address",
+		func(value string) error {
+			if err := checkIP(value); err != nil {
+				return err
+			}
+			if !strings.Contains(value, ":") {
+				return errors.New("address is ipv4")
+			}
+			return nil
+		})
+}
+
+// IsBase64 succeeds if string is valid standard base64 encoding
+// with padding, as defined in RFC 4648.
+//
+// Example:
+//
+//	str := NewString(t, "aGVsbG8=")
+//	str.IsBase64()
+func (s *String) IsBase64() *String {
+	return s.checkFormat("IsBase64()", "expected: string is valid base64",
+		func(value string) error {
+			_, err := base64.StdEncoding.DecodeString(value)
+			return err
+		})
+}
+
+// IsHex succeeds if string is non-empty and consists of hexadecimal digits
+// only. Both lower and upper case digits are accepted.
+//
+// Example:
+//
+//	str := NewString(t, "deadBEEF")
+//	str.IsHex()
+func (s *String) IsHex() *String {
+	return s.checkFormat("IsHex()", "expected: string is valid hex",
+		func(value string) error {
+			if value == "" {
+				return errors.New("string is empty")
+			}
+			for i, c := range value {
+				if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+					return fmt.Errorf("invalid hex digit %q at position %d", c, i)
+				}
+			}
+			return nil
+		})
+}
+
+func (s *String) checkFormat(
+	method string, expectation string, check func(value string) error,
+) *String {
+	opChain := s.chain.enter(method)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if err := check(s.value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.value},
+			Errors: []error{
+				errors.New(expectation),
+				err,
+			},
+		})
+	}
+
+	return s
+}
+
+func checkUUID(value string) error {
+	if !uuidRegexp.MatchString(value) {
+		return errors.New("expected format xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx")
+	}
+	return nil
+}
+
+func checkIP(value string) error {
+	if net.ParseIP(value) == nil {
+		return fmt.Errorf("can't parse %q as ip address", value)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestString_Formats(t *testing.T) {
+	cases := []struct {
+		name   string
+		check  func(s *String) *String
+		str    string
+		result chainResult
+	}{
+		{"json object", (*String).IsJSON, `{"foo": [1, 2]}`, success},
+		{"json scalar", (*String).IsJSON, `"foo"`, success},
+		{"json invalid", (*String).IsJSON, `{"foo":`, failure},
+		{"json empty", (*String).IsJSON, ``, failure},
+
+		{"uuid v1", (*String).IsUUID, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", success},
+		{"uuid upper", (*String).IsUUID, "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", success},
+		{"uuid short", (*String).IsUUID, "6ba7b810-9dad-11d1-80b4-00c04fd430c", failure},
+		{"uuid braces", (*String).IsUUID, "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", failure},
+		{"uuid no dashes", (*String).IsUUID, "6ba7b8109dad11d180b400c04fd430c8", failure},
+
+		{"uuidv4", (*String).IsUUIDv4, "f47ac10b-58cc-4372-a567-0e02b2c3d479", success},
+		{"uuidv4 v1", (*String).IsUUIDv4, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", failure},
+		{"uuidv4 variant", (*String).IsUUIDv4, "f47ac10b-58cc-4372-c567-0e02b2c3d479", failure},
+		{"uuidv4 invalid", (*String).IsUUIDv4, "f47ac10b", failure},
+
+		{"email", (*String).IsEmail, "john@example.com", success},
+		{"email plus", (*String).IsEmail, "john+tag@mail.example.com", success},
+		{"email name", (*String).IsEmail, "John <john@example.com>", failure},
+		{"email no at", (*String).IsEmail, "john.example.com", failure},
+		{"email no domain", (*String).IsEmail, "john@", failure},
+		{"email spaces", (*String).IsEmail, " john@example.com ", failure},
+
+		{"url", func(s *String) *String { return s.IsURL() },
+			"https://example.com/users?id=1", success},
+		{"url ws", func(s *String) *String { return s.IsURL() },
+			"ws://127.0.0.1:8080", success},
+		{"url relative", func(s *String) *String { return s.IsURL() },
+			"/users", failure},
+		{"url no host", func(s *String) *String { return s.IsURL() },
+			"mailto:john@example.com", failure},
+		{"url bad", func(s *String) *String { return s.IsURL() },
+			"http://[::1", failure},
+		{"url scheme", func(s *String) *String { return s.IsURL("http", "https") },
+			"HTTPS://example.com", success},
+		{"url wrong scheme", func(s *String) *String { return s.IsURL("https") },
+			"http://example.com", failure},
+
+		{"ip v4", (*String).IsIP, "192.168.0.1", success},
+		{"ip v6", (*String).IsIP, "2001:db8::1", success},
+		{"ip invalid", (*String).IsIP, "256.0.0.1", failure},
+		{"ip host", (*String).IsIP, "example.com", failure},
+
+		{"ipv4", (*String).IsIPv4, "10.0.0.1", success},
+		{"ipv4 v6", (*String).IsIPv4, "::1", failure},
+		{"ipv4 mapped", (*String).IsIPv4, "::ffff:10.0.0.1", failure},
+		{"ipv4 port", (*String).IsIPv4, "10.0.0.1:80", failure},
+
+		{"ipv6", (*String).IsIPv6, "2001:db8::1", success},
+		{"ipv6 mapped", (*String).IsIPv6, "::ffff:10.0.0.1", success},
+		{"ipv6 v4", (*String).IsIPv6, "10.0.0.1", failure},
+		{"ipv6 invalid", (*String).IsIPv6, "2001:db8:::1", failure},
+
+		{"base64", (*String).IsBase64, "aGVsbG8=", success},
+		{"base64 empty", (*String).IsBase64, "", success},
+		{"base64 no padding", (*String).IsBase64, "aGVsbG8", failure},
+		{"base64 url", (*String).IsBase64, "-_-_", failure},
+
+		{"hex", (*String).IsHex, "deadBEEF09", success},
+		{"hex odd", (*String).IsHex, "abc", success},
+		{"hex empty", (*String).IsHex, "", failure},
+		{"hex prefix", (*String).IsHex, "0xff", failure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			tc.check(NewString(reporter, tc.str)).
+				chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestString_FormatErrors(t *testing.T) {
+	handler := &mockAssertionHandler{}
+
+	NewStringC(Config{
+		AssertionHandler: handler,
+	}, "6ba7b810-9dad-11d1-80b4-00c04fd430c8").IsUUIDv4()
+
+	assert.Equal(t, 1, handler.failureCalled)
+	assert.Equal(t, AssertValid, handler.failure.Type)
+	assert.Equal(t, 2, len(handler.failure.Errors))
+	assert.Contains(t, handler.failure.Errors[1].Error(), "version is 1")
+}
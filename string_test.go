@@ -1,7 +1,10 @@
 package httpexpect
 
 import (
+	"errors"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -17,6 +20,7 @@ func TestString_FailedChain(t *testing.T) {
 	value.Path("$").chain.assert(t, failure)
 	value.Schema("")
 	value.Alias("foo")
+	value.Assert(nil)
 
 	var target interface{}
 	value.Decode(target)
@@ -47,6 +51,19 @@ func TestString_FailedChain(t *testing.T) {
 	value.NotHasSuffixFold("")
 	value.IsASCII()
 	value.NotASCII()
+	value.IsJSON()
+	value.IsUUID()
+	value.IsUUIDv4()
+	value.IsEmail()
+	value.IsURL()
+	value.IsIP()
+	value.IsIPv4()
+	value.IsIPv6()
+	value.IsBase64()
+	value.IsHex()
+	value.HasLength(0)
+	value.LengthInRange(0, 0)
+	value.WriteToFile(filepath.Join(t.TempDir(), "file"))
 
 	value.Match("").chain.assert(t, failure)
 	value.NotMatch("")
@@ -54,6 +71,7 @@ func TestString_FailedChain(t *testing.T) {
 	assert.Equal(t, 0, len(value.MatchAll("")))
 
 	value.AsBoolean().chain.assert(t, failure)
+	value.AsBytes().chain.assert(t, failure)
 	value.AsNumber().chain.assert(t, failure)
 	value.AsDateTime().chain.assert(t, failure)
 }
@@ -154,6 +172,43 @@ func TestString_Alias(t *testing.T) {
 	assert.Equal(t, []string{"foo", "AsNumber()"}, childValue.chain.context.AliasedPath)
 }
 
+func TestString_Warn(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	str := NewString(reporter, "Hello")
+
+	warn := str.Warn()
+	warn.IsEmpty()
+
+	warn.chain.assert(t, failure)
+	str.chain.assert(t, success)
+
+	assert.False(t, reporter.reported)
+}
+
+func TestString_Assert(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	var actual string
+	NewString(reporter, "foo").
+		Assert(func(s string) error {
+			actual = s
+			return nil
+		}).
+		chain.assert(t, success)
+	assert.Equal(t, "foo", actual)
+
+	NewString(reporter, "foo").
+		Assert(func(s string) error {
+			return errors.New("custom error")
+		}).
+		chain.assert(t, failure)
+
+	NewString(reporter, "foo").
+		Assert(nil).
+		chain.assert(t, failure)
+}
+
 func TestString_Path(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -643,6 +698,59 @@ func TestString_IsAscii(t *testing.T) {
 	}
 }
 
+func TestString_HasLength(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	NewString(reporter, "hello").HasLength(5).
+		chain.assert(t, success)
+	NewString(reporter, "hello").HasLength(4).
+		chain.assert(t, failure)
+	NewString(reporter, "я").HasLength(2).
+		chain.assert(t, success)
+
+	NewString(reporter, "hello").LengthInRange(5, 5).
+		chain.assert(t, success)
+	NewString(reporter, "hello").LengthInRange(1, 10).
+		chain.assert(t, success)
+	NewString(reporter, "hello").LengthInRange(6, 10).
+		chain.assert(t, failure)
+	NewString(reporter, "hello").LengthInRange(1, 4).
+		chain.assert(t, failure)
+	NewString(reporter, "hello").LengthInRange(10, 1).
+		chain.assert(t, failure)
+}
+
+func TestString_WriteToFile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		path := filepath.Join(dir, "file.bin")
+
+		NewString(reporter, "hello").WriteToFile(path).
+			chain.assert(t, success)
+
+		b, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(b))
+
+		NewString(reporter, "bye").WriteToFile(path).
+			chain.assert(t, success)
+
+		b, err = os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "bye", string(b))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewString(reporter, "hello").
+			WriteToFile(filepath.Join(dir, "missing", "file.bin")).
+			chain.assert(t, failure)
+	})
+}
+
 func TestString_AsNumber(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -0,0 +1,325 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// ResponseTimings contains durations of request phases.
+//
+// Phases that were not performed are zero. For example, DNS, Connect,
+// and TLSHandshake are zero if connection was reused, and TLSHandshake
+// is zero for plain HTTP.
+type ResponseTimings struct {
+	// Duration of DNS lookup.
+	DNS time.Duration
+
+	// Duration of establishing TCP connection.
+	Connect time.Duration
+
+	// Duration of TLS handshake.
+	TLSHandshake time.Duration
+
+	// Time to first byte: duration since request was fully written
+	// until first byte of response was received.
+	TTFB time.Duration
+
+	// Duration since first byte of response was received until
+	// response body was fully read.
+	Transfer time.Duration
+
+	// Duration since request was started until response body was
+	// fully read.
+	Total time.Duration
+}
+
+// Timings provides methods to inspect attached ResponseTimings value.
+type Timings struct {
+	noCopy noCopy
+	chain  *chain
+	value  *ResponseTimings
+}
+
+// NewTimings returns a new Timings instance.
+//
+// If reporter is nil, the function panics.
+// If value is nil, failure is reported.
+//
+// Example:
+//
+//	timings := NewTimings(t, &ResponseTimings{
+//		DNS:  time.Millisecond,
+//		TTFB: 20 * time.Millisecond,
+//	})
+//
+//	timings.DNS().Le(10 * time.Millisecond)
+//	timings.TTFB().Le(100 * time.Millisecond)
+func NewTimings(reporter Reporter, value *ResponseTimings) *Timings {
+	return newTimings(newChainWithDefaults("Timings()", reporter), value)
+}
+
+// NewTimingsC returns a new Timings instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is nil, failure is reported.
+//
+// See NewTimings for usage example.
+func NewTimingsC(config Config, value *ResponseTimings) *Timings {
+	return newTimings(newChainWithConfig("Timings()", config.withDefaults()), value)
+}
+
+func newTimings(parent *chain, val *ResponseTimings) *Timings {
+	t := &Timings{chain: parent.clone(), value: nil}
+
+	opChain := t.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil timings"),
+			},
+		})
+	} else {
+		t.value = val
+	}
+
+	return t
+}
+
+// Raw returns underlying ResponseTimings value attached to Timings.
+// This is the value originally passed to NewTimings.
+//
+// Example:
+//
+//	timings := NewTimings(t, value)
+//	assert.Equal(t, value, timings.Raw())
+func (t *Timings) Raw() *ResponseTimings {
+	return t.value
+}
+
+// Alias is similar to Value.Alias.
+func (t *Timings) Alias(name string) *Timings {
+	opChain := t.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	t.chain.setAlias(name)
+	return t
+}
+
+// DNS returns a new Duration instance with duration of DNS lookup.
+//
+// Example:
+//
+//	timings := NewTimings(t, value)
+//	timings.DNS().Le(10 * time.Millisecond)
+func (t *Timings) DNS() *Duration {
+	return t.phase("DNS()", func(v *ResponseTimings) time.Duration {
+		return v.DNS
+	})
+}
+
+// Connect returns a new Duration instance with duration of establishing
+// TCP connection.
+//
+// Example:
+//
+//	timings := NewTimings(t, value)
+//	timings.Connect().Le(10 * time.Millisecond)
+func (t *Timings) Connect() *Duration {
+	return t.phase("Connect()", func(v *ResponseTimings) time.Duration {
+		return v.Connect
+	})
+}
+
+// TLSHandshake returns a new Duration instance with duration of TLS handshake.
+//
+// Example:
+//
+//	timings := NewTimings(t, value)
+//	timings.TLSHandshake().Le(50 * time.Millisecond)
+func (t *Timings) TLSHandshake() *Duration {
+	return t.phase("TLSHandshake()", func(v *ResponseTimings) time.Duration {
+		return v.TLSHandshake
+	})
+}
+
+// TTFB returns a new Duration instance with time to first byte, i.e.
+// duration since request was fully written until first byte of response
+// was received.
+//
+// Example:
+//
+//	timings := NewTimings(t, value)
+//	timings.TTFB().Le(100 * time.Millisecond)
+func (t *Timings) TTFB() *Duration {
+	return t.phase("TTFB()", func(v *ResponseTimings) time.Duration {
+		return v.TTFB
+	})
+}
+
+// Transfer returns a new Duration instance with duration since first byte
+// of response was received until response body was fully read.
+//
+// Example:
+//
+//	timings := NewTimings(t, value)
+//	timings.Transfer().Le(time.Second)
+func (t *Timings) Transfer() *Duration {
+	return t.phase("Transfer()", func(v *ResponseTimings) time.Duration {
+		return v.Transfer
+	})
+}
+
+// Total returns a new Duration instance with duration since request was
+// started until response body was fully read.
+//
+// Example:
+//
+//	timings := NewTimings(t, value)
+//	timings.Total().Le(time.Second)
+func (t *Timings) Total() *Duration {
+	return t.phase("Total()", func(v *ResponseTimings) time.Duration {
+		return v.Total
+	})
+}
+
+func (t *Timings) phase(
+	method string, get func(*ResponseTimings) time.Duration,
+) *Duration {
+	opChain := t.chain.enter(method)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	value := get(t.value)
+
+	return newDuration(opChain, &value)
+}
+
+// Timestamps of request phases collected using httptrace hooks.
+type requestTimings struct {
+	mu    sync.Mutex
+	clock Clock
+
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+	bodyDone     time.Time
+}
+
+func newRequestTimings(clock Clock) *requestTimings {
+	return &requestTimings{
+		clock: clock,
+		start: clock.Now(),
+	}
+}
+
+// Returns request with attached httptrace hooks that record timestamps.
+//
+// If request is redirected, timestamps are overwritten by every request
+// in redirect chain, so that they correspond to the last one.
+func (rt *requestTimings) trace(httpReq *http.Request) *http.Request {
+	set := func(ts *time.Time) {
+		rt.mu.Lock()
+		defer rt.mu.Unlock()
+
+		*ts = rt.clock.Now()
+	}
+
+	trace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			rt.mu.Lock()
+			defer rt.mu.Unlock()
+
+			// reset phases of previous request in redirect chain
+			rt.dnsStart, rt.dnsDone = time.Time{}, time.Time{}
+			rt.connectStart, rt.connectDone = time.Time{}, time.Time{}
+			rt.tlsStart, rt.tlsDone = time.Time{}, time.Time{}
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			set(&rt.dnsStart)
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			set(&rt.dnsDone)
+		},
+		ConnectStart: func(string, string) {
+			rt.mu.Lock()
+			defer rt.mu.Unlock()
+
+			// dialer may try several addresses concurrently
+			if rt.connectStart.IsZero() {
+				rt.connectStart = rt.clock.Now()
+			}
+		},
+		ConnectDone: func(string, string, error) {
+			set(&rt.connectDone)
+		},
+		TLSHandshakeStart: func() {
+			set(&rt.tlsStart)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			set(&rt.tlsDone)
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			set(&rt.wroteRequest)
+		},
+		GotFirstResponseByte: func() {
+			set(&rt.firstByte)
+		},
+	}
+
+	return httpReq.WithContext(
+		httptrace.WithClientTrace(httpReq.Context(), trace))
+}
+
+// Invoked when response body is fully read or closed.
+func (rt *requestTimings) done() {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.bodyDone.IsZero() {
+		rt.bodyDone = rt.clock.Now()
+	}
+}
+
+// Returns durations of phases, or nil if hooks were not invoked, e.g.
+// if client doesn't support httptrace.
+func (rt *requestTimings) durations() *ResponseTimings {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.firstByte.IsZero() {
+		return nil
+	}
+
+	since := func(start, end time.Time) time.Duration {
+		if start.IsZero() || end.IsZero() || end.Before(start) {
+			return 0
+		}
+		return end.Sub(start)
+	}
+
+	return &ResponseTimings{
+		DNS:          since(rt.dnsStart, rt.dnsDone),
+		Connect:      since(rt.connectStart, rt.connectDone),
+		TLSHandshake: since(rt.tlsStart, rt.tlsDone),
+		TTFB:         since(rt.wroteRequest, rt.firstByte),
+		Transfer:     since(rt.firstByte, rt.bodyDone),
+		Total:        since(rt.start, rt.bodyDone),
+	}
+}
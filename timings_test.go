@@ -0,0 +1,236 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimings_FailedChain(t *testing.T) {
+	check := func(value *Timings, isNil bool) {
+		value.chain.assert(t, failure)
+
+		if isNil {
+			assert.Nil(t, value.Raw())
+		} else {
+			assert.NotNil(t, value.Raw())
+		}
+
+		value.Alias("foo")
+
+		value.DNS().chain.assert(t, failure)
+		value.Connect().chain.assert(t, failure)
+		value.TLSHandshake().chain.assert(t, failure)
+		value.TTFB().chain.assert(t, failure)
+		value.Transfer().chain.assert(t, failure)
+		value.Total().chain.assert(t, failure)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newTimings(chain, &ResponseTimings{})
+
+		check(value, false)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newTimings(chain, nil)
+
+		check(value, true)
+	})
+
+	t.Run("failed chain, nil value", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newTimings(chain, nil)
+
+		check(value, true)
+	})
+}
+
+func TestTimings_Constructors(t *testing.T) {
+	timings := &ResponseTimings{
+		DNS: time.Millisecond,
+	}
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewTimings(reporter, timings)
+		value.DNS().IsEqual(time.Millisecond)
+		value.chain.assert(t, success)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewTimingsC(Config{
+			Reporter: reporter,
+		}, timings)
+		value.DNS().IsEqual(time.Millisecond)
+		value.chain.assert(t, success)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newTimings(chain, timings)
+		assert.NotSame(t, value.chain, &chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestTimings_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewTimings(reporter, &ResponseTimings{})
+	assert.Equal(t, []string{"Timings()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"Timings()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"Timings()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+
+	childValue := value.TTFB()
+	assert.Equal(t, []string{"Timings()", "TTFB()"},
+		childValue.chain.context.Path)
+	assert.Equal(t, []string{"foo", "TTFB()"},
+		childValue.chain.context.AliasedPath)
+}
+
+func TestTimings_Getters(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	data := &ResponseTimings{
+		DNS:          1 * time.Millisecond,
+		Connect:      2 * time.Millisecond,
+		TLSHandshake: 3 * time.Millisecond,
+		TTFB:         4 * time.Millisecond,
+		Transfer:     5 * time.Millisecond,
+		Total:        15 * time.Millisecond,
+	}
+
+	value := NewTimings(reporter, data)
+
+	assert.Same(t, data, value.Raw())
+
+	value.DNS().IsEqual(1 * time.Millisecond)
+	value.Connect().IsEqual(2 * time.Millisecond)
+	value.TLSHandshake().IsEqual(3 * time.Millisecond)
+	value.TTFB().IsEqual(4 * time.Millisecond)
+	value.Transfer().IsEqual(5 * time.Millisecond)
+	value.Total().IsEqual(15 * time.Millisecond)
+
+	value.chain.assert(t, success)
+
+	ttfb := value.TTFB()
+	ttfb.Gt(10 * time.Millisecond)
+	ttfb.chain.assert(t, failure)
+}
+
+func TestTimings_Trace(t *testing.T) {
+	getTrace := func(rt *requestTimings) *httptrace.ClientTrace {
+		req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+		require.NoError(t, err)
+
+		trace := httptrace.ContextClientTrace(rt.trace(req).Context())
+		require.NotNil(t, trace)
+
+		return trace
+	}
+
+	t.Run("all phases", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		rt := newRequestTimings(clock)
+		trace := getTrace(rt)
+
+		trace.GetConn("example.com:443")
+		clock.Advance(time.Millisecond)
+
+		trace.DNSStart(httptrace.DNSStartInfo{})
+		clock.Advance(2 * time.Millisecond)
+		trace.DNSDone(httptrace.DNSDoneInfo{})
+
+		trace.ConnectStart("tcp", "1.2.3.4:443")
+		clock.Advance(time.Millisecond)
+		trace.ConnectStart("tcp", "[::1]:443")
+		clock.Advance(3 * time.Millisecond)
+		trace.ConnectDone("tcp", "1.2.3.4:443", nil)
+
+		trace.TLSHandshakeStart()
+		clock.Advance(5 * time.Millisecond)
+		trace.TLSHandshakeDone(tls.ConnectionState{}, nil)
+
+		trace.WroteRequest(httptrace.WroteRequestInfo{})
+		clock.Advance(10 * time.Millisecond)
+		trace.GotFirstResponseByte()
+
+		clock.Advance(20 * time.Millisecond)
+		rt.done()
+
+		clock.Advance(time.Second)
+		rt.done()
+
+		assert.Equal(t, &ResponseTimings{
+			DNS:          2 * time.Millisecond,
+			Connect:      4 * time.Millisecond,
+			TLSHandshake: 5 * time.Millisecond,
+			TTFB:         10 * time.Millisecond,
+			Transfer:     20 * time.Millisecond,
+			Total:        42 * time.Millisecond,
+		}, rt.durations())
+	})
+
+	t.Run("reused connection", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		rt := newRequestTimings(clock)
+		trace := getTrace(rt)
+
+		trace.GetConn("example.com:80")
+		trace.WroteRequest(httptrace.WroteRequestInfo{})
+		clock.Advance(10 * time.Millisecond)
+		trace.GotFirstResponseByte()
+
+		assert.Equal(t, &ResponseTimings{
+			TTFB: 10 * time.Millisecond,
+		}, rt.durations())
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		rt := newRequestTimings(clock)
+		trace := getTrace(rt)
+
+		trace.GetConn("example.com:80")
+		trace.DNSStart(httptrace.DNSStartInfo{})
+		clock.Advance(2 * time.Millisecond)
+		trace.DNSDone(httptrace.DNSDoneInfo{})
+		trace.WroteRequest(httptrace.WroteRequestInfo{})
+		trace.GotFirstResponseByte()
+
+		trace.GetConn("example.com:80")
+		trace.WroteRequest(httptrace.WroteRequestInfo{})
+		clock.Advance(10 * time.Millisecond)
+		trace.GotFirstResponseByte()
+		rt.done()
+
+		assert.Equal(t, &ResponseTimings{
+			TTFB:  10 * time.Millisecond,
+			Total: 12 * time.Millisecond,
+		}, rt.durations())
+	})
+
+	t.Run("no hooks", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		rt := newRequestTimings(clock)
+		rt.done()
+
+		assert.Nil(t, rt.durations())
+	})
+}
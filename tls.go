@@ -0,0 +1,259 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+)
+
+// TLS provides methods to inspect attached tls.ConnectionState value.
+type TLS struct {
+	noCopy noCopy
+	chain  *chain
+	value  *tls.ConnectionState
+}
+
+// NewTLS returns a new TLS instance.
+//
+// If reporter is nil, the function panics.
+// If value is nil, failure is reported.
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//
+//	state.HasMinVersion(tls.VersionTLS12)
+//	state.NegotiatedProtocol().IsEqual("h2")
+//	state.Certificate(0).Subject().IsEqual("example.com")
+func NewTLS(reporter Reporter, value *tls.ConnectionState) *TLS {
+	return newTLS(newChainWithDefaults("TLS()", reporter), value)
+}
+
+// NewTLSC returns a new TLS instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is nil, failure is reported.
+//
+// See NewTLS for usage example.
+func NewTLSC(config Config, value *tls.ConnectionState) *TLS {
+	return newTLS(newChainWithConfig("TLS()", config.withDefaults()), value)
+}
+
+func newTLS(parent *chain, val *tls.ConnectionState) *TLS {
+	t := &TLS{chain: parent.clone(), value: nil}
+
+	opChain := t.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil tls connection state"),
+			},
+		})
+	} else {
+		t.value = val
+	}
+
+	return t
+}
+
+// Raw returns underlying tls.ConnectionState value attached to TLS.
+// This is the value originally passed to NewTLS.
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	assert.Equal(t, resp.TLS, state.Raw())
+func (t *TLS) Raw() *tls.ConnectionState {
+	return t.value
+}
+
+// Alias is similar to Value.Alias.
+func (t *TLS) Alias(name string) *TLS {
+	opChain := t.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	t.chain.setAlias(name)
+	return t
+}
+
+// Version returns a new String instance with name of negotiated TLS
+// version, e.g. "TLS 1.3".
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	state.Version().IsEqual("TLS 1.3")
+func (t *TLS) Version() *String {
+	opChain := t.chain.enter("Version()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, tlsVersionName(t.value.Version))
+}
+
+// HasMinVersion succeeds if negotiated TLS version is greater than or
+// equal to given version.
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	state.HasMinVersion(tls.VersionTLS12)
+func (t *TLS) HasMinVersion(version uint16) *TLS {
+	opChain := t.chain.enter("HasMinVersion()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return t
+	}
+
+	if t.value.Version < version {
+		opChain.fail(AssertionFailure{
+			Type:     AssertGe,
+			Actual:   &AssertionValue{tlsVersionName(t.value.Version)},
+			Expected: &AssertionValue{tlsVersionName(version)},
+			Errors: []error{
+				errors.New("expected: tls version is at least given version"),
+			},
+		})
+	}
+
+	return t
+}
+
+// CipherSuite returns a new String instance with name of negotiated
+// cipher suite, e.g. "TLS_AES_128_GCM_SHA256".
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	state.CipherSuite().NotIsEqual("TLS_RSA_WITH_RC4_128_SHA")
+func (t *TLS) CipherSuite() *String {
+	opChain := t.chain.enter("CipherSuite()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, tls.CipherSuiteName(t.value.CipherSuite))
+}
+
+// NegotiatedProtocol returns a new String instance with application
+// protocol negotiated via ALPN, e.g. "h2" or "http/1.1".
+//
+// If ALPN was not used, returned string is empty.
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	state.NegotiatedProtocol().IsEqual("h2")
+func (t *TLS) NegotiatedProtocol() *String {
+	opChain := t.chain.enter("NegotiatedProtocol()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, t.value.NegotiatedProtocol)
+}
+
+// ServerName returns a new String instance with server name sent by
+// client in SNI extension.
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	state.ServerName().IsEqual("example.com")
+func (t *TLS) ServerName() *String {
+	opChain := t.chain.enter("ServerName()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, t.value.ServerName)
+}
+
+// CertificateCount returns a new Number instance with number of
+// certificates in peer certificate chain.
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	state.CertificateCount().Ge(2)
+func (t *TLS) CertificateCount() *Number {
+	opChain := t.chain.enter("CertificateCount()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(len(t.value.PeerCertificates)))
+}
+
+// Certificate returns a new Certificate instance with peer certificate
+// with given index.
+//
+// Certificates are ordered as sent by peer: index 0 is the leaf
+// certificate, and subsequent certificates are intermediates.
+//
+// If index is out of bounds, Certificate reports failure and returns
+// empty (but non-nil) instance.
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	state.Certificate(0).DNSNames().ContainsAll("example.com")
+//	state.Certificate(0).IsValidFor(30 * 24 * time.Hour)
+func (t *TLS) Certificate(index int) *Certificate {
+	opChain := t.chain.enter("Certificate(%d)", index)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newCertificate(opChain, nil)
+	}
+
+	if index < 0 || index >= len(t.value.PeerCertificates) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{index},
+			Expected: &AssertionValue{AssertionRange{
+				Min: 0,
+				Max: len(t.value.PeerCertificates) - 1,
+			}},
+			Errors: []error{
+				errors.New("expected: valid certificate index"),
+			},
+		})
+		return newCertificate(opChain, nil)
+	}
+
+	return newCertificate(opChain, t.value.PeerCertificates[index])
+}
+
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionSSL30: //nolint
+		return "SSL 3.0"
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	default:
+		return fmt.Sprintf("0x%04X", version)
+	}
+}
@@ -0,0 +1,216 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTLS_FailedChain(t *testing.T) {
+	check := func(value *TLS, isNil bool) {
+		value.chain.assert(t, failure)
+
+		if isNil {
+			assert.Nil(t, value.Raw())
+		} else {
+			assert.NotNil(t, value.Raw())
+		}
+
+		value.Alias("foo")
+
+		value.Version().chain.assert(t, failure)
+		value.CipherSuite().chain.assert(t, failure)
+		value.NegotiatedProtocol().chain.assert(t, failure)
+		value.ServerName().chain.assert(t, failure)
+		value.CertificateCount().chain.assert(t, failure)
+		value.Certificate(0).chain.assert(t, failure)
+
+		value.HasMinVersion(tls.VersionTLS12)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newTLS(chain, &tls.ConnectionState{})
+
+		check(value, false)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newTLS(chain, nil)
+
+		check(value, true)
+	})
+
+	t.Run("failed chain, nil value", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newTLS(chain, nil)
+
+		check(value, true)
+	})
+}
+
+func TestTLS_Constructors(t *testing.T) {
+	state := &tls.ConnectionState{
+		Version: tls.VersionTLS13,
+	}
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewTLS(reporter, state)
+		value.Version().IsEqual("TLS 1.3")
+		value.chain.assert(t, success)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewTLSC(Config{
+			Reporter: reporter,
+		}, state)
+		value.Version().IsEqual("TLS 1.3")
+		value.chain.assert(t, success)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newTLS(chain, state)
+		assert.NotSame(t, value.chain, &chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestTLS_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewTLS(reporter, &tls.ConnectionState{})
+	assert.Equal(t, []string{"TLS()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"TLS()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"TLS()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+
+	childValue := value.Certificate(0)
+	assert.Equal(t, []string{"TLS()", "Certificate(0)"},
+		childValue.chain.context.Path)
+	assert.Equal(t, []string{"foo", "Certificate(0)"},
+		childValue.chain.context.AliasedPath)
+}
+
+func TestTLS_Getters(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	data := &tls.ConnectionState{
+		Version:            tls.VersionTLS12,
+		CipherSuite:        tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		NegotiatedProtocol: "h2",
+		ServerName:         "example.com",
+	}
+
+	value := NewTLS(reporter, data)
+
+	assert.Same(t, data, value.Raw())
+
+	value.Version().IsEqual("TLS 1.2")
+	value.CipherSuite().IsEqual("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256")
+	value.NegotiatedProtocol().IsEqual("h2")
+	value.ServerName().IsEqual("example.com")
+	value.CertificateCount().IsEqual(0)
+
+	value.chain.assert(t, success)
+}
+
+func TestTLS_Version(t *testing.T) {
+	cases := []struct {
+		version uint16
+		name    string
+	}{
+		{tls.VersionTLS10, "TLS 1.0"},
+		{tls.VersionTLS11, "TLS 1.1"},
+		{tls.VersionTLS12, "TLS 1.2"},
+		{tls.VersionTLS13, "TLS 1.3"},
+		{0x1234, "0x1234"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewTLS(reporter, &tls.ConnectionState{Version: tc.version}).
+				Version().IsEqual(tc.name).
+				chain.assert(t, success)
+		})
+	}
+}
+
+func TestTLS_HasMinVersion(t *testing.T) {
+	cases := []struct {
+		name       string
+		version    uint16
+		minVersion uint16
+		result     chainResult
+	}{
+		{
+			name:       "greater",
+			version:    tls.VersionTLS13,
+			minVersion: tls.VersionTLS12,
+			result:     success,
+		},
+		{
+			name:       "equal",
+			version:    tls.VersionTLS12,
+			minVersion: tls.VersionTLS12,
+			result:     success,
+		},
+		{
+			name:       "less",
+			version:    tls.VersionTLS11,
+			minVersion: tls.VersionTLS12,
+			result:     failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewTLS(reporter, &tls.ConnectionState{Version: tc.version}).
+				HasMinVersion(tc.minVersion).
+				chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestTLS_Certificate(t *testing.T) {
+	leaf := &x509.Certificate{
+		Subject: pkix.Name{CommonName: "example.com"},
+	}
+	intermediate := &x509.Certificate{
+		Subject: pkix.Name{CommonName: "Example CA"},
+	}
+
+	reporter := newMockReporter(t)
+
+	value := NewTLS(reporter, &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{leaf, intermediate},
+	})
+
+	value.CertificateCount().IsEqual(2)
+
+	assert.Same(t, leaf, value.Certificate(0).Raw())
+	assert.Same(t, intermediate, value.Certificate(1).Raw())
+
+	value.Certificate(0).Subject().IsEqual("example.com")
+	value.Certificate(1).Subject().IsEqual("Example CA")
+
+	value.chain.assert(t, success)
+
+	value.Certificate(2).chain.assert(t, failure)
+	value.chain.clear()
+
+	value.Certificate(-1).chain.assert(t, failure)
+	value.chain.clear()
+}
@@ -0,0 +1,429 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// PathTemplateStyle defines how named parameters in request path are
+// substituted by Request.WithPath and Request.WithPathObject.
+type PathTemplateStyle int
+
+const (
+	// PathTemplateSimple substitutes every "{name}" parameter with
+	// fmt.Sprint() of its value. This is the default.
+	PathTemplateSimple PathTemplateStyle = iota
+
+	// PathTemplateRFC6570 treats path as URI template defined by RFC 6570.
+	// Expressions like "{/id}", "{+path}", "{?query*}", and "{#fragment}"
+	// are expanded when the request is sent. Expansion may add query
+	// parameters and fragment to request URL.
+	PathTemplateRFC6570
+)
+
+// Parsed URI template, as defined in RFC 6570.
+//
+// Supports all expression operators (level 3) and also value modifiers,
+// i.e. prefix (":N") and explode ("*") (level 4).
+type uriTemplate struct {
+	parts []uriTemplatePart
+}
+
+// Either literal or expression.
+type uriTemplatePart struct {
+	literal string
+	expr    *uriTemplateExpr
+}
+
+type uriTemplateExpr struct {
+	op   uriTemplateOp
+	vars []uriTemplateVar
+}
+
+type uriTemplateVar struct {
+	name    string
+	prefix  int
+	explode bool
+}
+
+// Expansion rules for every operator, see RFC 6570, Appendix A.
+type uriTemplateOp struct {
+	first    string
+	sep      string
+	named    bool
+	ifEmpty  string
+	reserved bool
+}
+
+var uriTemplateOps = map[byte]uriTemplateOp{
+	'+': {first: "", sep: ",", named: false, ifEmpty: "", reserved: true},
+	'.': {first: ".", sep: ".", named: false, ifEmpty: "", reserved: false},
+	'/': {first: "/", sep: "/", named: false, ifEmpty: "", reserved: false},
+	';': {first: ";", sep: ";", named: true, ifEmpty: "", reserved: false},
+	'?': {first: "?", sep: "&", named: true, ifEmpty: "=", reserved: false},
+	'&': {first: "&", sep: "&", named: true, ifEmpty: "=", reserved: false},
+	'#': {first: "#", sep: ",", named: false, ifEmpty: "", reserved: true},
+}
+
+var uriTemplateSimpleOp = uriTemplateOp{
+	first: "", sep: ",", named: false, ifEmpty: "", reserved: false,
+}
+
+func parseURITemplate(template string) (*uriTemplate, error) {
+	t := &uriTemplate{}
+
+	for len(template) != 0 {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			if strings.IndexByte(template, '}') >= 0 {
+				return nil, errors.New("unexpected '}' outside of expression")
+			}
+			t.parts = append(t.parts, uriTemplatePart{literal: template})
+			break
+		}
+
+		if start > 0 {
+			literal := template[:start]
+			if strings.IndexByte(literal, '}') >= 0 {
+				return nil, errors.New("unexpected '}' outside of expression")
+			}
+			t.parts = append(t.parts, uriTemplatePart{literal: literal})
+		}
+
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			return nil, errors.New("unclosed expression: missing '}'")
+		}
+		end += start
+
+		expr, err := parseURITemplateExpr(template[start+1 : end])
+		if err != nil {
+			return nil, err
+		}
+		t.parts = append(t.parts, uriTemplatePart{expr: expr})
+
+		template = template[end+1:]
+	}
+
+	return t, nil
+}
+
+func parseURITemplateExpr(s string) (*uriTemplateExpr, error) {
+	expr := &uriTemplateExpr{op: uriTemplateSimpleOp}
+
+	if s == "" {
+		return nil, errors.New("empty expression")
+	}
+
+	if op, ok := uriTemplateOps[s[0]]; ok {
+		expr.op = op
+		s = s[1:]
+	} else if strings.ContainsRune("=,!@|", rune(s[0])) {
+		return nil, fmt.Errorf("reserved operator %q", s[0])
+	}
+
+	for _, spec := range strings.Split(s, ",") {
+		v := uriTemplateVar{name: spec}
+
+		if strings.HasSuffix(spec, "*") {
+			v.name = strings.TrimSuffix(spec, "*")
+			v.explode = true
+		} else if i := strings.IndexByte(spec, ':'); i >= 0 {
+			v.name = spec[:i]
+
+			n, err := strconv.Atoi(spec[i+1:])
+			if err != nil || n <= 0 || n >= 10000 {
+				return nil, fmt.Errorf("invalid prefix modifier in %q", spec)
+			}
+			v.prefix = n
+		}
+
+		if !isURITemplateVarName(v.name) {
+			return nil, fmt.Errorf("invalid variable name %q", v.name)
+		}
+
+		expr.vars = append(expr.vars, v)
+	}
+
+	return expr, nil
+}
+
+func isURITemplateVarName(name string) bool {
+	if name == "" || name[0] == '.' || name[len(name)-1] == '.' {
+		return false
+	}
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '_' || c == '.':
+		case c == '%':
+			if i+2 >= len(name) || !isHexDigit(name[i+1]) || !isHexDigit(name[i+2]) {
+				return false
+			}
+			i += 2
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// Check if template has variable with given name.
+func (t *uriTemplate) hasVar(name string) bool {
+	for _, part := range t.parts {
+		if part.expr == nil {
+			continue
+		}
+		for _, v := range part.expr.vars {
+			if v.name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Expand template using given variables.
+//
+// Variable values may be strings, numbers, booleans, slices (lists), or maps
+// (associative arrays). Missing and nil variables, as well as empty lists
+// and maps, are undefined and are omitted from expansion.
+func (t *uriTemplate) expand(vars map[string]interface{}) (string, error) {
+	var b strings.Builder
+
+	for _, part := range t.parts {
+		if part.expr == nil {
+			b.WriteString(part.literal)
+			continue
+		}
+
+		if err := part.expr.expand(&b, vars); err != nil {
+			return "", err
+		}
+	}
+
+	return b.String(), nil
+}
+
+func (e *uriTemplateExpr) expand(b *strings.Builder, vars map[string]interface{}) error {
+	first := true
+
+	for _, v := range e.vars {
+		value, ok := vars[v.name]
+		if !ok || value == nil {
+			continue
+		}
+
+		var (
+			s   string
+			def bool
+			err error
+		)
+
+		rv := reflect.ValueOf(value)
+		for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+			if rv.IsNil() {
+				break
+			}
+			rv = rv.Elem()
+		}
+
+		switch rv.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			continue
+
+		case reflect.Slice, reflect.Array:
+			s, def, err = e.expandList(v, rv)
+
+		case reflect.Map:
+			s, def, err = e.expandMap(v, rv)
+
+		default:
+			s, def = e.expandString(v, fmt.Sprint(rv.Interface())), true
+		}
+
+		if err != nil {
+			return err
+		}
+		if !def {
+			continue
+		}
+
+		if first {
+			b.WriteString(e.op.first)
+			first = false
+		} else {
+			b.WriteString(e.op.sep)
+		}
+		b.WriteString(s)
+	}
+
+	return nil
+}
+
+func (e *uriTemplateExpr) expandString(v uriTemplateVar, value string) string {
+	if v.prefix > 0 && utf8.RuneCountInString(value) > v.prefix {
+		runes := []rune(value)
+		value = string(runes[:v.prefix])
+	}
+
+	if e.op.named {
+		if value == "" {
+			return v.name + e.op.ifEmpty
+		}
+		return v.name + "=" + e.encode(value)
+	}
+
+	return e.encode(value)
+}
+
+func (e *uriTemplateExpr) expandList(
+	v uriTemplateVar, rv reflect.Value,
+) (string, bool, error) {
+	if v.prefix > 0 {
+		return "", false, fmt.Errorf(
+			"prefix modifier can't be applied to list variable %q", v.name)
+	}
+
+	if rv.Len() == 0 {
+		return "", false, nil
+	}
+
+	items := make([]string, 0, rv.Len())
+
+	for i := 0; i < rv.Len(); i++ {
+		item := fmt.Sprint(rv.Index(i).Interface())
+
+		if v.explode && e.op.named {
+			if item == "" {
+				items = append(items, v.name+e.op.ifEmpty)
+			} else {
+				items = append(items, v.name+"="+e.encode(item))
+			}
+		} else {
+			items = append(items, e.encode(item))
+		}
+	}
+
+	if v.explode {
+		return strings.Join(items, e.op.sep), true, nil
+	}
+
+	s := strings.Join(items, ",")
+	if e.op.named {
+		s = v.name + "=" + s
+	}
+
+	return s, true, nil
+}
+
+func (e *uriTemplateExpr) expandMap(
+	v uriTemplateVar, rv reflect.Value,
+) (string, bool, error) {
+	if v.prefix > 0 {
+		return "", false, fmt.Errorf(
+			"prefix modifier can't be applied to map variable %q", v.name)
+	}
+
+	if rv.Len() == 0 {
+		return "", false, nil
+	}
+
+	type entry struct {
+		key   string
+		value string
+	}
+
+	entries := make([]entry, 0, rv.Len())
+
+	iter := rv.MapRange()
+	for iter.Next() {
+		entries = append(entries, entry{
+			key:   fmt.Sprint(iter.Key().Interface()),
+			value: fmt.Sprint(iter.Value().Interface()),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	items := make([]string, 0, len(entries)*2)
+
+	for _, ent := range entries {
+		if v.explode {
+			if e.op.named && ent.value == "" {
+				items = append(items, e.encode(ent.key)+e.op.ifEmpty)
+			} else {
+				items = append(items, e.encode(ent.key)+"="+e.encode(ent.value))
+			}
+		} else {
+			items = append(items, e.encode(ent.key), e.encode(ent.value))
+		}
+	}
+
+	if v.explode {
+		return strings.Join(items, e.op.sep), true, nil
+	}
+
+	s := strings.Join(items, ",")
+	if e.op.named {
+		s = v.name + "=" + s
+	}
+
+	return s, true, nil
+}
+
+// Percent-encode string, keeping unreserved characters, and also reserved
+// characters and pct-encoded triplets if operator allows them.
+func (e *uriTemplateExpr) encode(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case isURIUnreserved(c):
+			b.WriteByte(c)
+
+		case e.op.reserved && isURIReserved(c):
+			b.WriteByte(c)
+
+		case e.op.reserved && c == '%' &&
+			i+2 < len(s) && isHexDigit(s[i+1]) && isHexDigit(s[i+2]):
+			b.WriteString(s[i : i+3])
+			i += 2
+
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&15])
+		}
+	}
+
+	return b.String()
+}
+
+func isURIUnreserved(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isURIReserved(c byte) bool {
+	return strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0
+}
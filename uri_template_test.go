@@ -0,0 +1,147 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURITemplate_Expand(t *testing.T) {
+	// examples from RFC 6570
+	vars := map[string]interface{}{
+		"count": []string{"one", "two", "three"},
+		"dom":   []string{"example", "com"},
+		"dub":   "me/too",
+		"hello": "Hello World!",
+		"half":  "50%",
+		"var":   "value",
+		"who":   "fred",
+		"base":  "http://example.com/home/",
+		"path":  "/foo/bar",
+		"list":  []string{"red", "green", "blue"},
+		"keys": map[string]string{
+			"semi": ";", "dot": ".", "comma": ",",
+		},
+		"v":          "6",
+		"x":          "1024",
+		"y":          "768",
+		"empty":      "",
+		"empty_keys": map[string]string{},
+		"undef":      nil,
+	}
+
+	cases := []struct {
+		template string
+		expected string
+	}{
+		{"{var}", "value"},
+		{"{hello}", "Hello%20World%21"},
+		{"{half}", "50%25"},
+		{"O{empty}X", "OX"},
+		{"O{undef}X", "OX"},
+		{"{x,y}", "1024,768"},
+		{"{x,hello,y}", "1024,Hello%20World%21,768"},
+		{"?{x,empty}", "?1024,"},
+		{"?{x,undef}", "?1024"},
+		{"{var:3}", "val"},
+		{"{var:30}", "value"},
+		{"{list}", "red,green,blue"},
+		{"{list*}", "red,green,blue"},
+		{"{keys}", "comma,%2C,dot,.,semi,%3B"},
+		{"{keys*}", "comma=%2C,dot=.,semi=%3B"},
+		{"{+var}", "value"},
+		{"{+hello}", "Hello%20World!"},
+		{"{+half}", "50%25"},
+		{"{base}index", "http%3A%2F%2Fexample.com%2Fhome%2Findex"},
+		{"{+base}index", "http://example.com/home/index"},
+		{"{+path}/here", "/foo/bar/here"},
+		{"here?ref={+path}", "here?ref=/foo/bar"},
+		{"{+path:6}/here", "/foo/b/here"},
+		{"{+keys*}", "comma=,,dot=.,semi=;"},
+		{"{#var}", "#value"},
+		{"{#hello}", "#Hello%20World!"},
+		{"{#path:6}/here", "#/foo/b/here"},
+		{"{#list*}", "#red,green,blue"},
+		{"X{.var}", "X.value"},
+		{"X{.x,y}", "X.1024.768"},
+		{"X{.list*}", "X.red.green.blue"},
+		{"www{.dom*}", "www.example.com"},
+		{"{/who}", "/fred"},
+		{"{/who,who}", "/fred/fred"},
+		{"{/half,who}", "/50%25/fred"},
+		{"{/who}{/dub}", "/fred/me%2Ftoo"},
+		{"{/var,x}/here", "/value/1024/here"},
+		{"{/var:1,var}", "/v/value"},
+		{"{/list*,path:4}", "/red/green/blue/%2Ffoo"},
+		{"{;who}", ";who=fred"},
+		{"{;half}", ";half=50%25"},
+		{"{;empty}", ";empty"},
+		{"{;v,empty,who}", ";v=6;empty;who=fred"},
+		{"{;x,y,undef}", ";x=1024;y=768"},
+		{"{;list*}", ";list=red;list=green;list=blue"},
+		{"{;keys*}", ";comma=%2C;dot=.;semi=%3B"},
+		{"{?who}", "?who=fred"},
+		{"{?x,y,empty}", "?x=1024&y=768&empty="},
+		{"{?x,y,undef}", "?x=1024&y=768"},
+		{"{?var:3}", "?var=val"},
+		{"{?list}", "?list=red,green,blue"},
+		{"{?list*}", "?list=red&list=green&list=blue"},
+		{"{?keys*}", "?comma=%2C&dot=.&semi=%3B"},
+		{"{?empty_keys*}", ""},
+		{"?fixed=yes{&x}", "?fixed=yes&x=1024"},
+		{"{&var:3}", "&var=val"},
+		{"{count}", "one,two,three"},
+		{"{/count*}", "/one/two/three"},
+		{"{?count*}{#who}", "?count=one&count=two&count=three#fred"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.template, func(t *testing.T) {
+			tmpl, err := parseURITemplate(tc.template)
+			require.NoError(t, err)
+
+			s, err := tmpl.expand(vars)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, s)
+		})
+	}
+}
+
+func TestURITemplate_Invalid(t *testing.T) {
+	templates := []string{
+		"{",
+		"}",
+		"a}b",
+		"{}",
+		"{var",
+		"{=var}",
+		"{var:}",
+		"{var:0}",
+		"{var:10000}",
+		"{var:x}",
+		"{a b}",
+		"{.var.}",
+		"{var,}",
+		"{%zz}",
+	}
+
+	for _, template := range templates {
+		t.Run(template, func(t *testing.T) {
+			_, err := parseURITemplate(template)
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("prefix with composite value", func(t *testing.T) {
+		tmpl, err := parseURITemplate("{list:2}{keys:2}")
+		require.NoError(t, err)
+
+		_, err = tmpl.expand(map[string]interface{}{"list": []int{1}})
+		assert.Error(t, err)
+
+		_, err = tmpl.expand(map[string]interface{}{"keys": map[string]int{"a": 1}})
+		assert.Error(t, err)
+	})
+}
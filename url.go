@@ -0,0 +1,300 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// URL provides methods to inspect attached url.URL value.
+type URL struct {
+	noCopy noCopy
+	chain  *chain
+	value  *url.URL
+}
+
+// NewURL returns a new URL instance.
+//
+// If reporter is nil, the function panics.
+// If value is nil, failure is reported.
+//
+// Example:
+//
+//	u, _ := url.Parse("http://example.com/path?a=1")
+//	url := NewURL(t, u)
+//
+//	url.Host().IsEqual("example.com")
+//	url.Path().IsEqual("/path")
+//	url.Query("a").IsEqual("1")
+func NewURL(reporter Reporter, value *url.URL) *URL {
+	return newURL(newChainWithDefaults("URL()", reporter), value)
+}
+
+// NewURLC returns a new URL instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is nil, failure is reported.
+//
+// See NewURL for usage example.
+func NewURLC(config Config, value *url.URL) *URL {
+	return newURL(newChainWithConfig("URL()", config.withDefaults()), value)
+}
+
+func newURL(parent *chain, val *url.URL) *URL {
+	u := &URL{chain: parent.clone(), value: nil}
+
+	opChain := u.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil url"),
+			},
+		})
+	} else {
+		u.value = val
+	}
+
+	return u
+}
+
+// Raw returns underlying url.URL value attached to URL.
+// This is the value originally passed to NewURL.
+//
+// Example:
+//
+//	url := NewURL(t, u)
+//	assert.Equal(t, u, url.Raw())
+func (u *URL) Raw() *url.URL {
+	return u.value
+}
+
+// Alias is similar to Value.Alias.
+func (u *URL) Alias(name string) *URL {
+	opChain := u.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	u.chain.setAlias(name)
+	return u
+}
+
+// Full returns a new String instance with full encoded URL.
+//
+// Example:
+//
+//	url := NewURL(t, u)
+//	url.Full().IsEqual("http://example.com/path?a=1")
+func (u *URL) Full() *String {
+	opChain := u.chain.enter("Full()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, u.value.String())
+}
+
+// Scheme returns a new String instance with URL scheme.
+//
+// Example:
+//
+//	url := NewURL(t, u)
+//	url.Scheme().IsEqual("https")
+func (u *URL) Scheme() *String {
+	opChain := u.chain.enter("Scheme()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, u.value.Scheme)
+}
+
+// Host returns a new String instance with URL host, including port,
+// if it's present.
+//
+// Example:
+//
+//	url := NewURL(t, u)
+//	url.Host().IsEqual("example.com:8080")
+func (u *URL) Host() *String {
+	opChain := u.chain.enter("Host()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, u.value.Host)
+}
+
+// Path returns a new String instance with URL path, in decoded form.
+//
+// Example:
+//
+//	url := NewURL(t, u)
+//	url.Path().IsEqual("/users/john doe")
+func (u *URL) Path() *String {
+	opChain := u.chain.enter("Path()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, u.value.Path)
+}
+
+// RawQuery returns a new String instance with URL query string, in encoded
+// form, without leading '?'.
+//
+// Example:
+//
+//	url := NewURL(t, u)
+//	url.RawQuery().IsEqual("a=1&b=2")
+func (u *URL) RawQuery() *String {
+	opChain := u.chain.enter("RawQuery()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, u.value.RawQuery)
+}
+
+// Queries returns a new Object instance with all query parameters.
+//
+// Keys are parameter names, and values are arrays of parameter values.
+//
+// Example:
+//
+//	url := NewURL(t, u)
+//	url.Queries().IsEqual(map[string]interface{}{
+//		"a": []string{"1"},
+//		"b": []string{"2", "3"},
+//	})
+func (u *URL) Queries() *Object {
+	opChain := u.chain.enter("Queries()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	values, err := url.ParseQuery(u.value.RawQuery)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{u.value.RawQuery},
+			Errors: []error{
+				errors.New("expected: valid query string"),
+				err,
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	var value map[string]interface{}
+	value, _ = canonMap(opChain, values)
+
+	return newObject(opChain, value)
+}
+
+// Query returns a new String instance with first value of given query
+// parameter. If there is no such parameter, empty string is used.
+//
+// Example:
+//
+//	url := NewURL(t, u)
+//	url.Query("a").IsEqual("1")
+func (u *URL) Query(name string) *String {
+	opChain := u.chain.enter("Query(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, u.value.Query().Get(name))
+}
+
+// ContainsQuery succeeds if URL has query parameter with given name.
+//
+// Example:
+//
+//	url := NewURL(t, u)
+//	url.ContainsQuery("a")
+func (u *URL) ContainsQuery(name string) *URL {
+	opChain := u.chain.enter("ContainsQuery(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return u
+	}
+
+	if !u.value.Query().Has(name) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{u.value.RawQuery},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: url contains query parameter %q", name),
+			},
+		})
+	}
+
+	return u
+}
+
+// NotContainsQuery succeeds if URL doesn't have query parameter with
+// given name.
+//
+// Example:
+//
+//	url := NewURL(t, u)
+//	url.NotContainsQuery("debug")
+func (u *URL) NotContainsQuery(name string) *URL {
+	opChain := u.chain.enter("NotContainsQuery(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return u
+	}
+
+	if u.value.Query().Has(name) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{u.value.RawQuery},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: url does not contain query parameter %q", name),
+			},
+		})
+	}
+
+	return u
+}
+
+// Fragment returns a new String instance with URL fragment, in decoded
+// form, without leading '#'.
+//
+// Example:
+//
+//	url := NewURL(t, u)
+//	url.Fragment().IsEqual("section")
+func (u *URL) Fragment() *String {
+	opChain := u.chain.enter("Fragment()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, u.value.Fragment)
+}
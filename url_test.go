@@ -0,0 +1,200 @@
+package httpexpect
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURL_FailedChain(t *testing.T) {
+	check := func(value *URL, isNil bool) {
+		value.chain.assert(t, failure)
+
+		if isNil {
+			assert.Nil(t, value.Raw())
+		} else {
+			assert.NotNil(t, value.Raw())
+		}
+
+		value.Alias("foo")
+
+		value.Full().chain.assert(t, failure)
+		value.Scheme().chain.assert(t, failure)
+		value.Host().chain.assert(t, failure)
+		value.Path().chain.assert(t, failure)
+		value.RawQuery().chain.assert(t, failure)
+		value.Queries().chain.assert(t, failure)
+		value.Query("a").chain.assert(t, failure)
+		value.Fragment().chain.assert(t, failure)
+
+		value.ContainsQuery("a")
+		value.NotContainsQuery("a")
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newURL(chain, &url.URL{})
+
+		check(value, false)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newURL(chain, nil)
+
+		check(value, true)
+	})
+
+	t.Run("failed chain, nil value", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newURL(chain, nil)
+
+		check(value, true)
+	})
+}
+
+func TestURL_Constructors(t *testing.T) {
+	u, err := url.Parse("http://example.com/path")
+	require.NoError(t, err)
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewURL(reporter, u)
+		value.Path().IsEqual("/path")
+		value.chain.assert(t, success)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewURLC(Config{
+			Reporter: reporter,
+		}, u)
+		value.Path().IsEqual("/path")
+		value.chain.assert(t, success)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newURL(chain, u)
+		assert.NotSame(t, value.chain, &chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestURL_Raw(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	data := url.URL{}
+
+	value := NewURL(reporter, &data)
+
+	assert.Same(t, &data, value.Raw())
+	value.chain.assert(t, success)
+}
+
+func TestURL_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewURL(reporter, &url.URL{})
+	assert.Equal(t, []string{"URL()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"URL()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"URL()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+}
+
+func TestURL_Getters(t *testing.T) {
+	u, err := url.Parse("https://example.com:8080/users/john%20doe?a=1&b=2&b=3#top")
+	require.NoError(t, err)
+
+	reporter := newMockReporter(t)
+
+	value := NewURL(reporter, u)
+
+	value.Full().IsEqual("https://example.com:8080/users/john%20doe?a=1&b=2&b=3#top")
+	value.Scheme().IsEqual("https")
+	value.Host().IsEqual("example.com:8080")
+	value.Path().IsEqual("/users/john doe")
+	value.RawQuery().IsEqual("a=1&b=2&b=3")
+	value.Query("a").IsEqual("1")
+	value.Query("b").IsEqual("2")
+	value.Query("c").IsEmpty()
+	value.Fragment().IsEqual("top")
+
+	value.Queries().IsEqual(map[string]interface{}{
+		"a": []string{"1"},
+		"b": []string{"2", "3"},
+	})
+
+	value.chain.assert(t, success)
+
+	t.Run("invalid query", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewURL(reporter, &url.URL{RawQuery: "a=%"})
+
+		value.Queries().chain.assert(t, failure)
+		value.chain.assert(t, failure)
+	})
+}
+
+func TestURL_ContainsQuery(t *testing.T) {
+	cases := []struct {
+		name           string
+		rawQuery       string
+		query          string
+		expectContains bool
+	}{
+		{
+			name:           "present",
+			rawQuery:       "a=1&b=2",
+			query:          "a",
+			expectContains: true,
+		},
+		{
+			name:           "present with empty value",
+			rawQuery:       "a=&b=2",
+			query:          "a",
+			expectContains: true,
+		},
+		{
+			name:           "missing",
+			rawQuery:       "a=1&b=2",
+			query:          "c",
+			expectContains: false,
+		},
+		{
+			name:           "empty query",
+			rawQuery:       "",
+			query:          "a",
+			expectContains: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			if tc.expectContains {
+				NewURL(reporter, &url.URL{RawQuery: tc.rawQuery}).
+					ContainsQuery(tc.query).
+					chain.assert(t, success)
+
+				NewURL(reporter, &url.URL{RawQuery: tc.rawQuery}).
+					NotContainsQuery(tc.query).
+					chain.assert(t, failure)
+			} else {
+				NewURL(reporter, &url.URL{RawQuery: tc.rawQuery}).
+					ContainsQuery(tc.query).
+					chain.assert(t, failure)
+
+				NewURL(reporter, &url.URL{RawQuery: tc.rawQuery}).
+					NotContainsQuery(tc.query).
+					chain.assert(t, success)
+			}
+		})
+	}
+}
@@ -2,7 +2,10 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 // Value provides methods to inspect attached interface{} object
@@ -110,6 +113,81 @@ func (v *Value) Decode(target interface{}) *Value {
 	return v
 }
 
+// Capture stores the underlying value into a Go variable, which is useful
+// to pass values from one response to the next request.
+//
+// target should be a non-nil pointer. Value is converted to target type
+// the same way as in Decode, but unlike Decode, Capture is strict:
+//   - if value is null, failure is reported, unless target is pointer to
+//     a pointer, interface, slice, or map
+//   - if value can't be converted to target type, failure is reported
+//     and target is not modified
+//
+// Example:
+//
+//	var id int
+//	e.POST("/users").WithJSON(user).
+//		Expect().
+//		Status(http.StatusCreated).
+//		JSON().Path("$.id").Capture(&id)
+//
+//	e.GET("/users/{id}", id).
+//		Expect().
+//		Status(http.StatusOK)
+func (v *Value) Capture(target interface{}) *Value {
+	opChain := v.chain.enter("Capture()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	targetPtr := reflect.ValueOf(target)
+
+	if target == nil || targetPtr.Kind() != reflect.Ptr || targetPtr.IsNil() {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					"unexpected target argument: expected non-nil pointer, got %T",
+					target),
+			},
+		})
+		return v
+	}
+
+	targetType := targetPtr.Elem().Type()
+
+	if v.value == nil {
+		switch targetType.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		default:
+			opChain.fail(AssertionFailure{
+				Type:   AssertNotNil,
+				Actual: &AssertionValue{v.value},
+				Errors: []error{
+					errors.New("expected: value is not null"),
+					fmt.Errorf("can't capture null into %s", targetType),
+				},
+			})
+			return v
+		}
+	}
+
+	// decode into temporary variable to leave target untouched on failure
+	tmp := reflect.New(targetType)
+
+	canonDecode(opChain, v.value, tmp.Interface())
+
+	if opChain.failed() {
+		return v
+	}
+
+	targetPtr.Elem().Set(tmp.Elem())
+
+	return v
+}
+
 // Alias returns a new Value object with alias.
 // When a test of Value object with alias is failed,
 // an assertion is displayed as a chain starting from the alias.
@@ -139,6 +217,84 @@ func (v *Value) Alias(name string) *Value {
 	return v
 }
 
+// Warn returns a copy of Value in warning mode.
+//
+// Failures of assertions made on returned copy, and on values derived from
+// it, are reported to AssertionHandler with SeverityWarning. Such failures
+// are reported, but don't fail the test and don't affect original Value.
+// DefaultAssertionHandler logs warnings using Logger, or using Reporter
+// if it implements Logger (e.g. testing.T).
+//
+// Example:
+//
+//	value := NewValue(t, "deprecated")
+//
+//	value.Warn().NotEqual("deprecated") // warning is logged, test continues
+//	value.IsEqual("deprecated")         // success
+func (v *Value) Warn() *Value {
+	opChain := v.chain.enter("Warn()")
+	defer opChain.leave()
+
+	ret := newValue(opChain, v.value)
+
+	ret.chain.setRoot()
+	ret.chain.setSeverity(SeverityWarning)
+
+	return ret
+}
+
+// Assert invokes given function with underlying value and reports failure
+// if it returns non-nil error.
+//
+// It allows to implement custom checks that are not provided by httpexpect,
+// while still reporting failures via AssertionHandler, with assertion path,
+// actual value, and returned error included in failure message.
+//
+// Function should not modify given value.
+//
+// Example:
+//
+//	value := NewValue(t, "2024-01-01")
+//	value.Assert(func(v interface{}) error {
+//		s, ok := v.(string)
+//		if !ok {
+//			return errors.New("expected string")
+//		}
+//		_, err := time.Parse("2006-01-02", s)
+//		return err
+//	})
+func (v *Value) Assert(fn func(value interface{}) error) *Value {
+	opChain := v.chain.enter("Assert()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return v
+	}
+
+	if err := fn(v.value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: value satisfies custom assertion"),
+				err,
+			},
+		})
+	}
+
+	return v
+}
+
 // Path returns a new Value object for child object(s) matching given
 // JSONPath expression.
 //
@@ -383,6 +539,118 @@ func (v *Value) Boolean() *Boolean {
 	return newBoolean(opChain, data)
 }
 
+// AsNumber returns a new Number attached to underlying value, which may be
+// either a number or a string containing a number.
+//
+// Strings are parsed using strconv.ParseFloat, after trimming surrounding
+// whitespace. It's useful for APIs that return numbers as strings, e.g. to
+// avoid precision loss. If underlying value is neither a number nor a string
+// that can be parsed to number, failure is reported and empty (but non-nil)
+// value is returned.
+//
+// Example:
+//
+//	value := NewValue(t, "123.5")
+//	value.AsNumber().IsEqual(123.5)
+//
+//	value := NewValue(t, 123.5)
+//	value.AsNumber().IsEqual(123.5)
+func (v *Value) AsNumber() *Number {
+	opChain := v.chain.enter("AsNumber()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	switch data := v.value.(type) {
+	case float64:
+		return newNumber(opChain, data)
+
+	case string:
+		num, err := strconv.ParseFloat(strings.TrimSpace(data), 64)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{v.value},
+				Errors: []error{
+					errors.New("expected: string can be parsed to number"),
+					err,
+				},
+			})
+			return newNumber(opChain, 0)
+		}
+
+		return newNumber(opChain, num)
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{v.value},
+		Errors: []error{
+			errors.New("expected: value is number or string containing number"),
+		},
+	})
+
+	return newNumber(opChain, 0)
+}
+
+// AsBoolean returns a new Boolean attached to underlying value, which may be
+// either a boolean or a string containing a boolean.
+//
+// Accepts string values "true", "True", "false", "False", the same as
+// String.AsBoolean. If underlying value is neither a boolean nor such
+// string, failure is reported and empty (but non-nil) value is returned.
+//
+// Example:
+//
+//	value := NewValue(t, "true")
+//	value.AsBoolean().IsTrue()
+//
+//	value := NewValue(t, true)
+//	value.AsBoolean().IsTrue()
+func (v *Value) AsBoolean() *Boolean {
+	opChain := v.chain.enter("AsBoolean()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBoolean(opChain, false)
+	}
+
+	switch data := v.value.(type) {
+	case bool:
+		return newBoolean(opChain, data)
+
+	case string:
+		switch data {
+		case "true", "True":
+			return newBoolean(opChain, true)
+
+		case "false", "False":
+			return newBoolean(opChain, false)
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: string can be parsed to boolean"),
+			},
+		})
+		return newBoolean(opChain, false)
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{v.value},
+		Errors: []error{
+			errors.New("expected: value is boolean or string containing boolean"),
+		},
+	})
+
+	return newBoolean(opChain, false)
+}
+
 // IsNull succeeds if value is nil.
 //
 // Note that non-nil interface{} that points to nil value (e.g. nil slice or map)
@@ -763,15 +1031,18 @@ func (v *Value) IsEqual(value interface{}) *Value {
 		return v
 	}
 
-	expected, ok := canonValue(opChain, value)
+	canonExpected, ok := canonValue(opChain, value)
 	if !ok {
 		return v
 	}
 
-	if !reflect.DeepEqual(expected, v.value) {
+	actual := opChain.redact(v.value)
+	expected := opChain.redact(canonExpected)
+
+	if !reflect.DeepEqual(expected, actual) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
-			Actual:   &AssertionValue{v.value},
+			Actual:   &AssertionValue{actual},
 			Expected: &AssertionValue{expected},
 			Errors: []error{
 				errors.New("expected: values are equal"),
@@ -797,15 +1068,18 @@ func (v *Value) NotEqual(value interface{}) *Value {
 		return v
 	}
 
-	expected, ok := canonValue(opChain, value)
+	canonExpected, ok := canonValue(opChain, value)
 	if !ok {
 		return v
 	}
 
-	if reflect.DeepEqual(expected, v.value) {
+	actual := opChain.redact(v.value)
+	expected := opChain.redact(canonExpected)
+
+	if reflect.DeepEqual(expected, actual) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
-			Actual:   &AssertionValue{v.value},
+			Actual:   &AssertionValue{actual},
 			Expected: &AssertionValue{expected},
 			Errors: []error{
 				errors.New("expected: values are non-equal"),
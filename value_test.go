@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"runtime"
 	"testing"
@@ -19,15 +20,19 @@ func TestValue_FailedChain(t *testing.T) {
 	value.Path("$").chain.assert(t, failure)
 	value.Schema("")
 	value.Alias("foo")
+	value.Assert(nil)
 
 	var target interface{}
 	value.Decode(target)
+	value.Capture(&target)
 
 	value.Object().chain.assert(t, failure)
 	value.Array().chain.assert(t, failure)
 	value.String().chain.assert(t, failure)
 	value.Number().chain.assert(t, failure)
 	value.Boolean().chain.assert(t, failure)
+	value.AsNumber().chain.assert(t, failure)
+	value.AsBoolean().chain.assert(t, failure)
 
 	value.IsNull()
 	value.NotNull()
@@ -138,6 +143,150 @@ func TestValue_Decode(t *testing.T) {
 	})
 }
 
+func TestValue_Capture(t *testing.T) {
+	t.Run("number", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, 123.0)
+
+		var target int
+		value.Capture(&target)
+
+		value.chain.assert(t, success)
+		assert.Equal(t, 123, target)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "foo")
+
+		var target string
+		value.Capture(&target)
+
+		value.chain.assert(t, success)
+		assert.Equal(t, "foo", target)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		type S struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+
+		value := NewValue(reporter, map[string]interface{}{
+			"id":   1.0,
+			"name": "john",
+		})
+
+		var target S
+		value.Capture(&target)
+
+		value.chain.assert(t, success)
+		assert.Equal(t, S{ID: 1, Name: "john"}, target)
+	})
+
+	t.Run("path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, map[string]interface{}{
+			"user": map[string]interface{}{
+				"id": 42.0,
+			},
+		})
+
+		var target int
+		value.Path("$.user.id").Capture(&target)
+
+		value.chain.assert(t, success)
+		assert.Equal(t, 42, target)
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, map[string]interface{}{})
+
+		target := 1
+		value.Path("$.id").Capture(&target)
+
+		value.chain.assert(t, failure)
+		assert.Equal(t, 1, target)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, nil)
+
+		target := 1
+		value.Capture(&target)
+
+		value.chain.assert(t, failure)
+		assert.Equal(t, 1, target)
+	})
+
+	t.Run("null into pointer", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, nil)
+
+		target := new(int)
+		value.Capture(&target)
+
+		value.chain.assert(t, success)
+		assert.Nil(t, target)
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "foo")
+
+		target := 1
+		value.Capture(&target)
+
+		value.chain.assert(t, failure)
+		assert.Equal(t, 1, target)
+	})
+
+	t.Run("fractional into int", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, 1.5)
+
+		target := 1
+		value.Capture(&target)
+
+		value.chain.assert(t, failure)
+		assert.Equal(t, 1, target)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			target interface{}
+		}{
+			{name: "nil", target: nil},
+			{name: "not pointer", target: 123},
+			{name: "nil pointer", target: (*int)(nil)},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				value := NewValue(reporter, 123.0)
+				value.Capture(tc.target)
+
+				value.chain.assert(t, failure)
+			})
+		}
+	})
+}
+
 func TestValue_Alias(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -154,6 +303,118 @@ func TestValue_Alias(t *testing.T) {
 	assert.Equal(t, []string{"foo", "Number()"}, childValue.chain.context.AliasedPath)
 }
 
+func TestValue_Warn(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		value := NewValueC(Config{AssertionHandler: handler}, 123)
+
+		value.Warn().IsEqual(456)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, SeverityWarning, handler.failure.Severity)
+		assert.False(t, handler.failure.IsFatal)
+		assert.Equal(t, []string{"Value()", "Warn()", "IsEqual()"}, handler.ctx.Path)
+
+		value.chain.assert(t, success)
+	})
+
+	t.Run("derived values", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		value := NewValueC(Config{AssertionHandler: handler},
+			map[string]interface{}{"foo": 123})
+
+		warn := value.Warn()
+		warn.Object().Value("foo").Number().IsEqual(456)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, SeverityWarning, handler.failure.Severity)
+
+		assert.True(t, warn.chain.treeFailed())
+		assert.False(t, value.chain.treeFailed())
+
+		value.IsEqual(456)
+
+		assert.Equal(t, 2, handler.failureCalled)
+		assert.Equal(t, SeverityError, handler.failure.Severity)
+
+		value.chain.assert(t, failure)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		value := NewValueC(Config{AssertionHandler: handler}, 123)
+
+		value.Warn().IsEqual(123)
+
+		assert.Equal(t, 0, handler.failureCalled)
+		value.chain.assert(t, success)
+	})
+
+	t.Run("failed chain", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		value := NewValueC(Config{AssertionHandler: handler}, 123)
+
+		value.IsEqual(456)
+		assert.Equal(t, 1, handler.failureCalled)
+
+		warn := value.Warn()
+		warn.IsEqual(789)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		warn.chain.assert(t, failure)
+	})
+}
+
+func TestValue_Assert(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewValue(reporter, map[string]interface{}{"foo": 123})
+
+		var actual interface{}
+		value.Assert(func(v interface{}) error {
+			actual = v
+			return nil
+		})
+
+		value.chain.assert(t, success)
+		assert.Equal(t, map[string]interface{}{"foo": 123.0}, actual)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		value := NewValueC(Config{
+			AssertionHandler: handler,
+		}, "foo")
+
+		value.Assert(func(v interface{}) error {
+			return errors.New("custom error")
+		})
+
+		value.chain.assert(t, failure)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Equal(t, AssertValid, handler.failure.Type)
+		assert.Equal(t, &AssertionValue{"foo"}, handler.failure.Actual)
+		require.Equal(t, 2, len(handler.failure.Errors))
+		assert.Equal(t, "custom error", handler.failure.Errors[1].Error())
+		assert.Equal(t, []string{"Value()", "Assert()"}, handler.ctx.Path)
+	})
+
+	t.Run("nil function", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewValue(reporter, "foo")
+
+		value.Assert(nil)
+		value.chain.assert(t, failure)
+	})
+}
+
 func TestValue_Getters(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -464,6 +725,77 @@ func TestValue_GetBoolean(t *testing.T) {
 	}
 }
 
+func TestValue_AsNumber(t *testing.T) {
+	cases := []struct {
+		name        string
+		data        interface{}
+		result      chainResult
+		expectedNum float64
+	}{
+		{name: "number", data: 123.5, result: success, expectedNum: 123.5},
+		{name: "string", data: "123.5", result: success, expectedNum: 123.5},
+		{name: "negative string", data: "-10", result: success, expectedNum: -10},
+		{name: "exponent string", data: "1e3", result: success, expectedNum: 1000},
+		{name: "padded string", data: " 42 ", result: success, expectedNum: 42},
+		{name: "invalid string", data: "abc", result: failure},
+		{name: "empty string", data: "", result: failure},
+		{name: "boolean", data: true, result: failure},
+		{name: "null", data: nil, result: failure},
+		{name: "array", data: []interface{}{1}, result: failure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewValue(reporter, tc.data)
+			inner := value.AsNumber()
+
+			value.chain.assert(t, tc.result)
+			inner.chain.assert(t, tc.result)
+
+			if tc.result {
+				assert.Equal(t, tc.expectedNum, inner.Raw())
+			}
+		})
+	}
+}
+
+func TestValue_AsBoolean(t *testing.T) {
+	cases := []struct {
+		name         string
+		data         interface{}
+		result       chainResult
+		expectedBool bool
+	}{
+		{name: "true", data: true, result: success, expectedBool: true},
+		{name: "false", data: false, result: success, expectedBool: false},
+		{name: "true string", data: "true", result: success, expectedBool: true},
+		{name: "True string", data: "True", result: success, expectedBool: true},
+		{name: "false string", data: "false", result: success, expectedBool: false},
+		{name: "False string", data: "False", result: success, expectedBool: false},
+		{name: "invalid string", data: "yes", result: failure},
+		{name: "number", data: 1, result: failure},
+		{name: "null", data: nil, result: failure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewValue(reporter, tc.data)
+			inner := value.AsBoolean()
+
+			value.chain.assert(t, tc.result)
+			inner.chain.assert(t, tc.result)
+
+			if tc.result {
+				assert.Equal(t, tc.expectedBool, inner.Raw())
+			}
+		})
+	}
+}
+
 func TestValue_IsObject(t *testing.T) {
 	cases := []struct {
 		name       string
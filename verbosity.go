@@ -0,0 +1,96 @@
+package httpexpect
+
+import "fmt"
+
+// Verbosity defines which printers are used to print requests and
+// responses, see Config.Verbosity and Request.WithVerbosity.
+type Verbosity int
+
+const (
+	// VerbosityDefault uses Config.Printers as is.
+	VerbosityDefault Verbosity = iota
+
+	// VerbositySilent prints nothing. Failures are still reported, but
+	// warnings are not printed.
+	VerbositySilent
+
+	// VerbosityFailuresOnly prints requests and responses with bodies,
+	// but only for requests that have failed assertions (see LazyPrinter).
+	VerbosityFailuresOnly
+
+	// VerbosityCompact prints every request in compact form
+	// (see CompactPrinter).
+	VerbosityCompact
+
+	// VerbosityDebug prints every request and response with bodies
+	// (see DebugPrinter). Successful assertions are logged as well.
+	VerbosityDebug
+)
+
+func (v Verbosity) String() string {
+	switch v {
+	case VerbosityDefault:
+		return "VerbosityDefault"
+	case VerbositySilent:
+		return "VerbositySilent"
+	case VerbosityFailuresOnly:
+		return "VerbosityFailuresOnly"
+	case VerbosityCompact:
+		return "VerbosityCompact"
+	case VerbosityDebug:
+		return "VerbosityDebug"
+	}
+	return fmt.Sprintf("Verbosity(%d)", int(v))
+}
+
+func (v Verbosity) isValid() bool {
+	return v >= VerbosityDefault && v <= VerbosityDebug
+}
+
+// Returns logger used by printers created for verbosity level:
+// Config.Logger, or Config.Reporter if it implements Logger.
+func verbosityLogger(config Config) Logger {
+	if config.Logger != nil {
+		return config.Logger
+	}
+
+	logger, _ := config.Reporter.(Logger)
+	return logger
+}
+
+// Returns printers for given verbosity level.
+// For VerbosityDefault, returns Config.Printers.
+func verbosityPrinters(config Config, verbosity Verbosity) []Printer {
+	if verbosity == VerbosityDefault {
+		return config.Printers
+	}
+
+	logger := verbosityLogger(config)
+	if logger == nil {
+		return nil
+	}
+
+	switch verbosity {
+	case VerbosityFailuresOnly:
+		return []Printer{NewLazyPrinter(logger, true)}
+	case VerbosityCompact:
+		return []Printer{NewCompactPrinter(logger)}
+	case VerbosityDebug:
+		return []Printer{NewDebugPrinter(logger, true)}
+	}
+
+	return nil
+}
+
+// Returns logger for default assertion handler for given verbosity level,
+// or nil to keep default behavior.
+func verbosityHandlerLogger(config Config, verbosity Verbosity) Logger {
+	switch verbosity {
+	case VerbositySilent:
+		return LoggerFunc(func(string, ...interface{}) {})
+	case VerbosityDebug:
+		return verbosityLogger(config)
+	}
+
+	return nil
+}
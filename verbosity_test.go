@@ -0,0 +1,221 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerbosity_Printers(t *testing.T) {
+	logger := newMockLogger(t)
+
+	cases := []struct {
+		verbosity Verbosity
+		printers  []Printer
+	}{
+		{
+			verbosity: VerbositySilent,
+			printers:  nil,
+		},
+		{
+			verbosity: VerbosityFailuresOnly,
+			printers:  []Printer{NewLazyPrinter(logger, true)},
+		},
+		{
+			verbosity: VerbosityCompact,
+			printers:  []Printer{NewCompactPrinter(logger)},
+		},
+		{
+			verbosity: VerbosityDebug,
+			printers:  []Printer{NewDebugPrinter(logger, true)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.verbosity.String(), func(t *testing.T) {
+			config := Config{
+				Reporter:  newMockReporter(t),
+				Logger:    logger,
+				Verbosity: tc.verbosity,
+			}.withDefaults()
+
+			assert.Equal(t, tc.printers, config.Printers)
+		})
+	}
+
+	t.Run("VerbosityDefault", func(t *testing.T) {
+		printers := []Printer{NewCurlPrinter(logger)}
+
+		config := Config{
+			Reporter: newMockReporter(t),
+			Printers: printers,
+		}.withDefaults()
+
+		assert.Equal(t, printers, config.Printers)
+	})
+
+	t.Run("with printers", func(t *testing.T) {
+		config := Config{
+			Reporter:  newMockReporter(t),
+			Logger:    logger,
+			Verbosity: VerbosityDebug,
+			Printers:  []Printer{NewCurlPrinter(logger)},
+		}
+
+		assert.Error(t, config.Check())
+
+		assert.Panics(t, func() {
+			WithConfig(config)
+		})
+	})
+
+	t.Run("repeated defaults", func(t *testing.T) {
+		config := Config{
+			Reporter:  newMockReporter(t),
+			Logger:    logger,
+			Verbosity: VerbosityFailuresOnly,
+		}.withDefaults()
+
+		require.Equal(t, 1, len(config.Printers))
+
+		again := config.withDefaults()
+
+		require.Equal(t, 1, len(again.Printers))
+		assert.Same(t, &config.Printers[0], &again.Printers[0])
+	})
+
+	t.Run("reporter as logger", func(t *testing.T) {
+		config := Config{
+			Reporter:  t,
+			Verbosity: VerbosityCompact,
+		}.withDefaults()
+
+		assert.Equal(t, []Printer{NewCompactPrinter(t)}, config.Printers)
+	})
+
+	t.Run("no logger", func(t *testing.T) {
+		config := Config{
+			AssertionHandler: &mockAssertionHandler{},
+			Verbosity:        VerbosityDebug,
+		}.withDefaults()
+
+		assert.Nil(t, config.Printers)
+	})
+}
+
+func TestVerbosity_AssertionHandler(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		config := Config{
+			Reporter: newMockReporter(t),
+		}.withDefaults()
+
+		handler := config.AssertionHandler.(*DefaultAssertionHandler)
+		assert.Nil(t, handler.Logger)
+	})
+
+	t.Run("silent", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		config := Config{
+			Reporter:  reporter,
+			Verbosity: VerbositySilent,
+		}.withDefaults()
+
+		handler := config.AssertionHandler.(*DefaultAssertionHandler)
+		require.NotNil(t, handler.Logger)
+
+		handler.Failure(&AssertionContext{}, &AssertionFailure{
+			Type:     AssertValid,
+			Severity: SeverityWarning,
+		})
+
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("debug", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		config := Config{
+			Reporter:  newMockReporter(t),
+			Logger:    logger,
+			Verbosity: VerbosityDebug,
+		}.withDefaults()
+
+		handler := config.AssertionHandler.(*DefaultAssertionHandler)
+		assert.Same(t, logger, handler.Logger)
+
+		handler.Success(&AssertionContext{})
+		assert.True(t, logger.logged)
+	})
+
+	t.Run("custom handler", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			AssertionHandler: handler,
+			Verbosity:        VerbosityDebug,
+		}.withDefaults()
+
+		assert.Same(t, handler, config.AssertionHandler)
+	})
+}
+
+func TestVerbosity_Request(t *testing.T) {
+	t.Run("override", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		config := Config{
+			BaseURL:   "http://example.com",
+			Client:    &mockClient{},
+			Reporter:  newMockReporter(t),
+			Logger:    logger,
+			Verbosity: VerbositySilent,
+		}
+
+		req := NewRequestC(config, "GET", "/path")
+		req.WithVerbosity(VerbosityCompact)
+		req.Expect().chain.assert(t, success)
+
+		assert.Equal(t, "GET http://example.com/path", logger.lastMessage)
+	})
+
+	t.Run("failures only", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+			Logger:   logger,
+		}
+
+		req := NewRequestC(config, "GET", "http://example.com")
+		req.WithVerbosity(VerbosityFailuresOnly)
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+		assert.False(t, logger.logged)
+
+		resp.Status(http.StatusTeapot)
+		resp.chain.assert(t, failure)
+		assert.True(t, logger.logged)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, verbosity := range []Verbosity{VerbosityDefault, Verbosity(-1)} {
+			req := NewRequestC(Config{
+				Client:   &mockClient{},
+				Reporter: newMockReporter(t),
+			}, "GET", "http://example.com")
+
+			req.WithVerbosity(verbosity)
+			req.chain.assert(t, failure)
+		}
+	})
+}
+
+func TestVerbosity_String(t *testing.T) {
+	assert.Equal(t, "VerbosityDebug", VerbosityDebug.String())
+	assert.Equal(t, "Verbosity(100)", Verbosity(100).String())
+}
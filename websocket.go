@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -24,6 +25,18 @@ type WebsocketConn interface {
 	Subprotocol() string
 }
 
+// WebsocketControlConn is optionally implemented by WebsocketConn to provide
+// access to WebSocket control frames (pings and pongs).
+//
+// *websocket.Conn implements this interface. Websocket methods that work with
+// control frames report failure if connection doesn't implement it.
+type WebsocketControlConn interface {
+	WebsocketConn
+	SetPingHandler(h func(appData string) error)
+	SetPongHandler(h func(appData string) error)
+	WriteControl(messageType int, data []byte, deadline time.Time) error
+}
+
 // Websocket provides methods to read from, write into and close WebSocket
 // connection.
 type Websocket struct {
@@ -36,6 +49,9 @@ type Websocket struct {
 	readTimeout  time.Duration
 	writeTimeout time.Duration
 
+	// background reader, started on first use of control frames
+	reader *websocketReader
+
 	isClosed bool
 }
 
@@ -196,6 +212,398 @@ func (ws *Websocket) Expect() *WebsocketMessage {
 	return m
 }
 
+// ExpectPing reads messages from WebSocket connection until a ping message
+// is received, and returns a new WebsocketMessage instance for it.
+//
+// Received ping is automatically answered with pong message. Text, binary,
+// and close messages received while waiting are not lost; they are returned
+// by subsequent Expect calls.
+//
+// After the first call, connection is read by a background goroutine,
+// so that control frames are handled even if no other messages arrive.
+//
+// Read timeout set by WithReadTimeout is used as deadline for waiting.
+// If ping is not received in time, failure is reported.
+//
+// Underlying connection should implement WebsocketControlConn interface,
+// otherwise failure is reported.
+//
+// Example:
+//
+//	ws := resp.Websocket().WithReadTimeout(time.Second)
+//	ws.ExpectPing().Body().IsEqual("keepalive")
+func (ws *Websocket) ExpectPing() *WebsocketMessage {
+	opChain := ws.chain.enter("ExpectPing()")
+	defer opChain.leave()
+
+	if ws.checkUnusable(opChain, "ExpectPing()") {
+		return newEmptyWebsocketMessage(opChain)
+	}
+
+	return ws.expectControl(opChain, "ExpectPing()", websocket.PingMessage)
+}
+
+// ExpectPong reads messages from WebSocket connection until a pong message
+// is received, and returns a new WebsocketMessage instance for it.
+//
+// Text, binary, and close messages received while waiting are not lost;
+// they are returned by subsequent Expect calls.
+//
+// Read timeout set by WithReadTimeout is used as deadline for waiting.
+// If pong is not received in time, failure is reported.
+//
+// Underlying connection should implement WebsocketControlConn interface,
+// otherwise failure is reported.
+//
+// Example:
+//
+//	ws := resp.Websocket().WithReadTimeout(time.Second)
+//	ws.WritePing([]byte("hello"))
+//	ws.ExpectPong().Body().IsEqual("hello")
+func (ws *Websocket) ExpectPong() *WebsocketMessage {
+	opChain := ws.chain.enter("ExpectPong()")
+	defer opChain.leave()
+
+	if ws.checkUnusable(opChain, "ExpectPong()") {
+		return newEmptyWebsocketMessage(opChain)
+	}
+
+	return ws.expectControl(opChain, "ExpectPong()", websocket.PongMessage)
+}
+
+// ExpectPings waits until given number of ping messages is received from
+// server and checks that pings arrive with expected cadence.
+//
+// Interval between every two subsequent pings should belong to range
+// [every-tolerance; every+tolerance]. First ping should be received not
+// later than every+tolerance after the call. If not enough pings were
+// received in time, failure is reported.
+//
+// Every received ping is automatically answered with pong message, like
+// the default ping handler of gorilla/websocket does.
+//
+// Text, binary, and close messages received while waiting for pings are
+// not lost; they are returned by subsequent Expect calls.
+//
+// Underlying connection should implement WebsocketControlConn interface,
+// otherwise failure is reported.
+//
+// Example:
+//
+//	ws := resp.Websocket()
+//	ws.ExpectPings(time.Second, 100*time.Millisecond, 3)
+func (ws *Websocket) ExpectPings(
+	every, tolerance time.Duration, count int,
+) *Websocket {
+	opChain := ws.chain.enter("ExpectPings()")
+	defer opChain.leave()
+
+	if ws.checkUnusable(opChain, "ExpectPings()") {
+		return ws
+	}
+
+	switch {
+	case every <= 0:
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{every},
+			Errors: []error{
+				errors.New("invalid non-positive interval argument"),
+			},
+		})
+		return ws
+
+	case tolerance < 0:
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{tolerance},
+			Errors: []error{
+				errors.New("invalid negative tolerance argument"),
+			},
+		})
+		return ws
+
+	case count <= 0:
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{count},
+			Errors: []error{
+				errors.New("invalid non-positive count argument"),
+			},
+		})
+		return ws
+	}
+
+	reader := ws.startReader(opChain, "ExpectPings()")
+	if reader == nil {
+		return ws
+	}
+
+	start := time.Now()
+	timeout := every*time.Duration(count) + tolerance
+
+	var pings []time.Time
+
+	for len(pings) < count {
+		frame := reader.next(start.Add(timeout), websocket.PingMessage)
+
+		if frame.err == errWebsocketTimeout {
+			break
+		}
+
+		if frame.err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("failed to read from websocket"),
+					frame.err,
+				},
+			})
+			return ws
+		}
+
+		ws.printRead(frame.typ, frame.content, 0)
+
+		pings = append(pings, frame.at)
+	}
+
+	if len(pings) < count {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{len(pings)},
+			Expected: &AssertionValue{count},
+			Errors: []error{
+				fmt.Errorf("expected: %d pings are received within %s",
+					count, timeout),
+			},
+		})
+		return ws
+	}
+
+	prev := start
+	for i, ping := range pings {
+		minInterval, maxInterval := every-tolerance, every+tolerance
+		if i == 0 {
+			minInterval = 0
+		}
+
+		// ping may be queued before the call
+		interval := ping.Sub(prev)
+		if interval < 0 {
+			interval = 0
+		}
+
+		if interval < minInterval || interval > maxInterval {
+			opChain.fail(AssertionFailure{
+				Type:   AssertInRange,
+				Actual: &AssertionValue{interval},
+				Expected: &AssertionValue{AssertionRange{
+					Min: minInterval,
+					Max: maxInterval,
+				}},
+				Errors: []error{
+					fmt.Errorf("expected: interval before ping #%d is in range", i+1),
+				},
+			})
+			return ws
+		}
+
+		prev = ping
+	}
+
+	return ws
+}
+
+// ExpectMessagesOpts defines parameters for Websocket.ExpectMessages.
+type ExpectMessagesOpts struct {
+	// Maximum number of messages to read.
+	// If zero, number of messages is not limited.
+	Count int
+
+	// Maximum time to wait for messages.
+	// If zero, read timeout set by WithReadTimeout is used.
+	Timeout time.Duration
+}
+
+// ExpectMessages reads messages from WebSocket connection until predicate
+// matches a message, or given number of messages is read, or timeout elapses.
+// Returns a new Array instance with contents of all read messages, as strings.
+//
+// Unlike Expect, it allows to skip unrelated messages, e.g. heartbeats
+// interleaved with the message of interest.
+//
+// If predicate is non-nil, reading stops after the first matching message,
+// which becomes the last element of returned array; all other elements are
+// skipped messages. If no message matches before count or timeout is reached,
+// failure is reported. Assertions made inside predicate don't report failures
+// and are treated as mismatch.
+//
+// If predicate is nil, all messages are collected until count or timeout is
+// reached. If count is set and timeout elapses before count messages are read,
+// failure is reported.
+//
+// Timeout limits the whole operation, not a single read. Reading also stops
+// when close message is received.
+//
+// If connection implements WebsocketControlConn, it is read by background
+// goroutine, like in ExpectPing, so timeouts don't break the connection.
+//
+// Example:
+//
+//	ws := resp.Websocket()
+//	ws.WriteText("subscribe")
+//
+//	msgs := ws.ExpectMessages(func(msg *WebsocketMessage) bool {
+//		_, content, _ := msg.Raw()
+//		return string(content) != "heartbeat"
+//	}, ExpectMessagesOpts{Count: 10, Timeout: time.Second})
+//
+//	msgs.Last().String().IsEqual("subscribed")
+func (ws *Websocket) ExpectMessages(
+	predicate func(*WebsocketMessage) bool, options ...ExpectMessagesOpts,
+) *Array {
+	opChain := ws.chain.enter("ExpectMessages()")
+	defer opChain.leave()
+
+	if ws.checkUnusable(opChain, "ExpectMessages()") {
+		return newArray(opChain, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	var opts ExpectMessagesOpts
+	if len(options) != 0 {
+		opts = options[0]
+	}
+
+	switch {
+	case opts.Count < 0:
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{opts.Count},
+			Errors: []error{
+				errors.New("invalid negative count option"),
+			},
+		})
+		return newArray(opChain, nil)
+
+	case opts.Timeout < 0:
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{opts.Timeout},
+			Errors: []error{
+				errors.New("invalid negative timeout option"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	deadline := ws.readDeadline()
+	if opts.Timeout != 0 {
+		deadline = time.Now().Add(opts.Timeout)
+	}
+
+	if predicate == nil && opts.Count == 0 && deadline.IsZero() {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New(
+					"unexpected nil predicate without count and timeout"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	if _, ok := ws.conn.(WebsocketControlConn); ok {
+		if ws.startReader(opChain, "ExpectMessages()") == nil {
+			return newArray(opChain, nil)
+		}
+	}
+
+	var (
+		messages = []interface{}{}
+		matched  bool
+	)
+
+	for opts.Count == 0 || len(messages) < opts.Count {
+		frame, ok := ws.readFrame(opChain, deadline)
+		if !ok {
+			return newArray(opChain, nil)
+		}
+
+		if frame.err != nil {
+			if isWebsocketTimeout(frame.err) {
+				break
+			}
+
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("failed to read from websocket"),
+					frame.err,
+				},
+			})
+			return newArray(opChain, nil)
+		}
+
+		messages = append(messages, string(frame.content))
+
+		if predicate != nil {
+			func() {
+				msgChain := opChain.replace("ExpectMessages[%d]", len(messages)-1)
+				defer msgChain.leave()
+
+				msgChain.setRoot()
+				msgChain.setSeverity(SeverityLog)
+
+				msg := newWebsocketMessage(msgChain,
+					frame.typ, frame.content, frame.closeCode)
+
+				matched = predicate(msg) && !msgChain.treeFailed()
+			}()
+
+			if matched {
+				break
+			}
+		}
+
+		if frame.typ == websocket.CloseMessage {
+			break
+		}
+	}
+
+	switch {
+	case predicate != nil && !matched:
+		opChain.fail(AssertionFailure{
+			Type:   AssertContainsElement,
+			Actual: &AssertionValue{messages},
+			Errors: []error{
+				errors.New("expected: message matching predicate is received"),
+			},
+		})
+
+	case predicate == nil && opts.Count != 0 && len(messages) < opts.Count:
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{len(messages)},
+			Expected: &AssertionValue{opts.Count},
+			Errors: []error{
+				fmt.Errorf("expected: %d messages are received", opts.Count),
+			},
+		})
+	}
+
+	return newArray(opChain, messages)
+}
+
 // Disconnect closes the underlying WebSocket connection without sending or
 // waiting for a close message.
 //
@@ -218,6 +626,8 @@ func (ws *Websocket) Disconnect() *Websocket {
 
 	ws.isClosed = true
 
+	ws.config.lifecycle.websocketClosed(ws.conn)
+
 	if err := ws.conn.Close(); err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertOperation,
@@ -434,6 +844,60 @@ func (ws *Websocket) WriteMessage(typ int, content []byte, closeCode ...int) *We
 	return ws
 }
 
+// WritePing writes ping control message with given payload to the
+// underlying WebSocket connection.
+//
+// Write timeout set by WithWriteTimeout is used as deadline for writing.
+//
+// Underlying connection should implement WebsocketControlConn interface,
+// otherwise failure is reported.
+//
+// Example:
+//
+//	ws := resp.Websocket()
+//	ws.WritePing([]byte("hello"))
+//	ws.ExpectPong().Body().IsEqual("hello")
+func (ws *Websocket) WritePing(data []byte) *Websocket {
+	opChain := ws.chain.enter("WritePing()")
+	defer opChain.leave()
+
+	if ws.checkUnusable(opChain, "WritePing()") {
+		return ws
+	}
+
+	ws.writeControlMessage(opChain, "WritePing()", websocket.PingMessage, data)
+
+	return ws
+}
+
+// WritePong writes unsolicited pong control message with given payload
+// to the underlying WebSocket connection.
+//
+// Pongs in reply to received pings are sent automatically and there is no
+// need to call WritePong for them.
+//
+// Write timeout set by WithWriteTimeout is used as deadline for writing.
+//
+// Underlying connection should implement WebsocketControlConn interface,
+// otherwise failure is reported.
+//
+// Example:
+//
+//	ws := resp.Websocket()
+//	ws.WritePong([]byte("heartbeat"))
+func (ws *Websocket) WritePong(data []byte) *Websocket {
+	opChain := ws.chain.enter("WritePong()")
+	defer opChain.leave()
+
+	if ws.checkUnusable(opChain, "WritePong()") {
+		return ws
+	}
+
+	ws.writeControlMessage(opChain, "WritePong()", websocket.PongMessage, data)
+
+	return ws
+}
+
 // WriteBytesBinary is a shorthand for c.WriteMessage(websocket.BinaryMessage, b).
 func (ws *Websocket) WriteBytesBinary(b []byte) *Websocket {
 	opChain := ws.chain.enter("WriteBytesBinary()")
@@ -532,36 +996,190 @@ func (ws *Websocket) checkUnusable(opChain *chain, where string) bool {
 }
 
 func (ws *Websocket) readMessage(opChain *chain) *WebsocketMessage {
-	wm := newEmptyWebsocketMessage(opChain)
+	frame, ok := ws.readFrame(opChain, ws.readDeadline())
+	if !ok {
+		return nil
+	}
 
-	if !ws.setReadDeadline(opChain) {
+	if frame.err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to read from websocket"),
+				frame.err,
+			},
+		})
 		return nil
 	}
 
-	var err error
-	wm.typ, wm.content, err = ws.conn.ReadMessage()
+	wm := newEmptyWebsocketMessage(opChain)
 
-	if err != nil {
-		closeErr, ok := err.(*websocket.CloseError)
-		if !ok {
-			opChain.fail(AssertionFailure{
-				Type: AssertOperation,
-				Errors: []error{
-					errors.New("failed to read from websocket"),
-					err,
-				},
-			})
-			return nil
+	wm.typ, wm.content, wm.closeCode = frame.typ, frame.content, frame.closeCode
+
+	return wm
+}
+
+// Read next text, binary, or close message, waiting until deadline.
+// Read error is returned in frame; returns false if failure was reported.
+func (ws *Websocket) readFrame(
+	opChain *chain, deadline time.Time,
+) (websocketFrame, bool) {
+	var frame websocketFrame
+
+	if ws.reader != nil {
+		frame = ws.reader.next(deadline,
+			websocket.TextMessage, websocket.BinaryMessage, websocket.CloseMessage)
+	} else {
+		if !ws.setReadDeadline(opChain, deadline) {
+			return frame, false
 		}
 
-		wm.typ = websocket.CloseMessage
-		wm.closeCode = closeErr.Code
-		wm.content = []byte(closeErr.Text)
+		var err error
+		frame.typ, frame.content, err = ws.conn.ReadMessage()
+
+		if err != nil {
+			if closeErr, ok := err.(*websocket.CloseError); ok {
+				frame.typ = websocket.CloseMessage
+				frame.closeCode = closeErr.Code
+				frame.content = []byte(closeErr.Text)
+			} else {
+				frame.err = err
+			}
+		}
 	}
 
-	ws.printRead(wm.typ, wm.content, wm.closeCode)
+	if frame.err == nil {
+		ws.printRead(frame.typ, frame.content, frame.closeCode)
+	}
 
-	return wm
+	return frame, true
+}
+
+func (ws *Websocket) expectControl(
+	opChain *chain, where string, typ int,
+) *WebsocketMessage {
+	reader := ws.startReader(opChain, where)
+	if reader == nil {
+		return newEmptyWebsocketMessage(opChain)
+	}
+
+	frame := reader.next(ws.readDeadline(), typ)
+
+	if frame.err == errWebsocketTimeout {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("expected: %s message is received", wsMessageType(typ)),
+				frame.err,
+			},
+		})
+		return newEmptyWebsocketMessage(opChain)
+	}
+
+	if frame.err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to read from websocket"),
+				frame.err,
+			},
+		})
+		return newEmptyWebsocketMessage(opChain)
+	}
+
+	ws.printRead(frame.typ, frame.content, 0)
+
+	return newWebsocketMessage(opChain, frame.typ, frame.content)
+}
+
+func (ws *Websocket) writeControlMessage(
+	opChain *chain, where string, typ int, content []byte,
+) {
+	conn := ws.controlConn(opChain, where)
+	if conn == nil {
+		return
+	}
+
+	if err := ws.writeControl(conn, typ, content); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to write to websocket"),
+				err,
+			},
+		})
+	}
+}
+
+func isWebsocketTimeout(err error) bool {
+	if err == errWebsocketTimeout {
+		return true
+	}
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
+
+// Start background reader, if not started yet.
+// After reader is started, all reads go through it.
+func (ws *Websocket) startReader(opChain *chain, where string) *websocketReader {
+	if ws.reader != nil {
+		return ws.reader
+	}
+
+	conn := ws.controlConn(opChain, where)
+	if conn == nil {
+		return nil
+	}
+
+	// reader waits for frames with its own deadlines
+	if err := conn.SetReadDeadline(infiniteTime); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to set read deadline for websocket"),
+				err,
+			},
+		})
+		return nil
+	}
+
+	ws.reader = newWebsocketReader(conn)
+
+	return ws.reader
+}
+
+func (ws *Websocket) controlConn(opChain *chain, where string) WebsocketControlConn {
+	conn, ok := ws.conn.(WebsocketControlConn)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected %s call for websocket connection"+
+					" that doesn't implement WebsocketControlConn", where),
+			},
+		})
+		return nil
+	}
+
+	return conn
+}
+
+func (ws *Websocket) writeControl(
+	conn WebsocketControlConn, typ int, content []byte,
+) error {
+	ws.printWrite(typ, content, 0)
+
+	deadline := infiniteTime
+	if ws.writeTimeout != noDuration {
+		deadline = time.Now().Add(ws.writeTimeout)
+	}
+
+	err := conn.WriteControl(typ, content, deadline)
+	if err == websocket.ErrCloseSent {
+		return nil
+	}
+
+	return err
 }
 
 func (ws *Websocket) writeMessage(
@@ -618,12 +1236,14 @@ func (ws *Websocket) writeMessage(
 	}
 }
 
-func (ws *Websocket) setReadDeadline(opChain *chain) bool {
-	deadline := infiniteTime
+func (ws *Websocket) readDeadline() time.Time {
 	if ws.readTimeout != noDuration {
-		deadline = time.Now().Add(ws.readTimeout)
+		return time.Now().Add(ws.readTimeout)
 	}
+	return infiniteTime
+}
 
+func (ws *Websocket) setReadDeadline(opChain *chain, deadline time.Time) bool {
 	if err := ws.conn.SetReadDeadline(deadline); err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertOperation,
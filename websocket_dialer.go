@@ -35,6 +35,28 @@ func NewFastWebsocketDialer(handler fasthttp.RequestHandler) *websocket.Dialer {
 	}
 }
 
+// Returns websocket dialer bound to the same handler as client, if client
+// is http.Client with Binder or FastBinder transport. Otherwise returns nil.
+func newBinderWebsocketDialer(client Client) WebsocketDialer {
+	httpClient, ok := client.(*http.Client)
+	if !ok {
+		return nil
+	}
+
+	switch transport := httpClient.Transport.(type) {
+	case Binder:
+		return NewWebsocketDialer(transport.Handler)
+	case *Binder:
+		return NewWebsocketDialer(transport.Handler)
+	case FastBinder:
+		return NewFastWebsocketDialer(transport.Handler)
+	case *FastBinder:
+		return NewFastWebsocketDialer(transport.Handler)
+	}
+
+	return nil
+}
+
 type handlerConn struct {
 	net.Conn          // returned from dialer
 	backConn net.Conn // passed to the background goroutine
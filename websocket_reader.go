@@ -0,0 +1,179 @@
+package httpexpect
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Message or control frame read from WebSocket connection.
+type websocketFrame struct {
+	typ       int
+	content   []byte
+	closeCode int
+
+	// read error, if any; terminates frame sequence
+	err error
+
+	// when frame was received
+	at time.Time
+}
+
+var errWebsocketTimeout = errors.New("timeout while waiting for websocket message")
+
+// Background reader for WebSocket connection.
+//
+// gorilla/websocket processes control frames (pings and pongs) only
+// during reads, and ReadMessage doesn't return until next data message.
+// Also, after a read deadline is exceeded, connection becomes unusable.
+//
+// To allow waiting for control frames with timeouts, websocketReader
+// reads connection in a background goroutine, and puts every data
+// message and control frame into a queue. Websocket then picks frames
+// from the queue.
+//
+// Frames are not printed by reader goroutine; Websocket prints them
+// when they are picked from the queue.
+//
+// Received pings are automatically answered with pongs.
+type websocketReader struct {
+	mu     sync.Mutex
+	frames []websocketFrame
+
+	notify chan struct{} // signaled when new frame is queued
+	done   chan struct{} // closed when reader goroutine exits
+}
+
+func newWebsocketReader(conn WebsocketControlConn) *websocketReader {
+	r := &websocketReader{
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	conn.SetPingHandler(func(appData string) error {
+		r.push(websocketFrame{
+			typ:     websocket.PingMessage,
+			content: []byte(appData),
+		})
+
+		// same as default ping handler of gorilla/websocket
+		err := conn.WriteControl(websocket.PongMessage, []byte(appData),
+			time.Now().Add(time.Second))
+		if err == websocket.ErrCloseSent {
+			return nil
+		}
+		return err
+	})
+
+	conn.SetPongHandler(func(appData string) error {
+		r.push(websocketFrame{
+			typ:     websocket.PongMessage,
+			content: []byte(appData),
+		})
+
+		return nil
+	})
+
+	go r.run(conn)
+
+	return r
+}
+
+func (r *websocketReader) run(conn WebsocketControlConn) {
+	defer close(r.done)
+
+	for {
+		typ, content, err := conn.ReadMessage()
+
+		if err != nil {
+			if closeErr, ok := err.(*websocket.CloseError); ok {
+				r.push(websocketFrame{
+					typ:       websocket.CloseMessage,
+					content:   []byte(closeErr.Text),
+					closeCode: closeErr.Code,
+				})
+			} else {
+				r.push(websocketFrame{
+					err: err,
+				})
+			}
+			return
+		}
+
+		r.push(websocketFrame{
+			typ:     typ,
+			content: content,
+		})
+	}
+}
+
+func (r *websocketReader) push(frame websocketFrame) {
+	frame.at = time.Now()
+
+	r.mu.Lock()
+	r.frames = append(r.frames, frame)
+	r.mu.Unlock()
+
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Remove and return first queued frame of one of the given types.
+// Frames of other types are left in queue.
+// Waits until such frame is received, or deadline expires, or reader exits.
+// Zero deadline means no deadline.
+func (r *websocketReader) next(deadline time.Time, types ...int) websocketFrame {
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for {
+		if frame, ok := r.pick(types); ok {
+			return frame
+		}
+
+		select {
+		case <-r.notify:
+		case <-r.done:
+			// reader exited, check frames queued before exit
+			if frame, ok := r.pick(types); ok {
+				return frame
+			}
+			return websocketFrame{
+				err: errors.New("websocket connection is closed"),
+			}
+		case <-timeout:
+			return websocketFrame{
+				err: errWebsocketTimeout,
+			}
+		}
+	}
+}
+
+func (r *websocketReader) pick(types []int) (websocketFrame, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for n, frame := range r.frames {
+		if frame.err != nil {
+			// error frame is kept in queue to be reported on every call
+			return frame, true
+		}
+
+		for _, typ := range types {
+			if frame.typ == typ {
+				r.frames = append(r.frames[:n], r.frames[n+1:]...)
+				return frame, true
+			}
+		}
+	}
+
+	return websocketFrame{}, false
+}
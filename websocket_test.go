@@ -40,6 +40,13 @@ func TestWebsocket_FailedChain(t *testing.T) {
 	ws.CloseWithJSON(map[string]string{"a": "b"})
 	ws.CloseWithText("a")
 
+	ws.ExpectPings(time.Second, 0, 1)
+	ws.ExpectMessages(nil).chain.assert(t, failure)
+	ws.ExpectPing().chain.assert(t, failure)
+	ws.ExpectPong().chain.assert(t, failure)
+	ws.WritePing([]byte("a"))
+	ws.WritePong([]byte("a"))
+
 	ws.Disconnect()
 	ws.Close()
 }
@@ -947,7 +954,7 @@ func TestWebsocket_SetReadDeadline(t *testing.T) {
 				WithReadTimeout(time.Second)
 
 			opChain := ws.chain.enter("test")
-			ws.setReadDeadline(opChain)
+			ws.setReadDeadline(opChain, ws.readDeadline())
 			opChain.leave()
 
 			ws.chain.assert(t, tc.result)
@@ -1084,3 +1091,200 @@ func TestWebsocket_Printer(t *testing.T) {
 		}
 	})
 }
+
+func TestWebsocket_ExpectPings(t *testing.T) {
+	t.Run("invalid arguments", func(t *testing.T) {
+		cases := []struct {
+			name      string
+			every     time.Duration
+			tolerance time.Duration
+			count     int
+		}{
+			{"zero interval", 0, 0, 1},
+			{"negative tolerance", time.Second, -1, 1},
+			{"zero count", time.Second, 0, 0},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+				config := newMockConfig(reporter)
+
+				ws := NewWebsocketC(config, &mockWebsocketConn{})
+
+				ws.ExpectPings(tc.every, tc.tolerance, tc.count)
+				ws.chain.assert(t, failure)
+			})
+		}
+	})
+
+	t.Run("no control frames support", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		ws := NewWebsocketC(config, &mockWebsocketConn{})
+
+		ws.ExpectPings(time.Second, 0, 1)
+		ws.chain.assert(t, failure)
+	})
+}
+
+func TestWebsocket_ControlFrames(t *testing.T) {
+	t.Run("no control frames support", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		ws := NewWebsocketC(config, &mockWebsocketConn{})
+
+		ws.ExpectPing().chain.assert(t, failure)
+		ws.ExpectPong().chain.assert(t, failure)
+
+		ws.WritePing([]byte("a"))
+		ws.chain.assert(t, failure)
+
+		ws.chain.clear()
+
+		ws.WritePong([]byte("a"))
+		ws.chain.assert(t, failure)
+	})
+}
+
+func TestWebsocket_ExpectMessages(t *testing.T) {
+	t.Run("predicate matches", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		ws := NewWebsocketC(config, &mockWebsocketConn{
+			msgType: websocket.TextMessage,
+		})
+
+		calls := 0
+
+		msgs := ws.ExpectMessages(func(msg *WebsocketMessage) bool {
+			calls++
+			return calls == 3
+		})
+
+		ws.chain.assert(t, success)
+		msgs.chain.assert(t, success)
+
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, 3, len(msgs.Raw()))
+	})
+
+	t.Run("predicate assertion fails", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		ws := NewWebsocketC(config, &mockWebsocketConn{
+			msgType: websocket.TextMessage,
+		})
+
+		calls := 0
+
+		msgs := ws.ExpectMessages(func(msg *WebsocketMessage) bool {
+			calls++
+			if calls == 1 {
+				msg.BinaryMessage()
+			}
+			return true
+		})
+
+		ws.chain.assert(t, success)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, 2, len(msgs.Raw()))
+	})
+
+	t.Run("predicate doesn't match", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		ws := NewWebsocketC(config, &mockWebsocketConn{
+			msgType: websocket.TextMessage,
+		})
+
+		ws.ExpectMessages(func(msg *WebsocketMessage) bool {
+			return false
+		}, ExpectMessagesOpts{Count: 5})
+
+		ws.chain.assert(t, failure)
+	})
+
+	t.Run("close message", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		ws := NewWebsocketC(config, &mockWebsocketConn{
+			msgType: websocket.CloseMessage,
+		})
+
+		msgs := ws.ExpectMessages(nil, ExpectMessagesOpts{Count: 5})
+
+		ws.chain.assert(t, failure)
+		assert.Equal(t, 1, len(msgs.Raw()))
+	})
+
+	t.Run("count without predicate", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		ws := NewWebsocketC(config, &mockWebsocketConn{
+			msgType: websocket.TextMessage,
+		})
+
+		msgs := ws.ExpectMessages(nil, ExpectMessagesOpts{Count: 3})
+
+		ws.chain.assert(t, success)
+		msgs.chain.assert(t, success)
+
+		assert.Equal(t, 3, len(msgs.Raw()))
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		ws := NewWebsocketC(config, &mockWebsocketConn{
+			readMsgErr: errors.New("test error"),
+		})
+
+		ws.ExpectMessages(nil, ExpectMessagesOpts{Count: 3})
+		ws.chain.assert(t, failure)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		cases := []struct {
+			name string
+			opts []ExpectMessagesOpts
+		}{
+			{
+				name: "no predicate, count, and timeout",
+				opts: nil,
+			},
+			{
+				name: "negative count",
+				opts: []ExpectMessagesOpts{{Count: -1}},
+			},
+			{
+				name: "negative timeout",
+				opts: []ExpectMessagesOpts{{Timeout: -1}},
+			},
+			{
+				name: "multiple options",
+				opts: []ExpectMessagesOpts{{Count: 1}, {Count: 1}},
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+				config := newMockConfig(reporter)
+
+				ws := NewWebsocketC(config, &mockWebsocketConn{})
+
+				ws.ExpectMessages(nil, tc.opts...)
+				ws.chain.assert(t, failure)
+			})
+		}
+	})
+}